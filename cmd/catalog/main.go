@@ -0,0 +1,39 @@
+// Command catalog writes the English message catalog of the poker server as JSON: every
+// message key clients receive in errors, narration and status labels, with its wording.
+// It is the reference for translations and is run by go generate ./internal/server.
+//
+// Usage:
+//
+//	catalog [-o <file>]
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/robinr2/poker/internal/server"
+)
+
+func main() {
+	output := flag.String("o", "", "file to write (default stdout)")
+	flag.Parse()
+
+	// encoding/json sorts map keys, so the catalog diffs cleanly
+	data, err := json.MarshalIndent(server.MessageCatalog(), "", "  ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	data = append(data, '\n')
+
+	if *output == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(*output, data, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
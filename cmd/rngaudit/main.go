@@ -0,0 +1,35 @@
+// Command rngaudit verifies an RNG audit log written by the poker server (RNG_AUDIT_FILE).
+// It checks the hash chain and replays every recorded shuffle from its seed.
+//
+// Usage:
+//
+//	rngaudit <audit-log-file>
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/robinr2/poker/internal/server"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: rngaudit <audit-log-file>")
+		os.Exit(2)
+	}
+
+	file, err := os.Open(os.Args[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	verified, err := server.VerifyRNGAuditLog(file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "FAILED after %d valid hands: %v\n", verified, err)
+		os.Exit(1)
+	}
+	fmt.Printf("OK: %d hands verified\n", verified)
+}
@@ -2,9 +2,13 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -12,16 +16,16 @@ import (
 )
 
 func main() {
-	// Read configuration from environment variables
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
-	}
-
-	logLevel := os.Getenv("LOG_LEVEL")
-	if logLevel == "" {
-		logLevel = "info"
+	// Configuration comes from the defaults, then CONFIG_FILE (if set), then environment variables
+	configPath := os.Getenv("CONFIG_FILE")
+	fileConfig, err := loadConfig(configPath)
+	if err != nil {
+		slog.Error("invalid configuration", "error", err)
+		os.Exit(1)
 	}
+	port := fileConfig.Port
+	logLevel := fileConfig.LogLevel
+	config := fileConfig.Config
 
 	// Parse log level
 	var level slog.Level
@@ -38,18 +42,28 @@ func main() {
 		level = slog.LevelInfo
 	}
 
-	// Set up structured logging with JSON format
+	// Set up structured logging with JSON format; cards never reach the logs outside forensic mode
 	handler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: level,
 	})
-	logger := slog.New(handler)
+	logger := slog.New(server.NewRedactingHandler(handler, fileConfig.LogForensicCards))
 	slog.SetDefault(logger)
+	if fileConfig.LogForensicCards {
+		logger.Warn("forensic logging is on: hole cards may be written to the logs")
+	}
 
 	// Log the configuration on startup
-	logger.Info("starting poker application", "port", port, "log_level", logLevel)
+	logger.Info("starting poker application", "config_file", configPath, "port", port, "log_level", logLevel, "next_hand_delay", config.NextHandDelay, "action_timeout", config.ActionTimeout, "diagnostics_addr", config.DiagnosticsAddr)
+
+	// Export hand lifecycle traces when an OTLP endpoint is configured
+	shutdownTracing, err := server.SetupTracing(context.Background(), "poker-server")
+	if err != nil {
+		logger.Error("failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
 
 	// Create and start the server
-	srv := server.NewServer(logger)
+	srv := server.NewServerWithConfig(logger, config)
 
 	// Start server in a goroutine
 	// Bind to 0.0.0.0 to be accessible from Docker containers and external hosts
@@ -60,12 +74,27 @@ func main() {
 		}
 	}()
 
-	// Set up graceful shutdown
+	// The diagnostics listener is opt-in and should stay on a private address
+	if config.DiagnosticsAddr != "" {
+		go func() {
+			if err := srv.StartDiagnostics(config.DiagnosticsAddr); err != nil && err != http.ErrServerClosed {
+				logger.Error("diagnostics server error", "error", err)
+			}
+		}()
+	}
+
+	// Set up graceful shutdown and live reload
 	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
 
-	// Wait for shutdown signal
-	sig := <-sigChan
+	// Wait for shutdown signal, reloading the configuration on SIGHUP
+	var sig os.Signal
+	for sig = range sigChan {
+		if sig != syscall.SIGHUP {
+			break
+		}
+		reloadConfig(logger, srv, configPath, port, logLevel, fileConfig.LogForensicCards)
+	}
 	logger.Info("shutdown signal received", "signal", sig.String())
 
 	// Gracefully shutdown the server
@@ -75,7 +104,161 @@ func main() {
 	if err := srv.Shutdown(ctx); err != nil {
 		logger.Error("error during server shutdown", "error", err)
 	}
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Error("error flushing traces", "error", err)
+	}
 
 	logger.Info("server shutdown complete")
 	os.Exit(0)
 }
+
+// loadConfig builds the configuration from the defaults, the config file at path
+// (skipped when path is empty) and the environment variables documented in the README,
+// which take precedence over the file
+func loadConfig(path string) (server.FileConfig, error) {
+	fileConfig := server.FileConfig{
+		Port:     "8080",
+		LogLevel: "info",
+		Config:   server.DefaultConfig(),
+	}
+	if path != "" {
+		loaded, err := server.LoadConfigFile(path)
+		if err != nil {
+			return server.FileConfig{}, err
+		}
+		fileConfig = loaded
+	}
+
+	if port := os.Getenv("PORT"); port != "" {
+		fileConfig.Port = port
+	}
+	if logLevel := os.Getenv("LOG_LEVEL"); logLevel != "" {
+		fileConfig.LogLevel = logLevel
+	}
+	if forensic := os.Getenv("LOG_FORENSIC_CARDS"); forensic != "" {
+		enabled, err := strconv.ParseBool(forensic)
+		if err != nil {
+			return server.FileConfig{}, fmt.Errorf("invalid LOG_FORENSIC_CARDS %q: %w", forensic, err)
+		}
+		fileConfig.LogForensicCards = enabled
+	}
+	if nextHandDelay := os.Getenv("NEXT_HAND_DELAY"); nextHandDelay != "" {
+		delay, err := time.ParseDuration(nextHandDelay)
+		if err != nil {
+			return server.FileConfig{}, fmt.Errorf("invalid NEXT_HAND_DELAY %q: %w", nextHandDelay, err)
+		}
+		fileConfig.NextHandDelay = delay
+	}
+	if actionTimeout := os.Getenv("ACTION_TIMEOUT"); actionTimeout != "" {
+		timeout, err := time.ParseDuration(actionTimeout)
+		if err != nil {
+			return server.FileConfig{}, fmt.Errorf("invalid ACTION_TIMEOUT %q: %w", actionTimeout, err)
+		}
+		fileConfig.ActionTimeout = timeout
+	}
+	if reconnectGrace := os.Getenv("RECONNECT_GRACE"); reconnectGrace != "" {
+		grace, err := time.ParseDuration(reconnectGrace)
+		if err != nil {
+			return server.FileConfig{}, fmt.Errorf("invalid RECONNECT_GRACE %q: %w", reconnectGrace, err)
+		}
+		fileConfig.ReconnectGrace = grace
+	}
+	if sessionTTL := os.Getenv("SESSION_TTL"); sessionTTL != "" {
+		ttl, err := time.ParseDuration(sessionTTL)
+		if err != nil {
+			return server.FileConfig{}, fmt.Errorf("invalid SESSION_TTL %q: %w", sessionTTL, err)
+		}
+		fileConfig.SessionTTL = ttl
+	}
+	if sessionPolicy := os.Getenv("SESSION_POLICY"); sessionPolicy != "" {
+		fileConfig.SessionPolicy = sessionPolicy
+	}
+	if adminToken := os.Getenv("ADMIN_TOKEN"); adminToken != "" {
+		fileConfig.AdminToken = adminToken
+	}
+	if banListFile := os.Getenv("BAN_LIST_FILE"); banListFile != "" {
+		fileConfig.BanListFile = banListFile
+	}
+	if accountStoreFile := os.Getenv("ACCOUNT_STORE_FILE"); accountStoreFile != "" {
+		fileConfig.AccountStoreFile = accountStoreFile
+	}
+	if clubStoreFile := os.Getenv("CLUB_STORE_FILE"); clubStoreFile != "" {
+		fileConfig.ClubStoreFile = clubStoreFile
+	}
+	if rngAuditFile := os.Getenv("RNG_AUDIT_FILE"); rngAuditFile != "" {
+		fileConfig.RNGAuditFile = rngAuditFile
+	}
+	if selfTestInterval := os.Getenv("RNG_SELF_TEST_INTERVAL"); selfTestInterval != "" {
+		interval, err := time.ParseDuration(selfTestInterval)
+		if err != nil {
+			return server.FileConfig{}, fmt.Errorf("invalid RNG_SELF_TEST_INTERVAL %q: %w", selfTestInterval, err)
+		}
+		fileConfig.RNGSelfTest.Interval = interval
+	}
+	if maxConnections := os.Getenv("MAX_CONNECTIONS_PER_IP"); maxConnections != "" {
+		limit, err := strconv.Atoi(maxConnections)
+		if err != nil {
+			return server.FileConfig{}, fmt.Errorf("invalid MAX_CONNECTIONS_PER_IP %q: %w", maxConnections, err)
+		}
+		fileConfig.MaxConnectionsPerIP = limit
+	}
+	if diagnosticsAddr := os.Getenv("DIAGNOSTICS_ADDR"); diagnosticsAddr != "" {
+		fileConfig.DiagnosticsAddr = diagnosticsAddr
+	}
+	if certFile := os.Getenv("TLS_CERT_FILE"); certFile != "" {
+		fileConfig.TLS.CertFile = certFile
+	}
+	if keyFile := os.Getenv("TLS_KEY_FILE"); keyFile != "" {
+		fileConfig.TLS.KeyFile = keyFile
+	}
+	if domains := os.Getenv("AUTOCERT_DOMAINS"); domains != "" {
+		fileConfig.TLS.AutocertDomains = splitList(domains)
+	}
+	if cacheDir := os.Getenv("AUTOCERT_CACHE_DIR"); cacheDir != "" {
+		fileConfig.TLS.AutocertCacheDir = cacheDir
+	}
+	if proxies := os.Getenv("TRUSTED_PROXIES"); proxies != "" {
+		fileConfig.TrustedProxies = splitList(proxies)
+	}
+	if origins := os.Getenv("ALLOWED_ORIGINS"); origins != "" {
+		fileConfig.AllowedOrigins = splitList(origins)
+	}
+
+	if err := fileConfig.Validate(); err != nil {
+		return server.FileConfig{}, err
+	}
+	return fileConfig, nil
+}
+
+// reloadConfig re-reads the configuration on SIGHUP and applies its hot-reloadable settings
+// An invalid file is logged and the running configuration is kept
+func reloadConfig(logger *slog.Logger, srv *server.Server, path, port, logLevel string, forensic bool) {
+	if path == "" {
+		logger.Warn("SIGHUP received but no CONFIG_FILE is set, nothing to reload")
+		return
+	}
+
+	fileConfig, err := loadConfig(path)
+	if err != nil {
+		logger.Error("config reload failed, keeping current configuration", "error", err)
+		return
+	}
+	if fileConfig.Port != port || fileConfig.LogLevel != logLevel || fileConfig.LogForensicCards != forensic {
+		logger.Warn("port, logLevel and logForensicCards changes require a restart")
+	}
+
+	if err := srv.ReloadConfig(fileConfig.Config); err != nil {
+		logger.Error("config reload failed, keeping current configuration", "error", err)
+	}
+}
+
+// splitList splits a comma-separated environment value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,272 @@
+// Command tui is a terminal client for playing or watching a table without the browser
+// frontend, built on pkg/client. The table is redrawn after every event; type commands and
+// press enter to act.
+//
+// Usage:
+//
+//	tui [-url ws://localhost:8080/ws] [-name Alice | -token TOKEN] [-join table-1 | -watch table-1] [-plain]
+//
+// Commands: join <table>, watch <table>, unwatch, leave, start, fold, check, call,
+// raise <total>, lobby, help, quit. f, k, c and r are short for fold, check, call and raise.
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robinr2/poker/pkg/client"
+)
+
+const help = `join <table>   take a seat          watch <table>  observe a table
+leave          give up your seat    unwatch        stop observing
+start          deal a hand          lobby          show the tables
+fold (f)  check (k)  call (c)  raise <total> (r)   quit`
+
+func main() {
+	url := flag.String("url", "ws://localhost:8080/ws", "server WebSocket URL")
+	name := flag.String("name", "", "player name for a new session")
+	token := flag.String("token", "", "session token to resume instead of creating a session")
+	join := flag.String("join", "", "table to sit at after connecting")
+	watch := flag.String("watch", "", "table to watch after connecting")
+	plain := flag.Bool("plain", false, "print each screen below the last instead of redrawing, for logs and dumb terminals")
+	flag.Parse()
+
+	if *name == "" && *token == "" {
+		*name = "tui-" + strconv.Itoa(os.Getpid())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	c, err := client.Dial(ctx, client.Config{URL: *url, Name: *name, Token: *token})
+	cancel()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer c.Close()
+
+	v := &view{}
+	redraw := func() {
+		seat, seated := c.Seat()
+		var mySeat *int
+		if seated {
+			mySeat = &seat.SeatIndex
+		}
+		v.mu.Lock()
+		screen := v.render(mySeat)
+		v.mu.Unlock()
+		if !*plain {
+			screen = "\033[H\033[2J" + screen
+		} else {
+			screen = "\n" + screen
+		}
+		fmt.Print(screen)
+	}
+	subscribe(c, v, redraw)
+
+	if *join != "" {
+		err = c.JoinTable(*join)
+	} else if *watch != "" {
+		err = c.Watch(*watch)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "session token (resume with -token): %s\n", c.Token())
+
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+
+	for {
+		select {
+		case <-c.Done():
+			fmt.Println("\ndisconnected")
+			return
+		case line, ok := <-lines:
+			if !ok {
+				return
+			}
+			if quit := command(c, v, strings.Fields(line)); quit {
+				return
+			}
+			redraw()
+		}
+	}
+}
+
+// subscribe keeps v up to date from the client's messages and redraws after each change
+func subscribe(c *client.Client, v *view, redraw func()) {
+	update := func(f func()) {
+		v.mu.Lock()
+		f()
+		v.mu.Unlock()
+		redraw()
+	}
+
+	c.OnLobby(func(tables []client.TableInfo) { update(func() { v.lobby = tables }) })
+	c.OnSeatAssigned(func(seat client.SeatAssignment) {
+		update(func() { v.logf("seated at %s, seat %d", seat.TableID, seat.SeatIndex) })
+	})
+	c.OnSeatCleared(func() {
+		update(func() {
+			v.state, v.hole, v.request = nil, nil, nil
+			v.logf("left the table")
+		})
+	})
+	c.OnTableState(func(state client.TableState) {
+		update(func() {
+			v.state = &state
+			if seat, ok := c.Seat(); ok {
+				if hole := state.HoleCards[seat.SeatIndex]; len(hole) > 0 {
+					v.hole = hole
+				}
+			}
+		})
+	})
+	c.OnHandStarted(func(started client.HandStarted) {
+		update(func() {
+			v.board, v.hole, v.request = nil, nil, nil
+			v.logf("new hand, %s has the button", v.seatName(started.DealerSeat))
+		})
+	})
+	c.OnCardsDealt(func(dealt client.CardsDealt) {
+		update(func() {
+			if seat, ok := c.Seat(); ok {
+				v.hole = dealt.HoleCards[seat.SeatIndex]
+			}
+		})
+	})
+	c.OnActionRequest(func(request client.ActionRequest) {
+		update(func() {
+			v.request = nil
+			if seat, ok := c.Seat(); ok && seat.SeatIndex == request.SeatIndex {
+				v.request = &request
+			}
+		})
+	})
+	c.OnActionResult(func(result client.ActionResult) {
+		update(func() {
+			if seat, ok := c.Seat(); ok && seat.SeatIndex == result.SeatIndex {
+				v.request = nil
+			}
+			line := v.seatName(result.SeatIndex) + " " + result.Action
+			if result.AmountActed > 0 {
+				line += " " + strconv.Itoa(result.AmountActed)
+			}
+			v.logf("%s", line)
+		})
+	})
+	c.OnBoardDealt(func(board client.BoardDealt) {
+		update(func() {
+			v.board = board.BoardCards
+			v.logf("%s: %s", board.Street, cards(board.BoardCards))
+		})
+	})
+	c.OnShowdownReveal(func(reveal client.ShowdownReveal) {
+		update(func() {
+			if reveal.Mucked {
+				v.logf("%s mucks", v.seatName(reveal.SeatIndex))
+			} else {
+				v.logf("%s shows %s (%s)", v.seatName(reveal.SeatIndex), cards(reveal.Cards), strings.ReplaceAll(reveal.Hand, "_", " "))
+			}
+		})
+	})
+	c.OnHandResult(func(result client.HandResult) {
+		update(func() {
+			v.request = nil
+			if result.WinningHand != "" {
+				v.logf("%s win %d with %s", v.winners(result), result.PotAmount, result.WinningHand)
+			} else {
+				v.logf("%s win %d", v.winners(result), result.PotAmount)
+			}
+		})
+	})
+	c.OnSessionSummary(func(summary client.SessionSummary) {
+		update(func() {
+			v.logf("left %s after %s: %d hands, %+d chips, biggest pot %d", summary.TableID,
+				(time.Duration(summary.DurationMs) * time.Millisecond).Round(time.Second), summary.HandsPlayed, summary.NetChips, summary.BiggestPot)
+		})
+	})
+	c.OnError(func(err *client.Error) { update(func() { v.logf("error: %s", err.Message) }) })
+	c.OnReconnect(func() { update(func() { v.logf("reconnected") }) })
+}
+
+// command runs one line typed by the user; returns true to quit
+func command(c *client.Client, v *view, args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	var err error
+	switch strings.ToLower(args[0]) {
+	case "quit", "exit", "q":
+		return true
+	case "help", "?":
+		v.mu.Lock()
+		for _, line := range strings.Split(help, "\n") {
+			v.logf("%s", line)
+		}
+		v.mu.Unlock()
+	case "join":
+		if len(args) < 2 {
+			err = fmt.Errorf("usage: join <table>")
+			break
+		}
+		err = c.JoinTable(args[1])
+	case "watch":
+		if len(args) < 2 {
+			err = fmt.Errorf("usage: watch <table>")
+			break
+		}
+		err = c.Watch(args[1])
+	case "unwatch":
+		err = c.Unwatch()
+		v.mu.Lock()
+		v.state = nil
+		v.mu.Unlock()
+	case "leave":
+		err = c.LeaveTable()
+	case "start":
+		err = c.StartHand()
+	case "lobby":
+		err = c.Send("query_lobby", struct{}{})
+	case "fold", "f":
+		_, err = c.Act("fold", 0)
+	case "check", "k":
+		_, err = c.Act("check", 0)
+	case "call", "c":
+		_, err = c.Act("call", 0)
+	case "raise", "r", "bet", "b":
+		if len(args) < 2 {
+			err = fmt.Errorf("usage: raise <total>")
+			break
+		}
+		amount, convErr := strconv.Atoi(args[1])
+		if convErr != nil {
+			err = fmt.Errorf("invalid amount %q", args[1])
+			break
+		}
+		_, err = c.Act("raise", amount)
+	default:
+		err = fmt.Errorf("unknown command %q, type help", args[0])
+	}
+
+	if err != nil {
+		v.mu.Lock()
+		v.logf("%v", err)
+		v.mu.Unlock()
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/robinr2/poker/pkg/client"
+)
+
+// maxLogLines is how many recent events the view keeps below the table
+const maxLogLines = 8
+
+// view is what the terminal shows, updated from the client's callbacks
+type view struct {
+	mu      sync.Mutex
+	lobby   []client.TableInfo
+	state   *client.TableState
+	board   []client.Card
+	hole    []client.Card
+	request *client.ActionRequest // Set while it is our turn
+	log     []string
+}
+
+// logf appends a line to the event log
+func (v *view) logf(format string, args ...any) {
+	v.log = append(v.log, fmt.Sprintf(format, args...))
+	if len(v.log) > maxLogLines {
+		v.log = v.log[len(v.log)-maxLogLines:]
+	}
+}
+
+// seatName returns the player name at seat, or "seat N"
+func (v *view) seatName(seat int) string {
+	if v.state != nil && seat >= 0 && seat < len(v.state.Seats) && v.state.Seats[seat].PlayerName != nil {
+		return *v.state.Seats[seat].PlayerName
+	}
+	return fmt.Sprintf("seat %d", seat)
+}
+
+// render draws the whole screen as text
+func (v *view) render(mySeat *int) string {
+	var b strings.Builder
+
+	if v.state == nil {
+		b.WriteString("Lobby\n\n")
+		for _, table := range v.lobby {
+			fmt.Fprintf(&b, "  %-10s %-20s %d/%d seated  blinds %d/%d  buy-in %d\n",
+				table.ID, table.Name, table.SeatsOccupied, table.MaxSeats, table.SmallBlind, table.BigBlind, table.BuyIn)
+		}
+	} else {
+		v.renderTable(&b, mySeat)
+	}
+
+	if len(v.log) > 0 {
+		b.WriteString("\n")
+		for _, line := range v.log {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	if v.request != nil {
+		r := v.request
+		fmt.Fprintf(&b, "Your turn: %s", strings.Join(r.ValidActions, ", "))
+		if r.CallAmount > 0 {
+			fmt.Fprintf(&b, "  (call %d)", r.CallAmount)
+		}
+		if r.Deadline > 0 {
+			fmt.Fprintf(&b, "  %ds left", max(0, time.Until(time.UnixMilli(r.Deadline))/time.Second))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("> ")
+	return b.String()
+}
+
+// renderTable draws the seats, board, pot and our cards
+func (v *view) renderTable(b *strings.Builder, mySeat *int) {
+	s := v.state
+	fmt.Fprintf(b, "Table %s  blinds %d/%d", s.TableID, s.Blinds.SmallBlind, s.Blinds.BigBlind)
+	if s.Blinds.Ante > 0 {
+		fmt.Fprintf(b, " ante %d", s.Blinds.Ante)
+	}
+	if s.Frozen {
+		b.WriteString("  [FROZEN]")
+	}
+	if s.WaitingForPlayers {
+		b.WriteString("  [WAITING FOR PLAYERS]")
+	}
+	if s.NextHandAt != nil {
+		fmt.Fprintf(b, "  next hand in %ds", max(0, time.Until(time.UnixMilli(*s.NextHandAt))/time.Second))
+	}
+	b.WriteString("\n\n")
+
+	for _, seat := range s.Seats {
+		if seat.PlayerName == nil {
+			fmt.Fprintf(b, "   %d  -\n", seat.Index)
+			continue
+		}
+		marker := "  "
+		if s.CurrentActor != nil && *s.CurrentActor == seat.Index {
+			marker = "->"
+		}
+		button := "  "
+		if s.DealerSeat != nil && *s.DealerSeat == seat.Index {
+			button = "D "
+		}
+		stack := 0
+		if seat.Stack != nil {
+			stack = *seat.Stack
+		}
+		you := ""
+		if mySeat != nil && *mySeat == seat.Index {
+			you = " (you)"
+		}
+		fmt.Fprintf(b, "%s %d %s%-16s %7d", marker, seat.Index, button, *seat.PlayerName+you, stack)
+		if seat.Bet > 0 {
+			fmt.Fprintf(b, "  bet %d", seat.Bet)
+		}
+		if seat.Status != "active" {
+			fmt.Fprintf(b, "  %s", seat.Status)
+		}
+		if seat.Connection != "" && seat.Connection != "connected" {
+			fmt.Fprintf(b, "  (%s)", seat.Connection)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	if s.HandInProgress {
+		// The pot counts chips swept in from past streets; bets in front of the seats add to it
+		pot := 0
+		if s.Pot != nil {
+			pot = *s.Pot
+		}
+		for _, seat := range s.Seats {
+			pot += seat.Bet
+		}
+		fmt.Fprintf(b, "Board: %-16s Pot: %d\n", cards(v.board), pot)
+		if len(v.hole) > 0 {
+			fmt.Fprintf(b, "Your cards: %s\n", cards(v.hole))
+		}
+	} else {
+		b.WriteString("Waiting for the next hand\n")
+	}
+}
+
+// cards formats cards as "As Kd"
+func cards(list []client.Card) string {
+	if len(list) == 0 {
+		return "-"
+	}
+	parts := make([]string, len(list))
+	for i, card := range list {
+		parts[i] = card.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+// winners formats the winners of a hand result
+func (v *view) winners(result client.HandResult) string {
+	seats := append([]int(nil), result.WinnerSeats...)
+	sort.Ints(seats)
+	parts := make([]string, len(seats))
+	for i, seat := range seats {
+		parts[i] = fmt.Sprintf("%s +%d", v.seatName(seat), result.AmountsWon[seat])
+	}
+	return strings.Join(parts, ", ")
+}
@@ -3,7 +3,7 @@ import { useEffect, useState, useCallback, useRef } from 'react';
 import { LobbyView } from './components/LobbyView';
 import { NamePrompt } from './components/NamePrompt';
 import { TableView } from './components/TableView';
-import { useWebSocket } from './hooks/useWebSocket';
+import { useWebSocket, type SeatAction } from './hooks/useWebSocket';
 import { SessionService } from './services/SessionService';
 import './App.css';
 import './styles/TableView.css';
@@ -18,6 +18,7 @@ interface SeatInfo {
   playerName: string | null;
   status: string;
   stack?: number;
+  lastAction?: SeatAction;
 }
 
 const WS_URL = 'ws://localhost:8080/ws';
@@ -65,10 +66,11 @@ function App() {
       } else if (message.type === 'error' && message.payload) {
         // Handle error messages (e.g., invalid token)
         const errorMessage = message.payload.message as string;
+        const errorKey = message.payload.key as string | undefined;
         console.error('[App] Error from server:', errorMessage);
         
         // If token is invalid/expired, clear it and reload to reconnect without token
-        if (errorMessage && errorMessage.toLowerCase().includes('token')) {
+        if (errorKey === 'error.invalid_token') {
           SessionService.clearToken();
           // Reload the page to reconnect without token and show name prompt
           window.location.reload();
@@ -89,6 +91,9 @@ function App() {
     lastSeatMessage,
     tableState,
     gameState,
+    balances,
+    announcements,
+    narration,
   } = useWebSocket(WS_URL, initialToken || undefined, {
     onMessage: handleMessage,
   });
@@ -138,6 +143,7 @@ function App() {
         status: seat.status,
         stack: seat.stack,
         cardCount: seat.cardCount,
+        lastAction: seat.lastAction,
       }));
       setSeats(updatedSeats);
     }
@@ -167,6 +173,14 @@ function App() {
     }
   };
 
+  const handleQuickSeat = (): void => {
+    sendMessage(JSON.stringify({ type: 'quick_seat', payload: {} }));
+  };
+
+  const handleClaimBonus = (): void => {
+    sendMessage(JSON.stringify({ type: 'claim_bonus', payload: {} }));
+  };
+
   const handleLeaveTable = (): void => {
     try {
       const message = JSON.stringify({
@@ -218,14 +232,38 @@ function App() {
           ></div>
           <span className="status-text">{getStatusText()}</span>
           {playerName && <span className="player-name">• {playerName}</span>}
+          {Object.entries(balances).map(([currency, amount]) => (
+            <span key={currency} className="player-balance">
+              • {amount} {currency}
+            </span>
+          ))}
         </div>
       </header>
 
+      {announcements.length > 0 && (
+        <ul className="announcements">
+          {announcements.map((announcement) => (
+            <li
+              key={announcement.id}
+              className={`announcement announcement-${announcement.level}`}
+            >
+              <span className="announcement-sender">System:</span>{' '}
+              {announcement.message}
+            </li>
+          ))}
+        </ul>
+      )}
+
       <main className="app-main">
         {showPrompt && <NamePrompt onSubmit={handleNameSubmit} />}
 
         {!showPrompt && view === 'lobby' && (
-          <LobbyView tables={lobbyState} onJoinTable={handleJoinTable} />
+          <LobbyView
+            tables={lobbyState}
+            onJoinTable={handleJoinTable}
+            onClaimBonus={handleClaimBonus}
+            onQuickSeat={handleQuickSeat}
+          />
         )}
         {!showPrompt && view === 'table' && currentTableId && (
           <TableView
@@ -237,6 +275,9 @@ function App() {
             onSendMessage={sendMessage}
             sendAction={sendAction}
             sendStartHand={sendStartHand}
+            narration={narration}
+            frozen={tableState?.frozen}
+            closing={tableState?.closing}
           />
         )}
       </main>
@@ -5,12 +5,29 @@ import '../styles/LobbyView.css';
 interface LobbyViewProps {
   tables: TableInfo[];
   onJoinTable: (tableId: string) => void;
+  onClaimBonus?: () => void;
+  onQuickSeat?: () => void;
 }
 
-export function LobbyView({ tables, onJoinTable }: LobbyViewProps) {
+export function LobbyView({
+  tables,
+  onJoinTable,
+  onClaimBonus,
+  onQuickSeat,
+}: LobbyViewProps) {
   return (
     <div className="lobby-view">
       <h1>Lobby</h1>
+      {onQuickSeat && (
+        <button className="quick-seat-button" onClick={onQuickSeat}>
+          Play now
+        </button>
+      )}
+      {onClaimBonus && (
+        <button className="claim-bonus-button" onClick={onClaimBonus}>
+          Claim daily chips
+        </button>
+      )}
       <div className="lobby-grid">
         {tables.map((table) => (
           <TableCard key={table.id} table={table} onJoin={onJoinTable} />
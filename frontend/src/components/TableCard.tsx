@@ -3,6 +3,15 @@ export interface TableInfo {
   name: string;
   seatsOccupied: number;
   maxSeats: number;
+  buyIn?: number;
+  currency?: string; // "play" or "ledger"
+  description?: string;
+  theme?: string;
+  tags?: string[];
+  speed?: string; // "regular", "turbo" or "hyper"
+  observers?: number;
+  handsPerHour?: number; // Hands finished in the last hour
+  avgPot?: number;
 }
 
 interface TableCardProps {
@@ -20,12 +29,47 @@ export function TableCard({ table, onJoin }: TableCardProps) {
   };
 
   return (
-    <div className="table-card">
-      <h3>{table.name}</h3>
+    <div
+      className={
+        table.theme ? `table-card table-theme-${table.theme}` : 'table-card'
+      }
+    >
+      <h3>
+        {table.name}
+        {table.speed && table.speed !== 'regular' && (
+          <span className={`table-speed table-speed-${table.speed}`}>
+            {table.speed}
+          </span>
+        )}
+      </h3>
+      {table.description && (
+        <p className="table-description">{table.description}</p>
+      )}
+      {table.tags && table.tags.length > 0 && (
+        <div className="table-tags">
+          {table.tags.map((tag) => (
+            <span key={tag} className="table-tag">
+              {tag}
+            </span>
+          ))}
+        </div>
+      )}
       <p className="seat-count">
         {table.seatsOccupied}/{table.maxSeats}
       </p>
       <p className="seats-label">seats</p>
+      {table.buyIn !== undefined && (
+        <p className="table-buy-in">
+          Buy-in {table.buyIn} {table.currency === 'ledger' ? 'ledger' : 'play'}{' '}
+          chips
+        </p>
+      )}
+      {(table.handsPerHour || table.observers) ? (
+        <p className="table-activity">
+          {table.handsPerHour ?? 0} hands/h · avg pot {table.avgPot ?? 0} ·{' '}
+          {table.observers ?? 0} watching
+        </p>
+      ) : null}
       <button
         onClick={handleJoinClick}
         disabled={isFull}
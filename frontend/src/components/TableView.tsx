@@ -1,13 +1,40 @@
 import { useState, useEffect } from 'react';
 
+import type { Narration, SeatAction } from '../hooks/useWebSocket';
+import { translate } from '../i18n';
+
+// Public statistics sent for each seat on tables with showStats
+interface PlayerStats {
+  handsPlayed: number;
+  vpip: number;
+}
+
 interface SeatInfo {
   index: number;
   playerName: string | null;
   status: string;
   stack?: number;
   cardCount?: number;
+  stats?: PlayerStats;
+  lastAction?: SeatAction;
 }
 
+// Labels for seats' latest actions, e.g. "Raised to 60"
+const describeSeatAction = ({ action, amount, allIn }: SeatAction): string => {
+  const label = translate(`status.action.${action}`);
+  const withAmount = amount ? `${label} ${amount}` : label;
+  return allIn ? `${withAmount} (all-in)` : withAmount;
+};
+
+// Words a narrator line, with cards drawn as rank and suit symbol
+const formatNarration = ({ key, params = {} }: Narration): string => {
+  const cards = params.cards;
+  return translate(key, {
+    ...params,
+    cards: Array.isArray(cards) ? cards.map(formatCardDisplay) : cards,
+  });
+};
+
 interface GameState {
   dealerSeat: number | null;
   smallBlindSeat: number | null;
@@ -22,9 +49,11 @@ interface GameState {
   handInProgress?: boolean;
   minRaise?: number;
   maxRaise?: number;
+  raiseStep?: number;
   playerBets?: Record<number, number>;
   boardCards?: string[];
   street?: string;
+  revealedCards?: Record<number, string[]>;
   showdown?: {
     winnerSeats: number[];
     winningHand: string;
@@ -45,6 +74,9 @@ interface TableViewProps {
   onSendMessage?: (message: string) => void;
   sendAction?: (action: string, amount?: number) => void;
   sendStartHand?: () => void;
+  narration?: Narration[];
+  frozen?: boolean;
+  closing?: boolean;
 }
 
 // Helper function to convert card string format to display format
@@ -90,12 +122,16 @@ export function TableView({
   onSendMessage,
   sendAction, // TODO: Use sendAction for player actions to enable optimistic updates
   sendStartHand,
+  narration = [],
+  frozen = false,
+  closing = false,
 }: TableViewProps) {
   // Suppress unused warning for sendAction - will be used in future optimistic updates
   void sendAction;
 
   const [raiseAmount, setRaiseAmount] = useState<string>('');
   const [showShowdown, setShowShowdown] = useState<boolean>(true);
+  const [preAction, setPreAction] = useState<string>('');
 
   // Reset showShowdown when a new showdown appears
   useEffect(() => {
@@ -140,6 +176,7 @@ export function TableView({
   const handleAction = (action: string, amount?: number) => {
     if (onSendMessage && currentSeatIndex !== null) {
       const payload: Record<string, unknown> = {
+        actionId: crypto.randomUUID(),
         seatIndex: currentSeatIndex,
         action: action,
       };
@@ -156,12 +193,50 @@ export function TableView({
     }
   };
 
+  // Pre-actions are queued while waiting and consumed (or discarded) when the turn arrives
+  const isMyTurn = gameState?.currentActor === currentSeatIndex;
+  const canQueuePreAction =
+    isSeated &&
+    handInProgress &&
+    !isHandComplete &&
+    !isMyTurn &&
+    !gameState?.foldedPlayers?.includes(currentSeatIndex ?? -1);
+
+  // Any seated opponent may call the clock on a player who is taking too long
+  const canCallClock =
+    isSeated &&
+    !isHandComplete &&
+    !isMyTurn &&
+    gameState?.currentActor !== null &&
+    gameState?.currentActor !== undefined;
+
+  const handleCallClock = () => {
+    onSendMessage?.(JSON.stringify({ type: 'call_clock', payload: {} }));
+  };
+
+  useEffect(() => {
+    if (isMyTurn || isHandComplete) {
+      setPreAction('');
+    }
+  }, [isMyTurn, isHandComplete]);
+
+  const handlePreAction = (action: string) => {
+    const next = preAction === action ? '' : action;
+    setPreAction(next);
+    onSendMessage?.(
+      JSON.stringify({ type: 'pre_action', payload: { action: next } })
+    );
+  };
+
   // Raise button validation logic
   const isRaiseValid = gameState?.validActions?.includes('raise') || false;
   const raiseAmountNum = raiseAmount ? parseInt(raiseAmount, 10) : 0;
+  // Raises go up in the table's step; all-in may be any amount
+  const raiseStep = gameState?.raiseStep ?? 1;
   const isRaiseAmountValid =
     raiseAmountNum >= (gameState?.minRaise ?? 0) &&
-    raiseAmountNum <= (gameState?.maxRaise ?? 0);
+    raiseAmountNum <= (gameState?.maxRaise ?? 0) &&
+    (raiseAmountNum === gameState?.maxRaise || raiseAmountNum % raiseStep === 0);
 
   const handleMinRaise = () => {
     setRaiseAmount((gameState?.minRaise ?? 0).toString());
@@ -169,7 +244,7 @@ export function TableView({
 
   const handlePotRaise = () => {
     const potSized = (gameState?.callAmount ?? 0) + (gameState?.pot ?? 0);
-    setRaiseAmount(potSized.toString());
+    setRaiseAmount((potSized - (potSized % raiseStep)).toString());
   };
 
    const handleAllIn = () => {
@@ -202,6 +277,19 @@ export function TableView({
               <p className="seat-number">Seat {seat.index}</p>
               <p className="seat-player">{seat.playerName || 'Empty'}</p>
 
+              {/* HUD Stats (tables with showStats only) */}
+              {seat.playerName && seat.stats && (
+                <p className="seat-stats">
+                  VPIP {seat.stats.vpip}% · {seat.stats.handsPlayed} hands
+                </p>
+              )}
+
+              {seat.lastAction && (
+                <p className="seat-last-action">
+                  {describeSeatAction(seat.lastAction)}
+                </p>
+              )}
+
               {/* Bet Amount Display */}
               {seat.playerName &&
                 gameState?.playerBets &&
@@ -227,8 +315,24 @@ export function TableView({
                 </div>
               )}
 
+              {/* Opponent Hands Revealed for an All-In Runout */}
+              {seat.index !== currentSeatIndex &&
+                gameState?.revealedCards?.[seat.index] && (
+                  <div className="opponent-cards">
+                    {gameState.revealedCards[seat.index].map((card, idx) => (
+                      <span
+                        key={idx}
+                        className={`card face-up ${isRedSuit(card) ? 'red-suit' : 'black-suit'}`}
+                      >
+                        {formatCardDisplay(card)}
+                      </span>
+                    ))}
+                  </div>
+                )}
+
               {/* Card Backs for Opponents */}
               {seat.index !== currentSeatIndex &&
+                !gameState?.revealedCards?.[seat.index] &&
                 seat.playerName &&
                 seat.cardCount &&
                 seat.cardCount > 0 && (
@@ -388,6 +492,48 @@ export function TableView({
         </div>
       )}
 
+      {/* Pre-action Bar */}
+      {canQueuePreAction && (
+        <div className="pre-action-bar">
+          {[
+            ['check_fold', 'Check/Fold'],
+            ['check', 'Check'],
+            ['call_any', 'Call Any'],
+          ].map(([action, label]) => (
+            <label key={action} className="pre-action">
+              <input
+                type="checkbox"
+                checked={preAction === action}
+                onChange={() => handlePreAction(action)}
+              />
+              {label}
+            </label>
+          ))}
+        </div>
+      )}
+
+      {canCallClock && (
+        <button onClick={handleCallClock} className="call-clock-button">
+          Call the Clock
+        </button>
+      )}
+
+      {frozen && (
+        <div className="frozen-banner">{translate('status.table.frozen')}</div>
+      )}
+
+      {closing && (
+        <div className="closing-banner">{translate('status.table.closing')}</div>
+      )}
+
+      {narration.length > 0 && (
+        <ul className="narration">
+          {narration.map((line, i) => (
+            <li key={i}>{formatNarration(line)}</li>
+          ))}
+        </ul>
+      )}
+
       <div className="button-group">
         {showStartHandButton && (
           <button onClick={handleStartHand} className="start-hand-button">
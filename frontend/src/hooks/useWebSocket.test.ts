@@ -1157,16 +1157,16 @@ describe('Phase 5: Raise Protocol - Frontend Protocol and State', () => {
         result.current.sendAction?.('raise', 100);
       });
 
-      expect(mockServiceInstance.send).toHaveBeenCalledWith(
-        JSON.stringify({
-          type: 'player_action',
-          payload: {
-            seatIndex: 2,
-            action: 'raise',
-            amount: 100,
-          },
-        })
-      );
+      const sent = JSON.parse(mockServiceInstance.send.mock.calls[0][0]);
+      expect(sent).toEqual({
+        type: 'player_action',
+        payload: {
+          actionId: expect.any(String),
+          seatIndex: 2,
+          action: 'raise',
+          amount: 100,
+        },
+      });
     });
 
     it('sendAction omits amount for fold', async () => {
@@ -1200,15 +1200,15 @@ describe('Phase 5: Raise Protocol - Frontend Protocol and State', () => {
         result.current.sendAction?.('fold');
       });
 
-      expect(mockServiceInstance.send).toHaveBeenCalledWith(
-        JSON.stringify({
-          type: 'player_action',
-          payload: {
-            seatIndex: 1,
-            action: 'fold',
-          },
-        })
-      );
+      const sent = JSON.parse(mockServiceInstance.send.mock.calls[0][0]);
+      expect(sent).toEqual({
+        type: 'player_action',
+        payload: {
+          actionId: expect.any(String),
+          seatIndex: 1,
+          action: 'fold',
+        },
+      });
     });
 
     it('sendAction omits amount for check', async () => {
@@ -1242,15 +1242,15 @@ describe('Phase 5: Raise Protocol - Frontend Protocol and State', () => {
         result.current.sendAction?.('check');
       });
 
-      expect(mockServiceInstance.send).toHaveBeenCalledWith(
-        JSON.stringify({
-          type: 'player_action',
-          payload: {
-            seatIndex: 0,
-            action: 'check',
-          },
-        })
-      );
+      const sent = JSON.parse(mockServiceInstance.send.mock.calls[0][0]);
+      expect(sent).toEqual({
+        type: 'player_action',
+        payload: {
+          actionId: expect.any(String),
+          seatIndex: 0,
+          action: 'check',
+        },
+      });
     });
 
     it('sendAction omits amount for call', async () => {
@@ -1284,15 +1284,15 @@ describe('Phase 5: Raise Protocol - Frontend Protocol and State', () => {
         result.current.sendAction?.('call');
       });
 
-      expect(mockServiceInstance.send).toHaveBeenCalledWith(
-        JSON.stringify({
-          type: 'player_action',
-          payload: {
-            seatIndex: 3,
-            action: 'call',
-          },
-        })
-      );
+      const sent = JSON.parse(mockServiceInstance.send.mock.calls[0][0]);
+      expect(sent).toEqual({
+        type: 'player_action',
+        payload: {
+          actionId: expect.any(String),
+          seatIndex: 3,
+          action: 'call',
+        },
+      });
     });
   });
 });
@@ -2506,15 +2506,16 @@ describe('Phase 3: Frontend Integration - Seat Cleared During Active Hand', () =
         result.current.sendAction?.('fold');
       });
 
-      expect(mockServiceInstance.send).toHaveBeenCalledWith(
-        JSON.stringify({
-          type: 'player_action',
-          payload: {
-            seatIndex: 1,
-            action: 'fold',
-          },
-        })
-      );
+      const sendCalls = mockServiceInstance.send.mock.calls;
+      const sent = JSON.parse(sendCalls[sendCalls.length - 1][0]);
+      expect(sent).toEqual({
+        type: 'player_action',
+        payload: {
+          actionId: expect.any(String),
+          seatIndex: 1,
+          action: 'fold',
+        },
+      });
 
       // Clear mock calls
       vi.clearAllMocks();
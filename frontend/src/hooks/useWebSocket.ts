@@ -6,6 +6,37 @@ import {
   type ConnectionStatus,
 } from '../services/WebSocketService';
 
+// Public statistics sent for each seat on tables with showStats
+interface PlayerStats {
+  handsPlayed: number;
+  vpip: number;
+}
+
+// Chips held off the tables, per currency ("play" or "ledger")
+export type Balances = Record<string, number>;
+
+// Server announcement, shown as a system chat message
+export interface Announcement {
+  id: number;
+  message: string;
+  level: 'info' | 'warning';
+  tableId?: string;
+  createdAt: string;
+}
+
+// Only the most recent announcements are kept on screen
+const MAX_ANNOUNCEMENTS = 5;
+
+// Dealer commentary line as a message key and its parameters, e.g.
+// { key: 'narrator.wins', params: { seat: 3, amount: 240, hand: 'straight', high: '9' } }
+export interface Narration {
+  key: string;
+  params?: Record<string, string | number | string[]>;
+}
+
+// Only the most recent narrator lines are kept on screen
+const MAX_NARRATION = 8;
+
 interface SeatAssignedPayload {
   tableId: string;
   seatIndex: number;
@@ -17,17 +48,38 @@ interface SeatMessage {
   payload?: SeatAssignedPayload | Record<string, unknown>;
 }
 
+// A seat's latest action this street, e.g. { action: 'raise', amount: 60 }
+export interface SeatAction {
+  action: string;
+  amount?: number;
+  allIn?: boolean;
+}
+
 interface TableSeat {
   index: number;
   playerName: string | null;
   status: string;
   stack?: number;
   cardCount?: number;
+  stats?: PlayerStats;
+  lastAction?: SeatAction;
+  bet?: number;
+  betChips?: ChipCount[];
+}
+
+// Chips of one denomination, as laid out by the server so every client draws the same stacks
+export interface ChipCount {
+  denomination: number;
+  count: number;
 }
 
 interface TableState {
   tableId: string;
   seats: TableSeat[];
+  frozen?: boolean;
+  closing?: boolean;
+  pot?: number;
+  potChips?: ChipCount[];
 }
 
 interface ShowdownState {
@@ -55,9 +107,11 @@ interface GameState {
   handInProgress?: boolean;
   minRaise?: number;
   maxRaise?: number;
+  raiseStep?: number;
   playerBets: Record<number, number>; // Track each player's bet amount in current round
   boardCards?: string[];
   street?: string;
+  revealedCards?: Record<number, string[]>; // Hands turned face up for an all-in runout
   showdown?: ShowdownState;
   handComplete?: HandCompleteState;
 }
@@ -89,6 +143,7 @@ interface ActionRequestPayload {
   callAmount: number;
   minRaise?: number;
   maxRaise?: number;
+  raiseStep?: number;
 }
 
 interface ActionResultPayload {
@@ -107,6 +162,22 @@ interface BoardDealtPayload {
   street: string;
 }
 
+interface HandsRevealedPayload {
+  holeCards: Record<number, Card[]>;
+  reason: string;
+}
+
+// toRevealedCards converts revealed hands from {Rank, Suit} objects to "As"-style strings
+const toRevealedCards = (
+  holeCards: Record<number, Card[]>
+): Record<number, string[]> => {
+  const revealed: Record<number, string[]> = {};
+  for (const [seatIndex, cards] of Object.entries(holeCards)) {
+    revealed[Number(seatIndex)] = cards.map((card) => card.Rank + card.Suit);
+  }
+  return revealed;
+};
+
 interface UseWebSocketReturn {
   status: ConnectionStatus;
   sendMessage: (message: string) => void;
@@ -117,6 +188,9 @@ interface UseWebSocketReturn {
   lastSeatMessage: SeatMessage | null;
   tableState: TableState | null;
   gameState: GameState;
+  balances: Balances;
+  announcements: Announcement[];
+  narration: Narration[];
 }
 
 interface UseWebSocketOptions {
@@ -136,6 +210,9 @@ export function useWebSocket(
   );
   const [tableState, setTableState] = useState<TableState | null>(null);
   const [playerSeatIndex, setPlayerSeatIndex] = useState<number | null>(null);
+  const [balances, setBalances] = useState<Balances>({});
+  const [announcements, setAnnouncements] = useState<Announcement[]>([]);
+  const [narration, setNarration] = useState<Narration[]>([]);
   const [gameState, setGameState] = useState<GameState>({
     dealerSeat: null,
     smallBlindSeat: null,
@@ -181,21 +258,90 @@ export function useWebSocket(
       // Parse and handle messages
       try {
         const message = JSON.parse(data);
-        if (message.type === 'lobby_state' && message.payload) {
+        if (message.type === 'session_taken_over') {
+          // The session was opened in another tab or device; reconnecting
+          // here would take it straight back, so stay disconnected
+          service.disconnect();
+        } else if (message.type === 'lobby_state' && message.payload) {
           // Parse payload directly - backend sends it as an array, not a string
           const tables = message.payload as {
             id: string;
             name: string;
             seats_occupied: number;
             max_seats: number;
+            buy_in?: number;
+            currency?: string;
+            description?: string;
+            theme?: string;
+            tags?: string[];
+            speed?: string;
+            observers?: number;
+            hands_per_hour?: number;
+            avg_pot?: number;
           }[];
           const convertedTables: TableInfo[] = tables.map((t) => ({
             id: t.id,
             name: t.name,
             seatsOccupied: t.seats_occupied,
             maxSeats: t.max_seats,
+            buyIn: t.buy_in,
+            currency: t.currency,
+            description: t.description,
+            theme: t.theme,
+            tags: t.tags,
+            speed: t.speed,
+            observers: t.observers,
+            handsPerHour: t.hands_per_hour,
+            avgPot: t.avg_pot,
           }));
           setLobbyState(convertedTables);
+        } else if (
+          (message.type === 'session_created' ||
+            message.type === 'session_restored' ||
+            message.type === 'balances' ||
+            message.type === 'bonus_claimed') &&
+          message.payload
+        ) {
+          // Balances come with the session and privately whenever they change
+          const payload = message.payload as { balances?: Balances };
+          if (payload.balances) {
+            setBalances(payload.balances);
+          }
+        } else if (message.type === 'announcement' && message.payload) {
+          const announcement = message.payload as Announcement;
+          setAnnouncements((prev) =>
+            [...prev, announcement].slice(-MAX_ANNOUNCEMENTS)
+          );
+        } else if (message.type === 'narration' && message.payload) {
+          const line = message.payload as Narration;
+          setNarration((prev) => [...prev, line].slice(-MAX_NARRATION));
+        } else if (message.type === 'confirm_raise' && message.payload) {
+          // Large raises are only played once confirmed, within two seconds
+          const prompt = message.payload as {
+            actionId: string;
+            seatIndex: number;
+            amount: number;
+            chips: number;
+            stack: number;
+          };
+          if (
+            window.confirm(
+              `Raise to ${prompt.amount}, putting in ${prompt.chips} of your ${prompt.stack} chips?`
+            )
+          ) {
+            service.send(
+              JSON.stringify({
+                type: 'player_action',
+                payload: {
+                  actionId: prompt.actionId,
+                  seatIndex: prompt.seatIndex,
+                  action: 'raise',
+                  amount: prompt.amount,
+                  confirm: true,
+                },
+              })
+            );
+          }
         } else if (
           message.type === 'seat_assigned' ||
           message.type === 'seat_cleared'
@@ -224,6 +370,7 @@ export function useWebSocket(
               status: string;
               stack?: number;
               cardCount?: number;
+              stats?: PlayerStats;
             }>;
             handInProgress?: boolean;
             dealerSeat?: number;
@@ -231,6 +378,9 @@ export function useWebSocket(
             bigBlindSeat?: number;
             pot?: number;
             holeCards?: { [seatIndex: string]: Card[] };
+            revealedCards?: Record<number, Card[]>;
+            frozen?: boolean;
+            closing?: boolean;
           };
 
           // Update table state with all seat information
@@ -241,6 +391,8 @@ export function useWebSocket(
           setTableState({
             tableId: payload.tableId,
             seats: payload.seats,
+            frozen: payload.frozen,
+            closing: payload.closing,
           });
 
           // Update game state with new fields if present
@@ -249,7 +401,8 @@ export function useWebSocket(
             payload.smallBlindSeat !== undefined ||
             payload.bigBlindSeat !== undefined ||
             payload.pot !== undefined ||
-            payload.holeCards !== undefined
+            payload.holeCards !== undefined ||
+            payload.revealedCards !== undefined
           ) {
             setGameState((prev) => {
               const updated = { ...prev };
@@ -267,6 +420,9 @@ export function useWebSocket(
               if (payload.pot !== undefined) {
                 updated.pot = payload.pot;
               }
+              if (payload.revealedCards) {
+                updated.revealedCards = toRevealedCards(payload.revealedCards);
+              }
 
               // Update hole cards if present
               if (payload.holeCards) {
@@ -309,6 +465,7 @@ export function useWebSocket(
             updated.foldedPlayers = []; // Clear folded players for new hand
             delete updated.showdown; // Clear showdown state so button hides for all players
             delete updated.handComplete; // Clear handComplete state so button hides for all players
+            delete updated.revealedCards; // Clear hands revealed in the previous hand
             return updated;
           });
           console.log(
@@ -410,6 +567,9 @@ export function useWebSocket(
             if (payload.maxRaise !== undefined) {
               updated.maxRaise = payload.maxRaise;
             }
+            if (payload.raiseStep !== undefined) {
+              updated.raiseStep = payload.raiseStep;
+            }
 
             return updated;
           });
@@ -508,6 +668,16 @@ export function useWebSocket(
             'street:',
             payload.street
           );
+        } else if (message.type === 'hands_revealed' && message.payload) {
+          // Everyone left is all-in: the live hands are turned face up before the runout
+          const payload =
+            typeof message.payload === 'string'
+              ? (JSON.parse(message.payload) as HandsRevealedPayload)
+              : (message.payload as HandsRevealedPayload);
+          setGameState((prev) => ({
+            ...prev,
+            revealedCards: toRevealedCards(payload.holeCards),
+          }));
         } else if (message.type === 'showdown_result' && message.payload) {
           // Handle showdown_result message
           console.log(
@@ -593,7 +763,9 @@ export function useWebSocket(
     (action: string, amount?: number) => {
       if (serviceRef.current && playerSeatIndex !== null) {
         try {
+          // actionId lets the server recognize this action if it is resent after a network blip
           const payload: Record<string, unknown> = {
+            actionId: crypto.randomUUID(),
             seatIndex: playerSeatIndex,
             action: action,
           };
@@ -651,5 +823,8 @@ export function useWebSocket(
     lastSeatMessage,
     tableState,
     gameState,
+    balances,
+    announcements,
+    narration,
   };
 }
@@ -0,0 +1,21 @@
+import en from './locales/en.json';
+
+// Server messages arrive as a key plus parameters; this words them from a catalog.
+// locales/en.json is generated from the server (go generate ./internal/server).
+const catalog: Record<string, string> = en;
+
+export type MessageParams = Record<string, string | number | string[] | undefined>;
+
+// Fills a catalog template's {name} placeholders. A parameter whose value is itself a key
+// under the parameter's name (hand: 'straight' -> hand.straight) is worded from the catalog.
+// Unknown keys are returned as they are.
+export function translate(key: string, params: MessageParams = {}): string {
+  const template = catalog[key];
+  if (template === undefined) return key;
+  return template.replace(/\{(\w+)\}/g, (placeholder, name: string) => {
+    const value = params[name];
+    if (value === undefined) return placeholder;
+    if (Array.isArray(value)) return value.join(' ');
+    return catalog[`${name}.${value}`] ?? String(value);
+  });
+}
@@ -0,0 +1,303 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+var (
+	errAccountNotFound = errors.New("no data is held for this player")
+	errAccountOnline   = errors.New("player is online; kick them before deleting their account")
+	errAccountInClub   = errors.New("player is a club member; remove them from their clubs first")
+)
+
+// AccountClub is a club the player belongs to, in a data export
+type AccountClub struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role"`
+	Net  int    `json:"net"` // The player's position in the club's current ledger
+}
+
+// AccountDeletion is the response of DELETE /admin/accounts/{name}
+type AccountDeletion struct {
+	Player    string `json:"player"`    // Lowercased name of the account deleted
+	Pseudonym string `json:"pseudonym"` // What the retained hands call the player now
+	Hands     int    `json:"hands"`     // Retained hands the player was anonymized in
+}
+
+// Account returns a copy of the named account, if the store has one
+func (s *AccountStore) Account(name string) (Account, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	account, ok := s.accounts[accountKey(name)]
+	return account, ok
+}
+
+// Delete removes the named account
+func (s *AccountStore) Delete(name string) error {
+	key := accountKey(name)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account, ok := s.accounts[key]
+	if !ok {
+		return nil
+	}
+	delete(s.accounts, key)
+	if err := s.saveLocked(); err != nil {
+		// Keep memory in line with the file so the deletion can be retried
+		s.accounts[key] = account
+		return err
+	}
+	return nil
+}
+
+// dealtIn reports whether the player called name was dealt into replay
+func (replay *HandReplay) dealtIn(name string) bool {
+	for _, player := range replay.Players {
+		if accountKey(player) == accountKey(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// HandsOf returns the finished hands the player called name was dealt into, oldest first
+func (rs *ReplayStore) HandsOf(name string) []*HandReplay {
+	hands := []*HandReplay{}
+	if rs == nil {
+		return hands
+	}
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	for _, id := range rs.order {
+		if replay := rs.hands[id]; replay.dealtIn(name) {
+			hands = append(hands, replay)
+		}
+	}
+	return hands
+}
+
+// Rename replaces the name of the player called name with pseudonym in every finished hand and
+// summary. Returns the hands changed, by ID.
+// Finished replays are shared with readers, so they are copied rather than changed.
+func (rs *ReplayStore) Rename(name, pseudonym string) map[string]*HandReplay {
+	renamed := make(map[string]*HandReplay)
+	if rs == nil {
+		return renamed
+	}
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	for id, replay := range rs.hands {
+		if !replay.dealtIn(name) {
+			continue
+		}
+		updated := *replay
+		updated.Players = maps.Clone(replay.Players)
+		for seat, player := range updated.Players {
+			if accountKey(player) == accountKey(name) {
+				updated.Players[seat] = pseudonym
+			}
+		}
+		rs.hands[id] = &updated
+		renamed[id] = &updated
+	}
+	for tableID, history := range rs.history {
+		history = slices.Clone(history)
+		for i := range history {
+			if _, ok := renamed[history[i].HandID]; !ok {
+				continue
+			}
+			history[i].Winners = slices.Clone(history[i].Winners)
+			for j := range history[i].Winners {
+				if accountKey(history[i].Winners[j].Name) == accountKey(name) {
+					history[i].Winners[j].Name = pseudonym
+				}
+			}
+		}
+		rs.history[tableID] = history
+	}
+	return renamed
+}
+
+// replaceHands points the snippets of the given hands at their new replays
+func (ss *SnippetStore) replaceHands(hands map[string]*HandReplay) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	for id, replay := range hands {
+		if snippet, ok := ss.snippets[id]; ok {
+			updated := *snippet
+			updated.Hand = replay.public("")
+			ss.snippets[id] = &updated
+		}
+	}
+}
+
+// RenamePlayer replaces the name of the player called name with pseudonym in every superlative
+func (st *StatsTracker) RenamePlayer(name, pseudonym string) {
+	if st == nil {
+		return
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	matches := func(player string) bool { return accountKey(player) == accountKey(name) }
+	for _, tables := range st.superlatives {
+		for tableID, records := range tables {
+			if pot := records.BiggestPot; pot != nil && matches(pot.Winner) {
+				renamed := *pot
+				renamed.Winner = pseudonym
+				records.BiggestPot = &renamed
+			}
+			if hand := records.BestHand; hand != nil && matches(hand.Player) {
+				renamed := *hand
+				renamed.Player = pseudonym
+				records.BestHand = &renamed
+			}
+			if bust := records.FastestElimination; bust != nil && matches(bust.Player) {
+				renamed := *bust
+				renamed.Player = pseudonym
+				records.FastestElimination = &renamed
+			}
+			tables[tableID] = records
+		}
+	}
+}
+
+// accountClubs returns the clubs the player called name belongs to, with their ledger positions
+func (s *Server) accountClubs(name string) []AccountClub {
+	clubs := []AccountClub{}
+	for _, club := range s.clubs.Clubs(name) {
+		member, _ := club.member(accountKey(name))
+		clubs = append(clubs, AccountClub{ID: club.ID, Name: club.Name, Role: member.Role, Net: club.Ledger[accountKey(name)]})
+	}
+	return clubs
+}
+
+// shownTo returns hands as the player called name may see them, under the aliases of the other
+// players at anonymous tables
+func shownTo(hands []*HandReplay, name string) []*HandReplay {
+	shown := make([]*HandReplay, len(hands))
+	for i, replay := range hands {
+		shown[i] = replay.public(name)
+	}
+	return shown
+}
+
+// ExportAccount returns a zip archive of the data held about the player called name: their
+// account, the retained hands they were dealt into, their rake statement and their clubs
+func (s *Server) ExportAccount(name string) ([]byte, error) {
+	account, ok := s.accounts.Account(name)
+	hands := s.replays.HandsOf(name)
+	clubs := s.accountClubs(name)
+	if !ok && len(hands) == 0 && len(clubs) == 0 {
+		return nil, errAccountNotFound
+	}
+	account.Name = accountKey(name)
+
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	files := []struct {
+		name    string
+		content any
+	}{
+		{"account.json", account},
+		{"hands.json", shownTo(hands, name)},
+		{"statement.json", s.accounts.RakeStatement(name, time.Time{})},
+		{"clubs.json", clubs},
+	}
+	for _, file := range files {
+		data, err := json.MarshalIndent(file.content, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		w, err := writer.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return archive.Bytes(), nil
+}
+
+// DeleteAccount deletes the account of the player called name and replaces their name with a
+// pseudonym in the hands, snippets and superlatives the server keeps. Players who are online or
+// still in a club are refused. Bans, fraud alerts and logs are kept.
+func (s *Server) DeleteAccount(name string) (AccountDeletion, error) {
+	if _, ok := s.accounts.Account(name); !ok && len(s.replays.HandsOf(name)) == 0 {
+		return AccountDeletion{}, errAccountNotFound
+	}
+	if len(s.sessionManager.TokensByName(name)) > 0 {
+		return AccountDeletion{}, errAccountOnline
+	}
+	if len(s.clubs.Clubs(name)) > 0 {
+		return AccountDeletion{}, errAccountInClub
+	}
+	if err := s.accounts.Delete(name); err != nil {
+		return AccountDeletion{}, err
+	}
+
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	pseudonym := "Deleted-" + hex.EncodeToString(suffix)
+	hands := s.replays.Rename(name, pseudonym)
+	s.snippets.replaceHands(hands)
+	s.stats.RenamePlayer(name, pseudonym)
+	return AccountDeletion{Player: accountKey(name), Pseudonym: pseudonym, Hands: len(hands)}, nil
+}
+
+// handleExportAccount serves the data export of the account in the path as a zip download
+func (s *Server) handleExportAccount(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	archive, err := s.ExportAccount(name)
+	switch {
+	case errors.Is(err, errAccountNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case err != nil:
+		http.Error(w, "failed to export account: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.logger.Info("admin exported account", "player", name, "bytes", len(archive), "client_ip", ClientIP(r))
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+accountKey(name)+`-export.zip"`)
+	w.Write(archive)
+}
+
+// handleDeleteAccount deletes the account in the path and anonymizes the player in retained hands
+func (s *Server) handleDeleteAccount(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	deletion, err := s.DeleteAccount(name)
+	switch {
+	case errors.Is(err, errAccountNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case errors.Is(err, errAccountOnline), errors.Is(err, errAccountInClub):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, "failed to delete account: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.logger.Info("admin deleted account", "player", deletion.Player, "pseudonym", deletion.Pseudonym, "hands", deletion.Hands, "client_ip", ClientIP(r))
+	writeAdminJSON(w, http.StatusOK, deletion)
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestAdminAPI_ExportAccount verifies an account's export is a zip of its account, hands,
+// statement and clubs, and unknown players give 404
+func TestAdminAPI_ExportAccount(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{Tables: DefaultTables(), AdminToken: "secret"})
+	table := server.tables[0]
+	seatNamed(t, server, table, "Alice", "Bob")
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	handID := handIDOf(table)
+	playOutChecking(t, server, table)
+	storedReplay(t, server, handID)
+
+	if code := adminRequest(server, http.MethodGet, "/admin/accounts/Nobody/export", "secret", "").Code; code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown player, got %d", code)
+	}
+	rec := adminRequest(server, http.MethodGet, "/admin/accounts/alice/export", "secret", "")
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/zip" {
+		t.Fatalf("expected a zip, got %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	archive, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string]string)
+	for _, file := range archive.File {
+		r, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(r)
+		r.Close()
+		files[file.Name] = string(data)
+	}
+	for _, name := range []string{"account.json", "hands.json", "statement.json", "clubs.json"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("expected %s in the archive, got %v", name, archive.File)
+		}
+	}
+	var hands []HandReplay
+	if err := json.Unmarshal([]byte(files["hands.json"]), &hands); err != nil || len(hands) != 1 || hands[0].ID != handID {
+		t.Errorf("expected the hand Alice played, got %s (%v)", files["hands.json"], err)
+	}
+}
+
+// TestAdminAPI_DeleteAccount verifies deleting an account is refused while the player is online,
+// and afterwards their retained hands carry a pseudonym and their account is gone
+func TestAdminAPI_DeleteAccount(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{Tables: DefaultTables(), AdminToken: "secret"})
+	table := server.tables[0]
+	clients := seatNamed(t, server, table, "Alice", "Bob")
+	if err := server.accounts.SetPrivacy("Alice", PrivacyPayload{HideStats: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	handID := handIDOf(table)
+	playOutChecking(t, server, table)
+	storedReplay(t, server, handID)
+
+	if code := adminRequest(server, http.MethodDelete, "/admin/accounts/Alice", "secret", "").Code; code != http.StatusConflict {
+		t.Errorf("expected 409 while Alice is online, got %d", code)
+	}
+	if err := server.sessionManager.RemoveSession(clients[0].Token); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := adminRequest(server, http.MethodDelete, "/admin/accounts/Alice", "secret", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var deletion AccountDeletion
+	if err := json.Unmarshal(rec.Body.Bytes(), &deletion); err != nil {
+		t.Fatal(err)
+	}
+	if deletion.Player != "alice" || deletion.Hands != 1 || !strings.HasPrefix(deletion.Pseudonym, "Deleted-") {
+		t.Errorf("expected one of alice's hands anonymized, got %+v", deletion)
+	}
+
+	replay, _ := server.replays.Hand(handID)
+	if replay.dealtIn("Alice") || !replay.dealtIn(deletion.Pseudonym) || !replay.dealtIn("Bob") {
+		t.Errorf("expected only Alice replaced in the hand, got %v", replay.Players)
+	}
+	if _, ok := server.accounts.Account("Alice"); ok {
+		t.Error("expected the account deleted")
+	}
+	if code := adminRequest(server, http.MethodGet, "/admin/accounts/Alice/export", "secret", "").Code; code != http.StatusNotFound {
+		t.Errorf("expected nothing left to export, got %d", code)
+	}
+}
@@ -0,0 +1,202 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// errBonusCooldown is returned when an account claims the daily bonus again too soon
+var errBonusCooldown = errors.New("bonus_cooldown")
+
+// Account is what the server remembers about a player name across sessions and restarts
+type Account struct {
+	Name        string           `json:"name"` // Lowercased player name
+	LastBonusAt time.Time        `json:"lastBonusAt,omitempty"`
+	Inventory   []InventoryItem  `json:"inventory,omitempty"` // Tickets, vouchers and promo items held
+	Muted       []string         `json:"muted,omitempty"`     // Lowercased names whose emotes are hidden, sorted
+	Sessions    []SessionSummary `json:"sessions,omitempty"`  // Recent table sessions, oldest first
+	// RakeLedger totals the rake charged to the player each day, oldest first, over the last
+	// rakeLedgerDays days. Unlike Sessions it counts every hand, so statements cover whole periods.
+	RakeLedger []RakeDay `json:"rakeLedger,omitempty"`
+	// LoyaltyPoints are earned on the rake charged (see LoyaltyConfig) and spent on redemptions
+	LoyaltyPoints int `json:"loyaltyPoints,omitempty"`
+	// ManualTimeBank keeps the time bank for use_time_bank instead of engaging it when the action
+	// clock runs out
+	ManualTimeBank bool `json:"manualTimeBank,omitempty"`
+	// HideStats and LimitHistory are the player's privacy settings (see PrivacyPayload)
+	HideStats    bool `json:"hideStats,omitempty"`
+	LimitHistory bool `json:"limitHistory,omitempty"`
+}
+
+// AccountStore holds per-account state, keyed by lowercased player name, and persists it
+// to a JSON file. The zero path keeps accounts in memory only.
+type AccountStore struct {
+	mu       sync.Mutex
+	accounts map[string]Account
+	path     string
+}
+
+// accountKey canonicalizes a player name the same way account bans do
+func accountKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// LoadAccountStore reads the accounts stored at path; a missing file starts an empty store
+// An empty path returns an in-memory store
+func LoadAccountStore(path string) (*AccountStore, error) {
+	store := &AccountStore{
+		accounts: make(map[string]Account),
+		path:     path,
+	}
+	if path == "" {
+		return store, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read account store: %w", err)
+	}
+
+	var accounts []Account
+	if err := json.Unmarshal(data, &accounts); err != nil {
+		return nil, fmt.Errorf("failed to parse account store %s: %w", path, err)
+	}
+	for _, account := range accounts {
+		account.Name = accountKey(account.Name)
+		if account.Name == "" {
+			return nil, fmt.Errorf("invalid entry in account store %s: missing name", path)
+		}
+		store.accounts[account.Name] = account
+	}
+	return store, nil
+}
+
+// ClaimBonus records a bonus claim by name at now unless one was made less than cooldown ago
+// Returns when the next claim is allowed, with errBonusCooldown if this claim was refused
+func (s *AccountStore) ClaimBonus(name string, now time.Time, cooldown time.Duration) (time.Time, error) {
+	var next time.Time
+	err := s.update(name, func(account *Account) error {
+		if !account.LastBonusAt.IsZero() && now.Before(account.LastBonusAt.Add(cooldown)) {
+			next = account.LastBonusAt.Add(cooldown)
+			return errBonusCooldown
+		}
+		account.LastBonusAt = now
+		next = now.Add(cooldown)
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBonusCooldown) {
+		return time.Time{}, err
+	}
+	return next, err
+}
+
+// update applies change to a copy of the named account (a new one if there is none) and
+// persists it. Nothing is stored if change or the save fails.
+func (s *AccountStore) update(name string, change func(account *Account) error) error {
+	return s.updateAll([]string{name}, func(_ string, account *Account) error {
+		return change(account)
+	})
+}
+
+// updateAll applies change to copies of the named accounts, in turn, and persists them all
+// with one save. A name given twice, or in another case, changes the same account twice.
+// Nothing is stored if a change or the save fails.
+func (s *AccountStore) updateAll(names []string, change func(name string, account *Account) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed := make(map[string]Account, len(names))
+	for _, name := range names {
+		key := accountKey(name)
+		account, ok := changed[key]
+		if !ok {
+			account = s.copyLocked(key)
+		}
+		if err := change(name, &account); err != nil {
+			return err
+		}
+		changed[key] = account
+	}
+
+	previous := make(map[string]Account, len(changed))
+	for key, account := range changed {
+		if existing, existed := s.accounts[key]; existed {
+			previous[key] = existing
+		}
+		s.accounts[key] = account
+	}
+	if err := s.saveLocked(); err != nil {
+		// Keep memory in line with the file so the change can be retried
+		for key := range changed {
+			if existing, existed := previous[key]; existed {
+				s.accounts[key] = existing
+			} else {
+				delete(s.accounts, key)
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// copyLocked returns a copy of the account under key, or a new one, that can be changed
+// without touching the stored one (caller must hold s.mu)
+func (s *AccountStore) copyLocked(key string) Account {
+	account := s.accounts[key]
+	account.Name = key
+	account.Inventory = slices.Clone(account.Inventory)
+	account.Muted = slices.Clone(account.Muted)
+	account.Sessions = slices.Clone(account.Sessions)
+	account.RakeLedger = slices.Clone(account.RakeLedger)
+	return account
+}
+
+// saveLocked writes the accounts to the store's file (caller must hold s.mu)
+// The file is replaced atomically so a crash never leaves a truncated store
+func (s *AccountStore) saveLocked() error {
+	if s.path == "" {
+		return nil
+	}
+
+	accounts := make([]Account, 0, len(s.accounts))
+	for _, account := range s.accounts {
+		accounts = append(accounts, account)
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Name < accounts[j].Name
+	})
+
+	data, err := json.MarshalIndent(accounts, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal account store: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".accounts-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to save account store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save account store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save account store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to save account store: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// activityWindow is how far back the lobby's activity indicators look
+const activityWindow = time.Hour
+
+// completedHand is a hand finished at a table, as remembered for activity indicators
+type completedHand struct {
+	at  time.Time
+	pot int
+}
+
+// TableActivity summarizes how lively a table has been over the last activityWindow
+type TableActivity struct {
+	HandsPerHour int // Hands finished in the last hour
+	AveragePot   int // Average chips won per hand over those hands, rake included
+}
+
+// ActivityTracker remembers recently finished hands per table
+type ActivityTracker struct {
+	mu     sync.Mutex
+	tables map[string][]completedHand
+}
+
+// NewActivityTracker creates an empty ActivityTracker
+func NewActivityTracker() *ActivityTracker {
+	return &ActivityTracker{tables: make(map[string][]completedHand)}
+}
+
+// Run handles events until the channel is closed
+func (a *ActivityTracker) Run(events <-chan Event) {
+	for e := range events {
+		if e.Type == EventHandEnded {
+			a.record(e.TableID, e.Time, e.Pot)
+		}
+	}
+}
+
+// record adds a hand finished at tableID and forgets hands older than the window
+func (a *ActivityTracker) record(tableID string, at time.Time, pot int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.tables[tableID] = append(a.pruneLocked(tableID, at), completedHand{at: at, pot: pot})
+}
+
+// pruneLocked drops the hands at tableID that finished more than activityWindow before now
+// (internal, must be called with the lock held)
+func (a *ActivityTracker) pruneLocked(tableID string, now time.Time) []completedHand {
+	hands := a.tables[tableID]
+	cutoff := now.Add(-activityWindow)
+	kept := 0
+	for kept < len(hands) && !hands[kept].at.After(cutoff) {
+		kept++
+	}
+	hands = hands[kept:]
+	a.tables[tableID] = hands
+	return hands
+}
+
+// Activity returns the activity indicators of tableID at now
+func (a *ActivityTracker) Activity(tableID string, now time.Time) TableActivity {
+	if a == nil {
+		return TableActivity{}
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	hands := a.pruneLocked(tableID, now)
+	if len(hands) == 0 {
+		return TableActivity{}
+	}
+	total := 0
+	for _, hand := range hands {
+		total += hand.pot
+	}
+	return TableActivity{HandsPerHour: len(hands), AveragePot: total / len(hands)}
+}
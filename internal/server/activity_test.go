@@ -0,0 +1,60 @@
+package server
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// TestActivityTracker_Window verifies hands and average pot only count the last hour
+func TestActivityTracker_Window(t *testing.T) {
+	tracker := NewActivityTracker()
+	start := time.Now()
+
+	tracker.record("table-1", start, 100)
+	tracker.record("table-1", start.Add(30*time.Minute), 300)
+	tracker.record("table-2", start.Add(30*time.Minute), 50)
+
+	if got := tracker.Activity("table-1", start.Add(45*time.Minute)); got != (TableActivity{HandsPerHour: 2, AveragePot: 200}) {
+		t.Errorf("expected 2 hands averaging 200, got %+v", got)
+	}
+	if got := tracker.Activity("table-1", start.Add(75*time.Minute)); got != (TableActivity{HandsPerHour: 1, AveragePot: 300}) {
+		t.Errorf("expected the first hand to have aged out, got %+v", got)
+	}
+	if got := tracker.Activity("table-3", start); got != (TableActivity{}) {
+		t.Errorf("expected no activity for an idle table, got %+v", got)
+	}
+}
+
+// TestActivity_HandEndedFeedsLobby verifies finished hands show up in the lobby and activity sort
+func TestActivity_HandEndedFeedsLobby(t *testing.T) {
+	server := NewServer(slog.Default())
+	table := server.tables[1]
+	seatTwoPlayers(table)
+
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	// Seat 1 folds so seat 0 wins without evaluation
+	table.mu.Lock()
+	table.CurrentHand.FoldedPlayers[1] = true
+	table.mu.Unlock()
+	table.HandleShowdown()
+
+	deadline := time.Now().Add(time.Second)
+	var info TableInfo
+	for time.Now().Before(deadline) {
+		if info = server.GetLobbyState()[1]; info.HandsPerHour == 1 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if info.HandsPerHour != 1 || info.AveragePot <= 0 {
+		t.Fatalf("expected one hand with a pot, got %+v", info)
+	}
+
+	tables, _ := server.QueryLobby(LobbyFilter{Sort: LobbySortActivity})
+	if tables[0].ID != table.ID {
+		t.Errorf("expected the active table first, got %s", tables[0].ID)
+	}
+}
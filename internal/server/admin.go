@@ -0,0 +1,524 @@
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// BanRequest is the body of POST /admin/bans
+type BanRequest struct {
+	Kind     string `json:"kind"`               // "ip" or "account"
+	Value    string `json:"value"`              // IP, CIDR range or player name
+	Reason   string `json:"reason,omitempty"`   // Free text shown in the ban list
+	Duration string `json:"duration,omitempty"` // Go duration such as "24h"; empty bans permanently
+}
+
+// BalanceRequest is the body of POST /admin/balances
+type BalanceRequest struct {
+	Player   string       `json:"player"`   // Player name; must match exactly one live session
+	Currency ChipCurrency `json:"currency"` // "play" or "ledger"
+	Amount   int          `json:"amount"`   // Chips to credit; negative to debit
+}
+
+// BalanceResponse is the result of POST /admin/balances
+type BalanceResponse struct {
+	Player   string       `json:"player"`
+	Currency ChipCurrency `json:"currency"`
+	Balance  int          `json:"balance"`
+}
+
+// GrantItemRequest is the body of POST /admin/accounts/{name}/inventory
+type GrantItemRequest struct {
+	Kind      string       `json:"kind"`                // tournament_ticket, rakeback_voucher or promo
+	Reference string       `json:"reference,omitempty"` // Tournament ID for tickets, free text otherwise
+	Value     int          `json:"value,omitempty"`
+	Currency  ChipCurrency `json:"currency,omitempty"`
+	Duration  string       `json:"duration,omitempty"` // Go duration such as "720h"; empty never expires
+}
+
+// AnnouncementRequest is the body of POST /admin/announcements
+type AnnouncementRequest struct {
+	Message string `json:"message"`
+	Level   string `json:"level,omitempty"`   // "info" (default) or "warning"
+	TableID string `json:"tableId,omitempty"` // Only the players at this table; empty for everyone
+}
+
+// adminRoutes returns the operator API mounted at /admin:
+//   - GET    /admin/bans                            list active bans
+//   - POST   /admin/bans                            add a ban (BanRequest) and drop matching connections
+//   - DELETE /admin/bans?kind=...&value=...         lift a ban
+//   - GET    /admin/alerts?since=ID                 fraud alerts newer than ID (all when omitted)
+//   - GET    /admin/currencies                      chips, balances and rake per currency
+//   - POST   /admin/balances                        credit or debit a player's balance (BalanceRequest)
+//   - GET    /admin/accounts/{name}/inventory       list an account's tickets, vouchers and promo items
+//   - POST   /admin/accounts/{name}/inventory       grant an item (GrantItemRequest)
+//   - DELETE /admin/accounts/{name}/inventory/{id}  consume or revoke an item
+//   - GET    /admin/accounts/{name}/sessions        an account's recent table sessions, newest first
+//   - GET    /admin/accounts/{name}/statement?since=MS  rake charged over those sessions (RakeStatement)
+//   - GET    /admin/accounts/{name}/loyalty         an account's loyalty points
+//   - POST   /admin/accounts/{name}/loyalty/redeem  spend points on chips or a ticket (LoyaltyRedeemRequest)
+//   - POST   /admin/accounts/{name}/kick            unseat a player, folding and cashing out (KickRequest)
+//   - GET    /admin/accounts/{name}/export          everything held about a player, as a zip archive
+//   - DELETE /admin/accounts/{name}                 delete an account, anonymizing its retained hands (AccountDeletion)
+//   - GET    /admin/clubs                           every club with its members, tables and invite code
+//   - GET    /admin/announcements?since=ID          announcements newer than ID (all when omitted)
+//   - POST   /admin/announcements                   push an announcement (AnnouncementRequest)
+//   - GET    /admin/incidents?since=ID              cancelled hands newer than ID (all when omitted)
+//   - GET    /admin/rng                             card position statistics and recent RNG self-tests
+//   - POST   /admin/rng/selftest?samples=N          run an RNG self-test now
+//   - GET    /admin/latency                         action response times per table and per player
+//   - GET    /admin/bandwidth                       bytes sent to clients per message type
+//   - GET    /admin/memory                          what each table holds in memory (MemoryReport)
+//   - POST   /admin/tables/{id}/freeze              freeze a table after its current hand (FreezeRequest)
+//   - POST   /admin/tables/{id}/resume              lift a freeze
+//   - POST   /admin/tables/{id}/dissolve            close a frozen table, cashing everyone out
+//   - POST   /admin/tables/{id}/close               stop new players joining and close the table once empty
+//   - DELETE /admin/tables/{id}/close               let players join a closing table again
+//   - POST   /admin/tables/{id}/deal                deal the next hand now, skipping the countdown
+//   - POST   /admin/tables/{id}/blinds              change the blinds (BlindLevel), from the next hand if one is running
+//
+// Every request must carry "Authorization: Bearer <adminToken>"; without a configured
+// token the API answers 404 as if it did not exist
+func (s *Server) adminRoutes() http.Handler {
+	r := chi.NewRouter()
+	r.Use(s.adminAuthMiddleware)
+
+	r.Get("/bans", s.handleListBans)
+	r.Post("/bans", s.handleAddBan)
+	r.Delete("/bans", s.handleRemoveBan)
+	r.Get("/alerts", s.handleListAlerts)
+	r.Get("/currencies", s.handleListCurrencies)
+	r.Post("/balances", s.handleAdjustBalance)
+	r.Get("/accounts/{name}/inventory", s.handleListInventory)
+	r.Post("/accounts/{name}/inventory", s.handleGrantItem)
+	r.Delete("/accounts/{name}/inventory/{itemID}", s.handleConsumeItem)
+	r.Get("/accounts/{name}/sessions", s.handleListSessions)
+	r.Get("/accounts/{name}/statement", s.handleRakeStatement)
+	r.Get("/accounts/{name}/loyalty", s.handleLoyaltyPoints)
+	r.Post("/accounts/{name}/loyalty/redeem", s.handleRedeemLoyalty)
+	r.Post("/accounts/{name}/kick", s.handleKickPlayer)
+	r.Get("/accounts/{name}/export", s.handleExportAccount)
+	r.Delete("/accounts/{name}", s.handleDeleteAccount)
+	r.Get("/clubs", s.handleListClubs)
+	r.Get("/announcements", s.handleListAnnouncements)
+	r.Post("/announcements", s.handleAnnounce)
+	r.Get("/incidents", s.handleListIncidents)
+	r.Get("/rng", s.handleRNGStatus)
+	r.Post("/rng/selftest", s.handleRNGSelfTest)
+	r.Get("/latency", s.handleLatency)
+	r.Get("/bandwidth", s.handleBandwidth)
+	r.Get("/memory", s.handleMemory)
+	r.Post("/tables/{tableID}/freeze", s.handleFreezeTable)
+	r.Post("/tables/{tableID}/resume", s.handleResumeTable)
+	r.Post("/tables/{tableID}/dissolve", s.handleDissolveTable)
+	r.Post("/tables/{tableID}/close", s.handleCloseTable)
+	r.Delete("/tables/{tableID}/close", s.handleReopenClosingTable)
+	r.Post("/tables/{tableID}/deal", s.handleDealNow)
+	r.Post("/tables/{tableID}/blinds", s.handleSetBlinds)
+
+	return r
+}
+
+// adminAuthMiddleware checks the bearer token against Config.AdminToken
+// The token is read per request so config reloads apply immediately
+func (s *Server) adminAuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		adminToken := s.Config().AdminToken
+		if adminToken == "" {
+			http.NotFound(w, r)
+			return
+		}
+
+		provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(adminToken)) != 1 {
+			s.logger.Warn("admin request rejected", "path", r.URL.Path, "client_ip", ClientIP(r))
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleListBans writes the active bans
+func (s *Server) handleListBans(w http.ResponseWriter, r *http.Request) {
+	writeAdminJSON(w, http.StatusOK, s.bans.List(time.Now()))
+}
+
+// handleAddBan stores a ban and disconnects everyone it covers
+func (s *Server) handleAddBan(w http.ResponseWriter, r *http.Request) {
+	var req BanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid ban request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ban := Ban{Kind: req.Kind, Value: req.Value, Reason: req.Reason, CreatedAt: time.Now()}
+	if req.Duration != "" {
+		duration, err := time.ParseDuration(req.Duration)
+		if err != nil || duration <= 0 {
+			http.Error(w, "invalid ban duration", http.StatusBadRequest)
+			return
+		}
+		expiresAt := ban.CreatedAt.Add(duration)
+		ban.ExpiresAt = &expiresAt
+	}
+
+	ban, err := s.bans.Add(ban)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.logger.Info("ban added", "kind", ban.Kind, "value", ban.Value, "reason", ban.Reason, "expires_at", ban.ExpiresAt, "client_ip", ClientIP(r))
+	s.disconnectBanned(ban)
+	writeAdminJSON(w, http.StatusCreated, ban)
+}
+
+// handleRemoveBan lifts the ban named by the kind and value query parameters
+func (s *Server) handleRemoveBan(w http.ResponseWriter, r *http.Request) {
+	kind := r.URL.Query().Get("kind")
+	value := r.URL.Query().Get("value")
+
+	if err := s.bans.Remove(kind, value); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	s.logger.Info("ban removed", "kind", kind, "value", value, "client_ip", ClientIP(r))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleListAlerts writes the fraud alerts raised after the since query parameter
+func (s *Server) handleListAlerts(w http.ResponseWriter, r *http.Request) {
+	since := 0
+	if param := r.URL.Query().Get("since"); param != "" {
+		parsed, err := strconv.Atoi(param)
+		if err != nil {
+			http.Error(w, "invalid since", http.StatusBadRequest)
+			return
+		}
+		since = parsed
+	}
+
+	writeAdminJSON(w, http.StatusOK, s.fraud.Alerts(since))
+}
+
+// handleListCurrencies writes the accounting summary of each chip currency
+func (s *Server) handleListCurrencies(w http.ResponseWriter, r *http.Request) {
+	writeAdminJSON(w, http.StatusOK, s.currencySummaries())
+}
+
+// handleAdjustBalance credits or debits the balance of the player named in the request
+func (s *Server) handleAdjustBalance(w http.ResponseWriter, r *http.Request) {
+	var req BalanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid balance request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Currency == "" || validateCurrency(req.Currency) != nil {
+		http.Error(w, "invalid currency", http.StatusBadRequest)
+		return
+	}
+	if !s.Config().Bankroll.Enabled {
+		http.Error(w, "bankroll accounting is disabled", http.StatusConflict)
+		return
+	}
+
+	tokens := s.sessionManager.TokensByName(req.Player)
+	switch {
+	case len(tokens) == 0:
+		http.Error(w, "player not found", http.StatusNotFound)
+		return
+	case len(tokens) > 1:
+		http.Error(w, "player name is ambiguous", http.StatusConflict)
+		return
+	}
+
+	balance, err := s.sessionManager.AdjustBalance(tokens[0], req.Currency, req.Amount)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	s.logger.Info("balance adjusted", "player", req.Player, "currency", req.Currency, "amount", req.Amount, "balance", balance, "client_ip", ClientIP(r))
+	s.sendBalances(tokens[0])
+	writeAdminJSON(w, http.StatusOK, BalanceResponse{Player: req.Player, Currency: req.Currency, Balance: balance})
+}
+
+// handleKickPlayer removes the player named in the path from their table, banning them if asked
+func (s *Server) handleKickPlayer(w http.ResponseWriter, r *http.Request) {
+	var req KickRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid kick request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	var expiresAt *time.Time
+	if req.Duration != "" {
+		duration, err := time.ParseDuration(req.Duration)
+		if err != nil || duration <= 0 {
+			http.Error(w, "invalid ban duration", http.StatusBadRequest)
+			return
+		}
+		expires := time.Now().Add(duration)
+		expiresAt = &expires
+	}
+
+	name := chi.URLParam(r, "name")
+	result, err := s.KickPlayer(name, req, expiresAt)
+	switch {
+	case errors.Is(err, errPlayerNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case err != nil && result.TableID == "":
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		// Unseated, but a ban could not be stored
+		s.logger.Warn("kicked player not banned", "player", name, "error", err, "client_ip", ClientIP(r))
+		http.Error(w, "player kicked but not banned: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.logger.Info("admin kicked player", "player", name, "tableID", result.TableID, "seatIndex", result.SeatIndex, "cashedOut", result.CashedOut, "reason", req.Reason, "bans", len(result.Bans), "client_ip", ClientIP(r))
+	writeAdminJSON(w, http.StatusOK, result)
+}
+
+// handleListInventory writes the unexpired items held by the account in the path
+func (s *Server) handleListInventory(w http.ResponseWriter, r *http.Request) {
+	writeAdminJSON(w, http.StatusOK, s.accounts.Inventory(chi.URLParam(r, "name"), time.Now()))
+}
+
+// handleGrantItem adds an item to the account in the path, creating the account if needed
+func (s *Server) handleGrantItem(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	var req GrantItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid grant request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	item := InventoryItem{Kind: req.Kind, Reference: req.Reference, Value: req.Value, Currency: req.Currency, Source: "admin", GrantedAt: time.Now()}
+	if req.Duration != "" {
+		duration, err := time.ParseDuration(req.Duration)
+		if err != nil || duration <= 0 {
+			http.Error(w, "invalid item duration", http.StatusBadRequest)
+			return
+		}
+		expiresAt := item.GrantedAt.Add(duration)
+		item.ExpiresAt = &expiresAt
+	}
+
+	item, err := s.accounts.GrantItem(name, item)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.logger.Info("inventory item granted", "account", name, "id", item.ID, "kind", item.Kind, "reference", item.Reference, "client_ip", ClientIP(r))
+	s.sendInventory(name)
+	writeAdminJSON(w, http.StatusCreated, item)
+}
+
+// handleConsumeItem removes the item in the path from the account in the path
+func (s *Server) handleConsumeItem(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	item, err := s.accounts.ConsumeItem(name, chi.URLParam(r, "itemID"), time.Now())
+	if errors.Is(err, errItemNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.logger.Info("inventory item consumed", "account", name, "id", item.ID, "kind", item.Kind, "client_ip", ClientIP(r))
+	s.sendInventory(name)
+	writeAdminJSON(w, http.StatusOK, item)
+}
+
+// handleListAnnouncements writes the announcements sent after the since query parameter
+func (s *Server) handleListAnnouncements(w http.ResponseWriter, r *http.Request) {
+	since := 0
+	if param := r.URL.Query().Get("since"); param != "" {
+		parsed, err := strconv.Atoi(param)
+		if err != nil {
+			http.Error(w, "invalid since", http.StatusBadRequest)
+			return
+		}
+		since = parsed
+	}
+
+	writeAdminJSON(w, http.StatusOK, s.announcements.List(since))
+}
+
+// handleAnnounce pushes an announcement to everyone or to the players at one table
+func (s *Server) handleAnnounce(w http.ResponseWriter, r *http.Request) {
+	var req AnnouncementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid announcement request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	announcement, _, err := s.Announce(Announcement{Message: req.Message, Level: req.Level, TableID: req.TableID, Source: "admin"})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.logger.Info("admin announcement", "id", announcement.ID, "client_ip", ClientIP(r))
+	writeAdminJSON(w, http.StatusCreated, announcement)
+}
+
+// handleListIncidents writes the hand cancellations recorded after the since query parameter
+func (s *Server) handleListIncidents(w http.ResponseWriter, r *http.Request) {
+	since := 0
+	if param := r.URL.Query().Get("since"); param != "" {
+		parsed, err := strconv.Atoi(param)
+		if err != nil {
+			http.Error(w, "invalid since", http.StatusBadRequest)
+			return
+		}
+		since = parsed
+	}
+
+	writeAdminJSON(w, http.StatusOK, s.incidents.List(since))
+}
+
+// adminTableError writes the response for an error from FreezeTable, ResumeTable, DissolveTable,
+// CloseTable, ReopenClosingTable, DealNow or SetBlinds
+func adminTableError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errTableNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusConflict)
+}
+
+// handleFreezeTable freezes the table in the path and writes its snapshot
+func (s *Server) handleFreezeTable(w http.ResponseWriter, r *http.Request) {
+	var req FreezeRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid freeze request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	tableID := chi.URLParam(r, "tableID")
+	if err := s.FreezeTable(tableID, req.Reason); err != nil {
+		adminTableError(w, err)
+		return
+	}
+	s.logger.Info("admin froze table", "tableID", tableID, "client_ip", ClientIP(r))
+	s.writeTableSnapshot(w, tableID)
+}
+
+// handleResumeTable lifts the freeze on the table in the path and writes its snapshot
+func (s *Server) handleResumeTable(w http.ResponseWriter, r *http.Request) {
+	tableID := chi.URLParam(r, "tableID")
+	if err := s.ResumeTable(tableID); err != nil {
+		adminTableError(w, err)
+		return
+	}
+	s.logger.Info("admin resumed table", "tableID", tableID, "client_ip", ClientIP(r))
+	s.writeTableSnapshot(w, tableID)
+}
+
+// handleDissolveTable closes the frozen table in the path
+func (s *Server) handleDissolveTable(w http.ResponseWriter, r *http.Request) {
+	tableID := chi.URLParam(r, "tableID")
+	result, err := s.DissolveTable(tableID)
+	if err != nil {
+		adminTableError(w, err)
+		return
+	}
+	s.logger.Info("admin dissolved table", "tableID", tableID, "client_ip", ClientIP(r))
+	writeAdminJSON(w, http.StatusOK, result)
+}
+
+// handleCloseTable soft-closes the table in the path
+func (s *Server) handleCloseTable(w http.ResponseWriter, r *http.Request) {
+	tableID := chi.URLParam(r, "tableID")
+	result, err := s.CloseTable(tableID)
+	if err != nil {
+		adminTableError(w, err)
+		return
+	}
+	s.logger.Info("admin closing table", "tableID", tableID, "players", result.Players, "client_ip", ClientIP(r))
+	writeAdminJSON(w, http.StatusOK, result)
+}
+
+// handleReopenClosingTable cancels the soft close of the table in the path and writes its snapshot
+func (s *Server) handleReopenClosingTable(w http.ResponseWriter, r *http.Request) {
+	tableID := chi.URLParam(r, "tableID")
+	if err := s.ReopenClosingTable(tableID); err != nil {
+		adminTableError(w, err)
+		return
+	}
+	s.logger.Info("admin cancelled table close", "tableID", tableID, "client_ip", ClientIP(r))
+	s.writeTableSnapshot(w, tableID)
+}
+
+// handleDealNow deals the next hand at the table in the path and writes its snapshot
+func (s *Server) handleDealNow(w http.ResponseWriter, r *http.Request) {
+	tableID := chi.URLParam(r, "tableID")
+	if err := s.DealNow(tableID); err != nil {
+		adminTableError(w, err)
+		return
+	}
+	s.logger.Info("admin dealt next hand", "tableID", tableID, "client_ip", ClientIP(r))
+	s.writeTableSnapshot(w, tableID)
+}
+
+// handleSetBlinds changes the blinds of the table in the path
+func (s *Server) handleSetBlinds(w http.ResponseWriter, r *http.Request) {
+	var req BlindLevel
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid blinds request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tableID := chi.URLParam(r, "tableID")
+	result, err := s.SetBlinds(tableID, req)
+	if errors.Is(err, errInvalidBlinds) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		adminTableError(w, err)
+		return
+	}
+	s.logger.Info("admin set blinds", "tableID", tableID, "smallBlind", req.SmallBlind, "bigBlind", req.BigBlind, "pending", result.Pending, "client_ip", ClientIP(r))
+	writeAdminJSON(w, http.StatusOK, result)
+}
+
+// writeTableSnapshot writes the snapshot of the table with tableID
+func (s *Server) writeTableSnapshot(w http.ResponseWriter, tableID string) {
+	table := s.tableByID(tableID)
+	if table == nil {
+		http.Error(w, errTableNotFound.Error(), http.StatusNotFound)
+		return
+	}
+	snapshot, err := s.SnapshotTable(table)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	writeAdminJSON(w, http.StatusOK, snapshot)
+}
+
+// writeAdminJSON writes v as a JSON response with status
+func writeAdminJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// adminRequest sends an admin API request with the given bearer token
+func adminRequest(server *Server, method, target, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	server.Router().ServeHTTP(rec, req)
+	return rec
+}
+
+// TestAdminAPI_DisabledWithoutToken verifies the admin API is hidden when no token is configured
+func TestAdminAPI_DisabledWithoutToken(t *testing.T) {
+	server := NewServer(slog.Default())
+
+	rec := adminRequest(server, http.MethodGet, "/admin/bans", "", "")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rec.Code)
+	}
+}
+
+// TestAdminAPI_RequiresToken verifies requests without the right bearer token are refused
+func TestAdminAPI_RequiresToken(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{AdminToken: "secret"})
+
+	for _, token := range []string{"", "wrong"} {
+		rec := adminRequest(server, http.MethodGet, "/admin/bans", token, "")
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("token %q: expected 401, got %d", token, rec.Code)
+		}
+	}
+}
+
+// TestAdminAPI_ManagesBans verifies bans can be added, listed and lifted
+func TestAdminAPI_ManagesBans(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{AdminToken: "secret"})
+
+	rec := adminRequest(server, http.MethodPost, "/admin/bans", "secret",
+		`{"kind":"account","value":"Mallory","reason":"collusion","duration":"24h"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var created Ban
+	json.Unmarshal(rec.Body.Bytes(), &created)
+	if created.Value != "mallory" || created.ExpiresAt == nil || created.ExpiresAt.Before(time.Now().Add(23*time.Hour)) {
+		t.Errorf("unexpected ban %+v", created)
+	}
+
+	rec = adminRequest(server, http.MethodPost, "/admin/bans", "secret", `{"kind":"ip","value":"bogus"}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid ban, got %d", rec.Code)
+	}
+
+	rec = adminRequest(server, http.MethodGet, "/admin/bans", "secret", "")
+	var bans []Ban
+	json.Unmarshal(rec.Body.Bytes(), &bans)
+	if len(bans) != 1 || bans[0].Reason != "collusion" {
+		t.Fatalf("expected the account ban to be listed, got %+v", bans)
+	}
+
+	// A banned account cannot create a session
+	client := &Client{hub: server.hub, send: make(chan []byte, 256)}
+	if err := client.HandleSetName(server.sessionManager, server, slog.Default(), []byte(`{"name":"MALLORY"}`)); err == nil {
+		t.Error("expected set_name to be refused for a banned account")
+	}
+
+	rec = adminRequest(server, http.MethodDelete, "/admin/bans?kind=account&value=Mallory", "secret", "")
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", rec.Code)
+	}
+	rec = adminRequest(server, http.MethodDelete, "/admin/bans?kind=account&value=Mallory", "secret", "")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for a missing ban, got %d", rec.Code)
+	}
+}
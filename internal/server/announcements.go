@@ -0,0 +1,157 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Announcement levels
+const (
+	AnnouncementInfo    = "info"
+	AnnouncementWarning = "warning"
+)
+
+// maxAnnouncements bounds the announcements kept in memory; the oldest are dropped first
+const maxAnnouncements = 200
+
+// maxAnnouncementLength caps the text of one announcement in characters
+const maxAnnouncementLength = 500
+
+// Announcement is a system message pushed to every connected client or to the players at one table
+type Announcement struct {
+	ID        int       `json:"id"`
+	Message   string    `json:"message"`
+	Level     string    `json:"level"`
+	TableID   string    `json:"tableId,omitempty"` // Empty for a server-wide announcement
+	Source    string    `json:"source"`            // Who sent it, e.g. "admin"
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// AnnouncementLog numbers announcements and keeps the most recent for operators
+type AnnouncementLog struct {
+	mu            sync.Mutex
+	announcements []Announcement
+	nextID        int
+}
+
+// NewAnnouncementLog creates an empty AnnouncementLog
+func NewAnnouncementLog() *AnnouncementLog {
+	return &AnnouncementLog{nextID: 1}
+}
+
+// record assigns the next ID to announcement and stores it
+func (l *AnnouncementLog) record(announcement Announcement) Announcement {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	announcement.ID = l.nextID
+	l.nextID++
+	l.announcements = append(l.announcements, announcement)
+	if len(l.announcements) > maxAnnouncements {
+		l.announcements = l.announcements[len(l.announcements)-maxAnnouncements:]
+	}
+	return announcement
+}
+
+// List returns the announcements with an ID greater than since, oldest first
+func (l *AnnouncementLog) List(since int) []Announcement {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	announcements := make([]Announcement, 0)
+	for _, announcement := range l.announcements {
+		if announcement.ID > since {
+			announcements = append(announcements, announcement)
+		}
+	}
+	return announcements
+}
+
+// Announce validates and records an announcement, then sends it as an "announcement" message to
+// every connected client, or only to the players seated at TableID when it is set. It returns the
+// recorded announcement and how many clients it was delivered to.
+func (s *Server) Announce(announcement Announcement) (Announcement, int, error) {
+	announcement.Message = strings.TrimSpace(announcement.Message)
+	if announcement.Message == "" {
+		return Announcement{}, 0, errors.New("announcement message is required")
+	}
+	if len([]rune(announcement.Message)) > maxAnnouncementLength {
+		return Announcement{}, 0, fmt.Errorf("announcement must be at most %d characters", maxAnnouncementLength)
+	}
+	switch announcement.Level {
+	case "":
+		announcement.Level = AnnouncementInfo
+	case AnnouncementInfo, AnnouncementWarning:
+	default:
+		return Announcement{}, 0, fmt.Errorf("level must be %q or %q", AnnouncementInfo, AnnouncementWarning)
+	}
+
+	var table *Table
+	if announcement.TableID != "" {
+		if table = s.tableByID(announcement.TableID); table == nil {
+			return Announcement{}, 0, fmt.Errorf("table not found: %s", announcement.TableID)
+		}
+	}
+
+	announcement.CreatedAt = time.Now()
+	announcement = s.announcements.record(announcement)
+
+	delivered := 0
+	if table != nil {
+		for _, token := range table.seatedTokens() {
+			if s.sendPrivate(token, "announcement", announcement) {
+				delivered++
+			}
+		}
+	} else {
+		delivered = s.sendToAll("announcement", announcement)
+	}
+
+	s.logger.Info("announcement sent", "id", announcement.ID, "level", announcement.Level, "tableID", announcement.TableID, "source", announcement.Source, "delivered", delivered)
+	return announcement, delivered, nil
+}
+
+// seatedTokens returns the session tokens of every occupied seat
+func (t *Table) seatedTokens() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var tokens []string
+	for _, seat := range t.Seats {
+		if seat.Token != nil {
+			tokens = append(tokens, *seat.Token)
+		}
+	}
+	return tokens
+}
+
+// sendToAll sends a message to every connected client, skipping any whose send buffer is full,
+// and returns how many it reached
+func (s *Server) sendToAll(msgType string, payload interface{}) int {
+	if s.hub == nil {
+		return 0
+	}
+	message, err := marshalMessage(msgType, payload)
+	if err != nil {
+		s.logger.Warn("failed to marshal message", "type", msgType, "error", err)
+		return 0
+	}
+
+	s.hub.mu.RLock()
+	defer s.hub.mu.RUnlock()
+
+	sent := 0
+	// The hub lock keeps every client.send open; never block while holding it
+	for client := range s.hub.clients {
+		select {
+		case client.send <- message:
+			sent++
+		default:
+			s.logger.Warn("client send channel full, dropping message", "type", msgType)
+		}
+	}
+	return sent
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestAnnounce_ServerWideAndTable verifies server-wide announcements reach every connection while
+// table announcements reach only the players seated there
+func TestAnnounce_ServerWideAndTable(t *testing.T) {
+	server := NewServer(slog.Default())
+	seatTwoPlayers(server.tables[0])
+	seated := connectTestClient(server, "player1")
+	lobby := connectTestClient(server, "lobby-player")
+	anonymous := connectTestClient(server, "")
+
+	announcement, delivered, err := server.Announce(Announcement{Message: "  Maintenance at 02:00 UTC ", Level: AnnouncementWarning})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delivered != 3 || announcement.ID != 1 || announcement.Message != "Maintenance at 02:00 UTC" {
+		t.Errorf("unexpected announcement %+v delivered to %d", announcement, delivered)
+	}
+	for _, client := range []*Client{seated, lobby, anonymous} {
+		if messages := drainRawMessages(client); len(messages) != 1 || !strings.Contains(messages[0], `"type":"announcement"`) {
+			t.Errorf("expected one announcement, got %v", messages)
+		}
+	}
+
+	announcement, delivered, err = server.Announce(Announcement{Message: "Final hand before the break", TableID: server.tables[0].ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delivered != 1 || announcement.Level != AnnouncementInfo {
+		t.Errorf("expected one delivery at info level, got %+v delivered to %d", announcement, delivered)
+	}
+	if messages := drainRawMessages(seated); len(messages) != 1 {
+		t.Errorf("expected the seated player to receive the table announcement, got %v", messages)
+	}
+	if messages := drainRawMessages(lobby); len(messages) != 0 {
+		t.Errorf("expected nothing in the lobby, got %v", messages)
+	}
+}
+
+// TestAnnounce_Validation verifies empty, overlong, unknown-level and unknown-table announcements are refused
+func TestAnnounce_Validation(t *testing.T) {
+	server := NewServer(slog.Default())
+
+	for _, announcement := range []Announcement{
+		{Message: "   "},
+		{Message: strings.Repeat("x", maxAnnouncementLength+1)},
+		{Message: "hello", Level: "shout"},
+		{Message: "hello", TableID: "no-such-table"},
+	} {
+		if _, _, err := server.Announce(announcement); err == nil {
+			t.Errorf("expected %+v to be rejected", announcement)
+		}
+	}
+	if recorded := server.announcements.List(0); len(recorded) != 0 {
+		t.Errorf("expected nothing recorded, got %+v", recorded)
+	}
+}
+
+// TestAdminAPI_Announcements verifies operators can push announcements and list them since an ID
+func TestAdminAPI_Announcements(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{AdminToken: "secret"})
+	client := connectTestClient(server, "")
+
+	for _, message := range []string{"first", "second"} {
+		rec := adminRequest(server, http.MethodPost, "/admin/announcements", "secret", `{"message":"`+message+`"}`)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+	}
+	if messages := drainRawMessages(client); len(messages) != 2 {
+		t.Errorf("expected 2 announcements delivered, got %v", messages)
+	}
+
+	if rec := adminRequest(server, http.MethodPost, "/admin/announcements", "secret", `{"message":""}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an empty message, got %d", rec.Code)
+	}
+
+	rec := adminRequest(server, http.MethodGet, "/admin/announcements?since=1", "secret", "")
+	var listed []Announcement
+	json.Unmarshal(rec.Body.Bytes(), &listed)
+	if len(listed) != 1 || listed[0].Message != "second" || listed[0].Source != "admin" {
+		t.Errorf("expected only the second announcement, got %s", rec.Body.String())
+	}
+	if rec := adminRequest(server, http.MethodGet, "/admin/announcements?since=x", "secret", ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a bad since, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// newAliasKey returns a random secret for tableAlias, so aliases cannot be linked back to
+// tokens and change with every restart
+func newAliasKey() []byte {
+	key := make([]byte, 32)
+	rand.Read(key)
+	return key
+}
+
+// tableAlias returns the name the player with token goes by at the table with tableID when it
+// is anonymous. The alias is the same for as long as the session plays there, but unrelated to
+// the player's aliases at other tables or in later sessions, so it cannot be tracked.
+func (s *Server) tableAlias(tableID, token string) string {
+	mac := hmac.New(sha256.New, s.aliasKey)
+	mac.Write([]byte(tableID))
+	mac.Write([]byte{0})
+	mac.Write([]byte(token))
+	return "Anon-" + hex.EncodeToString(mac.Sum(nil)[:3])
+}
+
+// tableAnonymous reports whether the table with tableID hides its players' names
+func (s *Server) tableAnonymous(tableID string) bool {
+	table := s.tableByID(tableID)
+	return table != nil && table.Anonymous
+}
+
+// displayName returns the name shown to others for the player with token at the table with
+// tableID: their alias at an anonymous table, otherwise their name
+// Accounting, statistics and logs always use the real name.
+func (s *Server) displayName(tableID, token string) (string, error) {
+	name, err := s.sessionManager.GetPlayerName(token)
+	if err != nil || !s.tableAnonymous(tableID) {
+		return name, err
+	}
+	return s.tableAlias(tableID, token), nil
+}
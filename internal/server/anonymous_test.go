@@ -0,0 +1,139 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestAnonymousTable verifies players at an anonymous table appear to others under aliases in
+// table_state and replays, while the server keeps their names
+func TestAnonymousTable(t *testing.T) {
+	tables := DefaultTables()
+	tables[0].Anonymous = true
+	server := NewServerWithConfig(slog.Default(), Config{Tables: tables})
+	table := server.tables[0]
+	clients := seatNamed(t, server, table, "Alice", "Bob")
+
+	var shown []string
+	for _, seat := range server.buildTableState(table, nil).Seats {
+		if seat.PlayerName != nil {
+			shown = append(shown, *seat.PlayerName)
+		}
+	}
+	if len(shown) != 2 || shown[0] == shown[1] {
+		t.Fatalf("expected two distinct names, got %v", shown)
+	}
+	for _, name := range shown {
+		if !strings.HasPrefix(name, "Anon-") {
+			t.Errorf("expected an alias, got %q", name)
+		}
+	}
+	if alias := server.tableAlias(server.tables[1].ID, clients[0].Token); alias == shown[0] {
+		t.Error("expected a different alias at another table")
+	}
+	if name, _ := server.sessionManager.GetPlayerName(clients[0].Token); name != "Alice" {
+		t.Errorf("expected the session to keep the real name, got %q", name)
+	}
+
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	handID := handIDOf(table)
+	playOutChecking(t, server, table)
+	replay := storedReplay(t, server, handID)
+	if !replay.dealtIn("Alice") || replay.dealtIn(shown[0]) {
+		t.Errorf("expected the replay to keep the names, got %v", replay.Players)
+	}
+	if public := replay.public(""); public.dealtIn("Alice") || !public.dealtIn(shown[0]) {
+		t.Errorf("expected the replay shown under aliases, got %v", public.Players)
+	}
+	for _, summary := range server.replays.History(table.ID) {
+		for _, winner := range summary.Winners {
+			if !strings.HasPrefix(winner.Name, "Anon-") {
+				t.Errorf("expected the history to show aliases, got %q", winner.Name)
+			}
+		}
+	}
+
+	lobby := server.tableInfo(table, table.clock().Now())
+	if !lobby.Anonymous {
+		t.Error("expected the table marked anonymous in the lobby")
+	}
+}
+
+// TestAnonymousTable_AccountData verifies a hand played at an anonymous table is exported,
+// anonymized on deletion and pruned by the real names of its players
+func TestAnonymousTable_AccountData(t *testing.T) {
+	tables := DefaultTables()
+	tables[0].Anonymous = true
+	server := NewServerWithConfig(slog.Default(), Config{Tables: tables, HandRetention: time.Hour})
+	defer server.Shutdown(t.Context())
+	table := server.tables[0]
+	clients := seatNamed(t, server, table, "Alice", "Bob")
+	if err := server.accounts.SetPrivacy("Bob", PrivacyPayload{LimitHistory: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	handID := handIDOf(table)
+	playOutChecking(t, server, table)
+	storedReplay(t, server, handID)
+	bobAlias := server.tableAlias(table.ID, clients[1].Token)
+
+	export, err := server.ExportAccount("Alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(export), int64(len(export)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hands []HandReplay
+	for _, file := range archive.File {
+		if file.Name != "hands.json" {
+			continue
+		}
+		r, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(r)
+		r.Close()
+		if err := json.Unmarshal(data, &hands); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(hands) != 1 || !hands[0].dealtIn("Alice") || !hands[0].dealtIn(bobAlias) || hands[0].dealtIn("Bob") {
+		t.Errorf("expected Alice's hand with Bob under an alias, got %+v", hands)
+	}
+
+	if err := server.sessionManager.RemoveSession(clients[0].Token); err != nil {
+		t.Fatal(err)
+	}
+	deletion, err := server.DeleteAccount("Alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deletion.Hands != 1 {
+		t.Errorf("expected Alice's hand anonymized, got %+v", deletion)
+	}
+	replay, _ := server.replays.Hand(handID)
+	if replay.dealtIn("Alice") || !replay.dealtIn(deletion.Pseudonym) {
+		t.Errorf("expected Alice replaced in the hand, got %v", replay.Players)
+	}
+	if public := replay.public(""); !public.dealtIn(bobAlias) || public.dealtIn(deletion.Pseudonym) {
+		t.Errorf("expected the hand still shown under aliases, got %v", public.Players)
+	}
+
+	server.pruneHandHistories(time.Now().Add(2 * time.Hour))
+	if _, ok := server.replays.Hand(handID); ok {
+		t.Error("expected the hand Bob limited deleted past the retention minimum")
+	}
+}
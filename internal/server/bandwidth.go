@@ -0,0 +1,202 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+// defaultCompressMinBytes is the smallest message compressed when BandwidthConfig.MinBytes is zero.
+// Below it the deflate block overhead eats most of the saving.
+const defaultCompressMinBytes = 256
+
+// BandwidthConfig tunes what the server sends to each connection, for deployments whose lobbies
+// list hundreds of tables
+type BandwidthConfig struct {
+	// Compress negotiates permessage-deflate with clients that offer it
+	Compress bool `yaml:"compress"`
+	// Level is the flate level, from 1 (fastest) to 9 (smallest) (0 = 1)
+	Level int `yaml:"level"`
+	// MinBytes is the smallest message compressed; smaller ones are sent as they are (0 = 256)
+	MinBytes int `yaml:"minBytes"`
+	// TrimLobbyAbove leaves each table's description, theme, tags and today's superlatives out
+	// of lobby_state once the lobby lists more tables than this; query_lobby and /api/lobby
+	// still return them. Zero never trims.
+	TrimLobbyAbove int `yaml:"trimLobbyAbove"`
+}
+
+// validate reports the first invalid bandwidth setting
+func (c BandwidthConfig) validate() error {
+	if c.Level != 0 && (c.Level < flate.BestSpeed || c.Level > flate.BestCompression) {
+		return fmt.Errorf("bandwidth.level must be between %d and %d", flate.BestSpeed, flate.BestCompression)
+	}
+	if c.MinBytes < 0 {
+		return fmt.Errorf("bandwidth.minBytes must not be negative")
+	}
+	if c.TrimLobbyAbove < 0 {
+		return fmt.Errorf("bandwidth.trimLobbyAbove must not be negative")
+	}
+	return nil
+}
+
+// level returns the configured compression level, or the fastest
+func (c BandwidthConfig) level() int {
+	if c.Level == 0 {
+		return flate.BestSpeed
+	}
+	return c.Level
+}
+
+// minBytes returns the configured compression threshold, or the default
+func (c BandwidthConfig) minBytes() int {
+	if c.MinBytes == 0 {
+		return defaultCompressMinBytes
+	}
+	return c.MinBytes
+}
+
+// lobbyState returns the lobby listing sent as lobby_state, trimmed as
+// BandwidthConfig.TrimLobbyAbove describes
+func (s *Server) lobbyState() []TableInfo {
+	tables := s.GetLobbyState()
+	if limit := s.Config().Bandwidth.TrimLobbyAbove; limit > 0 && len(tables) > limit {
+		for i := range tables {
+			tables[i].Description = ""
+			tables[i].Theme = ""
+			tables[i].Tags = nil
+			tables[i].Today = nil
+		}
+	}
+	return tables
+}
+
+// MessageBandwidth counts the messages of one type sent to clients
+type MessageBandwidth struct {
+	Messages  int64 `json:"messages"`
+	Bytes     int64 `json:"bytes"`     // JSON as marshalled, before compression
+	WireBytes int64 `json:"wireBytes"` // Written to the network: frame headers included, after compression
+}
+
+// add counts other in b
+func (b *MessageBandwidth) add(other MessageBandwidth) {
+	b.Messages += other.Messages
+	b.Bytes += other.Bytes
+	b.WireBytes += other.WireBytes
+}
+
+// BandwidthReport is the response of GET /admin/bandwidth and /debug/bandwidth
+type BandwidthReport struct {
+	Total MessageBandwidth            `json:"total"`
+	Types map[string]MessageBandwidth `json:"types"` // By message type
+	// CompressionRatio is Total.WireBytes over Total.Bytes; a little above 1 when nothing is
+	// compressed, because of frame headers (0 = nothing sent yet)
+	CompressionRatio float64 `json:"compressionRatio"`
+}
+
+// BandwidthMeter counts what the server sends to clients, by message type, since it started
+type BandwidthMeter struct {
+	mu    sync.Mutex
+	types map[string]*MessageBandwidth
+}
+
+// NewBandwidthMeter returns an empty BandwidthMeter
+func NewBandwidthMeter() *BandwidthMeter {
+	return &BandwidthMeter{types: make(map[string]*MessageBandwidth)}
+}
+
+// Record counts message, which took wireBytes on the network
+func (m *BandwidthMeter) Record(message []byte, wireBytes int64) {
+	if m == nil {
+		return
+	}
+	msgType := messageType(message)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	counts, ok := m.types[msgType]
+	if !ok {
+		counts = &MessageBandwidth{}
+		m.types[msgType] = counts
+	}
+	counts.add(MessageBandwidth{Messages: 1, Bytes: int64(len(message)), WireBytes: wireBytes})
+}
+
+// Report returns the counts so far
+func (m *BandwidthMeter) Report() BandwidthReport {
+	report := BandwidthReport{Types: map[string]MessageBandwidth{}}
+	if m == nil {
+		return report
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for msgType, counts := range m.types {
+		report.Types[msgType] = *counts
+		report.Total.add(*counts)
+	}
+	if report.Total.Bytes > 0 {
+		report.CompressionRatio = float64(report.Total.WireBytes) / float64(report.Total.Bytes)
+	}
+	return report
+}
+
+// messageType returns the type of a message encoded by marshalMessage, without decoding it
+func messageType(message []byte) string {
+	rest, ok := bytes.CutPrefix(message, []byte(`{"type":"`))
+	if !ok {
+		return "unknown"
+	}
+	end := bytes.IndexByte(rest, '"')
+	if end < 0 {
+		return "unknown"
+	}
+	return string(rest[:end])
+}
+
+// handleBandwidth writes what the server has sent to clients, by message type
+func (s *Server) handleBandwidth(w http.ResponseWriter, r *http.Request) {
+	writeAdminJSON(w, http.StatusOK, s.bandwidth.Report())
+}
+
+// wireConn counts the bytes written to a WebSocket connection, frames after compression
+type wireConn struct {
+	net.Conn
+	written atomic.Int64
+}
+
+func (c *wireConn) Write(p []byte) (int, error) {
+	n, err := c.Conn.Write(p)
+	c.written.Add(int64(n))
+	return n, err
+}
+
+// Written returns the bytes written so far; zero for a nil wireConn
+func (c *wireConn) Written() int64 {
+	if c == nil {
+		return 0
+	}
+	return c.written.Load()
+}
+
+// meteredResponseWriter hands the WebSocket upgrader a wireConn when it hijacks the connection
+type meteredResponseWriter struct {
+	http.ResponseWriter
+	conn *wireConn
+}
+
+func (w *meteredResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not implement http.Hijacker")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+	w.conn = &wireConn{Conn: conn}
+	return w.conn, rw, nil
+}
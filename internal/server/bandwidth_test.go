@@ -0,0 +1,91 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// TestBandwidth_CompressesNegotiatedConnections verifies large messages are deflated for clients
+// that offer compression, sent as they are to clients that do not, and metered either way
+func TestBandwidth_CompressesNegotiatedConnections(t *testing.T) {
+	for _, offer := range []bool{true, false} {
+		server := NewServerWithConfig(slog.Default(), Config{
+			Tables:    DefaultTables(),
+			Bandwidth: BandwidthConfig{Compress: true, MinBytes: 64},
+		})
+		go server.hub.Run()
+		testServer := httptest.NewServer(server.HandleWebSocket(server.hub))
+
+		dialer := websocket.Dialer{EnableCompression: offer}
+		ws, _, err := dialer.Dial("ws"+strings.TrimPrefix(testServer.URL, "http"), nil)
+		if err != nil {
+			t.Fatalf("failed to connect: %v", err)
+		}
+		sendMessage(t, ws, "set_name", SetNamePayload{Name: "Alice"})
+		for _, want := range []string{"session_created", "lobby_state"} {
+			if msg := readMessage(t, ws); msg.Type != want {
+				t.Fatalf("expected %s, got %s", want, msg.Type)
+			}
+		}
+		// The meter records a message once it is written, which the client may see first
+		eventually(func() bool { return server.bandwidth.Report().Total.Messages == 2 })
+		ws.Close()
+		testServer.Close()
+
+		report := server.bandwidth.Report()
+		lobby := report.Types["lobby_state"]
+		if lobby.Messages != 1 || lobby.Bytes == 0 {
+			t.Fatalf("compression offered=%v: expected lobby_state metered, got %+v", offer, report)
+		}
+		if compressed := lobby.WireBytes < lobby.Bytes; compressed != offer {
+			t.Errorf("compression offered=%v: lobby_state took %d bytes on the wire for %d bytes", offer, lobby.WireBytes, lobby.Bytes)
+		}
+		if report.Total.Messages != 2 || report.CompressionRatio == 0 {
+			t.Errorf("compression offered=%v: expected both messages in the total, got %+v", offer, report)
+		}
+	}
+}
+
+// TestBandwidth_TrimsLargeLobbies verifies lobby_state drops table details past the limit,
+// while lobby queries keep them
+func TestBandwidth_TrimsLargeLobbies(t *testing.T) {
+	tables := DefaultTables()
+	for i := range tables {
+		tables[i].Description = "Friendly game"
+		tables[i].Tags = []string{"beginners"}
+	}
+	server := NewServerWithConfig(slog.Default(), Config{Tables: tables, Bandwidth: BandwidthConfig{TrimLobbyAbove: len(tables)}})
+	if lobby := server.lobbyState(); lobby[0].Description == "" || len(lobby[0].Tags) == 0 {
+		t.Fatalf("expected a lobby at the limit untrimmed, got %+v", lobby[0])
+	}
+
+	next := server.Config()
+	next.Bandwidth.TrimLobbyAbove = len(tables) - 1
+	if err := server.ReloadConfig(next); err != nil {
+		t.Fatal(err)
+	}
+	for _, info := range server.lobbyState() {
+		if info.Description != "" || info.Tags != nil {
+			t.Errorf("expected table details trimmed, got %+v", info)
+		}
+	}
+	if queried, _ := server.QueryLobby(LobbyFilter{Tags: []string{"beginners"}}); len(queried) != len(tables) {
+		t.Errorf("expected lobby queries to keep tags, got %d tables", len(queried))
+	}
+}
+
+// TestAdminAPI_Bandwidth verifies the bandwidth report is served
+func TestAdminAPI_Bandwidth(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{AdminToken: "secret"})
+	server.bandwidth.Record([]byte(`{"type":"table_state","payload":{}}`), 20)
+
+	rec := adminRequest(server, http.MethodGet, "/admin/bandwidth", "secret", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"table_state":{"messages":1,"bytes":35,"wireBytes":20}`) {
+		t.Errorf("expected table_state in the report, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,412 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Ban kinds
+const (
+	BanKindIP      = "ip"      // Value is an IP address or CIDR range
+	BanKindAccount = "account" // Value is a player name, matched case-insensitively
+)
+
+// Ban blocks an IP range or an account (player name) from connecting
+type Ban struct {
+	Kind      string     `json:"kind"`
+	Value     string     `json:"value"`
+	Reason    string     `json:"reason,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"` // nil = permanent
+	Automatic bool       `json:"automatic,omitempty"` // Issued by abuse detection rather than an operator
+}
+
+// expired reports whether the ban no longer applies at now
+func (b Ban) expired(now time.Time) bool {
+	return b.ExpiresAt != nil && !now.Before(*b.ExpiresAt)
+}
+
+// key identifies the ban within its list; adding a ban with the same key replaces it
+func (b Ban) key() string {
+	return b.Kind + ":" + b.Value
+}
+
+// normalizeBan validates a ban and canonicalizes its value
+func normalizeBan(b Ban) (Ban, error) {
+	value := strings.TrimSpace(b.Value)
+	switch b.Kind {
+	case BanKindIP:
+		networks, err := parseTrustedProxies([]string{value})
+		if err != nil {
+			return Ban{}, fmt.Errorf("invalid ip ban: %w", err)
+		}
+		b.Value = networks[0].String()
+		// Single addresses read better without the /32 or /128 suffix
+		if ones, bits := networks[0].Mask.Size(); ones == bits {
+			b.Value = networks[0].IP.String()
+		}
+	case BanKindAccount:
+		if value == "" {
+			return Ban{}, errors.New("account ban requires a player name")
+		}
+		b.Value = strings.ToLower(value)
+	default:
+		return Ban{}, fmt.Errorf("ban kind must be %q or %q", BanKindIP, BanKindAccount)
+	}
+	return b, nil
+}
+
+// BanList holds the active bans and persists them to a JSON file
+// The zero path keeps bans in memory only
+type BanList struct {
+	mu   sync.RWMutex
+	bans map[string]Ban
+	path string
+}
+
+// LoadBanList reads the ban list stored at path; a missing file starts an empty list
+// An empty path returns an in-memory list
+func LoadBanList(path string) (*BanList, error) {
+	list := &BanList{
+		bans: make(map[string]Ban),
+		path: path,
+	}
+	if path == "" {
+		return list, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return list, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ban list: %w", err)
+	}
+
+	var bans []Ban
+	if err := json.Unmarshal(data, &bans); err != nil {
+		return nil, fmt.Errorf("failed to parse ban list %s: %w", path, err)
+	}
+	for _, ban := range bans {
+		ban, err := normalizeBan(ban)
+		if err != nil {
+			return nil, fmt.Errorf("invalid entry in ban list %s: %w", path, err)
+		}
+		list.bans[ban.key()] = ban
+	}
+	return list, nil
+}
+
+// Add validates and stores ban, replacing any ban on the same IP or account
+// Returns the stored (normalized) ban
+func (l *BanList) Add(ban Ban) (Ban, error) {
+	ban, err := normalizeBan(ban)
+	if err != nil {
+		return Ban{}, err
+	}
+	if ban.CreatedAt.IsZero() {
+		ban.CreatedAt = time.Now()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.bans[ban.key()] = ban
+	if err := l.saveLocked(); err != nil {
+		return Ban{}, err
+	}
+	return ban, nil
+}
+
+// Remove lifts the ban of kind on value
+func (l *BanList) Remove(kind, value string) error {
+	ban, err := normalizeBan(Ban{Kind: kind, Value: value})
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.bans[ban.key()]; !ok {
+		return fmt.Errorf("ban not found: %s %s", kind, value)
+	}
+	delete(l.bans, ban.key())
+	return l.saveLocked()
+}
+
+// List returns the bans in effect at now, oldest first
+func (l *BanList) List(now time.Time) []Ban {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	bans := make([]Ban, 0, len(l.bans))
+	for _, ban := range l.bans {
+		if !ban.expired(now) {
+			bans = append(bans, ban)
+		}
+	}
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].CreatedAt.Before(bans[j].CreatedAt)
+	})
+	return bans
+}
+
+// IPBan returns the ban covering ip at now, if any
+func (l *BanList) IPBan(ip string, now time.Time) (Ban, bool) {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return Ban{}, false
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for _, ban := range l.bans {
+		if ban.Kind != BanKindIP || ban.expired(now) {
+			continue
+		}
+		networks, err := parseTrustedProxies([]string{ban.Value})
+		if err == nil && networks[0].Contains(addr) {
+			return ban, true
+		}
+	}
+	return Ban{}, false
+}
+
+// AccountBan returns the ban on the player name at now, if any
+func (l *BanList) AccountBan(name string, now time.Time) (Ban, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	ban, ok := l.bans[BanKindAccount+":"+strings.ToLower(strings.TrimSpace(name))]
+	if !ok || ban.expired(now) {
+		return Ban{}, false
+	}
+	return ban, true
+}
+
+// saveLocked writes the unexpired bans to the list's file (caller must hold l.mu)
+// The file is replaced atomically so a crash never leaves a truncated list
+func (l *BanList) saveLocked() error {
+	if l.path == "" {
+		return nil
+	}
+
+	now := time.Now()
+	bans := make([]Ban, 0, len(l.bans))
+	for key, ban := range l.bans {
+		if ban.expired(now) {
+			delete(l.bans, key)
+			continue
+		}
+		bans = append(bans, ban)
+	}
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].CreatedAt.Before(bans[j].CreatedAt)
+	})
+
+	data, err := json.MarshalIndent(bans, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal ban list: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(l.path), ".bans-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to save ban list: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save ban list: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save ban list: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), l.path); err != nil {
+		return fmt.Errorf("failed to save ban list: %w", err)
+	}
+	return nil
+}
+
+// connLimiter counts open WebSocket connections per client IP
+type connLimiter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// newConnLimiter creates an empty connLimiter
+func newConnLimiter() *connLimiter {
+	return &connLimiter{counts: make(map[string]int)}
+}
+
+// acquire reserves a connection slot for ip; limit 0 means unlimited
+// Returns false when ip already has limit connections open
+func (l *connLimiter) acquire(ip string, limit int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if limit > 0 && l.counts[ip] >= limit {
+		return false
+	}
+	l.counts[ip]++
+	return true
+}
+
+// release frees a slot reserved by acquire
+func (l *connLimiter) release(ip string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.counts[ip]--
+	if l.counts[ip] <= 0 {
+		delete(l.counts, ip)
+	}
+}
+
+// AbuseConfig controls automatic temporary IP bans for clients that keep sending
+// malformed or unknown messages. MaxStrikes 0 disables the bans.
+type AbuseConfig struct {
+	MaxStrikes  int           `yaml:"maxStrikes"`  // Protocol violations tolerated per Window
+	Window      time.Duration `yaml:"window"`      // Period over which strikes are counted
+	BanDuration time.Duration `yaml:"banDuration"` // Length of the temporary ban
+}
+
+// validate reports negative abuse settings
+func (c AbuseConfig) validate() error {
+	if c.MaxStrikes < 0 || c.Window < 0 || c.BanDuration < 0 {
+		return errors.New("abuse settings must not be negative")
+	}
+	if c.MaxStrikes > 0 && (c.Window == 0 || c.BanDuration == 0) {
+		return errors.New("abuse.window and abuse.banDuration are required when abuse.maxStrikes is set")
+	}
+	return nil
+}
+
+// abuseTracker counts protocol violations per IP in fixed windows
+type abuseTracker struct {
+	mu      sync.Mutex
+	strikes map[string]*strikeWindow
+}
+
+// strikeWindow is the strike count of one IP since start
+type strikeWindow struct {
+	start time.Time
+	count int
+}
+
+// newAbuseTracker creates an empty abuseTracker
+func newAbuseTracker() *abuseTracker {
+	return &abuseTracker{strikes: make(map[string]*strikeWindow)}
+}
+
+// strike records a violation by ip at now and reports whether it exceeded cfg.MaxStrikes
+// The count resets once the IP crosses the limit so a lifted ban starts from zero
+func (a *abuseTracker) strike(ip string, now time.Time, cfg AbuseConfig) bool {
+	if cfg.MaxStrikes <= 0 {
+		return false
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Drop stale windows so the map does not grow with every IP ever seen
+	for addr, window := range a.strikes {
+		if now.Sub(window.start) >= cfg.Window {
+			delete(a.strikes, addr)
+		}
+	}
+
+	window, ok := a.strikes[ip]
+	if !ok {
+		window = &strikeWindow{start: now}
+		a.strikes[ip] = window
+	}
+	window.count++
+
+	if window.count > cfg.MaxStrikes {
+		delete(a.strikes, ip)
+		return true
+	}
+	return false
+}
+
+// recordProtocolAbuse counts a malformed message from c and bans its IP temporarily
+// once the configured limit is crossed. Returns true if the client was banned
+// and its connection should be dropped.
+func (s *Server) recordProtocolAbuse(c *Client, logger *slog.Logger) bool {
+	cfg := s.Config().Abuse
+	now := time.Now()
+	if c.RemoteIP == "" || !s.abuse.strike(c.RemoteIP, now, cfg) {
+		return false
+	}
+
+	expiresAt := now.Add(cfg.BanDuration)
+	ban, err := s.bans.Add(Ban{
+		Kind:      BanKindIP,
+		Value:     c.RemoteIP,
+		Reason:    "repeated protocol violations",
+		CreatedAt: now,
+		ExpiresAt: &expiresAt,
+		Automatic: true,
+	})
+	if err != nil {
+		logger.Error("failed to record automatic ban", "client_ip", c.RemoteIP, "error", err)
+		return false
+	}
+
+	logger.Warn("client banned for protocol abuse", "client_ip", c.RemoteIP, "until", expiresAt)
+	s.disconnectBanned(ban)
+	return true
+}
+
+// disconnectBanned drops every open connection covered by ban
+func (s *Server) disconnectBanned(ban Ban) {
+	var matcher *net.IPNet
+	if ban.Kind == BanKindIP {
+		if networks, err := parseTrustedProxies([]string{ban.Value}); err == nil {
+			matcher = networks[0]
+		}
+	}
+
+	message, err := marshalMessage("error", ErrorPayload{Message: "banned", Key: "error.banned"})
+	if err != nil {
+		return
+	}
+
+	s.hub.mu.RLock()
+	defer s.hub.mu.RUnlock()
+	for client := range s.hub.clients {
+		matched := false
+		switch ban.Kind {
+		case BanKindIP:
+			ip := net.ParseIP(client.RemoteIP)
+			matched = ip != nil && matcher != nil && matcher.Contains(ip)
+		case BanKindAccount:
+			if client.Token != "" {
+				name, err := s.sessionManager.GetPlayerName(client.Token)
+				matched = err == nil && strings.EqualFold(name, ban.Value)
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		// The hub lock keeps client.send open; never block while holding it
+		select {
+		case client.send <- message:
+		default:
+		}
+		client.closeAfterNotice()
+	}
+}
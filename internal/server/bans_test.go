@@ -0,0 +1,218 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// TestBanList_MatchesIPsAndAccounts verifies IP, CIDR and account bans apply and expire
+func TestBanList_MatchesIPsAndAccounts(t *testing.T) {
+	list, _ := LoadBanList("")
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+
+	if _, err := list.Add(Ban{Kind: BanKindIP, Value: "203.0.113.7"}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if _, err := list.Add(Ban{Kind: BanKindIP, Value: "198.51.100.0/24", ExpiresAt: &expiresAt}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if _, err := list.Add(Ban{Kind: BanKindAccount, Value: "Mallory"}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		banned bool
+		check  func() bool
+	}{
+		{"exact ip", true, func() bool { _, ok := list.IPBan("203.0.113.7", now); return ok }},
+		{"other ip", false, func() bool { _, ok := list.IPBan("203.0.113.8", now); return ok }},
+		{"ip in range", true, func() bool { _, ok := list.IPBan("198.51.100.42", now); return ok }},
+		{"range after expiry", false, func() bool { _, ok := list.IPBan("198.51.100.42", now.Add(2*time.Hour)); return ok }},
+		{"account any case", true, func() bool { _, ok := list.AccountBan("mallory", now); return ok }},
+		{"other account", false, func() bool { _, ok := list.AccountBan("Alice", now); return ok }},
+	}
+	for _, tt := range tests {
+		if got := tt.check(); got != tt.banned {
+			t.Errorf("%s: banned = %v, want %v", tt.name, got, tt.banned)
+		}
+	}
+
+	if got := len(list.List(now.Add(2 * time.Hour))); got != 2 {
+		t.Errorf("expected 2 bans after the range expired, got %d", got)
+	}
+}
+
+// TestBanList_RejectsInvalidBans verifies unknown kinds and bad addresses are refused
+func TestBanList_RejectsInvalidBans(t *testing.T) {
+	list, _ := LoadBanList("")
+	for _, ban := range []Ban{
+		{Kind: "email", Value: "a@example.com"},
+		{Kind: BanKindIP, Value: "not-an-ip"},
+		{Kind: BanKindAccount, Value: "  "},
+	} {
+		if _, err := list.Add(ban); err == nil {
+			t.Errorf("expected %+v to be rejected", ban)
+		}
+	}
+}
+
+// TestBanList_Persists verifies bans survive a reload and removals are saved
+func TestBanList_Persists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bans.json")
+
+	list, err := LoadBanList(path)
+	if err != nil {
+		t.Fatalf("LoadBanList failed: %v", err)
+	}
+	list.Add(Ban{Kind: BanKindIP, Value: "203.0.113.7", Reason: "spam"})
+	list.Add(Ban{Kind: BanKindAccount, Value: "Mallory"})
+
+	reloaded, err := LoadBanList(path)
+	if err != nil {
+		t.Fatalf("LoadBanList failed: %v", err)
+	}
+	bans := reloaded.List(time.Now())
+	if len(bans) != 2 || bans[0].Reason != "spam" {
+		t.Fatalf("expected both bans to be persisted, got %+v", bans)
+	}
+
+	if err := reloaded.Remove(BanKindAccount, "MALLORY"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if err := reloaded.Remove(BanKindAccount, "Mallory"); err == nil {
+		t.Error("expected removing a missing ban to fail")
+	}
+
+	final, _ := LoadBanList(path)
+	if got := len(final.List(time.Now())); got != 1 {
+		t.Errorf("expected 1 ban after removal, got %d", got)
+	}
+}
+
+// TestConnLimiter verifies the per-IP cap and slot release
+func TestConnLimiter(t *testing.T) {
+	limiter := newConnLimiter()
+
+	if !limiter.acquire("10.0.0.1", 2) || !limiter.acquire("10.0.0.1", 2) {
+		t.Fatal("expected two connections to be allowed")
+	}
+	if limiter.acquire("10.0.0.1", 2) {
+		t.Error("expected the third connection to be refused")
+	}
+	if !limiter.acquire("10.0.0.2", 2) {
+		t.Error("expected another IP to be unaffected")
+	}
+
+	limiter.release("10.0.0.1")
+	if !limiter.acquire("10.0.0.1", 2) {
+		t.Error("expected a released slot to be reusable")
+	}
+	if !limiter.acquire("10.0.0.1", 0) {
+		t.Error("expected limit 0 to be unlimited")
+	}
+}
+
+// TestAbuseTracker verifies strikes ban only past the limit within one window
+func TestAbuseTracker(t *testing.T) {
+	tracker := newAbuseTracker()
+	cfg := AbuseConfig{MaxStrikes: 2, Window: time.Minute, BanDuration: time.Hour}
+	now := time.Now()
+
+	if tracker.strike("10.0.0.1", now, cfg) || tracker.strike("10.0.0.1", now, cfg) {
+		t.Fatal("expected strikes up to the limit to be tolerated")
+	}
+	if tracker.strike("10.0.0.1", now.Add(2*time.Minute), cfg) {
+		t.Error("expected a new window to reset the count")
+	}
+	tracker.strike("10.0.0.1", now.Add(2*time.Minute), cfg)
+	if !tracker.strike("10.0.0.1", now.Add(2*time.Minute), cfg) {
+		t.Error("expected the strike past the limit to ban")
+	}
+
+	if tracker.strike("10.0.0.2", now, AbuseConfig{}) {
+		t.Error("expected the zero AbuseConfig to never ban")
+	}
+}
+
+// TestHandleWebSocket_RefusesBannedAndExcessConnections verifies bans and the per-IP cap are enforced at upgrade
+func TestHandleWebSocket_RefusesBannedAndExcessConnections(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{MaxConnectionsPerIP: 1})
+	testServer := httptest.NewServer(server.Router())
+	defer testServer.Close()
+	wsURL := "ws" + strings.TrimPrefix(testServer.URL, "http") + "/ws"
+
+	ws1, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	_, resp, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err == nil || resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 for the second connection, got %v (%v)", resp, err)
+	}
+
+	// Closing the first connection frees its slot
+	ws1.Close()
+	deadline := time.Now().Add(time.Second)
+	for {
+		ws, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+		if err == nil {
+			ws.Close()
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected slot to be released: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	server.bans.Add(Ban{Kind: BanKindIP, Value: "127.0.0.1"})
+	_, resp, err = websocket.DefaultDialer.Dial(wsURL, nil)
+	if err == nil || resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403 for a banned IP, got %v (%v)", resp, err)
+	}
+}
+
+// TestRecordProtocolAbuse_BansClientIP verifies repeated junk messages lead to a temporary IP ban
+func TestRecordProtocolAbuse_BansClientIP(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{
+		Abuse: AbuseConfig{MaxStrikes: 3, Window: time.Minute, BanDuration: time.Hour},
+	})
+	testServer := httptest.NewServer(server.Router())
+	defer testServer.Close()
+	wsURL := "ws" + strings.TrimPrefix(testServer.URL, "http") + "/ws"
+
+	ws, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer ws.Close()
+
+	for i := 0; i < 4; i++ {
+		ws.WriteMessage(websocket.TextMessage, []byte("not json"))
+	}
+
+	ws.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		if _, _, err := ws.ReadMessage(); err != nil {
+			break
+		}
+	}
+
+	ban, banned := server.bans.IPBan("127.0.0.1", time.Now())
+	if !banned {
+		t.Fatal("expected the client IP to be banned")
+	}
+	if !ban.Automatic || ban.ExpiresAt == nil {
+		t.Errorf("expected an automatic temporary ban, got %+v", ban)
+	}
+}
@@ -0,0 +1,70 @@
+package server
+
+// BoardTexture describes how coordinated the community cards are, for hints, bots and hand
+// analysis. A dry board such as K72 rainbow gives few draws; a wet one such as 9h8h7c gives many.
+type BoardTexture struct {
+	Paired        bool `json:"paired"`        // Two or more board cards share a rank
+	Monotone      bool `json:"monotone"`      // Every card is of one suit
+	TwoTone       bool `json:"twoTone"`       // Two cards share a suit and more cards are to come: a flush draw is possible
+	FlushPossible bool `json:"flushPossible"` // Three or more cards share a suit
+	Connected     bool `json:"connected"`     // Three ranks fit within a straight: a straight is possible
+	// Wetness scores the draws the board allows, from 0 (dry) to 6 (very wet): 3 each for a
+	// possible flush and straight, or 1 each while only a flush or straight draw is possible
+	Wetness int `json:"wetness"`
+}
+
+// ClassifyBoard returns the texture of a flop, turn or river board; an empty board has none
+func ClassifyBoard(board []Card) BoardTexture {
+	var texture BoardTexture
+	if len(board) == 0 {
+		return texture
+	}
+	toCome := len(board) < 5
+
+	maxSuited := 0
+	var suits [4]int
+	for _, card := range board {
+		suits[card.Suit()]++
+		maxSuited = max(maxSuited, suits[card.Suit()])
+	}
+	texture.Monotone = maxSuited == len(board)
+	texture.FlushPossible = maxSuited >= 3
+	texture.TwoTone = maxSuited == 2 && toCome
+
+	// Aces count both high and low, as they do in straights
+	var present [15]bool
+	for rank, count := range rankCounts(board) {
+		if count > 0 {
+			present[rank] = true
+		}
+		if count >= 2 {
+			texture.Paired = true
+		}
+	}
+	present[1] = present[14]
+	straightCards := 0
+	for low := 1; low <= 10; low++ {
+		inWindow := 0
+		for rank := low; rank < low+5; rank++ {
+			if present[rank] {
+				inWindow++
+			}
+		}
+		straightCards = max(straightCards, inWindow)
+	}
+	texture.Connected = straightCards >= 3
+
+	switch {
+	case texture.FlushPossible:
+		texture.Wetness += 3
+	case texture.TwoTone:
+		texture.Wetness++
+	}
+	switch {
+	case texture.Connected:
+		texture.Wetness += 3
+	case straightCards == 2 && toCome:
+		texture.Wetness++
+	}
+	return texture
+}
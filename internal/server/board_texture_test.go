@@ -0,0 +1,62 @@
+package server
+
+import (
+	"testing"
+)
+
+// TestClassifyBoard verifies the texture of dry, wet, paired and complete boards
+func TestClassifyBoard(t *testing.T) {
+	tests := []struct {
+		name  string
+		board []Card
+		want  BoardTexture
+	}{
+		{
+			name:  "dry rainbow flop",
+			board: []Card{NewCard("K", "s"), NewCard("7", "d"), NewCard("2", "c")},
+			want:  BoardTexture{},
+		},
+		{
+			name:  "connected two-tone flop",
+			board: []Card{NewCard("9", "h"), NewCard("8", "h"), NewCard("7", "c")},
+			want:  BoardTexture{TwoTone: true, Connected: true, Wetness: 4},
+		},
+		{
+			name:  "monotone flop with a wheel draw",
+			board: []Card{NewCard("A", "h"), NewCard("7", "h"), NewCard("2", "h")},
+			want:  BoardTexture{Monotone: true, FlushPossible: true, Wetness: 4},
+		},
+		{
+			name:  "wheel cards are connected",
+			board: []Card{NewCard("A", "s"), NewCard("2", "d"), NewCard("3", "c")},
+			want:  BoardTexture{Connected: true, Wetness: 3},
+		},
+		{
+			name:  "paired flop",
+			board: []Card{NewCard("7", "s"), NewCard("7", "d"), NewCard("2", "c")},
+			want:  BoardTexture{Paired: true},
+		},
+		{
+			name:  "river leaves no draws",
+			board: []Card{NewCard("K", "s"), NewCard("Q", "s"), NewCard("8", "d"), NewCard("4", "c"), NewCard("2", "h")},
+			want:  BoardTexture{},
+		},
+		{
+			name:  "flush on the turn",
+			board: []Card{NewCard("K", "c"), NewCard("9", "c"), NewCard("4", "c"), NewCard("4", "d")},
+			want:  BoardTexture{Paired: true, FlushPossible: true, Wetness: 4},
+		},
+		{
+			name: "no board",
+			want: BoardTexture{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyBoard(tt.board); got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
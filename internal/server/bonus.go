@@ -0,0 +1,84 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// BonusClaimedPayload represents the payload for bonus_claimed messages
+type BonusClaimedPayload struct {
+	Amount      int                  `json:"amount"`
+	Balances    map[ChipCurrency]int `json:"balances"`
+	NextClaimAt int64                `json:"nextClaimAt"` // Unix ms when the account may claim again
+}
+
+// BonusUnavailablePayload represents the payload for bonus_unavailable messages,
+// sent instead of an error so the client can show when to come back
+type BonusUnavailablePayload struct {
+	Reason      string `json:"reason"`                // bonus_cooldown or bonus_not_eligible
+	NextClaimAt int64  `json:"nextClaimAt,omitempty"` // Unix ms, for bonus_cooldown
+}
+
+// HandleClaimBonus processes a claim_bonus message: a player whose play chips, on and off the
+// tables, are below the threshold is granted the daily bonus once per cooldown
+// The cooldown is tracked per account (player name) in the account store, so neither a new
+// session nor a restart resets it
+func (c *Client) HandleClaimBonus(sm *SessionManager, server *Server, logger *slog.Logger) error {
+	session, err := sm.GetSession(c.Token)
+	if err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+
+	cfg := server.Config().Bankroll
+	if !cfg.Enabled || cfg.DailyBonus <= 0 {
+		return fmt.Errorf("bonus_disabled")
+	}
+
+	balances, err := sm.Balances(c.Token)
+	if err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+	if holdings := balances[CurrencyPlay] + server.playChipsAtTables(c.Token); cfg.BonusThreshold > 0 && holdings >= cfg.BonusThreshold {
+		return c.sendMessage("bonus_unavailable", BonusUnavailablePayload{Reason: "bonus_not_eligible"})
+	}
+
+	nextClaimAt, err := server.accounts.ClaimBonus(session.Name, time.Now(), cfg.BonusCooldown)
+	if errors.Is(err, errBonusCooldown) {
+		return c.sendMessage("bonus_unavailable", BonusUnavailablePayload{Reason: errBonusCooldown.Error(), NextClaimAt: nextClaimAt.UnixMilli()})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record bonus claim: %w", err)
+	}
+
+	if _, err := sm.AdjustBalance(c.Token, CurrencyPlay, cfg.DailyBonus); err != nil {
+		return fmt.Errorf("failed to credit bonus: %w", err)
+	}
+	balances, _ = sm.Balances(c.Token)
+
+	logger.Info("daily bonus claimed", "token", c.Token, "name", session.Name, "amount", cfg.DailyBonus, "nextClaimAt", nextClaimAt)
+	return c.sendMessage("bonus_claimed", BonusClaimedPayload{
+		Amount:      cfg.DailyBonus,
+		Balances:    balances,
+		NextClaimAt: nextClaimAt.UnixMilli(),
+	})
+}
+
+// playChipsAtTables returns the stack the player has at play-money tables
+func (s *Server) playChipsAtTables(token string) int {
+	s.mu.RLock()
+	tables := append([]*Table(nil), s.tables...)
+	s.mu.RUnlock()
+
+	chips := 0
+	for _, table := range tables {
+		if table.Currency != CurrencyPlay {
+			continue
+		}
+		if seat, found := table.GetSeatByToken(&token); found {
+			chips += seat.Stack
+		}
+	}
+	return chips
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// claimBonus sends claim_bonus for client and returns the type and payload of the reply
+func claimBonus(t *testing.T, server *Server, client *Client) (string, json.RawMessage) {
+	t.Helper()
+	if err := client.HandleClaimBonus(server.sessionManager, server, slog.Default()); err != nil {
+		t.Fatalf("claim_bonus: %v", err)
+	}
+	var msg WebSocketMessage
+	if err := json.Unmarshal(<-client.send, &msg); err != nil {
+		t.Fatal(err)
+	}
+	return msg.Type, msg.Payload
+}
+
+// TestClaimBonus_ThresholdAndCooldown verifies only short-stacked accounts are granted the bonus,
+// once per cooldown, and that stacks on play tables count towards the threshold
+func TestClaimBonus_ThresholdAndCooldown(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{
+		Bankroll: BankrollConfig{Enabled: true, DailyBonus: 2000, BonusThreshold: 1000, BonusCooldown: 24 * time.Hour},
+	})
+	sm := server.sessionManager
+	session, _ := sm.CreateSession("Alice")
+	client := &Client{hub: server.hub, Token: session.Token, send: make(chan []byte, 256)}
+
+	// A stack at a play table counts as holdings
+	table := server.tables[0]
+	table.mu.Lock()
+	table.Seats[0].Token = &session.Token
+	table.Seats[0].Status = "waiting"
+	table.Seats[0].Stack = 1500
+	table.mu.Unlock()
+	if msgType, payload := claimBonus(t, server, client); msgType != "bonus_unavailable" {
+		t.Fatalf("expected bonus_unavailable while holding a stack, got %s %s", msgType, payload)
+	}
+
+	table.mu.Lock()
+	table.Seats[0] = Seat{Index: 0, Status: "empty"}
+	table.mu.Unlock()
+	msgType, payload := claimBonus(t, server, client)
+	if msgType != "bonus_claimed" {
+		t.Fatalf("expected bonus_claimed, got %s %s", msgType, payload)
+	}
+	var claimed BonusClaimedPayload
+	json.Unmarshal(payload, &claimed)
+	if claimed.Amount != 2000 || claimed.Balances[CurrencyPlay] != 2000 {
+		t.Errorf("unexpected claim %+v", claimed)
+	}
+
+	// A fresh session under the same name is the same account and still on cooldown
+	sm.AdjustBalance(session.Token, CurrencyPlay, -2000)
+	again, _ := sm.CreateSession("alice")
+	other := &Client{hub: server.hub, Token: again.Token, send: make(chan []byte, 256)}
+	msgType, payload = claimBonus(t, server, other)
+	var unavailable BonusUnavailablePayload
+	json.Unmarshal(payload, &unavailable)
+	if msgType != "bonus_unavailable" || unavailable.Reason != "bonus_cooldown" || unavailable.NextClaimAt != claimed.NextClaimAt {
+		t.Errorf("expected bonus_cooldown until %d, got %s %+v", claimed.NextClaimAt, msgType, unavailable)
+	}
+}
+
+// TestClaimBonus_Disabled verifies claims are refused without a configured bonus
+func TestClaimBonus_Disabled(t *testing.T) {
+	server := NewServer(slog.Default())
+	session, _ := server.sessionManager.CreateSession("Alice")
+	client := &Client{hub: server.hub, Token: session.Token, send: make(chan []byte, 256)}
+
+	err := client.HandleClaimBonus(server.sessionManager, server, slog.Default())
+	if err == nil || err.Error() != "bonus_disabled" {
+		t.Errorf("expected bonus_disabled, got %v", err)
+	}
+}
+
+// TestAccountStore_PersistsBonusClaims verifies cooldowns survive reloading the store
+func TestAccountStore_PersistsBonusClaims(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.json")
+	store, err := LoadAccountStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	if _, err := store.ClaimBonus("Alice", now, time.Hour); err != nil {
+		t.Fatalf("first claim: %v", err)
+	}
+
+	reloaded, err := LoadAccountStore(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if _, err := reloaded.ClaimBonus(" alice ", now.Add(30*time.Minute), time.Hour); err != errBonusCooldown {
+		t.Errorf("expected cooldown after reload, got %v", err)
+	}
+	if _, err := reloaded.ClaimBonus("alice", now.Add(time.Hour), time.Hour); err != nil {
+		t.Errorf("expected claim after the cooldown, got %v", err)
+	}
+}
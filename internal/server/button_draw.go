@@ -0,0 +1,66 @@
+package server
+
+import "fmt"
+
+// ButtonDrawCard is the card one seat was dealt in a draw for the button
+type ButtonDrawCard struct {
+	SeatIndex int  `json:"seatIndex"`
+	Card      Card `json:"card"`
+}
+
+// ButtonDrawPayload represents the payload for button_draw messages, sent to a new table before
+// its first hand: each active seat's card, dealt face up, and the seat whose card won the button
+type ButtonDrawPayload struct {
+	Cards      []ButtonDrawCard `json:"cards"` // In seat order
+	DealerSeat int              `json:"dealerSeat"`
+	// SeedCommitment is the SHA-256 of the draw's shuffle seed, recorded in the RNG audit log
+	// like a hand's
+	SeedCommitment string `json:"seedCommitment"`
+}
+
+// buttonDrawRank orders cards in a draw for the button: by rank, ties broken by suit with
+// spades highest, then hearts, diamonds and clubs
+func buttonDrawRank(card Card) int {
+	return card.Rank()*4 + 3 - card.Suit()
+}
+
+// highCardButtonLocked reports whether the button of the table's first hand is drawn for
+// (internal, must be called with lock held)
+func (t *Table) highCardButtonLocked() bool {
+	return t.Server != nil && t.Server.Config().Features.HighCardButton
+}
+
+// buttonDrawDueLocked reports whether the next hand starts with a draw for the button
+// (internal, must be called with lock held)
+func (t *Table) buttonDrawDueLocked() bool {
+	return !t.DealerRotatedThisRound && t.DealerSeat == nil && t.highCardButtonLocked()
+}
+
+// drawForButtonLocked deals one card from a deck shuffled with seed to each active seat and
+// gives the button to the highest (internal, must be called with lock held)
+func (t *Table) drawForButtonLocked(seed ShuffleSeed) (*ButtonDrawPayload, error) {
+	deck := NewDeck()
+	ShuffleDeckWithSeed(deck, seed)
+	if t.Server != nil {
+		if err := t.Server.rngAudit.Record(t.ID, seed, deck); err != nil {
+			return nil, fmt.Errorf("failed to record shuffle: %w", err)
+		}
+	}
+
+	draw := &ButtonDrawPayload{SeedCommitment: seed.Commitment()}
+	best := -1
+	for i, seat := range t.Seats {
+		if seat.Status != "active" {
+			continue
+		}
+		card := deck[len(draw.Cards)]
+		draw.Cards = append(draw.Cards, ButtonDrawCard{SeatIndex: i, Card: card})
+		if rank := buttonDrawRank(card); rank > best {
+			best = rank
+			draw.DealerSeat = i
+		}
+	}
+	dealerSeat := draw.DealerSeat
+	t.DealerSeat = &dealerSeat
+	return draw, nil
+}
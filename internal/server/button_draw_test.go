@@ -0,0 +1,85 @@
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// TestButtonDrawRank verifies higher ranks win and equal ranks go to spades, hearts, diamonds, clubs
+func TestButtonDrawRank(t *testing.T) {
+	order := []string{"As", "Ah", "Ad", "Ac", "Ks", "2c"}
+	for i := 1; i < len(order); i++ {
+		higher, lower := NewCard(order[i-1][:1], order[i-1][1:]), NewCard(order[i][:1], order[i][1:])
+		if buttonDrawRank(higher) <= buttonDrawRank(lower) {
+			t.Errorf("expected %s to beat %s for the button", higher, lower)
+		}
+	}
+}
+
+// TestButtonDraw_FirstHand verifies a new table's first button goes to the highest card drawn,
+// shown to the table before the hand, and then moves on as usual
+func TestButtonDraw_FirstHand(t *testing.T) {
+	// Find a draw the first seat does not win, so the default could not pass for it
+	var seed ShuffleSeed
+	var want int
+	for i := range 256 {
+		seed = ShuffleSeed{byte(i)}
+		deck := NewDeck()
+		ShuffleDeckWithSeed(deck, seed)
+		want = 0
+		for seat := 1; seat < 3; seat++ {
+			if buttonDrawRank(deck[seat]) > buttonDrawRank(deck[want]) {
+				want = seat
+			}
+		}
+		if want != 0 {
+			break
+		}
+	}
+
+	server := NewServerWithConfig(slog.Default(), Config{
+		Tables:   DefaultTables(),
+		Features: FeatureFlags{HighCardButton: true},
+	})
+	table := server.tables[0]
+	table.shuffleSeeds = func() (ShuffleSeed, error) { return seed, nil }
+	clients := seatNamed(t, server, table, "Alice", "Bob", "Carol")
+	drainRawMessages(clients[0])
+
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	messages := drainRawMessages(clients[0])
+	draw, started := -1, -1
+	for i, message := range messages {
+		switch messageType([]byte(message)) {
+		case "button_draw":
+			draw = i
+		case "hand_started":
+			started = i
+		}
+	}
+	if draw < 0 || started < draw {
+		t.Fatalf("expected button_draw before hand_started, got %v", messages)
+	}
+	if !strings.Contains(messages[draw], fmt.Sprintf(`"dealerSeat":%d`, want)) || strings.Count(messages[draw], `"seatIndex"`) != 3 {
+		t.Errorf("expected seat %d to win a three-card draw, got %s", want, messages[draw])
+	}
+	if table.CurrentHand.DealerSeat != want {
+		t.Errorf("expected the hand dealt with seat %d on the button, got %d", want, table.CurrentHand.DealerSeat)
+	}
+
+	playOutChecking(t, server, table)
+	drainRawMessages(clients[0])
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if len(payloadsOf[ButtonDrawPayload](t, clients[0], "button_draw")) != 0 {
+		t.Error("expected no draw after the first hand")
+	}
+	if table.CurrentHand.DealerSeat == want {
+		t.Error("expected the button to move on after the first hand")
+	}
+}
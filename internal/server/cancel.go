@@ -0,0 +1,218 @@
+package server
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Reasons a hand is cancelled
+const (
+	CancelDeckInconsistent = "deck_inconsistent"   // Cards are missing, duplicated or unknown
+	CancelInvariantBroken  = "invariant_violation" // The chips committed to the hand no longer add up
+	CancelEngineError      = "engine_error"        // The hand could not be moved forward
+	CancelTableDissolved   = "table_dissolved"     // An admin dissolved the frozen table mid-hand
+)
+
+// maxIncidents bounds the incidents kept in memory; the oldest are dropped first
+const maxIncidents = 200
+
+// Incident records a hand the engine could not finish safely and cancelled, refunding the players
+type Incident struct {
+	ID         int         `json:"id"`
+	TableID    string      `json:"tableId"`
+	Reason     string      `json:"reason"` // CancelDeckInconsistent, CancelInvariantBroken or CancelEngineError
+	Error      string      `json:"error"`
+	Street     string      `json:"street"`
+	Refunds    map[int]int `json:"refunds"`              // Chips given back per seat
+	Unrefunded int         `json:"unrefunded,omitempty"` // Chips of players who had already left the table
+	CreatedAt  time.Time   `json:"createdAt"`
+}
+
+// IncidentLog numbers incidents and keeps the most recent for operators
+type IncidentLog struct {
+	mu        sync.Mutex
+	incidents []Incident
+	nextID    int
+}
+
+// NewIncidentLog creates an empty IncidentLog
+func NewIncidentLog() *IncidentLog {
+	return &IncidentLog{nextID: 1}
+}
+
+// record assigns the next ID to incident and stores it
+func (l *IncidentLog) record(incident Incident) Incident {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	incident.ID = l.nextID
+	l.nextID++
+	l.incidents = append(l.incidents, incident)
+	if len(l.incidents) > maxIncidents {
+		l.incidents = l.incidents[len(l.incidents)-maxIncidents:]
+	}
+	return incident
+}
+
+// List returns the incidents with an ID greater than since, oldest first
+func (l *IncidentLog) List(since int) []Incident {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	incidents := make([]Incident, 0)
+	for _, incident := range l.incidents {
+		if incident.ID > since {
+			incidents = append(incidents, incident)
+		}
+	}
+	return incidents
+}
+
+// HandCancelledPayload represents the payload for hand_cancelled messages
+type HandCancelledPayload struct {
+	Reason  string      `json:"reason"`
+	Refunds map[int]int `json:"refunds"` // Chips given back per seat
+}
+
+// checkHandInvariantsLocked reports the first way the current hand has become inconsistent: cards
+// duplicated or unknown, or a negative stack. The reason is empty when the hand is sound (internal, must be called with lock held)
+func (t *Table) checkHandInvariantsLocked() (string, error) {
+	hand := t.CurrentHand
+	if hand == nil {
+		return "", nil
+	}
+
+	var seen map[Card]bool
+	count := func(cards []Card) error {
+		for _, card := range cards {
+			if !card.Valid() {
+				return fmt.Errorf("unknown card %#02x", uint8(card))
+			}
+			if seen[card] {
+				return fmt.Errorf("card %s appears twice", card)
+			}
+			seen[card] = true
+		}
+		return nil
+	}
+	// The undealt deck and the dealt cards are each checked for repeats
+	seen = make(map[Card]bool, 52)
+	if err := count(hand.Deck); err != nil {
+		return CancelDeckInconsistent, err
+	}
+	seen = make(map[Card]bool, 52)
+	if err := count(hand.BoardCards); err != nil {
+		return CancelDeckInconsistent, err
+	}
+	for _, seat := range slices.Sorted(maps.Keys(hand.HoleCards)) {
+		if err := count(hand.HoleCards[seat]); err != nil {
+			return CancelDeckInconsistent, err
+		}
+	}
+
+	for i, seat := range t.Seats {
+		if seat.Stack < 0 {
+			return CancelInvariantBroken, fmt.Errorf("seat %d has a negative stack of %d", i, seat.Stack)
+		}
+	}
+	return "", nil
+}
+
+// untrackedChips returns the chips in the pot and bets beyond what the players are recorded as
+// having put in. Every action must leave it unchanged.
+func (h *Hand) untrackedChips() int {
+	committed := h.Pot
+	for _, bet := range h.PlayerBets {
+		committed += bet
+	}
+	for _, amount := range h.TotalContributions {
+		committed -= amount
+	}
+	return committed
+}
+
+// refundHandLocked gives every player still seated back what they put into hand. Chips of players
+// who already left are returned as unrefunded (internal, must be called with lock held)
+func (t *Table) refundHandLocked(hand *Hand) (map[int]int, int) {
+	refunds := make(map[int]int)
+	unrefunded := 0
+	for seat, amount := range hand.TotalContributions {
+		if amount <= 0 {
+			continue
+		}
+		if t.Seats[seat].Token == nil {
+			unrefunded += amount
+			continue
+		}
+		t.Seats[seat].Stack += amount
+		refunds[seat] = amount
+	}
+	return refunds, unrefunded
+}
+
+// CancelHand aborts the current hand after an unrecoverable error: every player gets back what they
+// put in, the incident is logged and recorded for operators, the table is told with a hand_cancelled
+// message, and the next hand is dealt as usual with the same button.
+// Does nothing if no hand is running. Must be called without the table lock held.
+func (t *Table) CancelHand(reason string, cause error) {
+	t.mu.Lock()
+	hand := t.CurrentHand
+	if hand == nil {
+		t.mu.Unlock()
+		return
+	}
+
+	refunds, unrefunded := t.refundHandLocked(hand)
+	t.stopActionClockLocked()
+	t.preActions = nil
+	t.CurrentHand = nil
+	t.phase = PhaseWaitingForPlayers
+	// The cancelled hand is replayed with the same button
+	t.DealerRotatedThisRound = true
+	t.endHandSpanLocked(cause)
+	t.publishEvent(Event{Type: EventHandCancelled, Street: hand.Street})
+	t.mu.Unlock()
+
+	if t.Server == nil {
+		return
+	}
+
+	incident := t.Server.incidents.record(Incident{
+		TableID:    t.ID,
+		Reason:     reason,
+		Error:      cause.Error(),
+		Street:     hand.Street,
+		Refunds:    maps.Clone(refunds),
+		Unrefunded: unrefunded,
+		CreatedAt:  time.Now(),
+	})
+	t.Server.logger.Error("hand cancelled", "tableID", t.ID, "incident", incident.ID, "reason", reason,
+		"street", hand.Street, "refunds", refunds, "unrefunded", unrefunded, "error", cause)
+
+	if err := t.Server.broadcastTableMessage(t, "hand_cancelled", HandCancelledPayload{Reason: reason, Refunds: refunds}); err != nil {
+		t.logWarn("failed to broadcast hand_cancelled", "error", err)
+	}
+	if err := t.Server.broadcastTableState(t.ID, nil); err != nil {
+		t.logWarn("failed to broadcast table_state after cancelling hand", "error", err)
+	}
+	t.ScheduleNextHand()
+}
+
+// cancelHandOnError cancels the current hand after err left it stuck, naming the broken invariant
+// when there is one. Must be called without the table lock held.
+func (t *Table) cancelHandOnError(err error) {
+	t.mu.RLock()
+	reason, invariantErr := t.checkHandInvariantsLocked()
+	t.mu.RUnlock()
+	switch {
+	case invariantErr == nil:
+		reason = CancelEngineError
+	case !strings.Contains(err.Error(), invariantErr.Error()):
+		err = fmt.Errorf("%w (%v)", err, invariantErr)
+	}
+	t.CancelHand(reason, err)
+}
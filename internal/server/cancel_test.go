@@ -0,0 +1,156 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// stacksBeforeHand returns every seat's stack plus what it has put into the current hand
+func stacksBeforeHand(table *Table) map[int]int {
+	table.mu.RLock()
+	defer table.mu.RUnlock()
+	stacks := make(map[int]int)
+	for i, seat := range table.Seats {
+		if seat.Token != nil {
+			stacks[i] = seat.Stack + table.CurrentHand.TotalContributions[i]
+		}
+	}
+	return stacks
+}
+
+// TestCancelHand_RefundsContributions verifies a cancelled hand gives every player back what they
+// put in and leaves the button where it was
+func TestCancelHand_RefundsContributions(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	want := stacksBeforeHand(table)
+	table.mu.RLock()
+	dealer := *table.DealerSeat
+	table.mu.RUnlock()
+
+	actCurrent(t, server, table, "call")
+	table.CancelHand(CancelEngineError, errors.New("boom"))
+
+	table.mu.RLock()
+	if table.CurrentHand != nil {
+		t.Error("expected no hand after cancelling")
+	}
+	for seat, stack := range want {
+		if table.Seats[seat].Stack != stack {
+			t.Errorf("seat %d: expected stack %d, got %d", seat, stack, table.Seats[seat].Stack)
+		}
+	}
+	table.mu.RUnlock()
+	if phase := table.Phase(); phase != PhaseWaitingForPlayers {
+		t.Errorf("expected phase %s, got %s", PhaseWaitingForPlayers, phase)
+	}
+
+	found := false
+	for _, msg := range drainRawMessages(clients[0]) {
+		if strings.Contains(msg, `"type":"hand_cancelled"`) && strings.Contains(msg, `"reason":"engine_error"`) {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected a hand_cancelled message")
+	}
+
+	incidents := server.incidents.List(0)
+	if len(incidents) != 1 || incidents[0].Reason != CancelEngineError || incidents[0].Error != "boom" {
+		t.Fatalf("expected one engine_error incident, got %+v", incidents)
+	}
+
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	table.mu.RLock()
+	defer table.mu.RUnlock()
+	if *table.DealerSeat != dealer {
+		t.Errorf("expected the cancelled hand to be replayed with dealer %d, got %d", dealer, *table.DealerSeat)
+	}
+}
+
+// TestCancelHand_DeckInconsistency verifies a board card clashing with a hole card cancels the hand
+func TestCancelHand_DeckInconsistency(t *testing.T) {
+	server, table, _ := preActionTable(t)
+	want := stacksBeforeHand(table)
+
+	// Every card left for the flop (burn card included) duplicates a hole card
+	table.mu.Lock()
+	duplicate := table.CurrentHand.HoleCards[0][0]
+	for i := 0; i < 4; i++ {
+		table.CurrentHand.Deck[i] = duplicate
+	}
+	table.mu.Unlock()
+
+	actCurrent(t, server, table, "call")
+	actCurrent(t, server, table, "call")
+	actCurrent(t, server, table, "check")
+
+	table.mu.RLock()
+	if table.CurrentHand != nil {
+		t.Error("expected the hand to be cancelled")
+	}
+	for seat, stack := range want {
+		if table.Seats[seat].Stack != stack {
+			t.Errorf("seat %d: expected stack %d, got %d", seat, stack, table.Seats[seat].Stack)
+		}
+	}
+	table.mu.RUnlock()
+
+	incidents := server.incidents.List(0)
+	if len(incidents) != 1 || incidents[0].Reason != CancelDeckInconsistent || incidents[0].Street != "flop" {
+		t.Fatalf("expected one deck_inconsistent incident on the flop, got %+v", incidents)
+	}
+	if incidents[0].Refunds[0] == 0 {
+		t.Errorf("expected seat 0 to be refunded, got %v", incidents[0].Refunds)
+	}
+}
+
+// TestCheckHandInvariants verifies duplicated and unknown cards are reported
+func TestCheckHandInvariants(t *testing.T) {
+	_, table, _ := preActionTable(t)
+
+	table.mu.Lock()
+	defer table.mu.Unlock()
+	if reason, err := table.checkHandInvariantsLocked(); err != nil {
+		t.Fatalf("expected a freshly dealt hand to be sound, got %s: %v", reason, err)
+	}
+
+	table.CurrentHand.HoleCards[1][0] = table.CurrentHand.HoleCards[0][0]
+	if reason, err := table.checkHandInvariantsLocked(); reason != CancelDeckInconsistent || err == nil {
+		t.Errorf("expected a duplicated hole card to be reported, got %q: %v", reason, err)
+	}
+
+	table.CurrentHand.HoleCards[1][0] = NewCard("X", "s")
+	if reason, err := table.checkHandInvariantsLocked(); reason != CancelDeckInconsistent || err == nil {
+		t.Errorf("expected an unknown card to be reported, got %q: %v", reason, err)
+	}
+}
+
+// TestAdminAPI_ListIncidents verifies cancelled hands are listed after the since parameter
+func TestAdminAPI_ListIncidents(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{AdminToken: "secret"})
+	server.incidents.record(Incident{TableID: "table-1", Reason: CancelEngineError})
+	server.incidents.record(Incident{TableID: "table-2", Reason: CancelInvariantBroken})
+
+	rec := adminRequest(server, http.MethodGet, "/admin/incidents?since=1", "secret", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var incidents []Incident
+	if err := json.Unmarshal(rec.Body.Bytes(), &incidents); err != nil {
+		t.Fatal(err)
+	}
+	if len(incidents) != 1 || incidents[0].TableID != "table-2" {
+		t.Errorf("expected only the incident on table-2, got %+v", incidents)
+	}
+
+	rec = adminRequest(server, http.MethodGet, "/admin/incidents?since=x", "secret", "")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid since, got %d", rec.Code)
+	}
+}
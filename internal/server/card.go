@@ -0,0 +1,98 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// cardRanks holds each rank letter at its numeric rank, 2 to 14 (ace high)
+const cardRanks = "..23456789TJQKA"
+
+// cardSuits holds each suit letter at its number: spades, hearts, diamonds, clubs
+const cardSuits = "shdc"
+
+// Card is a playing card packed into a byte: its numeric rank (2 to 14, ace high) times four
+// plus its suit (0 to 3). Cards compare with == and dealing or evaluating them does no string
+// work; the letters are only looked up for display and the JSON protocol, where a card is
+// still {"Rank": "A", "Suit": "s"}. The zero Card is no card.
+type Card uint8
+
+// NewCard returns the card with a rank letter (A, 2-9, T, J, Q or K) and a suit letter (s, h,
+// d or c), or the zero Card if either is unknown
+func NewCard(rank, suit string) Card {
+	if len(rank) != 1 || len(suit) != 1 {
+		return 0
+	}
+	r, s := strings.IndexByte(cardRanks, rank[0]), strings.IndexByte(cardSuits, suit[0])
+	if r < 2 || s < 0 {
+		return 0
+	}
+	return Card(r<<2 | s)
+}
+
+// Rank returns the card's numeric rank, from 2 to 14 with the ace high
+func (c Card) Rank() int {
+	return int(c >> 2)
+}
+
+// Suit returns the card's suit number: 0 spades, 1 hearts, 2 diamonds, 3 clubs
+func (c Card) Suit() int {
+	return int(c & 3)
+}
+
+// Valid reports whether c is one of the 52 cards
+func (c Card) Valid() bool {
+	return c.Rank() >= 2 && c.Rank() <= 14
+}
+
+// RankLetter returns the card's rank letter, like "A" or "7"; empty for an invalid card
+func (c Card) RankLetter() string {
+	if !c.Valid() {
+		return ""
+	}
+	return cardRanks[c.Rank() : c.Rank()+1]
+}
+
+// SuitLetter returns the card's suit letter: s, h, d or c; empty for an invalid card
+func (c Card) SuitLetter() string {
+	if !c.Valid() {
+		return ""
+	}
+	return cardSuits[c.Suit() : c.Suit()+1]
+}
+
+// String returns the 2-character string representation of a card (e.g., "As", "Kh")
+func (c Card) String() string {
+	return c.RankLetter() + c.SuitLetter()
+}
+
+// wireCard is a card as the JSON protocol writes it
+type wireCard struct {
+	Rank string
+	Suit string
+}
+
+// MarshalJSON writes the card as {"Rank": "A", "Suit": "s"}
+func (c Card) MarshalJSON() ([]byte, error) {
+	return []byte(`{"Rank":"` + c.RankLetter() + `","Suit":"` + c.SuitLetter() + `"}`), nil
+}
+
+// UnmarshalJSON reads a card written as {"Rank": "A", "Suit": "s"}; an empty rank and suit
+// read as the zero Card
+func (c *Card) UnmarshalJSON(data []byte) error {
+	var wire wireCard
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	if wire.Rank == "" && wire.Suit == "" {
+		*c = 0
+		return nil
+	}
+	card := NewCard(wire.Rank, wire.Suit)
+	if card == 0 {
+		return fmt.Errorf("unknown card %q", wire.Rank+wire.Suit)
+	}
+	*c = card
+	return nil
+}
@@ -12,7 +12,7 @@ func TestCardDistribution(t *testing.T) {
 	logger := slog.Default()
 	server := &Server{
 		logger: logger,
-		tables: [4]*Table{},
+		tables: make([]*Table, 4),
 	}
 	table := NewTable("test-table", "Test Table", server)
 
@@ -55,12 +55,12 @@ func TestCardDistribution(t *testing.T) {
 		// Deal 6 cards (2 to each of 3 players)
 		for j := 0; j < 6; j++ {
 			card := deck[j]
-			suitCounts[card.Suit]++
+			suitCounts[card.SuitLetter()]++
 			totalCards++
 
 			// Log first hand to see what's being dealt
 			if i == 0 {
-				t.Logf("Hand 0, Card %d: %s%s", j, card.Rank, card.Suit)
+				t.Logf("Hand 0, Card %d: %s%s", j, card.RankLetter(), card.SuitLetter())
 			}
 		}
 	}
@@ -98,7 +98,7 @@ func TestDealHoleCardsDistribution(t *testing.T) {
 	logger := slog.Default()
 	server := &Server{
 		logger: logger,
-		tables: [4]*Table{},
+		tables: make([]*Table, 4),
 	}
 	table := NewTable("test-table", "Test Table", server)
 
@@ -148,11 +148,11 @@ func TestDealHoleCardsDistribution(t *testing.T) {
 		// Count suits in dealt cards
 		for seatNum, cards := range hand.HoleCards {
 			for cardIdx, card := range cards {
-				suitCounts[card.Suit]++
+				suitCounts[card.SuitLetter()]++
 
 				// Log first hand
 				if i == 0 {
-					t.Logf("Hand 0, Seat %d, Card %d: %s%s", seatNum, cardIdx, card.Rank, card.Suit)
+					t.Logf("Hand 0, Seat %d, Card %d: %s%s", seatNum, cardIdx, card.RankLetter(), card.SuitLetter())
 				}
 			}
 		}
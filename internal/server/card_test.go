@@ -0,0 +1,50 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestNewCard verifies cards pack their rank and suit, and unknown letters give the zero Card
+func TestNewCard(t *testing.T) {
+	card := NewCard("A", "d")
+	if card.Rank() != 14 || card.Suit() != 2 || card.RankLetter() != "A" || card.SuitLetter() != "d" {
+		t.Errorf("expected the ace of diamonds, got rank %d suit %d (%s)", card.Rank(), card.Suit(), card)
+	}
+	if NewCard("2", "s").Rank() != 2 {
+		t.Error("expected deuces ranked 2")
+	}
+	for _, bad := range [][2]string{{"X", "s"}, {"A", "x"}, {".", "s"}, {"10", "h"}, {"", ""}} {
+		if card := NewCard(bad[0], bad[1]); card != 0 || card.Valid() {
+			t.Errorf("expected no card for %q, got %v", bad, card)
+		}
+	}
+
+	seen := make(map[Card]bool)
+	for _, card := range NewDeck() {
+		if !card.Valid() || seen[card] {
+			t.Errorf("expected 52 distinct valid cards, got %s twice or invalid", card)
+		}
+		seen[card] = true
+	}
+}
+
+// TestCard_JSON verifies cards keep their {"Rank", "Suit"} form on the wire
+func TestCard_JSON(t *testing.T) {
+	data, err := json.Marshal([]Card{NewCard("T", "c"), NewCard("K", "h")})
+	if err != nil || string(data) != `[{"Rank":"T","Suit":"c"},{"Rank":"K","Suit":"h"}]` {
+		t.Fatalf("unexpected encoding %s (%v)", data, err)
+	}
+
+	var cards []Card
+	if err := json.Unmarshal([]byte(`[{"Rank": "T", "Suit": "c"}, {"Suit": "h", "Rank": "K"}]`), &cards); err != nil {
+		t.Fatal(err)
+	}
+	if len(cards) != 2 || cards[0] != NewCard("T", "c") || cards[1] != NewCard("K", "h") {
+		t.Errorf("expected Tc Kh, got %v", cards)
+	}
+	var card Card
+	if err := json.Unmarshal([]byte(`{"Rank": "Z", "Suit": "s"}`), &card); err == nil {
+		t.Error("expected an unknown card refused")
+	}
+}
@@ -0,0 +1,47 @@
+package server
+
+// chipDenominations are the chip values clients draw, smallest first
+var chipDenominations = []int{1, 5, 25, 100, 500, 1000, 5000, 25000, 100000, 500000}
+
+// ChipCount is a pile of Count chips worth Denomination each
+type ChipCount struct {
+	Denomination int `json:"denomination"`
+	Count        int `json:"count"`
+}
+
+// ChipBreakdown splits amount into chips for a table whose small blind is smallBlind, largest
+// denominations first, so every client renders the same stacks. The smallest chip in play is the
+// largest denomination that divides the small blind; smaller chips only make up odd amounts that
+// it cannot cover. Returns nil for amounts of zero or less.
+func ChipBreakdown(amount, smallBlind int) []ChipCount {
+	if amount <= 0 {
+		return nil
+	}
+
+	smallest := 1
+	for _, denomination := range chipDenominations {
+		if smallBlind > 0 && smallBlind%denomination == 0 {
+			smallest = denomination
+		}
+	}
+
+	var breakdown []ChipCount
+	remaining := amount
+	take := func(denomination int) {
+		if count := remaining / denomination; count > 0 {
+			breakdown = append(breakdown, ChipCount{Denomination: denomination, Count: count})
+			remaining -= count * denomination
+		}
+	}
+	for i := len(chipDenominations) - 1; i >= 0; i-- {
+		if chipDenominations[i] >= smallest {
+			take(chipDenominations[i])
+		}
+	}
+	for i := len(chipDenominations) - 1; i >= 0 && remaining > 0; i-- {
+		if chipDenominations[i] < smallest {
+			take(chipDenominations[i])
+		}
+	}
+	return breakdown
+}
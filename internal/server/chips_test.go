@@ -0,0 +1,55 @@
+package server
+
+import (
+	"log/slog"
+	"reflect"
+	"testing"
+)
+
+// TestChipBreakdown verifies amounts split into the largest chips, with the smallest chip set by
+// the small blind and smaller chips only for odd amounts
+func TestChipBreakdown(t *testing.T) {
+	tests := []struct {
+		name       string
+		amount     int
+		smallBlind int
+		want       []ChipCount
+	}{
+		{"zero", 0, 10, nil},
+		{"negative", -5, 10, nil},
+		{"blinds", 30, 10, []ChipCount{{25, 1}, {5, 1}}},
+		{"mixed", 1280, 10, []ChipCount{{1000, 1}, {100, 2}, {25, 3}, {5, 1}}},
+		{"high stakes", 7500, 500, []ChipCount{{5000, 1}, {1000, 2}, {500, 1}}},
+		{"odd amount at high stakes", 1003, 500, []ChipCount{{1000, 1}, {1, 3}}},
+		{"no blind", 6, 0, []ChipCount{{5, 1}, {1, 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ChipBreakdown(tt.amount, tt.smallBlind); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ChipBreakdown(%d, %d) = %v, want %v", tt.amount, tt.smallBlind, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTableState_ChipBreakdowns verifies table_state carries the bets and pot as chips
+func TestTableState_ChipBreakdowns(t *testing.T) {
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+	seatTwoPlayers(table)
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	state := server.buildTableState(table, nil)
+	table.mu.RLock()
+	bbSeat := table.CurrentHand.BigBlindSeat
+	table.mu.RUnlock()
+	bb := state.Seats[bbSeat]
+	if bb.Bet != 20 || !reflect.DeepEqual(bb.BetChips, []ChipCount{{5, 4}}) {
+		t.Errorf("expected the big blind as four 5 chips, got %d %v", bb.Bet, bb.BetChips)
+	}
+	if state.PotChips != nil {
+		t.Errorf("expected no chips in the middle before the flop, got %v", state.PotChips)
+	}
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// CallClockConfig lets opponents "call the clock" on a player who is taking too long: the
+// player is left a short countdown, after which the server checks or folds for them.
+// Duration 0 disables calling the clock.
+type CallClockConfig struct {
+	Duration time.Duration `yaml:"duration"` // Time the player has left once the clock is called
+	Cooldown time.Duration `yaml:"cooldown"` // How long a player must wait before calling the clock again
+}
+
+// validate reports negative call-the-clock settings
+func (c CallClockConfig) validate() error {
+	if c.Duration < 0 || c.Cooldown < 0 {
+		return errors.New("callClock settings must not be negative")
+	}
+	return nil
+}
+
+// ClockCalledPayload represents the payload for clock_called messages
+type ClockCalledPayload struct {
+	SeatIndex int   `json:"seatIndex"` // Seat put on the clock
+	CalledBy  int   `json:"calledBy"`  // Seat that called the clock
+	Deadline  int64 `json:"deadline"`  // Unix ms when the seat's time runs out
+}
+
+// HandleCallClock processes a call_clock message: a seated opponent of the current actor cuts the
+// actor's remaining time down to the configured countdown. The clock can be called once per turn,
+// and each player only once per cooldown.
+func (c *Client) HandleCallClock(sm *SessionManager, server *Server, logger *slog.Logger) error {
+	cfg := server.Config().CallClock
+	if cfg.Duration <= 0 {
+		return newMessageError("error.call_clock_disabled", nil)
+	}
+
+	session, err := sm.GetSession(c.Token)
+	if err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+	if session.TableID == nil || session.SeatIndex == nil {
+		return newMessageError("error.not_seated", nil)
+	}
+	table := server.tableByID(*session.TableID)
+	if table == nil {
+		return newMessageError("error.table_not_found", nil)
+	}
+	callerSeat := *session.SeatIndex
+
+	now := table.clock().Now()
+	table.mu.Lock()
+	hand := table.CurrentHand
+	if hand == nil || hand.CurrentActor == nil {
+		table.mu.Unlock()
+		return newMessageError("error.no_hand_in_progress", nil)
+	}
+	actor := *hand.CurrentActor
+	if actor == callerSeat {
+		table.mu.Unlock()
+		return newMessageError("error.your_turn", nil)
+	}
+	if table.clockCalled {
+		table.mu.Unlock()
+		return newMessageError("error.clock_already_called", nil)
+	}
+	if last, ok := table.clockCalls[c.Token]; ok && now.Sub(last) < cfg.Cooldown {
+		table.mu.Unlock()
+		wait := (cfg.Cooldown - now.Sub(last) + time.Second - 1) / time.Second
+		return newMessageError("error.call_clock_cooldown", map[string]any{"seconds": int(wait)})
+	}
+
+	deadline := now.Add(cfg.Duration)
+	if table.ActionDeadline != nil && table.ActionDeadline.Before(deadline) {
+		// The player already has less time left than the countdown would give them
+		table.mu.Unlock()
+		return newMessageError("error.clock_already_short", nil)
+	}
+	table.runActionClockLocked(actor, deadline)
+	table.clockCalled = true
+	if table.clockCalls == nil {
+		table.clockCalls = make(map[string]time.Time)
+	}
+	table.clockCalls[c.Token] = now
+	table.trimPlayersLocked()
+	var actorToken string
+	if token := table.Seats[actor].Token; token != nil {
+		actorToken = *token
+	}
+	table.publishEvent(Event{Type: EventClockCalled, SeatIndex: actor, Token: actorToken, CalledBy: callerSeat})
+	table.mu.Unlock()
+
+	logger.Info("clock called", "tableID", table.ID, "seatIndex", actor, "calledBy", callerSeat, "deadline", deadline)
+	return server.broadcastTableMessage(table, "clock_called", ClockCalledPayload{
+		SeatIndex: actor,
+		CalledBy:  callerSeat,
+		Deadline:  deadline.UnixMilli(),
+	})
+}
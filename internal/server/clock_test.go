@@ -0,0 +1,122 @@
+package server
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// enableCallClock turns calling the clock on for server
+func enableCallClock(server *Server, duration, cooldown time.Duration) {
+	updateConfig(server, func(config *Config) {
+		config.CallClock = CallClockConfig{Duration: duration, Cooldown: cooldown}
+	})
+}
+
+// callClockKey calls the clock as client and returns the message key of the error, empty on success
+func callClockKey(server *Server, client *Client) string {
+	err := client.HandleCallClock(server.sessionManager, server, slog.Default())
+	if err == nil {
+		return ""
+	}
+	var messageErr *MessageError
+	if errors.As(err, &messageErr) {
+		return messageErr.Key
+	}
+	return err.Error()
+}
+
+// TestCallClock_TimesOutActor verifies a called clock folds the tanking player when it runs out
+func TestCallClock_TimesOutActor(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	clock := useFakeClock(server)
+	enableCallClock(server, 20*time.Second, time.Minute)
+	actor := currentActor(table)
+	caller := (actor + 1) % 3
+
+	drainRawMessages(clients[caller])
+	if key := callClockKey(server, clients[caller]); key != "" {
+		t.Fatalf("expected the clock to be called, got %s", key)
+	}
+
+	messages := drainRawMessages(clients[caller])
+	if len(messages) == 0 || !strings.Contains(messages[0], `"type":"clock_called"`) {
+		t.Errorf("expected a clock_called broadcast, got %v", messages)
+	}
+	table.mu.RLock()
+	clockCalled := table.clockCalled
+	hasDeadline := table.ActionDeadline != nil && table.ActionDeadline.Sub(clock.Now()) == 20*time.Second
+	table.mu.RUnlock()
+	if !clockCalled || !hasDeadline {
+		t.Fatalf("expected the actor on a called clock, called=%v deadline=%v", clockCalled, hasDeadline)
+	}
+
+	clock.waitForTimers(t, 1)
+	clock.Advance(20 * time.Second)
+	eventually(func() bool { return currentActor(table) != actor })
+	table.mu.RLock()
+	folded := table.CurrentHand != nil && table.CurrentHand.FoldedPlayers[actor]
+	clockCalled = table.clockCalled
+	table.mu.RUnlock()
+	if !folded {
+		t.Error("expected the actor to be folded when the called clock ran out")
+	}
+	if clockCalled {
+		t.Error("expected the next player's turn to start with an uncalled clock")
+	}
+}
+
+// TestCallClock_Limits verifies the clock cannot be called when disabled, on oneself, twice in a
+// turn, or again by the same player within the cooldown
+func TestCallClock_Limits(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	actor := currentActor(table)
+	caller := (actor + 2) % 3 // Acts last, so stays off the clock on the next turn
+	other := (actor + 1) % 3
+
+	if key := callClockKey(server, clients[caller]); key != "error.call_clock_disabled" {
+		t.Errorf("expected call_clock_disabled, got %q", key)
+	}
+
+	enableCallClock(server, time.Minute, time.Hour)
+	if key := callClockKey(server, clients[actor]); key != "error.your_turn" {
+		t.Errorf("expected your_turn, got %q", key)
+	}
+	if key := callClockKey(server, clients[caller]); key != "" {
+		t.Fatalf("expected the clock to be called, got %q", key)
+	}
+	if key := callClockKey(server, clients[other]); key != "error.clock_already_called" {
+		t.Errorf("expected clock_already_called, got %q", key)
+	}
+
+	// Next turn: the same caller is cooling down, somebody else may still call it
+	actCurrent(t, server, table, "call")
+	if currentActor(table) != other {
+		t.Fatalf("expected seat %d to act next, got %d", other, currentActor(table))
+	}
+	err := clients[caller].HandleCallClock(server.sessionManager, server, slog.Default())
+	var messageErr *MessageError
+	if !errors.As(err, &messageErr) || messageErr.Key != "error.call_clock_cooldown" || messageErr.Params["seconds"] != 3600 {
+		t.Errorf("expected a cooldown of 3600 seconds, got %v", err)
+	}
+	if key := callClockKey(server, clients[actor]); key != "" {
+		t.Errorf("expected another player to be able to call the clock, got %q", key)
+	}
+}
+
+// TestCallClock_AlreadyShort verifies the clock is not called when it would give the player more time
+func TestCallClock_AlreadyShort(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	enableCallClock(server, time.Minute, 0)
+	actor := currentActor(table)
+
+	table.mu.Lock()
+	table.runActionClockLocked(actor, time.Now().Add(10*time.Second))
+	table.mu.Unlock()
+
+	if key := callClockKey(server, clients[(actor+1)%3]); key != "error.clock_already_short" {
+		t.Errorf("expected clock_already_short, got %q", key)
+	}
+}
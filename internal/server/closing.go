@@ -0,0 +1,125 @@
+package server
+
+import (
+	"errors"
+	"slices"
+)
+
+// TableCloseResult is the result of POST /admin/tables/{tableID}/close
+type TableCloseResult struct {
+	TableID string `json:"tableId"`
+	Players int    `json:"players"` // Players still seated, who play on until they leave
+	Closed  bool   `json:"closed"`  // The table was empty and left the lobby at once
+}
+
+var (
+	errTableClosing    = errors.New("table is already closing")
+	errTableNotClosing = errors.New("table is not closing")
+)
+
+// SoftClose starts draining the table: the players seated keep playing, but nobody new can sit
+// down. Returns the number of players still seated.
+func (t *Table) SoftClose() (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closing {
+		return 0, errTableClosing
+	}
+	t.closing = true
+	players := 0
+	for _, seat := range t.Seats {
+		if seat.Token != nil {
+			players++
+		}
+	}
+	return players, nil
+}
+
+// CancelSoftClose lets players join a closing table again
+func (t *Table) CancelSoftClose() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.closing {
+		return errTableNotClosing
+	}
+	t.closing = false
+	return nil
+}
+
+// Closing reports whether the table is draining for a soft close (thread-safe)
+func (t *Table) Closing() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.closing
+}
+
+// CloseTable soft-closes the table with tableID for a maintenance drain or a lobby rebalance: the
+// hands go on while anyone is seated, no one can join, and the table leaves the lobby once the
+// last player is gone. An empty table leaves at once.
+func (s *Server) CloseTable(tableID string) (TableCloseResult, error) {
+	table := s.tableByID(tableID)
+	if table == nil {
+		return TableCloseResult{}, errTableNotFound
+	}
+	players, err := table.SoftClose()
+	if err != nil {
+		return TableCloseResult{}, err
+	}
+
+	s.logger.Warn("table closing", "tableID", tableID, "players", players)
+	result := TableCloseResult{TableID: tableID, Players: players, Closed: s.closeDrainedTable(table)}
+	if !result.Closed {
+		s.notifyTableStatus(table)
+	}
+	return result, nil
+}
+
+// ReopenClosingTable cancels the soft close of the table with tableID, if it still has players
+func (s *Server) ReopenClosingTable(tableID string) error {
+	table := s.tableByID(tableID)
+	if table == nil {
+		return errTableNotFound
+	}
+	if err := table.CancelSoftClose(); err != nil {
+		return err
+	}
+
+	s.logger.Warn("table close cancelled", "tableID", tableID)
+	s.notifyTableStatus(table)
+	return nil
+}
+
+// closeDrainedTable removes a closing table from the server once nobody is seated and no hand is
+// running, telling its observers and the lobby; unlike a table closed for lack of players it is
+// never reopened. Reports whether the table was removed.
+func (s *Server) closeDrainedTable(table *Table) bool {
+	s.mu.Lock()
+	table.mu.Lock()
+	drained := table.closing && table.CurrentHand == nil
+	for _, seat := range table.Seats {
+		drained = drained && seat.Token == nil
+	}
+	if !drained || !slices.Contains(s.tables, table) {
+		table.mu.Unlock()
+		s.mu.Unlock()
+		return false
+	}
+	table.cancelNextHandLocked()
+	table.stopActionClockLocked()
+	table.mu.Unlock()
+	s.tables = slices.DeleteFunc(s.tables, func(t *Table) bool { return t == table })
+	s.mu.Unlock()
+
+	for _, token := range s.observers.Tokens(table.ID) {
+		s.sendPrivate(token, "table_closed", TableClosedPayload{TableID: table.ID})
+		s.observers.Remove(token)
+	}
+	s.logger.Warn("closing table drained and closed", "tableID", table.ID)
+	if err := s.broadcastLobbyState(); err != nil {
+		s.logger.Warn("failed to broadcast lobby state after closing table", "error", err)
+	}
+	return true
+}
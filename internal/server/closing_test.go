@@ -0,0 +1,101 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+)
+
+// TestCloseTable_DrainsThenCloses verifies a closing table keeps dealing to its players, refuses
+// new ones and leaves the lobby with its last player
+func TestCloseTable_DrainsThenCloses(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{
+		Tables: []TableConfig{{Name: "Main", SmallBlind: 10, BigBlind: 20, BuyIn: 1000}},
+	})
+	table := server.tables[0]
+	clients := seatNamed(t, server, table, "Alice", "Bob")
+	watcherSession, _ := server.sessionManager.CreateSession("Watcher")
+	watcher := connectTestClient(server, watcherSession.Token)
+	server.observers.Watch(watcher.Token, table.ID)
+
+	result, err := server.CloseTable(table.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Players != 2 || result.Closed {
+		t.Errorf("expected the table draining with 2 players, got %+v", result)
+	}
+	if _, err := server.CloseTable(table.ID); err != errTableClosing {
+		t.Errorf("expected errTableClosing closing twice, got %v", err)
+	}
+
+	session, _ := server.sessionManager.CreateSession("Carol")
+	if _, err := server.seatPlayer(session.Token, "", table); err == nil {
+		t.Error("expected no one to join a closing table")
+	} else if key, _ := errorMessageKey(err); key != "error.table_closing" {
+		t.Errorf("expected error.table_closing, got %v", err)
+	}
+	lobby := server.GetLobbyState()
+	if len(lobby) != 1 || !lobby[0].Closing {
+		t.Errorf("expected the lobby to show the table closing, got %+v", lobby)
+	}
+
+	// The players seated play on
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("expected hands dealt while closing: %v", err)
+	}
+	playOutChecking(t, server, table)
+
+	if err := clients[0].HandleLeaveTable(server.sessionManager, server, slog.Default(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if server.tableByID(table.ID) == nil {
+		t.Fatal("expected the table open while Bob is seated")
+	}
+	if err := clients[1].HandleLeaveTable(server.sessionManager, server, slog.Default(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if server.tableByID(table.ID) != nil || len(server.GetLobbyState()) != 0 {
+		t.Error("expected the table closed once empty")
+	}
+	if closed := payloadsOf[TableClosedPayload](t, watcher, "table_closed"); len(closed) != 1 {
+		t.Errorf("expected the observer told the table closed, got %v", closed)
+	}
+}
+
+// TestAdminAPI_CloseTable verifies an empty table closes at once and the close of a busy table can
+// be cancelled
+func TestAdminAPI_CloseTable(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{
+		AdminToken: "secret",
+		Tables: []TableConfig{
+			{Name: "Empty", SmallBlind: 10, BigBlind: 20, BuyIn: 1000},
+			{Name: "Busy", SmallBlind: 10, BigBlind: 20, BuyIn: 1000},
+		},
+	})
+	empty, busy := server.tables[0], server.tables[1]
+	seatNamed(t, server, busy, "Alice")
+
+	rec := adminRequest(server, http.MethodPost, "/admin/tables/"+empty.ID+"/close", "secret", "")
+	var result TableCloseResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil || rec.Code != http.StatusOK || !result.Closed {
+		t.Errorf("expected the empty table closed at once, got %d %s", rec.Code, rec.Body.String())
+	}
+	if server.tableByID(empty.ID) != nil {
+		t.Error("expected the empty table gone")
+	}
+
+	if code := adminRequest(server, http.MethodDelete, "/admin/tables/"+busy.ID+"/close", "secret", "").Code; code != http.StatusConflict {
+		t.Errorf("expected 409 reopening a table that is not closing, got %d", code)
+	}
+	if code := adminRequest(server, http.MethodPost, "/admin/tables/"+busy.ID+"/close", "secret", "").Code; code != http.StatusOK {
+		t.Fatalf("expected 200 closing, got %d", code)
+	}
+	if code := adminRequest(server, http.MethodDelete, "/admin/tables/"+busy.ID+"/close", "secret", "").Code; code != http.StatusOK {
+		t.Errorf("expected 200 cancelling the close, got %d", code)
+	}
+	if busy.Closing() {
+		t.Error("expected the table open to new players again")
+	}
+}
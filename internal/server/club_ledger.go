@@ -0,0 +1,289 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"sort"
+	"time"
+)
+
+// clubSettlementHistory is how many settle-up reports each club keeps
+const clubSettlementHistory = 12
+
+// clubSettleCheckInterval is how often the server looks for clubs due a settle-up report
+const clubSettleCheckInterval = time.Minute
+
+// ClubPosition is one player's net chips over a ledger period
+type ClubPosition struct {
+	Name string `json:"name"`
+	Net  int    `json:"net"` // Negative when the player is down
+}
+
+// ClubTransfer is one payment that squares the positions of a settle-up report
+type ClubTransfer struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Amount int    `json:"amount"`
+}
+
+// ClubSettlement is a settle-up report closing one ledger period of a club: who ended up where
+// at the club's tables, and who should pay whom to square up. The server only reports it;
+// settling is between the players.
+type ClubSettlement struct {
+	ClubID    string         `json:"clubId"`
+	From      time.Time      `json:"from"`
+	To        time.Time      `json:"to"`
+	Positions []ClubPosition `json:"positions"` // Biggest winner first
+	Transfers []ClubTransfer `json:"transfers"`
+}
+
+// clubPositions returns the positions of ledger, biggest winner first
+func clubPositions(ledger map[string]int) []ClubPosition {
+	positions := make([]ClubPosition, 0, len(ledger))
+	for name, net := range ledger {
+		positions = append(positions, ClubPosition{Name: name, Net: net})
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].Net != positions[j].Net {
+			return positions[i].Net > positions[j].Net
+		}
+		return positions[i].Name < positions[j].Name
+	})
+	return positions
+}
+
+// settleUp returns the transfers that square positions, sorted biggest winner first: the
+// biggest losers pay the biggest winners first, which keeps the number of payments low. Chips
+// lost to rake are owed to nobody, so the losers may not pay out everything they are down.
+func settleUp(positions []ClubPosition) []ClubTransfer {
+	var winners, losers []ClubPosition
+	for _, position := range positions {
+		switch {
+		case position.Net > 0:
+			winners = append(winners, position)
+		case position.Net < 0:
+			losers = append(losers, ClubPosition{Name: position.Name, Net: -position.Net})
+		}
+	}
+	slices.SortStableFunc(losers, func(a, b ClubPosition) int { return b.Net - a.Net })
+
+	transfers := []ClubTransfer{}
+	for w, l := 0, 0; w < len(winners) && l < len(losers); {
+		amount := min(winners[w].Net, losers[l].Net)
+		transfers = append(transfers, ClubTransfer{From: losers[l].Name, To: winners[w].Name, Amount: amount})
+		winners[w].Net -= amount
+		losers[l].Net -= amount
+		if winners[w].Net == 0 {
+			w++
+		}
+		if losers[l].Net == 0 {
+			l++
+		}
+	}
+	return transfers
+}
+
+// RecordNet adds net chips from a stay at one of the club's tables to name's position in the
+// club's ledger. Players removed from the club while seated are still recorded.
+func (s *ClubStore) RecordNet(clubID, name string, net int) error {
+	_, err := s.update(clubID, func(club *Club) error {
+		if club.Ledger == nil {
+			club.Ledger = make(map[string]int)
+		}
+		club.Ledger[accountKey(name)] += net
+		return nil
+	})
+	return err
+}
+
+// Settle closes the club's ledger period at now and starts a new one. It returns the report
+// for the closed period, or false when nothing was recorded in it.
+func (s *ClubStore) Settle(clubID string, now time.Time) (ClubSettlement, bool, error) {
+	var settlement ClubSettlement
+	_, err := s.update(clubID, func(club *Club) error {
+		if len(club.Ledger) > 0 {
+			positions := clubPositions(club.Ledger)
+			settlement = ClubSettlement{
+				ClubID:    club.ID,
+				From:      club.ledgerSince(),
+				To:        now,
+				Positions: positions,
+				Transfers: settleUp(positions),
+			}
+			club.Settlements = append(club.Settlements, settlement)
+			if len(club.Settlements) > clubSettlementHistory {
+				club.Settlements = club.Settlements[len(club.Settlements)-clubSettlementHistory:]
+			}
+		}
+		club.Ledger = nil
+		club.LedgerSince = now
+		return nil
+	})
+	return settlement, err == nil && settlement.ClubID != "", err
+}
+
+// DueForSettlement returns the IDs of the clubs whose ledger period has run for every or longer
+func (s *ClubStore) DueForSettlement(now time.Time, every time.Duration) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var due []string
+	for _, club := range s.clubs {
+		if !now.Before(club.ledgerSince().Add(every)) {
+			due = append(due, club.ID)
+		}
+	}
+	sort.Strings(due)
+	return due
+}
+
+// ledgerSince returns when the club's current ledger period started; clubs stored before
+// ledgers were kept start theirs at creation
+func (c Club) ledgerSince() time.Time {
+	if c.LedgerSince.IsZero() {
+		return c.CreatedAt
+	}
+	return c.LedgerSince
+}
+
+// ClubLedgerPayload represents the payload for club_ledger messages: the club's positions so
+// far this period, for its owner and managers
+type ClubLedgerPayload struct {
+	ClubID    string         `json:"clubId"`
+	Since     time.Time      `json:"since"`
+	Positions []ClubPosition `json:"positions"` // Stays that have ended, biggest winner first
+	// Seated are the players at the club's tables now, with their net chips this stay; it goes
+	// into the ledger when they leave
+	Seated      []ClubPosition   `json:"seated"`
+	Settlements []ClubSettlement `json:"settlements,omitempty"` // Past reports, newest first; owner only
+}
+
+// recordClubStay adds a finished stay at a club table to its club's ledger
+func (s *Server) recordClubStay(name string, summary SessionSummary) {
+	table := s.tableByID(summary.TableID)
+	if table == nil || table.ClubID == "" {
+		return
+	}
+	if err := s.clubs.RecordNet(table.ClubID, name, summary.NetChips); err != nil {
+		s.logger.Warn("failed to record club stay", "clubID", table.ClubID, "name", name, "error", err)
+	}
+}
+
+// clubLedger builds the club's ledger as the member name sees it
+func (s *Server) clubLedger(club Club, name string) ClubLedgerPayload {
+	ledger := ClubLedgerPayload{
+		ClubID:    club.ID,
+		Since:     club.ledgerSince(),
+		Positions: clubPositions(club.Ledger),
+		Seated:    []ClubPosition{},
+	}
+	seated := make(map[string]int)
+	for _, clubTable := range club.Tables {
+		for player, net := range s.stats.OpenStays(clubTable.ID) {
+			seated[accountKey(player)] += net
+		}
+	}
+	ledger.Seated = append(ledger.Seated, clubPositions(seated)...)
+	if club.Members[0].Name == accountKey(name) {
+		for i := len(club.Settlements) - 1; i >= 0; i-- {
+			ledger.Settlements = append(ledger.Settlements, club.Settlements[i])
+		}
+	}
+	return ledger
+}
+
+// settleClub closes the club's ledger period at now and sends the report to its owner, if
+// they are online. Returns false when there was nothing to settle.
+func (s *Server) settleClub(clubID string, now time.Time) (ClubSettlement, bool, error) {
+	settlement, ok, err := s.clubs.Settle(clubID, now)
+	if err != nil || !ok {
+		return settlement, ok, err
+	}
+	s.logger.Info("club settled up", "clubID", clubID, "players", len(settlement.Positions), "transfers", len(settlement.Transfers))
+	if club, found := s.clubs.Club(clubID); found {
+		for _, token := range s.sessionManager.TokensByName(club.Members[0].Name) {
+			s.sendPrivate(token, "club_settlement", settlement)
+		}
+	}
+	return settlement, true, nil
+}
+
+// settleDueClubs settles every club whose ledger period is over, when clubs settle up on a
+// schedule
+func (s *Server) settleDueClubs(now time.Time) {
+	cfg := s.Config().Clubs
+	if !cfg.Enabled || cfg.SettleEvery <= 0 {
+		return
+	}
+	for _, clubID := range s.clubs.DueForSettlement(now, cfg.SettleEvery) {
+		if _, _, err := s.settleClub(clubID, now); err != nil {
+			s.logger.Warn("failed to settle club", "clubID", clubID, "error", err)
+		}
+	}
+}
+
+// runClubSettlement settles clubs that are due every clubSettleCheckInterval until stop is
+// closed
+func (s *Server) runClubSettlement(stop <-chan struct{}) {
+	ticker := time.NewTicker(clubSettleCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			s.settleDueClubs(now)
+		}
+	}
+}
+
+// HandleClubLedger processes a club_ledger message: an owner or manager sees where the club's
+// players stand this period
+func (c *Client) HandleClubLedger(sm *SessionManager, server *Server, payload []byte) error {
+	var req ClubPayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return invalidPayloadError("club_ledger", err)
+	}
+	name, err := c.clubRequest(sm, server)
+	if err != nil {
+		return err
+	}
+	club, ok := server.clubs.Club(req.ClubID)
+	if !ok {
+		return newMessageError("error.club_not_found", nil)
+	}
+	if err := club.authorize(name, ClubRoleManager); err != nil {
+		return err
+	}
+	return c.sendMessage("club_ledger", server.clubLedger(club, name))
+}
+
+// HandleSettleClub processes a settle_club message: the owner closes the ledger period now
+// rather than waiting for the scheduled settle-up
+func (c *Client) HandleSettleClub(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+	var req ClubPayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return invalidPayloadError("settle_club", err)
+	}
+	name, err := c.clubRequest(sm, server)
+	if err != nil {
+		return err
+	}
+	club, ok := server.clubs.Club(req.ClubID)
+	if !ok {
+		return newMessageError("error.club_not_found", nil)
+	}
+	if err := club.authorize(name, ClubRoleOwner); err != nil {
+		return err
+	}
+	_, settled, err := server.settleClub(club.ID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !settled {
+		return newMessageError("error.club_ledger_empty", nil)
+	}
+	logger.Info("club settle-up requested", "clubID", club.ID, "by", name)
+	return nil
+}
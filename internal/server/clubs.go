@@ -0,0 +1,725 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Club member roles. The owner runs the club; managers invite, remove members and open tables;
+// members play at the club's tables.
+const (
+	ClubRoleOwner   = "owner"
+	ClubRoleManager = "manager"
+	ClubRoleMember  = "member"
+)
+
+// clubNameMaxLength is the longest club or club table name, in characters
+const clubNameMaxLength = 40
+
+// inviteCodeAlphabet leaves out letters and digits that are easily mistaken for one another
+const inviteCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// ClubConfig lets players create home game clubs with private tables. The zero value disables
+// them.
+type ClubConfig struct {
+	Enabled   bool `yaml:"enabled"`
+	MaxTables int  `yaml:"maxTables"` // Tables each club may open; 0 means no limit
+	// SettleEvery is how long each club's ledger period runs before its owner gets a settle-up
+	// report; 0 settles only when the owner asks
+	SettleEvery time.Duration `yaml:"settleEvery"`
+}
+
+// validate reports a negative table limit or settle-up period
+func (c ClubConfig) validate() error {
+	if c.MaxTables < 0 {
+		return errors.New("clubs maxTables must not be negative")
+	}
+	if c.SettleEvery < 0 {
+		return errors.New("clubs settleEvery must not be negative")
+	}
+	return nil
+}
+
+// ClubMember is one member of a club
+type ClubMember struct {
+	Name     string    `json:"name"` // Lowercased player name
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joinedAt"`
+}
+
+// ClubTable is a private table a club opened; only the club's members see and join it
+type ClubTable struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	SmallBlind int    `json:"smallBlind"`
+	BigBlind   int    `json:"bigBlind"`
+	BuyIn      int    `json:"buyIn"`
+}
+
+// Club is a group of players who share private tables, joined with an invite code
+type Club struct {
+	ID         string       `json:"id"`
+	Name       string       `json:"name"`
+	InviteCode string       `json:"inviteCode"`
+	CreatedAt  time.Time    `json:"createdAt"`
+	Members    []ClubMember `json:"members"` // The owner first, then in joining order
+	Tables     []ClubTable  `json:"tables,omitempty"`
+
+	// Ledger is each player's net chips from stays at the club's tables ended since
+	// LedgerSince, by lowercased name; Settlements are the settle-up reports that closed the
+	// earlier periods, oldest first
+	Ledger      map[string]int   `json:"ledger,omitempty"`
+	LedgerSince time.Time        `json:"ledgerSince"`
+	Settlements []ClubSettlement `json:"settlements,omitempty"`
+}
+
+// member returns the member with the lowercased name, if any
+func (c Club) member(name string) (ClubMember, bool) {
+	i := slices.IndexFunc(c.Members, func(m ClubMember) bool { return m.Name == name })
+	if i < 0 {
+		return ClubMember{}, false
+	}
+	return c.Members[i], true
+}
+
+// clubRoleAtLeast reports whether role ranks at least as high as minimum
+func clubRoleAtLeast(role, minimum string) bool {
+	rank := map[string]int{ClubRoleMember: 1, ClubRoleManager: 2, ClubRoleOwner: 3}
+	return rank[role] >= rank[minimum]
+}
+
+// ClubStore holds the clubs and persists them to a JSON file. The zero path keeps them in
+// memory only.
+type ClubStore struct {
+	mu    sync.Mutex
+	clubs map[string]Club
+	path  string
+}
+
+// LoadClubStore reads the clubs stored at path; a missing file starts an empty store
+// An empty path returns an in-memory store
+func LoadClubStore(path string) (*ClubStore, error) {
+	store := &ClubStore{
+		clubs: make(map[string]Club),
+		path:  path,
+	}
+	if path == "" {
+		return store, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read club store: %w", err)
+	}
+
+	var clubs []Club
+	if err := json.Unmarshal(data, &clubs); err != nil {
+		return nil, fmt.Errorf("failed to parse club store %s: %w", path, err)
+	}
+	for _, club := range clubs {
+		if club.ID == "" || len(club.Members) == 0 || club.Members[0].Role != ClubRoleOwner {
+			return nil, fmt.Errorf("invalid entry in club store %s: club %q needs an id and an owner", path, club.ID)
+		}
+		store.clubs[club.ID] = club
+	}
+	return store, nil
+}
+
+// newClubCode returns a random eight character code from inviteCodeAlphabet
+func newClubCode() (string, error) {
+	random := make([]byte, 8)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate club code: %w", err)
+	}
+	code := make([]byte, len(random))
+	for i, b := range random {
+		code[i] = inviteCodeAlphabet[int(b)%len(inviteCodeAlphabet)]
+	}
+	return string(code), nil
+}
+
+// uniqueCodeLocked returns a new code no club uses as its invite code, or as its ID when
+// lowercased (caller must hold s.mu)
+func (s *ClubStore) uniqueCodeLocked() (string, error) {
+	for {
+		code, err := newClubCode()
+		if err != nil {
+			return "", err
+		}
+		_, taken := s.clubs["club-"+strings.ToLower(code)]
+		for _, club := range s.clubs {
+			taken = taken || club.InviteCode == code
+		}
+		if !taken {
+			return code, nil
+		}
+	}
+}
+
+// validClubName trims name and checks it is 1 to clubNameMaxLength characters
+func validClubName(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	return name, name != "" && utf8.RuneCountInString(name) <= clubNameMaxLength
+}
+
+// Create founds a club named name with owner as its owner and only member
+func (s *ClubStore) Create(name, owner string, now time.Time) (Club, error) {
+	name, ok := validClubName(name)
+	if !ok {
+		return Club{}, newMessageError("error.invalid_club_name", map[string]any{"max": clubNameMaxLength})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, err := s.uniqueCodeLocked()
+	if err != nil {
+		return Club{}, err
+	}
+	code, err := s.uniqueCodeLocked()
+	if err != nil {
+		return Club{}, err
+	}
+	club := Club{
+		ID:          "club-" + strings.ToLower(id),
+		Name:        name,
+		InviteCode:  code,
+		CreatedAt:   now,
+		LedgerSince: now,
+		Members:     []ClubMember{{Name: accountKey(owner), Role: ClubRoleOwner, JoinedAt: now}},
+	}
+	s.clubs[club.ID] = club
+	if err := s.saveLocked(); err != nil {
+		delete(s.clubs, club.ID)
+		return Club{}, err
+	}
+	return club, nil
+}
+
+// authorize checks that actor is a member of the club holding at least the minimum role
+func (c Club) authorize(actor, minimum string) error {
+	member, ok := c.member(accountKey(actor))
+	if !ok {
+		return newMessageError("error.not_club_member", nil)
+	}
+	if !clubRoleAtLeast(member.Role, minimum) {
+		return newMessageError("error.club_permission", nil)
+	}
+	return nil
+}
+
+// update applies change to a copy of the club with clubID and stores the result
+func (s *ClubStore) update(clubID string, change func(club *Club) error) (Club, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, ok := s.clubs[clubID]
+	if !ok {
+		return Club{}, newMessageError("error.club_not_found", nil)
+	}
+
+	// The slices and the ledger are copied so a failed change never touches the stored ones
+	club := previous
+	club.Members = slices.Clone(previous.Members)
+	club.Tables = slices.Clone(previous.Tables)
+	club.Ledger = maps.Clone(previous.Ledger)
+	club.Settlements = slices.Clone(previous.Settlements)
+	if err := change(&club); err != nil {
+		return Club{}, err
+	}
+
+	s.clubs[clubID] = club
+	if err := s.saveLocked(); err != nil {
+		// Keep memory in line with the file so the change can be retried
+		s.clubs[clubID] = previous
+		return Club{}, err
+	}
+	return club, nil
+}
+
+// updateAs is update on behalf of actor, who must hold at least the minimum role
+func (s *ClubStore) updateAs(clubID, actor, minimum string, change func(club *Club) error) (Club, error) {
+	return s.update(clubID, func(club *Club) error {
+		if err := club.authorize(actor, minimum); err != nil {
+			return err
+		}
+		return change(club)
+	})
+}
+
+// Join adds name to the club whose invite code is code, as a member
+func (s *ClubStore) Join(code, name string, now time.Time) (Club, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	s.mu.Lock()
+	clubID := ""
+	for _, club := range s.clubs {
+		if code != "" && club.InviteCode == code {
+			clubID = club.ID
+		}
+	}
+	s.mu.Unlock()
+	if clubID == "" {
+		return Club{}, newMessageError("error.invalid_invite_code", nil)
+	}
+
+	return s.update(clubID, func(club *Club) error {
+		if _, ok := club.member(accountKey(name)); ok {
+			return newMessageError("error.already_club_member", nil)
+		}
+		club.Members = append(club.Members, ClubMember{Name: accountKey(name), Role: ClubRoleMember, JoinedAt: now})
+		return nil
+	})
+}
+
+// Leave takes name out of the club with clubID; the owner cannot leave their club
+func (s *ClubStore) Leave(clubID, name string) error {
+	_, err := s.updateAs(clubID, name, ClubRoleMember, func(club *Club) error {
+		if club.Members[0].Name == accountKey(name) {
+			return newMessageError("error.owner_cannot_leave", nil)
+		}
+		club.Members = slices.DeleteFunc(club.Members, func(m ClubMember) bool { return m.Name == accountKey(name) })
+		return nil
+	})
+	return err
+}
+
+// SetRole makes the member name a manager or a plain member; only the owner may
+func (s *ClubStore) SetRole(clubID, actor, name, role string) (Club, error) {
+	if role != ClubRoleManager && role != ClubRoleMember {
+		return Club{}, newMessageError("error.invalid_club_role", nil)
+	}
+	return s.updateAs(clubID, actor, ClubRoleOwner, func(club *Club) error {
+		i := slices.IndexFunc(club.Members, func(m ClubMember) bool { return m.Name == accountKey(name) })
+		if i < 0 {
+			return newMessageError("error.not_club_member", nil)
+		}
+		if club.Members[i].Role == ClubRoleOwner {
+			return newMessageError("error.club_permission", nil)
+		}
+		club.Members[i].Role = role
+		return nil
+	})
+}
+
+// RemoveMember takes name out of the club on actor's behalf. Managers may remove members; only
+// the owner may remove a manager, and nobody the owner.
+func (s *ClubStore) RemoveMember(clubID, actor, name string) (Club, error) {
+	return s.updateAs(clubID, actor, ClubRoleManager, func(club *Club) error {
+		removed, ok := club.member(accountKey(name))
+		if !ok {
+			return newMessageError("error.not_club_member", nil)
+		}
+		actorMember, _ := club.member(accountKey(actor))
+		if removed.Role == ClubRoleOwner || (removed.Role == ClubRoleManager && actorMember.Role != ClubRoleOwner) {
+			return newMessageError("error.club_permission", nil)
+		}
+		club.Members = slices.DeleteFunc(club.Members, func(m ClubMember) bool { return m.Name == removed.Name })
+		return nil
+	})
+}
+
+// RotateInviteCode replaces the club's invite code, so the old one no longer lets anyone join
+func (s *ClubStore) RotateInviteCode(clubID, actor string) (Club, error) {
+	return s.updateAs(clubID, actor, ClubRoleManager, func(club *Club) error {
+		code, err := s.uniqueCodeLocked()
+		if err != nil {
+			return err
+		}
+		club.InviteCode = code
+		return nil
+	})
+}
+
+// AddTable records a new private table for the club on actor's behalf, refusing it beyond
+// maxTables (0 means no limit); the table's ID is filled in
+func (s *ClubStore) AddTable(clubID, actor string, table ClubTable, maxTables int) (Club, ClubTable, error) {
+	name, ok := validClubName(table.Name)
+	if !ok {
+		return Club{}, ClubTable{}, newMessageError("error.invalid_club_name", map[string]any{"max": clubNameMaxLength})
+	}
+	table.Name = name
+	if table.SmallBlind <= 0 || table.BigBlind < table.SmallBlind || table.BuyIn < table.BigBlind {
+		return Club{}, ClubTable{}, newMessageError("error.invalid_club_table", nil)
+	}
+	club, err := s.updateAs(clubID, actor, ClubRoleManager, func(club *Club) error {
+		if maxTables > 0 && len(club.Tables) >= maxTables {
+			return newMessageError("error.club_table_limit", map[string]any{"max": maxTables})
+		}
+		table.ID = fmt.Sprintf("%s-%d", club.ID, len(club.Tables)+1)
+		club.Tables = append(club.Tables, table)
+		return nil
+	})
+	return club, table, err
+}
+
+// Club returns the club with clubID
+func (s *ClubStore) Club(clubID string) (Club, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	club, ok := s.clubs[clubID]
+	return club, ok
+}
+
+// Role returns name's role in the club with clubID, or "" when they are not a member
+func (s *ClubStore) Role(clubID, name string) string {
+	club, _ := s.Club(clubID)
+	member, _ := club.member(accountKey(name))
+	return member.Role
+}
+
+// Clubs returns every club, or only those name belongs to when name is not empty, by name
+func (s *ClubStore) Clubs(name string) []Club {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var clubs []Club
+	for _, club := range s.clubs {
+		if _, ok := club.member(accountKey(name)); ok || name == "" {
+			clubs = append(clubs, club)
+		}
+	}
+	sort.Slice(clubs, func(i, j int) bool {
+		if clubs[i].Name != clubs[j].Name {
+			return clubs[i].Name < clubs[j].Name
+		}
+		return clubs[i].ID < clubs[j].ID
+	})
+	return clubs
+}
+
+// saveLocked writes the clubs to the store's file (caller must hold s.mu)
+// The file is replaced atomically so a crash never leaves a truncated store
+func (s *ClubStore) saveLocked() error {
+	if s.path == "" {
+		return nil
+	}
+
+	clubs := make([]Club, 0, len(s.clubs))
+	for _, club := range s.clubs {
+		clubs = append(clubs, club)
+	}
+	sort.Slice(clubs, func(i, j int) bool {
+		return clubs[i].ID < clubs[j].ID
+	})
+
+	data, err := json.MarshalIndent(clubs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal club store: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".clubs-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to save club store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save club store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save club store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to save club store: %w", err)
+	}
+	return nil
+}
+
+// CreateClubPayload represents the payload for create_club messages
+type CreateClubPayload struct {
+	Name string `json:"name"`
+}
+
+// JoinClubPayload represents the payload for join_club messages
+type JoinClubPayload struct {
+	InviteCode string `json:"inviteCode"`
+}
+
+// ClubPayload represents the payload for leave_club and rotate_club_invite messages, and for
+// club_left replies
+type ClubPayload struct {
+	ClubID string `json:"clubId"`
+}
+
+// ClubMemberPayload represents the payload for set_club_role and remove_club_member messages
+type ClubMemberPayload struct {
+	ClubID string `json:"clubId"`
+	Name   string `json:"name"`
+	Role   string `json:"role,omitempty"` // set_club_role only: manager or member
+}
+
+// CreateClubTablePayload represents the payload for create_club_table messages
+type CreateClubTablePayload struct {
+	ClubID     string `json:"clubId"`
+	Name       string `json:"name"`
+	SmallBlind int    `json:"smallBlind"`
+	BigBlind   int    `json:"bigBlind"`
+	BuyIn      int    `json:"buyIn"`
+}
+
+// ClubView is a club as one of its members sees it: the payload of club messages and the
+// entries of club_list. Only the owner and managers see the invite code.
+type ClubView struct {
+	ID         string       `json:"id"`
+	Name       string       `json:"name"`
+	Role       string       `json:"role"` // The viewer's role
+	InviteCode string       `json:"inviteCode,omitempty"`
+	Members    []ClubMember `json:"members"`
+	Tables     []TableInfo  `json:"tables"` // Live lobby listings of the club's private tables
+}
+
+// ClubListPayload represents the payload for club_list messages
+type ClubListPayload struct {
+	Clubs []ClubView `json:"clubs"`
+}
+
+// clubView builds the view of club that the member name gets
+func (s *Server) clubView(club Club, name string) ClubView {
+	member, _ := club.member(accountKey(name))
+	view := ClubView{
+		ID:      club.ID,
+		Name:    club.Name,
+		Role:    member.Role,
+		Members: club.Members,
+		Tables:  []TableInfo{},
+	}
+	if clubRoleAtLeast(member.Role, ClubRoleManager) {
+		view.InviteCode = club.InviteCode
+	}
+	now := time.Now()
+	for _, clubTable := range club.Tables {
+		if table := s.tableByID(clubTable.ID); table != nil {
+			view.Tables = append(view.Tables, s.tableInfo(table, now))
+		}
+	}
+	return view
+}
+
+// openClubTable creates the private table t of the club with clubID and adds it to the server
+func (s *Server) openClubTable(clubID string, t ClubTable) *Table {
+	table := NewTable(t.ID, t.Name, s)
+	table.SmallBlind = t.SmallBlind
+	table.BigBlind = t.BigBlind
+	table.BuyIn = t.BuyIn
+	table.ClubID = clubID
+
+	s.mu.Lock()
+	s.tables = append(s.tables, table)
+	s.mu.Unlock()
+	return table
+}
+
+// clubName returns the name token acts under in clubs. Player names are chosen, not proven, so
+// a name held by another live session as well is refused: either could be an impostor.
+func (s *Server) clubName(token string) (string, error) {
+	name, err := s.sessionManager.GetPlayerName(token)
+	if err != nil {
+		return "", fmt.Errorf("session not found: %w", err)
+	}
+	if tokens := s.sessionManager.TokensByName(name); len(tokens) != 1 || tokens[0] != token {
+		return "", newMessageError("error.club_name_shared", nil)
+	}
+	return name, nil
+}
+
+// checkClubAccess refuses token a club table unless they are a member of its club
+func (s *Server) checkClubAccess(table *Table, token string) error {
+	if table.ClubID == "" {
+		return nil
+	}
+	name, err := s.clubName(token)
+	if err != nil {
+		return err
+	}
+	if s.clubs.Role(table.ClubID, name) == "" {
+		return newMessageError("error.not_club_member", nil)
+	}
+	return nil
+}
+
+// clubRequest checks that clubs are enabled and returns the requesting player's name, refused
+// while another live session holds it too (see clubName)
+func (c *Client) clubRequest(sm *SessionManager, server *Server) (string, error) {
+	if !server.Config().Clubs.Enabled {
+		return "", newMessageError("error.clubs_disabled", nil)
+	}
+	return server.clubName(c.Token)
+}
+
+// sendClub replies with the club as the player name sees it
+func (c *Client) sendClub(server *Server, club Club, name string) error {
+	return c.sendMessage("club", server.clubView(club, name))
+}
+
+// HandleCreateClub processes a create_club message: the player founds a club and owns it
+func (c *Client) HandleCreateClub(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+	var req CreateClubPayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return invalidPayloadError("create_club", err)
+	}
+	name, err := c.clubRequest(sm, server)
+	if err != nil {
+		return err
+	}
+	club, err := server.clubs.Create(req.Name, name, time.Now())
+	if err != nil {
+		return err
+	}
+	logger.Info("club created", "clubID", club.ID, "owner", name)
+	return c.sendClub(server, club, name)
+}
+
+// HandleJoinClub processes a join_club message: the player joins the club with the invite code
+func (c *Client) HandleJoinClub(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+	var req JoinClubPayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return invalidPayloadError("join_club", err)
+	}
+	name, err := c.clubRequest(sm, server)
+	if err != nil {
+		return err
+	}
+	club, err := server.clubs.Join(req.InviteCode, name, time.Now())
+	if err != nil {
+		return err
+	}
+	logger.Info("club joined", "clubID", club.ID, "name", name)
+	return c.sendClub(server, club, name)
+}
+
+// HandleLeaveClub processes a leave_club message
+func (c *Client) HandleLeaveClub(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+	var req ClubPayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return invalidPayloadError("leave_club", err)
+	}
+	name, err := c.clubRequest(sm, server)
+	if err != nil {
+		return err
+	}
+	if err := server.clubs.Leave(req.ClubID, name); err != nil {
+		return err
+	}
+	logger.Info("club left", "clubID", req.ClubID, "name", name)
+	return c.sendMessage("club_left", ClubPayload{ClubID: req.ClubID})
+}
+
+// HandleListClubs processes a list_clubs message, replying with the player's clubs
+func (c *Client) HandleListClubs(sm *SessionManager, server *Server) error {
+	name, err := c.clubRequest(sm, server)
+	if err != nil {
+		return err
+	}
+	list := ClubListPayload{Clubs: []ClubView{}}
+	for _, club := range server.clubs.Clubs(name) {
+		list.Clubs = append(list.Clubs, server.clubView(club, name))
+	}
+	return c.sendMessage("club_list", list)
+}
+
+// HandleRotateClubInvite processes a rotate_club_invite message: an owner or manager replaces
+// the club's invite code
+func (c *Client) HandleRotateClubInvite(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+	var req ClubPayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return invalidPayloadError("rotate_club_invite", err)
+	}
+	name, err := c.clubRequest(sm, server)
+	if err != nil {
+		return err
+	}
+	club, err := server.clubs.RotateInviteCode(req.ClubID, name)
+	if err != nil {
+		return err
+	}
+	logger.Info("club invite code rotated", "clubID", club.ID, "by", name)
+	return c.sendClub(server, club, name)
+}
+
+// HandleSetClubRole processes a set_club_role message: the owner promotes a member to manager
+// or back
+func (c *Client) HandleSetClubRole(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+	var req ClubMemberPayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return invalidPayloadError("set_club_role", err)
+	}
+	name, err := c.clubRequest(sm, server)
+	if err != nil {
+		return err
+	}
+	club, err := server.clubs.SetRole(req.ClubID, name, req.Name, req.Role)
+	if err != nil {
+		return err
+	}
+	logger.Info("club role changed", "clubID", club.ID, "member", accountKey(req.Name), "role", req.Role, "by", name)
+	return c.sendClub(server, club, name)
+}
+
+// HandleRemoveClubMember processes a remove_club_member message. A removed player seated at a
+// club table plays on until they leave it, but cannot sit down there again.
+func (c *Client) HandleRemoveClubMember(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+	var req ClubMemberPayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return invalidPayloadError("remove_club_member", err)
+	}
+	name, err := c.clubRequest(sm, server)
+	if err != nil {
+		return err
+	}
+	club, err := server.clubs.RemoveMember(req.ClubID, name, req.Name)
+	if err != nil {
+		return err
+	}
+	logger.Info("club member removed", "clubID", club.ID, "member", accountKey(req.Name), "by", name)
+	return c.sendClub(server, club, name)
+}
+
+// HandleCreateClubTable processes a create_club_table message: an owner or manager opens a
+// private table that only the club's members see and join
+func (c *Client) HandleCreateClubTable(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+	var req CreateClubTablePayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return invalidPayloadError("create_club_table", err)
+	}
+	name, err := c.clubRequest(sm, server)
+	if err != nil {
+		return err
+	}
+	club, clubTable, err := server.clubs.AddTable(req.ClubID, name, ClubTable{
+		Name:       req.Name,
+		SmallBlind: req.SmallBlind,
+		BigBlind:   req.BigBlind,
+		BuyIn:      req.BuyIn,
+	}, server.Config().Clubs.MaxTables)
+	if err != nil {
+		return err
+	}
+	server.openClubTable(club.ID, clubTable)
+	logger.Info("club table opened", "clubID", club.ID, "tableID", clubTable.ID, "by", name)
+	return c.sendClub(server, club, name)
+}
+
+// handleListClubs writes every club with its members, tables and invite code
+func (s *Server) handleListClubs(w http.ResponseWriter, r *http.Request) {
+	clubs := s.clubs.Clubs("")
+	if clubs == nil {
+		clubs = []Club{}
+	}
+	writeAdminJSON(w, http.StatusOK, clubs)
+}
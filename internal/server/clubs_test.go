@@ -0,0 +1,269 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestClubStore_RolesAndPersistence verifies who may do what in a club and that clubs survive a
+// reload of the store
+func TestClubStore_RolesAndPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "clubs.json")
+	store, err := LoadClubStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	club, err := store.Create("  Friday Game ", "Olive", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if club.Name != "Friday Game" || store.Role(club.ID, "olive") != ClubRoleOwner {
+		t.Fatalf("unexpected club %+v", club)
+	}
+
+	if _, err := store.Join("WRONG", "Mia", now); err == nil {
+		t.Error("expected an unknown invite code refused")
+	}
+	for _, name := range []string{"Mia", "Max"} {
+		if _, err := store.Join(strings.ToLower(club.InviteCode), name, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := store.Join(club.InviteCode, "mia", now); err == nil {
+		t.Error("expected joining twice refused")
+	}
+
+	table := ClubTable{Name: "Main", SmallBlind: 5, BigBlind: 10, BuyIn: 500}
+	if _, _, err := store.AddTable(club.ID, "Mia", table, 0); err == nil {
+		t.Error("expected a member refused a table")
+	}
+	if _, err := store.SetRole(club.ID, "Mia", "Max", ClubRoleManager); err == nil {
+		t.Error("expected a member refused changing roles")
+	}
+	if _, err := store.SetRole(club.ID, "Olive", "Mia", ClubRoleManager); err != nil {
+		t.Fatal(err)
+	}
+	if _, added, err := store.AddTable(club.ID, "Mia", table, 1); err != nil || added.ID != club.ID+"-1" {
+		t.Fatalf("expected the manager to open %s-1, got %+v (%v)", club.ID, added, err)
+	}
+	if _, _, err := store.AddTable(club.ID, "Mia", table, 1); err == nil {
+		t.Error("expected the table limit enforced")
+	}
+	if _, err := store.RemoveMember(club.ID, "Mia", "Olive"); err == nil {
+		t.Error("expected a manager refused removing the owner")
+	}
+	if err := store.Leave(club.ID, "Olive"); err == nil {
+		t.Error("expected the owner refused leaving")
+	}
+	if _, err := store.RemoveMember(club.ID, "Mia", "Max"); err != nil {
+		t.Fatal(err)
+	}
+	rotated, err := store.RotateInviteCode(club.ID, "Mia")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Join(club.InviteCode, "Max", now); err == nil || rotated.InviteCode == club.InviteCode {
+		t.Error("expected the old invite code retired")
+	}
+
+	reloaded, err := LoadClubStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clubs := reloaded.Clubs("mia")
+	if len(clubs) != 1 || len(clubs[0].Members) != 2 || len(clubs[0].Tables) != 1 || clubs[0].InviteCode != rotated.InviteCode {
+		t.Errorf("expected the club reloaded as saved, got %+v", clubs)
+	}
+	if len(reloaded.Clubs("max")) != 0 {
+		t.Error("expected Max no longer a member")
+	}
+}
+
+// TestClubs_PrivateTablesForMembersOnly verifies a club table stays out of the public lobby,
+// refuses non-members and comes back after a restart
+func TestClubs_PrivateTablesForMembersOnly(t *testing.T) {
+	config := Config{
+		Clubs:         ClubConfig{Enabled: true},
+		ClubStoreFile: filepath.Join(t.TempDir(), "clubs.json"),
+	}
+	server := NewServerWithConfig(slog.Default(), config)
+	public := len(server.GetLobbyState())
+
+	owner, _ := server.sessionManager.CreateSession("Olive")
+	ownerClient := connectTestClient(server, owner.Token)
+	send := func(client *Client, handle func(*SessionManager, *Server, *slog.Logger, []byte) error, payload any) {
+		t.Helper()
+		data, _ := json.Marshal(payload)
+		if err := handle(server.sessionManager, server, slog.Default(), data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	send(ownerClient, ownerClient.HandleCreateClub, CreateClubPayload{Name: "Friday Game"})
+	club := payloadsOf[ClubView](t, ownerClient, "club")[0]
+	send(ownerClient, ownerClient.HandleCreateClubTable, CreateClubTablePayload{ClubID: club.ID, Name: "Main", SmallBlind: 5, BigBlind: 10, BuyIn: 500})
+	club = payloadsOf[ClubView](t, ownerClient, "club")[0]
+	if len(club.Tables) != 1 || club.InviteCode == "" {
+		t.Fatalf("expected the owner to see the new table and the invite code, got %+v", club)
+	}
+	if len(server.GetLobbyState()) != public {
+		t.Error("expected the club table kept out of the public lobby")
+	}
+	table := server.tableByID(club.Tables[0].ID)
+
+	guest, _ := server.sessionManager.CreateSession("Gus")
+	if _, err := server.seatPlayer(guest.Token, "", table); err == nil {
+		t.Error("expected a non-member refused a seat")
+	}
+	guestClient := connectTestClient(server, guest.Token)
+	send(guestClient, guestClient.HandleJoinClub, JoinClubPayload{InviteCode: club.InviteCode})
+	if joined := payloadsOf[ClubView](t, guestClient, "club"); len(joined) != 1 || joined[0].Role != ClubRoleMember || joined[0].InviteCode != "" {
+		t.Errorf("expected Gus to join as a member without the invite code, got %+v", joined)
+	}
+	if _, err := server.seatPlayer(guest.Token, "", table); err != nil {
+		t.Fatalf("expected a member seated, got %v", err)
+	}
+
+	restarted := NewServerWithConfig(slog.Default(), config)
+	if restarted.tableByID(table.ID) == nil {
+		t.Error("expected the club table reopened after a restart")
+	}
+}
+
+// TestClubStore_LedgerSettlesUp verifies a settle-up report has the biggest losers pay the
+// biggest winners, leaves rake unpaid, starts a new period and is kept across a reload
+func TestClubStore_LedgerSettlesUp(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "clubs.json")
+	store, _ := LoadClubStore(path)
+	start := time.Now()
+	club, err := store.Create("Friday Game", "Olive", start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, net := range map[string]int{"Mia": 300, "Max": -250, "Olive": -120, "mia": 50} {
+		if err := store.RecordNet(club.ID, name, net); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	end := start.Add(time.Hour)
+	settlement, ok, err := store.Settle(club.ID, end)
+	if err != nil || !ok {
+		t.Fatalf("expected a report, got %v (%v)", ok, err)
+	}
+	wantPositions := []ClubPosition{{"mia", 350}, {"olive", -120}, {"max", -250}}
+	wantTransfers := []ClubTransfer{{"max", "mia", 250}, {"olive", "mia", 100}}
+	if !slices.Equal(settlement.Positions, wantPositions) || !slices.Equal(settlement.Transfers, wantTransfers) {
+		t.Errorf("expected %v settled by %v, got %v settled by %v", wantPositions, wantTransfers, settlement.Positions, settlement.Transfers)
+	}
+	if !settlement.From.Equal(start) || !settlement.To.Equal(end) {
+		t.Errorf("expected the report to cover %v to %v, got %v to %v", start, end, settlement.From, settlement.To)
+	}
+	if _, ok, _ := store.Settle(club.ID, end.Add(time.Hour)); ok {
+		t.Error("expected nothing to settle in an empty period")
+	}
+	if due := store.DueForSettlement(end.Add(time.Hour+time.Minute), time.Hour); len(due) != 0 {
+		t.Errorf("expected the new period to start at the last settle-up, got %v due", due)
+	}
+
+	reloaded, _ := LoadClubStore(path)
+	kept, _ := reloaded.Club(club.ID)
+	if len(kept.Ledger) != 0 || len(kept.Settlements) != 1 || !slices.Equal(kept.Settlements[0].Transfers, wantTransfers) {
+		t.Errorf("expected the report kept and the ledger cleared, got %+v", kept)
+	}
+}
+
+// TestClubs_StaysFeedTheLedgerAndOwnerGetsReport verifies stays at a club table land on the
+// club's ledger, which only the owner and managers see, and that the scheduled settle-up
+// reaches the owner
+func TestClubs_StaysFeedTheLedgerAndOwnerGetsReport(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{Clubs: ClubConfig{Enabled: true, SettleEvery: time.Hour}})
+	club, _ := server.clubs.Create("Friday Game", "Olive", time.Now())
+	club, _ = server.clubs.Join(club.InviteCode, "Gus", time.Now())
+	club, table, _ := server.clubs.AddTable(club.ID, "Olive", ClubTable{Name: "Main", SmallBlind: 5, BigBlind: 10, BuyIn: 500}, 0)
+	server.openClubTable(club.ID, table)
+
+	owner, _ := server.sessionManager.CreateSession("Olive")
+	ownerClient := connectTestClient(server, owner.Token)
+	guest, _ := server.sessionManager.CreateSession("Gus")
+	guestClient := connectTestClient(server, guest.Token)
+	server.endTableSession(guest.Token, "Gus", SessionSummary{TableID: table.ID, HandsPlayed: 4, NetChips: -80})
+	server.endTableSession(owner.Token, "Olive", SessionSummary{TableID: table.ID, HandsPlayed: 4, NetChips: 75})
+	server.endTableSession(guest.Token, "Gus", SessionSummary{TableID: table.ID, NetChips: 0})
+
+	payload, _ := json.Marshal(ClubPayload{ClubID: club.ID})
+	if err := guestClient.HandleClubLedger(server.sessionManager, server, payload); err == nil {
+		t.Error("expected a member refused the ledger")
+	}
+	if err := ownerClient.HandleClubLedger(server.sessionManager, server, payload); err != nil {
+		t.Fatal(err)
+	}
+	ledger := payloadsOf[ClubLedgerPayload](t, ownerClient, "club_ledger")
+	if len(ledger) != 1 || !slices.Equal(ledger[0].Positions, []ClubPosition{{"olive", 75}, {"gus", -80}}) {
+		t.Fatalf("expected both stays on the ledger, got %+v", ledger)
+	}
+
+	server.settleDueClubs(time.Now())
+	if got := payloadsOf[ClubSettlement](t, ownerClient, "club_settlement"); len(got) != 0 {
+		t.Errorf("expected no report before the period is over, got %+v", got)
+	}
+	server.settleDueClubs(time.Now().Add(2 * time.Hour))
+	got := payloadsOf[ClubSettlement](t, ownerClient, "club_settlement")
+	if len(got) != 1 || !slices.Equal(got[0].Transfers, []ClubTransfer{{"gus", "olive", 75}}) {
+		t.Errorf("expected the owner told Gus owes 75, got %+v", got)
+	}
+	if err := ownerClient.HandleSettleClub(server.sessionManager, server, slog.Default(), payload); err == nil {
+		t.Error("expected an empty period refused a settle-up")
+	}
+}
+
+// TestClubs_ImpersonatorRefused verifies a session that takes a club owner's name can neither
+// run the club, read its ledger nor sit at its tables while the owner is connected, and that
+// the owner is refused too until the impostor's session ends
+func TestClubs_ImpersonatorRefused(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{Clubs: ClubConfig{Enabled: true}})
+	owner, _ := server.sessionManager.CreateSession("Olive")
+	ownerClient := connectTestClient(server, owner.Token)
+	createPayload, _ := json.Marshal(CreateClubPayload{Name: "Friday Game"})
+	if err := ownerClient.HandleCreateClub(server.sessionManager, server, slog.Default(), createPayload); err != nil {
+		t.Fatal(err)
+	}
+	club := payloadsOf[ClubView](t, ownerClient, "club")[0]
+	tablePayload, _ := json.Marshal(CreateClubTablePayload{ClubID: club.ID, Name: "Main", SmallBlind: 5, BigBlind: 10, BuyIn: 500})
+	if err := ownerClient.HandleCreateClubTable(server.sessionManager, server, slog.Default(), tablePayload); err != nil {
+		t.Fatal(err)
+	}
+	table := server.tableByID(payloadsOf[ClubView](t, ownerClient, "club")[0].Tables[0].ID)
+
+	impostor, _ := server.sessionManager.CreateSession("olive")
+	impostorClient := connectTestClient(server, impostor.Token)
+	clubPayload, _ := json.Marshal(ClubPayload{ClubID: club.ID})
+	for action, err := range map[string]error{
+		"rotate_club_invite": impostorClient.HandleRotateClubInvite(server.sessionManager, server, slog.Default(), clubPayload),
+		"club_ledger":        impostorClient.HandleClubLedger(server.sessionManager, server, clubPayload),
+		"take_seat":          func() error { _, err := server.seatPlayer(impostor.Token, "", table); return err }(),
+		"owner":              ownerClient.HandleRotateClubInvite(server.sessionManager, server, slog.Default(), clubPayload),
+	} {
+		if key, _ := errorMessageKey(err); key != "error.club_name_shared" {
+			t.Errorf("%s: expected error.club_name_shared, got %v", action, err)
+		}
+	}
+	if len(drainRawMessages(impostorClient)) != 0 {
+		t.Error("expected nothing about the club sent to the impostor")
+	}
+	if rotated, _ := server.clubs.Club(club.ID); rotated.InviteCode != club.InviteCode {
+		t.Error("expected the invite code unchanged")
+	}
+
+	if err := server.sessionManager.RemoveSession(impostor.Token); err != nil {
+		t.Fatal(err)
+	}
+	if err := ownerClient.HandleRotateClubInvite(server.sessionManager, server, slog.Default(), clubPayload); err != nil {
+		t.Errorf("expected the owner back in charge once alone, got %v", err)
+	}
+}
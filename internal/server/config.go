@@ -0,0 +1,698 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the tunable settings of the poker server
+// The zero value is valid: every automatic behavior is disabled, hands
+// only start when a client sends start_hand, and the default tables are used
+type Config struct {
+	// NextHandDelay is the pause between the end of a hand and the automatic start
+	// of the next one. Zero disables automatic hand scheduling.
+	NextHandDelay time.Duration `yaml:"nextHandDelay"`
+
+	// ActionTimeout is how long a player has to act before the server checks
+	// (or folds) for them. Zero disables the action clock.
+	ActionTimeout time.Duration `yaml:"actionTimeout"`
+
+	// TimeBank is extra time each player has at a table, on top of the action clock, to spend
+	// across all their turns there. Zero disables time banks.
+	TimeBank time.Duration `yaml:"timeBank"`
+
+	// ReconnectGrace is how long a seated player whose connection drops keeps their seat
+	// to reconnect. Zero clears the seat at once.
+	ReconnectGrace time.Duration `yaml:"reconnectGrace"`
+
+	// SeatReservation is how long a seat taken with reserve_seat is held while the player
+	// chooses their buy-in. Zero disables reservations.
+	SeatReservation time.Duration `yaml:"seatReservation"`
+
+	// DiagnosticsAddr is the address of the opt-in diagnostics listener
+	// (pprof, goroutine dumps, table snapshots). Empty disables it.
+	DiagnosticsAddr string `yaml:"diagnosticsAddr"`
+
+	// Tables lists the tables created at startup. Empty uses DefaultTables.
+	Tables []TableConfig `yaml:"tables"`
+
+	// Pacing spaces out the dealing of the flop, turn and river. The zero value deals instantly.
+	Pacing PacingConfig `yaml:"pacing"`
+
+	// Showdown sets who reveals first at showdown and the pause between reveals. The zero value
+	// starts with the river's last aggressor and reveals every hand at once.
+	Showdown ShowdownConfig `yaml:"showdown"`
+
+	// TableBreaking merges nearly empty cash tables of the same stakes, with the players'
+	// consent, and closes tables left empty. The zero value leaves tables alone.
+	// Interval needs a restart; the rest can be reloaded.
+	TableBreaking TableBreakingConfig `yaml:"tableBreaking"`
+
+	// Rake is the house fee taken from each pot. The zero value takes no rake.
+	Rake RakeConfig `yaml:"rake"`
+
+	// Features toggles optional behavior
+	Features FeatureFlags `yaml:"features"`
+
+	// TLS enables HTTPS on the main listener. The zero value serves plain HTTP.
+	TLS TLSConfig `yaml:"tls"`
+
+	// TrustedProxies lists the IPs or CIDR ranges of reverse proxies whose
+	// X-Forwarded-For header is believed. Empty trusts no one.
+	TrustedProxies []string `yaml:"trustedProxies"`
+
+	// AllowedOrigins lists the browser origins, besides the server's own, that may open
+	// WebSocket connections and make cross-origin requests. Entries look like
+	// "https://poker.example.com", "https://*.example.com" or "localhost:5173";
+	// "*" allows every origin and is meant for development only.
+	AllowedOrigins []string `yaml:"allowedOrigins"`
+
+	// SessionTTL is how long a session lives after creation or its last renewal.
+	// Expired sessions are removed and their players unseated. Zero disables expiry.
+	SessionTTL time.Duration `yaml:"sessionTTL"`
+
+	// SessionPolicy decides what happens when a session that is already connected
+	// connects again: SessionPolicyTakeover (the default when empty) moves the session
+	// to the new connection, SessionPolicyReject refuses the new connection
+	SessionPolicy string `yaml:"sessionPolicy"`
+
+	// AdminToken enables the /admin API for requests carrying it as a bearer token.
+	// Empty disables the API.
+	AdminToken string `yaml:"adminToken"`
+
+	// BanListFile is where bans are persisted. Empty keeps bans in memory only.
+	BanListFile string `yaml:"banListFile"`
+
+	// AccountStoreFile is where per-account state such as bonus claims is persisted.
+	// Empty keeps it in memory only, so bonus cooldowns reset on restart.
+	AccountStoreFile string `yaml:"accountStoreFile"`
+
+	// ClubStoreFile is where clubs, their members and their tables are persisted. Empty keeps
+	// them in memory only.
+	ClubStoreFile string `yaml:"clubStoreFile"`
+
+	// MaxConnectionsPerIP caps concurrent WebSocket connections from one client IP.
+	// Zero means unlimited.
+	MaxConnectionsPerIP int `yaml:"maxConnectionsPerIP"`
+
+	// Bandwidth compresses messages to clients that support it and trims lobby_state for large
+	// lobbies. The zero value does neither. Compression changes apply to new connections.
+	Bandwidth BandwidthConfig `yaml:"bandwidth"`
+
+	// Abuse bans IPs that keep sending malformed or unknown messages. The zero value never bans.
+	Abuse AbuseConfig `yaml:"abuse"`
+
+	// HandRetention is the regulatory minimum time finished hands are kept. Hands dealt to a
+	// player who limited their history are deleted once this old; others stay until the replay
+	// store evicts them. Zero keeps every hand. Turning it on or off needs a restart.
+	HandRetention time.Duration `yaml:"handRetention"`
+
+	// RNGAuditFile is where every hand's shuffle seed, commitment and deck order are
+	// appended as a hash-chained JSON Lines log, checkable with cmd/rngaudit.
+	// Empty disables the audit log.
+	RNGAuditFile string `yaml:"rngAuditFile"`
+
+	// RNGSources names external random sources, such as a certified hardware RNG or remote
+	// service, that tables draw their shuffle seeds from (TableConfig.RNGSource). Changes need a restart.
+	RNGSources map[string]RNGSourceConfig `yaml:"rngSources"`
+
+	// RNGSelfTest schedules statistical self-tests of the shuffler, reported by GET /admin/rng.
+	// The zero value runs them only on request. Samples can be reloaded; Interval needs a restart.
+	RNGSelfTest RNGSelfTestConfig `yaml:"rngSelfTest"`
+
+	// Fraud tunes the anti-fraud detector, whose alerts are listed by the admin API.
+	// The zero value raises no alerts.
+	Fraud FraudConfig `yaml:"fraud"`
+
+	// CallClock lets opponents cut a tanking player's time to act. The zero value disables it.
+	CallClock CallClockConfig `yaml:"callClock"`
+
+	// Emotes lets seated players send predefined emotes and throwables. The zero value disables them.
+	Emotes EmoteConfig `yaml:"emotes"`
+
+	// Bankroll makes buy-ins come out of per-currency session balances and cash-outs go
+	// back into them. The zero value keeps buy-ins free.
+	Bankroll BankrollConfig `yaml:"bankroll"`
+
+	// Loyalty awards points for the rake players are charged, redeemable for chips or
+	// tournament tickets through the admin API. The zero value awards no points.
+	Loyalty LoyaltyConfig `yaml:"loyalty"`
+
+	// Clubs lets players found clubs with private tables for their members. The zero value
+	// disables them.
+	Clubs ClubConfig `yaml:"clubs"`
+
+	// Equity bounds the equity simulations behind replay snippets. The zero value runs them on
+	// half the CPUs with no time limit. Timeout can be reloaded; Workers needs a restart.
+	Equity EquityConfig `yaml:"equity"`
+}
+
+// TableConfig describes one table and its stakes
+type TableConfig struct {
+	Name       string `yaml:"name"`
+	SmallBlind int    `yaml:"smallBlind"`
+	BigBlind   int    `yaml:"bigBlind"`
+	BuyIn      int    `yaml:"buyIn"` // Stack given to a player when they sit down
+	// MinBuyIn and MaxBuyIn bound the stack a player may choose after reserving a seat
+	// (see Config.SeatReservation). Zero means BuyIn.
+	MinBuyIn int `yaml:"minBuyIn"`
+	MaxBuyIn int `yaml:"maxBuyIn"`
+	// ShowStats shows each player's hands played and VPIP to everyone at the table.
+	// Off by default since it exposes how players play.
+	ShowStats bool `yaml:"showStats"`
+	// Currency is the chip currency played at the table: "play" (default) or "ledger".
+	// Ledger tables need bankroll accounting enabled.
+	Currency ChipCurrency `yaml:"currency"`
+
+	// Lobby metadata chosen by the operator. Tags such as "beginners" or "deep stack"
+	// can be used to filter the lobby.
+	Description string   `yaml:"description"`
+	Theme       string   `yaml:"theme"`
+	Tags        []string `yaml:"tags"`
+
+	// Speed is "regular" (default), "turbo" or "hyper". Turbo halves the action clock, the
+	// pause between hands and street pacing; hyper quarters them.
+	Speed string `yaml:"speed"`
+
+	// HeadsUp makes a two-seat winner-stays table: players queue with "challenge" and the
+	// next challenger takes the loser's seat, or the loser is offered a rematch.
+	HeadsUp bool `yaml:"headsUp"`
+
+	// Hosts names the players who may take the table's host seat: a seat outside the game that
+	// is never dealt in, from which the host chats to the table, pauses dealing and calls bomb
+	// pots. Empty means the table has no host seat.
+	Hosts []string `yaml:"hosts"`
+	// BombPotAnte is what every player antes in a bomb pot; zero means two big blinds
+	BombPotAnte int `yaml:"bombPotAnte"`
+
+	// Practice marks a training table, played for play chips only, where players may turn on
+	// preflop hints from a simple chart. Hints are never given at other tables.
+	Practice bool `yaml:"practice"`
+
+	// Anonymous shows players to each other under aliases that change with every table and
+	// session instead of their names, so nobody can track how they play. The server still keeps
+	// real names for accounting.
+	Anonymous bool `yaml:"anonymous"`
+
+	// ConfirmRaisePercent makes players confirm, within two seconds, any raise putting in more
+	// than this percent of their stack, so a misclick cannot shove. Zero never asks.
+	ConfirmRaisePercent int `yaml:"confirmRaisePercent"`
+
+	// BetIncrement makes every bet and raise a multiple of this many chips, usually the small
+	// blind; all-ins may be any amount. Zero allows any amount.
+	BetIncrement int `yaml:"betIncrement"`
+
+	// RNGSource names the Config.RNGSources entry the table's shuffle seeds come from. Empty uses
+	// the operating system's CSPRNG.
+	RNGSource string `yaml:"rngSource"`
+}
+
+// RakeConfig describes the house fee taken from each pot
+type RakeConfig struct {
+	Percent      float64 `yaml:"percent"`      // Share of the pot taken, 0-100
+	Cap          int     `yaml:"cap"`          // Maximum rake per hand; 0 means no cap
+	NoFlopNoDrop bool    `yaml:"noFlopNoDrop"` // Take no rake from hands that end before the flop
+}
+
+// PacingConfig sets the pause before each street is dealt once betting on the previous
+// one closes, including all-in runouts. Instant ignores the delays, for bots and tests.
+type PacingConfig struct {
+	Flop    time.Duration `yaml:"flop"`
+	Turn    time.Duration `yaml:"turn"`
+	River   time.Duration `yaml:"river"`
+	Instant bool          `yaml:"instant"`
+}
+
+// delay returns the pause before street is dealt
+func (c PacingConfig) delay(street string) time.Duration {
+	if c.Instant {
+		return 0
+	}
+	switch street {
+	case "flop":
+		return c.Flop
+	case "turn":
+		return c.Turn
+	case "river":
+		return c.River
+	default:
+		return 0
+	}
+}
+
+// FeatureFlags toggles optional behavior; every flag defaults to off
+type FeatureFlags struct {
+	// DisableManualStart rejects start_hand messages so only the scheduler deals hands
+	DisableManualStart bool `yaml:"disableManualStart"`
+	// Narration sends narration messages describing table events to everyone at the table
+	Narration bool `yaml:"narration"`
+	// ReplaySnippets keeps a shareable replay, with each player's equity street by street, of
+	// all-ins where the board turned the hand around
+	ReplaySnippets bool `yaml:"replaySnippets"`
+	// HighCardButton deals each player a card face up before a table's first hand and gives the
+	// button to the highest, instead of to the first seat taken
+	HighCardButton bool `yaml:"highCardButton"`
+}
+
+// FileConfig is the layout of the YAML configuration file: process settings
+// that only cmd/server uses plus the server Config
+type FileConfig struct {
+	Port     string `yaml:"port"`
+	LogLevel string `yaml:"logLevel"`
+	// LogForensicCards lets cards into the logs, which are otherwise redacted; only for
+	// investigating a problem on a server whose logs are kept private
+	LogForensicCards bool `yaml:"logForensicCards"`
+	Config           `yaml:",inline"`
+}
+
+// Default table stakes, used when a TableConfig leaves them unset
+const (
+	defaultSmallBlind = 10
+	defaultBigBlind   = 20
+	defaultBuyIn      = 1000
+)
+
+// applyDefaults fills stakes left out of the config file with the default 10/20 stakes
+func (tc *TableConfig) applyDefaults() {
+	if tc.SmallBlind == 0 {
+		tc.SmallBlind = defaultSmallBlind
+	}
+	if tc.BigBlind == 0 {
+		tc.BigBlind = defaultBigBlind
+	}
+	if tc.BuyIn == 0 {
+		tc.BuyIn = defaultBuyIn
+	}
+}
+
+// DefaultConfig returns the configuration used by the server binary
+func DefaultConfig() Config {
+	return Config{
+		NextHandDelay:   5 * time.Second,
+		ActionTimeout:   30 * time.Second,
+		ReconnectGrace:  30 * time.Second,
+		SeatReservation: time.Minute,
+		SessionTTL:      24 * time.Hour,
+		Tables:          DefaultTables(),
+		RNGSelfTest:     RNGSelfTestConfig{Interval: time.Hour},
+		Pacing: PacingConfig{
+			Flop:  time.Second,
+			Turn:  time.Second,
+			River: time.Second,
+		},
+		Showdown: ShowdownConfig{RevealInterval: time.Second},
+		Features: FeatureFlags{HighCardButton: true},
+		TableBreaking: TableBreakingConfig{
+			Interval:       time.Minute,
+			MergeBelow:     2,
+			ConsentTimeout: 30 * time.Second,
+			CloseAfter:     10 * time.Minute,
+		},
+
+		MaxConnectionsPerIP: 10,
+		Bandwidth:           BandwidthConfig{Compress: true, TrimLobbyAbove: 100},
+		Abuse: AbuseConfig{
+			MaxStrikes:  20,
+			Window:      time.Minute,
+			BanDuration: 15 * time.Minute,
+		},
+		Fraud: FraudConfig{
+			ChipDumpFolds:       5,
+			ChipDumpWindow:      time.Hour,
+			LargeBetPotFraction: 0.5,
+			SharedIP:            true,
+		},
+		Bankroll: BankrollConfig{
+			Enabled:           true,
+			StartingPlayChips: 10000,
+			DailyBonus:        2000,
+			BonusThreshold:    1000,
+			BonusCooldown:     24 * time.Hour,
+		},
+		Clubs:  ClubConfig{Enabled: true, MaxTables: 4, SettleEvery: 7 * 24 * time.Hour},
+		Equity: EquityConfig{Workers: 2, Timeout: 10 * time.Second},
+	}
+}
+
+// DefaultTables returns the four 10/20 tables the server has always offered
+func DefaultTables() []TableConfig {
+	tables := make([]TableConfig, 4)
+	for i := range tables {
+		tables[i] = TableConfig{
+			Name:       fmt.Sprintf("Table %d", i+1),
+			SmallBlind: defaultSmallBlind,
+			BigBlind:   defaultBigBlind,
+			BuyIn:      defaultBuyIn,
+		}
+	}
+	return tables
+}
+
+// LoadConfigFile reads and validates a YAML configuration file
+// Settings missing from the file keep the values from DefaultConfig, and
+// unknown keys are rejected so typos do not silently fall back to defaults
+func LoadConfigFile(path string) (FileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return FileConfig{}, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	fileConfig := FileConfig{
+		Port:     "8080",
+		LogLevel: "info",
+		Config:   DefaultConfig(),
+	}
+	// Tables listed in the file replace the defaults rather than merging with them
+	fileConfig.Tables = nil
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&fileConfig); err != nil {
+		return FileConfig{}, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	if len(fileConfig.Tables) == 0 {
+		fileConfig.Tables = DefaultTables()
+	}
+	for i := range fileConfig.Tables {
+		fileConfig.Tables[i].applyDefaults()
+	}
+
+	if err := fileConfig.Validate(); err != nil {
+		return FileConfig{}, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	return fileConfig, nil
+}
+
+// Validate reports the first setting that the server cannot run with
+func (c Config) Validate() error {
+	if c.NextHandDelay < 0 {
+		return fmt.Errorf("nextHandDelay must not be negative")
+	}
+	if c.ActionTimeout < 0 {
+		return fmt.Errorf("actionTimeout must not be negative")
+	}
+	if c.TimeBank < 0 {
+		return fmt.Errorf("timeBank must not be negative")
+	}
+	if c.SessionTTL < 0 {
+		return fmt.Errorf("sessionTTL must not be negative")
+	}
+	if c.ReconnectGrace < 0 {
+		return fmt.Errorf("reconnectGrace must not be negative")
+	}
+	if c.SeatReservation < 0 {
+		return fmt.Errorf("seatReservation must not be negative")
+	}
+
+	for i, table := range c.Tables {
+		if table.Name == "" {
+			return fmt.Errorf("tables[%d]: name is required", i)
+		}
+		if table.SmallBlind <= 0 {
+			return fmt.Errorf("tables[%d]: smallBlind must be positive", i)
+		}
+		if table.BigBlind < table.SmallBlind {
+			return fmt.Errorf("tables[%d]: bigBlind must be at least smallBlind", i)
+		}
+		if table.BuyIn < table.BigBlind {
+			return fmt.Errorf("tables[%d]: buyIn must cover at least one big blind", i)
+		}
+		if table.MinBuyIn != 0 && (table.MinBuyIn < table.BigBlind || table.MinBuyIn > table.BuyIn) {
+			return fmt.Errorf("tables[%d]: minBuyIn must be between one big blind and buyIn", i)
+		}
+		if table.MaxBuyIn != 0 && table.MaxBuyIn < table.BuyIn {
+			return fmt.Errorf("tables[%d]: maxBuyIn must be at least buyIn", i)
+		}
+		if err := validateCurrency(table.Currency); err != nil {
+			return fmt.Errorf("tables[%d]: %w", i, err)
+		}
+		if table.Currency == CurrencyLedger && !c.Bankroll.Enabled {
+			return fmt.Errorf("tables[%d]: ledger tables require bankroll.enabled", i)
+		}
+		if err := validateSpeed(table.Speed); err != nil {
+			return fmt.Errorf("tables[%d]: %w", i, err)
+		}
+		for _, tag := range table.Tags {
+			if strings.TrimSpace(tag) == "" {
+				return fmt.Errorf("tables[%d]: tags must not be empty", i)
+			}
+		}
+		for _, host := range table.Hosts {
+			if strings.TrimSpace(host) == "" {
+				return fmt.Errorf("tables[%d]: hosts must not be empty", i)
+			}
+		}
+		if table.BombPotAnte < 0 {
+			return fmt.Errorf("tables[%d]: bombPotAnte must not be negative", i)
+		}
+		if table.Practice && table.Currency == CurrencyLedger {
+			return fmt.Errorf("tables[%d]: practice tables are played for play chips", i)
+		}
+		if table.ConfirmRaisePercent < 0 || table.ConfirmRaisePercent > 100 {
+			return fmt.Errorf("tables[%d]: confirmRaisePercent must be between 0 and 100", i)
+		}
+		if table.BetIncrement < 0 {
+			return fmt.Errorf("tables[%d]: betIncrement must not be negative", i)
+		}
+		if _, ok := c.RNGSources[table.RNGSource]; table.RNGSource != "" && !ok {
+			return fmt.Errorf("tables[%d]: rngSource %q is not in rngSources", i, table.RNGSource)
+		}
+	}
+	for name, source := range c.RNGSources {
+		if err := source.validate(name); err != nil {
+			return err
+		}
+	}
+
+	if c.Pacing.Flop < 0 || c.Pacing.Turn < 0 || c.Pacing.River < 0 {
+		return fmt.Errorf("pacing delays must not be negative")
+	}
+
+	if c.Rake.Percent < 0 || c.Rake.Percent > 100 {
+		return fmt.Errorf("rake.percent must be between 0 and 100")
+	}
+	if c.Rake.Cap < 0 {
+		return fmt.Errorf("rake.cap must not be negative")
+	}
+
+	if err := validateSessionPolicy(c.SessionPolicy); err != nil {
+		return err
+	}
+
+	if c.HandRetention < 0 {
+		return fmt.Errorf("handRetention must not be negative")
+	}
+	if c.MaxConnectionsPerIP < 0 {
+		return fmt.Errorf("maxConnectionsPerIP must not be negative")
+	}
+	if err := c.Bandwidth.validate(); err != nil {
+		return err
+	}
+	if err := c.Abuse.validate(); err != nil {
+		return err
+	}
+	if err := c.Fraud.validate(); err != nil {
+		return err
+	}
+	if err := c.CallClock.validate(); err != nil {
+		return err
+	}
+	if err := c.Emotes.validate(); err != nil {
+		return err
+	}
+	if err := c.Bankroll.validate(); err != nil {
+		return err
+	}
+	if err := c.Loyalty.validate(); err != nil {
+		return err
+	}
+	if err := c.RNGSelfTest.validate(); err != nil {
+		return err
+	}
+	if err := c.Showdown.validate(); err != nil {
+		return err
+	}
+	if err := c.TableBreaking.validate(); err != nil {
+		return err
+	}
+	if err := c.Clubs.validate(); err != nil {
+		return err
+	}
+	if err := c.Equity.validate(); err != nil {
+		return err
+	}
+
+	if err := c.TLS.validate(); err != nil {
+		return err
+	}
+	if _, err := parseTrustedProxies(c.TrustedProxies); err != nil {
+		return fmt.Errorf("trustedProxies: %w", err)
+	}
+	if err := validateAllowedOrigins(c.AllowedOrigins); err != nil {
+		return fmt.Errorf("allowedOrigins: %w", err)
+	}
+
+	return nil
+}
+
+// Config returns the server's current configuration (thread-safe)
+func (s *Server) Config() Config {
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
+
+	return s.config
+}
+
+// ReloadConfig applies the hot-reloadable parts of next: timers and time banks, rake, feature flags, allowed origins,
+// the session policy, the admin token, connection limits, bandwidth settings, abuse bans, fraud detection, the call
+// clock, emotes, clubs and the equity timeout
+// Tables, bankroll accounting, listener settings, the session TTL, the ban list file, the equity workers and the diagnostics address only take effect on restart; changes to them
+// are logged and ignored. Running timers keep their deadlines; new values apply from
+// the next hand: a hand in progress keeps the action clock, pacing and rake it was dealt with,
+// and its table is sent rules_pending. Returns an error and changes nothing if next is invalid.
+func (s *Server) ReloadConfig(next Config) error {
+	if err := next.Validate(); err != nil {
+		return err
+	}
+
+	s.configMu.Lock()
+	current := s.config
+	rulesChanged := rulesFromConfig(next) != rulesFromConfig(current)
+
+	if next.DiagnosticsAddr != current.DiagnosticsAddr {
+		s.logger.Warn("diagnosticsAddr change requires a restart", "current", current.DiagnosticsAddr, "requested", next.DiagnosticsAddr)
+	}
+	if !slices.EqualFunc(next.Tables, current.Tables, func(a, b TableConfig) bool { return reflect.DeepEqual(a, b) }) {
+		s.logger.Warn("table changes require a restart")
+	}
+	if !slices.Equal(next.TrustedProxies, current.TrustedProxies) || !tlsEqual(next.TLS, current.TLS) {
+		s.logger.Warn("tls and trustedProxies changes require a restart")
+	}
+	if next.BanListFile != current.BanListFile {
+		s.logger.Warn("banListFile change requires a restart", "current", current.BanListFile, "requested", next.BanListFile)
+	}
+	if next.AccountStoreFile != current.AccountStoreFile {
+		s.logger.Warn("accountStoreFile change requires a restart", "current", current.AccountStoreFile, "requested", next.AccountStoreFile)
+	}
+	if next.ClubStoreFile != current.ClubStoreFile {
+		s.logger.Warn("clubStoreFile change requires a restart", "current", current.ClubStoreFile, "requested", next.ClubStoreFile)
+	}
+	if next.RNGAuditFile != current.RNGAuditFile {
+		s.logger.Warn("rngAuditFile change requires a restart", "current", current.RNGAuditFile, "requested", next.RNGAuditFile)
+	}
+	if !maps.Equal(next.RNGSources, current.RNGSources) {
+		s.logger.Warn("rngSources changes require a restart")
+	}
+	if next.RNGSelfTest.Interval != current.RNGSelfTest.Interval {
+		s.logger.Warn("rngSelfTest.interval change requires a restart", "current", current.RNGSelfTest.Interval, "requested", next.RNGSelfTest.Interval)
+	}
+	if (next.HandRetention > 0) != (current.HandRetention > 0) {
+		s.logger.Warn("turning handRetention on or off requires a restart", "current", current.HandRetention, "requested", next.HandRetention)
+	}
+	if next.TableBreaking.Interval != current.TableBreaking.Interval {
+		s.logger.Warn("tableBreaking.interval change requires a restart", "current", current.TableBreaking.Interval, "requested", next.TableBreaking.Interval)
+	}
+	if next.Bankroll != current.Bankroll {
+		s.logger.Warn("bankroll changes require a restart")
+	}
+	if next.Equity.Workers != current.Equity.Workers {
+		s.logger.Warn("equity.workers change requires a restart", "current", current.Equity.Workers, "requested", next.Equity.Workers)
+	}
+	if next.SessionTTL != current.SessionTTL {
+		s.logger.Warn("sessionTTL change requires a restart", "current", current.SessionTTL, "requested", next.SessionTTL)
+	}
+
+	s.config.NextHandDelay = next.NextHandDelay
+	s.config.ActionTimeout = next.ActionTimeout
+	s.config.TimeBank = next.TimeBank
+	s.config.ReconnectGrace = next.ReconnectGrace
+	s.config.SeatReservation = next.SeatReservation
+	s.config.Pacing = next.Pacing
+	s.config.Showdown = next.Showdown
+	s.config.Rake = next.Rake
+	s.config.Loyalty = next.Loyalty
+	s.config.Features = next.Features
+	s.config.AllowedOrigins = next.AllowedOrigins
+	s.config.SessionPolicy = next.SessionPolicy
+	s.config.AdminToken = next.AdminToken
+	s.config.MaxConnectionsPerIP = next.MaxConnectionsPerIP
+	s.config.Bandwidth = next.Bandwidth
+	if (next.HandRetention > 0) == (current.HandRetention > 0) {
+		s.config.HandRetention = next.HandRetention
+	}
+	s.config.Abuse = next.Abuse
+	s.config.Fraud = next.Fraud
+	s.config.CallClock = next.CallClock
+	s.config.Emotes = next.Emotes
+	s.config.Clubs = next.Clubs
+	s.config.RNGSelfTest.Samples = next.RNGSelfTest.Samples
+	s.config.Equity.Timeout = next.Equity.Timeout
+	interval := s.config.TableBreaking.Interval
+	s.config.TableBreaking = next.TableBreaking
+	s.config.TableBreaking.Interval = interval
+	s.configMu.Unlock()
+
+	s.logger.Info("configuration reloaded",
+		"next_hand_delay", next.NextHandDelay,
+		"action_timeout", next.ActionTimeout,
+		"time_bank", next.TimeBank,
+		"reconnect_grace", next.ReconnectGrace,
+		"seat_reservation", next.SeatReservation,
+		"pacing", next.Pacing,
+		"showdown", next.Showdown,
+		"rake_percent", next.Rake.Percent,
+		"rake_cap", next.Rake.Cap,
+		"disable_manual_start", next.Features.DisableManualStart,
+		"narration", next.Features.Narration,
+		"replay_snippets", next.Features.ReplaySnippets,
+		"high_card_button", next.Features.HighCardButton,
+		"allowed_origins", next.AllowedOrigins,
+		"session_policy", next.SessionPolicy,
+		"admin_api", next.AdminToken != "",
+		"max_connections_per_ip", next.MaxConnectionsPerIP,
+		"hand_retention", next.HandRetention,
+		"compress", next.Bandwidth.Compress,
+		"trim_lobby_above", next.Bandwidth.TrimLobbyAbove,
+		"abuse_max_strikes", next.Abuse.MaxStrikes,
+		"fraud_chip_dump_folds", next.Fraud.ChipDumpFolds,
+		"fraud_shared_ip", next.Fraud.SharedIP,
+		"call_clock_duration", next.CallClock.Duration,
+		"emotes", next.Emotes.Enabled,
+		"clubs", next.Clubs.Enabled,
+		"club_settle_every", next.Clubs.SettleEvery,
+		"rng_self_test_samples", next.RNGSelfTest.Samples,
+		"equity_timeout", next.Equity.Timeout,
+		"table_breaking_merge_below", next.TableBreaking.MergeBelow,
+		"table_breaking_close_after", next.TableBreaking.CloseAfter,
+	)
+
+	if rulesChanged {
+		s.mu.RLock()
+		tables := slices.Clone(s.tables)
+		s.mu.RUnlock()
+		for _, table := range tables {
+			if table != nil {
+				s.announcePendingRules(table)
+			}
+		}
+	}
+	return nil
+}
+
+// tlsEqual reports whether two TLS configurations are the same
+func tlsEqual(a, b TLSConfig) bool {
+	return a.CertFile == b.CertFile &&
+		a.KeyFile == b.KeyFile &&
+		a.AutocertCacheDir == b.AutocertCacheDir &&
+		slices.Equal(a.AutocertDomains, b.AutocertDomains)
+}
@@ -0,0 +1,260 @@
+package server
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// updateConfig changes the running server's configuration under its lock, as ReloadConfig does,
+// for settings a test turns on after the server has started
+func updateConfig(server *Server, update func(config *Config)) {
+	server.configMu.Lock()
+	update(&server.config)
+	server.configMu.Unlock()
+}
+
+// writeConfigFile writes contents to a temporary YAML file and returns its path
+func writeConfigFile(t *testing.T, contents string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+// TestLoadConfigFile_ParsesAllSections verifies every section of the file is loaded
+func TestLoadConfigFile_ParsesAllSections(t *testing.T) {
+	path := writeConfigFile(t, `
+port: "9090"
+logLevel: debug
+nextHandDelay: 3s
+actionTimeout: 15s
+tables:
+  - name: Micro
+  - name: High Stakes
+    smallBlind: 50
+    bigBlind: 100
+    buyIn: 5000
+rake:
+  percent: 5
+  cap: 30
+  noFlopNoDrop: true
+features:
+  disableManualStart: true
+`)
+
+	fileConfig, err := LoadConfigFile(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFile failed: %v", err)
+	}
+
+	if fileConfig.Port != "9090" || fileConfig.LogLevel != "debug" {
+		t.Errorf("expected port 9090 and logLevel debug, got %q and %q", fileConfig.Port, fileConfig.LogLevel)
+	}
+	if fileConfig.NextHandDelay != 3*time.Second || fileConfig.ActionTimeout != 15*time.Second {
+		t.Errorf("expected timers 3s/15s, got %s/%s", fileConfig.NextHandDelay, fileConfig.ActionTimeout)
+	}
+	if len(fileConfig.Tables) != 2 {
+		t.Fatalf("expected 2 tables, got %d", len(fileConfig.Tables))
+	}
+	micro := TableConfig{Name: "Micro", SmallBlind: 10, BigBlind: 20, BuyIn: 1000}
+	if !reflect.DeepEqual(fileConfig.Tables[0], micro) {
+		t.Errorf("expected default stakes for Micro, got %+v", fileConfig.Tables[0])
+	}
+	highStakes := TableConfig{Name: "High Stakes", SmallBlind: 50, BigBlind: 100, BuyIn: 5000}
+	if !reflect.DeepEqual(fileConfig.Tables[1], highStakes) {
+		t.Errorf("expected %+v, got %+v", highStakes, fileConfig.Tables[1])
+	}
+	if fileConfig.Rake != (RakeConfig{Percent: 5, Cap: 30, NoFlopNoDrop: true}) {
+		t.Errorf("unexpected rake config %+v", fileConfig.Rake)
+	}
+	if !fileConfig.Features.DisableManualStart {
+		t.Error("expected disableManualStart to be set")
+	}
+}
+
+// TestLoadConfigFile_DefaultsForMissingSettings verifies an empty file yields the default config
+func TestLoadConfigFile_DefaultsForMissingSettings(t *testing.T) {
+	path := writeConfigFile(t, "{}\n")
+
+	fileConfig, err := LoadConfigFile(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFile failed: %v", err)
+	}
+
+	defaults := DefaultConfig()
+	if fileConfig.NextHandDelay != defaults.NextHandDelay || fileConfig.ActionTimeout != defaults.ActionTimeout {
+		t.Errorf("expected default timers, got %s/%s", fileConfig.NextHandDelay, fileConfig.ActionTimeout)
+	}
+	if len(fileConfig.Tables) != 4 {
+		t.Errorf("expected 4 default tables, got %d", len(fileConfig.Tables))
+	}
+}
+
+// TestLoadConfigFile_Rejects verifies unknown keys and invalid values fail startup
+func TestLoadConfigFile_Rejects(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		errPart  string
+	}{
+		{"unknown key", "nextHandDelai: 5s\n", "nextHandDelai"},
+		{"bad duration", "actionTimeout: soon\n", "soon"},
+		{"negative timer", "nextHandDelay: -1s\n", "nextHandDelay"},
+		{"negative session ttl", "sessionTTL: -1h\n", "sessionTTL"},
+		{"big blind below small blind", "tables:\n  - name: T\n    smallBlind: 20\n    bigBlind: 10\n", "bigBlind"},
+		{"missing table name", "tables:\n  - smallBlind: 5\n", "name"},
+		{"rake over 100", "rake:\n  percent: 150\n", "rake.percent"},
+		{"negative call clock", "callClock:\n  duration: -10s\n", "callClock"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadConfigFile(writeConfigFile(t, tt.contents))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.errPart) {
+				t.Errorf("expected error mentioning %q, got %v", tt.errPart, err)
+			}
+		})
+	}
+}
+
+// TestNewServerWithConfig_CreatesConfiguredTables verifies tables and stakes come from the config
+func TestNewServerWithConfig_CreatesConfiguredTables(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{
+		Tables: []TableConfig{{Name: "High Stakes", SmallBlind: 50, BigBlind: 100, BuyIn: 5000}},
+	})
+
+	if len(server.tables) != 1 {
+		t.Fatalf("expected 1 table, got %d", len(server.tables))
+	}
+	table := server.tables[0]
+	if table.ID != "table-1" || table.Name != "High Stakes" {
+		t.Errorf("expected table-1 High Stakes, got %s %s", table.ID, table.Name)
+	}
+
+	token := "player1"
+	seat, err := table.AssignSeat(&token)
+	if err != nil {
+		t.Fatalf("failed to assign seat: %v", err)
+	}
+	if seat.Stack != 5000 {
+		t.Errorf("expected buy-in stack 5000, got %d", seat.Stack)
+	}
+
+	token2 := "player2"
+	if _, err := table.AssignSeat(&token2); err != nil {
+		t.Fatalf("failed to assign seat: %v", err)
+	}
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("failed to start hand: %v", err)
+	}
+	table.mu.RLock()
+	defer table.mu.RUnlock()
+	hand := table.CurrentHand
+	if hand.PlayerBets[hand.SmallBlindSeat] != 50 || hand.PlayerBets[hand.BigBlindSeat] != 100 {
+		t.Errorf("expected blinds 50/100, got %d/%d", hand.PlayerBets[hand.SmallBlindSeat], hand.PlayerBets[hand.BigBlindSeat])
+	}
+}
+
+// TestReloadConfig_AppliesHotSettingsOnly verifies reload updates timers, rake and flags but not tables
+func TestReloadConfig_AppliesHotSettingsOnly(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), DefaultConfig())
+
+	next := DefaultConfig()
+	next.NextHandDelay = time.Second
+	next.ActionTimeout = 10 * time.Second
+	next.Rake = RakeConfig{Percent: 5, Cap: 20}
+	next.Features.DisableManualStart = true
+	next.Tables = []TableConfig{{Name: "Only", SmallBlind: 1, BigBlind: 2, BuyIn: 100}}
+
+	if err := server.ReloadConfig(next); err != nil {
+		t.Fatalf("ReloadConfig failed: %v", err)
+	}
+
+	config := server.Config()
+	if config.NextHandDelay != time.Second || config.ActionTimeout != 10*time.Second {
+		t.Errorf("expected reloaded timers, got %s/%s", config.NextHandDelay, config.ActionTimeout)
+	}
+	if config.Rake != next.Rake || !config.Features.DisableManualStart {
+		t.Errorf("expected reloaded rake and features, got %+v %+v", config.Rake, config.Features)
+	}
+	if len(config.Tables) != 4 || len(server.tables) != 4 {
+		t.Errorf("expected tables to be unchanged until restart")
+	}
+}
+
+// TestReloadConfig_RejectsInvalidConfig verifies an invalid reload leaves the running config intact
+func TestReloadConfig_RejectsInvalidConfig(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), DefaultConfig())
+
+	next := DefaultConfig()
+	next.ActionTimeout = -time.Second
+	if err := server.ReloadConfig(next); err == nil {
+		t.Fatal("expected error for negative actionTimeout")
+	}
+
+	if server.Config().ActionTimeout != DefaultConfig().ActionTimeout {
+		t.Error("expected running config to be unchanged")
+	}
+}
+
+// TestReloadConfig_HandKeepsItsRules verifies a reload during a hand leaves its action clock and
+// rake alone, tells the table what changes, and applies from the next hand
+func TestReloadConfig_HandKeepsItsRules(t *testing.T) {
+	cfg := Config{
+		ActionTimeout: 30 * time.Second,
+		Pacing:        PacingConfig{Instant: true},
+		Tables:        []TableConfig{{Name: "Main", SmallBlind: 10, BigBlind: 20, BuyIn: 1000}},
+	}
+	server := NewServerWithConfig(slog.Default(), cfg)
+	table := server.tables[0]
+	clients := seatNamed(t, server, table, "Alice", "Bob")
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	drainRawMessages(clients[0])
+
+	next := cfg
+	next.ActionTimeout = 10 * time.Second
+	next.Rake = RakeConfig{Percent: 5, Cap: 20}
+	if err := server.ReloadConfig(next); err != nil {
+		t.Fatalf("ReloadConfig failed: %v", err)
+	}
+
+	table.mu.RLock()
+	timeout, rake := table.actionTimeoutLocked(), table.rulesLocked().rake
+	table.mu.RUnlock()
+	if timeout != 30*time.Second || rake != (RakeConfig{}) {
+		t.Errorf("expected the hand to keep a 30s clock and no rake, got %s %+v", timeout, rake)
+	}
+	pending := payloadsOf[RulesPendingPayload](t, clients[0], "rules_pending")
+	want := TableRules{Blinds: BlindLevel{SmallBlind: 10, BigBlind: 20}, ActionTimeoutMs: 10000, RakePercent: 5, RakeCap: 20}
+	if len(pending) != 1 || pending[0].TableID != table.ID || pending[0].TableRules != want {
+		t.Fatalf("expected rules_pending with %+v, got %+v", want, pending)
+	}
+	if state := server.buildTableState(table, nil); state.PendingRules == nil || *state.PendingRules != want {
+		t.Errorf("expected table_state to show the pending rules, got %+v", state.PendingRules)
+	}
+
+	playOutChecking(t, server, table)
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	table.mu.RLock()
+	timeout = table.actionTimeoutLocked()
+	table.mu.RUnlock()
+	if timeout != 10*time.Second {
+		t.Errorf("expected the next hand on a 10s clock, got %s", timeout)
+	}
+	if state := server.buildTableState(table, nil); state.PendingRules != nil {
+		t.Errorf("expected no pending rules once applied, got %+v", state.PendingRules)
+	}
+}
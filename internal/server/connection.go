@@ -0,0 +1,140 @@
+package server
+
+import (
+	"time"
+)
+
+// Seat connection statuses, sent in connection_status messages and table_state
+const (
+	ConnectionConnected    = "connected"    // The player has a live connection
+	ConnectionDisconnected = "disconnected" // The connection dropped; the seat is cleared, or kept while the table is frozen
+	ConnectionReconnecting = "reconnecting" // The connection dropped; the seat is held until the reconnect deadline
+	ConnectionTimedOut     = "timed_out"    // The player did not reconnect in time and lost the seat
+)
+
+// seatDisconnect is a seated player whose connection dropped
+type seatDisconnect struct {
+	status   string     // ConnectionDisconnected or ConnectionReconnecting
+	deadline *time.Time // When a reconnecting seat is given up
+	timer    ClockTimer // Fires at deadline; nil for disconnected seats
+}
+
+// ConnectionStatusPayload represents the payload for connection_status messages, sent to the
+// table whenever a seated player's connection changes so the others know why play stalls
+type ConnectionStatusPayload struct {
+	SeatIndex         int    `json:"seatIndex"`
+	PlayerName        string `json:"playerName"`
+	Status            string `json:"status"`
+	ReconnectDeadline *int64 `json:"reconnectDeadline,omitempty"` // Unix ms, while reconnecting
+}
+
+// connectionLocked returns the connection status of the seated player with token and, while
+// they are reconnecting, when their seat is given up (caller must hold t.mu)
+func (t *Table) connectionLocked(token string) (string, *time.Time) {
+	if d, ok := t.disconnected[token]; ok {
+		return d.status, d.deadline
+	}
+	return ConnectionConnected, nil
+}
+
+// dropConnectionLocked forgets a player's dropped connection, stopping any reconnect timer,
+// and reports whether there was one (caller must hold t.mu)
+func (t *Table) dropConnectionLocked(token string) bool {
+	d, ok := t.disconnected[token]
+	if !ok {
+		return false
+	}
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	delete(t.disconnected, token)
+	return true
+}
+
+// broadcastConnectionStatus tells the table about a change to a seated player's connection
+func (s *Server) broadcastConnectionStatus(table *Table, token string, seatIndex int, status string, deadline *time.Time) {
+	payload := ConnectionStatusPayload{SeatIndex: seatIndex, Status: status}
+	if name, err := s.sessionManager.GetPlayerName(token); err == nil {
+		payload.PlayerName = name
+		if table.Anonymous {
+			payload.PlayerName = s.tableAlias(table.ID, token)
+		}
+	}
+	if deadline != nil {
+		ms := deadline.UnixMilli()
+		payload.ReconnectDeadline = &ms
+	}
+	if err := s.broadcastTableMessage(table, "connection_status", payload); err != nil {
+		s.logger.Warn("failed to broadcast connection_status", "tableId", table.ID, "error", err)
+	}
+}
+
+// keepDisconnectedSeat marks a player's seat as disconnected, or as reconnecting when grace is
+// positive; a reconnecting seat is cleared if the player has not reconnected after grace
+func (s *Server) keepDisconnectedSeat(table *Table, token string, seatIndex int, grace time.Duration) {
+	d := &seatDisconnect{status: ConnectionDisconnected}
+	table.mu.Lock()
+	table.dropConnectionLocked(token)
+	if grace > 0 {
+		deadline := table.clock().Now().Add(grace)
+		d.status = ConnectionReconnecting
+		d.deadline = &deadline
+		d.timer = table.clock().AfterFunc(grace, func() { s.reconnectTimedOut(table, token, d) })
+	}
+	if table.disconnected == nil {
+		table.disconnected = make(map[string]*seatDisconnect)
+	}
+	table.disconnected[token] = d
+	table.mu.Unlock()
+
+	s.broadcastConnectionStatus(table, token, seatIndex, d.status, d.deadline)
+	if err := s.broadcastTableState(table.ID, nil); err != nil {
+		s.logger.Warn("failed to broadcast table_state on disconnect", "error", err)
+	}
+}
+
+// reconnectTimedOut clears the seat of a player who did not reconnect in time
+func (s *Server) reconnectTimedOut(table *Table, token string, d *seatDisconnect) {
+	table.mu.Lock()
+	if table.disconnected[token] != d {
+		// The player reconnected, or the seat was cleared, first
+		table.mu.Unlock()
+		return
+	}
+	delete(table.disconnected, token)
+	table.mu.Unlock()
+
+	seat, found := table.GetSeatByToken(&token)
+	if !found {
+		return
+	}
+	s.broadcastConnectionStatus(table, token, seat.Index, ConnectionTimedOut, nil)
+	s.clearDisconnectedSeat(table, token)
+	s.logger.Info("player did not reconnect in time and lost their seat", "token", token, "tableId", table.ID)
+}
+
+// handleReconnect restores the seat of a player who connects again after losing their
+// connection, telling the table they are back
+func (s *Server) handleReconnect(token string) {
+	session, err := s.sessionManager.GetSession(token)
+	if err != nil || session.TableID == nil || session.SeatIndex == nil {
+		return
+	}
+	table := s.tableByID(*session.TableID)
+	if table == nil {
+		return
+	}
+
+	table.mu.Lock()
+	dropped := table.dropConnectionLocked(token)
+	table.mu.Unlock()
+	if !dropped {
+		return
+	}
+
+	s.logger.Info("player reconnected to their seat", "token", token, "tableId", table.ID)
+	s.broadcastConnectionStatus(table, token, *session.SeatIndex, ConnectionConnected, nil)
+	if err := s.broadcastTableState(table.ID, nil); err != nil {
+		s.logger.Warn("failed to broadcast table_state on reconnect", "error", err)
+	}
+}
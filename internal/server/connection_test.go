@@ -0,0 +1,107 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// connectionStatuses returns the connection_status messages client received
+func connectionStatuses(client *Client) []ConnectionStatusPayload {
+	var statuses []ConnectionStatusPayload
+	for _, raw := range drainRawMessages(client) {
+		var msg struct {
+			Type    string                  `json:"type"`
+			Payload ConnectionStatusPayload `json:"payload"`
+		}
+		if json.Unmarshal([]byte(raw), &msg) == nil && msg.Type == "connection_status" {
+			statuses = append(statuses, msg.Payload)
+		}
+	}
+	return statuses
+}
+
+// TestConnection_ReconnectWithinGrace verifies a dropped player's seat is held as reconnecting
+// and restored when they come back, with the table told each time
+func TestConnection_ReconnectWithinGrace(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	clock := useFakeClock(server)
+	updateConfig(server, func(config *Config) { config.ReconnectGrace = 30 * time.Second })
+	token := clients[1].Token
+	drainRawMessages(clients[0])
+
+	server.HandleDisconnect(token)
+	statuses := connectionStatuses(clients[0])
+	if len(statuses) != 1 || statuses[0].SeatIndex != 1 || statuses[0].Status != ConnectionReconnecting || statuses[0].PlayerName != "Bob" {
+		t.Fatalf("expected Bob reconnecting, got %+v", statuses)
+	}
+	if want := clock.Now().Add(30 * time.Second).UnixMilli(); statuses[0].ReconnectDeadline == nil || *statuses[0].ReconnectDeadline != want {
+		t.Errorf("expected the reconnect deadline %d, got %v", want, statuses[0].ReconnectDeadline)
+	}
+	state := server.buildTableState(table, nil)
+	if seat := state.Seats[1]; seat.PlayerName == nil || seat.Connection != ConnectionReconnecting || seat.ReconnectDeadline == nil {
+		t.Errorf("expected the held seat reconnecting in table_state, got %+v", seat)
+	}
+
+	clock.Advance(10 * time.Second)
+	server.handleReconnect(token)
+	if statuses := connectionStatuses(clients[0]); len(statuses) != 1 || statuses[0].Status != ConnectionConnected {
+		t.Errorf("expected Bob connected again, got %+v", statuses)
+	}
+	clock.Advance(30 * time.Second)
+	if _, seated := table.GetSeatByToken(&token); !seated {
+		t.Error("expected the seat kept after reconnecting")
+	}
+	if seat := server.buildTableState(table, nil).Seats[1]; seat.Connection != ConnectionConnected || seat.ReconnectDeadline != nil {
+		t.Errorf("expected the seat connected in table_state, got %+v", seat)
+	}
+}
+
+// TestConnection_TimesOut verifies a player who does not reconnect in time loses their seat
+func TestConnection_TimesOut(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	clock := useFakeClock(server)
+	updateConfig(server, func(config *Config) { config.ReconnectGrace = 30 * time.Second })
+	token := clients[2].Token
+
+	server.HandleDisconnect(token)
+	drainRawMessages(clients[0])
+	clock.Advance(30 * time.Second)
+
+	if statuses := connectionStatuses(clients[0]); len(statuses) != 1 || statuses[0].SeatIndex != 2 || statuses[0].Status != ConnectionTimedOut {
+		t.Errorf("expected Carol timed out, got %+v", statuses)
+	}
+	if _, seated := table.GetSeatByToken(&token); seated {
+		t.Error("expected the seat cleared")
+	}
+	if session, _ := server.sessionManager.GetSession(token); session.TableID != nil {
+		t.Error("expected the session unseated")
+	}
+}
+
+// TestConnection_DisconnectWithoutGrace verifies the seat is cleared at once without a grace,
+// and kept as disconnected while the table is frozen
+func TestConnection_DisconnectWithoutGrace(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	drainRawMessages(clients[0])
+
+	server.HandleDisconnect(clients[1].Token)
+	if statuses := connectionStatuses(clients[0]); len(statuses) != 1 || statuses[0].Status != ConnectionDisconnected {
+		t.Errorf("expected Bob disconnected, got %+v", statuses)
+	}
+	if _, seated := table.GetSeatByToken(&clients[1].Token); seated {
+		t.Error("expected the seat cleared")
+	}
+
+	if err := server.FreezeTable(table.ID, "maintenance"); err != nil {
+		t.Fatal(err)
+	}
+	drainRawMessages(clients[0])
+	server.HandleDisconnect(clients[2].Token)
+	if statuses := connectionStatuses(clients[0]); len(statuses) != 1 || statuses[0].Status != ConnectionDisconnected || statuses[0].ReconnectDeadline != nil {
+		t.Errorf("expected Carol disconnected, got %+v", statuses)
+	}
+	if seat := server.buildTableState(table, nil).Seats[2]; seat.PlayerName == nil || seat.Connection != ConnectionDisconnected {
+		t.Errorf("expected the frozen table to keep the seat as disconnected, got %+v", seat)
+	}
+}
@@ -0,0 +1,172 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ChipCurrency tells apart chips that have no value from chips backed by real value
+// Balances, buy-ins, cash-outs and rake are accounted separately for each currency,
+// so chips never move from one currency to another
+type ChipCurrency string
+
+const (
+	CurrencyPlay   ChipCurrency = "play"   // Free play money; every session starts with some
+	CurrencyLedger ChipCurrency = "ledger" // Real-value chips, only ever credited by operators
+)
+
+// errInsufficientBalance is returned when a session cannot afford a buy-in or debit
+var errInsufficientBalance = errors.New("insufficient_balance")
+
+// validateCurrency reports an unknown currency; empty means play money
+func validateCurrency(currency ChipCurrency) error {
+	switch currency {
+	case "", CurrencyPlay, CurrencyLedger:
+		return nil
+	default:
+		return fmt.Errorf("currency must be %q or %q", CurrencyPlay, CurrencyLedger)
+	}
+}
+
+// BankrollConfig makes chips at tables come out of session balances
+// When enabled, sitting down debits the table's buy-in from the player's balance in the
+// table's currency and leaving credits the remaining stack back. The zero value disables
+// accounting: buy-ins are free and stacks vanish on leaving.
+type BankrollConfig struct {
+	Enabled           bool `yaml:"enabled"`
+	StartingPlayChips int  `yaml:"startingPlayChips"` // Play-money balance given to every new session
+
+	// DailyBonus is the play-money grant an account may claim once per BonusCooldown while
+	// it holds less than BonusThreshold play chips, counting chips on tables. Zero disables it.
+	DailyBonus     int           `yaml:"dailyBonus"`
+	BonusThreshold int           `yaml:"bonusThreshold"`
+	BonusCooldown  time.Duration `yaml:"bonusCooldown"`
+}
+
+// validate reports the first invalid bankroll setting
+func (c BankrollConfig) validate() error {
+	if c.StartingPlayChips < 0 {
+		return fmt.Errorf("bankroll.startingPlayChips must not be negative")
+	}
+	if c.DailyBonus < 0 || c.BonusThreshold < 0 {
+		return fmt.Errorf("bankroll.dailyBonus and bankroll.bonusThreshold must not be negative")
+	}
+	if c.DailyBonus > 0 && c.BonusCooldown <= 0 {
+		return fmt.Errorf("bankroll.bonusCooldown must be positive when dailyBonus is set")
+	}
+	return nil
+}
+
+// BalancesPayload represents the payload for balances messages, sent privately to a
+// player whenever their balances change
+type BalancesPayload struct {
+	Balances map[ChipCurrency]int `json:"balances"`
+}
+
+// CurrencySummary is the admin API's accounting for one currency
+type CurrencySummary struct {
+	Currency      ChipCurrency `json:"currency"`
+	Tables        int          `json:"tables"`
+	ChipsOnTables int          `json:"chipsOnTables"` // Stacks of seated players
+	Balances      int          `json:"balances"`      // Chips held by live sessions off the tables
+	RakeCollected int          `json:"rakeCollected"`
+}
+
+// grantStartingBalance credits a new session with the configured play-money balance
+func (s *Server) grantStartingBalance(token string) {
+	cfg := s.Config().Bankroll
+	if !cfg.Enabled || cfg.StartingPlayChips <= 0 {
+		return
+	}
+	if _, err := s.sessionManager.AdjustBalance(token, CurrencyPlay, cfg.StartingPlayChips); err != nil {
+		s.logger.Warn("failed to grant starting balance", "token", token, "error", err)
+	}
+}
+
+// buyIn debits the table's buy-in from the player's balance in the table's currency
+// Returns errInsufficientBalance if they cannot afford it
+func (s *Server) buyIn(token string, table *Table, amount int) error {
+	if !s.Config().Bankroll.Enabled {
+		return nil
+	}
+	if _, err := s.sessionManager.AdjustBalance(token, table.Currency, -amount); err != nil {
+		return err
+	}
+	s.logger.Info("bought in", "token", token, "tableID", table.ID, "currency", table.Currency, "amount", amount)
+	return nil
+}
+
+// refundBuyIn returns a buy-in taken for a seat the player did not get
+func (s *Server) refundBuyIn(token string, table *Table, amount int) {
+	if !s.Config().Bankroll.Enabled {
+		return
+	}
+	if _, err := s.sessionManager.AdjustBalance(token, table.Currency, amount); err != nil {
+		s.logger.Warn("failed to refund buy-in", "token", token, "tableID", table.ID, "error", err)
+	}
+}
+
+// cashOut credits a departing player's stack back to their balance in the table's currency
+// Must be called without the table lock held
+func (t *Table) cashOut(token string, stack int) {
+	if t.Server == nil || !t.Server.Config().Bankroll.Enabled || stack <= 0 {
+		return
+	}
+	if _, err := t.Server.sessionManager.AdjustBalance(token, t.Currency, stack); err != nil {
+		// The session is already gone; its chips go with it
+		t.logWarn("failed to cash out stack", "token", token, "currency", t.Currency, "amount", stack, "error", err)
+		return
+	}
+	t.logInfo("cashed out", "token", token, "currency", t.Currency, "amount", stack)
+	t.Server.sendBalances(token)
+}
+
+// sendBalances privately sends the player their current balances
+func (s *Server) sendBalances(token string) {
+	if !s.Config().Bankroll.Enabled {
+		return
+	}
+	balances, err := s.sessionManager.Balances(token)
+	if err != nil {
+		return
+	}
+	s.sendPrivate(token, "balances", BalancesPayload{Balances: balances})
+}
+
+// currencySummaries totals chips and rake per currency across tables and sessions
+func (s *Server) currencySummaries() []CurrencySummary {
+	summaries := map[ChipCurrency]*CurrencySummary{
+		CurrencyPlay:   {Currency: CurrencyPlay},
+		CurrencyLedger: {Currency: CurrencyLedger},
+	}
+
+	s.mu.RLock()
+	tables := append([]*Table(nil), s.tables...)
+	s.mu.RUnlock()
+
+	for _, table := range tables {
+		table.mu.RLock()
+		summary := summaries[table.Currency]
+		summary.Tables++
+		summary.RakeCollected += table.RakeCollected
+		for _, seat := range table.Seats {
+			if seat.Token != nil {
+				summary.ChipsOnTables += seat.Stack
+			}
+		}
+		table.mu.RUnlock()
+	}
+
+	for currency, total := range s.sessionManager.TotalBalances() {
+		summaries[currency].Balances += total
+	}
+
+	result := make([]CurrencySummary, 0, len(summaries))
+	for _, summary := range summaries {
+		result = append(result, *summary)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Currency < result[j].Currency })
+	return result
+}
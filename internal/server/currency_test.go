@@ -0,0 +1,193 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+)
+
+// newBankrollServer returns a server with a play table and a ledger table and bankroll accounting on
+func newBankrollServer() *Server {
+	return NewServerWithConfig(slog.Default(), Config{
+		AdminToken: "secret",
+		Bankroll:   BankrollConfig{Enabled: true, StartingPlayChips: 5000},
+		Tables: []TableConfig{
+			{Name: "Play", SmallBlind: 10, BigBlind: 20, BuyIn: 1000},
+			{Name: "Ledger", SmallBlind: 10, BigBlind: 20, BuyIn: 500, Currency: CurrencyLedger},
+		},
+	})
+}
+
+// TestBankroll_BuyInsAndCashOutsStayInTheirCurrency verifies seats are paid for and cashed out
+// in the table's currency only
+func TestBankroll_BuyInsAndCashOutsStayInTheirCurrency(t *testing.T) {
+	server := newBankrollServer()
+	sm := server.sessionManager
+	session, _ := sm.CreateSession("Alice")
+	server.grantStartingBalance(session.Token)
+	client := &Client{hub: server.hub, Token: session.Token, send: make(chan []byte, 256)}
+	playTable, ledgerTable := server.tables[0], server.tables[1]
+
+	// No ledger chips yet: the ledger table is refused and nothing is debited
+	err := client.HandleJoinTable(sm, server, slog.Default(), []byte(`{"tableId":"`+ledgerTable.ID+`"}`))
+	if err == nil || err.Error() != "insufficient_balance" {
+		t.Fatalf("expected insufficient_balance, got %v", err)
+	}
+	if _, seated := ledgerTable.GetSeatByToken(&session.Token); seated {
+		t.Fatal("expected no seat at the ledger table")
+	}
+
+	if err := client.HandleJoinTable(sm, server, slog.Default(), []byte(`{"tableId":"`+playTable.ID+`"}`)); err != nil {
+		t.Fatalf("join play table: %v", err)
+	}
+	if balances, _ := sm.Balances(session.Token); balances[CurrencyPlay] != 4000 || balances[CurrencyLedger] != 0 {
+		t.Errorf("expected 4000 play chips after buying in, got %v", balances)
+	}
+
+	playTable.mu.Lock()
+	playTable.Seats[0].Stack = 1300
+	playTable.mu.Unlock()
+	if err := playTable.ClearSeat(&session.Token); err != nil {
+		t.Fatal(err)
+	}
+	if balances, _ := sm.Balances(session.Token); balances[CurrencyPlay] != 5300 || balances[CurrencyLedger] != 0 {
+		t.Errorf("expected the 1300 stack back as play chips, got %v", balances)
+	}
+}
+
+// TestBankroll_DisabledKeepsBuyInsFree verifies the zero config seats players without balances
+func TestBankroll_DisabledKeepsBuyInsFree(t *testing.T) {
+	server := NewServer(slog.Default())
+	session, _ := server.sessionManager.CreateSession("Alice")
+	server.grantStartingBalance(session.Token)
+	client := &Client{hub: server.hub, Token: session.Token, send: make(chan []byte, 256)}
+
+	if err := client.HandleJoinTable(server.sessionManager, server, slog.Default(), []byte(`{"tableId":"table-1"}`)); err != nil {
+		t.Fatalf("expected a free seat, got %v", err)
+	}
+	if balances, _ := server.sessionManager.Balances(session.Token); len(balances) != 0 {
+		t.Errorf("expected no balances, got %v", balances)
+	}
+}
+
+// TestAdminAPI_BalancesAndCurrencies verifies operators can credit ledger chips and see per-currency totals
+func TestAdminAPI_BalancesAndCurrencies(t *testing.T) {
+	server := newBankrollServer()
+	session, _ := server.sessionManager.CreateSession("Alice")
+	server.sessionManager.CreateSession("Bob")
+	server.sessionManager.CreateSession("bob")
+
+	rec := adminRequest(server, http.MethodPost, "/admin/balances", "secret", `{"player":"alice","currency":"ledger","amount":800}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var response BalanceResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	if response.Balance != 800 {
+		t.Errorf("expected balance 800, got %+v", response)
+	}
+
+	cases := []struct {
+		body string
+		want int
+	}{
+		{`{"player":"nobody","currency":"ledger","amount":1}`, http.StatusNotFound},
+		{`{"player":"bob","currency":"ledger","amount":1}`, http.StatusConflict},
+		{`{"player":"alice","currency":"gold","amount":1}`, http.StatusBadRequest},
+		{`{"player":"alice","currency":"ledger","amount":-900}`, http.StatusConflict},
+	}
+	for _, tc := range cases {
+		if rec := adminRequest(server, http.MethodPost, "/admin/balances", "secret", tc.body); rec.Code != tc.want {
+			t.Errorf("%s: expected %d, got %d", tc.body, tc.want, rec.Code)
+		}
+	}
+
+	// Buy in at the ledger table and check the summary splits the chips by currency
+	client := &Client{hub: server.hub, Token: session.Token, send: make(chan []byte, 256)}
+	if err := client.HandleJoinTable(server.sessionManager, server, slog.Default(), []byte(`{"tableId":"table-2"}`)); err != nil {
+		t.Fatalf("join ledger table: %v", err)
+	}
+	server.tables[1].mu.Lock()
+	server.tables[1].RakeCollected = 25
+	server.tables[1].mu.Unlock()
+
+	rec = adminRequest(server, http.MethodGet, "/admin/currencies", "secret", "")
+	var summaries []CurrencySummary
+	if err := json.Unmarshal(rec.Body.Bytes(), &summaries); err != nil {
+		t.Fatalf("invalid summary: %v", err)
+	}
+	want := []CurrencySummary{
+		{Currency: CurrencyLedger, Tables: 1, ChipsOnTables: 500, Balances: 300, RakeCollected: 25},
+		{Currency: CurrencyPlay, Tables: 1},
+	}
+	if len(summaries) != len(want) || summaries[0] != want[0] || summaries[1] != want[1] {
+		t.Errorf("expected %+v, got %+v", want, summaries)
+	}
+}
+
+// TestConfigValidate_LedgerTablesNeedBankroll verifies ledger tables are refused without accounting
+func TestConfigValidate_LedgerTablesNeedBankroll(t *testing.T) {
+	config := Config{Tables: []TableConfig{{Name: "Ledger", SmallBlind: 10, BigBlind: 20, BuyIn: 500, Currency: CurrencyLedger}}}
+	if err := config.Validate(); err == nil {
+		t.Error("expected ledger table without bankroll to be rejected")
+	}
+	config.Bankroll.Enabled = true
+	if err := config.Validate(); err != nil {
+		t.Errorf("expected valid config, got %v", err)
+	}
+	config.Tables[0].Currency = "gold"
+	if err := config.Validate(); err == nil {
+		t.Error("expected unknown currency to be rejected")
+	}
+}
+
+// TestBankroll_LeavingMidHandKeepsChips verifies players who leave during a hand, the player to
+// act first, are folded out of it so the pot is still paid out and no chips are lost
+func TestBankroll_LeavingMidHandKeepsChips(t *testing.T) {
+	server := newBankrollServer()
+	table := server.tables[0]
+	var tokens []string
+	for _, name := range []string{"Alice", "Bob", "Carol"} {
+		session, _ := server.sessionManager.CreateSession(name)
+		server.grantStartingBalance(session.Token)
+		if _, err := server.seatPlayer(session.Token, "", table); err != nil {
+			t.Fatal(err)
+		}
+		tokens = append(tokens, session.Token)
+	}
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Everyone leaves, the current actor first, so the last one standing wins the blinds
+	for range tokens {
+		table.mu.RLock()
+		var leaving string
+		for _, seat := range table.Seats {
+			if seat.Token == nil {
+				continue
+			}
+			hand := table.CurrentHand
+			if leaving == "" || (hand != nil && hand.CurrentActor != nil && *hand.CurrentActor == seat.Index) {
+				leaving = *seat.Token
+			}
+		}
+		table.mu.RUnlock()
+		if err := table.ClearSeat(&leaving); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	summary := func() CurrencySummary {
+		for _, summary := range server.currencySummaries() {
+			if summary.Currency == CurrencyPlay {
+				return summary
+			}
+		}
+		return CurrencySummary{}
+	}
+	if !eventually(func() bool { s := summary(); return s.ChipsOnTables == 0 && s.Balances+s.RakeCollected == 15000 }) {
+		t.Errorf("expected all 15000 chips back in balances, got %+v", summary())
+	}
+}
@@ -0,0 +1,299 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	runtimepprof "runtime/pprof"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// snapshotLockTimeout bounds how long a table snapshot waits for the table lock
+// A table that cannot be locked within this time is reported as locked instead of hanging the request
+const snapshotLockTimeout = time.Second
+
+// TableSnapshot is a point-in-time view of a table for debugging
+// Hole cards, the remaining deck and session tokens are never included
+type TableSnapshot struct {
+	ID               string         `json:"id"`
+	Name             string         `json:"name"`
+	Phase            HandPhase      `json:"phase"`
+	DealerSeat       *int           `json:"dealerSeat,omitempty"`
+	Seats            []SeatSnapshot `json:"seats"`
+	Hand             *HandSnapshot  `json:"hand,omitempty"`
+	ActionDeadline   *time.Time     `json:"actionDeadline,omitempty"`
+	NextHandAt       *time.Time     `json:"nextHandAt,omitempty"`
+	Freeze           *TableFreeze   `json:"freeze,omitempty"`
+	Closing          bool           `json:"closing,omitempty"`
+	ProcessedActions int            `json:"processedActions"`
+	LockWait         string         `json:"lockWait"`
+}
+
+// SeatSnapshot describes one seat in a TableSnapshot
+type SeatSnapshot struct {
+	Index      int    `json:"index"`
+	Status     string `json:"status"`
+	Stack      int    `json:"stack"`
+	PlayerName string `json:"playerName,omitempty"`
+}
+
+// HandSnapshot describes the running hand in a TableSnapshot
+type HandSnapshot struct {
+	Street         string       `json:"street"`
+	Pot            int          `json:"pot"`
+	CurrentBet     int          `json:"currentBet"`
+	CurrentActor   *int         `json:"currentActor,omitempty"`
+	SmallBlindSeat int          `json:"smallBlindSeat"`
+	BigBlindSeat   int          `json:"bigBlindSeat"`
+	BoardCards     []Card       `json:"boardCards"`
+	HoleCardCounts map[int]int  `json:"holeCardCounts"`
+	PlayerBets     map[int]int  `json:"playerBets"`
+	Contributions  map[int]int  `json:"contributions"`
+	FoldedPlayers  map[int]bool `json:"foldedPlayers"`
+	ActedPlayers   map[int]bool `json:"actedPlayers"`
+	DeckRemaining  int          `json:"deckRemaining"`
+}
+
+// RuntimeSnapshot summarizes process health for the diagnostics listener
+type RuntimeSnapshot struct {
+	Goroutines       int    `json:"goroutines"`
+	HeapAllocBytes   uint64 `json:"heapAllocBytes"`
+	HeapObjects      uint64 `json:"heapObjects"`
+	NumGC            uint32 `json:"numGC"`
+	ConnectedClients int    `json:"connectedClients"`
+	GoVersion        string `json:"goVersion"`
+	// Equity is the load on the equity simulation workers behind replay snippets
+	Equity EquityPoolStats `json:"equity"`
+}
+
+// DiagnosticsHandler returns the handler served by the diagnostics listener:
+//   - /debug/pprof/...        net/http/pprof profiles
+//   - /debug/goroutines       full goroutine dump as text
+//   - /debug/runtime          RuntimeSnapshot as JSON
+//   - /debug/tables           TableSnapshot of every table as JSON
+//   - /debug/tables/{tableID} TableSnapshot of one table as JSON
+//   - /debug/latency          LatencyReport of action response times as JSON
+//   - /debug/bandwidth        BandwidthReport of bytes sent per message type as JSON
+//   - /debug/memory           MemoryReport of what each table holds in memory as JSON
+//
+// It exposes internals and must only be reachable by operators
+func (s *Server) DiagnosticsHandler() http.Handler {
+	r := chi.NewRouter()
+
+	r.HandleFunc("/debug/pprof/", pprof.Index)
+	r.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	r.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	r.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	r.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	r.Handle("/debug/pprof/{profile}", http.HandlerFunc(pprof.Index))
+
+	r.Get("/debug/goroutines", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		runtimepprof.Lookup("goroutine").WriteTo(w, 2)
+	})
+	r.Get("/debug/runtime", s.handleRuntimeSnapshot)
+	r.Get("/debug/tables", s.handleTableSnapshots)
+	r.Get("/debug/tables/{tableID}", s.handleTableSnapshot)
+	r.Get("/debug/latency", func(w http.ResponseWriter, r *http.Request) {
+		writeDiagnosticsJSON(w, s.latencyReport())
+	})
+	r.Get("/debug/bandwidth", func(w http.ResponseWriter, r *http.Request) {
+		writeDiagnosticsJSON(w, s.bandwidth.Report())
+	})
+	r.Get("/debug/memory", func(w http.ResponseWriter, r *http.Request) {
+		writeDiagnosticsJSON(w, s.memoryReport(time.Now()))
+	})
+
+	return r
+}
+
+// StartDiagnostics serves DiagnosticsHandler on addr until the server shuts down
+// Intended for a loopback or otherwise private address
+func (s *Server) StartDiagnostics(addr string) error {
+	s.mu.Lock()
+	s.diagnosticsServer = &http.Server{
+		Addr:    addr,
+		Handler: s.DiagnosticsHandler(),
+	}
+	diagnosticsServer := s.diagnosticsServer
+	s.mu.Unlock()
+
+	s.logger.Info("starting diagnostics listener", "addr", addr)
+
+	err := diagnosticsServer.ListenAndServe()
+	if err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("diagnostics server error: %w", err)
+	}
+	return err
+}
+
+// handleRuntimeSnapshot writes a RuntimeSnapshot
+func (s *Server) handleRuntimeSnapshot(w http.ResponseWriter, r *http.Request) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	s.hub.mu.RLock()
+	connectedClients := len(s.hub.clients)
+	s.hub.mu.RUnlock()
+
+	writeDiagnosticsJSON(w, RuntimeSnapshot{
+		Goroutines:       runtime.NumGoroutine(),
+		HeapAllocBytes:   mem.HeapAlloc,
+		HeapObjects:      mem.HeapObjects,
+		NumGC:            mem.NumGC,
+		ConnectedClients: connectedClients,
+		GoVersion:        runtime.Version(),
+		Equity:           s.equity.Stats(),
+	})
+}
+
+// handleTableSnapshots writes a snapshot of every table; tables whose lock
+// cannot be taken are reported with an error entry instead
+func (s *Server) handleTableSnapshots(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	tables := s.tables
+	s.mu.RUnlock()
+
+	snapshots := make([]interface{}, 0, len(tables))
+	for _, table := range tables {
+		if table == nil {
+			continue
+		}
+		snapshot, err := s.SnapshotTable(table)
+		if err != nil {
+			snapshots = append(snapshots, map[string]string{"id": table.ID, "error": err.Error()})
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	writeDiagnosticsJSON(w, snapshots)
+}
+
+// handleTableSnapshot writes the snapshot of a single table
+func (s *Server) handleTableSnapshot(w http.ResponseWriter, r *http.Request) {
+	tableID := chi.URLParam(r, "tableID")
+
+	s.mu.RLock()
+	var table *Table
+	for _, t := range s.tables {
+		if t != nil && t.ID == tableID {
+			table = t
+			break
+		}
+	}
+	s.mu.RUnlock()
+
+	if table == nil {
+		http.Error(w, "table not found", http.StatusNotFound)
+		return
+	}
+
+	snapshot, err := s.SnapshotTable(table)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	writeDiagnosticsJSON(w, snapshot)
+}
+
+// SnapshotTable captures the sanitized state of table
+// Returns an error if the table lock cannot be taken within snapshotLockTimeout,
+// which usually means the table is stuck holding its lock
+func (s *Server) SnapshotTable(table *Table) (TableSnapshot, error) {
+	waitStart := time.Now()
+	for !table.mu.TryRLock() {
+		if time.Since(waitStart) > snapshotLockTimeout {
+			return TableSnapshot{}, fmt.Errorf("table lock not acquired within %s", snapshotLockTimeout)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	lockWait := time.Since(waitStart)
+
+	snapshot := TableSnapshot{
+		ID:               table.ID,
+		Name:             table.Name,
+		Phase:            table.phaseLocked(),
+		ProcessedActions: len(table.processedActions),
+		LockWait:         lockWait.String(),
+	}
+	if table.DealerSeat != nil {
+		dealer := *table.DealerSeat
+		snapshot.DealerSeat = &dealer
+	}
+	if table.ActionDeadline != nil {
+		deadline := *table.ActionDeadline
+		snapshot.ActionDeadline = &deadline
+	}
+	if table.NextHandAt != nil {
+		startsAt := *table.NextHandAt
+		snapshot.NextHandAt = &startsAt
+	}
+	if table.freeze != nil {
+		freeze := *table.freeze
+		snapshot.Freeze = &freeze
+	}
+	snapshot.Closing = table.closing
+
+	// Player names are looked up after the table lock is released
+	tokens := make(map[int]string)
+	for i := 0; i < 6; i++ {
+		seat := table.Seats[i]
+		snapshot.Seats = append(snapshot.Seats, SeatSnapshot{
+			Index:  i,
+			Status: seat.Status,
+			Stack:  seat.Stack,
+		})
+		if seat.Token != nil {
+			tokens[i] = *seat.Token
+		}
+	}
+
+	if hand := table.CurrentHand; hand != nil {
+		handSnapshot := &HandSnapshot{
+			Street:         hand.Street,
+			Pot:            hand.Pot,
+			CurrentBet:     hand.CurrentBet,
+			SmallBlindSeat: hand.SmallBlindSeat,
+			BigBlindSeat:   hand.BigBlindSeat,
+			BoardCards:     append([]Card{}, hand.BoardCards...),
+			HoleCardCounts: make(map[int]int),
+			PlayerBets:     maps.Clone(hand.PlayerBets),
+			Contributions:  maps.Clone(hand.TotalContributions),
+			FoldedPlayers:  maps.Clone(hand.FoldedPlayers),
+			ActedPlayers:   maps.Clone(hand.ActedPlayers),
+			DeckRemaining:  len(hand.Deck),
+		}
+		if hand.CurrentActor != nil {
+			actor := *hand.CurrentActor
+			handSnapshot.CurrentActor = &actor
+		}
+		for seatIndex, cards := range hand.HoleCards {
+			handSnapshot.HoleCardCounts[seatIndex] = len(cards)
+		}
+		snapshot.Hand = handSnapshot
+	}
+	table.mu.RUnlock()
+
+	for seatIndex, token := range tokens {
+		if name, err := s.sessionManager.GetPlayerName(token); err == nil {
+			snapshot.Seats[seatIndex].PlayerName = name
+		}
+	}
+
+	return snapshot, nil
+}
+
+// writeDiagnosticsJSON writes v as indented JSON for easy reading with curl
+func writeDiagnosticsJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(v)
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestDiagnosticsHandler_TableSnapshotIsSanitized verifies snapshots describe the hand without leaking secrets
+func TestDiagnosticsHandler_TableSnapshotIsSanitized(t *testing.T) {
+	server := NewServer(slog.Default())
+	session, err := server.sessionManager.CreateSession("Alice")
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	table := server.tables[0]
+	token2 := "player2"
+	table.Seats[0] = Seat{Index: 0, Token: &session.Token, Status: "active", Stack: 1000}
+	table.Seats[1] = Seat{Index: 1, Token: &token2, Status: "active", Stack: 1000}
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("failed to start hand: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/debug/tables/"+table.ID, nil)
+	w := httptest.NewRecorder()
+	server.DiagnosticsHandler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	body := w.Body.String()
+	if strings.Contains(body, session.Token) || strings.Contains(body, token2) {
+		t.Error("expected snapshot not to contain session tokens")
+	}
+	// No board is dealt preflop, so any card in the body would be a hole card or deck card
+	if strings.Contains(body, `"Rank"`) {
+		t.Error("expected snapshot not to contain any cards preflop")
+	}
+
+	var snapshot TableSnapshot
+	if err := json.Unmarshal(w.Body.Bytes(), &snapshot); err != nil {
+		t.Fatalf("failed to unmarshal snapshot: %v", err)
+	}
+	if snapshot.Phase != PhasePreflop {
+		t.Errorf("expected phase %s, got %s", PhasePreflop, snapshot.Phase)
+	}
+	if snapshot.Hand == nil {
+		t.Fatal("expected hand in snapshot")
+	}
+	if snapshot.Hand.HoleCardCounts[0] != 2 || snapshot.Hand.HoleCardCounts[1] != 2 {
+		t.Errorf("expected 2 hole cards per player, got %v", snapshot.Hand.HoleCardCounts)
+	}
+	if snapshot.Hand.DeckRemaining != 48 {
+		t.Errorf("expected 48 cards left in deck, got %d", snapshot.Hand.DeckRemaining)
+	}
+	if snapshot.Seats[0].PlayerName != "Alice" {
+		t.Errorf("expected seat 0 player name Alice, got %q", snapshot.Seats[0].PlayerName)
+	}
+}
+
+// TestDiagnosticsHandler_UnknownTable verifies unknown table IDs return 404
+func TestDiagnosticsHandler_UnknownTable(t *testing.T) {
+	server := NewServer(slog.Default())
+
+	req := httptest.NewRequest(http.MethodGet, "/debug/tables/table-99", nil)
+	w := httptest.NewRecorder()
+	server.DiagnosticsHandler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+}
+
+// TestDiagnosticsHandler_StuckTableReportsLock verifies a table holding its lock is reported instead of hanging
+func TestDiagnosticsHandler_StuckTableReportsLock(t *testing.T) {
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+
+	table.mu.Lock()
+	defer table.mu.Unlock()
+
+	req := httptest.NewRequest(http.MethodGet, "/debug/tables/"+table.ID, nil)
+	w := httptest.NewRecorder()
+	server.DiagnosticsHandler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", w.Code)
+	}
+}
+
+// TestDiagnosticsHandler_ProfilingEndpoints verifies pprof, goroutine dump and runtime endpoints respond
+func TestDiagnosticsHandler_ProfilingEndpoints(t *testing.T) {
+	server := NewServer(slog.Default())
+	handler := server.DiagnosticsHandler()
+
+	for _, path := range []string{"/debug/pprof/", "/debug/pprof/heap", "/debug/goroutines", "/debug/runtime", "/debug/tables"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: expected status 200, got %d", path, w.Code)
+		}
+	}
+}
+
+// TestServer_DiagnosticsNotOnPublicRouter verifies diagnostics are only served by the separate listener
+func TestServer_DiagnosticsNotOnPublicRouter(t *testing.T) {
+	server := NewServer(slog.Default())
+
+	req := httptest.NewRequest(http.MethodGet, "/debug/tables", nil)
+	w := httptest.NewRecorder()
+	server.Router().ServeHTTP(w, req)
+
+	if strings.Contains(w.Body.String(), "table-1") {
+		t.Error("expected public router not to serve table snapshots")
+	}
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+)
+
+// BlindsResult is the result of POST /admin/tables/{tableID}/blinds
+type BlindsResult struct {
+	TableID string     `json:"tableId"`
+	Blinds  BlindLevel `json:"blinds"`
+	// Pending is set when a hand was in progress: it keeps its blinds and the new level applies
+	// from the next hand
+	Pending bool `json:"pending"`
+}
+
+var (
+	errInvalidBlinds  = errors.New("invalid blinds")
+	errDealFrozen     = errors.New("table is frozen")
+	errDealHostPaused = errors.New("the table's host paused dealing")
+)
+
+// DealNow deals the table's next hand at once instead of waiting for the countdown
+// A hand in progress, a frozen or host-paused table, or fewer than two players are refused.
+func (s *Server) DealNow(tableID string) error {
+	table := s.tableByID(tableID)
+	if table == nil {
+		return errTableNotFound
+	}
+
+	table.mu.RLock()
+	frozen, hostPaused := table.freeze != nil, table.hostPaused
+	table.mu.RUnlock()
+	switch {
+	case frozen:
+		return errDealFrozen
+	case hostPaused:
+		return errDealHostPaused
+	}
+	return table.StartHand()
+}
+
+// SetBlinds changes the table's blinds: at once between hands, or from the next hand when one
+// is in progress
+func (s *Server) SetBlinds(tableID string, level BlindLevel) (BlindsResult, error) {
+	if level.SmallBlind <= 0 {
+		return BlindsResult{}, fmt.Errorf("%w: smallBlind must be positive", errInvalidBlinds)
+	}
+	if level.BigBlind < level.SmallBlind {
+		return BlindsResult{}, fmt.Errorf("%w: bigBlind must be at least smallBlind", errInvalidBlinds)
+	}
+	if level.Ante != 0 {
+		return BlindsResult{}, fmt.Errorf("%w: antes are not supported", errInvalidBlinds)
+	}
+	table := s.tableByID(tableID)
+	if table == nil {
+		return BlindsResult{}, errTableNotFound
+	}
+
+	table.mu.Lock()
+	result := BlindsResult{TableID: tableID, Blinds: level, Pending: table.CurrentHand != nil}
+	if result.Pending {
+		table.pendingBlinds = &level
+	} else {
+		table.SmallBlind, table.BigBlind = level.SmallBlind, level.BigBlind
+		table.pendingBlinds = nil
+	}
+	table.mu.Unlock()
+
+	if result.Pending {
+		s.announcePendingRules(table)
+	} else {
+		s.notifyTableStatus(table)
+	}
+	return result, nil
+}
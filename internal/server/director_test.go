@@ -0,0 +1,113 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+)
+
+// TestAdminAPI_DealNow verifies an admin can deal the next hand at once, but not over a hand in
+// progress or at a frozen table
+func TestAdminAPI_DealNow(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{
+		AdminToken: "secret",
+		Tables:     []TableConfig{{Name: "Main", SmallBlind: 10, BigBlind: 20, BuyIn: 1000}},
+	})
+	table := server.tables[0]
+	deal := func() int {
+		return adminRequest(server, http.MethodPost, "/admin/tables/"+table.ID+"/deal", "secret", "").Code
+	}
+
+	if code := adminRequest(server, http.MethodPost, "/admin/tables/nope/deal", "secret", "").Code; code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown table, got %d", code)
+	}
+	seatNamed(t, server, table, "Alice")
+	if code := deal(); code != http.StatusConflict {
+		t.Errorf("expected 409 with one player, got %d", code)
+	}
+
+	seatNamed(t, server, table, "Bob")
+	if err := server.FreezeTable(table.ID, ""); err != nil {
+		t.Fatal(err)
+	}
+	if code := deal(); code != http.StatusConflict {
+		t.Errorf("expected 409 at a frozen table, got %d", code)
+	}
+	if err := server.ResumeTable(table.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if code := deal(); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	table.mu.RLock()
+	handRunning := table.CurrentHand != nil
+	table.mu.RUnlock()
+	if !handRunning {
+		t.Error("expected a hand dealt")
+	}
+	if code := deal(); code != http.StatusConflict {
+		t.Errorf("expected 409 with a hand in progress, got %d", code)
+	}
+}
+
+// TestAdminAPI_SetBlinds verifies new blinds apply at once between hands and from the next hand
+// when one is running
+func TestAdminAPI_SetBlinds(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{
+		AdminToken: "secret",
+		Tables:     []TableConfig{{Name: "Main", SmallBlind: 10, BigBlind: 20, BuyIn: 1000}},
+	})
+	table := server.tables[0]
+	setBlinds := func(body string) (int, BlindsResult) {
+		rec := adminRequest(server, http.MethodPost, "/admin/tables/"+table.ID+"/blinds", "secret", body)
+		var result BlindsResult
+		if rec.Code == http.StatusOK {
+			if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return rec.Code, result
+	}
+	blinds := func() (int, int) {
+		table.mu.RLock()
+		defer table.mu.RUnlock()
+		return table.SmallBlind, table.BigBlind
+	}
+
+	for _, body := range []string{`{`, `{"smallBlind": 0, "bigBlind": 20}`, `{"smallBlind": 20, "bigBlind": 10}`, `{"smallBlind": 10, "bigBlind": 20, "ante": 5}`} {
+		if code, _ := setBlinds(body); code != http.StatusBadRequest {
+			t.Errorf("expected 400 for %s, got %d", body, code)
+		}
+	}
+
+	if code, result := setBlinds(`{"smallBlind": 25, "bigBlind": 50}`); code != http.StatusOK || result.Pending {
+		t.Fatalf("expected the blinds applied at once, got %d %+v", code, result)
+	}
+	if sb, bb := blinds(); sb != 25 || bb != 50 {
+		t.Errorf("expected 25/50, got %d/%d", sb, bb)
+	}
+
+	clients := seatNamed(t, server, table, "Alice", "Bob")
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if code, result := setBlinds(`{"smallBlind": 50, "bigBlind": 100}`); code != http.StatusOK || !result.Pending {
+		t.Fatalf("expected the blinds pending, got %d %+v", code, result)
+	}
+	if pending := payloadsOf[RulesPendingPayload](t, clients[0], "rules_pending"); len(pending) != 1 || pending[0].Blinds != (BlindLevel{SmallBlind: 50, BigBlind: 100}) {
+		t.Errorf("expected rules_pending with the 50/100 blinds, got %+v", pending)
+	}
+	if sb, bb := blinds(); sb != 25 || bb != 50 {
+		t.Errorf("expected the running hand to keep 25/50, got %d/%d", sb, bb)
+	}
+
+	playOutChecking(t, server, table)
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if sb, bb := blinds(); sb != 50 || bb != 100 {
+		t.Errorf("expected 50/100 from the next hand, got %d/%d", sb, bb)
+	}
+}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+)
+
+// EmoteConfig lets seated players send predefined emotes and throwables to their table.
+// They are separate from any free-text chat: clients animate them from the ID alone and there is
+// nothing to moderate. The zero value disables them.
+type EmoteConfig struct {
+	Enabled  bool          `yaml:"enabled"`
+	Cooldown time.Duration `yaml:"cooldown"` // Minimum time between two emotes from one player
+}
+
+// validate reports a negative cooldown
+func (c EmoteConfig) validate() error {
+	if c.Cooldown < 0 {
+		return errors.New("emotes cooldown must not be negative")
+	}
+	return nil
+}
+
+// Emotes are aimed at the table or, optionally, a seat; throwables fly at a seat and need one
+var (
+	emoteIDs     = []string{"thumbs_up", "clap", "laugh", "cry", "angry", "think", "wave", "good_game"}
+	throwableIDs = []string{"tomato", "egg", "rose", "beer"}
+)
+
+// EmotePayload represents the payload for emote messages, both from the sender and as
+// broadcast to the table
+type EmotePayload struct {
+	Emote      string `json:"emote"`
+	SeatIndex  int    `json:"seatIndex"`            // Sender's seat; ignored when sent
+	TargetSeat *int   `json:"targetSeat,omitempty"` // Seat the emote is aimed at; required for throwables
+}
+
+// emoteLimiterLimit is how many players the EmoteLimiter remembers before it forgets those whose
+// cooldown is over, which no longer hold anything back
+const emoteLimiterLimit = 1000
+
+// EmoteLimiter enforces the per-player emote cooldown across all tables
+type EmoteLimiter struct {
+	mu   sync.Mutex
+	last map[string]time.Time // When each player (by token) last sent an emote
+}
+
+// NewEmoteLimiter creates an EmoteLimiter that has seen no emotes
+func NewEmoteLimiter() *EmoteLimiter {
+	return &EmoteLimiter{last: make(map[string]time.Time)}
+}
+
+// allow records an emote from token at now, or returns how long it must wait first
+func (l *EmoteLimiter) allow(token string, now time.Time, cooldown time.Duration) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if last, ok := l.last[token]; ok && now.Sub(last) < cooldown {
+		return cooldown - now.Sub(last), false
+	}
+	l.last[token] = now
+	if len(l.last) > emoteLimiterLimit {
+		for other, last := range l.last {
+			if now.Sub(last) >= cooldown {
+				delete(l.last, other)
+			}
+		}
+	}
+	return 0, true
+}
+
+// forget drops the player with token, whose session ended
+func (l *EmoteLimiter) forget(token string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.last, token)
+}
+
+// Players returns how many players the limiter remembers
+func (l *EmoteLimiter) Players() int {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.last)
+}
+
+// HandleEmote processes an emote message from a seated player and broadcasts it to the table
+func (c *Client) HandleEmote(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+	cfg := server.Config().Emotes
+	if !cfg.Enabled {
+		return newMessageError("error.emotes_disabled", nil)
+	}
+	var emote EmotePayload
+	if err := json.Unmarshal(payload, &emote); err != nil {
+		return invalidPayloadError("emote", err)
+	}
+	throwable := slices.Contains(throwableIDs, emote.Emote)
+	if !throwable && !slices.Contains(emoteIDs, emote.Emote) {
+		return newMessageError("error.unknown_emote", map[string]any{"emote": emote.Emote})
+	}
+
+	session, err := sm.GetSession(c.Token)
+	if err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+	if session.TableID == nil || session.SeatIndex == nil {
+		return newMessageError("error.not_seated", nil)
+	}
+	table := server.tableByID(*session.TableID)
+	if table == nil {
+		return newMessageError("error.table_not_found", nil)
+	}
+	emote.SeatIndex = *session.SeatIndex
+
+	if throwable && emote.TargetSeat == nil {
+		return newMessageError("error.emote_target_required", nil)
+	}
+	if target := emote.TargetSeat; target != nil {
+		table.mu.RLock()
+		occupied := *target >= 0 && *target < len(table.Seats) && table.Seats[*target].Token != nil
+		table.mu.RUnlock()
+		if !occupied || *target == emote.SeatIndex {
+			return newMessageError("error.invalid_emote_target", nil)
+		}
+	}
+
+	if wait, ok := server.emotes.allow(c.Token, table.clock().Now(), cfg.Cooldown); !ok {
+		seconds := int((wait + time.Second - 1) / time.Second)
+		return newMessageError("error.emote_cooldown", map[string]any{"seconds": seconds})
+	}
+
+	logger.Debug("emote sent", "tableID", table.ID, "seatIndex", emote.SeatIndex, "emote", emote.Emote)
+	return server.broadcastSocialMessage(table, session.Name, "emote", emote)
+}
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// emoteKey sends an emote as client and returns the message key of the error, empty on success
+func emoteKey(server *Server, client *Client, emote string, target *int) string {
+	payload, _ := json.Marshal(EmotePayload{Emote: emote, TargetSeat: target})
+	err := client.HandleEmote(server.sessionManager, server, slog.Default(), payload)
+	if err == nil {
+		return ""
+	}
+	var messageErr *MessageError
+	if errors.As(err, &messageErr) {
+		return messageErr.Key
+	}
+	return err.Error()
+}
+
+// TestEmote_BroadcastsToTable verifies an emote reaches the table with the sender's seat and target
+func TestEmote_BroadcastsToTable(t *testing.T) {
+	server, _, clients := preActionTable(t)
+	updateConfig(server, func(config *Config) { config.Emotes = EmoteConfig{Enabled: true} })
+	for _, client := range clients {
+		drainRawMessages(client)
+	}
+
+	target := 2
+	if key := emoteKey(server, clients[0], "tomato", &target); key != "" {
+		t.Fatalf("throwing a tomato failed: %s", key)
+	}
+	var emotes []EmotePayload
+	for _, raw := range drainRawMessages(clients[1]) {
+		var msg struct {
+			Type    string       `json:"type"`
+			Payload EmotePayload `json:"payload"`
+		}
+		if json.Unmarshal([]byte(raw), &msg) == nil && msg.Type == "emote" {
+			emotes = append(emotes, msg.Payload)
+		}
+	}
+	if len(emotes) != 1 || emotes[0].Emote != "tomato" || emotes[0].SeatIndex != 0 || emotes[0].TargetSeat == nil || *emotes[0].TargetSeat != 2 {
+		t.Errorf("expected seat 0's tomato at seat 2, got %+v", emotes)
+	}
+}
+
+// TestEmote_Validation verifies unknown emotes, bad targets and disabled emotes are rejected
+func TestEmote_Validation(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	if key := emoteKey(server, clients[0], "clap", nil); key != "error.emotes_disabled" {
+		t.Errorf("disabled: expected error.emotes_disabled, got %q", key)
+	}
+	updateConfig(server, func(config *Config) { config.Emotes = EmoteConfig{Enabled: true} })
+
+	self, empty := 0, len(table.Seats)-1
+	tests := []struct {
+		name   string
+		emote  string
+		target *int
+		want   string
+	}{
+		{"unknown emote", "moon", nil, "error.unknown_emote"},
+		{"throwable without target", "egg", nil, "error.emote_target_required"},
+		{"aimed at self", "rose", &self, "error.invalid_emote_target"},
+		{"aimed at an empty seat", "wave", &empty, "error.invalid_emote_target"},
+		{"emote at the table", "wave", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if key := emoteKey(server, clients[0], tt.emote, tt.target); key != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, key)
+			}
+		})
+	}
+
+	watcher, _ := server.sessionManager.CreateSession("Dave")
+	if key := emoteKey(server, connectTestClient(server, watcher.Token), "wave", nil); key != "error.not_seated" {
+		t.Errorf("unseated: expected error.not_seated, got %q", key)
+	}
+}
+
+// TestEmote_Cooldown verifies each player waits the cooldown between emotes, independently of others
+func TestEmote_Cooldown(t *testing.T) {
+	server, _, clients := preActionTable(t)
+	clock := useFakeClock(server)
+	updateConfig(server, func(config *Config) { config.Emotes = EmoteConfig{Enabled: true, Cooldown: 5 * time.Second} })
+
+	if key := emoteKey(server, clients[0], "clap", nil); key != "" {
+		t.Fatalf("first emote failed: %s", key)
+	}
+	if key := emoteKey(server, clients[0], "laugh", nil); key != "error.emote_cooldown" {
+		t.Errorf("expected error.emote_cooldown, got %q", key)
+	}
+	if key := emoteKey(server, clients[1], "laugh", nil); key != "" {
+		t.Errorf("another player's emote failed: %s", key)
+	}
+	clock.Advance(5 * time.Second)
+	if key := emoteKey(server, clients[0], "laugh", nil); key != "" {
+		t.Errorf("emote after the cooldown failed: %s", key)
+	}
+}
+
+// TestEmoteLimiter_Bounded verifies the limiter forgets an ended session, and past its limit the
+// players whose cooldown is over
+func TestEmoteLimiter_Bounded(t *testing.T) {
+	limiter := NewEmoteLimiter()
+	now := time.Now()
+	limiter.allow("alice", now, time.Second)
+	limiter.forget("alice")
+	if _, ok := limiter.allow("alice", now, time.Second); !ok {
+		t.Error("expected a forgotten player to emote again at once")
+	}
+
+	for i := range emoteLimiterLimit {
+		limiter.allow(fmt.Sprintf("gone-%d", i), now, time.Second)
+	}
+	limiter.allow("bob", now.Add(500*time.Millisecond), time.Second)
+	if players := limiter.Players(); players != emoteLimiterLimit+2 {
+		t.Fatalf("expected every player within the cooldown kept, got %d", players)
+	}
+	limiter.allow("carol", now.Add(time.Second), time.Second)
+	if players := limiter.Players(); players != 2 {
+		t.Errorf("expected only Bob and Carol kept past the limit, got %d", players)
+	}
+}
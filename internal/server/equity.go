@@ -0,0 +1,239 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+)
+
+// equitySamples is how many random run-outs estimate equity when more than two board cards
+// are still to come; with two or fewer every run-out is dealt
+const equitySamples = 2000
+
+// equityCheckInterval is how many run-outs a simulation deals between checks for cancellation
+const equityCheckInterval = 100
+
+// EquityConfig bounds the equity simulations behind replay snippets
+type EquityConfig struct {
+	// Workers is how many simulations may run at once; the rest wait for a free worker.
+	// Zero means half the CPUs, at least one. Needs a restart.
+	Workers int `yaml:"workers"`
+	// Timeout abandons a simulation that has not finished, waiting included, after this long;
+	// zero means no limit
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// validate reports a negative worker count or timeout
+func (c EquityConfig) validate() error {
+	if c.Workers < 0 {
+		return errors.New("equity workers must not be negative")
+	}
+	if c.Timeout < 0 {
+		return errors.New("equity timeout must not be negative")
+	}
+	return nil
+}
+
+// EquityPoolStats are the figures of an EquityPool, reported in the runtime diagnostics
+type EquityPoolStats struct {
+	Workers   int `json:"workers"`
+	Busy      int `json:"busy"`      // Workers simulating now
+	Waiting   int `json:"waiting"`   // Simulations queued for a worker
+	Completed int `json:"completed"` // Simulations finished since start
+	Cancelled int `json:"cancelled"` // Simulations given up, waiting or running, since start
+	// Utilization is the share of the workers' time spent simulating since start, from 0 to 1
+	Utilization float64 `json:"utilization"`
+}
+
+// EquityPool runs equity simulations on a bounded number of workers, so all-ins finishing at
+// many tables at once queue up instead of taking every CPU. Closing the pool cancels the
+// simulations waiting and running. The nil EquityPool runs each simulation straight away.
+type EquityPool struct {
+	slots   chan struct{}
+	ctx     context.Context
+	cancel  context.CancelFunc
+	started time.Time
+
+	mu        sync.Mutex
+	busy      int
+	waiting   int
+	completed int
+	cancelled int
+	busyTime  time.Duration // Time spent simulating by finished and cancelled runs
+}
+
+// NewEquityPool creates an EquityPool of workers workers, half the CPUs when zero
+func NewEquityPool(workers int) *EquityPool {
+	if workers <= 0 {
+		workers = max(runtime.NumCPU()/2, 1)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &EquityPool{
+		slots:   make(chan struct{}, workers),
+		ctx:     ctx,
+		cancel:  cancel,
+		started: time.Now(),
+	}
+}
+
+// Equities returns handEquities once a worker is free to compute them
+// Returns an error if ctx is done or the pool closed before the simulation finished.
+func (p *EquityPool) Equities(ctx context.Context, holeCards map[int][]Card, board []Card, rng *rand.Rand) (map[int]float64, error) {
+	if p == nil {
+		return handEquities(ctx, holeCards, board, rng)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(p.ctx, cancel)
+	defer stop()
+
+	p.mu.Lock()
+	p.waiting++
+	p.mu.Unlock()
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		p.mu.Lock()
+		p.waiting--
+		p.cancelled++
+		p.mu.Unlock()
+		return nil, ctx.Err()
+	}
+	defer func() { <-p.slots }()
+
+	p.mu.Lock()
+	p.waiting--
+	p.busy++
+	p.mu.Unlock()
+	start := time.Now()
+	equities, err := handEquities(ctx, holeCards, board, rng)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.busy--
+	p.busyTime += time.Since(start)
+	if err != nil {
+		p.cancelled++
+	} else {
+		p.completed++
+	}
+	return equities, err
+}
+
+// Stats returns the pool's current figures
+func (p *EquityPool) Stats() EquityPoolStats {
+	if p == nil {
+		return EquityPoolStats{}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := EquityPoolStats{
+		Workers:   cap(p.slots),
+		Busy:      p.busy,
+		Waiting:   p.waiting,
+		Completed: p.completed,
+		Cancelled: p.cancelled,
+	}
+	if elapsed := time.Since(p.started) * time.Duration(cap(p.slots)); elapsed > 0 {
+		stats.Utilization = min(p.busyTime.Seconds()/elapsed.Seconds(), 1)
+	}
+	return stats
+}
+
+// Close cancels every simulation waiting or running; later ones fail at once
+func (p *EquityPool) Close() {
+	if p == nil {
+		return
+	}
+	p.cancel()
+}
+
+// handEquities returns each seat's share of the pot with the given hole cards and board, as a
+// fraction from 0 to 1: the share of run-outs it wins, ties counted as split. Run-outs are
+// sampled with rng when too many to deal out. Returns ctx's error if it is done first.
+func handEquities(ctx context.Context, holeCards map[int][]Card, board []Card, rng *rand.Rand) (map[int]float64, error) {
+	seats := make([]int, 0, len(holeCards))
+	used := slices.Clone(board)
+	for seat, cards := range holeCards {
+		seats = append(seats, seat)
+		used = append(used, cards...)
+	}
+	slices.Sort(seats)
+	var remaining []Card
+	for _, card := range NewDeck() {
+		if !slices.Contains(used, card) {
+			remaining = append(remaining, card)
+		}
+	}
+
+	wins := make(map[int]float64, len(seats))
+	runOuts := 0
+	runOut := func(extra []Card) error {
+		if runOuts%equityCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		full := append(slices.Clone(board), extra...)
+		var best HandRank
+		var winners []int
+		for _, seat := range seats {
+			rank := EvaluateHand(holeCards[seat], full)
+			switch cmp := CompareHands(rank, best); {
+			case winners == nil || cmp > 0:
+				best, winners = rank, []int{seat}
+			case cmp == 0:
+				winners = append(winners, seat)
+			}
+		}
+		for _, seat := range winners {
+			wins[seat] += 1 / float64(len(winners))
+		}
+		runOuts++
+		return nil
+	}
+
+	switch missing := 5 - len(board); {
+	case missing <= 0:
+		if err := runOut(nil); err != nil {
+			return nil, err
+		}
+	case missing == 1:
+		for _, card := range remaining {
+			if err := runOut([]Card{card}); err != nil {
+				return nil, err
+			}
+		}
+	case missing == 2:
+		for i := range remaining {
+			for j := i + 1; j < len(remaining); j++ {
+				if err := runOut([]Card{remaining[i], remaining[j]}); err != nil {
+					return nil, err
+				}
+			}
+		}
+	default:
+		deck := slices.Clone(remaining)
+		for range equitySamples {
+			// A partial Fisher-Yates shuffle draws the missing cards
+			for i := range missing {
+				j := i + rng.IntN(len(deck)-i)
+				deck[i], deck[j] = deck[j], deck[i]
+			}
+			if err := runOut(deck[:missing]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	equities := make(map[int]float64, len(seats))
+	for _, seat := range seats {
+		equities[seat] = wins[seat] / float64(runOuts)
+	}
+	return equities, nil
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Event types published on the server's EventBus
+const (
+	EventPlayerSeated  = "player_seated"  // A player took a seat; RemoteIP is set
+	EventPlayerLeft    = "player_left"    // A player's seat was cleared (leave, disconnect, logout or bust)
+	EventPlayerAction  = "player_action"  // A betting action was applied
+	EventHandStarted   = "hand_started"   // A hand was dealt; Players lists who was dealt in
+	EventHandEnded     = "hand_ended"     // A hand was paid out; Pot is the chips awarded plus rake
+	EventBoardDealt    = "board_dealt"    // Board cards were dealt; Street and Board are set
+	EventClockCalled   = "clock_called"   // An opponent called the clock on the current actor
+	EventHandCancelled = "hand_cancelled" // A hand was aborted and refunded; Street is where it stopped
+	EventCardsShown    = "cards_shown"    // The uncontested winner of a finished hand showed cards; HandID and Shown are set
+	// EventSeatTransferred: a seat kept for a dropped connection moved to the player's new
+	// session (see RecoverSeat); Token is the new session, PreviousToken the old and RemoteIP is set
+	EventSeatTransferred = "seat_transferred"
+)
+
+// Event is something that happened at a table, published for observers such as the
+// anti-fraud detector. Fields that do not apply to Type are left zero.
+type Event struct {
+	Type      string
+	TableID   string
+	Time      time.Time
+	SeatIndex int
+	Token     string
+	RemoteIP  string // player_seated and seat_transferred only
+	Busted    bool   // player_left only: the player lost their last chip
+
+	// hand_started only
+	Players    []string       // Tokens of the players dealt in
+	HandID     string         // Identifies the hand in replays (hand_ended and cards_shown: the finished hand)
+	DealerSeat int            // Seat with the button
+	Seats      map[int]string // Token per seat dealt in
+	Stacks     map[int]int    // Stack per seat dealt in, before the blinds
+	Blinds     map[int]int    // Blinds posted per seat
+	HoleCards  map[int][]Card // Cards dealt per seat
+
+	// player_action only
+	Street    string // Street the action was taken on (board_dealt: the street dealt)
+	Action    string
+	Amount    int    // Chips the action moved into the pot
+	BetToCall int    // Chips the player faced before acting
+	Pot       int    // Chips committed to the hand after the action, current street included (hand_ended: chips won)
+	Aggressor string // Token of the player who made the bet being faced, empty if none
+	Timeout   bool   // Applied by the server (action clock, logout) rather than sent by the player
+	StreetBet int    // The player's total bet on the street after the action
+	// ResponseTime is how long the player took to answer their action_request; 0 when it was
+	// not measured (timeouts and queued pre-actions)
+	ResponseTime time.Duration
+
+	// hand_ended only
+	Winnings    map[int]int    // Chips won per seat, after rake
+	Rake        map[int]int    // Rake charged per seat, in proportion to the chips each put in
+	WinningRank *HandRank      // Best hand shown down; nil when the hand was won uncontested
+	ShownDown   map[int][]Card // Cards turned over at showdown per seat; mucked hands are left out
+
+	// board_dealt only
+	Board   []Card       // The whole board after the deal
+	Texture BoardTexture // How coordinated Board is
+
+	// clock_called only
+	CalledBy int // Seat that called the clock on SeatIndex
+
+	// cards_shown only
+	Shown []Card // Every card SeatIndex has shown from the hand
+
+	// seat_transferred only
+	PreviousToken string // Token the seat was held under before the transfer
+}
+
+// eventBufferSize is the per-subscriber queue length; events beyond it are dropped
+const eventBufferSize = 256
+
+// EventBus fans events out to subscribers without ever blocking the publisher
+// A subscriber that falls behind loses events rather than stalling the tables.
+// The nil EventBus discards everything, so tables built without a server still work.
+type EventBus struct {
+	mu          sync.RWMutex
+	subscribers map[int]chan Event
+	nextID      int
+	logger      *slog.Logger
+}
+
+// NewEventBus creates an EventBus with no subscribers
+func NewEventBus(logger *slog.Logger) *EventBus {
+	return &EventBus{
+		subscribers: make(map[int]chan Event),
+		logger:      logger,
+	}
+}
+
+// Subscribe returns a channel receiving every event published from now on and a
+// function that unsubscribes and closes the channel
+func (b *EventBus) Subscribe() (<-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	events := make(chan Event, eventBufferSize)
+	b.subscribers[id] = events
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, id)
+			close(events)
+			b.mu.Unlock()
+		})
+	}
+	return events, unsubscribe
+}
+
+// Publish delivers e to every subscriber that has room for it
+// Safe to call with table locks held
+func (b *EventBus) Publish(e Event) {
+	if b == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, events := range b.subscribers {
+		select {
+		case events <- e:
+		default:
+			b.logger.Warn("event subscriber full, dropping event", "type", e.Type, "tableID", e.TableID)
+		}
+	}
+}
+
+// publishEvent publishes e on the server's event bus, stamped with the table ID
+func (t *Table) publishEvent(e Event) {
+	if t.Server == nil {
+		return
+	}
+	e.TableID = t.ID
+	t.Server.events.Publish(e)
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// TestEventBus_DeliversAndDrops verifies subscribers get events, full subscribers never block, and unsubscribe closes
+func TestEventBus_DeliversAndDrops(t *testing.T) {
+	bus := NewEventBus(slog.Default())
+	events, unsubscribe := bus.Subscribe()
+
+	for i := 0; i < eventBufferSize+10; i++ {
+		bus.Publish(Event{Type: EventPlayerAction, SeatIndex: i})
+	}
+
+	first := <-events
+	if first.SeatIndex != 0 || first.Time.IsZero() {
+		t.Errorf("expected first event stamped with a time, got %+v", first)
+	}
+
+	unsubscribe()
+	unsubscribe()
+	count := 1
+	for range events {
+		count++
+	}
+	if count != eventBufferSize {
+		t.Errorf("expected %d buffered events, got %d", eventBufferSize, count)
+	}
+
+	var nilBus *EventBus
+	nilBus.Publish(Event{Type: EventPlayerLeft})
+}
+
+// TestProcessTableAction_PublishesFoldWithAggressor verifies action events name the bettor being folded to
+func TestProcessTableAction_PublishesFoldWithAggressor(t *testing.T) {
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+	seatTwoPlayers(table)
+	events, unsubscribe := server.events.Subscribe()
+	defer unsubscribe()
+
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+
+	table.mu.RLock()
+	actor := *table.CurrentHand.CurrentActor
+	bigBlind := table.CurrentHand.BigBlindSeat
+	table.mu.RUnlock()
+
+	if err := server.processTableAction(context.Background(), table, nil, "", actor, "fold"); err != nil {
+		t.Fatalf("fold failed: %v", err)
+	}
+
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case e := <-events:
+			if e.Type != EventPlayerAction {
+				continue
+			}
+			if e.Action != "fold" || e.SeatIndex != actor || e.TableID != table.ID {
+				t.Fatalf("unexpected event %+v", e)
+			}
+			if want := *table.Seats[bigBlind].Token; e.Aggressor != want {
+				t.Errorf("expected aggressor %q (big blind), got %q", want, e.Aggressor)
+			}
+			if e.BetToCall != table.BigBlind-table.SmallBlind || !e.Timeout {
+				t.Errorf("expected a server fold facing %d, got %+v", table.BigBlind-table.SmallBlind, e)
+			}
+			return
+		case <-timeout:
+			t.Fatal("expected a player_action event")
+		}
+	}
+}
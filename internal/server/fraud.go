@@ -0,0 +1,227 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Fraud alert kinds
+const (
+	AlertChipDumping = "chip_dumping" // The same player keeps folding to large bets from the same opponent
+	AlertSharedIP    = "shared_ip"    // Two players at one table connect from the same IP
+)
+
+// maxFraudAlerts bounds the alerts kept in memory; the oldest are dropped first
+const maxFraudAlerts = 500
+
+// foldPairPruneThreshold is the number of tracked pairs above which stale pairs are swept
+const foldPairPruneThreshold = 1024
+
+// FraudConfig tunes the anti-fraud detector. Detectors only raise alerts for
+// operators to review; nobody is punished automatically. The zero value disables them.
+type FraudConfig struct {
+	// ChipDumpFolds is how many times one player may fold to a large bet from the same
+	// opponent within ChipDumpWindow before an alert is raised. Zero disables the check.
+	ChipDumpFolds  int           `yaml:"chipDumpFolds"`
+	ChipDumpWindow time.Duration `yaml:"chipDumpWindow"`
+	// LargeBetPotFraction is the share of the pot (including the bet) a bet must reach to count as large
+	LargeBetPotFraction float64 `yaml:"largeBetPotFraction"`
+
+	// SharedIP raises an alert when two players at the same table share an IP address
+	SharedIP bool `yaml:"sharedIP"`
+}
+
+// validate reports fraud settings the detector cannot use
+func (c FraudConfig) validate() error {
+	if c.ChipDumpFolds < 0 || c.ChipDumpWindow < 0 {
+		return errors.New("fraud settings must not be negative")
+	}
+	if c.LargeBetPotFraction < 0 || c.LargeBetPotFraction > 1 {
+		return errors.New("fraud.largeBetPotFraction must be between 0 and 1")
+	}
+	if c.ChipDumpFolds > 0 && c.ChipDumpWindow == 0 {
+		return errors.New("fraud.chipDumpWindow is required when fraud.chipDumpFolds is set")
+	}
+	return nil
+}
+
+// FraudAlert is a suspicious pattern surfaced to operators through the admin API
+type FraudAlert struct {
+	ID        int       `json:"id"`
+	Kind      string    `json:"kind"`
+	TableID   string    `json:"tableId"`
+	Players   []string  `json:"players"` // Names of the players involved (session tokens are never exposed)
+	Detail    string    `json:"detail"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// seatedPlayer is what the detector remembers about an occupied seat
+type seatedPlayer struct {
+	Token    string
+	RemoteIP string
+}
+
+// foldPair identifies a bettor and the player who folded to them
+type foldPair struct {
+	Bettor string
+	Folder string
+}
+
+// FraudDetector consumes table events and raises FraudAlerts
+// All state is built from events, so it never touches table locks
+type FraudDetector struct {
+	mu         sync.Mutex
+	seats      map[string]map[int]seatedPlayer // tableID -> seat -> player
+	folds      map[foldPair][]time.Time        // Recent folds to large bets per pair
+	alerts     []FraudAlert
+	nextID     int
+	config     func() FraudConfig
+	playerName func(token string) string
+	logger     *slog.Logger
+}
+
+// NewFraudDetector creates a detector reading its settings from config on every event
+// and naming players in alerts with playerName
+func NewFraudDetector(config func() FraudConfig, playerName func(token string) string, logger *slog.Logger) *FraudDetector {
+	return &FraudDetector{
+		seats:      make(map[string]map[int]seatedPlayer),
+		folds:      make(map[foldPair][]time.Time),
+		nextID:     1,
+		config:     config,
+		playerName: playerName,
+		logger:     logger,
+	}
+}
+
+// Run handles events until the channel is closed
+func (d *FraudDetector) Run(events <-chan Event) {
+	for e := range events {
+		d.handle(e)
+	}
+}
+
+// Alerts returns the alerts with an ID greater than since, oldest first
+func (d *FraudDetector) Alerts(since int) []FraudAlert {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	alerts := make([]FraudAlert, 0)
+	for _, alert := range d.alerts {
+		if alert.ID > since {
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts
+}
+
+// handle updates the detector's view of the tables with e and runs the checks it triggers
+func (d *FraudDetector) handle(e Event) {
+	cfg := d.config()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	switch e.Type {
+	case EventPlayerSeated:
+		d.handleSeatedLocked(e, cfg)
+	case EventSeatTransferred:
+		// The seat's new connection is checked like a new player's
+		if seats, ok := d.seats[e.TableID]; ok && seats[e.SeatIndex].Token == e.PreviousToken {
+			delete(seats, e.SeatIndex)
+		}
+		d.handleSeatedLocked(e, cfg)
+	case EventPlayerLeft:
+		if seats, ok := d.seats[e.TableID]; ok && seats[e.SeatIndex].Token == e.Token {
+			delete(seats, e.SeatIndex)
+		}
+	case EventPlayerAction:
+		if e.Action == "fold" {
+			d.handleFoldLocked(e, cfg)
+		}
+	}
+}
+
+// handleSeatedLocked records the new player and checks their IP against the rest of the table
+func (d *FraudDetector) handleSeatedLocked(e Event, cfg FraudConfig) {
+	seats, ok := d.seats[e.TableID]
+	if !ok {
+		seats = make(map[int]seatedPlayer)
+		d.seats[e.TableID] = seats
+	}
+
+	if cfg.SharedIP && e.RemoteIP != "" {
+		for _, other := range seats {
+			if other.RemoteIP == e.RemoteIP && other.Token != e.Token {
+				d.raiseLocked(AlertSharedIP, e.TableID, []string{other.Token, e.Token},
+					fmt.Sprintf("players share IP %s", e.RemoteIP), e.Time)
+			}
+		}
+	}
+
+	seats[e.SeatIndex] = seatedPlayer{Token: e.Token, RemoteIP: e.RemoteIP}
+}
+
+// handleFoldLocked counts voluntary folds to large bets per bettor/folder pair
+func (d *FraudDetector) handleFoldLocked(e Event, cfg FraudConfig) {
+	if cfg.ChipDumpFolds <= 0 || e.Timeout || e.Aggressor == "" || e.Aggressor == e.Token || e.Pot <= 0 {
+		return
+	}
+	if float64(e.BetToCall) < cfg.LargeBetPotFraction*float64(e.Pot) {
+		return
+	}
+
+	if len(d.folds) > foldPairPruneThreshold {
+		for pair, times := range d.folds {
+			if e.Time.Sub(times[len(times)-1]) >= cfg.ChipDumpWindow {
+				delete(d.folds, pair)
+			}
+		}
+	}
+
+	pair := foldPair{Bettor: e.Aggressor, Folder: e.Token}
+	recent := d.folds[pair][:0]
+	for _, at := range d.folds[pair] {
+		if e.Time.Sub(at) < cfg.ChipDumpWindow {
+			recent = append(recent, at)
+		}
+	}
+	recent = append(recent, e.Time)
+
+	if len(recent) < cfg.ChipDumpFolds {
+		d.folds[pair] = recent
+		return
+	}
+
+	// Start counting afresh so one pattern raises one alert per ChipDumpFolds folds
+	delete(d.folds, pair)
+	d.raiseLocked(AlertChipDumping, e.TableID, []string{e.Aggressor, e.Token},
+		fmt.Sprintf("folded to large bets from the same opponent %d times within %s", len(recent), cfg.ChipDumpWindow), e.Time)
+}
+
+// raiseLocked stores a new alert and logs it for operators
+func (d *FraudDetector) raiseLocked(kind, tableID string, tokens []string, detail string, at time.Time) {
+	players := make([]string, len(tokens))
+	for i, token := range tokens {
+		players[i] = d.playerName(token)
+	}
+
+	alert := FraudAlert{
+		ID:        d.nextID,
+		Kind:      kind,
+		TableID:   tableID,
+		Players:   players,
+		Detail:    detail,
+		CreatedAt: at,
+	}
+	d.nextID++
+
+	d.alerts = append(d.alerts, alert)
+	if len(d.alerts) > maxFraudAlerts {
+		d.alerts = d.alerts[len(d.alerts)-maxFraudAlerts:]
+	}
+
+	d.logger.Warn("fraud alert", "id", alert.ID, "kind", kind, "tableID", tableID, "players", players, "tokens", tokens, "detail", detail)
+}
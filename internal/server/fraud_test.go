@@ -0,0 +1,111 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// newTestFraudDetector returns a detector with fixed settings that names players after their tokens
+func newTestFraudDetector(cfg FraudConfig) *FraudDetector {
+	return NewFraudDetector(func() FraudConfig { return cfg }, func(token string) string { return "name-" + token }, slog.Default())
+}
+
+// largeFold is a fold by folder facing a pot-sized bet from bettor
+func largeFold(bettor, folder string, at time.Time) Event {
+	return Event{Type: EventPlayerAction, TableID: "table-1", Time: at, Token: folder,
+		Action: "fold", BetToCall: 100, Pot: 150, Aggressor: bettor}
+}
+
+// TestFraudDetector_ChipDumping verifies repeated folds to large bets between one pair raise one alert
+func TestFraudDetector_ChipDumping(t *testing.T) {
+	detector := newTestFraudDetector(FraudConfig{ChipDumpFolds: 3, ChipDumpWindow: time.Hour, LargeBetPotFraction: 0.5})
+	now := time.Now()
+
+	detector.handle(largeFold("alice", "bob", now))
+	detector.handle(largeFold("alice", "bob", now.Add(time.Minute)))
+	detector.handle(largeFold("carol", "bob", now.Add(2*time.Minute)))
+	if alerts := detector.Alerts(0); len(alerts) != 0 {
+		t.Fatalf("expected no alert before the threshold, got %+v", alerts)
+	}
+
+	detector.handle(largeFold("alice", "bob", now.Add(3*time.Minute)))
+	alerts := detector.Alerts(0)
+	if len(alerts) != 1 {
+		t.Fatalf("expected one alert, got %+v", alerts)
+	}
+	if alerts[0].Kind != AlertChipDumping || alerts[0].Players[0] != "name-alice" || alerts[0].Players[1] != "name-bob" {
+		t.Errorf("unexpected alert %+v", alerts[0])
+	}
+	if got := detector.Alerts(alerts[0].ID); len(got) != 0 {
+		t.Errorf("expected no alerts after since=%d, got %+v", alerts[0].ID, got)
+	}
+}
+
+// TestFraudDetector_IgnoresInnocentFolds verifies small bets, server folds and stale folds are not counted
+func TestFraudDetector_IgnoresInnocentFolds(t *testing.T) {
+	detector := newTestFraudDetector(FraudConfig{ChipDumpFolds: 2, ChipDumpWindow: time.Hour, LargeBetPotFraction: 0.5})
+	now := time.Now()
+
+	small := largeFold("alice", "bob", now)
+	small.BetToCall = 20
+	timeout := largeFold("alice", "bob", now)
+	timeout.Timeout = true
+
+	detector.handle(small)
+	detector.handle(timeout)
+	detector.handle(largeFold("alice", "bob", now))
+	detector.handle(largeFold("alice", "bob", now.Add(2*time.Hour)))
+
+	if alerts := detector.Alerts(0); len(alerts) != 0 {
+		t.Errorf("expected no alerts, got %+v", alerts)
+	}
+}
+
+// TestFraudDetector_SharedIP verifies players sharing an IP at one table are flagged, and leaving clears them
+func TestFraudDetector_SharedIP(t *testing.T) {
+	detector := newTestFraudDetector(FraudConfig{SharedIP: true})
+
+	detector.handle(Event{Type: EventPlayerSeated, TableID: "table-1", SeatIndex: 0, Token: "alice", RemoteIP: "203.0.113.7"})
+	detector.handle(Event{Type: EventPlayerSeated, TableID: "table-2", SeatIndex: 0, Token: "bob", RemoteIP: "203.0.113.7"})
+	if alerts := detector.Alerts(0); len(alerts) != 0 {
+		t.Fatalf("expected no alert across tables, got %+v", alerts)
+	}
+
+	detector.handle(Event{Type: EventPlayerLeft, TableID: "table-1", SeatIndex: 0, Token: "alice"})
+	detector.handle(Event{Type: EventPlayerSeated, TableID: "table-2", SeatIndex: 1, Token: "carol", RemoteIP: "198.51.100.1"})
+	detector.handle(Event{Type: EventPlayerSeated, TableID: "table-1", SeatIndex: 3, Token: "dave", RemoteIP: "203.0.113.7"})
+	if alerts := detector.Alerts(0); len(alerts) != 0 {
+		t.Fatalf("expected no alert after the first player left, got %+v", alerts)
+	}
+
+	detector.handle(Event{Type: EventPlayerSeated, TableID: "table-2", SeatIndex: 2, Token: "erin", RemoteIP: "203.0.113.7"})
+	alerts := detector.Alerts(0)
+	if len(alerts) != 1 || alerts[0].Kind != AlertSharedIP || alerts[0].TableID != "table-2" {
+		t.Fatalf("expected one shared_ip alert at table-2, got %+v", alerts)
+	}
+}
+
+// TestAdminAPI_ListsFraudAlerts verifies alerts are served by the admin API
+func TestAdminAPI_ListsFraudAlerts(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{AdminToken: "secret", Fraud: FraudConfig{SharedIP: true}})
+
+	server.fraud.handle(Event{Type: EventPlayerSeated, TableID: "table-1", SeatIndex: 0, Token: "alice", RemoteIP: "203.0.113.7"})
+	server.fraud.handle(Event{Type: EventPlayerSeated, TableID: "table-1", SeatIndex: 1, Token: "bob", RemoteIP: "203.0.113.7"})
+
+	rec := adminRequest(server, http.MethodGet, "/admin/alerts", "secret", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var alerts []FraudAlert
+	json.Unmarshal(rec.Body.Bytes(), &alerts)
+	if len(alerts) != 1 || alerts[0].Kind != AlertSharedIP {
+		t.Errorf("expected the shared_ip alert, got %+v", alerts)
+	}
+
+	if rec := adminRequest(server, http.MethodGet, "/admin/alerts?since=x", "secret", ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a bad since, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+// TableFreeze records why and when an admin froze a table
+type TableFreeze struct {
+	Reason   string    `json:"reason,omitempty"`
+	FrozenAt time.Time `json:"frozenAt"`
+}
+
+// FreezeRequest is the body of POST /admin/tables/{tableID}/freeze
+type FreezeRequest struct {
+	Reason string `json:"reason,omitempty"` // Free text kept with the freeze and logged
+}
+
+// DissolveResult is the result of POST /admin/tables/{tableID}/dissolve
+type DissolveResult struct {
+	TableID string      `json:"tableId"`
+	Stacks  map[int]int `json:"stacks"` // Chips cashed out per seat, cancelled hand refunds included
+}
+
+var (
+	errTableNotFound  = errors.New("table not found")
+	errTableFrozen    = errors.New("table is already frozen")
+	errTableNotFrozen = errors.New("table is not frozen")
+)
+
+// Freeze suspends the table for an investigation: the hand in progress is played out, but no
+// further hand is dealt and players can neither join nor leave until the table is resumed or
+// dissolved. Everything else is left untouched for inspection (see SnapshotTable).
+func (t *Table) Freeze(reason string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.freeze != nil {
+		return errTableFrozen
+	}
+	t.freeze = &TableFreeze{Reason: reason, FrozenAt: time.Now()}
+	t.cancelNextHandLocked()
+	return nil
+}
+
+// Resume lifts a freeze and restarts the countdown to the next hand
+func (t *Table) Resume() error {
+	t.mu.Lock()
+	if t.freeze == nil {
+		t.mu.Unlock()
+		return errTableNotFrozen
+	}
+	t.freeze = nil
+	t.mu.Unlock()
+
+	t.ScheduleNextHand()
+	return nil
+}
+
+// Frozen reports whether an admin has frozen the table (thread-safe)
+func (t *Table) Frozen() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.freeze != nil
+}
+
+// FreezeTable freezes the table with tableID and tells its players
+func (s *Server) FreezeTable(tableID, reason string) error {
+	table := s.tableByID(tableID)
+	if table == nil {
+		return errTableNotFound
+	}
+	if err := table.Freeze(reason); err != nil {
+		return err
+	}
+
+	table.mu.RLock()
+	handInProgress := table.CurrentHand != nil
+	table.mu.RUnlock()
+	s.logger.Warn("table frozen", "tableID", tableID, "reason", reason, "handInProgress", handInProgress)
+	s.notifyTableStatus(table)
+	return nil
+}
+
+// ResumeTable lifts the freeze on the table with tableID and tells its players
+func (s *Server) ResumeTable(tableID string) error {
+	table := s.tableByID(tableID)
+	if table == nil {
+		return errTableNotFound
+	}
+	if err := table.Resume(); err != nil {
+		return err
+	}
+
+	s.logger.Warn("table resumed", "tableID", tableID)
+	s.notifyTableStatus(table)
+	return nil
+}
+
+// DissolveTable closes a frozen table for good: a hand still running is cancelled and refunded,
+// every player is cashed out and unseated, and the table is removed from the lobby
+func (s *Server) DissolveTable(tableID string) (DissolveResult, error) {
+	table := s.tableByID(tableID)
+	if table == nil {
+		return DissolveResult{}, errTableNotFound
+	}
+	if !table.Frozen() {
+		return DissolveResult{}, errTableNotFrozen
+	}
+
+	table.CancelHand(CancelTableDissolved, errors.New("table dissolved by an admin"))
+
+	s.mu.Lock()
+	s.tables = slices.DeleteFunc(s.tables, func(t *Table) bool { return t == table })
+	s.mu.Unlock()
+
+	table.mu.Lock()
+	table.cancelNextHandLocked()
+	table.stopActionClockLocked()
+	result := DissolveResult{TableID: tableID, Stacks: make(map[int]int)}
+	tokens := make(map[int]string)
+	for i, seat := range table.Seats {
+		if seat.Token != nil {
+			tokens[i] = *seat.Token
+			result.Stacks[i] = seat.Stack
+		}
+	}
+	table.mu.Unlock()
+
+	for _, token := range tokens {
+		if err := table.ClearSeat(&token); err != nil {
+			s.logger.Warn("failed to clear seat of dissolved table", "tableID", tableID, "token", token, "error", err)
+			continue
+		}
+		if _, err := s.sessionManager.UpdateSession(token, nil, nil); err != nil {
+			s.logger.Debug("session not updated after table dissolved", "token", token, "error", err)
+		}
+		s.sendPrivate(token, "seat_cleared", SeatClearedPayload{})
+	}
+	for _, token := range s.observers.Tokens(tableID) {
+		s.observers.Remove(token)
+	}
+
+	s.logger.Warn("table dissolved", "tableID", tableID, "stacks", result.Stacks)
+	if err := s.broadcastLobbyState(); err != nil {
+		s.logger.Warn("failed to broadcast lobby state after dissolving table", "error", err)
+	}
+	return result, nil
+}
+
+// notifyTableStatus sends table_state to the table and lobby_state to everyone after the
+// table was frozen or resumed
+func (s *Server) notifyTableStatus(table *Table) {
+	if err := s.broadcastTableState(table.ID, nil); err != nil {
+		s.logger.Warn("failed to broadcast table_state", "tableID", table.ID, "error", err)
+	}
+	if err := s.broadcastLobbyState(); err != nil {
+		s.logger.Warn("failed to broadcast lobby state", "error", err)
+	}
+}
@@ -0,0 +1,155 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestFreezeTable_BlocksHandsJoinsAndLeaves verifies a frozen table deals no new hand and keeps its
+// players where they are until it is resumed
+func TestFreezeTable_BlocksHandsJoinsAndLeaves(t *testing.T) {
+	server, table, clients := preActionTable(t)
+
+	if err := server.FreezeTable(table.ID, "chip dumping report"); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.FreezeTable(table.ID, ""); err != errTableFrozen {
+		t.Errorf("expected errTableFrozen freezing twice, got %v", err)
+	}
+
+	// The hand in progress is played out
+	actCurrent(t, server, table, "fold")
+	actCurrent(t, server, table, "fold")
+	table.mu.RLock()
+	handRunning := table.CurrentHand != nil
+	table.mu.RUnlock()
+	if handRunning {
+		t.Fatal("expected the hand to finish while frozen")
+	}
+	if table.CanStartHand() {
+		t.Error("expected no hand to start while frozen")
+	}
+
+	session, _ := server.sessionManager.CreateSession("Dave")
+	joiner := connectTestClient(server, session.Token)
+	payload, _ := json.Marshal(JoinTablePayload{TableId: table.ID})
+	err := joiner.HandleJoinTable(server.sessionManager, server, slog.Default(), payload)
+	if key, _ := errorMessageKey(err); key != "error.table_frozen" {
+		t.Errorf("expected error.table_frozen joining, got %v", err)
+	}
+	err = clients[0].HandleLeaveTable(server.sessionManager, server, slog.Default(), nil)
+	if key, _ := errorMessageKey(err); key != "error.table_frozen" {
+		t.Errorf("expected error.table_frozen leaving, got %v", err)
+	}
+	server.HandleDisconnect(clients[1].Token)
+	if _, seated := table.GetSeatByToken(&clients[1].Token); !seated {
+		t.Error("expected the seat to be kept on disconnect while frozen")
+	}
+
+	found := false
+	for _, msg := range drainRawMessages(clients[2]) {
+		if strings.Contains(msg, `"type":"table_state"`) && strings.Contains(msg, `"frozen":true`) {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected table_state to show the table frozen")
+	}
+
+	if err := server.ResumeTable(table.ID); err != nil {
+		t.Fatal(err)
+	}
+	if !table.CanStartHand() {
+		t.Error("expected hands to start again after resuming")
+	}
+	if err := server.ResumeTable(table.ID); err != errTableNotFrozen {
+		t.Errorf("expected errTableNotFrozen resuming twice, got %v", err)
+	}
+}
+
+// TestDissolveTable_RefundsAndUnseats verifies dissolving cancels the hand, cashes everyone out
+// with their contributions returned and removes the table
+func TestDissolveTable_RefundsAndUnseats(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	want := stacksBeforeHand(table)
+
+	if _, err := server.DissolveTable(table.ID); err != errTableNotFrozen {
+		t.Fatalf("expected errTableNotFrozen dissolving an open table, got %v", err)
+	}
+	if err := server.FreezeTable(table.ID, ""); err != nil {
+		t.Fatal(err)
+	}
+	result, err := server.DissolveTable(table.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for seat, stack := range want {
+		if result.Stacks[seat] != stack {
+			t.Errorf("seat %d: expected %d cashed out, got %d", seat, stack, result.Stacks[seat])
+		}
+	}
+	if server.tableByID(table.ID) != nil {
+		t.Error("expected the table to be removed")
+	}
+	for seat, client := range clients {
+		if server.FindPlayerSeat(&client.Token) != nil {
+			t.Errorf("seat %d: expected the player to be unseated", seat)
+		}
+		session, _ := server.sessionManager.GetSession(client.Token)
+		if session.TableID != nil {
+			t.Errorf("seat %d: expected the session to leave the table", seat)
+		}
+	}
+	incidents := server.incidents.List(0)
+	if len(incidents) != 1 || incidents[0].Reason != CancelTableDissolved {
+		t.Errorf("expected the cancelled hand to be recorded, got %+v", incidents)
+	}
+}
+
+// TestAdminAPI_FreezeTable verifies the freeze, resume and dissolve routes
+func TestAdminAPI_FreezeTable(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{AdminToken: "secret"})
+	tableID := server.tables[0].ID
+
+	rec := adminRequest(server, http.MethodPost, "/admin/tables/nope/freeze", "secret", "")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown table, got %d", rec.Code)
+	}
+
+	rec = adminRequest(server, http.MethodPost, "/admin/tables/"+tableID+"/dissolve", "secret", "")
+	if rec.Code != http.StatusConflict {
+		t.Errorf("expected 409 dissolving an open table, got %d", rec.Code)
+	}
+
+	rec = adminRequest(server, http.MethodPost, "/admin/tables/"+tableID+"/freeze", "secret", `{"reason": "collusion review"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var snapshot TableSnapshot
+	if err := json.Unmarshal(rec.Body.Bytes(), &snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Freeze == nil || snapshot.Freeze.Reason != "collusion review" {
+		t.Errorf("expected the snapshot to show the freeze, got %+v", snapshot.Freeze)
+	}
+
+	rec = adminRequest(server, http.MethodPost, "/admin/tables/"+tableID+"/resume", "secret", "")
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200 resuming, got %d", rec.Code)
+	}
+
+	adminRequest(server, http.MethodPost, "/admin/tables/"+tableID+"/freeze", "secret", "")
+	rec = adminRequest(server, http.MethodPost, "/admin/tables/"+tableID+"/dissolve", "secret", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 dissolving, got %d", rec.Code)
+	}
+	for _, info := range server.GetLobbyState() {
+		if info.ID == tableID {
+			t.Error("expected the dissolved table to leave the lobby")
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"time"
+)
+
+// ScheduleNextHand arranges for the next hand to start automatically after the
+// configured NextHandDelay (shortened for turbo and hyper tables), broadcasting a next_hand timer_tick every second until then.
+// Does nothing if scheduling is disabled, a countdown is already running, or the
+// table cannot start a hand (fewer than 2 players or a hand already running).
+// A table left with fewer than 2 players between hands is paused instead: any countdown is
+// cancelled and the table is told it is waiting for players, until enough sit down again.
+// Returns true if a new countdown was started.
+func (t *Table) ScheduleNextHand() bool {
+	if t.nextHandDelay() <= 0 {
+		return false
+	}
+
+	t.mu.Lock()
+	players := t.playerCountLocked()
+	if !t.canStartHandLocked() {
+		pause := t.CurrentHand == nil && t.freeze == nil && !t.hostPaused && !t.paused
+		if pause {
+			t.paused = true
+			t.cancelNextHandLocked()
+		}
+		t.mu.Unlock()
+		if pause {
+			t.logInfo("table paused, waiting for players", "players", players)
+			t.notifyPause(true, players)
+		}
+		return false
+	}
+	resumed := t.paused
+	t.paused = false
+	if t.nextHandCancel != nil {
+		t.mu.Unlock()
+		return false
+	}
+	cancel := make(chan struct{})
+	t.nextHandCancel = cancel
+	deadline := t.clock().Now().Add(t.nextHandDelay())
+	t.NextHandAt = &deadline
+	t.mu.Unlock()
+
+	if resumed {
+		t.logInfo("table resumed", "players", players)
+		t.notifyPause(false, players)
+	}
+	t.logInfo("next hand scheduled", "startsAt", deadline)
+	go t.runNextHandCountdown(deadline, cancel)
+	return true
+}
+
+// TablePausePayload represents the payload for waiting_for_players and table_resumed messages
+type TablePausePayload struct {
+	TableID    string `json:"tableId"`
+	Players    int    `json:"players"` // Players able to play the next hand
+	MinPlayers int    `json:"minPlayers"`
+}
+
+// notifyPause tells the table it was paused for lack of players, or resumed
+// Must be called without the table lock held
+func (t *Table) notifyPause(paused bool, players int) {
+	if t.Server == nil {
+		return
+	}
+	msgType := "table_resumed"
+	if paused {
+		msgType = "waiting_for_players"
+	}
+	payload := TablePausePayload{TableID: t.ID, Players: players, MinPlayers: 2}
+	if err := t.Server.broadcastTableMessage(t, msgType, payload); err != nil {
+		t.logWarn("failed to broadcast "+msgType, "error", err)
+	}
+	if err := t.Server.broadcastTableState(t.ID, nil); err != nil {
+		t.logWarn("failed to broadcast table_state", "error", err)
+	}
+}
+
+// cancelNextHandLocked stops a pending automatic hand start (internal, must be called with lock held)
+func (t *Table) cancelNextHandLocked() {
+	if t.nextHandCancel != nil {
+		close(t.nextHandCancel)
+		t.nextHandCancel = nil
+	}
+	t.NextHandAt = nil
+}
+
+// runNextHandCountdown ticks the next_hand timer until deadline and then starts the hand
+// Exits early if cancel is closed (a hand was started by other means)
+func (t *Table) runNextHandCountdown(deadline time.Time, cancel chan struct{}) {
+	t.runTimer(TimerNextHand, nil, deadline, cancel, func() {
+		// Claim the countdown; if it was cancelled in the meantime another start won the race
+		t.mu.Lock()
+		if t.nextHandCancel != cancel {
+			t.mu.Unlock()
+			return
+		}
+		t.nextHandCancel = nil
+		t.NextHandAt = nil
+		canStart := t.canStartHandLocked()
+		t.mu.Unlock()
+
+		if !canStart {
+			t.logInfo("scheduled hand skipped, table can no longer start a hand")
+			return
+		}
+
+		if err := t.StartHand(); err != nil {
+			t.logWarn("failed to start scheduled hand", "error", err)
+		}
+	})
+}
+
+// paceStreet deals street by calling deal once the configured pacing delay has passed
+// With no delay deal runs immediately. Otherwise nobody is on the clock during the pause,
+// and deal is skipped if the hand ended or moved on meanwhile (everyone else folded or left).
+// Must be called without the table lock held
+func (t *Table) paceStreet(street string, deal func()) {
+	t.mu.Lock()
+	delay := t.pacingDelayLocked(street)
+	if delay <= 0 {
+		t.mu.Unlock()
+		deal()
+		return
+	}
+
+	hand := t.CurrentHand
+	if hand == nil {
+		t.mu.Unlock()
+		return
+	}
+	fromStreet := hand.Street
+	// Betting is closed; without an actor no action is accepted until the street is dealt
+	hand.CurrentActor = nil
+	t.mu.Unlock()
+
+	t.logInfo("pacing street", "street", street, "delay", delay)
+	t.clock().AfterFunc(delay, func() {
+		t.mu.RLock()
+		current := t.CurrentHand == hand && hand.Street == fromStreet
+		t.mu.RUnlock()
+		if !current {
+			return
+		}
+		deal()
+	})
+}
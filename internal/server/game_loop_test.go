@@ -0,0 +1,360 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// seatTwoPlayers seats two active players with 1000 chips at seats 0 and 1
+func seatTwoPlayers(table *Table) {
+	token1 := "player1"
+	token2 := "player2"
+	table.mu.Lock()
+	table.Seats[0] = Seat{Index: 0, Token: &token1, Status: "active", Stack: 1000}
+	table.Seats[1] = Seat{Index: 1, Token: &token2, Status: "active", Stack: 1000}
+	table.mu.Unlock()
+}
+
+// TestScheduleNextHand_DisabledByDefault verifies the zero Config never schedules hands
+func TestScheduleNextHand_DisabledByDefault(t *testing.T) {
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+	seatTwoPlayers(table)
+
+	if table.ScheduleNextHand() {
+		t.Error("expected no countdown with NextHandDelay = 0")
+	}
+}
+
+// TestScheduleNextHand_RequiresTwoPlayers verifies no countdown starts without enough players
+func TestScheduleNextHand_RequiresTwoPlayers(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{NextHandDelay: time.Second})
+	table := server.tables[0]
+
+	token1 := "player1"
+	table.Seats[0] = Seat{Index: 0, Token: &token1, Status: "waiting", Stack: 1000}
+
+	if table.ScheduleNextHand() {
+		t.Error("expected no countdown with a single player")
+	}
+}
+
+// TestScheduleNextHand_StartsHandAfterDelay verifies the countdown is broadcast and the hand starts
+func TestScheduleNextHand_StartsHandAfterDelay(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{NextHandDelay: 3 * time.Second})
+	clock := useFakeClock(server)
+	table := server.tables[0]
+	seatTwoPlayers(table)
+
+	client := &Client{hub: server.hub, Token: "player1", send: make(chan []byte, 256)}
+	server.hub.mu.Lock()
+	server.hub.clients[client] = true
+	server.hub.mu.Unlock()
+
+	if !table.ScheduleNextHand() {
+		t.Fatal("expected countdown to be scheduled")
+	}
+	if table.ScheduleNextHand() {
+		t.Error("expected second schedule call to be ignored while countdown runs")
+	}
+
+	// One tick per second remaining, then the hand starts
+	for second := 0; second < 3; second++ {
+		clock.waitForTimers(t, 1)
+		if phase := table.Phase(); phase == PhasePreflop {
+			t.Fatalf("expected the hand to wait for the countdown, started after %ds", second)
+		}
+		clock.Advance(time.Second)
+	}
+	if !eventually(func() bool { return table.Phase() == PhasePreflop }) {
+		t.Fatalf("expected scheduled hand to start, phase is %s", table.Phase())
+	}
+
+	// The first messages must be the countdown ticks
+	for _, remaining := range []int64{3000, 2000, 1000} {
+		select {
+		case msg := <-client.send:
+			var wsMsg WebSocketMessage
+			if err := json.Unmarshal(msg, &wsMsg); err != nil {
+				t.Fatalf("failed to unmarshal message: %v", err)
+			}
+			if wsMsg.Type != "timer_tick" {
+				t.Fatalf("expected timer_tick, got %q", wsMsg.Type)
+			}
+			var payload TimerTickPayload
+			if err := json.Unmarshal(wsMsg.Payload, &payload); err != nil {
+				t.Fatalf("failed to unmarshal payload: %v", err)
+			}
+			if payload.Timer != TimerNextHand {
+				t.Errorf("expected timer %q, got %q", TimerNextHand, payload.Timer)
+			}
+			if payload.RemainingMs != remaining {
+				t.Errorf("expected remainingMs %d, got %d", remaining, payload.RemainingMs)
+			}
+			if payload.Deadline-payload.ServerTime != remaining {
+				t.Errorf("expected deadline %d to be %dms after serverTime %d", payload.Deadline, remaining, payload.ServerTime)
+			}
+		default:
+			t.Fatal("expected timer_tick message")
+		}
+	}
+}
+
+// TestScheduleNextHand_CancelledByManualStart verifies StartHand cancels a pending countdown
+func TestScheduleNextHand_CancelledByManualStart(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{NextHandDelay: time.Second})
+	clock := useFakeClock(server)
+	table := server.tables[0]
+	seatTwoPlayers(table)
+
+	if !table.ScheduleNextHand() {
+		t.Fatal("expected countdown to be scheduled")
+	}
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("failed to start hand: %v", err)
+	}
+
+	table.mu.RLock()
+	pending := table.nextHandCancel != nil
+	hand := table.CurrentHand
+	table.mu.RUnlock()
+	if pending {
+		t.Error("expected manual start to cancel the countdown")
+	}
+
+	// The cancelled countdown must not replace the running hand
+	clock.Advance(2 * time.Second)
+	table.mu.RLock()
+	defer table.mu.RUnlock()
+	if table.CurrentHand != hand {
+		t.Error("expected manually started hand to keep running")
+	}
+}
+
+// TestHandleShowdown_SchedulesNextHand verifies finishing a hand queues the next one
+func TestHandleShowdown_SchedulesNextHand(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{NextHandDelay: time.Minute})
+	table := server.tables[0]
+	seatTwoPlayers(table)
+
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("failed to start hand: %v", err)
+	}
+
+	// Seat 1 folds so seat 0 wins without evaluation
+	table.mu.Lock()
+	table.CurrentHand.FoldedPlayers[1] = true
+	table.mu.Unlock()
+	table.HandleShowdown()
+
+	table.mu.Lock()
+	defer table.mu.Unlock()
+	if table.nextHandCancel == nil {
+		t.Fatal("expected next hand to be scheduled after showdown")
+	}
+	table.cancelNextHandLocked()
+}
+
+// actCurrent applies action for whoever is on the clock
+func actCurrent(t *testing.T, server *Server, table *Table, action string, amount ...int) {
+	t.Helper()
+	table.mu.RLock()
+	if table.CurrentHand == nil || table.CurrentHand.CurrentActor == nil {
+		table.mu.RUnlock()
+		t.Fatalf("no current actor for %s", action)
+	}
+	actor := *table.CurrentHand.CurrentActor
+	table.mu.RUnlock()
+	if err := server.processTableAction(context.Background(), table, nil, "", actor, action, amount...); err != nil {
+		t.Fatalf("%s failed: %v", action, err)
+	}
+}
+
+// waitForStreet polls until the hand reaches street or the timeout passes
+func waitForStreet(table *Table, street string, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		table.mu.RLock()
+		reached := table.CurrentHand != nil && table.CurrentHand.Street == street
+		table.mu.RUnlock()
+		if reached {
+			return true
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return false
+}
+
+// TestPacing_DelaysNextStreet verifies the flop waits for its delay with nobody on the clock
+func TestPacing_DelaysNextStreet(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{Pacing: PacingConfig{Flop: 100 * time.Millisecond}})
+	table := server.tables[0]
+	seatTwoPlayers(table)
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+
+	actCurrent(t, server, table, "call")
+	table.mu.RLock()
+	lastActor := *table.CurrentHand.CurrentActor
+	table.mu.RUnlock()
+	actCurrent(t, server, table, "check")
+
+	table.mu.RLock()
+	street := table.CurrentHand.Street
+	actor := table.CurrentHand.CurrentActor
+	table.mu.RUnlock()
+	if street != "preflop" || actor != nil {
+		t.Fatalf("expected a pause on preflop with no actor, got street %s actor %v", street, actor)
+	}
+	if err := server.processTableAction(context.Background(), table, nil, "", lastActor, "check"); err == nil {
+		t.Error("expected actions to be rejected while the flop is paced")
+	}
+
+	if !waitForStreet(table, "flop", 2*time.Second) {
+		t.Fatal("flop was never dealt")
+	}
+	table.mu.RLock()
+	defer table.mu.RUnlock()
+	if table.CurrentHand.CurrentActor == nil || len(table.CurrentHand.BoardCards) != 3 {
+		t.Errorf("expected flop with an actor, got board %v actor %v", table.CurrentHand.BoardCards, table.CurrentHand.CurrentActor)
+	}
+}
+
+// TestPacing_InstantIgnoresDelays verifies instant mode deals the next street immediately
+func TestPacing_InstantIgnoresDelays(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{Pacing: PacingConfig{Flop: time.Hour, Instant: true}})
+	table := server.tables[0]
+	seatTwoPlayers(table)
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+
+	actCurrent(t, server, table, "call")
+	actCurrent(t, server, table, "check")
+
+	table.mu.RLock()
+	defer table.mu.RUnlock()
+	if table.CurrentHand.Street != "flop" {
+		t.Errorf("expected the flop immediately, got %s", table.CurrentHand.Street)
+	}
+}
+
+// TestPacing_SpacesAllInRunout verifies an all-in runout deals streets one delay apart
+func TestPacing_SpacesAllInRunout(t *testing.T) {
+	delay := 60 * time.Millisecond
+	server := NewServerWithConfig(slog.Default(), Config{Pacing: PacingConfig{Flop: delay, Turn: delay, River: delay}})
+	table := server.tables[0]
+	seatTwoPlayers(table)
+
+	client := &Client{hub: server.hub, Token: "player1", send: make(chan []byte, 256)}
+	server.hub.mu.Lock()
+	server.hub.clients[client] = true
+	server.hub.mu.Unlock()
+
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	for len(client.send) > 0 {
+		<-client.send
+	}
+
+	start := time.Now()
+	actCurrent(t, server, table, "raise", 1000)
+	actCurrent(t, server, table, "call")
+
+	var boardTimes []time.Duration
+	timeout := time.After(2 * time.Second)
+	for len(boardTimes) < 3 {
+		select {
+		case msg := <-client.send:
+			var wsMsg WebSocketMessage
+			if err := json.Unmarshal(msg, &wsMsg); err != nil {
+				t.Fatalf("failed to unmarshal message: %v", err)
+			}
+			if wsMsg.Type == "board_dealt" {
+				boardTimes = append(boardTimes, time.Since(start))
+			}
+		case <-timeout:
+			t.Fatalf("runout incomplete, board_dealt after %v", boardTimes)
+		}
+	}
+
+	for i, at := range boardTimes {
+		if want := time.Duration(i+1) * delay; at < want {
+			t.Errorf("street %d dealt after %v, expected at least %v", i+1, at, want)
+		}
+	}
+}
+
+// TestScheduleNextHand_PausesForPlayers verifies a table left with one player stops its countdown
+// and waits for players, then resumes once a second player sits down
+func TestScheduleNextHand_PausesForPlayers(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{NextHandDelay: 3 * time.Second})
+	clock := useFakeClock(server)
+	table := server.tables[0]
+	clients := make([]*Client, 3)
+	for i, name := range []string{"Alice", "Bob", "Carol"} {
+		session, _ := server.sessionManager.CreateSession(name)
+		clients[i] = connectTestClient(server, session.Token)
+	}
+	for _, client := range clients[:2] {
+		if _, err := server.seatPlayer(client.Token, "", table); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !table.ScheduleNextHand() {
+		t.Fatal("expected a countdown with two players")
+	}
+	drainRawMessages(clients[0])
+
+	messageTypes := func(client *Client) map[string]bool {
+		types := make(map[string]bool)
+		for _, raw := range drainRawMessages(client) {
+			var msg WebSocketMessage
+			if json.Unmarshal([]byte(raw), &msg) == nil {
+				types[msg.Type] = true
+			}
+		}
+		return types
+	}
+
+	if err := table.ClearSeat(&clients[1].Token); err != nil {
+		t.Fatal(err)
+	}
+	table.mu.RLock()
+	paused, countdown := table.paused, table.nextHandCancel != nil
+	table.mu.RUnlock()
+	if !paused || countdown {
+		t.Fatalf("expected the table paused without a countdown, got paused %v, countdown %v", paused, countdown)
+	}
+	if types := messageTypes(clients[0]); !types["waiting_for_players"] {
+		t.Errorf("expected waiting_for_players, got %v", types)
+	}
+	if !server.buildTableState(table, nil).WaitingForPlayers {
+		t.Error("expected table_state to show the table waiting for players")
+	}
+	clock.Advance(5 * time.Second)
+	if table.Phase() != PhaseWaitingForPlayers {
+		t.Fatalf("expected no hand while paused, phase is %s", table.Phase())
+	}
+	if table.ScheduleNextHand() || len(messageTypes(clients[0])) != 0 {
+		t.Error("expected scheduling again while paused to do nothing")
+	}
+
+	if _, err := server.seatPlayer(clients[2].Token, "", table); err != nil {
+		t.Fatal(err)
+	}
+	if !table.ScheduleNextHand() {
+		t.Fatal("expected a countdown once a second player sat down")
+	}
+	if types := messageTypes(clients[0]); !types["table_resumed"] {
+		t.Errorf("expected table_resumed, got %v", types)
+	}
+	if server.buildTableState(table, nil).WaitingForPlayers {
+		t.Error("expected table_state to show the table no longer waiting")
+	}
+}
@@ -10,38 +10,25 @@ type HandRank struct {
 	Kickers []int // Card ranks in order of importance for tiebreaking
 }
 
-// rankToNumeric converts a card rank string to numeric value
-// A=14, K=13, Q=12, J=11, T=10, 9=9, ..., 2=2, and A=1 for wheel straight
-func rankToNumeric(rank string) int {
-	switch rank {
-	case "A":
-		return 14
-	case "K":
-		return 13
-	case "Q":
-		return 12
-	case "J":
-		return 11
-	case "T":
-		return 10
-	default:
-		// For "2" through "9"
-		return int(rank[0]) - int('0')
-	}
-}
-
-// EvaluateHand takes 2 hole cards and 5 board cards, returns the best 5-card hand
+// EvaluateHand takes 2 hole cards and the board so far (flop, turn or river), returns the best
+// 5-card hand
 func EvaluateHand(holeCards []Card, boardCards []Card) HandRank {
 	// Combine all cards
 	allCards := make([]Card, len(holeCards)+len(boardCards))
 	copy(allCards, holeCards)
 	copy(allCards[len(holeCards):], boardCards)
 
-	// Generate all possible 5-card combinations from 7 cards
+	return EvaluateCards(allCards)
+}
+
+// EvaluateCards returns the best 5-card hand among 5, 6 or 7 cards, so a hand can be rated on
+// the flop or turn without padding the board. Fewer than 5 cards make no hand: Rank is -1.
+func EvaluateCards(cards []Card) HandRank {
+	// Generate all possible 5-card combinations
 	var bestHand HandRank
 	bestHand.Rank = -1 // Initialize to invalid
 
-	combinations := generate5CardCombinations(allCards)
+	combinations := generate5CardCombinations(cards)
 	for _, combo := range combinations {
 		hand := evaluateFixed5Cards(combo)
 		if bestHand.Rank == -1 || compareHandRanks(hand, bestHand) > 0 {
@@ -52,18 +39,19 @@ func EvaluateHand(holeCards []Card, boardCards []Card) HandRank {
 	return bestHand
 }
 
-// generate5CardCombinations generates all possible 5-card combinations from 7 cards
+// generate5CardCombinations generates all possible 5-card combinations from the cards given
+// (one from 5 cards, 6 from 6, 21 from 7); none when there are fewer than 5
 func generate5CardCombinations(cards []Card) [][]Card {
 	var result [][]Card
 
-	// Use bit masking: iterate through all 7-bit numbers, selecting indices where bit is 1
-	for mask := 0; mask < 128; mask++ { // 2^7 = 128
+	// Use bit masking: iterate through all len(cards)-bit numbers, selecting indices where bit is 1
+	for mask := 0; mask < 1<<len(cards); mask++ {
 		if popcount(mask) != 5 {
 			continue
 		}
 
 		var combo []Card
-		for i := 0; i < 7; i++ {
+		for i := range cards {
 			if (mask & (1 << i)) != 0 {
 				combo = append(combo, cards[i])
 			}
@@ -141,14 +129,13 @@ func compareHandRanks(h1, h2 HandRank) int {
 	return 0
 }
 
-// groupByRank groups cards by their rank and returns map of rank -> count
-func groupByRank(cards []Card) map[int][]Card {
-	grouped := make(map[int][]Card)
+// rankCounts counts the cards of each numeric rank, indexed by rank
+func rankCounts(cards []Card) [15]int {
+	var counts [15]int
 	for _, card := range cards {
-		rank := rankToNumeric(card.Rank)
-		grouped[rank] = append(grouped[rank], card)
+		counts[card.Rank()]++
 	}
-	return grouped
+	return counts
 }
 
 // isFlush checks if all 5 cards have the same suit
@@ -156,9 +143,9 @@ func isFlush(cards []Card) bool {
 	if len(cards) != 5 {
 		return false
 	}
-	suit := cards[0].Suit
+	suit := cards[0].Suit()
 	for i := 1; i < len(cards); i++ {
-		if cards[i].Suit != suit {
+		if cards[i].Suit() != suit {
 			return false
 		}
 	}
@@ -174,7 +161,7 @@ func isStraight(cards []Card) (bool, int) {
 
 	ranks := make([]int, len(cards))
 	for i, card := range cards {
-		ranks[i] = rankToNumeric(card.Rank)
+		ranks[i] = card.Rank()
 	}
 	sort.Ints(ranks)
 
@@ -207,7 +194,7 @@ func checkRoyalFlush(cards []Card) HandRank {
 
 	ranks := make([]int, len(cards))
 	for i, card := range cards {
-		ranks[i] = rankToNumeric(card.Rank)
+		ranks[i] = card.Rank()
 	}
 	sort.Ints(ranks)
 
@@ -252,15 +239,15 @@ func checkStraightFlush(cards []Card) HandRank {
 
 // checkFourOfAKind checks for four of a kind
 func checkFourOfAKind(cards []Card) HandRank {
-	grouped := groupByRank(cards)
+	counts := rankCounts(cards)
 
 	var quadRank int
 	var kicker int
 
-	for rank, rankCards := range grouped {
-		if len(rankCards) == 4 {
+	for rank, count := range counts {
+		if count == 4 {
 			quadRank = rank
-		} else if len(rankCards) == 1 {
+		} else if count == 1 {
 			kicker = rank
 		}
 	}
@@ -277,15 +264,15 @@ func checkFourOfAKind(cards []Card) HandRank {
 
 // checkFullHouse checks for full house (3 of a kind + pair)
 func checkFullHouse(cards []Card) HandRank {
-	grouped := groupByRank(cards)
+	counts := rankCounts(cards)
 
 	var tripleRank int
 	var pairRank int
 
-	for rank, rankCards := range grouped {
-		if len(rankCards) == 3 {
+	for rank, count := range counts {
+		if count == 3 {
 			tripleRank = rank
-		} else if len(rankCards) == 2 {
+		} else if count == 2 {
 			pairRank = rank
 		}
 	}
@@ -309,7 +296,7 @@ func checkFlush(cards []Card) HandRank {
 	// Get all ranks and sort descending
 	ranks := make([]int, len(cards))
 	for i, card := range cards {
-		ranks[i] = rankToNumeric(card.Rank)
+		ranks[i] = card.Rank()
 	}
 	sort.Sort(sort.Reverse(sort.IntSlice(ranks)))
 
@@ -345,15 +332,15 @@ func checkStraight(cards []Card) HandRank {
 
 // checkThreeOfAKind checks for three of a kind
 func checkThreeOfAKind(cards []Card) HandRank {
-	grouped := groupByRank(cards)
+	counts := rankCounts(cards)
 
 	var tripleRank int
 	var kickers []int
 
-	for rank, rankCards := range grouped {
-		if len(rankCards) == 3 {
+	for rank, count := range counts {
+		if count == 3 {
 			tripleRank = rank
-		} else if len(rankCards) == 1 {
+		} else if count == 1 {
 			kickers = append(kickers, rank)
 		}
 	}
@@ -377,15 +364,15 @@ func checkThreeOfAKind(cards []Card) HandRank {
 
 // checkTwoPair checks for two pair
 func checkTwoPair(cards []Card) HandRank {
-	grouped := groupByRank(cards)
+	counts := rankCounts(cards)
 
 	var pairRanks []int
 	var kicker int
 
-	for rank, rankCards := range grouped {
-		if len(rankCards) == 2 {
+	for rank, count := range counts {
+		if count == 2 {
 			pairRanks = append(pairRanks, rank)
-		} else if len(rankCards) == 1 {
+		} else if count == 1 {
 			kicker = rank
 		}
 	}
@@ -407,15 +394,15 @@ func checkTwoPair(cards []Card) HandRank {
 
 // checkOnePair checks for one pair
 func checkOnePair(cards []Card) HandRank {
-	grouped := groupByRank(cards)
+	counts := rankCounts(cards)
 
 	var pairRank int
 	var kickers []int
 
-	for rank, rankCards := range grouped {
-		if len(rankCards) == 2 {
+	for rank, count := range counts {
+		if count == 2 {
 			pairRank = rank
-		} else if len(rankCards) == 1 {
+		} else if count == 1 {
 			kickers = append(kickers, rank)
 		}
 	}
@@ -441,7 +428,7 @@ func checkOnePair(cards []Card) HandRank {
 func checkHighCard(cards []Card) HandRank {
 	ranks := make([]int, len(cards))
 	for i, card := range cards {
-		ranks[i] = rankToNumeric(card.Rank)
+		ranks[i] = card.Rank()
 	}
 	sort.Sort(sort.Reverse(sort.IntSlice(ranks)))
 
@@ -7,15 +7,15 @@ import (
 func TestEvaluateHand_RoyalFlush(t *testing.T) {
 	// Royal Flush: A-K-Q-J-T all same suit
 	holeCards := []Card{
-		{Rank: "A", Suit: "s"},
-		{Rank: "K", Suit: "s"},
+		NewCard("A", "s"),
+		NewCard("K", "s"),
 	}
 	boardCards := []Card{
-		{Rank: "Q", Suit: "s"},
-		{Rank: "J", Suit: "s"},
-		{Rank: "T", Suit: "s"},
-		{Rank: "9", Suit: "h"},
-		{Rank: "8", Suit: "h"},
+		NewCard("Q", "s"),
+		NewCard("J", "s"),
+		NewCard("T", "s"),
+		NewCard("9", "h"),
+		NewCard("8", "h"),
 	}
 
 	result := EvaluateHand(holeCards, boardCards)
@@ -34,15 +34,15 @@ func TestEvaluateHand_RoyalFlush(t *testing.T) {
 func TestEvaluateHand_StraightFlush(t *testing.T) {
 	// Straight Flush: 9-8-7-6-5 all diamonds
 	holeCards := []Card{
-		{Rank: "9", Suit: "d"},
-		{Rank: "8", Suit: "d"},
+		NewCard("9", "d"),
+		NewCard("8", "d"),
 	}
 	boardCards := []Card{
-		{Rank: "7", Suit: "d"},
-		{Rank: "6", Suit: "d"},
-		{Rank: "5", Suit: "d"},
-		{Rank: "K", Suit: "h"},
-		{Rank: "Q", Suit: "h"},
+		NewCard("7", "d"),
+		NewCard("6", "d"),
+		NewCard("5", "d"),
+		NewCard("K", "h"),
+		NewCard("Q", "h"),
 	}
 
 	result := EvaluateHand(holeCards, boardCards)
@@ -61,15 +61,15 @@ func TestEvaluateHand_StraightFlush(t *testing.T) {
 func TestEvaluateHand_FourOfAKind(t *testing.T) {
 	// Four of a Kind: 4 Kings
 	holeCards := []Card{
-		{Rank: "K", Suit: "s"},
-		{Rank: "K", Suit: "h"},
+		NewCard("K", "s"),
+		NewCard("K", "h"),
 	}
 	boardCards := []Card{
-		{Rank: "K", Suit: "d"},
-		{Rank: "K", Suit: "c"},
-		{Rank: "Q", Suit: "s"},
-		{Rank: "2", Suit: "h"},
-		{Rank: "3", Suit: "h"},
+		NewCard("K", "d"),
+		NewCard("K", "c"),
+		NewCard("Q", "s"),
+		NewCard("2", "h"),
+		NewCard("3", "h"),
 	}
 
 	result := EvaluateHand(holeCards, boardCards)
@@ -88,15 +88,15 @@ func TestEvaluateHand_FourOfAKind(t *testing.T) {
 func TestEvaluateHand_FullHouse(t *testing.T) {
 	// Full House: 3 Aces and 2 Kings
 	holeCards := []Card{
-		{Rank: "A", Suit: "s"},
-		{Rank: "A", Suit: "h"},
+		NewCard("A", "s"),
+		NewCard("A", "h"),
 	}
 	boardCards := []Card{
-		{Rank: "A", Suit: "d"},
-		{Rank: "K", Suit: "c"},
-		{Rank: "K", Suit: "s"},
-		{Rank: "2", Suit: "h"},
-		{Rank: "3", Suit: "h"},
+		NewCard("A", "d"),
+		NewCard("K", "c"),
+		NewCard("K", "s"),
+		NewCard("2", "h"),
+		NewCard("3", "h"),
 	}
 
 	result := EvaluateHand(holeCards, boardCards)
@@ -115,15 +115,15 @@ func TestEvaluateHand_FullHouse(t *testing.T) {
 func TestEvaluateHand_Flush(t *testing.T) {
 	// Flush: 5 spades (A-K-Q-J-9)
 	holeCards := []Card{
-		{Rank: "A", Suit: "s"},
-		{Rank: "K", Suit: "s"},
+		NewCard("A", "s"),
+		NewCard("K", "s"),
 	}
 	boardCards := []Card{
-		{Rank: "Q", Suit: "s"},
-		{Rank: "J", Suit: "s"},
-		{Rank: "9", Suit: "s"},
-		{Rank: "2", Suit: "h"},
-		{Rank: "3", Suit: "h"},
+		NewCard("Q", "s"),
+		NewCard("J", "s"),
+		NewCard("9", "s"),
+		NewCard("2", "h"),
+		NewCard("3", "h"),
 	}
 
 	result := EvaluateHand(holeCards, boardCards)
@@ -142,15 +142,15 @@ func TestEvaluateHand_Flush(t *testing.T) {
 func TestEvaluateHand_Straight(t *testing.T) {
 	// Straight: A-K-Q-J-T (high straight)
 	holeCards := []Card{
-		{Rank: "A", Suit: "s"},
-		{Rank: "K", Suit: "h"},
+		NewCard("A", "s"),
+		NewCard("K", "h"),
 	}
 	boardCards := []Card{
-		{Rank: "Q", Suit: "d"},
-		{Rank: "J", Suit: "c"},
-		{Rank: "T", Suit: "s"},
-		{Rank: "2", Suit: "h"},
-		{Rank: "3", Suit: "h"},
+		NewCard("Q", "d"),
+		NewCard("J", "c"),
+		NewCard("T", "s"),
+		NewCard("2", "h"),
+		NewCard("3", "h"),
 	}
 
 	result := EvaluateHand(holeCards, boardCards)
@@ -169,15 +169,15 @@ func TestEvaluateHand_Straight(t *testing.T) {
 func TestEvaluateHand_ThreeOfAKind(t *testing.T) {
 	// Three of a Kind: 3 Jacks
 	holeCards := []Card{
-		{Rank: "J", Suit: "s"},
-		{Rank: "J", Suit: "h"},
+		NewCard("J", "s"),
+		NewCard("J", "h"),
 	}
 	boardCards := []Card{
-		{Rank: "J", Suit: "d"},
-		{Rank: "K", Suit: "c"},
-		{Rank: "Q", Suit: "s"},
-		{Rank: "2", Suit: "h"},
-		{Rank: "3", Suit: "h"},
+		NewCard("J", "d"),
+		NewCard("K", "c"),
+		NewCard("Q", "s"),
+		NewCard("2", "h"),
+		NewCard("3", "h"),
 	}
 
 	result := EvaluateHand(holeCards, boardCards)
@@ -196,15 +196,15 @@ func TestEvaluateHand_ThreeOfAKind(t *testing.T) {
 func TestEvaluateHand_TwoPair(t *testing.T) {
 	// Two Pair: Kings and Nines
 	holeCards := []Card{
-		{Rank: "K", Suit: "s"},
-		{Rank: "K", Suit: "h"},
+		NewCard("K", "s"),
+		NewCard("K", "h"),
 	}
 	boardCards := []Card{
-		{Rank: "9", Suit: "d"},
-		{Rank: "9", Suit: "c"},
-		{Rank: "Q", Suit: "s"},
-		{Rank: "2", Suit: "h"},
-		{Rank: "3", Suit: "h"},
+		NewCard("9", "d"),
+		NewCard("9", "c"),
+		NewCard("Q", "s"),
+		NewCard("2", "h"),
+		NewCard("3", "h"),
 	}
 
 	result := EvaluateHand(holeCards, boardCards)
@@ -223,15 +223,15 @@ func TestEvaluateHand_TwoPair(t *testing.T) {
 func TestEvaluateHand_OnePair(t *testing.T) {
 	// One Pair: Pair of Tens
 	holeCards := []Card{
-		{Rank: "T", Suit: "s"},
-		{Rank: "T", Suit: "h"},
+		NewCard("T", "s"),
+		NewCard("T", "h"),
 	}
 	boardCards := []Card{
-		{Rank: "K", Suit: "d"},
-		{Rank: "Q", Suit: "c"},
-		{Rank: "J", Suit: "s"},
-		{Rank: "2", Suit: "h"},
-		{Rank: "3", Suit: "h"},
+		NewCard("K", "d"),
+		NewCard("Q", "c"),
+		NewCard("J", "s"),
+		NewCard("2", "h"),
+		NewCard("3", "h"),
 	}
 
 	result := EvaluateHand(holeCards, boardCards)
@@ -250,15 +250,15 @@ func TestEvaluateHand_OnePair(t *testing.T) {
 func TestEvaluateHand_HighCard(t *testing.T) {
 	// High Card: A-K-Q-J-9 (no other hand)
 	holeCards := []Card{
-		{Rank: "A", Suit: "s"},
-		{Rank: "K", Suit: "h"},
+		NewCard("A", "s"),
+		NewCard("K", "h"),
 	}
 	boardCards := []Card{
-		{Rank: "Q", Suit: "d"},
-		{Rank: "J", Suit: "c"},
-		{Rank: "9", Suit: "s"},
-		{Rank: "2", Suit: "h"},
-		{Rank: "3", Suit: "h"},
+		NewCard("Q", "d"),
+		NewCard("J", "c"),
+		NewCard("9", "s"),
+		NewCard("2", "h"),
+		NewCard("3", "h"),
 	}
 
 	result := EvaluateHand(holeCards, boardCards)
@@ -325,15 +325,15 @@ func TestCompareHands_Tie(t *testing.T) {
 func TestEvaluateHand_WheelStraight(t *testing.T) {
 	// Wheel Straight (A-2-3-4-5): Ace acts as low card
 	holeCards := []Card{
-		{Rank: "A", Suit: "s"},
-		{Rank: "2", Suit: "h"},
+		NewCard("A", "s"),
+		NewCard("2", "h"),
 	}
 	boardCards := []Card{
-		{Rank: "3", Suit: "d"},
-		{Rank: "4", Suit: "c"},
-		{Rank: "5", Suit: "s"},
-		{Rank: "K", Suit: "h"},
-		{Rank: "Q", Suit: "h"},
+		NewCard("3", "d"),
+		NewCard("4", "c"),
+		NewCard("5", "s"),
+		NewCard("K", "h"),
+		NewCard("Q", "h"),
 	}
 
 	result := EvaluateHand(holeCards, boardCards)
@@ -354,15 +354,15 @@ func TestEvaluateHand_AllSevenCards(t *testing.T) {
 	// 2 hole + 5 board = 7 cards
 	// Board has royal flush potential (A-K-Q-J-T all hearts)
 	holeCards := []Card{
-		{Rank: "A", Suit: "h"},
-		{Rank: "K", Suit: "h"},
+		NewCard("A", "h"),
+		NewCard("K", "h"),
 	}
 	boardCards := []Card{
-		{Rank: "Q", Suit: "h"},
-		{Rank: "J", Suit: "h"},
-		{Rank: "T", Suit: "h"},
-		{Rank: "2", Suit: "c"},
-		{Rank: "3", Suit: "d"},
+		NewCard("Q", "h"),
+		NewCard("J", "h"),
+		NewCard("T", "h"),
+		NewCard("2", "c"),
+		NewCard("3", "d"),
 	}
 
 	result := EvaluateHand(holeCards, boardCards)
@@ -385,23 +385,23 @@ func TestEvaluateHand_TwoPairBug_JJvsA5(t *testing.T) {
 
 	// Player 1: JJ
 	holeCards1 := []Card{
-		{Rank: "J", Suit: "s"},
-		{Rank: "J", Suit: "c"},
+		NewCard("J", "s"),
+		NewCard("J", "c"),
 	}
 
 	// Player 2: A5
 	holeCards2 := []Card{
-		{Rank: "A", Suit: "s"},
-		{Rank: "5", Suit: "c"},
+		NewCard("A", "s"),
+		NewCard("5", "c"),
 	}
 
 	// Board: 66422
 	boardCards := []Card{
-		{Rank: "6", Suit: "s"},
-		{Rank: "6", Suit: "c"},
-		{Rank: "4", Suit: "s"},
-		{Rank: "2", Suit: "s"},
-		{Rank: "2", Suit: "c"},
+		NewCard("6", "s"),
+		NewCard("6", "c"),
+		NewCard("4", "s"),
+		NewCard("2", "s"),
+		NewCard("2", "c"),
 	}
 
 	hand1 := EvaluateHand(holeCards1, boardCards)
@@ -447,11 +447,11 @@ func TestEvaluateHand_TwoPairShowdownSimulation(t *testing.T) {
 
 	// Setup board: 66422
 	boardCards := []Card{
-		{Rank: "6", Suit: "s"},
-		{Rank: "6", Suit: "c"},
-		{Rank: "4", Suit: "s"},
-		{Rank: "2", Suit: "s"},
-		{Rank: "2", Suit: "c"},
+		NewCard("6", "s"),
+		NewCard("6", "c"),
+		NewCard("4", "s"),
+		NewCard("2", "s"),
+		NewCard("2", "c"),
 	}
 
 	// Player hands
@@ -462,15 +462,15 @@ func TestEvaluateHand_TwoPairShowdownSimulation(t *testing.T) {
 		{
 			name: "Player 1 (JJ)",
 			holeCards: []Card{
-				{Rank: "J", Suit: "s"},
-				{Rank: "J", Suit: "c"},
+				NewCard("J", "s"),
+				NewCard("J", "c"),
 			},
 		},
 		{
 			name: "Player 2 (A5)",
 			holeCards: []Card{
-				{Rank: "A", Suit: "s"},
-				{Rank: "5", Suit: "c"},
+				NewCard("A", "s"),
+				NewCard("5", "c"),
 			},
 		},
 	}
@@ -524,3 +524,41 @@ func sliceEqual(a, b []int) bool {
 	}
 	return true
 }
+
+func TestEvaluateHand_IncompleteBoards(t *testing.T) {
+	holeCards := []Card{NewCard("9", "h"), NewCard("8", "h")}
+
+	// Flop: 5 cards in all make a straight
+	flop := []Card{NewCard("7", "h"), NewCard("6", "c"), NewCard("5", "d")}
+	if result := EvaluateHand(holeCards, flop); result.Rank != 4 || !sliceEqual(result.Kickers, []int{9, 8, 7, 6, 5}) {
+		t.Errorf("expected a nine-high straight on the flop, got %+v", result)
+	}
+
+	// Turn: the best 5 of 6 cards is a flush
+	turn := []Card{NewCard("7", "h"), NewCard("K", "h"), NewCard("5", "d"), NewCard("2", "h")}
+	if result := EvaluateHand(holeCards, turn); result.Rank != 5 || result.Kickers[0] != 13 {
+		t.Errorf("expected a king-high flush on the turn, got %+v", result)
+	}
+
+	// Preflop there is no 5-card hand to make
+	if result := EvaluateHand(holeCards, nil); result.Rank != -1 {
+		t.Errorf("expected no hand from 2 cards, got %+v", result)
+	}
+}
+
+func TestEvaluateCards_MatchesSevenCardEvaluation(t *testing.T) {
+	cards := []Card{
+		NewCard("A", "s"), NewCard("A", "d"), NewCard("K", "c"),
+		NewCard("K", "h"), NewCard("Q", "s"), NewCard("2", "c"), NewCard("3", "d"),
+	}
+
+	for n := 5; n <= 7; n++ {
+		result := EvaluateCards(cards[:n])
+		if result.Rank != 2 || !sliceEqual(result.Kickers, []int{14, 13, 12}) {
+			t.Errorf("%d cards: expected aces and kings with a queen, got %+v", n, result)
+		}
+	}
+	if got := EvaluateCards(cards[:4]); got.Rank != -1 {
+		t.Errorf("expected no hand from 4 cards, got %+v", got)
+	}
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+)
+
+// HandLogWinner is one winner in a hand's log record
+type HandLogWinner struct {
+	Seat   int    `json:"seat"`
+	Player string `json:"player"`
+	Amount int    `json:"amount"` // Chips won, after rake
+}
+
+// loggedHand is what the HandLogger knows of a hand in progress
+type loggedHand struct {
+	id      string
+	started time.Time
+	names   map[int]string // Player name per seat dealt in
+	street  string         // Furthest street reached
+}
+
+// HandLogger writes one structured info record per finished hand and, at debug level, one per
+// action, from table events, so hands can be analysed from the logs without the history store
+type HandLogger struct {
+	logger     *slog.Logger
+	playerName func(token string) string
+	hands      map[string]*loggedHand // By table ID
+}
+
+// NewHandLogger creates a HandLogger writing to logger; playerName names the players dealt in
+func NewHandLogger(logger *slog.Logger, playerName func(token string) string) *HandLogger {
+	return &HandLogger{
+		logger:     logger,
+		playerName: playerName,
+		hands:      make(map[string]*loggedHand),
+	}
+}
+
+// Run handles events until the channel is closed
+func (hl *HandLogger) Run(events <-chan Event) {
+	for e := range events {
+		hl.handle(e)
+	}
+}
+
+// handle follows the hands in progress and logs the actions and finished hands
+func (hl *HandLogger) handle(e Event) {
+	switch e.Type {
+	case EventHandStarted:
+		hand := &loggedHand{id: e.HandID, started: e.Time, names: make(map[int]string, len(e.Seats)), street: "preflop"}
+		for seat, token := range e.Seats {
+			if hl.playerName != nil {
+				hand.names[seat] = hl.playerName(token)
+			}
+		}
+		hl.hands[e.TableID] = hand
+	case EventBoardDealt:
+		if hand, ok := hl.hands[e.TableID]; ok {
+			hand.street = e.Street
+		}
+	case EventPlayerAction:
+		hand, ok := hl.hands[e.TableID]
+		if !ok || !hl.logger.Enabled(context.Background(), slog.LevelDebug) {
+			return
+		}
+		hl.logger.Debug("hand action",
+			"handID", hand.id,
+			"tableID", e.TableID,
+			"seat", e.SeatIndex,
+			"player", hand.names[e.SeatIndex],
+			"street", e.Street,
+			"action", e.Action,
+			"amount", e.Amount,
+			"pot", e.Pot,
+			"timeout", e.Timeout,
+			"responseMs", e.ResponseTime.Milliseconds(),
+		)
+	case EventHandEnded:
+		hand, ok := hl.hands[e.TableID]
+		if !ok {
+			return
+		}
+		delete(hl.hands, e.TableID)
+
+		var winners []HandLogWinner
+		paid := 0
+		for _, seat := range slices.Sorted(maps.Keys(e.Winnings)) {
+			if amount := e.Winnings[seat]; amount > 0 {
+				winners = append(winners, HandLogWinner{Seat: seat, Player: hand.names[seat], Amount: amount})
+				paid += amount
+			}
+		}
+		winningHand := ""
+		if e.WinningRank != nil && e.WinningRank.Rank >= 0 && e.WinningRank.Rank < len(handRankIDs) {
+			winningHand = handRankIDs[e.WinningRank.Rank]
+		}
+		hl.logger.Info("hand completed",
+			"handID", hand.id,
+			"tableID", e.TableID,
+			"durationMs", e.Time.Sub(hand.started).Milliseconds(),
+			"street", hand.street,
+			"showdown", e.WinningRank != nil,
+			"players", len(hand.names),
+			"pot", e.Pot,
+			"rake", e.Pot-paid,
+			"winners", winners,
+			"winningHand", winningHand,
+		)
+	case EventHandCancelled:
+		delete(hl.hands, e.TableID)
+	}
+}
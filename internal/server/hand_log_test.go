@@ -0,0 +1,76 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestHandLogger_OneRecordPerHand verifies a finished hand is logged once with its outcome,
+// and actions only at debug level
+func TestHandLogger_OneRecordPerHand(t *testing.T) {
+	for _, level := range []slog.Level{slog.LevelInfo, slog.LevelDebug} {
+		var out bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: level}))
+		names := map[string]string{"alice": "Alice", "bob": "Bob"}
+		hl := NewHandLogger(logger, func(token string) string { return names[token] })
+
+		start := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+		seats := map[int]string{0: "alice", 1: "bob"}
+		hl.handle(Event{Type: EventHandStarted, TableID: "table-1", HandID: "h1", Time: start, Seats: seats})
+		hl.handle(Event{Type: EventPlayerAction, TableID: "table-1", SeatIndex: 0, Street: "preflop", Action: "raise", Amount: 60, Pot: 90})
+		hl.handle(Event{Type: EventBoardDealt, TableID: "table-1", Street: "flop"})
+		hl.handle(Event{Type: EventBoardDealt, TableID: "table-1", Street: "turn"})
+		hl.handle(Event{
+			Type: EventHandEnded, TableID: "table-1", HandID: "h1", Time: start.Add(42 * time.Second),
+			Pot: 200, Winnings: map[int]int{0: 190}, WinningRank: &HandRank{Rank: 2, Kickers: []int{14, 13, 2}},
+		})
+		// A cancelled hand leaves no record
+		hl.handle(Event{Type: EventHandStarted, TableID: "table-1", HandID: "h2", Time: start, Seats: seats})
+		hl.handle(Event{Type: EventHandCancelled, TableID: "table-1"})
+		hl.handle(Event{Type: EventHandEnded, TableID: "table-1", HandID: "h2", Time: start, Pot: 30, Winnings: map[int]int{1: 30}})
+
+		var hands, actions []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+			var record map[string]any
+			if err := json.Unmarshal([]byte(line), &record); err != nil {
+				t.Fatalf("%s: malformed record %q", level, line)
+			}
+			switch record["msg"] {
+			case "hand completed":
+				hands = append(hands, record)
+			case "hand action":
+				actions = append(actions, record)
+			}
+		}
+
+		if len(hands) != 1 {
+			t.Fatalf("%s: expected one hand record, got %v", level, hands)
+		}
+		hand := hands[0]
+		for key, want := range map[string]any{
+			"handID": "h1", "tableID": "table-1", "durationMs": 42000.0, "street": "turn", "showdown": true,
+			"players": 2.0, "pot": 200.0, "rake": 10.0, "winningHand": "two_pair",
+		} {
+			if hand[key] != want {
+				t.Errorf("%s: expected %s %v, got %v", level, key, want, hand[key])
+			}
+		}
+		winners, _ := hand["winners"].([]any)
+		if len(winners) != 1 {
+			t.Errorf("%s: expected one winner, got %v", level, hand["winners"])
+		} else if winner, _ := winners[0].(map[string]any); winner["player"] != "Alice" || winner["amount"] != 190.0 {
+			t.Errorf("%s: expected Alice the only winner, got %v", level, hand["winners"])
+		}
+
+		if want := map[slog.Level]int{slog.LevelInfo: 0, slog.LevelDebug: 1}[level]; len(actions) != want {
+			t.Errorf("%s: expected %d action records, got %v", level, want, actions)
+		}
+		if level == slog.LevelDebug && len(actions) == 1 && (actions[0]["player"] != "Alice" || actions[0]["action"] != "raise" || actions[0]["handID"] != "h1") {
+			t.Errorf("expected Alice's raise logged, got %v", actions[0])
+		}
+	}
+}
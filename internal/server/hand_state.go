@@ -0,0 +1,246 @@
+package server
+
+import (
+	"fmt"
+)
+
+// HandPhase represents a stage in the lifecycle of a hand at a table
+type HandPhase string
+
+const (
+	PhaseWaitingForPlayers HandPhase = "waiting_for_players" // No hand running
+	PhasePreflop           HandPhase = "preflop"             // Hole cards dealt, preflop betting
+	PhaseFlop              HandPhase = "flop"                // Flop dealt, flop betting
+	PhaseTurn              HandPhase = "turn"                // Turn dealt, turn betting
+	PhaseRiver             HandPhase = "river"               // River dealt, river betting
+	PhaseShowdown          HandPhase = "showdown"            // Betting closed, winners being determined
+	PhasePayout            HandPhase = "payout"              // Pot being distributed, bust-outs and dealer rotation
+)
+
+// handPhaseTransitions lists the legal next phases for each phase
+// Any betting phase may jump straight to showdown when all but one player fold
+// or when the remaining streets are run out
+var handPhaseTransitions = map[HandPhase][]HandPhase{
+	PhaseWaitingForPlayers: {PhasePreflop},
+	PhasePreflop:           {PhaseFlop, PhaseShowdown},
+	PhaseFlop:              {PhaseTurn, PhaseShowdown},
+	PhaseTurn:              {PhaseRiver, PhaseShowdown},
+	PhaseRiver:             {PhaseShowdown},
+	PhaseShowdown:          {PhasePayout},
+	PhasePayout:            {PhaseWaitingForPlayers},
+}
+
+// CanTransitionTo reports whether moving from p to next is a legal transition
+func (p HandPhase) CanTransitionTo(next HandPhase) bool {
+	for _, allowed := range handPhaseTransitions[p] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// streetPhase maps a hand street name to its betting phase
+func streetPhase(street string) HandPhase {
+	switch street {
+	case "flop":
+		return PhaseFlop
+	case "turn":
+		return PhaseTurn
+	case "river":
+		return PhaseRiver
+	default:
+		return PhasePreflop
+	}
+}
+
+// Phase returns the current phase of the hand lifecycle (thread-safe)
+func (t *Table) Phase() HandPhase {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.phaseLocked()
+}
+
+// phaseLocked returns the current phase (internal, must be called with lock held)
+// Betting phases are derived from CurrentHand.Street so the hand remains the single
+// source of truth for street state; showdown and payout are tracked on the table
+func (t *Table) phaseLocked() HandPhase {
+	if t.phase == PhaseShowdown || t.phase == PhasePayout {
+		return t.phase
+	}
+	if t.CurrentHand == nil {
+		return PhaseWaitingForPlayers
+	}
+	return streetPhase(t.CurrentHand.Street)
+}
+
+// transitionLocked moves the table to the next phase (internal, must be called with lock held)
+// Returns an error and leaves the phase unchanged if the transition is illegal
+func (t *Table) transitionLocked(next HandPhase) error {
+	current := t.phaseLocked()
+	if !current.CanTransitionTo(next) {
+		return fmt.Errorf("illegal hand phase transition: %s -> %s", current, next)
+	}
+
+	t.phase = next
+	if t.Server != nil {
+		t.Server.logger.Debug("hand phase transition", "tableID", t.ID, "from", current, "to", next)
+	}
+	return nil
+}
+
+// ProgressHand drives the hand forward once a betting round has closed
+// This is the single place that decides what happens after betting:
+// - One player left (all others folded): showdown for the early winner
+// - At least one player all-in: reveal the live hands if nobody can bet, run out the board, showdown
+// - River betting complete: showdown
+// - Otherwise: deal the next street and request action from its first actor
+// Streets are dealt after the configured pacing delay (see paceStreet)
+// Must be called without the table lock held
+func (t *Table) ProgressHand() {
+	t.mu.RLock()
+	hand := t.CurrentHand
+	if hand == nil {
+		t.mu.RUnlock()
+		return
+	}
+
+	// Check if only one player remains (all others folded) - early winner
+	nonFoldedCount := 0
+	for i := 0; i < 6; i++ {
+		if t.Seats[i].Status == "active" && !hand.FoldedPlayers[i] {
+			nonFoldedCount++
+		}
+	}
+	allPlayersAllIn := hand.AreAllActivePlayersAllIn(t.Seats)
+	bettingClosed := hand.playersWhoCanAct(t.Seats) <= 1
+	currentStreet := hand.Street
+	t.mu.RUnlock()
+
+	// If only one player remains, award pot immediately (early winner)
+	if nonFoldedCount <= 1 {
+		t.HandleShowdown()
+		return
+	}
+
+	if allPlayersAllIn {
+		// All remaining players are all-in - turn the live hands face up, then auto-deal
+		// the remaining streets and go to showdown. The hands stay down while two or more
+		// players still have chips, as they could still bet against each other.
+		t.logInfo("all players all-in, auto-dealing remaining streets", "currentStreet", currentStreet)
+		if currentStreet != "river" && bettingClosed {
+			if err := t.revealLiveHoleCards("all_in"); err != nil {
+				t.logWarn("failed to reveal hands for all-in runout", "error", err)
+			}
+		}
+		t.runOutBoard()
+		return
+	}
+
+	// We're on the river and betting is complete - trigger showdown
+	if currentStreet == "river" {
+		t.HandleShowdown()
+		return
+	}
+
+	// Normal street advancement with action prompts, after the pacing delay
+	t.paceStreet(nextStreet(currentStreet), func() {
+		err := t.AdvanceToNextStreetWithBroadcast()
+		if err != nil {
+			t.logWarn("failed to advance to next street", "error", err)
+			t.cancelHandOnError(fmt.Errorf("failed to advance to next street: %w", err))
+			return
+		}
+
+		t.requestFirstAction()
+	})
+}
+
+// runOutBoard deals every remaining street without prompting for action, each after its
+// pacing delay, then goes to showdown
+// Must be called without the table lock held
+func (t *Table) runOutBoard() {
+	t.mu.RLock()
+	hand := t.CurrentHand
+	if hand == nil {
+		t.mu.RUnlock()
+		return
+	}
+	currentStreet := hand.Street
+	t.mu.RUnlock()
+
+	if currentStreet == "river" {
+		t.HandleShowdown()
+		return
+	}
+
+	t.paceStreet(nextStreet(currentStreet), func() {
+		t.logInfo("auto-advancing from street", "street", currentStreet)
+		err := t.AdvanceToNextStreetWithBroadcast()
+		if err != nil {
+			t.logWarn("failed to auto-advance street (all-in)", "error", err)
+			t.cancelHandOnError(fmt.Errorf("failed to auto-advance street: %w", err))
+			return
+		}
+		t.runOutBoard()
+	})
+}
+
+// nextStreet returns the street dealt after street ("" after the river)
+func nextStreet(street string) string {
+	switch street {
+	case "preflop":
+		return "flop"
+	case "flop":
+		return "turn"
+	case "turn":
+		return "river"
+	default:
+		return ""
+	}
+}
+
+// requestFirstAction sets the first actor for the current street and broadcasts their action_request
+// Must be called without the table lock held
+func (t *Table) requestFirstAction() {
+	t.mu.Lock()
+	hand := t.CurrentHand
+	if hand == nil {
+		t.mu.Unlock()
+		return
+	}
+
+	firstActor := hand.GetFirstActor(t.Seats)
+	hand.CurrentActor = &firstActor
+
+	// Get valid actions and call amount for the first actor of the new street
+	validActions := hand.GetValidActions(firstActor, t.Seats[firstActor].Stack, t.Seats)
+	callAmount := hand.GetCallAmount(firstActor)
+	currentBet := hand.CurrentBet
+	pot := hand.Pot
+	t.mu.Unlock()
+
+	if t.Server == nil {
+		return
+	}
+
+	err := t.Server.BroadcastActionRequest(t.ID, firstActor, validActions, callAmount, currentBet, pot)
+	if err != nil {
+		t.logWarn("failed to broadcast action_request for new street", "error", err)
+	}
+}
+
+// logInfo logs at info level through the server logger when one is attached
+func (t *Table) logInfo(msg string, args ...any) {
+	if t.Server != nil {
+		t.Server.logger.Info(msg, append([]any{"tableID", t.ID}, args...)...)
+	}
+}
+
+// logWarn logs at warn level through the server logger when one is attached
+func (t *Table) logWarn(msg string, args ...any) {
+	if t.Server != nil {
+		t.Server.logger.Warn(msg, append([]any{"tableID", t.ID}, args...)...)
+	}
+}
@@ -0,0 +1,174 @@
+package server
+
+import (
+	"log/slog"
+	"testing"
+)
+
+// TestHandPhase_CanTransitionTo verifies the legal and illegal phase transitions
+func TestHandPhase_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from  HandPhase
+		to    HandPhase
+		legal bool
+	}{
+		{PhaseWaitingForPlayers, PhasePreflop, true},
+		{PhasePreflop, PhaseFlop, true},
+		{PhaseFlop, PhaseTurn, true},
+		{PhaseTurn, PhaseRiver, true},
+		{PhaseRiver, PhaseShowdown, true},
+		{PhasePreflop, PhaseShowdown, true},
+		{PhaseShowdown, PhasePayout, true},
+		{PhasePayout, PhaseWaitingForPlayers, true},
+		{PhaseWaitingForPlayers, PhaseFlop, false},
+		{PhaseWaitingForPlayers, PhaseShowdown, false},
+		{PhasePreflop, PhaseTurn, false},
+		{PhaseRiver, PhaseFlop, false},
+		{PhaseShowdown, PhaseWaitingForPlayers, false},
+		{PhasePayout, PhasePreflop, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.from.CanTransitionTo(tt.to); got != tt.legal {
+			t.Errorf("%s -> %s: expected legal=%v, got %v", tt.from, tt.to, tt.legal, got)
+		}
+	}
+}
+
+// TestTable_PhaseFollowsHandLifecycle verifies the phase through start, streets and showdown
+func TestTable_PhaseFollowsHandLifecycle(t *testing.T) {
+	table := NewTable("table-1", "Table 1", nil)
+	token1 := "player1"
+	token2 := "player2"
+	table.Seats[0] = Seat{Index: 0, Token: &token1, Status: "active", Stack: 1000}
+	table.Seats[1] = Seat{Index: 1, Token: &token2, Status: "active", Stack: 1000}
+
+	if phase := table.Phase(); phase != PhaseWaitingForPlayers {
+		t.Fatalf("expected %s before first hand, got %s", PhaseWaitingForPlayers, phase)
+	}
+
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("failed to start hand: %v", err)
+	}
+	if phase := table.Phase(); phase != PhasePreflop {
+		t.Fatalf("expected %s after StartHand, got %s", PhasePreflop, phase)
+	}
+
+	for _, expected := range []HandPhase{PhaseFlop, PhaseTurn, PhaseRiver} {
+		if err := table.AdvanceToNextStreetWithBroadcast(); err != nil {
+			t.Fatalf("failed to advance street: %v", err)
+		}
+		if phase := table.Phase(); phase != expected {
+			t.Fatalf("expected %s, got %s", expected, phase)
+		}
+	}
+
+	table.HandleShowdown()
+	if phase := table.Phase(); phase != PhaseWaitingForPlayers {
+		t.Errorf("expected %s after showdown, got %s", PhaseWaitingForPlayers, phase)
+	}
+}
+
+// TestTable_TransitionLockedRejectsIllegalTransition verifies illegal transitions leave the phase unchanged
+func TestTable_TransitionLockedRejectsIllegalTransition(t *testing.T) {
+	table := NewTable("table-1", "Table 1", nil)
+
+	table.mu.Lock()
+	err := table.transitionLocked(PhaseShowdown)
+	phase := table.phaseLocked()
+	table.mu.Unlock()
+
+	if err == nil {
+		t.Error("expected error for waiting_for_players -> showdown")
+	}
+	if phase != PhaseWaitingForPlayers {
+		t.Errorf("expected phase to remain %s, got %s", PhaseWaitingForPlayers, phase)
+	}
+}
+
+// TestTable_HandleShowdownWithoutHandIsNoop verifies showdown cannot run when no hand exists
+func TestTable_HandleShowdownWithoutHandIsNoop(t *testing.T) {
+	table := NewTable("table-1", "Table 1", nil)
+	token1 := "player1"
+	table.Seats[0] = Seat{Index: 0, Token: &token1, Status: "active", Stack: 1000}
+
+	table.HandleShowdown()
+
+	if table.DealerSeat != nil {
+		t.Error("expected dealer not to rotate when no hand is running")
+	}
+	if phase := table.Phase(); phase != PhaseWaitingForPlayers {
+		t.Errorf("expected %s, got %s", PhaseWaitingForPlayers, phase)
+	}
+}
+
+// TestTable_ProgressHandAdvancesStreet verifies a closed preflop round deals the flop and sets the first actor
+func TestTable_ProgressHandAdvancesStreet(t *testing.T) {
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+	token1 := "player1"
+	token2 := "player2"
+	table.Seats[0] = Seat{Index: 0, Token: &token1, Status: "active", Stack: 1000}
+	table.Seats[1] = Seat{Index: 1, Token: &token2, Status: "active", Stack: 1000}
+
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("failed to start hand: %v", err)
+	}
+
+	table.ProgressHand()
+
+	table.mu.RLock()
+	defer table.mu.RUnlock()
+	if table.CurrentHand == nil {
+		t.Fatal("expected hand to still be running")
+	}
+	if table.CurrentHand.Street != "flop" {
+		t.Errorf("expected street flop, got %s", table.CurrentHand.Street)
+	}
+	if len(table.CurrentHand.BoardCards) != 3 {
+		t.Errorf("expected 3 board cards, got %d", len(table.CurrentHand.BoardCards))
+	}
+	if table.CurrentHand.CurrentActor == nil {
+		t.Error("expected first actor to be set for the flop")
+	}
+}
+
+// TestTable_ProgressHandAllInRunsOutBoard verifies an all-in hand is dealt out and settled
+func TestTable_ProgressHandAllInRunsOutBoard(t *testing.T) {
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+	token1 := "player1"
+	token2 := "player2"
+	table.Seats[0] = Seat{Index: 0, Token: &token1, Status: "active", Stack: 1000}
+	table.Seats[1] = Seat{Index: 1, Token: &token2, Status: "active", Stack: 1000}
+
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("failed to start hand: %v", err)
+	}
+
+	// Both players all-in preflop
+	table.mu.Lock()
+	hand := table.CurrentHand
+	for _, seat := range []int{0, 1} {
+		hand.PlayerBets[seat] = 1000
+		hand.TotalContributions[seat] = 1000
+		hand.ActedPlayers[seat] = true
+		table.Seats[seat].Stack = 0
+	}
+	hand.CurrentBet = 1000
+	hand.BigBlindHasOption = false
+	table.mu.Unlock()
+
+	table.ProgressHand()
+
+	if phase := table.Phase(); phase != PhaseWaitingForPlayers {
+		t.Errorf("expected %s after all-in runout, got %s", PhaseWaitingForPlayers, phase)
+	}
+
+	table.mu.RLock()
+	total := table.Seats[0].Stack + table.Seats[1].Stack
+	table.mu.RUnlock()
+	if total != 2000 {
+		t.Errorf("expected all 2000 chips to be awarded, got %d", total)
+	}
+}
@@ -1,24 +1,74 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // TableInfo represents table information for the lobby view
 type TableInfo struct {
-	ID            string `json:"id"`
-	Name          string `json:"name"`
-	SeatsOccupied int    `json:"seats_occupied"`
-	MaxSeats      int    `json:"max_seats"`
+	ID            string        `json:"id"`
+	Name          string        `json:"name"`
+	SeatsOccupied int           `json:"seats_occupied"`
+	MaxSeats      int           `json:"max_seats"`
+	SmallBlind    int           `json:"small_blind"`
+	BigBlind      int           `json:"big_blind"`
+	BuyIn         int           `json:"buy_in"`
+	Currency      ChipCurrency  `json:"currency"`
+	Description   string        `json:"description,omitempty"`
+	Theme         string        `json:"theme,omitempty"`
+	Tags          []string      `json:"tags,omitempty"`
+	GameType      string        `json:"game_type"`
+	Speed         string        `json:"speed"`
+	Pot           int           `json:"pot"` // Chips in the middle of the hand in progress (0 between hands)
+	Observers     int           `json:"observers"`
+	HandsPerHour  int           `json:"hands_per_hour"`        // Hands finished in the last hour
+	AveragePot    int           `json:"avg_pot"`               // Average pot of those hands
+	Frozen        bool          `json:"frozen,omitempty"`      // Frozen by an admin: nobody can join or leave
+	Closing       bool          `json:"closing,omitempty"`     // Closing: nobody can join, and it leaves the lobby once empty
+	HeadsUp       bool          `json:"heads_up,omitempty"`    // Winner-stays heads-up table
+	Challengers   int           `json:"challengers,omitempty"` // Players queued to play the winner
+	Host          string        `json:"host,omitempty"`        // Name of the player in the host seat
+	ClubID        string        `json:"club_id,omitempty"`     // Private table of this club
+	Today         *Superlatives `json:"today,omitempty"`       // Today's biggest pot, best hand and fastest elimination
+	Practice      bool          `json:"practice,omitempty"`    // Training table where players may turn on preflop hints
+	Anonymous     bool          `json:"anonymous,omitempty"`   // Players are shown under aliases rather than their names
+	// ConfirmRaisePercent is the share of the stack above which raises must be confirmed (0 = never)
+	ConfirmRaisePercent int `json:"confirm_raise_percent,omitempty"`
 }
 
 // WebSocketMessage represents a generic WebSocket message structure
+// ServerTime is set on every server-sent message (Unix ms) so clients can track clock skew
 type WebSocketMessage struct {
-	Type    string          `json:"type"`
-	Payload json.RawMessage `json:"payload"`
+	Type       string          `json:"type"`
+	Payload    json.RawMessage `json:"payload"`
+	ServerTime int64           `json:"serverTime,omitempty"`
+}
+
+// serverTimeMillis returns the current server time as Unix milliseconds,
+// the unit used for every timestamp and deadline in the protocol
+func serverTimeMillis() int64 {
+	return time.Now().UnixMilli()
+}
+
+// TimeSyncPayload represents the payload for time_sync messages sent by the client
+type TimeSyncPayload struct {
+	ClientTime int64 `json:"clientTime"` // Client clock (Unix ms) when the ping was sent
+}
+
+// TimeSyncResponsePayload represents the payload for time_sync replies
+// The client estimates its offset as serverTime - (clientTime + roundTrip/2)
+type TimeSyncResponsePayload struct {
+	ClientTime int64 `json:"clientTime"` // Echo of the client's ping time
+	ServerTime int64 `json:"serverTime"` // Server clock (Unix ms) when the ping was answered
 }
 
 // SetNamePayload represents the payload for set_name messages
@@ -28,20 +78,27 @@ type SetNamePayload struct {
 
 // SessionCreatedPayload represents the payload for session_created messages
 type SessionCreatedPayload struct {
-	Token string `json:"token"`
-	Name  string `json:"name"`
+	Token     string               `json:"token"`
+	Name      string               `json:"name"`
+	ExpiresAt int64                `json:"expiresAt,omitempty"` // Unix ms when the session lapses unless renewed
+	Balances  map[ChipCurrency]int `json:"balances,omitempty"`  // Chips held off the tables, per currency
 }
 
 // SessionRestoredPayload represents the payload for session_restored messages
 type SessionRestoredPayload struct {
-	Name      string  `json:"name"`
-	TableID   *string `json:"tableID,omitempty"`
-	SeatIndex *int    `json:"seatIndex,omitempty"`
+	Name      string               `json:"name"`
+	TableID   *string              `json:"tableID,omitempty"`
+	SeatIndex *int                 `json:"seatIndex,omitempty"`
+	ExpiresAt int64                `json:"expiresAt,omitempty"` // Unix ms when the session lapses unless renewed
+	Balances  map[ChipCurrency]int `json:"balances,omitempty"`  // Chips held off the tables, per currency
 }
 
-// ErrorPayload represents the payload for error messages
+// ErrorPayload represents the payload for error messages. Key and Params identify the error for
+// clients that localize it; Message is the English text.
 type ErrorPayload struct {
-	Message string `json:"message"`
+	Message string         `json:"message"`
+	Key     string         `json:"key"`
+	Params  map[string]any `json:"params,omitempty"`
 }
 
 // JoinTablePayload represents the payload for join_table messages
@@ -64,9 +121,12 @@ type SeatClearedPayload struct{}
 
 // HandStartedPayload represents the payload for hand_started messages
 type HandStartedPayload struct {
-	DealerSeat     int `json:"dealerSeat"`
-	SmallBlindSeat int `json:"smallBlindSeat"`
-	BigBlindSeat   int `json:"bigBlindSeat"`
+	HandID         string `json:"handId"` // Fetch the hand from /api/replays/{handId} once it ends
+	DealerSeat     int    `json:"dealerSeat"`
+	SmallBlindSeat int    `json:"smallBlindSeat"`
+	BigBlindSeat   int    `json:"bigBlindSeat"`
+	SeedCommitment string `json:"seedCommitment,omitempty"` // SHA-256 of the shuffle seed, checkable against the RNG audit log
+	BombPot        bool   `json:"bombPot,omitempty"`        // Everyone anted instead of posting blinds; the flop comes next
 }
 
 // BlindPostedPayload represents the payload for blind_posted messages
@@ -91,17 +151,27 @@ type ActionRequestPayload struct {
 	Pot          int      `json:"pot"`
 	MinRaise     int      `json:"minRaise"`
 	MaxRaise     int      `json:"maxRaise"`
+	// RaiseStep is the step raises go up in from MinRaise; MaxRaise (all-in) is always allowed
+	RaiseStep int   `json:"raiseStep"`
+	Deadline  int64 `json:"deadline,omitempty"` // Unix ms when the actor's clock runs out (omitted when no clock)
+	// TimeBankMs is the time bank the actor has left beyond Deadline (omitted when none)
+	TimeBankMs int64 `json:"timeBankMs,omitempty"`
 }
 
 // PlayerActionPayload represents the payload for player_action messages
+// ActionID is generated by the client and lets the server recognize resent actions
 type PlayerActionPayload struct {
+	ActionID  string `json:"actionId"`
 	SeatIndex int    `json:"seatIndex"`
 	Action    string `json:"action"`
 	Amount    *int   `json:"amount,omitempty"`
+	// Confirm plays a raise held by confirm_raise (see Table.ConfirmRaisePercent)
+	Confirm bool `json:"confirm,omitempty"`
 }
 
 // ActionResultPayload represents the payload for action_result messages
 type ActionResultPayload struct {
+	ActionID    string `json:"actionId,omitempty"`
 	SeatIndex   int    `json:"seatIndex"`
 	Action      string `json:"action"`
 	AmountActed int    `json:"amountActed"`
@@ -114,16 +184,18 @@ type ActionResultPayload struct {
 
 // BoardDealtPayload represents the payload for board_dealt messages
 type BoardDealtPayload struct {
-	BoardCards []Card `json:"boardCards"`
-	Street     string `json:"street"`
+	BoardCards []Card       `json:"boardCards"`
+	Street     string       `json:"street"`
+	Texture    BoardTexture `json:"texture"` // How coordinated the board is, for hints
 }
 
 // ShowdownResultPayload represents the result of a showdown
 type ShowdownResultPayload struct {
-	WinnerSeats []int       `json:"winnerSeats"` // Seat indices of winners
-	WinningHand string      `json:"winningHand"` // Human-readable hand name
-	PotAmount   int         `json:"potAmount"`   // Total pot size
-	AmountsWon  map[int]int `json:"amountsWon"`  // Map of seat index to amount won
+	WinnerSeats []int       `json:"winnerSeats"`    // Seat indices of winners
+	WinningHand string      `json:"winningHand"`    // Human-readable hand name
+	PotAmount   int         `json:"potAmount"`      // Total pot size
+	AmountsWon  map[int]int `json:"amountsWon"`     // Map of seat index to amount won
+	Rake        int         `json:"rake,omitempty"` // Chips taken by the house before the pot was awarded
 }
 
 // HandCompletePayload represents hand completion
@@ -136,7 +208,12 @@ func (c *Client) HandleSetName(sm *SessionManager, server *Server, logger *slog.
 	var setNamePayload SetNamePayload
 	err := json.Unmarshal(payload, &setNamePayload)
 	if err != nil {
-		return fmt.Errorf("invalid set_name payload: %w", err)
+		return invalidPayloadError("set_name", err)
+	}
+
+	if _, banned := server.bans.AccountBan(setNamePayload.Name, time.Now()); banned {
+		logger.Warn("set_name refused: account banned", "name", setNamePayload.Name, "client_ip", c.RemoteIP)
+		return fmt.Errorf("banned")
 	}
 
 	// Create a new session
@@ -146,12 +223,17 @@ func (c *Client) HandleSetName(sm *SessionManager, server *Server, logger *slog.
 	}
 
 	// Update client token
-	c.Token = session.Token
+	c.setToken(session.Token)
+
+	server.grantStartingBalance(session.Token)
+	balances, _ := sm.Balances(session.Token)
 
 	// Send session_created message
 	payloadObj := SessionCreatedPayload{
-		Token: session.Token,
-		Name:  session.Name,
+		Token:     session.Token,
+		Name:      session.Name,
+		ExpiresAt: expiresAtMillis(session.ExpiresAt),
+		Balances:  balances,
 	}
 	payloadBytes, err := json.Marshal(payloadObj)
 	if err != nil {
@@ -159,8 +241,9 @@ func (c *Client) HandleSetName(sm *SessionManager, server *Server, logger *slog.
 	}
 
 	response := WebSocketMessage{
-		Type:    "session_created",
-		Payload: json.RawMessage(payloadBytes),
+		Type:       "session_created",
+		Payload:    json.RawMessage(payloadBytes),
+		ServerTime: serverTimeMillis(),
 	}
 
 	responseBytes, err := json.Marshal(response)
@@ -184,6 +267,8 @@ func (c *Client) SendSessionRestored(session *Session, logger *slog.Logger) erro
 		Name:      session.Name,
 		TableID:   session.TableID,
 		SeatIndex: session.SeatIndex,
+		ExpiresAt: expiresAtMillis(session.ExpiresAt),
+		Balances:  session.Balances,
 	}
 	payloadBytes, err := json.Marshal(payloadObj)
 	if err != nil {
@@ -191,8 +276,9 @@ func (c *Client) SendSessionRestored(session *Session, logger *slog.Logger) erro
 	}
 
 	response := WebSocketMessage{
-		Type:    "session_restored",
-		Payload: json.RawMessage(payloadBytes),
+		Type:       "session_restored",
+		Payload:    json.RawMessage(payloadBytes),
+		ServerTime: serverTimeMillis(),
 	}
 
 	responseBytes, err := json.Marshal(response)
@@ -207,9 +293,13 @@ func (c *Client) SendSessionRestored(session *Session, logger *slog.Logger) erro
 }
 
 // SendError sends an error message to the client
-func (c *Client) SendError(message string, logger *slog.Logger) error {
+func (c *Client) SendError(sendErr error, logger *slog.Logger) error {
+	key, params := errorMessageKey(sendErr)
+	message := sendErr.Error()
 	payloadObj := ErrorPayload{
 		Message: message,
+		Key:     key,
+		Params:  params,
 	}
 	payloadBytes, err := json.Marshal(payloadObj)
 	if err != nil {
@@ -217,8 +307,9 @@ func (c *Client) SendError(message string, logger *slog.Logger) error {
 	}
 
 	response := WebSocketMessage{
-		Type:    "error",
-		Payload: json.RawMessage(payloadBytes),
+		Type:       "error",
+		Payload:    json.RawMessage(payloadBytes),
+		ServerTime: serverTimeMillis(),
 	}
 
 	responseBytes, err := json.Marshal(response)
@@ -226,7 +317,7 @@ func (c *Client) SendError(message string, logger *slog.Logger) error {
 		return fmt.Errorf("failed to marshal error response: %w", err)
 	}
 
-	logger.Info("error sent to client", "message", message)
+	logger.Info("error sent to client", "message", message, "key", key)
 
 	c.send <- responseBytes
 	return nil
@@ -234,7 +325,7 @@ func (c *Client) SendError(message string, logger *slog.Logger) error {
 
 // SendLobbyState sends the current lobby state to the client
 func (c *Client) SendLobbyState(server *Server, logger *slog.Logger) error {
-	lobbyState := server.GetLobbyState()
+	lobbyState := server.lobbyState()
 
 	// Marshal the lobby state to JSON
 	payloadBytes, err := json.Marshal(lobbyState)
@@ -243,8 +334,9 @@ func (c *Client) SendLobbyState(server *Server, logger *slog.Logger) error {
 	}
 
 	response := WebSocketMessage{
-		Type:    "lobby_state",
-		Payload: json.RawMessage(payloadBytes),
+		Type:       "lobby_state",
+		Payload:    json.RawMessage(payloadBytes),
+		ServerTime: serverTimeMillis(),
 	}
 
 	responseBytes, err := json.Marshal(response)
@@ -272,31 +364,65 @@ func HealthCheckHandler(logger *slog.Logger) http.HandlerFunc {
 	}
 }
 
-// GetLobbyState returns a slice of TableInfo for all tables in the server
+// GetLobbyState returns a slice of TableInfo for all public tables in the server
 // Thread-safe method using RLock on Server.mu
 func (s *Server) GetLobbyState() []TableInfo {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	now := time.Now()
 	lobbyState := make([]TableInfo, 0, len(s.tables))
 	for _, table := range s.tables {
-		if table == nil {
+		// Club tables are listed to the club's members only (see clubView)
+		if table == nil || table.ClubID != "" {
 			continue
 		}
-		tableInfo := TableInfo{
-			ID:            table.ID,
-			Name:          table.Name,
-			MaxSeats:      table.MaxSeats,
-			SeatsOccupied: table.GetOccupiedSeatCount(),
-		}
-		lobbyState = append(lobbyState, tableInfo)
+		lobbyState = append(lobbyState, s.tableInfo(table, now))
 	}
 	return lobbyState
 }
 
+// tableInfo returns the lobby listing of table at now
+func (s *Server) tableInfo(table *Table, now time.Time) TableInfo {
+	seated, pot := table.lobbyCounts()
+	activity := s.activity.Activity(table.ID, now)
+	tableInfo := TableInfo{
+		ID:                  table.ID,
+		Name:                table.Name,
+		MaxSeats:            table.MaxSeats,
+		SeatsOccupied:       seated,
+		SmallBlind:          table.SmallBlind,
+		BigBlind:            table.BigBlind,
+		BuyIn:               table.BuyIn,
+		Currency:            table.Currency,
+		Description:         table.Description,
+		Theme:               table.Theme,
+		Tags:                table.Tags,
+		GameType:            table.GameType,
+		Speed:               table.Speed,
+		Pot:                 pot,
+		Observers:           s.observers.Count(table.ID),
+		HandsPerHour:        activity.HandsPerHour,
+		AveragePot:          activity.AveragePot,
+		Frozen:              table.Frozen(),
+		Closing:             table.Closing(),
+		HeadsUp:             table.HeadsUp,
+		Challengers:         table.challengerCount(),
+		ClubID:              table.ClubID,
+		Today:               s.todaysSuperlatives(table, now),
+		Practice:            table.Practice,
+		Anonymous:           table.Anonymous,
+		ConfirmRaisePercent: table.ConfirmRaisePercent,
+	}
+	if host := table.hostToken(); host != "" {
+		tableInfo.Host, _ = s.sessionManager.GetPlayerName(host)
+	}
+	return tableInfo
+}
+
 // broadcastLobbyState sends the current lobby state to all connected clients
 func (s *Server) broadcastLobbyState() error {
-	lobbyState := s.GetLobbyState()
+	lobbyState := s.lobbyState()
 
 	// First marshal the lobby state to JSON
 	payloadBytes, err := json.Marshal(lobbyState)
@@ -311,8 +437,9 @@ func (s *Server) broadcastLobbyState() error {
 	}
 
 	response := WebSocketMessage{
-		Type:    "lobby_state",
-		Payload: json.RawMessage(payloadString),
+		Type:       "lobby_state",
+		Payload:    json.RawMessage(payloadString),
+		ServerTime: serverTimeMillis(),
 	}
 
 	responseBytes, err := json.Marshal(response)
@@ -334,7 +461,7 @@ func (s *Server) broadcastLobbyState() error {
 // This is used to send the state to other players after one player makes a change
 // Note: Only clients NOT at a table receive lobby_state (clients at tables only receive table_state)
 func (s *Server) broadcastLobbyStateExcluding(excludeClient *Client) error {
-	lobbyState := s.GetLobbyState()
+	lobbyState := s.lobbyState()
 
 	// First marshal the lobby state to JSON
 	payloadBytes, err := json.Marshal(lobbyState)
@@ -349,8 +476,9 @@ func (s *Server) broadcastLobbyStateExcluding(excludeClient *Client) error {
 	}
 
 	response := WebSocketMessage{
-		Type:    "lobby_state",
-		Payload: json.RawMessage(payloadString),
+		Type:       "lobby_state",
+		Payload:    json.RawMessage(payloadString),
+		ServerTime: serverTimeMillis(),
 	}
 
 	responseBytes, err := json.Marshal(response)
@@ -385,12 +513,46 @@ func (s *Server) broadcastLobbyStateExcluding(excludeClient *Client) error {
 	return nil
 }
 
+// HandleTimeSync answers a time_sync ping with the client's timestamp and the server clock
+func (c *Client) HandleTimeSync(logger *slog.Logger, payload []byte) error {
+	var timeSyncPayload TimeSyncPayload
+	err := json.Unmarshal(payload, &timeSyncPayload)
+	if err != nil {
+		return invalidPayloadError("time_sync", err)
+	}
+
+	now := serverTimeMillis()
+	payloadBytes, err := json.Marshal(TimeSyncResponsePayload{
+		ClientTime: timeSyncPayload.ClientTime,
+		ServerTime: now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	response := WebSocketMessage{
+		Type:       "time_sync",
+		Payload:    json.RawMessage(payloadBytes),
+		ServerTime: now,
+	}
+
+	responseBytes, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	logger.Debug("time_sync answered", "clientTime", timeSyncPayload.ClientTime, "serverTime", now)
+
+	c.send <- responseBytes
+	return nil
+}
+
 // HandleJoinTable processes a join_table message and assigns the player to a table seat
 func (c *Client) HandleJoinTable(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
 	var joinTablePayload JoinTablePayload
 	err := json.Unmarshal(payload, &joinTablePayload)
 	if err != nil {
-		return fmt.Errorf("invalid join_table payload: %w", err)
+		return invalidPayloadError("join_table", err)
 	}
 
 	// Verify session exists
@@ -420,16 +582,9 @@ func (c *Client) HandleJoinTable(sm *SessionManager, server *Server, logger *slo
 		return fmt.Errorf("invalid_table")
 	}
 
-	// Assign seat on the table
-	seat, err := table.AssignSeat(&c.Token)
-	if err != nil {
-		return fmt.Errorf("table_full")
-	}
-
-	// Update session with table and seat info
-	_, err = sm.UpdateSession(c.Token, &table.ID, &seat.Index)
+	seat, err := server.seatPlayer(c.Token, c.RemoteIP, table)
 	if err != nil {
-		return fmt.Errorf("failed to update session: %w", err)
+		return err
 	}
 
 	// Send seat_assigned message to client
@@ -458,9 +613,68 @@ func (c *Client) HandleJoinTable(sm *SessionManager, server *Server, logger *slo
 
 	logger.Info("player joined table", "token", c.Token, "tableId", table.ID, "seatIndex", seat.Index)
 
+	// Start the countdown to the first hand once enough players are seated
+	table.ScheduleNextHand()
+
 	return nil
 }
 
+// errTableFull is returned when a player tries to sit at a table with no empty seat
+var errTableFull = errors.New("table_full")
+
+// seatPlayer buys the player in and seats them at table, updating their session
+// Returns insufficient_balance or table_full when the seat cannot be taken; notifying the
+// player and the table is left to the caller
+func (s *Server) seatPlayer(token, remoteIP string, table *Table) (Seat, error) {
+	return s.seatPlayerWithStack(token, remoteIP, table, table.BuyIn)
+}
+
+// seatPlayerWithStack seats token at table like seatPlayer, buying in for stack chips
+func (s *Server) seatPlayerWithStack(token, remoteIP string, table *Table, stack int) (Seat, error) {
+	if _, err := s.sessionManager.GetSession(token); err != nil {
+		return Seat{}, fmt.Errorf("session not found: %w", err)
+	}
+	if table.Frozen() {
+		return Seat{}, newMessageError("error.table_frozen", nil)
+	}
+	if table.Closing() {
+		return Seat{}, newMessageError("error.table_closing", nil)
+	}
+	if s.hostedTable(token) != nil {
+		return Seat{}, newMessageError("error.hosting", nil)
+	}
+	if err := s.checkClubAccess(table, token); err != nil {
+		return Seat{}, err
+	}
+
+	// Pay for the stack out of the player's balance in the table's currency
+	if err := s.buyIn(token, table, stack); err != nil {
+		if errors.Is(err, errInsufficientBalance) {
+			return Seat{}, err
+		}
+		return Seat{}, fmt.Errorf("failed to buy in: %w", err)
+	}
+
+	// Assign seat on the table
+	seat, err := table.assignSeat(&token, stack)
+	if err != nil {
+		s.refundBuyIn(token, table, stack)
+		return Seat{}, errTableFull
+	}
+	s.sendBalances(token)
+	s.waitlist.Remove(token)
+	s.removeChallenger(token)
+	s.observers.Remove(token)
+
+	table.publishEvent(Event{Type: EventPlayerSeated, SeatIndex: seat.Index, Token: token, RemoteIP: remoteIP})
+
+	// Update session with table and seat info
+	if _, err := s.sessionManager.UpdateSession(token, &table.ID, &seat.Index); err != nil {
+		return Seat{}, fmt.Errorf("failed to update session: %w", err)
+	}
+	return seat, nil
+}
+
 // SendSeatAssigned sends a seat_assigned message to the client
 func (c *Client) SendSeatAssigned(tableID string, seatIndex int, status string, logger *slog.Logger) error {
 	payloadObj := SeatAssignedPayload{
@@ -474,8 +688,9 @@ func (c *Client) SendSeatAssigned(tableID string, seatIndex int, status string,
 	}
 
 	response := WebSocketMessage{
-		Type:    "seat_assigned",
-		Payload: json.RawMessage(payloadBytes),
+		Type:       "seat_assigned",
+		Payload:    json.RawMessage(payloadBytes),
+		ServerTime: serverTimeMillis(),
 	}
 
 	responseBytes, err := json.Marshal(response)
@@ -515,10 +730,13 @@ func (c *Client) HandleLeaveTable(sm *SessionManager, server *Server, logger *sl
 	server.mu.RUnlock()
 
 	if table == nil {
-		return fmt.Errorf("table not found")
+		return newMessageError("error.table_not_found", nil)
+	}
+	if table.Frozen() {
+		return newMessageError("error.table_frozen", nil)
 	}
 
-	// Clear the seat
+	// Clear the seat, folding the player out of a hand in progress first
 	err = table.ClearSeat(&c.Token)
 	if err != nil {
 		return fmt.Errorf("failed to clear seat: %w", err)
@@ -568,8 +786,9 @@ func (c *Client) SendSeatCleared(logger *slog.Logger) error {
 	}
 
 	response := WebSocketMessage{
-		Type:    "seat_cleared",
-		Payload: json.RawMessage(payloadBytes),
+		Type:       "seat_cleared",
+		Payload:    json.RawMessage(payloadBytes),
+		ServerTime: serverTimeMillis(),
 	}
 
 	responseBytes, err := json.Marshal(response)
@@ -585,23 +804,57 @@ func (c *Client) SendSeatCleared(logger *slog.Logger) error {
 
 // TableStateSeat represents a single seat in the table_state message
 type TableStateSeat struct {
-	Index      int     `json:"index"`
-	PlayerName *string `json:"playerName"`
-	Status     string  `json:"status"`
-	Stack      *int    `json:"stack"`
-	CardCount  *int    `json:"cardCount,omitempty"`
+	Index      int           `json:"index"`
+	PlayerName *string       `json:"playerName"`
+	Status     string        `json:"status"`
+	Stack      *int          `json:"stack"`
+	CardCount  *int          `json:"cardCount,omitempty"`
+	Stats      *PlayerStats  `json:"stats,omitempty"`      // Public statistics, only on tables with ShowStats
+	LastAction *SeatAction   `json:"lastAction,omitempty"` // Latest action this street during a hand
+	Bet        int           `json:"bet,omitempty"`        // Chips in front of the seat on the current street
+	BetChips   []ChipCount   `json:"betChips,omitempty"`   // Bet as chips, see ChipBreakdown
+	Metrics    *StackMetrics `json:"metrics,omitempty"`    // Derived stack figures for occupied seats
+	Connection string        `json:"connection,omitempty"` // Connection status of occupied seats
+	// ReconnectDeadline is when a reconnecting seat is given up, Unix ms
+	ReconnectDeadline *int64 `json:"reconnectDeadline,omitempty"`
+}
+
+// BlindLevel is the forced bets a table deals its hands with
+type BlindLevel struct {
+	SmallBlind int `json:"smallBlind"`
+	BigBlind   int `json:"bigBlind"`
+	Ante       int `json:"ante"` // Always 0 until antes exist
 }
 
 // TableStatePayload represents the payload for table_state messages
 type TableStatePayload struct {
 	TableId        string           `json:"tableId"`
+	Blinds         BlindLevel       `json:"blinds"` // The active level, so clients never infer it from posted blinds
 	Seats          []TableStateSeat `json:"seats"`
 	HandInProgress bool             `json:"handInProgress"`
 	DealerSeat     *int             `json:"dealerSeat,omitempty"`
 	SmallBlindSeat *int             `json:"smallBlindSeat,omitempty"`
 	BigBlindSeat   *int             `json:"bigBlindSeat,omitempty"`
 	Pot            *int             `json:"pot,omitempty"`
+	PotChips       []ChipCount      `json:"potChips,omitempty"` // Pot as chips, see ChipBreakdown
 	HoleCards      map[int][]Card   `json:"holeCards,omitempty"`
+	RevealedCards  map[int][]Card   `json:"revealedCards,omitempty"` // Hole cards turned face up for everyone (all-in runout)
+	CurrentActor   *int             `json:"currentActor,omitempty"`
+	ActionDeadline *int64           `json:"actionDeadline,omitempty"` // Unix ms when the current actor's clock runs out
+	ClockCalled    bool             `json:"clockCalled,omitempty"`    // An opponent called the clock on the current actor
+	Frozen         bool             `json:"frozen,omitempty"`         // An admin froze the table; no hand is dealt until it resumes
+	Closing        bool             `json:"closing,omitempty"`        // An admin is closing the table; nobody can join
+	NextHandAt     *int64           `json:"nextHandAt,omitempty"`     // Unix ms when the next hand is dealt automatically
+	// WaitingForPlayers is set while automatic dealing is paused for lack of a second player
+	WaitingForPlayers bool `json:"waitingForPlayers,omitempty"`
+	// Host is the name of the player in the host seat, if any; HostPaused is set while they have
+	// dealing paused, and BombPotNext once they have called a bomb pot for the next hand
+	Host        string `json:"host,omitempty"`
+	HostPaused  bool   `json:"hostPaused,omitempty"`
+	BombPotNext bool   `json:"bombPotNext,omitempty"`
+	// PendingRules are the blinds and timers the next hand is dealt under, when they changed
+	// during the hand in progress
+	PendingRules *TableRules `json:"pendingRules,omitempty"`
 }
 
 // SendTableState sends a table_state message to a single client
@@ -623,117 +876,148 @@ func (c *Client) SendTableState(server *Server, tableID string, logger *slog.Log
 		return fmt.Errorf("table not found: %s", tableID)
 	}
 
-	// Determine which seat (if any) the client is sitting at
-	var clientSeatIndex *int
-	session, err := server.sessionManager.GetSession(c.Token)
-	if err == nil && session.SeatIndex != nil {
-		clientSeatIndex = session.SeatIndex
+	payloadObj := server.buildTableState(table, server.viewerSeat(c.Token))
+
+	payloadBytes, err := json.Marshal(payloadObj)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	response := WebSocketMessage{
+		Type:       "table_state",
+		Payload:    json.RawMessage(payloadBytes),
+		ServerTime: serverTimeMillis(),
+	}
+
+	responseBytes, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	logger.Info("table_state sent to client", "tableId", tableID)
+
+	c.send <- responseBytes
+	return nil
+}
+
+// viewerSeat returns the seat the session holding token sits in, or nil for spectators
+func (s *Server) viewerSeat(token string) *int {
+	session, err := s.sessionManager.GetSession(token)
+	if err != nil || session.SeatIndex == nil {
+		return nil
 	}
+	seatIndex := *session.SeatIndex
+	return &seatIndex
+}
+
+// buildTableState builds the table_state payload as seen from viewerSeat (nil for spectators)
+// Hole cards pass through redactHoleCards, so the viewer only ever gets their own; every
+// occupied seat gets a card count so clients can render card backs
+func (s *Server) buildTableState(table *Table, viewerSeat *int) TableStatePayload {
+	payload := TableStatePayload{
+		TableId: table.ID,
+		Seats:   make([]TableStateSeat, 6),
+	}
+
+	// Player names and statistics are looked up after releasing the table lock
+	var tokens [6]*string
 
-	// Build seats array with player names
-	seats := make([]TableStateSeat, 6)
 	table.mu.RLock()
+	showStats := table.ShowStats
+	payload.Blinds = BlindLevel{SmallBlind: table.SmallBlind, BigBlind: table.BigBlind}
+	for i, m := range table.stackMetricsLocked() {
+		payload.Seats[i].Metrics = &m
+	}
 	for i, seat := range table.Seats {
-		seats[i].Index = i
-		seats[i].Status = seat.Status
+		payload.Seats[i].Index = i
+		payload.Seats[i].Status = seat.Status
 
 		if seat.Token != nil {
-			// Get player name by token
-			playerName, err := server.sessionManager.GetPlayerName(*seat.Token)
-			if err != nil {
-				logger.Warn("failed to get player name", "token", *seat.Token, "error", err)
-				seats[i].PlayerName = nil
-			} else {
-				seats[i].PlayerName = &playerName
-			}
+			tokens[i] = seat.Token
 			// Set stack for occupied seat
 			stack := seat.Stack
-			seats[i].Stack = &stack
-		} else {
-			seats[i].PlayerName = nil
-			seats[i].Stack = nil
+			payload.Seats[i].Stack = &stack
+			status, deadline := table.connectionLocked(*seat.Token)
+			payload.Seats[i].Connection = status
+			if deadline != nil {
+				ms := deadline.UnixMilli()
+				payload.Seats[i].ReconnectDeadline = &ms
+			}
 		}
 	}
 
 	// Get game state info when hand is active
-	var dealerSeat *int
-	var smallBlindSeat *int
-	var bigBlindSeat *int
-	var pot *int
-	handInProgress := false
-
-	if table.CurrentHand != nil {
-		handInProgress = true
-		dealerSeat = table.DealerSeat
-		sbSeat := table.CurrentHand.SmallBlindSeat
-		bbSeat := table.CurrentHand.BigBlindSeat
-		potAmount := table.CurrentHand.Pot
-		smallBlindSeat = &sbSeat
-		bigBlindSeat = &bbSeat
-		pot = &potAmount
-	}
-
-	// Populate card counts for all occupied seats during active hand
-	if table.CurrentHand != nil {
-		for i, seat := range table.Seats {
-			if seat.Token != nil {
-				// This seat has a player
-				if cardList, hasCards := table.CurrentHand.HoleCards[i]; hasCards {
-					cardCount := len(cardList)
-					seats[i].CardCount = &cardCount
-				}
-			}
+	if hand := table.CurrentHand; hand != nil {
+		payload.HandInProgress = true
+		payload.DealerSeat = table.DealerSeat
+		sbSeat := hand.SmallBlindSeat
+		bbSeat := hand.BigBlindSeat
+		potAmount := hand.Pot
+		payload.SmallBlindSeat = &sbSeat
+		payload.BigBlindSeat = &bbSeat
+		payload.Pot = &potAmount
+		payload.PotChips = ChipBreakdown(potAmount, table.SmallBlind)
+		for i, bet := range hand.PlayerBets {
+			payload.Seats[i].Bet = bet
+			payload.Seats[i].BetChips = ChipBreakdown(bet, table.SmallBlind)
 		}
-	}
-
-	table.mu.RUnlock()
-
-	// Populate hole cards - only if client is seated and hand is active
-	var holeCards map[int][]Card
-	if clientSeatIndex != nil && table.CurrentHand != nil {
-		// Client is seated - give them their own cards only (privacy)
-		holeCards = make(map[int][]Card)
-		table.mu.RLock()
-		if clientCards, hasCards := table.CurrentHand.HoleCards[*clientSeatIndex]; hasCards {
-			holeCards[*clientSeatIndex] = clientCards
+		if hand.CurrentActor != nil {
+			actor := *hand.CurrentActor
+			payload.CurrentActor = &actor
 		}
-		table.mu.RUnlock()
-	}
 
-	// Create payload
-	payloadObj := TableStatePayload{
-		TableId:        tableID,
-		Seats:          seats,
-		HandInProgress: handInProgress,
-		DealerSeat:     dealerSeat,
-		SmallBlindSeat: smallBlindSeat,
-		BigBlindSeat:   bigBlindSeat,
-		Pot:            pot,
-		HoleCards:      holeCards,
-	}
+		for i, last := range hand.LastActions {
+			payload.Seats[i].LastAction = &last
+		}
 
-	payloadBytes, err := json.Marshal(payloadObj)
-	if err != nil {
-		return fmt.Errorf("failed to marshal payload: %w", err)
-	}
+		// Populate card counts for all occupied seats during active hand
+		for i, seat := range table.Seats {
+			if cardList, hasCards := hand.HoleCards[i]; hasCards && seat.Token != nil {
+				cardCount := len(cardList)
+				payload.Seats[i].CardCount = &cardCount
+			}
+		}
 
-	// DEBUG: Log the payload being sent
-	logger.Info("DEBUG table_state payload", "payload", string(payloadBytes))
+		payload.HoleCards = redactHoleCards(hand.HoleCards, viewerSeat)
+		payload.RevealedCards = revealedHoleCards(hand)
+	}
+	payload.ActionDeadline, payload.NextHandAt = table.deadlinesLocked()
+	payload.ClockCalled = table.clockCalled && table.CurrentHand != nil
+	payload.Frozen = table.freeze != nil
+	payload.Closing = table.closing
+	payload.WaitingForPlayers = table.paused
+	payload.HostPaused = table.hostPaused
+	payload.BombPotNext = table.bombPot
+	payload.PendingRules = table.pendingRulesLocked()
+	host := table.host
+	table.mu.RUnlock()
 
-	response := WebSocketMessage{
-		Type:    "table_state",
-		Payload: json.RawMessage(payloadBytes),
+	if host != nil {
+		payload.Host, _ = s.sessionManager.GetPlayerName(*host)
 	}
 
-	responseBytes, err := json.Marshal(response)
-	if err != nil {
-		return fmt.Errorf("failed to marshal response: %w", err)
+	for i, token := range tokens {
+		if token == nil {
+			continue
+		}
+		playerName, err := s.sessionManager.GetPlayerName(*token)
+		if err != nil {
+			s.logger.Warn("failed to get player name", "token", *token, "error", err)
+			continue
+		}
+		shownName := playerName
+		if table.Anonymous {
+			shownName = s.tableAlias(table.ID, *token)
+		}
+		payload.Seats[i].PlayerName = &shownName
+		if showStats && !s.accounts.Privacy(playerName).HideStats {
+			if stats, ok := s.stats.Stats(*token, table.ID); ok {
+				payload.Seats[i].Stats = &stats
+			}
+		}
 	}
 
-	logger.Info("table_state sent to client", "tableId", tableID)
-
-	c.send <- responseBytes
-	return nil
+	return payload
 }
 
 // broadcastTableState sends the current table state to all clients at a specific table except the sender
@@ -778,94 +1062,7 @@ func (s *Server) broadcastTableState(tableID string, excludeClient *Client) erro
 // sendPersonalizedTableState sends a personalized table_state to a specific client
 // The client sees their own hole cards (if seated) and card counts for all occupied seats
 func (s *Server) sendPersonalizedTableState(client *Client, table *Table) error {
-	// Determine which seat (if any) the client is sitting at
-	var clientSeatIndex *int
-	session, err := s.sessionManager.GetSession(client.Token)
-	if err == nil && session.SeatIndex != nil {
-		clientSeatIndex = session.SeatIndex
-	}
-
-	// Build seats array with player names
-	seats := make([]TableStateSeat, 6)
-	table.mu.RLock()
-	for i, seat := range table.Seats {
-		seats[i].Index = i
-		seats[i].Status = seat.Status
-
-		if seat.Token != nil {
-			// Get player name by token
-			playerName, err := s.sessionManager.GetPlayerName(*seat.Token)
-			if err != nil {
-				s.logger.Warn("failed to get player name", "token", *seat.Token, "error", err)
-				seats[i].PlayerName = nil
-			} else {
-				seats[i].PlayerName = &playerName
-			}
-			// Set stack for occupied seat
-			stack := seat.Stack
-			seats[i].Stack = &stack
-		} else {
-			seats[i].PlayerName = nil
-			seats[i].Stack = nil
-		}
-	}
-
-	// Get game state info when hand is active
-	var dealerSeat *int
-	var smallBlindSeat *int
-	var bigBlindSeat *int
-	var pot *int
-	handInProgress := false
-
-	if table.CurrentHand != nil {
-		handInProgress = true
-		dealerSeat = table.DealerSeat
-		sbSeat := table.CurrentHand.SmallBlindSeat
-		bbSeat := table.CurrentHand.BigBlindSeat
-		potAmount := table.CurrentHand.Pot
-		smallBlindSeat = &sbSeat
-		bigBlindSeat = &bbSeat
-		pot = &potAmount
-	}
-
-	// Populate card counts for all occupied seats during active hand
-	if table.CurrentHand != nil {
-		for i, seat := range table.Seats {
-			if seat.Token != nil {
-				// This seat has a player
-				if cardList, hasCards := table.CurrentHand.HoleCards[i]; hasCards {
-					cardCount := len(cardList)
-					seats[i].CardCount = &cardCount
-				}
-			}
-		}
-	}
-
-	table.mu.RUnlock()
-
-	// Populate hole cards - only if client is seated and hand is active
-	var holeCards map[int][]Card
-	if clientSeatIndex != nil && table.CurrentHand != nil {
-		// Client is seated - give them their own cards only (privacy)
-		holeCards = make(map[int][]Card)
-		table.mu.RLock()
-		if clientCards, hasCards := table.CurrentHand.HoleCards[*clientSeatIndex]; hasCards {
-			holeCards[*clientSeatIndex] = clientCards
-		}
-		table.mu.RUnlock()
-	}
-
-	// Create payload
-	payloadObj := TableStatePayload{
-		TableId:        table.ID,
-		Seats:          seats,
-		HandInProgress: handInProgress,
-		DealerSeat:     dealerSeat,
-		SmallBlindSeat: smallBlindSeat,
-		BigBlindSeat:   bigBlindSeat,
-		Pot:            pot,
-		HoleCards:      holeCards,
-	}
+	payloadObj := s.buildTableState(table, s.viewerSeat(client.Token))
 
 	payloadBytes, err := json.Marshal(payloadObj)
 	if err != nil {
@@ -873,8 +1070,9 @@ func (s *Server) sendPersonalizedTableState(client *Client, table *Table) error
 	}
 
 	response := WebSocketMessage{
-		Type:    "table_state",
-		Payload: json.RawMessage(payloadBytes),
+		Type:       "table_state",
+		Payload:    json.RawMessage(payloadBytes),
+		ServerTime: serverTimeMillis(),
 	}
 
 	responseBytes, err := json.Marshal(response)
@@ -898,6 +1096,45 @@ func (s *Server) sendPersonalizedTableState(client *Client, table *Table) error
 	return nil
 }
 
+// broadcastTableMessage sends a message with the given type and payload to all clients at the table
+func (s *Server) broadcastTableMessage(table *Table, msgType string, payload interface{}) error {
+	return s.broadcastTableMessageExcept(table, msgType, payload, nil)
+}
+
+// broadcastTableMessageExcept sends a message to the clients at the table for which skip, if
+// not nil, returns false
+func (s *Server) broadcastTableMessageExcept(table *Table, msgType string, payload interface{}, skip func(*Client) bool) error {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s payload: %w", msgType, err)
+	}
+
+	response := WebSocketMessage{
+		Type:       msgType,
+		Payload:    json.RawMessage(payloadBytes),
+		ServerTime: serverTimeMillis(),
+	}
+
+	responseBytes, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s message: %w", msgType, err)
+	}
+
+	// Send to all clients at the table
+	for _, client := range s.GetClientsAtTable(table.ID) {
+		if skip != nil && skip(client) {
+			continue
+		}
+		select {
+		case client.send <- responseBytes:
+		default:
+			s.logger.Warn("client send channel full, skipping message", "type", msgType, "tableId", table.ID)
+		}
+	}
+
+	return nil
+}
+
 // broadcastHandStarted sends hand_started message to all clients at the table with dealer and blind info
 func (s *Server) broadcastHandStarted(table *Table) error {
 	// Get all clients at the table
@@ -910,18 +1147,24 @@ func (s *Server) broadcastHandStarted(table *Table) error {
 		table.mu.RUnlock()
 		return fmt.Errorf("CurrentHand is nil")
 	}
+	handID := hand.ID
 	dealerSeat := *table.DealerSeat
 	sbSeat := hand.SmallBlindSeat
 	bbSeat := hand.BigBlindSeat
+	seedCommitment := hand.SeedCommitment
+	bombPot := hand.BombPot
 	table.mu.RUnlock()
 
 	s.logger.Info("hand_started details", "dealerSeat", dealerSeat, "sbSeat", sbSeat, "bbSeat", bbSeat)
 
 	// Create payload
 	payloadObj := HandStartedPayload{
+		HandID:         handID,
 		DealerSeat:     dealerSeat,
 		SmallBlindSeat: sbSeat,
 		BigBlindSeat:   bbSeat,
+		SeedCommitment: seedCommitment,
+		BombPot:        bombPot,
 	}
 
 	payloadBytes, err := json.Marshal(payloadObj)
@@ -930,8 +1173,9 @@ func (s *Server) broadcastHandStarted(table *Table) error {
 	}
 
 	response := WebSocketMessage{
-		Type:    "hand_started",
-		Payload: json.RawMessage(payloadBytes),
+		Type:       "hand_started",
+		Payload:    json.RawMessage(payloadBytes),
+		ServerTime: serverTimeMillis(),
 	}
 
 	responseBytes, err := json.Marshal(response)
@@ -977,8 +1221,9 @@ func (s *Server) broadcastBlindPosted(table *Table, seatNum int, amount int) err
 	}
 
 	response := WebSocketMessage{
-		Type:    "blind_posted",
-		Payload: json.RawMessage(payloadBytes),
+		Type:       "blind_posted",
+		Payload:    json.RawMessage(payloadBytes),
+		ServerTime: serverTimeMillis(),
 	}
 
 	responseBytes, err := json.Marshal(response)
@@ -998,11 +1243,15 @@ func (s *Server) broadcastBlindPosted(table *Table, seatNum int, amount int) err
 	return nil
 }
 
-// broadcastCardsDealt sends cards_dealt messages to all clients at the table with privacy filtering
-// Each player receives only their own hole cards
+// broadcastCardsDealt privately sends every seated player their own hole cards
+// Despite the name nothing is broadcast: each cards_dealt goes through sendPrivate to the
+// connection holding the seat's token, so opponents and spectators never receive it
 func (s *Server) broadcastCardsDealt(table *Table) error {
-	// Get all clients at the table
-	clients := s.GetClientsAtTable(table.ID)
+	type delivery struct {
+		token   string
+		payload CardsDealtPayload
+	}
+	var deliveries []delivery
 
 	table.mu.RLock()
 	hand := table.CurrentHand
@@ -1010,56 +1259,20 @@ func (s *Server) broadcastCardsDealt(table *Table) error {
 		table.mu.RUnlock()
 		return fmt.Errorf("CurrentHand is nil")
 	}
-	holeCards := hand.HoleCards
-	table.mu.RUnlock()
-
-	// Send personalized message to each player
-	for _, client := range clients {
-		// Find which seat this client is at
-		session, err := s.sessionManager.GetSession(client.Token)
-		if err != nil {
-			s.logger.Warn("failed to get session for client", "token", client.Token, "error", err)
-			continue
-		}
-
-		if session.SeatIndex == nil {
-			s.logger.Warn("client has no seat assigned", "token", client.Token)
-			continue
-		}
-
-		seatIndex := *session.SeatIndex
-
-		// Filter hole cards to only show this player's cards
-		filteredCards := filterHoleCardsForPlayer(holeCards, seatIndex)
-
-		// Create payload
-		payloadObj := CardsDealtPayload{
-			HoleCards: filteredCards,
-		}
-
-		payloadBytes, err := json.Marshal(payloadObj)
-		if err != nil {
-			s.logger.Warn("failed to marshal cards_dealt payload", "error", err)
-			continue
-		}
-
-		response := WebSocketMessage{
-			Type:    "cards_dealt",
-			Payload: json.RawMessage(payloadBytes),
-		}
-
-		responseBytes, err := json.Marshal(response)
-		if err != nil {
-			s.logger.Warn("failed to marshal cards_dealt response", "error", err)
+	for i, seat := range table.Seats {
+		if seat.Token == nil {
 			continue
 		}
+		seatIndex := i
+		deliveries = append(deliveries, delivery{
+			token:   *seat.Token,
+			payload: CardsDealtPayload{HoleCards: redactHoleCards(hand.HoleCards, &seatIndex)},
+		})
+	}
+	table.mu.RUnlock()
 
-		// Send to this client
-		select {
-		case client.send <- responseBytes:
-		default:
-			s.logger.Warn("client send channel full, skipping cards_dealt message")
-		}
+	for _, d := range deliveries {
+		s.sendPrivate(d.token, "cards_dealt", d.payload)
 	}
 
 	return nil
@@ -1084,6 +1297,7 @@ func (s *Server) broadcastBoardDealt(table *Table, street string) error {
 	payloadObj := BoardDealtPayload{
 		BoardCards: boardCards,
 		Street:     street,
+		Texture:    ClassifyBoard(boardCards),
 	}
 
 	payloadBytes, err := json.Marshal(payloadObj)
@@ -1092,8 +1306,9 @@ func (s *Server) broadcastBoardDealt(table *Table, street string) error {
 	}
 
 	response := WebSocketMessage{
-		Type:    "board_dealt",
-		Payload: json.RawMessage(payloadBytes),
+		Type:       "board_dealt",
+		Payload:    json.RawMessage(payloadBytes),
+		ServerTime: serverTimeMillis(),
 	}
 
 	responseBytes, err := json.Marshal(response)
@@ -1140,7 +1355,7 @@ func handRankToString(rank int) string {
 }
 
 // broadcastShowdown sends showdown results to all players at the table
-func (s *Server) broadcastShowdown(table *Table, winners []int, rank *HandRank, amountsWon map[int]int) {
+func (s *Server) broadcastShowdown(table *Table, winners []int, rank *HandRank, amountsWon map[int]int, rake int) {
 	clients := s.GetClientsAtTable(table.ID)
 	s.logger.Info("broadcasting showdown_result", "tableID", table.ID, "num_clients", len(clients))
 
@@ -1160,6 +1375,7 @@ func (s *Server) broadcastShowdown(table *Table, winners []int, rank *HandRank,
 		WinningHand: winningHandName,
 		PotAmount:   potAmount,
 		AmountsWon:  amountsWon,
+		Rake:        rake,
 	}
 
 	payloadBytes, err := json.Marshal(payload)
@@ -1169,8 +1385,9 @@ func (s *Server) broadcastShowdown(table *Table, winners []int, rank *HandRank,
 	}
 
 	response := WebSocketMessage{
-		Type:    "showdown_result",
-		Payload: json.RawMessage(payloadBytes),
+		Type:       "showdown_result",
+		Payload:    json.RawMessage(payloadBytes),
+		ServerTime: serverTimeMillis(),
 	}
 
 	responseBytes, err := json.Marshal(response)
@@ -1197,8 +1414,16 @@ func (s *Server) broadcastHandComplete(table *Table) {
 	clients := s.GetClientsAtTable(table.ID)
 	s.logger.Info("broadcasting hand_complete", "tableID", table.ID, "num_clients", len(clients))
 
+	message := "Hand complete. Click 'Start Hand' to begin next hand."
+	if delay := table.nextHandDelay(); delay > 0 {
+		message = fmt.Sprintf("Hand complete. Next hand starts in %d seconds.", int(delay.Round(time.Second)/time.Second))
+		if !table.CanStartHand() {
+			message = "Hand complete. Waiting for players."
+		}
+	}
+
 	payload := HandCompletePayload{
-		Message: "Hand complete. Click 'Start Hand' to begin next hand.",
+		Message: message,
 	}
 
 	payloadBytes, err := json.Marshal(payload)
@@ -1208,8 +1433,9 @@ func (s *Server) broadcastHandComplete(table *Table) {
 	}
 
 	response := WebSocketMessage{
-		Type:    "hand_complete",
-		Payload: json.RawMessage(payloadBytes),
+		Type:       "hand_complete",
+		Payload:    json.RawMessage(payloadBytes),
+		ServerTime: serverTimeMillis(),
 	}
 
 	responseBytes, err := json.Marshal(response)
@@ -1231,6 +1457,42 @@ func (s *Server) broadcastHandComplete(table *Table) {
 	s.logger.Info("hand_complete broadcast complete", "tableID", table.ID, "sentCount", sentCount)
 }
 
+// SendActionResult sends an action_result message to a single client
+// Used to replay the original result when a client resends an already processed action
+func (c *Client) SendActionResult(payload ActionResultPayload, logger *slog.Logger) error {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	response := WebSocketMessage{
+		Type:       "action_result",
+		Payload:    json.RawMessage(payloadBytes),
+		ServerTime: serverTimeMillis(),
+	}
+
+	responseBytes, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	// A duplicate action is answered under the table lock, so a client that is not reading must
+	// not hold up the table (safely handle closed channel)
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Debug("recovered from send on closed channel", "error", r)
+		}
+	}()
+
+	select {
+	case c.send <- responseBytes:
+		logger.Info("action_result sent to client", "actionId", payload.ActionID)
+	default:
+		logger.Warn("client send channel full, skipping action_result message", "actionId", payload.ActionID)
+	}
+	return nil
+}
+
 // HandleStartHand processes a start_hand message to manually trigger hand start (temporary testing feature)
 func (c *Client) HandleStartHand(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
 	// Verify session exists
@@ -1256,7 +1518,12 @@ func (c *Client) HandleStartHand(sm *SessionManager, server *Server, logger *slo
 	server.mu.RUnlock()
 
 	if table == nil {
-		return fmt.Errorf("table not found")
+		return newMessageError("error.table_not_found", nil)
+	}
+
+	// Tables run by the scheduler alone ignore manual starts
+	if server.Config().Features.DisableManualStart {
+		return fmt.Errorf("manual_start_disabled")
 	}
 
 	// Start the hand (this will handle all broadcasting internally)
@@ -1274,6 +1541,15 @@ func (c *Client) HandleStartHand(sm *SessionManager, server *Server, logger *slo
 // HandlePlayerAction processes a player action (fold, check, call, raise) during a hand
 // For raise actions, amount should be provided as variadic parameter
 func (server *Server) HandlePlayerAction(sm *SessionManager, client *Client, seatIndex int, action string, amount ...int) error {
+	return server.HandlePlayerActionWithID(context.Background(), sm, client, "", seatIndex, action, amount...)
+}
+
+// HandlePlayerActionWithID processes a player action tagged with a client-generated action ID
+// If the same client already submitted actionID during the current hand, the original
+// action_result is resent to that client and the action is not processed again.
+// An empty actionID disables duplicate detection.
+// ctx carries the trace of the request that delivered the action.
+func (server *Server) HandlePlayerActionWithID(ctx context.Context, sm *SessionManager, client *Client, actionID string, seatIndex int, action string, amount ...int) error {
 	// Get the session for the client
 	session, err := sm.GetSession(client.Token)
 	if err != nil {
@@ -1282,12 +1558,12 @@ func (server *Server) HandlePlayerAction(sm *SessionManager, client *Client, sea
 
 	// Verify player is seated at a table
 	if session.TableID == nil || session.SeatIndex == nil {
-		return fmt.Errorf("player not seated")
+		return newMessageError("error.player_not_seated", nil)
 	}
 
 	// Verify seat index matches the session
 	if *session.SeatIndex != seatIndex {
-		return fmt.Errorf("seat index mismatch: client at seat %d, action for seat %d", *session.SeatIndex, seatIndex)
+		return newMessageError("error.seat_mismatch", map[string]any{"seatIndex": *session.SeatIndex, "actionSeat": seatIndex})
 	}
 
 	// Get the table reference
@@ -1302,21 +1578,63 @@ func (server *Server) HandlePlayerAction(sm *SessionManager, client *Client, sea
 	server.mu.RUnlock()
 
 	if table == nil {
-		return fmt.Errorf("table not found")
+		return newMessageError("error.table_not_found", nil)
 	}
 
+	return server.processTableAction(ctx, table, client, actionID, seatIndex, action, amount...)
+}
+
+// processTableAction validates and applies an action for the seat currently on the clock
+// client is the player who sent the action and may be nil for server-initiated actions
+// (e.g. an action timeout), in which case duplicate detection is skipped
+// Each call records a poker.action span in the current hand's trace, linked to ctx
+// Must be called without the table lock held
+func (server *Server) processTableAction(ctx context.Context, table *Table, client *Client, actionID string, seatIndex int, action string, amount ...int) (err error) {
 	// Verify action is valid
-	table.mu.Lock()
+	lockStart, lockWait := table.lockTimed()
 	defer table.mu.Unlock()
 
+	// The action span belongs to the hand's trace; the request that carried it is linked
+	_, span := tracer().Start(table.handContextLocked(), SpanAction,
+		trace.WithTimestamp(lockStart),
+		trace.WithLinks(trace.LinkFromContext(ctx)),
+		trace.WithAttributes(
+			attribute.String("poker.table_id", table.ID),
+			attribute.Int("poker.seat_index", seatIndex),
+			attribute.String("poker.action", action),
+			attribute.String("poker.action_id", actionID),
+			attribute.Bool("poker.timeout", client == nil),
+			lockWaitAttribute(lockWait),
+		),
+	)
+	defer func() {
+		if err != nil {
+			failSpan(span, err)
+		}
+		span.End()
+	}()
+
+	// Resend the original result for actions that were already processed
+	if client != nil && actionID != "" {
+		if result, ok := table.getProcessedActionLocked(client.Token, actionID); ok {
+			span.SetAttributes(attribute.Bool("poker.duplicate", true))
+			server.logger.Info("duplicate action ignored", "tableID", table.ID, "token", client.Token, "actionId", actionID)
+			return client.SendActionResult(result, server.logger)
+		}
+	}
+
 	// Check that a hand is in progress
 	if table.CurrentHand == nil {
-		return fmt.Errorf("no hand in progress")
+		return newMessageError("error.no_hand_in_progress", nil)
 	}
 
 	// Verify it's the player's turn
 	if table.CurrentHand.CurrentActor == nil || *table.CurrentHand.CurrentActor != seatIndex {
-		return fmt.Errorf("not current actor: current actor is %v, player at seat %d", table.CurrentHand.CurrentActor, seatIndex)
+		params := map[string]any{"currentActor": "none", "seatIndex": seatIndex}
+		if table.CurrentHand.CurrentActor != nil {
+			params["currentActor"] = *table.CurrentHand.CurrentActor
+		}
+		return newMessageError("error.not_current_actor", params)
 	}
 
 	// Get valid actions for this player
@@ -1329,15 +1647,28 @@ func (server *Server) HandlePlayerAction(sm *SessionManager, client *Client, sea
 		}
 	}
 	if !isValid {
-		return fmt.Errorf("invalid action '%s' for seat %d: valid actions are %v", action, seatIndex, validActions)
+		return newMessageError("error.invalid_action", map[string]any{"action": action, "seatIndex": seatIndex, "validActions": validActions})
+	}
+
+	// Remember what the player faced for the event published below
+	hand := table.CurrentHand
+	betToCall := hand.CurrentBet - hand.PlayerBets[seatIndex]
+	betBefore := hand.CurrentBet
+	var aggressor string
+	if hand.Aggressor != nil && betToCall > 0 {
+		if token := table.Seats[*hand.Aggressor].Token; token != nil {
+			aggressor = *token
+		}
 	}
 
+	untrackedBefore := hand.untrackedChips()
+
 	// Process the action - pass amount if provided
 	var amountActed int
 	if action == "raise" {
 		// Raise requires an amount
 		if len(amount) == 0 {
-			return fmt.Errorf("raise action requires amount parameter")
+			return newMessageError("error.raise_amount_required", nil)
 		}
 		amountActed, err = table.CurrentHand.ProcessAction(seatIndex, action, table.Seats[seatIndex].Stack, amount[0])
 	} else {
@@ -1352,109 +1683,96 @@ func (server *Server) HandlePlayerAction(sm *SessionManager, client *Client, sea
 	table.Seats[seatIndex].Stack -= amountActed
 	newStack := table.Seats[seatIndex].Stack
 
+	if hand.CurrentBet > betBefore {
+		hand.Aggressor = &seatIndex
+		hand.AggressorStreet = hand.Street
+	}
+	lastAction := SeatAction{Action: action, Amount: hand.PlayerBets[seatIndex], AllIn: newStack == 0}
+	if action == "raise" && betBefore == 0 {
+		lastAction.Action = "bet"
+	}
+	if action == "fold" || action == "check" {
+		lastAction.Amount = 0
+	}
+	hand.recordLastAction(seatIndex, lastAction)
+	var actorToken string
+	if token := table.Seats[seatIndex].Token; token != nil {
+		actorToken = *token
+	}
+	committed := hand.Pot
+	for _, bet := range hand.PlayerBets {
+		committed += bet
+	}
+	// A measured response time is never 0, which would read as not measured
+	var responseTime time.Duration
+	if client != nil && !table.actionRequestedAt.IsZero() {
+		responseTime = max(table.clock().Now().Sub(table.actionRequestedAt), 1)
+	}
+	table.actionRequestedAt = time.Time{}
+	table.publishEvent(Event{
+		Type:         EventPlayerAction,
+		SeatIndex:    seatIndex,
+		Token:        actorToken,
+		Street:       hand.Street,
+		Action:       action,
+		Amount:       amountActed,
+		BetToCall:    betToCall,
+		Pot:          committed,
+		Aggressor:    aggressor,
+		Timeout:      client == nil,
+		StreetBet:    hand.PlayerBets[seatIndex],
+		ResponseTime: responseTime,
+	})
+
+	// The player has acted, so their clock stops
+	table.stopActionClockLocked()
+
+	// A hand whose chips no longer add up cannot be finished safely
+	if untracked := hand.untrackedChips(); untracked != untrackedBefore || newStack < 0 {
+		table.mu.Unlock()
+		table.CancelHand(CancelInvariantBroken, fmt.Errorf("action by seat %d left %d chips unaccounted for and a stack of %d",
+			seatIndex, untracked-untrackedBefore, newStack))
+		table.mu.Lock()
+		return newMessageError("error.hand_cancelled", nil)
+	}
+
+	span.SetAttributes(attribute.Int("poker.amount_acted", amountActed))
+
+	// recordResult builds the action_result payload and remembers it under actionID
+	recordResult := func(nextActor *int, roundOver bool) ActionResultPayload {
+		span.SetAttributes(attribute.Bool("poker.round_over", roundOver))
+		result := ActionResultPayload{
+			ActionID:    actionID,
+			SeatIndex:   seatIndex,
+			Action:      action,
+			AmountActed: amountActed,
+			NewStack:    newStack,
+			Pot:         table.CurrentHand.Pot,
+			NextActor:   nextActor,
+			RoundOver:   roundOver,
+		}
+		if client != nil && actionID != "" {
+			table.recordProcessedActionLocked(client.Token, actionID, result)
+		}
+		return result
+	}
+
 	// Check if betting round is complete
 	if table.CurrentHand.IsBettingRoundComplete(table.Seats) {
 		// Betting round is over - broadcast with no next actor
+		result := recordResult(nil, true)
 		// Temporarily unlock to broadcast
 		table.mu.Unlock()
-		err = server.BroadcastActionResult(
-			table.ID, seatIndex, action, amountActed, newStack, table.CurrentHand.Pot,
-			nil, true, nil,
-		)
+		err = server.broadcastActionResultPayload(table.ID, result)
 		table.mu.Lock()
 		if err != nil {
 			server.logger.Warn("failed to broadcast action_result", "error", err)
 		}
 
-		// Check if only one player remains (all others folded) - early winner
-		nonFoldedCount := 0
-		for i := 0; i < 6; i++ {
-			if table.Seats[i].Status == "active" && !table.CurrentHand.FoldedPlayers[i] {
-				nonFoldedCount++
-			}
-		}
-
-		// If only one player remains, award pot immediately (early winner)
-		if nonFoldedCount <= 1 {
-			table.mu.Unlock()
-			table.HandleShowdown()
-			table.mu.Lock()
-			return nil
-		}
-
-		// Multiple players remain - check if all players are all-in
-		allPlayersAllIn := table.CurrentHand.AreAllActivePlayersAllIn(table.Seats)
-
-		if allPlayersAllIn {
-			// All remaining players are all-in - auto-deal remaining streets and go to showdown
-			server.logger.Info("all players all-in, auto-dealing remaining streets", "currentStreet", table.CurrentHand.Street)
-
-			// Deal all remaining streets without prompting for action
-			for table.CurrentHand != nil && table.CurrentHand.Street != "river" {
-				currentStreet := table.CurrentHand.Street
-				server.logger.Info("auto-advancing from street", "street", currentStreet)
-
-				table.mu.Unlock()
-				err = table.AdvanceToNextStreetWithBroadcast()
-				if err != nil {
-					server.logger.Warn("failed to auto-advance street (all-in)", "error", err)
-				}
-				table.mu.Lock()
-
-				if table.CurrentHand != nil {
-					server.logger.Info("advanced to street", "street", table.CurrentHand.Street, "boardCards", len(table.CurrentHand.BoardCards))
-				}
-			}
-
-			// All streets dealt - trigger showdown
-			if table.CurrentHand != nil {
-				server.logger.Info("calling showdown", "street", table.CurrentHand.Street, "boardCards", len(table.CurrentHand.BoardCards))
-			}
-			table.mu.Unlock()
-			table.HandleShowdown()
-			table.mu.Lock()
-			return nil
-		}
-
-		// Not all players are all-in - normal street advancement with action prompts
-		currentStreet := table.CurrentHand.Street
-		if currentStreet != "river" {
-			// Unlock before calling AdvanceToNextStreetWithBroadcast (long-running operation with broadcasting)
-			table.mu.Unlock()
-			err = table.AdvanceToNextStreetWithBroadcast()
-			if err != nil {
-				server.logger.Warn("failed to advance to next street", "error", err)
-			}
-			table.mu.Lock()
-
-			// After advancing street, set first actor for the new street and request their action
-			// Determine who acts first on the new street
-			if table.CurrentHand != nil {
-				firstActor := table.CurrentHand.GetFirstActor(table.Seats)
-				table.CurrentHand.CurrentActor = &firstActor
-
-				// Get valid actions and call amount for the first actor of the new street
-				nextValidActions := table.CurrentHand.GetValidActions(firstActor, table.Seats[firstActor].Stack, table.Seats)
-				nextCallAmount := table.CurrentHand.GetCallAmount(firstActor)
-
-				// Unlock to broadcast action_request for the new street
-				table.mu.Unlock()
-				err = server.BroadcastActionRequest(
-					table.ID, firstActor, nextValidActions, nextCallAmount,
-					table.CurrentHand.CurrentBet, table.CurrentHand.Pot,
-				)
-				table.mu.Lock()
-				if err != nil {
-					server.logger.Warn("failed to broadcast action_request for new street", "error", err)
-				}
-			}
-		} else {
-			// We're on the river and betting is complete - trigger showdown
-			table.mu.Unlock()
-			table.HandleShowdown()
-			table.mu.Lock()
-		}
-
+		// Hand the flow over to the table: next street, all-in runout or showdown
+		table.mu.Unlock()
+		table.ProgressHand()
+		table.mu.Lock()
 		return nil
 	}
 
@@ -1466,18 +1784,15 @@ func (server *Server) HandlePlayerAction(sm *SessionManager, client *Client, sea
 
 	if nextActor == nil {
 		// Only one player left (all others folded) - award pot immediately
+		result := recordResult(nil, true)
 		table.mu.Unlock()
-		err = server.BroadcastActionResult(
-			table.ID, seatIndex, action, amountActed, newStack, table.CurrentHand.Pot,
-			nil, true, nil,
-		)
+		err = server.broadcastActionResultPayload(table.ID, result)
 		table.mu.Lock()
 		if err != nil {
 			server.logger.Warn("failed to broadcast action_result", "error", err)
 		}
 
 		// Immediately award pot to remaining player (early winner)
-		// HandleShowdown already has correct logic for this (table.go lines 180-211)
 		table.mu.Unlock()
 		table.HandleShowdown()
 		table.mu.Lock()
@@ -1493,12 +1808,10 @@ func (server *Server) HandlePlayerAction(sm *SessionManager, client *Client, sea
 	nextCallAmount := table.CurrentHand.GetCallAmount(*nextActor)
 
 	// Broadcast the action result with the next actor
+	result := recordResult(nextActor, false)
 	// Temporarily unlock to broadcast
 	table.mu.Unlock()
-	err = server.BroadcastActionResult(
-		table.ID, seatIndex, action, amountActed, newStack, table.CurrentHand.Pot,
-		nextActor, false, nil,
-	)
+	err = server.broadcastActionResultPayload(table.ID, result)
 	if err != nil {
 		server.logger.Warn("failed to broadcast action_result", "error", err)
 	}
@@ -1518,18 +1831,31 @@ func (server *Server) HandlePlayerAction(sm *SessionManager, client *Client, sea
 
 // HandlePlayerActionMessage processes a player_action message from the WebSocket
 // This is the entry point that extracts the action from the payload and calls HandlePlayerAction
-func (c *Client) HandlePlayerActionMessage(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+func (c *Client) HandlePlayerActionMessage(ctx context.Context, sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
 	var actionPayload PlayerActionPayload
 	err := json.Unmarshal(payload, &actionPayload)
 	if err != nil {
-		return fmt.Errorf("invalid player_action payload: %w", err)
+		return invalidPayloadError("player_action", err)
+	}
+
+	// Every action must carry a client-generated ID so resends can be detected
+	if actionPayload.ActionID == "" {
+		return fmt.Errorf("missing_action_id")
+	}
+
+	// Large raises wait for the player's confirmation at tables that ask for one
+	if actionPayload.Action == "raise" && actionPayload.Amount != nil {
+		held, err := server.holdLargeRaise(c, actionPayload.ActionID, actionPayload.SeatIndex, *actionPayload.Amount, actionPayload.Confirm)
+		if held || err != nil {
+			return err
+		}
 	}
 
 	// Call the main handler, passing amount if present
 	if actionPayload.Amount != nil {
-		err = server.HandlePlayerAction(sm, c, actionPayload.SeatIndex, actionPayload.Action, *actionPayload.Amount)
+		err = server.HandlePlayerActionWithID(ctx, sm, c, actionPayload.ActionID, actionPayload.SeatIndex, actionPayload.Action, *actionPayload.Amount)
 	} else {
-		err = server.HandlePlayerAction(sm, c, actionPayload.SeatIndex, actionPayload.Action)
+		err = server.HandlePlayerActionWithID(ctx, sm, c, actionPayload.ActionID, actionPayload.SeatIndex, actionPayload.Action)
 	}
 	if err != nil {
 		return err
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
@@ -206,65 +207,6 @@ func TestGetLobbyStateThreadSafety(t *testing.T) {
 	}
 }
 
-// TestFilterHoleCardsForPlayer verifies that filterHoleCardsForPlayer returns only the player's cards
-func TestFilterHoleCardsForPlayer(t *testing.T) {
-	// Create test hole cards - using a map[int][]Card structure (slice, not array)
-	holeCards := map[int][]Card{
-		0: {Card{Rank: "A", Suit: "s"}, Card{Rank: "K", Suit: "h"}},
-		1: {Card{Rank: "Q", Suit: "d"}, Card{Rank: "J", Suit: "c"}},
-		2: {Card{Rank: "T", Suit: "s"}, Card{Rank: "9", Suit: "h"}},
-		3: {Card{Rank: "8", Suit: "d"}, Card{Rank: "7", Suit: "c"}},
-	}
-
-	// Test filtering for player at seat 0
-	filtered := filterHoleCardsForPlayer(holeCards, 0)
-	if len(filtered) != 1 {
-		t.Errorf("expected 1 card entry for player 0, got %d", len(filtered))
-	}
-	if cards, ok := filtered[0]; ok {
-		if len(cards) != 2 {
-			t.Errorf("expected 2 cards for player 0, got %d", len(cards))
-		}
-		if cards[0].Rank != "A" || cards[0].Suit != "s" {
-			t.Errorf("expected As, got %s", cards[0].String())
-		}
-		if cards[1].Rank != "K" || cards[1].Suit != "h" {
-			t.Errorf("expected Kh, got %s", cards[1].String())
-		}
-	} else {
-		t.Error("expected seat 0 in filtered map")
-	}
-
-	// Verify no other seats are present
-	for seat := range filtered {
-		if seat != 0 {
-			t.Errorf("unexpected seat %d in filtered map", seat)
-		}
-	}
-
-	// Test filtering for player at seat 2
-	filtered = filterHoleCardsForPlayer(holeCards, 2)
-	if len(filtered) != 1 {
-		t.Errorf("expected 1 card entry for player 2, got %d", len(filtered))
-	}
-	if cards, ok := filtered[2]; ok {
-		if len(cards) != 2 {
-			t.Errorf("expected 2 cards for player 2, got %d", len(cards))
-		}
-		if cards[0].Rank != "T" || cards[0].Suit != "s" {
-			t.Errorf("expected Ts, got %s", cards[0].String())
-		}
-	} else {
-		t.Error("expected seat 2 in filtered map")
-	}
-
-	// Test filtering for player not in holeCards
-	filtered = filterHoleCardsForPlayer(holeCards, 5)
-	if len(filtered) != 0 {
-		t.Errorf("expected empty map for player 5, got %d entries", len(filtered))
-	}
-}
-
 // TestBroadcastHandStarted verifies hand_started message broadcast with dealer and blind info
 func TestBroadcastHandStarted(t *testing.T) {
 	logger := slog.Default()
@@ -458,6 +400,27 @@ func TestHandleStartHand(t *testing.T) {
 	table.mu.RUnlock()
 }
 
+// TestHandleStartHand_ManualStartDisabled verifies start_hand is rejected when the feature flag is set
+func TestHandleStartHand_ManualStartDisabled(t *testing.T) {
+	logger := slog.Default()
+	server := NewServerWithConfig(logger, Config{Features: FeatureFlags{DisableManualStart: true}})
+	sm := NewSessionManager(logger)
+	table := server.tables[0]
+	seatTwoPlayers(table)
+
+	session, _ := sm.CreateSession("Player1")
+	sm.UpdateSession(session.Token, &table.ID, &[]int{0}[0])
+	client := &Client{hub: server.hub, Token: session.Token, send: make(chan []byte, 256)}
+
+	err := client.HandleStartHand(sm, server, logger, []byte("{}"))
+	if err == nil || err.Error() != "manual_start_disabled" {
+		t.Errorf("expected manual_start_disabled error, got %v", err)
+	}
+	if table.Phase() != PhaseWaitingForPlayers {
+		t.Error("expected no hand to start")
+	}
+}
+
 // TestHandleStartHandNotSeated verifies error when player is not seated
 func TestHandleStartHandNotSeated(t *testing.T) {
 	logger := slog.Default()
@@ -977,6 +940,17 @@ func TestTableStateOmitsGameStateWhenNoHand(t *testing.T) {
 	}
 }
 
+// TestTableStateIncludesBlindLevel verifies table_state carries the table's blinds, hand or no hand
+func TestTableStateIncludesBlindLevel(t *testing.T) {
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+
+	want := BlindLevel{SmallBlind: table.SmallBlind, BigBlind: table.BigBlind}
+	if got := server.buildTableState(table, nil).Blinds; got != want || got.BigBlind == 0 {
+		t.Errorf("expected blinds %+v, got %+v", want, got)
+	}
+}
+
 // TestTableStateGameStateFields verifies dealer, blinds, and pot are correctly set
 func TestTableStateGameStateFields(t *testing.T) {
 	logger := slog.Default()
@@ -2015,6 +1989,7 @@ func TestHandlePlayerAction_RaiseWithAmount(t *testing.T) {
 	// Test that we can marshal a PlayerActionPayload with an Amount field
 	raiseAmount := 50
 	payload := PlayerActionPayload{
+		ActionID:  "action-raise",
 		SeatIndex: 0,
 		Action:    "raise",
 		Amount:    &raiseAmount,
@@ -2025,7 +2000,7 @@ func TestHandlePlayerAction_RaiseWithAmount(t *testing.T) {
 	}
 
 	// Test the message handler which should extract the amount
-	err = client.HandlePlayerActionMessage(sm, server, logger, payloadBytes)
+	err = client.HandlePlayerActionMessage(context.Background(), sm, server, logger, payloadBytes)
 	if err != nil {
 		t.Errorf("expected no error for valid raise with amount, got %v", err)
 	}
@@ -2093,6 +2068,7 @@ func TestHandlePlayerAction_RaiseMissingAmount(t *testing.T) {
 
 	// Create a raise action without amount
 	payload := PlayerActionPayload{
+		ActionID:  "action-raise",
 		SeatIndex: 0,
 		Action:    "raise",
 		Amount:    nil, // Missing amount
@@ -2102,7 +2078,7 @@ func TestHandlePlayerAction_RaiseMissingAmount(t *testing.T) {
 		t.Fatalf("failed to marshal payload: %v", err)
 	}
 
-	err = client.HandlePlayerActionMessage(sm, server, logger, payloadBytes)
+	err = client.HandlePlayerActionMessage(context.Background(), sm, server, logger, payloadBytes)
 	if err == nil {
 		t.Error("expected error for raise without amount, got nil")
 	}
@@ -2539,9 +2515,9 @@ func TestBroadcastBoardDealt_IncludesCorrectBoardCards(t *testing.T) {
 			}
 
 			for i, card := range payload.BoardCards {
-				if card.Rank != boardCards[i].Rank || card.Suit != boardCards[i].Suit {
+				if card.RankLetter() != boardCards[i].RankLetter() || card.SuitLetter() != boardCards[i].SuitLetter() {
 					t.Errorf("card %d mismatch: expected %s%s, got %s%s", i,
-						boardCards[i].Rank, boardCards[i].Suit, card.Rank, card.Suit)
+						boardCards[i].RankLetter(), boardCards[i].SuitLetter(), card.RankLetter(), card.SuitLetter())
 				}
 			}
 		default:
@@ -2803,7 +2779,7 @@ func TestHandlerFlow_RiverToShowdown(t *testing.T) {
 		logger: slog.Default(),
 	}
 	table := NewTable("table-1", "Test Table", server)
-	server.tables[0] = table
+	server.tables = []*Table{table}
 
 	// Setup: Create a hand already at river with 2 active players
 	hand := &Hand{
@@ -2813,12 +2789,12 @@ func TestHandlerFlow_RiverToShowdown(t *testing.T) {
 		BigBlindSeat:   0, // 2-player game
 		Street:         "river",
 		HoleCards: map[int][]Card{
-			0: {Card{Rank: "A", Suit: "s"}, Card{Rank: "K", Suit: "s"}},
-			1: {Card{Rank: "Q", Suit: "h"}, Card{Rank: "J", Suit: "h"}},
+			0: {NewCard("A", "s"), NewCard("K", "s")},
+			1: {NewCard("Q", "h"), NewCard("J", "h")},
 		},
 		BoardCards: []Card{
-			{Rank: "T", Suit: "d"}, {Rank: "9", Suit: "c"}, {Rank: "8", Suit: "s"},
-			{Rank: "7", Suit: "h"}, {Rank: "6", Suit: "d"},
+			NewCard("T", "d"), NewCard("9", "c"), NewCard("8", "s"),
+			NewCard("7", "h"), NewCard("6", "d"),
 		},
 		FoldedPlayers: make(map[int]bool),
 		ActedPlayers:  make(map[int]bool),
@@ -2872,7 +2848,7 @@ func TestHandlerFlow_AllFoldBeforeShowdown(t *testing.T) {
 		logger: slog.Default(),
 	}
 	table := NewTable("table-1", "Test Table", server)
-	server.tables[0] = table
+	server.tables = []*Table{table}
 
 	// Setup: Create a hand at flop where all but one player folded
 	hand := &Hand{
@@ -2882,12 +2858,12 @@ func TestHandlerFlow_AllFoldBeforeShowdown(t *testing.T) {
 		BigBlindSeat:   2,
 		Street:         "flop",
 		HoleCards: map[int][]Card{
-			0: {Card{Rank: "A", Suit: "s"}, Card{Rank: "K", Suit: "s"}},
-			1: {Card{Rank: "2", Suit: "h"}, Card{Rank: "3", Suit: "h"}},
-			2: {Card{Rank: "4", Suit: "d"}, Card{Rank: "5", Suit: "d"}},
+			0: {NewCard("A", "s"), NewCard("K", "s")},
+			1: {NewCard("2", "h"), NewCard("3", "h")},
+			2: {NewCard("4", "d"), NewCard("5", "d")},
 		},
 		BoardCards: []Card{
-			{Rank: "6", Suit: "c"}, {Rank: "7", Suit: "s"}, {Rank: "8", Suit: "h"},
+			NewCard("6", "c"), NewCard("7", "s"), NewCard("8", "h"),
 		},
 		FoldedPlayers: map[int]bool{
 			1: true, // Seat 1 folded
@@ -3254,12 +3230,12 @@ func TestHandleAction_RiverBettingCompleteTriggersShowdown(t *testing.T) {
 		BigBlindSeat:   0,
 		Street:         "river",
 		HoleCards: map[int][]Card{
-			0: {Card{Rank: "A", Suit: "s"}, Card{Rank: "K", Suit: "s"}},
-			1: {Card{Rank: "Q", Suit: "h"}, Card{Rank: "J", Suit: "h"}},
+			0: {NewCard("A", "s"), NewCard("K", "s")},
+			1: {NewCard("Q", "h"), NewCard("J", "h")},
 		},
 		BoardCards: []Card{
-			{Rank: "T", Suit: "d"}, {Rank: "9", Suit: "c"}, {Rank: "8", Suit: "s"},
-			{Rank: "7", Suit: "h"}, {Rank: "6", Suit: "d"},
+			NewCard("T", "d"), NewCard("9", "c"), NewCard("8", "s"),
+			NewCard("7", "h"), NewCard("6", "d"),
 		},
 		FoldedPlayers: make(map[int]bool),
 		ActedPlayers:  make(map[int]bool),
@@ -3364,12 +3340,12 @@ func TestHandleAction_RiverNoShowdownIfNotComplete(t *testing.T) {
 		BigBlindSeat:   0,
 		Street:         "river",
 		HoleCards: map[int][]Card{
-			0: {Card{Rank: "A", Suit: "s"}, Card{Rank: "K", Suit: "s"}},
-			1: {Card{Rank: "Q", Suit: "h"}, Card{Rank: "J", Suit: "h"}},
+			0: {NewCard("A", "s"), NewCard("K", "s")},
+			1: {NewCard("Q", "h"), NewCard("J", "h")},
 		},
 		BoardCards: []Card{
-			{Rank: "T", Suit: "d"}, {Rank: "9", Suit: "c"}, {Rank: "8", Suit: "s"},
-			{Rank: "7", Suit: "h"}, {Rank: "6", Suit: "d"},
+			NewCard("T", "d"), NewCard("9", "c"), NewCard("8", "s"),
+			NewCard("7", "h"), NewCard("6", "d"),
 		},
 		FoldedPlayers: make(map[int]bool),
 		ActedPlayers:  make(map[int]bool),
@@ -3586,9 +3562,9 @@ func TestHandlePlayerAction_AllFoldFlop_EarlyWinner(t *testing.T) {
 	table.mu.Lock()
 	table.CurrentHand.Street = "flop"
 	table.CurrentHand.BoardCards = []Card{
-		{Rank: "A", Suit: "s"},
-		{Rank: "K", Suit: "h"},
-		{Rank: "Q", Suit: "d"},
+		NewCard("A", "s"),
+		NewCard("K", "h"),
+		NewCard("Q", "d"),
 	}
 	// Mark two players as folded, only player 2 remains
 	table.CurrentHand.FoldedPlayers[0] = true
@@ -3688,10 +3664,10 @@ func TestHandlePlayerAction_AllFoldTurn_EarlyWinner(t *testing.T) {
 	table.mu.Lock()
 	table.CurrentHand.Street = "turn"
 	table.CurrentHand.BoardCards = []Card{
-		{Rank: "A", Suit: "s"},
-		{Rank: "K", Suit: "h"},
-		{Rank: "Q", Suit: "d"},
-		{Rank: "J", Suit: "c"},
+		NewCard("A", "s"),
+		NewCard("K", "h"),
+		NewCard("Q", "d"),
+		NewCard("J", "c"),
 	}
 	// Mark two players as folded, only player 2 remains
 	table.CurrentHand.FoldedPlayers[0] = true
@@ -3791,11 +3767,11 @@ func TestHandlePlayerAction_AllFoldRiver_EarlyWinner(t *testing.T) {
 	table.mu.Lock()
 	table.CurrentHand.Street = "river"
 	table.CurrentHand.BoardCards = []Card{
-		{Rank: "A", Suit: "s"},
-		{Rank: "K", Suit: "h"},
-		{Rank: "Q", Suit: "d"},
-		{Rank: "J", Suit: "c"},
-		{Rank: "T", Suit: "s"},
+		NewCard("A", "s"),
+		NewCard("K", "h"),
+		NewCard("Q", "d"),
+		NewCard("J", "c"),
+		NewCard("T", "s"),
 	}
 	// Mark two players as folded, only player 2 remains
 	table.CurrentHand.FoldedPlayers[0] = true
@@ -3854,3 +3830,229 @@ func newInt(i int) *int {
 func newString(s string) *string {
 	return &s
 }
+
+// TestHandlePlayerActionWithID_DuplicateReturnsOriginalResult verifies a resent action ID is not applied twice
+func TestHandlePlayerActionWithID_DuplicateReturnsOriginalResult(t *testing.T) {
+	logger := slog.Default()
+	server := NewServer(logger)
+	sm := NewSessionManager(logger)
+	hub := server.hub
+
+	table := server.tables[0]
+
+	session1, _ := sm.CreateSession("Player1")
+	session2, _ := sm.CreateSession("Player2")
+	token1 := session1.Token
+	token2 := session2.Token
+	sm.UpdateSession(token1, &table.ID, newInt(0))
+	sm.UpdateSession(token2, &table.ID, newInt(1))
+
+	table.mu.Lock()
+	table.Seats[0].Token = &token1
+	table.Seats[0].Status = "active"
+	table.Seats[0].Stack = 1000
+	table.Seats[1].Token = &token2
+	table.Seats[1].Status = "active"
+	table.Seats[1].Stack = 1000
+	table.mu.Unlock()
+
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("failed to start hand: %v", err)
+	}
+
+	client := &Client{
+		hub:   hub,
+		Token: token1,
+		send:  make(chan []byte, 256),
+	}
+
+	if err := server.HandlePlayerActionWithID(context.Background(), sm, client, "action-1", 0, "call"); err != nil {
+		t.Fatalf("expected no error for first call, got %v", err)
+	}
+
+	table.mu.RLock()
+	stackAfterFirst := table.Seats[0].Stack
+	table.mu.RUnlock()
+
+	// Resend the same action ID - must not move chips again
+	if err := server.HandlePlayerActionWithID(context.Background(), sm, client, "action-1", 0, "call"); err != nil {
+		t.Fatalf("expected no error for duplicate call, got %v", err)
+	}
+
+	table.mu.RLock()
+	stackAfterDuplicate := table.Seats[0].Stack
+	table.mu.RUnlock()
+	if stackAfterDuplicate != stackAfterFirst {
+		t.Errorf("expected stack to stay %d after duplicate, got %d", stackAfterFirst, stackAfterDuplicate)
+	}
+
+	// The duplicate must be answered with the original action_result
+	select {
+	case msg := <-client.send:
+		var wsMsg WebSocketMessage
+		if err := json.Unmarshal(msg, &wsMsg); err != nil {
+			t.Fatalf("failed to unmarshal message: %v", err)
+		}
+		if wsMsg.Type != "action_result" {
+			t.Fatalf("expected action_result, got %q", wsMsg.Type)
+		}
+		var payload ActionResultPayload
+		if err := json.Unmarshal(wsMsg.Payload, &payload); err != nil {
+			t.Fatalf("failed to unmarshal payload: %v", err)
+		}
+		if payload.ActionID != "action-1" {
+			t.Errorf("expected actionId 'action-1', got %q", payload.ActionID)
+		}
+		if payload.Action != "call" || payload.AmountActed != 10 {
+			t.Errorf("expected original call of 10, got %s of %d", payload.Action, payload.AmountActed)
+		}
+		if payload.NewStack != stackAfterFirst {
+			t.Errorf("expected newStack %d, got %d", stackAfterFirst, payload.NewStack)
+		}
+	default:
+		t.Error("expected action_result to be resent for duplicate action")
+	}
+}
+
+// TestHandlePlayerActionWithID_DuplicateDoesNotBlock verifies a duplicate action from a client
+// that is not reading its messages is answered without holding the table lock
+func TestHandlePlayerActionWithID_DuplicateDoesNotBlock(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	actor := currentActor(table)
+	client := clients[actor]
+	if err := server.HandlePlayerActionWithID(context.Background(), server.sessionManager, client, "action-1", actor, "call"); err != nil {
+		t.Fatal(err)
+	}
+	for len(client.send) < cap(client.send) {
+		client.send <- nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- server.HandlePlayerActionWithID(context.Background(), server.sessionManager, client, "action-1", actor, "call")
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected the duplicate ignored, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the duplicate answered without waiting on a full send queue")
+	}
+	if !table.mu.TryLock() {
+		t.Fatal("expected the table lock released")
+	}
+	table.mu.Unlock()
+}
+
+// TestHandlePlayerActionWithID_IDsScopedPerHand verifies processed IDs are forgotten when a new hand starts
+func TestHandlePlayerActionWithID_IDsScopedPerHand(t *testing.T) {
+	logger := slog.Default()
+	server := NewServer(logger)
+
+	table := server.tables[0]
+	table.recordProcessedActionLocked("token-1", "action-1", ActionResultPayload{ActionID: "action-1"})
+
+	if _, ok := table.getProcessedActionLocked("token-1", "action-1"); !ok {
+		t.Fatal("expected recorded action to be found")
+	}
+	if _, ok := table.getProcessedActionLocked("token-2", "action-1"); ok {
+		t.Error("expected action IDs to be scoped per token")
+	}
+
+	token1 := "token-1"
+	token2 := "token-2"
+	table.Seats[0].Token = &token1
+	table.Seats[0].Status = "active"
+	table.Seats[0].Stack = 1000
+	table.Seats[1].Token = &token2
+	table.Seats[1].Status = "active"
+	table.Seats[1].Stack = 1000
+
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("failed to start hand: %v", err)
+	}
+
+	if _, ok := table.getProcessedActionLocked("token-1", "action-1"); ok {
+		t.Error("expected processed actions to be reset by StartHand")
+	}
+}
+
+// TestHandlePlayerActionMessage_RequiresActionID verifies player_action messages without an ID are rejected
+func TestHandlePlayerActionMessage_RequiresActionID(t *testing.T) {
+	logger := slog.Default()
+	server := NewServer(logger)
+	sm := NewSessionManager(logger)
+
+	client := &Client{
+		hub:  server.hub,
+		send: make(chan []byte, 256),
+	}
+
+	payload, _ := json.Marshal(PlayerActionPayload{SeatIndex: 0, Action: "fold"})
+	err := client.HandlePlayerActionMessage(context.Background(), sm, server, logger, payload)
+	if err == nil || err.Error() != "missing_action_id" {
+		t.Errorf("expected missing_action_id error, got %v", err)
+	}
+}
+
+// TestTableState_LastActions verifies table_state carries each seat's latest action on the
+// street, starting with the blinds, and that only folds survive into the next street
+func TestTableState_LastActions(t *testing.T) {
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+	tokens := []string{"player1", "player2", "player3"}
+	table.mu.Lock()
+	for i := range tokens {
+		table.Seats[i] = Seat{Index: i, Token: &tokens[i], Status: "active", Stack: 1000}
+	}
+	table.mu.Unlock()
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	lastActions := func() map[int]SeatAction {
+		actions := make(map[int]SeatAction)
+		for _, seat := range server.buildTableState(table, nil).Seats {
+			if seat.LastAction != nil {
+				actions[seat.Index] = *seat.LastAction
+			}
+		}
+		return actions
+	}
+
+	table.mu.RLock()
+	sbSeat, bbSeat := table.CurrentHand.SmallBlindSeat, table.CurrentHand.BigBlindSeat
+	raiser := *table.CurrentHand.CurrentActor
+	table.mu.RUnlock()
+	got := lastActions()
+	if got[sbSeat] != (SeatAction{Action: "small_blind", Amount: 10}) || got[bbSeat] != (SeatAction{Action: "big_blind", Amount: 20}) || len(got) != 2 {
+		t.Fatalf("expected only the blinds before any action, got %+v", got)
+	}
+
+	actCurrent(t, server, table, "raise", 60)
+	folder := currentActor(table)
+	actCurrent(t, server, table, "fold")
+	if got = lastActions(); got[raiser] != (SeatAction{Action: "raise", Amount: 60}) || got[folder] != (SeatAction{Action: "fold"}) {
+		t.Fatalf("expected the raise to 60 and the fold, got %+v", got)
+	}
+	actCurrent(t, server, table, "call")
+
+	if !waitForStreet(table, "flop", time.Second) {
+		t.Fatal("expected the flop to be dealt")
+	}
+	got = lastActions()
+	if len(got) != 1 || got[folder] != (SeatAction{Action: "fold"}) {
+		t.Fatalf("expected only the fold to carry over to the flop, got %+v", got)
+	}
+
+	for currentActor(table) < 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+	bettor := currentActor(table)
+	actCurrent(t, server, table, "raise", 40)
+	got = lastActions()
+	if got[bettor] != (SeatAction{Action: "bet", Amount: 40}) {
+		t.Errorf("expected the first raise on the flop to read as a bet, got %+v", got[bettor])
+	}
+}
@@ -0,0 +1,320 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// rematchWindow is how long the loser of a heads-up match has to accept a rematch before the
+// seat opens to anyone
+const rematchWindow = 30 * time.Second
+
+// What happens to the freed seat after a heads-up match, reported in MatchResultPayload.Next
+const (
+	MatchNextRematch    = "rematch"    // No challenger is queued: the loser is offered a rematch
+	MatchNextChallenger = "challenger" // The next challenger in the queue takes the seat
+)
+
+// challenger is a player queued for a seat at a heads-up table
+type challenger struct {
+	token    string
+	remoteIP string
+}
+
+// rematchOffer holds a heads-up table's open seat for the player who just lost there
+type rematchOffer struct {
+	token     string
+	expiresAt time.Time
+	timer     ClockTimer
+}
+
+// matchEnd is the outcome of a heads-up match, collected under the table lock and announced after
+type matchEnd struct {
+	winnerSeat  int
+	winnerToken string
+	loserToken  string
+	offer       *rematchOffer // Nil when a challenger takes the seat
+}
+
+// ChallengePayload represents the payload for challenge messages
+type ChallengePayload struct {
+	TableID string `json:"tableId"`
+}
+
+// ChallengeStatusPayload represents the payload for challenge_status messages
+type ChallengeStatusPayload struct {
+	TableID  string `json:"tableId"`
+	Position int    `json:"position"` // 1-based place in the table's challenger queue; 0 once left
+}
+
+// MatchResultPayload represents the payload for match_result messages, broadcast when a
+// heads-up match ends with a player busting
+type MatchResultPayload struct {
+	TableID    string `json:"tableId"`
+	WinnerSeat int    `json:"winnerSeat"`
+	Winner     string `json:"winner"`
+	Loser      string `json:"loser"`
+	Next       string `json:"next"` // MatchNextRematch or MatchNextChallenger
+}
+
+// RematchOfferPayload represents the payload for rematch_offer messages, sent to the loser
+type RematchOfferPayload struct {
+	TableID   string `json:"tableId"`
+	Opponent  string `json:"opponent"`
+	BuyIn     int    `json:"buyIn"`
+	ExpiresAt int64  `json:"expiresAt"` // Unix ms
+}
+
+// RematchPayload represents the payload for rematch messages
+type RematchPayload struct {
+	Accept bool `json:"accept"`
+}
+
+// seatOpenToLocked reports whether token may take an empty seat: while a rematch is offered,
+// the open seat is held for the loser (caller must hold t.mu)
+func (t *Table) seatOpenToLocked(token string) bool {
+	return !t.closed && (t.rematch == nil || t.rematch.token == token)
+}
+
+// clearRematchLocked withdraws the table's rematch offer, if any (caller must hold t.mu)
+func (t *Table) clearRematchLocked() {
+	if t.rematch != nil {
+		t.rematch.timer.Stop()
+		t.rematch = nil
+	}
+}
+
+// challengerPositionLocked returns token's 1-based place in the challenger queue, or 0
+// (caller must hold t.mu)
+func (t *Table) challengerPositionLocked(token string) int {
+	return slices.IndexFunc(t.challengers, func(c challenger) bool { return c.token == token }) + 1
+}
+
+// challengerCount returns how many players are queued to challenge at the table
+func (t *Table) challengerCount() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.challengers)
+}
+
+// endMatchLocked records the end of a heads-up match in which loserToken busted: the seat goes
+// to the next challenger, or is held for the loser's rematch when nobody is queued
+// Returns nil when the table is not heads-up or nobody is left to have won (caller must hold t.mu)
+func (t *Table) endMatchLocked(loserToken string) *matchEnd {
+	if !t.HeadsUp {
+		return nil
+	}
+	match := &matchEnd{winnerSeat: -1, loserToken: loserToken}
+	for i := 0; i < t.MaxSeats; i++ {
+		if t.Seats[i].Token != nil && t.Seats[i].Status != "reserved" {
+			match.winnerSeat = i
+			match.winnerToken = *t.Seats[i].Token
+		}
+	}
+	if match.winnerSeat < 0 {
+		return nil
+	}
+
+	t.clearRematchLocked()
+	if len(t.challengers) == 0 && t.Server != nil {
+		offer := &rematchOffer{token: loserToken, expiresAt: t.clock().Now().Add(rematchWindow)}
+		offer.timer = t.clock().AfterFunc(rematchWindow, func() { t.Server.rematchExpired(t, offer) })
+		t.rematch = offer
+		match.offer = offer
+	}
+	return match
+}
+
+// announceMatchEnd tells the table who won the heads-up match, offers the loser a rematch or
+// seats the next challenger
+// Assumes the table lock has already been released
+func (s *Server) announceMatchEnd(table *Table, match *matchEnd) {
+	winner, _ := s.displayName(table.ID, match.winnerToken)
+	loser, _ := s.displayName(table.ID, match.loserToken)
+	result := MatchResultPayload{
+		TableID:    table.ID,
+		WinnerSeat: match.winnerSeat,
+		Winner:     winner,
+		Loser:      loser,
+		Next:       MatchNextChallenger,
+	}
+	if match.offer != nil {
+		result.Next = MatchNextRematch
+	}
+	if err := s.broadcastTableMessage(table, "match_result", result); err != nil {
+		s.logger.Warn("failed to broadcast match_result", "tableID", table.ID, "error", err)
+	}
+	s.logger.Info("heads-up match ended", "tableID", table.ID, "winner", result.Winner, "loser", result.Loser, "next", result.Next)
+
+	if match.offer != nil {
+		s.sendPrivate(match.loserToken, "rematch_offer", RematchOfferPayload{
+			TableID:   table.ID,
+			Opponent:  result.Winner,
+			BuyIn:     table.BuyIn,
+			ExpiresAt: match.offer.expiresAt.UnixMilli(),
+		})
+		return
+	}
+	s.fillChallengers(table)
+}
+
+// rematchExpired opens the seat held by offer once the loser has let it lapse
+func (s *Server) rematchExpired(table *Table, offer *rematchOffer) {
+	table.mu.Lock()
+	if table.rematch != offer {
+		table.mu.Unlock()
+		return
+	}
+	table.rematch = nil
+	table.mu.Unlock()
+
+	s.logger.Info("rematch offer expired", "token", offer.token, "tableID", table.ID)
+	s.fillFromWaitlist(table.ID)
+}
+
+// fillChallengers seats queued challengers at a heads-up table, in queue order, while it has room
+// Challengers who cannot be seated lose their place
+func (s *Server) fillChallengers(table *Table) {
+	for {
+		table.mu.Lock()
+		open := false
+		for i := 0; i < table.MaxSeats; i++ {
+			open = open || table.Seats[i].Token == nil
+		}
+		if !open || table.rematch != nil || len(table.challengers) == 0 {
+			table.mu.Unlock()
+			return
+		}
+		next := table.challengers[0]
+		table.challengers = table.challengers[1:]
+		table.mu.Unlock()
+
+		seat, err := s.seatPlayer(next.token, next.remoteIP, table)
+		if err != nil {
+			s.logger.Debug("challenger not seated", "token", next.token, "tableID", table.ID, "error", err)
+			if errors.Is(err, errTableFull) {
+				// Taken since the check; keep the challenger's place
+				table.mu.Lock()
+				table.challengers = slices.Insert(table.challengers, 0, next)
+				table.mu.Unlock()
+				return
+			}
+			s.sendPrivate(next.token, "challenge_status", ChallengeStatusPayload{TableID: table.ID})
+			continue
+		}
+		s.logger.Info("challenger seated", "token", next.token, "tableID", table.ID, "seatIndex", seat.Index)
+		s.announceSeat(table, next.token, seat)
+	}
+}
+
+// removeChallenger takes token off every table's challenger queue
+func (s *Server) removeChallenger(token string) bool {
+	removed := false
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, table := range s.tables {
+		table.mu.Lock()
+		if i := table.challengerPositionLocked(token) - 1; i >= 0 {
+			table.challengers = slices.Delete(table.challengers, i, i+1)
+			removed = true
+		}
+		table.mu.Unlock()
+	}
+	return removed
+}
+
+// HandleChallenge processes a challenge message: the player takes the open seat at a heads-up
+// table, or joins its challenger queue to play the winner of the current match
+func (c *Client) HandleChallenge(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+	var req ChallengePayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return invalidPayloadError("challenge", err)
+	}
+	if _, err := sm.GetSession(c.Token); err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+	table := server.tableByID(req.TableID)
+	if table == nil {
+		return newMessageError("error.table_not_found", nil)
+	}
+	if !table.HeadsUp {
+		return newMessageError("error.not_heads_up", nil)
+	}
+	if server.FindPlayerSeat(&c.Token) != nil {
+		return fmt.Errorf("already_seated")
+	}
+
+	// A player challenges one table at a time
+	server.removeChallenger(c.Token)
+	table.mu.Lock()
+	table.challengers = append(table.challengers, challenger{token: c.Token, remoteIP: c.RemoteIP})
+	position := len(table.challengers)
+	table.mu.Unlock()
+
+	logger.Info("challenger queued", "token", c.Token, "tableID", table.ID, "position", position)
+	if err := c.sendMessage("challenge_status", ChallengeStatusPayload{TableID: table.ID, Position: position}); err != nil {
+		return err
+	}
+	// Seats the challenger straight away if the table has room
+	server.fillChallengers(table)
+	return nil
+}
+
+// HandleLeaveChallenge processes a leave_challenge message
+func (c *Client) HandleLeaveChallenge(server *Server, logger *slog.Logger) error {
+	if !server.removeChallenger(c.Token) {
+		return newMessageError("error.not_challenging", nil)
+	}
+	logger.Info("challenger left the queue", "token", c.Token)
+	return c.sendMessage("challenge_status", ChallengeStatusPayload{})
+}
+
+// HandleRematch processes a rematch message: the loser of a heads-up match takes the seat held
+// for them, or declines and opens it to anyone
+func (c *Client) HandleRematch(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+	var req RematchPayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return invalidPayloadError("rematch", err)
+	}
+	if _, err := sm.GetSession(c.Token); err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+
+	var table *Table
+	server.mu.RLock()
+	for _, t := range server.tables {
+		t.mu.RLock()
+		if t.rematch != nil && t.rematch.token == c.Token {
+			table = t
+		}
+		t.mu.RUnlock()
+	}
+	server.mu.RUnlock()
+	if table == nil {
+		return newMessageError("error.no_rematch", nil)
+	}
+
+	if !req.Accept {
+		table.mu.Lock()
+		if table.rematch != nil && table.rematch.token == c.Token {
+			table.clearRematchLocked()
+		}
+		table.mu.Unlock()
+		logger.Info("rematch declined", "token", c.Token, "tableID", table.ID)
+		server.fillFromWaitlist(table.ID)
+		return nil
+	}
+
+	// Seating the loser withdraws the offer
+	seat, err := server.seatPlayer(c.Token, c.RemoteIP, table)
+	if err != nil {
+		return err
+	}
+	logger.Info("rematch accepted", "token", c.Token, "tableID", table.ID, "seatIndex", seat.Index)
+	server.announceSeat(table, c.Token, seat)
+	return nil
+}
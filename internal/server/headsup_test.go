@@ -0,0 +1,165 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// newHeadsUpServer returns a server whose only table is a heads-up table, on a fake clock
+func newHeadsUpServer() (*Server, *Table, *fakeClock) {
+	server := NewServerWithConfig(slog.Default(), Config{
+		Tables: []TableConfig{{Name: "Duel", SmallBlind: 10, BigBlind: 20, BuyIn: 1000, HeadsUp: true}},
+	})
+	return server, server.tables[0], useFakeClock(server)
+}
+
+// challengeWith creates a session named name and sends challenge for table
+func challengeWith(t *testing.T, server *Server, table *Table, name string) *Client {
+	t.Helper()
+	session, _ := server.sessionManager.CreateSession(name)
+	client := connectTestClient(server, session.Token)
+	payload, _ := json.Marshal(ChallengePayload{TableID: table.ID})
+	if err := client.HandleChallenge(server.sessionManager, server, slog.Default(), payload); err != nil {
+		t.Fatalf("challenge as %s: %v", name, err)
+	}
+	return client
+}
+
+// bustCurrentActor deals a hand in which the player first to act folds with nothing behind
+// Returns the busted player's token
+func bustCurrentActor(t *testing.T, server *Server, table *Table) string {
+	t.Helper()
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	table.mu.Lock()
+	actor := *table.CurrentHand.CurrentActor
+	table.Seats[actor].Stack = 0
+	table.CurrentHand.FoldedPlayers[actor] = true
+	loser := *table.Seats[actor].Token
+	table.mu.Unlock()
+	table.HandleShowdown()
+	return loser
+}
+
+// seatedTokens returns the tokens seated at table
+func seatedTokens(table *Table) []string {
+	table.mu.RLock()
+	defer table.mu.RUnlock()
+	var tokens []string
+	for _, seat := range table.Seats {
+		if seat.Token != nil {
+			tokens = append(tokens, *seat.Token)
+		}
+	}
+	return tokens
+}
+
+// TestHeadsUp_ChallengerTakesLosersSeat verifies challengers queue for a full heads-up table
+// and the first of them replaces the player who busts
+func TestHeadsUp_ChallengerTakesLosersSeat(t *testing.T) {
+	server, table, _ := newHeadsUpServer()
+	alice := challengeWith(t, server, table, "Alice")
+	bob := challengeWith(t, server, table, "Bob")
+	if seated := seatedTokens(table); len(seated) != 2 {
+		t.Fatalf("expected both challengers seated, got %v", seated)
+	}
+	if info, _ := server.lobbyInfo(table.ID); info.MaxSeats != 2 || !info.HeadsUp {
+		t.Errorf("expected a two-seat heads-up table in the lobby, got %+v", info)
+	}
+
+	carol := challengeWith(t, server, table, "Carol")
+	if !strings.Contains(strings.Join(drainRawMessages(carol), "\n"), `"position":1`) {
+		t.Error("expected carol to be told she is first in the challenger queue")
+	}
+	if info, _ := server.lobbyInfo(table.ID); info.Challengers != 1 {
+		t.Errorf("expected one challenger in the lobby, got %d", info.Challengers)
+	}
+	drainRawMessages(alice)
+	drainRawMessages(bob)
+
+	loser := bustCurrentActor(t, server, table)
+	winner := alice
+	if loser == alice.Token {
+		winner = bob
+	}
+	seated := seatedTokens(table)
+	if len(seated) != 2 || !strings.Contains(strings.Join(seated, ","), carol.Token) || strings.Contains(strings.Join(seated, ","), loser) {
+		t.Fatalf("expected carol to replace the loser, got %v", seated)
+	}
+	if !strings.Contains(strings.Join(drainRawMessages(winner), "\n"), `"next":"challenger"`) {
+		t.Error("expected a match_result announcing the next challenger")
+	}
+}
+
+// TestHeadsUp_Rematch verifies the loser is offered a rematch when nobody is queued, and the
+// seat is held for them until they accept
+func TestHeadsUp_Rematch(t *testing.T) {
+	server, table, _ := newHeadsUpServer()
+	alice := challengeWith(t, server, table, "Alice")
+	bob := challengeWith(t, server, table, "Bob")
+	loser := bustCurrentActor(t, server, table)
+	loserClient := alice
+	if loser == bob.Token {
+		loserClient = bob
+	}
+	if !strings.Contains(strings.Join(drainRawMessages(loserClient), "\n"), `"type":"rematch_offer"`) {
+		t.Fatal("expected the loser to be offered a rematch")
+	}
+
+	carol, _ := server.sessionManager.CreateSession("Carol")
+	if _, err := server.seatPlayer(carol.Token, "", table); !errors.Is(err, errTableFull) {
+		t.Fatalf("expected the seat to be held for the rematch, got %v", err)
+	}
+
+	payload, _ := json.Marshal(RematchPayload{Accept: true})
+	if err := loserClient.HandleRematch(server.sessionManager, server, slog.Default(), payload); err != nil {
+		t.Fatal(err)
+	}
+	if seated := seatedTokens(table); len(seated) != 2 || !strings.Contains(strings.Join(seated, ","), loser) {
+		t.Fatalf("expected the loser back in their seat, got %v", seated)
+	}
+	if err := loserClient.HandleRematch(server.sessionManager, server, slog.Default(), payload); err == nil {
+		t.Error("expected a second rematch to fail without an offer")
+	}
+}
+
+// TestHeadsUp_RematchExpires verifies an unanswered rematch offer lapses and the seat goes to
+// the challenger who queued meanwhile
+func TestHeadsUp_RematchExpires(t *testing.T) {
+	server, table, clock := newHeadsUpServer()
+	challengeWith(t, server, table, "Alice")
+	challengeWith(t, server, table, "Bob")
+	loser := bustCurrentActor(t, server, table)
+
+	carol := challengeWith(t, server, table, "Carol")
+	if seated := seatedTokens(table); len(seated) != 1 {
+		t.Fatalf("expected carol to wait while the rematch is offered, got %v", seated)
+	}
+
+	clock.Advance(rematchWindow)
+	seated := seatedTokens(table)
+	if len(seated) != 2 || !strings.Contains(strings.Join(seated, ","), carol.Token) || strings.Contains(strings.Join(seated, ","), loser) {
+		t.Fatalf("expected carol seated once the offer lapsed, got %v", seated)
+	}
+}
+
+// TestHandleChallenge_Errors verifies challenges are refused away from heads-up tables and
+// leaving the queue needs a place in it
+func TestHandleChallenge_Errors(t *testing.T) {
+	server := NewServer(slog.Default())
+	session, _ := server.sessionManager.CreateSession("Alice")
+	client := connectTestClient(server, session.Token)
+
+	var msgErr *MessageError
+	payload, _ := json.Marshal(ChallengePayload{TableID: server.tables[0].ID})
+	if err := client.HandleChallenge(server.sessionManager, server, slog.Default(), payload); !errors.As(err, &msgErr) || msgErr.Key != "error.not_heads_up" {
+		t.Errorf("expected error.not_heads_up, got %v", err)
+	}
+	if err := client.HandleLeaveChallenge(server, slog.Default()); !errors.As(err, &msgErr) || msgErr.Key != "error.not_challenging" {
+		t.Errorf("expected error.not_challenging, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"slices"
+)
+
+// tableHistoryLength is how many hand summaries each table keeps for its history panel
+const tableHistoryLength = 20
+
+// HandSummary is one line of a table's hand history: who won how much with what
+type HandSummary struct {
+	HandID      string          `json:"handId"`  // Replayable from /api/replays/{handId}
+	EndedAt     int64           `json:"endedAt"` // Unix ms
+	Winners     []SummaryWinner `json:"winners"`
+	Pot         int             `json:"pot"`                   // Chips paid out, rake included
+	WinningHand string          `json:"winningHand,omitempty"` // Empty when everyone else folded
+	Board       []Card          `json:"board"`
+	Shown       map[int][]Card  `json:"shown,omitempty"` // Cards shown per seat, at showdown or by choice
+}
+
+// SummaryWinner is a seat that won chips in a HandSummary
+type SummaryWinner struct {
+	Seat   int    `json:"seat"`
+	Name   string `json:"name"`
+	Amount int    `json:"amount"`
+}
+
+// summarize returns the history line of a finished hand
+func (r *HandReplay) summarize() HandSummary {
+	summary := HandSummary{
+		HandID:      r.ID,
+		EndedAt:     r.EndedAt.UnixMilli(),
+		Pot:         r.Pot,
+		WinningHand: r.WinningHand,
+		Board:       []Card{},
+		Shown:       r.HoleCards,
+	}
+	players := r.public("").Players
+	for _, seat := range slices.Sorted(maps.Keys(r.Winnings)) {
+		summary.Winners = append(summary.Winners, SummaryWinner{Seat: seat, Name: players[seat], Amount: r.Winnings[seat]})
+	}
+	for _, step := range r.Steps {
+		if step.Type == ReplayStepBoard {
+			summary.Board = step.Board
+		}
+	}
+	return summary
+}
+
+// History returns the summaries of the last hands played at tableID, newest first
+func (rs *ReplayStore) History(tableID string) []HandSummary {
+	if rs == nil {
+		return nil
+	}
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	history := slices.Clone(rs.history[tableID])
+	slices.Reverse(history)
+	return history
+}
+
+// QueryHandHistoryPayload represents the payload for query_hand_history messages
+type QueryHandHistoryPayload struct {
+	TableID string `json:"tableId"`
+}
+
+// HandHistoryPayload represents the payload for hand_history messages
+type HandHistoryPayload struct {
+	TableID string        `json:"tableId"`
+	Hands   []HandSummary `json:"hands"` // Newest first
+}
+
+// HandleQueryHandHistory processes a query_hand_history message and replies with hand_history
+// Any table can be queried, seated or not: summaries show nothing that was not shown at the table
+func (c *Client) HandleQueryHandHistory(server *Server, logger *slog.Logger, payload []byte) error {
+	var query QueryHandHistoryPayload
+	if err := json.Unmarshal(payload, &query); err != nil {
+		return invalidPayloadError("query_hand_history", err)
+	}
+	table := server.tableByID(query.TableID)
+	if table == nil {
+		return newMessageError("error.table_not_found", nil)
+	}
+
+	hands := server.replays.History(table.ID)
+	if hands == nil {
+		hands = []HandSummary{}
+	}
+	logger.Debug("hand history queried", "token", c.Token, "tableId", table.ID, "hands", len(hands))
+	return c.sendMessage("hand_history", HandHistoryPayload{TableID: table.ID, Hands: hands})
+}
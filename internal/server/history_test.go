@@ -0,0 +1,97 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// TestHandHistory_SummarizesHands verifies finished hands are summarized newest first
+func TestHandHistory_SummarizesHands(t *testing.T) {
+	server, table, _ := preActionTable(t)
+	first := handIDOf(table)
+	actCurrent(t, server, table, "fold")
+	actCurrent(t, server, table, "fold")
+	storedReplay(t, server, first)
+
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	second := handIDOf(table)
+	playOutChecking(t, server, table)
+	storedReplay(t, server, second)
+
+	history := server.replays.History(table.ID)
+	if len(history) != 2 || history[0].HandID != second || history[1].HandID != first {
+		t.Fatalf("expected the two hands newest first, got %+v", history)
+	}
+	folded, shown := history[1], history[0]
+	if len(folded.Winners) != 1 || folded.Pot != 30 || folded.WinningHand != "" || len(folded.Board) != 0 {
+		t.Errorf("unexpected summary of the folded hand: %+v", folded)
+	}
+	if folded.Winners[0].Name == "" || folded.Winners[0].Amount != 30 {
+		t.Errorf("expected the winner's name and the blinds won, got %+v", folded.Winners[0])
+	}
+	if shown.WinningHand == "" || len(shown.Board) != 5 || shown.Pot != 60 {
+		t.Errorf("unexpected summary of the shown down hand: %+v", shown)
+	}
+}
+
+// TestHandHistory_KeepsLastHands verifies each table keeps only its last tableHistoryLength hands
+func TestHandHistory_KeepsLastHands(t *testing.T) {
+	store := NewReplayStore(func(string, string) (string, string) { return "", "" }, nil)
+	for i := range tableHistoryLength + 5 {
+		id := string(rune('a' + i))
+		store.handle(Event{Type: EventHandStarted, TableID: "table-1", HandID: id, Stacks: map[int]int{0: 100, 1: 100}})
+		store.handle(Event{Type: EventHandEnded, TableID: "table-1", Winnings: map[int]int{0: 10}, Time: time.UnixMilli(int64(i))})
+	}
+	store.handle(Event{Type: EventHandStarted, TableID: "table-2", HandID: "other", Stacks: map[int]int{0: 100, 1: 100}})
+	store.handle(Event{Type: EventHandEnded, TableID: "table-2", Winnings: map[int]int{1: 10}})
+
+	history := store.History("table-1")
+	if len(history) != tableHistoryLength {
+		t.Fatalf("expected %d hands, got %d", tableHistoryLength, len(history))
+	}
+	if history[0].EndedAt != tableHistoryLength+4 || history[len(history)-1].EndedAt != 5 {
+		t.Errorf("expected hands 5 to %d, got %d to %d", tableHistoryLength+4, history[len(history)-1].EndedAt, history[0].EndedAt)
+	}
+	if other := store.History("table-2"); len(other) != 1 || other[0].HandID != "other" {
+		t.Errorf("expected table-2 to keep its own hand, got %+v", other)
+	}
+}
+
+// TestHandleQueryHandHistory verifies the query_hand_history reply
+func TestHandleQueryHandHistory(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	handID := handIDOf(table)
+	actCurrent(t, server, table, "fold")
+	actCurrent(t, server, table, "fold")
+	storedReplay(t, server, handID)
+	client := clients[0]
+	drainRawMessages(client)
+
+	payload, _ := json.Marshal(QueryHandHistoryPayload{TableID: table.ID})
+	if err := client.HandleQueryHandHistory(server, slog.Default(), payload); err != nil {
+		t.Fatal(err)
+	}
+	var reply struct {
+		Type    string             `json:"type"`
+		Payload HandHistoryPayload `json:"payload"`
+	}
+	messages := drainRawMessages(client)
+	if len(messages) != 1 {
+		t.Fatalf("expected one reply, got %d", len(messages))
+	}
+	if err := json.Unmarshal([]byte(messages[0]), &reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply.Type != "hand_history" || reply.Payload.TableID != table.ID || len(reply.Payload.Hands) != 1 || reply.Payload.Hands[0].HandID != handID {
+		t.Errorf("unexpected reply: %+v", reply)
+	}
+
+	payload, _ = json.Marshal(QueryHandHistoryPayload{TableID: "nope"})
+	if err := client.HandleQueryHandHistory(server, slog.Default(), payload); err == nil {
+		t.Error("expected an error for an unknown table")
+	}
+}
@@ -0,0 +1,273 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// hostChatMaxLength is the longest host_chat message, in characters
+const hostChatMaxLength = 200
+
+// blindPost is a forced bet posted as a hand starts: a blind, or a bomb pot ante
+type blindPost struct {
+	seat   int
+	amount int
+}
+
+// TakeHostSeatPayload represents the payload for take_host_seat messages
+type TakeHostSeatPayload struct {
+	TableID string `json:"tableId"`
+}
+
+// HostSeatPayload represents the payload for host_seat messages, sent to the host when they take
+// or give up the host seat
+type HostSeatPayload struct {
+	TableID string `json:"tableId"` // Empty once the player has left the host seat
+}
+
+// HostChatPayload represents the payload for host_chat messages, both from the host and as
+// broadcast to the table
+type HostChatPayload struct {
+	Text string `json:"text"`
+	Host string `json:"host"` // Host's name; ignored when sent
+}
+
+// HostPausePayload represents the payload for host_pause messages
+type HostPausePayload struct {
+	Paused bool `json:"paused"`
+}
+
+// BombPotCalledPayload represents the payload for bomb_pot_called messages, broadcast when the
+// host calls a bomb pot for the next hand
+type BombPotCalledPayload struct {
+	TableID string `json:"tableId"`
+	Ante    int    `json:"ante"`
+}
+
+// bombPotAnte returns what every player antes in a bomb pot at the table
+func (t *Table) bombPotAnte() int {
+	if t.BombPotAnte > 0 {
+		return t.BombPotAnte
+	}
+	return 2 * t.BigBlind
+}
+
+// postBombPotLocked has every player in the hand post the bomb pot ante, starting left of the
+// dealer, and closes preflop betting: nobody acts before the flop (caller must hold t.mu)
+func (t *Table) postBombPotLocked(hand *Hand) []blindPost {
+	ante := t.bombPotAnte()
+	hand.BombPot = true
+	hand.BigBlindHasOption = false
+	hand.Aggressor = nil
+	hand.AggressorStreet = ""
+	hand.CurrentBet = 0
+
+	var posted []blindPost
+	for n := 1; n <= len(t.Seats); n++ {
+		i := (hand.DealerSeat + n) % len(t.Seats)
+		if t.Seats[i].Status != "active" {
+			continue
+		}
+		amount := min(ante, t.Seats[i].Stack)
+		t.Seats[i].Stack -= amount
+		hand.PlayerBets[i] = amount
+		hand.TotalContributions[i] = amount
+		hand.ActedPlayers[i] = true
+		hand.CurrentBet = max(hand.CurrentBet, amount)
+		hand.recordLastAction(i, SeatAction{Action: "ante", Amount: amount, AllIn: t.Seats[i].Stack == 0})
+		posted = append(posted, blindPost{seat: i, amount: amount})
+	}
+	return posted
+}
+
+// hostToken returns the token of the player in the table's host seat, or "" when it is empty
+func (t *Table) hostToken() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.host == nil {
+		return ""
+	}
+	return *t.host
+}
+
+// hostedTable returns the table whose host seat token holds, or nil
+func (s *Server) hostedTable(token string) *Table {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, table := range s.tables {
+		if table.hostToken() == token {
+			return table
+		}
+	}
+	return nil
+}
+
+// removeHost empties the host seat token holds, if any. Dealing paused by the host resumes, but
+// a bomb pot they called is still dealt.
+func (s *Server) removeHost(token string) bool {
+	table := s.hostedTable(token)
+	if table == nil {
+		return false
+	}
+	table.mu.Lock()
+	if table.host == nil || *table.host != token {
+		table.mu.Unlock()
+		return false
+	}
+	table.host = nil
+	resume := table.hostPaused
+	table.hostPaused = false
+	table.mu.Unlock()
+
+	s.logger.Info("host left the host seat", "token", token, "tableID", table.ID, "resumed", resume)
+	if resume {
+		table.ScheduleNextHand()
+	}
+	s.notifyTableStatus(table)
+	return true
+}
+
+// HandleTakeHostSeat processes a take_host_seat message: a player named in the table's Hosts
+// takes its host seat, from which they see the table and run it without being dealt in
+func (c *Client) HandleTakeHostSeat(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+	var req TakeHostSeatPayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return invalidPayloadError("take_host_seat", err)
+	}
+	name, err := sm.GetPlayerName(c.Token)
+	if err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+	table := server.tableByID(req.TableID)
+	if table == nil {
+		return newMessageError("error.table_not_found", nil)
+	}
+	if !slices.Contains(table.Hosts, accountKey(name)) {
+		return newMessageError("error.not_a_host", nil)
+	}
+	if server.FindPlayerSeat(&c.Token) != nil {
+		return fmt.Errorf("already_seated")
+	}
+
+	// A player hosts one table at a time, and hosts rather than watches it
+	if current := server.hostedTable(c.Token); current != nil && current != table {
+		server.removeHost(c.Token)
+	}
+	table.mu.Lock()
+	if table.host != nil && *table.host != c.Token {
+		table.mu.Unlock()
+		return newMessageError("error.host_seat_taken", nil)
+	}
+	table.host = &c.Token
+	table.mu.Unlock()
+	server.stopWatching(c.Token)
+
+	logger.Info("host took the host seat", "token", c.Token, "tableID", table.ID)
+	if err := c.sendMessage("host_seat", HostSeatPayload{TableID: table.ID}); err != nil {
+		return err
+	}
+	server.notifyTableStatus(table)
+	return nil
+}
+
+// HandleLeaveHostSeat processes a leave_host_seat message
+func (c *Client) HandleLeaveHostSeat(server *Server, logger *slog.Logger) error {
+	if !server.removeHost(c.Token) {
+		return newMessageError("error.not_hosting", nil)
+	}
+	logger.Info("host seat given up", "token", c.Token)
+	return c.sendMessage("host_seat", HostSeatPayload{})
+}
+
+// hostTable returns the table the client is hosting, or error.not_hosting
+func (c *Client) hostTable(server *Server) (*Table, error) {
+	table := server.hostedTable(c.Token)
+	if table == nil {
+		return nil, newMessageError("error.not_hosting", nil)
+	}
+	return table, nil
+}
+
+// HandleHostChat processes a host_chat message and broadcasts it to the table. Unlike emotes
+// it is free text, so only the host can send it; players who muted the host do not get it.
+func (c *Client) HandleHostChat(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+	var chat HostChatPayload
+	if err := json.Unmarshal(payload, &chat); err != nil {
+		return invalidPayloadError("host_chat", err)
+	}
+	table, err := c.hostTable(server)
+	if err != nil {
+		return err
+	}
+	chat.Text = strings.TrimSpace(chat.Text)
+	if chat.Text == "" || utf8.RuneCountInString(chat.Text) > hostChatMaxLength {
+		return newMessageError("error.invalid_host_chat", map[string]any{"max": hostChatMaxLength})
+	}
+	chat.Host, err = sm.GetPlayerName(c.Token)
+	if err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+
+	logger.Debug("host chat sent", "tableID", table.ID, "length", len(chat.Text))
+	return server.broadcastSocialMessage(table, chat.Host, "host_chat", chat)
+}
+
+// HandleHostPause processes a host_pause message: pausing stops new hands from being dealt
+// after the one in progress, while players stay free to come and go, and resuming restarts the
+// countdown to the next hand
+func (c *Client) HandleHostPause(server *Server, logger *slog.Logger, payload []byte) error {
+	var req HostPausePayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return invalidPayloadError("host_pause", err)
+	}
+	table, err := c.hostTable(server)
+	if err != nil {
+		return err
+	}
+
+	table.mu.Lock()
+	if table.hostPaused == req.Paused {
+		table.mu.Unlock()
+		return nil
+	}
+	table.hostPaused = req.Paused
+	if req.Paused {
+		table.cancelNextHandLocked()
+	}
+	table.mu.Unlock()
+
+	logger.Info("host paused the table", "tableID", table.ID, "paused", req.Paused)
+	if !req.Paused {
+		table.ScheduleNextHand()
+	}
+	server.notifyTableStatus(table)
+	return nil
+}
+
+// HandleBombPot processes a bomb_pot message: the next hand at the host's table is a bomb pot,
+// where every player antes and the cards are dealt straight to the flop
+func (c *Client) HandleBombPot(server *Server, logger *slog.Logger) error {
+	table, err := c.hostTable(server)
+	if err != nil {
+		return err
+	}
+
+	table.mu.Lock()
+	if table.bombPot {
+		table.mu.Unlock()
+		return newMessageError("error.bomb_pot_pending", nil)
+	}
+	table.bombPot = true
+	table.mu.Unlock()
+
+	ante := table.bombPotAnte()
+	logger.Info("bomb pot called", "tableID", table.ID, "ante", ante)
+	if err := server.broadcastTableMessage(table, "bomb_pot_called", BombPotCalledPayload{TableID: table.ID, Ante: ante}); err != nil {
+		return err
+	}
+	return server.broadcastTableState(table.ID, nil)
+}
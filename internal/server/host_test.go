@@ -0,0 +1,156 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// newHostedServer creates a server with one 10/20 table Hank may host, with Hank in the host
+// seat and Alice, Bob and Carol seated
+func newHostedServer(t *testing.T) (*Server, *Table, *Client, []*Client) {
+	t.Helper()
+	server := NewServerWithConfig(slog.Default(), Config{Tables: []TableConfig{
+		{Name: "Home Game", SmallBlind: 10, BigBlind: 20, BuyIn: 1000, Hosts: []string{"Hank"}},
+	}})
+	table := server.tables[0]
+	session, _ := server.sessionManager.CreateSession("Hank")
+	host := connectTestClient(server, session.Token)
+	payload, _ := json.Marshal(TakeHostSeatPayload{TableID: table.ID})
+	if err := host.HandleTakeHostSeat(server.sessionManager, server, slog.Default(), payload); err != nil {
+		t.Fatal(err)
+	}
+	return server, table, host, seatNamed(t, server, table, "Alice", "Bob", "Carol")
+}
+
+// TestHostSeat_HostRunsTheTableWithoutBeingDealtIn verifies only listed hosts take the host
+// seat, and the host follows the table without a seat or cards of their own
+func TestHostSeat_HostRunsTheTableWithoutBeingDealtIn(t *testing.T) {
+	server, table, host, _ := newHostedServer(t)
+
+	session, _ := server.sessionManager.CreateSession("Mallory")
+	payload, _ := json.Marshal(TakeHostSeatPayload{TableID: table.ID})
+	if err := connectTestClient(server, session.Token).HandleTakeHostSeat(server.sessionManager, server, slog.Default(), payload); err == nil {
+		t.Error("expected a player not named in hosts to be refused")
+	}
+	if _, err := server.seatPlayer(host.Token, "", table); err == nil {
+		t.Error("expected the host refused a playing seat")
+	}
+	if lobby := server.GetLobbyState(); lobby[0].Host != "Hank" {
+		t.Errorf("expected Hank shown hosting in the lobby, got %q", lobby[0].Host)
+	}
+
+	drainRawMessages(host)
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if len(table.CurrentHand.HoleCards) != 3 {
+		t.Errorf("expected only the three players dealt in, got %d hands", len(table.CurrentHand.HoleCards))
+	}
+	if started := payloadsOf[HandStartedPayload](t, host, "hand_started"); len(started) != 1 {
+		t.Errorf("expected the host to see the hand start, got %+v", started)
+	}
+
+	if err := host.HandleLeaveHostSeat(server, slog.Default()); err != nil {
+		t.Fatal(err)
+	}
+	if err := host.HandleBombPot(server, slog.Default()); err == nil {
+		t.Error("expected a bomb pot refused once the host left")
+	}
+}
+
+// TestHostSeat_PauseStopsDealing verifies a paused table deals no new hand until the host
+// resumes it or leaves
+func TestHostSeat_PauseStopsDealing(t *testing.T) {
+	server, table, host, _ := newHostedServer(t)
+
+	pause := func(paused bool) {
+		t.Helper()
+		payload, _ := json.Marshal(HostPausePayload{Paused: paused})
+		if err := host.HandleHostPause(server, slog.Default(), payload); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pause(true)
+	if table.CanStartHand() {
+		t.Error("expected no hand while the host has the table paused")
+	}
+	pause(false)
+	if !table.CanStartHand() {
+		t.Error("expected dealing to resume")
+	}
+
+	pause(true)
+	server.removeHost(host.Token)
+	if !table.CanStartHand() {
+		t.Error("expected dealing to resume when the host leaves")
+	}
+}
+
+// TestHostSeat_BombPot verifies a bomb pot has everyone ante and deals straight to the flop,
+// after which the table goes back to blinds
+func TestHostSeat_BombPot(t *testing.T) {
+	server, table, host, players := newHostedServer(t)
+
+	if err := host.HandleBombPot(server, slog.Default()); err != nil {
+		t.Fatal(err)
+	}
+	if err := host.HandleBombPot(server, slog.Default()); err == nil {
+		t.Error("expected a second bomb pot refused while one is pending")
+	}
+	called := payloadsOf[BombPotCalledPayload](t, players[0], "bomb_pot_called")
+	if len(called) != 1 || called[0].Ante != 40 {
+		t.Errorf("expected the table told of a 40 chip bomb pot, got %+v", called)
+	}
+
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	table.mu.RLock()
+	hand := table.CurrentHand
+	street, pot, bombPot := hand.Street, hand.Pot, hand.BombPot
+	stacks := []int{table.Seats[0].Stack, table.Seats[1].Stack, table.Seats[2].Stack}
+	table.mu.RUnlock()
+	if !bombPot || street != "flop" || pot != 120 {
+		t.Errorf("expected a 120 chip bomb pot on the flop, got %s with %d (bomb pot %v)", street, pot, bombPot)
+	}
+	for i, stack := range stacks {
+		if stack != 960 {
+			t.Errorf("expected seat %d to have anted 40, has %d left", i, stack)
+		}
+	}
+
+	playOutChecking(t, server, table)
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	table.mu.RLock()
+	defer table.mu.RUnlock()
+	if table.CurrentHand.BombPot || table.CurrentHand.Street != "preflop" {
+		t.Error("expected the next hand dealt with blinds")
+	}
+}
+
+// TestHostSeat_HostChat verifies the host's messages reach the table, within the length limit
+func TestHostSeat_HostChat(t *testing.T) {
+	server, _, host, players := newHostedServer(t)
+	chat := func(client *Client, text string) error {
+		payload, _ := json.Marshal(HostChatPayload{Text: text})
+		return client.HandleHostChat(server.sessionManager, server, slog.Default(), payload)
+	}
+
+	if err := chat(players[0], "hello"); err == nil {
+		t.Error("expected a player refused host chat")
+	}
+	if err := chat(host, strings.Repeat("a", hostChatMaxLength+1)); err == nil {
+		t.Error("expected an overlong message refused")
+	}
+	if err := chat(host, "  Welcome to the game!  "); err != nil {
+		t.Fatal(err)
+	}
+	got := payloadsOf[HostChatPayload](t, players[1], "host_chat")
+	if len(got) != 1 || got[0] != (HostChatPayload{Text: "Welcome to the game!", Host: "Hank"}) {
+		t.Errorf("expected the host's message, got %+v", got)
+	}
+}
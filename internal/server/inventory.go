@@ -0,0 +1,145 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Inventory item kinds
+const (
+	ItemTournamentTicket = "tournament_ticket" // An entry into the tournament named by Reference
+	ItemRakebackVoucher  = "rakeback_voucher"  // Returns up to Value chips of rake paid
+	ItemPromo            = "promo"             // Anything else an operator hands out; Reference says what
+)
+
+// errItemNotFound is returned when consuming an item the account does not hold
+var errItemNotFound = errors.New("item_not_found")
+
+// InventoryItem is a ticket, voucher or promotional item held on an account
+type InventoryItem struct {
+	ID        string       `json:"id"`
+	Kind      string       `json:"kind"`
+	Reference string       `json:"reference,omitempty"` // What the item is for, e.g. a tournament ID
+	Value     int          `json:"value,omitempty"`     // Chip value where the kind has one
+	Currency  ChipCurrency `json:"currency,omitempty"`  // Currency of Value
+	Source    string       `json:"source,omitempty"`    // Who granted it, e.g. "admin" or a satellite ID
+	GrantedAt time.Time    `json:"grantedAt"`
+	ExpiresAt *time.Time   `json:"expiresAt,omitempty"` // nil = never
+}
+
+// expired reports whether the item can no longer be used at now
+func (i InventoryItem) expired(now time.Time) bool {
+	return i.ExpiresAt != nil && !now.Before(*i.ExpiresAt)
+}
+
+// validateItem reports the first problem with an item about to be granted
+func validateItem(item InventoryItem) error {
+	switch item.Kind {
+	case ItemTournamentTicket, ItemRakebackVoucher, ItemPromo:
+	default:
+		return fmt.Errorf("kind must be %q, %q or %q", ItemTournamentTicket, ItemRakebackVoucher, ItemPromo)
+	}
+	if item.Kind == ItemTournamentTicket && item.Reference == "" {
+		return fmt.Errorf("tournament tickets need a reference")
+	}
+	if item.Value < 0 {
+		return fmt.Errorf("value must not be negative")
+	}
+	if err := validateCurrency(item.Currency); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GrantItem adds item to the named account's inventory and returns it with its ID set
+func (s *AccountStore) GrantItem(name string, item InventoryItem) (InventoryItem, error) {
+	if err := validateItem(item); err != nil {
+		return InventoryItem{}, err
+	}
+	item.ID = uuid.New().String()
+	if item.GrantedAt.IsZero() {
+		item.GrantedAt = time.Now()
+	}
+
+	err := s.update(name, func(account *Account) error {
+		account.Inventory = append(account.Inventory, item)
+		return nil
+	})
+	if err != nil {
+		return InventoryItem{}, err
+	}
+	return item, nil
+}
+
+// ConsumeItem removes the unexpired item with id from the named account and returns it
+// Removal and return happen under one lock, so an item can be used only once
+func (s *AccountStore) ConsumeItem(name, id string, now time.Time) (InventoryItem, error) {
+	return s.consume(name, now, func(item InventoryItem) bool { return item.ID == id })
+}
+
+// ConsumeItemOfKind consumes the oldest unexpired item of kind for reference, as a tournament
+// registration would with a ticket
+func (s *AccountStore) ConsumeItemOfKind(name, kind, reference string, now time.Time) (InventoryItem, error) {
+	return s.consume(name, now, func(item InventoryItem) bool {
+		return item.Kind == kind && item.Reference == reference
+	})
+}
+
+// consume removes and returns the first unexpired item on the account accepted by match
+func (s *AccountStore) consume(name string, now time.Time, match func(InventoryItem) bool) (InventoryItem, error) {
+	var consumed InventoryItem
+	err := s.update(name, func(account *Account) error {
+		for i, item := range account.Inventory {
+			if match(item) && !item.expired(now) {
+				consumed = item
+				account.Inventory = append(account.Inventory[:i], account.Inventory[i+1:]...)
+				return nil
+			}
+		}
+		return errItemNotFound
+	})
+	if err != nil {
+		return InventoryItem{}, err
+	}
+	return consumed, nil
+}
+
+// Inventory returns the named account's unexpired items, oldest first
+func (s *AccountStore) Inventory(name string, now time.Time) []InventoryItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := make([]InventoryItem, 0)
+	for _, item := range s.accounts[accountKey(name)].Inventory {
+		if !item.expired(now) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// InventoryPayload represents the payload for inventory messages
+type InventoryPayload struct {
+	Items []InventoryItem `json:"items"`
+}
+
+// HandleGetInventory processes a get_inventory message and replies with the player's items
+func (c *Client) HandleGetInventory(sm *SessionManager, server *Server, logger *slog.Logger) error {
+	name, err := sm.GetPlayerName(c.Token)
+	if err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+	return c.sendMessage("inventory", InventoryPayload{Items: server.accounts.Inventory(name, time.Now())})
+}
+
+// sendInventory privately sends the current inventory to every live session of the account
+func (s *Server) sendInventory(name string) {
+	items := s.accounts.Inventory(name, time.Now())
+	for _, token := range s.sessionManager.TokensByName(name) {
+		s.sendPrivate(token, "inventory", InventoryPayload{Items: items})
+	}
+}
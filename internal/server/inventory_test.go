@@ -0,0 +1,155 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestAccountStore_GrantAndConsumeItems verifies items are granted with IDs, consumed once,
+// matched by kind and reference, and hidden once expired
+func TestAccountStore_GrantAndConsumeItems(t *testing.T) {
+	store, err := LoadAccountStore("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+
+	ticket, err := store.GrantItem("Alice", InventoryItem{Kind: ItemTournamentTicket, Reference: "sunday-major", Source: "satellite-1"})
+	if err != nil {
+		t.Fatalf("grant ticket: %v", err)
+	}
+	if ticket.ID == "" || ticket.GrantedAt.IsZero() {
+		t.Errorf("expected ID and grant time to be set, got %+v", ticket)
+	}
+	expiresAt := now.Add(time.Hour)
+	voucher, _ := store.GrantItem("alice", InventoryItem{Kind: ItemRakebackVoucher, Value: 500, ExpiresAt: &expiresAt})
+
+	if items := store.Inventory("ALICE", now); len(items) != 2 {
+		t.Fatalf("expected 2 items across name casing, got %+v", items)
+	}
+	if items := store.Inventory("alice", expiresAt); len(items) != 1 || items[0].ID != ticket.ID {
+		t.Errorf("expected only the ticket after the voucher expired, got %+v", items)
+	}
+	if _, err := store.ConsumeItem("alice", voucher.ID, expiresAt); !errors.Is(err, errItemNotFound) {
+		t.Errorf("expected expired voucher to be unusable, got %v", err)
+	}
+
+	if _, err := store.ConsumeItemOfKind("alice", ItemTournamentTicket, "other-event", now); !errors.Is(err, errItemNotFound) {
+		t.Errorf("expected ticket for another tournament to be rejected, got %v", err)
+	}
+	used, err := store.ConsumeItemOfKind("alice", ItemTournamentTicket, "sunday-major", now)
+	if err != nil || used.ID != ticket.ID {
+		t.Fatalf("expected to consume the ticket, got %+v, %v", used, err)
+	}
+	if _, err := store.ConsumeItem("alice", ticket.ID, now); !errors.Is(err, errItemNotFound) {
+		t.Errorf("expected a consumed ticket to be gone, got %v", err)
+	}
+}
+
+// TestAccountStore_RejectsInvalidItems verifies unknown kinds and unreferenced tickets are refused
+func TestAccountStore_RejectsInvalidItems(t *testing.T) {
+	store, _ := LoadAccountStore("")
+
+	for _, item := range []InventoryItem{
+		{Kind: "gift_card"},
+		{Kind: ItemTournamentTicket},
+		{Kind: ItemPromo, Value: -1},
+		{Kind: ItemPromo, Currency: "gold"},
+	} {
+		if _, err := store.GrantItem("alice", item); err == nil {
+			t.Errorf("expected %+v to be rejected", item)
+		}
+	}
+	if items := store.Inventory("alice", time.Now()); len(items) != 0 {
+		t.Errorf("expected no items, got %+v", items)
+	}
+}
+
+// TestAccountStore_PersistsInventory verifies items survive a reload from disk
+func TestAccountStore_PersistsInventory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.json")
+	store, err := LoadAccountStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	item, err := store.GrantItem("Alice", InventoryItem{Kind: ItemPromo, Reference: "welcome-pack"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := LoadAccountStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := reloaded.Inventory("alice", time.Now())
+	if len(items) != 1 || items[0].ID != item.ID || items[0].Reference != "welcome-pack" {
+		t.Errorf("expected persisted promo, got %+v", items)
+	}
+}
+
+// TestAdminAPI_Inventory verifies operators can grant, list and consume items and that the
+// player's live session is sent the updated inventory
+func TestAdminAPI_Inventory(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{AdminToken: "secret"})
+	session, _ := server.sessionManager.CreateSession("Alice")
+	client := connectTestClient(server, session.Token)
+
+	rec := adminRequest(server, http.MethodPost, "/admin/accounts/Alice/inventory", "secret", `{"kind":"rakeback_voucher","value":250,"duration":"720h"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var granted InventoryItem
+	json.Unmarshal(rec.Body.Bytes(), &granted)
+	if granted.Source != "admin" || granted.ExpiresAt == nil {
+		t.Errorf("unexpected granted item %+v", granted)
+	}
+
+	var msg WebSocketMessage
+	if err := json.Unmarshal(<-client.send, &msg); err != nil || msg.Type != "inventory" {
+		t.Fatalf("expected inventory push, got %s (%v)", msg.Type, err)
+	}
+
+	rec = adminRequest(server, http.MethodGet, "/admin/accounts/alice/inventory", "secret", "")
+	var items []InventoryItem
+	json.Unmarshal(rec.Body.Bytes(), &items)
+	if len(items) != 1 || items[0].ID != granted.ID {
+		t.Fatalf("expected the granted voucher, got %s", rec.Body.String())
+	}
+
+	for _, body := range []string{`{"kind":"gift_card"}`, `{"kind":"promo","duration":"soon"}`, `not json`} {
+		if rec := adminRequest(server, http.MethodPost, "/admin/accounts/alice/inventory", "secret", body); rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 for %s, got %d", body, rec.Code)
+		}
+	}
+
+	if rec := adminRequest(server, http.MethodDelete, "/admin/accounts/alice/inventory/"+granted.ID, "secret", ""); rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 consuming the voucher, got %d", rec.Code)
+	}
+	if rec := adminRequest(server, http.MethodDelete, "/admin/accounts/alice/inventory/"+granted.ID, "secret", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 consuming it twice, got %d", rec.Code)
+	}
+}
+
+// TestHandleGetInventory verifies a player can fetch their own items
+func TestHandleGetInventory(t *testing.T) {
+	server := NewServer(slog.Default())
+	session, _ := server.sessionManager.CreateSession("Alice")
+	client := &Client{hub: server.hub, Token: session.Token, send: make(chan []byte, 256)}
+	server.accounts.GrantItem("Alice", InventoryItem{Kind: ItemTournamentTicket, Reference: "sunday-major"})
+
+	if err := client.HandleGetInventory(server.sessionManager, server, slog.Default()); err != nil {
+		t.Fatal(err)
+	}
+	var msg WebSocketMessage
+	json.Unmarshal(<-client.send, &msg)
+	var payload InventoryPayload
+	json.Unmarshal(msg.Payload, &payload)
+	if msg.Type != "inventory" || len(payload.Items) != 1 || payload.Items[0].Reference != "sunday-major" {
+		t.Errorf("unexpected reply %s %s", msg.Type, msg.Payload)
+	}
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"errors"
+	"time"
+)
+
+// KickRequest is the body of POST /admin/accounts/{name}/kick
+type KickRequest struct {
+	Reason   string `json:"reason,omitempty"`   // Free text kept with any ban and logged
+	Ban      bool   `json:"ban,omitempty"`      // Also ban the account
+	BanIP    bool   `json:"banIp,omitempty"`    // Also ban the IP the player is connected from
+	Duration string `json:"duration,omitempty"` // Go duration of the bans such as "24h"; empty bans permanently
+}
+
+// KickResult is the result of POST /admin/accounts/{name}/kick
+type KickResult struct {
+	Player    string `json:"player"`
+	TableID   string `json:"tableId"`
+	SeatIndex int    `json:"seatIndex"`
+	CashedOut int    `json:"cashedOut"` // Stack returned to the player's balance, after folding any live hand
+	Bans      []Ban  `json:"bans,omitempty"`
+}
+
+var (
+	errPlayerNotFound  = errors.New("player not found")
+	errPlayerAmbiguous = errors.New("player name is ambiguous")
+	errKickNotSeated   = errors.New("player is not seated")
+	errKickFrozen      = errors.New("table is frozen")
+	errKickOffline     = errors.New("player is offline, so their IP is unknown")
+)
+
+// KickPlayer removes the player named name from their table: a hand they are in is folded, their
+// stack is cashed out to their balance, and they get seat_cleared. With req.Ban or req.BanIP the
+// account or the IP they connect from is then banned until expiresAt (nil for good) and their
+// connection dropped. Frozen tables keep their players, so a kick there is refused.
+func (s *Server) KickPlayer(name string, req KickRequest, expiresAt *time.Time) (KickResult, error) {
+	tokens := s.sessionManager.TokensByName(name)
+	switch {
+	case len(tokens) == 0:
+		return KickResult{}, errPlayerNotFound
+	case len(tokens) > 1:
+		return KickResult{}, errPlayerAmbiguous
+	}
+	token := tokens[0]
+
+	var table *Table
+	var seat Seat
+	s.mu.RLock()
+	for _, t := range s.tables {
+		if found, ok := t.GetSeatByToken(&token); ok {
+			table, seat = t, found
+			break
+		}
+	}
+	s.mu.RUnlock()
+	if table == nil {
+		return KickResult{}, errKickNotSeated
+	}
+	if table.Frozen() {
+		return KickResult{}, errKickFrozen
+	}
+
+	remoteIP := ""
+	if req.BanIP {
+		if s.hub != nil {
+			s.hub.mu.RLock()
+			if client, ok := s.hub.sessions[token]; ok {
+				remoteIP = client.RemoteIP
+			}
+			s.hub.mu.RUnlock()
+		}
+		if remoteIP == "" {
+			return KickResult{}, errKickOffline
+		}
+	}
+
+	s.foldDepartingPlayer(table, seat.Index)
+	// The fold is final, so the stack left now is what the player takes away
+	seat, seated := table.GetSeatByToken(&token)
+	if !seated {
+		return KickResult{}, errKickNotSeated
+	}
+	if err := table.ClearSeat(&token); err != nil {
+		return KickResult{}, err
+	}
+	if _, err := s.sessionManager.UpdateSession(token, nil, nil); err != nil {
+		s.logger.Debug("session not updated after kick", "token", token, "error", err)
+	}
+	s.sendPrivate(token, "error", ErrorPayload{Message: "kicked", Key: "error.kicked"})
+	s.sendPrivate(token, "seat_cleared", SeatClearedPayload{})
+	s.notifyTableStatus(table)
+
+	result := KickResult{Player: name, TableID: table.ID, SeatIndex: seat.Index, CashedOut: seat.Stack}
+	var bans []Ban
+	if req.Ban {
+		bans = append(bans, Ban{Kind: BanKindAccount, Value: name, Reason: req.Reason, ExpiresAt: expiresAt})
+	}
+	if req.BanIP {
+		bans = append(bans, Ban{Kind: BanKindIP, Value: remoteIP, Reason: req.Reason, ExpiresAt: expiresAt})
+	}
+	for _, ban := range bans {
+		ban, err := s.bans.Add(ban)
+		if err != nil {
+			// The player is already unseated; report the ban that failed
+			return result, err
+		}
+		result.Bans = append(result.Bans, ban)
+		s.disconnectBanned(ban)
+	}
+	return result, nil
+}
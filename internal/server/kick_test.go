@@ -0,0 +1,80 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestAdminAPI_KickPlayer verifies a kicked player is folded out of the live hand, cashed out and
+// unseated, and banned by account and IP when asked
+func TestAdminAPI_KickPlayer(t *testing.T) {
+	server := newBankrollServer()
+	table := server.tables[0]
+	clients := make(map[string]*Client)
+	for _, name := range []string{"Alice", "Bob", "Carol"} {
+		session, _ := server.sessionManager.CreateSession(name)
+		server.grantStartingBalance(session.Token)
+		if _, err := server.seatPlayer(session.Token, "", table); err != nil {
+			t.Fatal(err)
+		}
+		clients[name] = connectTestClient(server, session.Token)
+	}
+	clients["Alice"].RemoteIP = "203.0.113.7"
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	alice := clients["Alice"].Token
+	seat, _ := table.GetSeatByToken(&alice)
+
+	if code := adminRequest(server, http.MethodPost, "/admin/accounts/Nobody/kick", "secret", `{}`).Code; code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown player, got %d", code)
+	}
+	if code := adminRequest(server, http.MethodPost, "/admin/accounts/Alice/kick", "secret", `{"ban": true, "duration": "soon"}`).Code; code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a bad duration, got %d", code)
+	}
+
+	drainRawMessages(clients["Alice"])
+	rec := adminRequest(server, http.MethodPost, "/admin/accounts/Alice/kick", "secret", `{"reason": "abuse", "ban": true, "banIp": true, "duration": "24h"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var result KickResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.TableID != table.ID || result.SeatIndex != seat.Index || len(result.Bans) != 2 {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	if _, seated := table.GetSeatByToken(&alice); seated {
+		t.Error("expected Alice unseated")
+	}
+	table.mu.RLock()
+	folded := table.CurrentHand == nil || table.CurrentHand.FoldedPlayers[seat.Index]
+	table.mu.RUnlock()
+	if !folded {
+		t.Error("expected Alice folded out of the hand")
+	}
+	if balances, _ := server.sessionManager.Balances(alice); balances[CurrencyPlay] != 4000+result.CashedOut || result.CashedOut <= 0 {
+		t.Errorf("expected the %d stack cashed out, got %v", result.CashedOut, balances)
+	}
+	if _, banned := server.bans.AccountBan("alice", time.Now()); !banned {
+		t.Error("expected the account banned")
+	}
+	if _, banned := server.bans.IPBan("203.0.113.7", time.Now()); !banned {
+		t.Error("expected the IP banned")
+	}
+	messages := strings.Join(drainRawMessages(clients["Alice"]), "\n")
+	for _, want := range []string{`"key":"error.kicked"`, `"type":"seat_cleared"`, `"key":"error.banned"`} {
+		if !strings.Contains(messages, want) {
+			t.Errorf("expected Alice to receive %s, got %s", want, messages)
+		}
+	}
+
+	if code := adminRequest(server, http.MethodPost, "/admin/accounts/Alice/kick", "secret", `{}`).Code; code != http.StatusConflict {
+		t.Errorf("expected 409 kicking a player who is not seated, got %d", code)
+	}
+}
@@ -0,0 +1,167 @@
+package server
+
+import (
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// latencySamples is how many recent response times are kept per table and per player
+const latencySamples = 200
+
+// LatencyStats summarizes how quickly players answer their action requests. The percentiles
+// and the maximum cover the last latencySamples answers.
+type LatencyStats struct {
+	Actions         int   `json:"actions"`  // Answers measured since the server started
+	Timeouts        int   `json:"timeouts"` // Turns the action clock (or a logout) ended instead
+	MeanMs          int64 `json:"meanMs"`
+	P50Ms           int64 `json:"p50Ms"`
+	P95Ms           int64 `json:"p95Ms"`
+	MaxMs           int64 `json:"maxMs"`
+	ActionTimeoutMs int64 `json:"actionTimeoutMs,omitempty"` // Tables only: the clock players are on now
+}
+
+// LatencyReport is the response of GET /admin/latency and /debug/latency
+type LatencyReport struct {
+	Tables  map[string]LatencyStats `json:"tables"`  // By table ID
+	Players map[string]LatencyStats `json:"players"` // By lowercased player name
+}
+
+// latencyWindow accumulates the response times of one table or player
+type latencyWindow struct {
+	actions  int
+	timeouts int
+	recent   []time.Duration // Ring of the last latencySamples response times
+	next     int             // Where the next response time goes once recent is full
+}
+
+// add records one response time
+func (w *latencyWindow) add(d time.Duration) {
+	w.actions++
+	if len(w.recent) < latencySamples {
+		w.recent = append(w.recent, d)
+		return
+	}
+	w.recent[w.next] = d
+	w.next = (w.next + 1) % latencySamples
+}
+
+// stats summarizes the window
+func (w *latencyWindow) stats() LatencyStats {
+	stats := LatencyStats{Actions: w.actions, Timeouts: w.timeouts}
+	if len(w.recent) == 0 {
+		return stats
+	}
+	sorted := slices.Clone(w.recent)
+	slices.Sort(sorted)
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	stats.MeanMs = (total / time.Duration(len(sorted))).Milliseconds()
+	stats.P50Ms = sorted[(len(sorted)-1)*50/100].Milliseconds()
+	stats.P95Ms = sorted[(len(sorted)-1)*95/100].Milliseconds()
+	stats.MaxMs = sorted[len(sorted)-1].Milliseconds()
+	return stats
+}
+
+// LatencyTracker measures, per table and per player, the time from an action_request going out
+// to the player's answer, so lagging clients stand out and action clocks can be tuned to how
+// long players really take. The nil LatencyTracker measures nothing.
+type LatencyTracker struct {
+	mu         sync.Mutex
+	tables     map[string]*latencyWindow
+	players    map[string]*latencyWindow
+	playerName func(token string) string
+}
+
+// NewLatencyTracker creates an empty LatencyTracker; playerName names the player acting
+func NewLatencyTracker(playerName func(token string) string) *LatencyTracker {
+	return &LatencyTracker{
+		tables:     make(map[string]*latencyWindow),
+		players:    make(map[string]*latencyWindow),
+		playerName: playerName,
+	}
+}
+
+// Run handles events until the channel is closed
+func (lt *LatencyTracker) Run(events <-chan Event) {
+	for e := range events {
+		if e.Type == EventPlayerAction {
+			lt.record(e)
+		}
+	}
+}
+
+// record adds a player_action's response time, or its timeout, to the table and the player
+// Queued pre-actions were answered before the request went out and are left out.
+func (lt *LatencyTracker) record(e Event) {
+	if !e.Timeout && e.ResponseTime <= 0 {
+		return
+	}
+	name := ""
+	if lt.playerName != nil && e.Token != "" {
+		name = accountKey(lt.playerName(e.Token))
+	}
+
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	windows := []*latencyWindow{lt.windowLocked(lt.tables, e.TableID)}
+	if name != "" {
+		windows = append(windows, lt.windowLocked(lt.players, name))
+	}
+	for _, w := range windows {
+		if e.Timeout {
+			w.timeouts++
+		} else {
+			w.add(e.ResponseTime)
+		}
+	}
+}
+
+// windowLocked returns the window under key, creating it if needed (caller must hold lt.mu)
+func (lt *LatencyTracker) windowLocked(windows map[string]*latencyWindow, key string) *latencyWindow {
+	w, ok := windows[key]
+	if !ok {
+		w = &latencyWindow{}
+		windows[key] = w
+	}
+	return w
+}
+
+// Report summarizes the response times of every table and player seen so far
+func (lt *LatencyTracker) Report() LatencyReport {
+	report := LatencyReport{Tables: map[string]LatencyStats{}, Players: map[string]LatencyStats{}}
+	if lt == nil {
+		return report
+	}
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	for id, w := range lt.tables {
+		report.Tables[id] = w.stats()
+	}
+	for name, w := range lt.players {
+		report.Players[name] = w.stats()
+	}
+	return report
+}
+
+// latencyReport is the latency report with each open table's current action clock filled in
+func (s *Server) latencyReport() LatencyReport {
+	report := s.latency.Report()
+	for id, stats := range report.Tables {
+		if table := s.tableByID(id); table != nil {
+			table.mu.RLock()
+			stats.ActionTimeoutMs = table.actionTimeoutLocked().Milliseconds()
+			table.mu.RUnlock()
+			report.Tables[id] = stats
+		}
+	}
+	return report
+}
+
+// handleLatency writes the action latency of every table and player
+func (s *Server) handleLatency(w http.ResponseWriter, r *http.Request) {
+	writeAdminJSON(w, http.StatusOK, s.latencyReport())
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// TestLatencyTracker_Report verifies response times are summarized per table and per player,
+// timeouts are counted apart and pre-actions left out
+func TestLatencyTracker_Report(t *testing.T) {
+	names := map[string]string{"tok-a": "Alice", "tok-b": "Bob"}
+	latency := NewLatencyTracker(func(token string) string { return names[token] })
+
+	for i := 1; i <= 10; i++ {
+		latency.record(Event{Type: EventPlayerAction, TableID: "table-1", Token: "tok-a", ResponseTime: time.Duration(i) * 100 * time.Millisecond})
+	}
+	latency.record(Event{Type: EventPlayerAction, TableID: "table-1", Token: "tok-b", ResponseTime: 4 * time.Second})
+	latency.record(Event{Type: EventPlayerAction, TableID: "table-1", Token: "tok-b", Timeout: true})
+	latency.record(Event{Type: EventPlayerAction, TableID: "table-1", Token: "tok-b"})
+
+	report := latency.Report()
+	alice := report.Players["alice"]
+	if alice != (LatencyStats{Actions: 10, MeanMs: 550, P50Ms: 500, P95Ms: 900, MaxMs: 1000}) {
+		t.Errorf("unexpected stats for Alice: %+v", alice)
+	}
+	if bob := report.Players["bob"]; bob.Actions != 1 || bob.Timeouts != 1 || bob.MaxMs != 4000 {
+		t.Errorf("expected Bob's answer and timeout counted and his pre-action left out, got %+v", bob)
+	}
+	if table := report.Tables["table-1"]; table.Actions != 11 || table.Timeouts != 1 || table.MaxMs != 4000 {
+		t.Errorf("unexpected stats for the table: %+v", table)
+	}
+
+	for i := 0; i < latencySamples; i++ {
+		latency.record(Event{Type: EventPlayerAction, TableID: "table-1", Token: "tok-a", ResponseTime: time.Second})
+	}
+	if alice := latency.Report().Players["alice"]; alice.Actions != 10+latencySamples || alice.P50Ms != 1000 || alice.MeanMs != 1000 {
+		t.Errorf("expected only the last %d answers summarized, got %+v", latencySamples, alice)
+	}
+}
+
+// TestLatency_MeasuredFromActionRequest verifies a player's answer is timed from the
+// action_request sent to them and reported with the table's action clock
+func TestLatency_MeasuredFromActionRequest(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{
+		ActionTimeout: 30 * time.Second,
+		Tables:        []TableConfig{{Name: "Main", SmallBlind: 10, BigBlind: 20, BuyIn: 1000}},
+	})
+	clock := useFakeClock(server)
+	table := server.tables[0]
+	clients := seatNamed(t, server, table, "Alice", "Bob", "Carol")
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	table.mu.RLock()
+	actor := *table.CurrentHand.CurrentActor
+	token := *table.Seats[actor].Token
+	table.mu.RUnlock()
+	var client *Client
+	for _, c := range clients {
+		if c.Token == token {
+			client = c
+		}
+	}
+	name, _ := server.sessionManager.GetPlayerName(token)
+
+	clock.Advance(1500 * time.Millisecond)
+	if err := server.processTableAction(context.Background(), table, client, "", actor, "call"); err != nil {
+		t.Fatal(err)
+	}
+	if !eventually(func() bool { return server.latencyReport().Players[accountKey(name)].Actions == 1 }) {
+		t.Fatalf("expected %s's answer measured, got %+v", name, server.latencyReport())
+	}
+	report := server.latencyReport()
+	if stats := report.Players[accountKey(name)]; stats.MeanMs != 1500 {
+		t.Errorf("expected a 1500ms answer, got %+v", stats)
+	}
+	if stats := report.Tables[table.ID]; stats.Actions != 1 || stats.ActionTimeoutMs != 30000 {
+		t.Errorf("expected the table's answer and clock reported, got %+v", stats)
+	}
+}
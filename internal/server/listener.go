@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// TLSConfig selects how the server terminates TLS
+// Either a certificate/key pair or autocert domains may be set, not both.
+// The zero value serves plain HTTP (e.g. behind a TLS-terminating proxy).
+type TLSConfig struct {
+	CertFile string `yaml:"certFile"` // PEM certificate chain
+	KeyFile  string `yaml:"keyFile"`  // PEM private key
+
+	// AutocertDomains obtains certificates from Let's Encrypt for these host names.
+	// The server must be reachable on port 443 for the TLS-ALPN challenge.
+	AutocertDomains []string `yaml:"autocertDomains"`
+	// AutocertCacheDir stores obtained certificates across restarts
+	AutocertCacheDir string `yaml:"autocertCacheDir"`
+}
+
+// Enabled reports whether the server should serve HTTPS
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" || len(c.AutocertDomains) > 0
+}
+
+// validate reports conflicting or incomplete TLS settings
+func (c TLSConfig) validate() error {
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return fmt.Errorf("tls.certFile and tls.keyFile must be set together")
+	}
+	if c.CertFile != "" && len(c.AutocertDomains) > 0 {
+		return fmt.Errorf("tls.certFile and tls.autocertDomains are mutually exclusive")
+	}
+	return nil
+}
+
+// clientIPKey is the request context key holding the resolved client IP
+type clientIPKey struct{}
+
+// parseTrustedProxies parses IP addresses and CIDR ranges into networks
+// A bare IP is treated as a single-address range
+func parseTrustedProxies(entries []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", entry)
+			}
+			bits := 32
+			if ip.To4() == nil {
+				bits = 128
+			}
+			entry = fmt.Sprintf("%s/%d", entry, bits)
+		}
+
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", entry, err)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// isTrustedProxy reports whether ip belongs to one of the trusted networks
+func isTrustedProxy(ip net.IP, trusted []*net.IPNet) bool {
+	for _, network := range trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveClientIP determines the real client address of a request
+// X-Forwarded-For is only honored when the direct peer is a trusted proxy; the header
+// is then walked right to left, skipping further trusted proxies, so a client cannot
+// spoof its address by prepending entries of its own
+func resolveClientIP(remoteAddr, forwardedFor string, trusted []*net.IPNet) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+
+	peer := net.ParseIP(host)
+	if peer == nil || forwardedFor == "" || !isTrustedProxy(peer, trusted) {
+		return host
+	}
+
+	hops := strings.Split(forwardedFor, ",")
+	clientIP := host
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			// A malformed entry ends the trusted chain; use the last good hop
+			break
+		}
+		clientIP = hop.String()
+		if !isTrustedProxy(hop, trusted) {
+			break
+		}
+	}
+	return clientIP
+}
+
+// clientIPMiddleware resolves the client IP once per request and stores it in the context
+func (s *Server) clientIPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientIP := resolveClientIP(r.RemoteAddr, r.Header.Get("X-Forwarded-For"), s.trustedProxies)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientIPKey{}, clientIP)))
+	})
+}
+
+// ClientIP returns the client address resolved by the server's trusted-proxy handling,
+// falling back to the request's direct peer address
+func ClientIP(r *http.Request) string {
+	if clientIP, ok := r.Context().Value(clientIPKey{}).(string); ok {
+		return clientIP
+	}
+	return resolveClientIP(r.RemoteAddr, "", nil)
+}
+
+// listenAndServe runs httpServer with the TLS mode selected by tlsConfig
+func (s *Server) listenAndServe(httpServer *http.Server, tlsConfig TLSConfig) error {
+	switch {
+	case tlsConfig.CertFile != "":
+		s.logger.Info("serving HTTPS with certificate file", "cert_file", tlsConfig.CertFile)
+		return httpServer.ListenAndServeTLS(tlsConfig.CertFile, tlsConfig.KeyFile)
+	case len(tlsConfig.AutocertDomains) > 0:
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(tlsConfig.AutocertDomains...),
+		}
+		if tlsConfig.AutocertCacheDir != "" {
+			manager.Cache = autocert.DirCache(tlsConfig.AutocertCacheDir)
+		}
+		httpServer.TLSConfig = manager.TLSConfig()
+		s.logger.Info("serving HTTPS with autocert", "domains", tlsConfig.AutocertDomains)
+		return httpServer.ListenAndServeTLS("", "")
+	default:
+		return httpServer.ListenAndServe()
+	}
+}
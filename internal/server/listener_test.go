@@ -0,0 +1,91 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestResolveClientIP verifies X-Forwarded-For is only trusted from configured proxies
+func TestResolveClientIP(t *testing.T) {
+	trusted, err := parseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.5"})
+	if err != nil {
+		t.Fatalf("failed to parse trusted proxies: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		expected     string
+	}{
+		{"direct client", "203.0.113.7:5000", "", "203.0.113.7"},
+		{"untrusted peer ignores header", "203.0.113.7:5000", "198.51.100.1", "203.0.113.7"},
+		{"trusted proxy", "10.1.2.3:5000", "198.51.100.1", "198.51.100.1"},
+		{"chained trusted proxies", "10.1.2.3:5000", "198.51.100.1, 192.168.1.5", "198.51.100.1"},
+		{"spoofed left entries ignored", "10.1.2.3:5000", "1.1.1.1, 198.51.100.1", "198.51.100.1"},
+		{"malformed hop stops the chain", "10.1.2.3:5000", "198.51.100.1, junk", "10.1.2.3"},
+		{"ipv6 client", "[2001:db8::1]:5000", "", "2001:db8::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveClientIP(tt.remoteAddr, tt.forwardedFor, trusted); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestParseTrustedProxies_Invalid verifies malformed entries are rejected
+func TestParseTrustedProxies_Invalid(t *testing.T) {
+	for _, entry := range []string{"not-an-ip", "10.0.0.0/99"} {
+		if _, err := parseTrustedProxies([]string{entry}); err == nil {
+			t.Errorf("expected error for %q", entry)
+		}
+	}
+}
+
+// TestClientIPMiddleware verifies handlers see the resolved client IP
+func TestClientIPMiddleware(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{TrustedProxies: []string{"10.0.0.1"}})
+
+	var seen string
+	handler := server.clientIPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = ClientIP(r)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	req.RemoteAddr = "10.0.0.1:4000"
+	req.Header.Set("X-Forwarded-For", "198.51.100.9")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if seen != "198.51.100.9" {
+		t.Errorf("expected forwarded client IP, got %q", seen)
+	}
+}
+
+// TestTLSConfigValidate verifies conflicting TLS settings are rejected
+func TestTLSConfigValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   TLSConfig
+		valid bool
+	}{
+		{"plain http", TLSConfig{}, true},
+		{"cert and key", TLSConfig{CertFile: "cert.pem", KeyFile: "key.pem"}, true},
+		{"autocert", TLSConfig{AutocertDomains: []string{"poker.example.com"}}, true},
+		{"cert without key", TLSConfig{CertFile: "cert.pem"}, false},
+		{"cert and autocert", TLSConfig{CertFile: "cert.pem", KeyFile: "key.pem", AutocertDomains: []string{"poker.example.com"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Config{TLS: tt.cfg}.Validate()
+			if (err == nil) != tt.valid {
+				t.Errorf("expected valid=%v, got error %v", tt.valid, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Game types a table can deal
+const (
+	GameTypeHoldem = "holdem" // No-limit Texas hold'em, the only game dealt today
+)
+
+// Lobby sort orders
+const (
+	LobbySortPlayers  = "players"  // Most seated players first
+	LobbySortPot      = "pot"      // Biggest pot in play first
+	LobbySortActivity = "activity" // Most hands played in the last hour first
+)
+
+// LobbyFilter narrows and orders the lobby, and is the payload of query_lobby messages
+// Tables must match every set criterion
+// The zero LobbyFilter lists every table in configuration order
+type LobbyFilter struct {
+	Tags         []string `json:"tags,omitempty"`        // Tables must carry all of these tags
+	Theme        string   `json:"theme,omitempty"`       // Tables must use this theme
+	MinBigBlind  int      `json:"minBigBlind,omitempty"` // Big blind range, inclusive; 0 means no bound
+	MaxBigBlind  int      `json:"maxBigBlind,omitempty"`
+	MinOpenSeats int      `json:"minOpenSeats,omitempty"` // Tables must have at least this many empty seats
+	GameType     string   `json:"gameType,omitempty"`
+	Speed        string   `json:"speed,omitempty"`
+	Sort         string   `json:"sort,omitempty"`      // LobbySortPlayers, LobbySortPot or LobbySortActivity; empty keeps configuration order
+	Ascending    bool     `json:"ascending,omitempty"` // Reverse the sort to smallest first
+	Offset       int      `json:"offset,omitempty"`    // Tables to skip after sorting
+	Limit        int      `json:"limit,omitempty"`     // Maximum tables returned; 0 means all
+}
+
+// LobbyQueryResultPayload represents the payload for lobby_query_result messages
+type LobbyQueryResultPayload struct {
+	Tables []TableInfo `json:"tables"`
+	Total  int         `json:"total"` // Matching tables before offset and limit
+}
+
+// validate reports the first filter setting that cannot be applied
+func (f LobbyFilter) validate() error {
+	if f.MinBigBlind < 0 || f.MaxBigBlind < 0 || f.MinOpenSeats < 0 || f.Offset < 0 || f.Limit < 0 {
+		return fmt.Errorf("invalid_lobby_query")
+	}
+	switch f.Sort {
+	case "", LobbySortPlayers, LobbySortPot, LobbySortActivity:
+		return nil
+	default:
+		return fmt.Errorf("invalid_lobby_query")
+	}
+}
+
+// parseLobbyFilter reads a filter from query parameters: tag (repeatable or comma-separated),
+// theme, min_bb, max_bb, open_seats, game, speed, sort, order (asc or desc), offset and limit
+func parseLobbyFilter(query url.Values) (LobbyFilter, error) {
+	var filter LobbyFilter
+	for _, value := range query["tag"] {
+		filter.Tags = append(filter.Tags, strings.Split(value, ",")...)
+	}
+	filter.Tags = normalizeTags(filter.Tags)
+	filter.Theme = strings.TrimSpace(query.Get("theme"))
+	filter.GameType = strings.TrimSpace(query.Get("game"))
+	filter.Speed = strings.TrimSpace(query.Get("speed"))
+	filter.Sort = query.Get("sort")
+
+	switch query.Get("order") {
+	case "", "desc":
+	case "asc":
+		filter.Ascending = true
+	default:
+		return LobbyFilter{}, fmt.Errorf("invalid order")
+	}
+
+	ints := []struct {
+		param  string
+		target *int
+	}{
+		{"min_bb", &filter.MinBigBlind},
+		{"max_bb", &filter.MaxBigBlind},
+		{"open_seats", &filter.MinOpenSeats},
+		{"offset", &filter.Offset},
+		{"limit", &filter.Limit},
+	}
+	for _, field := range ints {
+		value := query.Get(field.param)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return LobbyFilter{}, fmt.Errorf("invalid %s", field.param)
+		}
+		*field.target = parsed
+	}
+
+	if err := filter.validate(); err != nil {
+		return LobbyFilter{}, err
+	}
+	return filter, nil
+}
+
+// matches reports whether table passes the filter
+func (f LobbyFilter) matches(table TableInfo) bool {
+	if f.Theme != "" && !strings.EqualFold(f.Theme, table.Theme) {
+		return false
+	}
+	for _, tag := range f.Tags {
+		if !slices.Contains(table.Tags, tag) {
+			return false
+		}
+	}
+	if f.MinBigBlind > 0 && table.BigBlind < f.MinBigBlind {
+		return false
+	}
+	if f.MaxBigBlind > 0 && table.BigBlind > f.MaxBigBlind {
+		return false
+	}
+	if table.MaxSeats-table.SeatsOccupied < f.MinOpenSeats {
+		return false
+	}
+	if f.GameType != "" && !strings.EqualFold(f.GameType, table.GameType) {
+		return false
+	}
+	if f.Speed != "" && !strings.EqualFold(f.Speed, table.Speed) {
+		return false
+	}
+	return true
+}
+
+// QueryLobby returns the page of the lobby listing selected by filter, and how many tables
+// matched in total
+func (s *Server) QueryLobby(filter LobbyFilter) ([]TableInfo, int) {
+	tables := s.GetLobbyState()
+	matching := make([]TableInfo, 0, len(tables))
+	for _, table := range tables {
+		if filter.matches(table) {
+			matching = append(matching, table)
+		}
+	}
+
+	var key func(TableInfo) int
+	switch filter.Sort {
+	case LobbySortPlayers:
+		key = func(table TableInfo) int { return table.SeatsOccupied }
+	case LobbySortPot:
+		key = func(table TableInfo) int { return table.Pot }
+	case LobbySortActivity:
+		key = func(table TableInfo) int { return table.HandsPerHour }
+	}
+	if key != nil {
+		// Stable so ties keep configuration order
+		sort.SliceStable(matching, func(i, j int) bool {
+			if filter.Ascending {
+				return key(matching[i]) < key(matching[j])
+			}
+			return key(matching[i]) > key(matching[j])
+		})
+	}
+
+	total := len(matching)
+	start := min(filter.Offset, total)
+	end := total
+	if filter.Limit > 0 {
+		end = min(start+filter.Limit, total)
+	}
+	return matching[start:end], total
+}
+
+// handleLobbyQuery serves GET /api/lobby: the lobby listing, filtered, sorted and paged by
+// the query parameters, with the number of matching tables in X-Total-Count
+func (s *Server) handleLobbyQuery(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseLobbyFilter(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tables, total := s.QueryLobby(filter)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	json.NewEncoder(w).Encode(tables)
+}
+
+// HandleQueryLobby processes a query_lobby message and replies with lobby_query_result
+// Unlike lobby_state, which always lists every table, the reply holds only the requested page
+func (c *Client) HandleQueryLobby(server *Server, logger *slog.Logger, payload []byte) error {
+	var filter LobbyFilter
+	if len(payload) > 0 {
+		if err := json.Unmarshal(payload, &filter); err != nil {
+			return invalidPayloadError("query_lobby", err)
+		}
+	}
+	filter.Tags = normalizeTags(filter.Tags)
+	if err := filter.validate(); err != nil {
+		return err
+	}
+
+	tables, total := server.QueryLobby(filter)
+	logger.Debug("lobby queried", "token", c.Token, "matching", total, "returned", len(tables))
+	return c.sendMessage("lobby_query_result", LobbyQueryResultPayload{Tables: tables, Total: total})
+}
+
+// normalizeTags lowercases and trims tags, dropping empty and duplicate ones
+func normalizeTags(tags []string) []string {
+	var normalized []string
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
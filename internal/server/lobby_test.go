@@ -0,0 +1,141 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+// newThemedServer returns a server with three tables carrying lobby metadata
+func newThemedServer() *Server {
+	return NewServerWithConfig(slog.Default(), Config{Tables: []TableConfig{
+		{Name: "Starter", SmallBlind: 1, BigBlind: 2, BuyIn: 200, Theme: "classic", Description: "Learn the ropes", Tags: []string{"Beginners", " beginners "}},
+		{Name: "Deep", SmallBlind: 5, BigBlind: 10, BuyIn: 2000, Theme: "neon", Tags: []string{"deep stack"}},
+		{Name: "Deep Starter", SmallBlind: 1, BigBlind: 2, BuyIn: 1000, Theme: "classic", Tags: []string{"beginners", "deep stack"}},
+	}})
+}
+
+// queryLobby requests GET target and decodes the listing
+func queryLobby(t *testing.T, server *Server, target string) []TableInfo {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	server.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: expected 200, got %d", target, rec.Code)
+	}
+	var tables []TableInfo
+	if err := json.Unmarshal(rec.Body.Bytes(), &tables); err != nil {
+		t.Fatalf("%s: invalid listing: %v", target, err)
+	}
+	return tables
+}
+
+// tableNames returns the names of tables in order
+func tableNames(tables []TableInfo) []string {
+	names := make([]string, len(tables))
+	for i, table := range tables {
+		names[i] = table.Name
+	}
+	return names
+}
+
+// TestLobbyQuery_FiltersByTagsAndTheme verifies metadata is listed and filters combine
+func TestLobbyQuery_FiltersByTagsAndTheme(t *testing.T) {
+	server := newThemedServer()
+
+	all := queryLobby(t, server, "/api/lobby")
+	if len(all) != 3 {
+		t.Fatalf("expected 3 tables, got %v", tableNames(all))
+	}
+	if all[0].Description != "Learn the ropes" || all[0].Theme != "classic" || len(all[0].Tags) != 1 || all[0].Tags[0] != "beginners" {
+		t.Errorf("expected normalized metadata, got %+v", all[0])
+	}
+
+	cases := map[string][]string{
+		"/api/lobby?tag=beginners":                  {"Starter", "Deep Starter"},
+		"/api/lobby?tag=Beginners,deep%20stack":     {"Deep Starter"},
+		"/api/lobby?tag=beginners&tag=deep+stack":   {"Deep Starter"},
+		"/api/lobby?theme=NEON":                     {"Deep"},
+		"/api/lobby?theme=classic&tag=deep%20stack": {"Deep Starter"},
+		"/api/lobby?tag=heads-up":                   {},
+	}
+	for target, want := range cases {
+		got := tableNames(queryLobby(t, server, target))
+		if len(got) != len(want) {
+			t.Errorf("%s: expected %v, got %v", target, want, got)
+			continue
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s: expected %v, got %v", target, want, got)
+				break
+			}
+		}
+	}
+}
+
+// TestQueryLobby_StakesSeatsSortAndPaging verifies the stakes and seat filters, sorting and paging
+func TestQueryLobby_StakesSeatsSortAndPaging(t *testing.T) {
+	server := newThemedServer()
+	seatTwoPlayers(server.tables[2])
+	table := server.tables[1]
+	for i := 0; i < 5; i++ {
+		token := fmt.Sprintf("player%d", i)
+		table.Seats[i].Token = &token
+		table.Seats[i].Status = "waiting"
+	}
+	table.CurrentHand = &Hand{Pot: 40, PlayerBets: map[int]int{0: 10}}
+
+	cases := map[string][]string{
+		"/api/lobby?max_bb=2":                      {"Starter", "Deep Starter"},
+		"/api/lobby?min_bb=5&max_bb=10":            {"Deep"},
+		"/api/lobby?open_seats=2":                  {"Starter", "Deep Starter"},
+		"/api/lobby?sort=players":                  {"Deep", "Deep Starter", "Starter"},
+		"/api/lobby?sort=players&order=asc":        {"Starter", "Deep Starter", "Deep"},
+		"/api/lobby?sort=pot&limit=1":              {"Deep"},
+		"/api/lobby?sort=players&offset=1&limit=1": {"Deep Starter"},
+		"/api/lobby?game=holdem&speed=regular":     {"Starter", "Deep", "Deep Starter"},
+		"/api/lobby?game=omaha":                    {},
+	}
+	for target, want := range cases {
+		if got := tableNames(queryLobby(t, server, target)); !slices.Equal(got, want) {
+			t.Errorf("%s: expected %v, got %v", target, want, got)
+		}
+	}
+
+	tables, total := server.QueryLobby(LobbyFilter{Sort: LobbySortPot, Limit: 1})
+	if total != 3 || tables[0].Pot != 50 {
+		t.Errorf("expected total 3 and a 50 chip pot first, got %d and %+v", total, tables)
+	}
+
+	rec := httptest.NewRecorder()
+	server.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/lobby?sort=size", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown sort, got %d", rec.Code)
+	}
+}
+
+// TestHandleQueryLobby_RepliesWithPage verifies query_lobby answers only the requested tables
+func TestHandleQueryLobby_RepliesWithPage(t *testing.T) {
+	server := newThemedServer()
+	client := &Client{hub: server.hub, send: make(chan []byte, 256)}
+
+	if err := client.HandleQueryLobby(server, slog.Default(), []byte(`{"tags":["Beginners"],"limit":1}`)); err != nil {
+		t.Fatalf("query_lobby: %v", err)
+	}
+	var msg WebSocketMessage
+	json.Unmarshal(<-client.send, &msg)
+	var result LobbyQueryResultPayload
+	json.Unmarshal(msg.Payload, &result)
+	if msg.Type != "lobby_query_result" || result.Total != 2 || len(result.Tables) != 1 || result.Tables[0].Name != "Starter" {
+		t.Errorf("unexpected reply %s %+v", msg.Type, result)
+	}
+
+	if err := client.HandleQueryLobby(server, slog.Default(), []byte(`{"limit":-1}`)); err == nil {
+		t.Error("expected a negative limit to be rejected")
+	}
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"regexp"
+	"sync"
+)
+
+// redactedValue replaces card data in log records
+const redactedValue = "[redacted]"
+
+// cardType is the type whose values RedactingHandler keeps out of the logs
+var cardType = reflect.TypeFor[Card]()
+
+// cardText matches a card anywhere in a string, such as "As" in "As Kd", or a card written as
+// JSON, such as {"Rank":"A","Suit":"s"} in a marshaled message
+var cardText = regexp.MustCompile(`\b[2-9TJQKA][shdc]\b|"Rank":\s*"[2-9TJQKA]"`)
+
+// cardTypes caches, by reflect.Type, whether a type holds cards
+var cardTypes sync.Map
+
+// RedactingHandler wraps a slog.Handler so that no log record carries cards. An attribute whose
+// value is or holds a Card (a card, hole cards, a board, a deck, an Event or a Hand) or is a
+// string with a card in it, like "As Kd" or a marshaled table_state, is written as "[redacted]".
+// Board cards go too, since a handler cannot tell them from hole cards, and so does any text
+// that merely looks like a card. Cards inside interface values, such as []any, are not found,
+// so they must not be logged that way.
+// Forensic mode lets cards through, for investigating a problem on a server whose logs are kept
+// private; it must be turned on explicitly.
+type RedactingHandler struct {
+	next     slog.Handler
+	forensic bool
+}
+
+// NewRedactingHandler wraps next, redacting cards unless forensic is set
+func NewRedactingHandler(next slog.Handler, forensic bool) *RedactingHandler {
+	return &RedactingHandler{next: next, forensic: forensic}
+}
+
+// Enabled reports whether the wrapped handler handles records at level
+func (h *RedactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle passes r on with its cards redacted
+func (h *RedactingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if h.forensic {
+		return h.next.Handle(ctx, r)
+	}
+	redacted := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(redactAttr(a))
+		return true
+	})
+	return h.next.Handle(ctx, redacted)
+}
+
+// WithAttrs returns a handler adding attrs, with their cards redacted, to every record
+func (h *RedactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if !h.forensic {
+		attrs = redactAttrs(attrs)
+	}
+	return &RedactingHandler{next: h.next.WithAttrs(attrs), forensic: h.forensic}
+}
+
+// WithGroup returns a handler nesting later attributes under name
+func (h *RedactingHandler) WithGroup(name string) slog.Handler {
+	return &RedactingHandler{next: h.next.WithGroup(name), forensic: h.forensic}
+}
+
+// redactAttrs returns attrs with their cards redacted
+func redactAttrs(attrs []slog.Attr) []slog.Attr {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = redactAttr(a)
+	}
+	return redacted
+}
+
+// redactAttr returns a with any cards in its value, or in a group's members, redacted
+func redactAttr(a slog.Attr) slog.Attr {
+	value := a.Value.Resolve()
+	switch value.Kind() {
+	case slog.KindGroup:
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(redactAttrs(value.Group())...)}
+	case slog.KindString:
+		if cardText.MatchString(value.String()) {
+			return slog.String(a.Key, redactedValue)
+		}
+	case slog.KindAny:
+		if holdsCards(reflect.TypeOf(value.Any())) {
+			return slog.String(a.Key, redactedValue)
+		}
+	}
+	return slog.Attr{Key: a.Key, Value: value}
+}
+
+// holdsCards reports whether values of type t are or contain cards
+func holdsCards(t reflect.Type) bool {
+	if t == nil {
+		return false
+	}
+	if holds, ok := cardTypes.Load(t); ok {
+		return holds.(bool)
+	}
+	holds := typeHoldsCards(t, make(map[reflect.Type]bool))
+	cardTypes.Store(t, holds)
+	return holds
+}
+
+// typeHoldsCards walks t's elements and fields looking for Card, skipping types already seen
+func typeHoldsCards(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if t == cardType {
+		return true
+	}
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Chan:
+		return typeHoldsCards(t.Elem(), seen)
+	case reflect.Map:
+		return typeHoldsCards(t.Key(), seen) || typeHoldsCards(t.Elem(), seen)
+	case reflect.Struct:
+		for i := range t.NumField() {
+			if typeHoldsCards(t.Field(i).Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
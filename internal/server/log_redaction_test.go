@@ -0,0 +1,105 @@
+package server
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// lockedBuffer is a log destination safe to read while the server writes to it
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// TestRedactingHandler verifies cards are redacted in every form they can be logged in, unless
+// forensic mode is on
+func TestRedactingHandler(t *testing.T) {
+	hole := map[int][]Card{0: cards("As Kd")}
+	log := func(forensic bool) string {
+		var out bytes.Buffer
+		logger := slog.New(NewRedactingHandler(slog.NewJSONHandler(&out, nil), forensic))
+		logger.With("dealt", hole).WithGroup("hand").Info("leak",
+			"card", NewCard("A", "s"),
+			"cards", hole[0],
+			"event", Event{Type: EventHandStarted, HoleCards: hole},
+			"text", "As Kd",
+			"sentence", "Alice shows As and Kd",
+			"json", `{"seat":0,"holeCards":[{"Rank":"A","Suit":"s"}]}`,
+			slog.Group("nested", "hole", hole),
+			"seat", 0,
+			"name", "Alice",
+		)
+		return out.String()
+	}
+
+	redacted := log(false)
+	if strings.Contains(redacted, `"Rank"`) || strings.Contains(redacted, `\"Rank\"`) || strings.Contains(redacted, `"As Kd"`) {
+		t.Errorf("expected no cards in %s", redacted)
+	}
+	if strings.Count(redacted, redactedValue) != 8 || !strings.Contains(redacted, `"name":"Alice"`) || !strings.Contains(redacted, `"seat":0`) {
+		t.Errorf("expected eight attributes redacted and the rest kept, got %s", redacted)
+	}
+	if forensic := log(true); strings.Contains(forensic, redactedValue) || !strings.Contains(forensic, `{"Rank":"A","Suit":"s"}`) {
+		t.Errorf("expected cards logged in forensic mode, got %s", forensic)
+	}
+}
+
+// TestRedactingHandler_NoCardsFromHand plays hands with debug logging on, sending each player the
+// table state mid-hand, and checks that no log line carries any card dealt
+func TestRedactingHandler_NoCardsFromHand(t *testing.T) {
+	var out lockedBuffer
+	logger := slog.New(NewRedactingHandler(slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug}), false))
+	server := NewServerWithConfig(logger, Config{
+		Tables: []TableConfig{{Name: "Main", SmallBlind: 10, BigBlind: 20, BuyIn: 1000}},
+	})
+	table := server.tables[0]
+	clients := seatNamed(t, server, table, "Alice", "Bob", "Carol")
+
+	var dealt []Card
+	for range 3 {
+		if err := table.StartHand(); err != nil {
+			t.Fatal(err)
+		}
+		table.mu.RLock()
+		for _, holeCards := range table.CurrentHand.HoleCards {
+			dealt = append(dealt, holeCards...)
+		}
+		table.mu.RUnlock()
+		for _, client := range clients {
+			if err := client.SendTableState(server, table.ID, logger); err != nil {
+				t.Fatal(err)
+			}
+			drainRawMessages(client)
+		}
+		playOutChecking(t, server, table)
+	}
+	if !eventually(func() bool { return strings.Count(out.String(), `"msg":"hand completed"`) == 3 }) {
+		t.Fatal("expected a log record for each hand")
+	}
+
+	// Cards marshaled into a logged string come out with their quotes escaped
+	logs := out.String()
+	if strings.Contains(logs, `"Rank"`) || strings.Contains(logs, `\"Rank\"`) {
+		t.Error("expected no card in the logs")
+	}
+	for _, card := range dealt {
+		if strings.Contains(logs, `"`+card.String()+`"`) {
+			t.Errorf("expected hole card %s kept out of the logs", card)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// LoyaltyConfig awards loyalty points for the rake players are charged, redeemable for chips or
+// tournament tickets. Points are earned only from rake in Currency and chips are redeemed in it,
+// so chips never move between currencies. The zero value awards no points.
+type LoyaltyConfig struct {
+	PointsPerRake int            `yaml:"pointsPerRake"` // Points per chip of rake charged; 0 disables loyalty
+	Currency      ChipCurrency   `yaml:"currency"`      // Currency whose rake earns points; empty is play money
+	PointsPerChip int            `yaml:"pointsPerChip"` // Points one redeemed chip costs; 0 disables chip redemptions
+	Tickets       map[string]int `yaml:"tickets"`       // Points price of a tournament ticket, by tournament reference
+}
+
+// validate reports the first invalid loyalty setting
+func (c LoyaltyConfig) validate() error {
+	if c.PointsPerRake < 0 || c.PointsPerChip < 0 {
+		return fmt.Errorf("loyalty.pointsPerRake and loyalty.pointsPerChip must not be negative")
+	}
+	if err := validateCurrency(c.Currency); err != nil {
+		return fmt.Errorf("loyalty.currency: %w", err)
+	}
+	for reference, price := range c.Tickets {
+		if reference == "" || price <= 0 {
+			return fmt.Errorf("loyalty.tickets: every ticket needs a reference and a positive price")
+		}
+	}
+	return nil
+}
+
+// currency returns the currency loyalty points are earned and redeemed in
+func (c LoyaltyConfig) currency() ChipCurrency {
+	if c.Currency == "" {
+		return CurrencyPlay
+	}
+	return c.Currency
+}
+
+// LoyaltyRedeemRequest is the body of POST /admin/accounts/{name}/loyalty/redeem; set one of
+// Chips and Ticket
+type LoyaltyRedeemRequest struct {
+	Chips  int    `json:"chips,omitempty"`  // Chips to credit to the player's balance, at PointsPerChip each
+	Ticket string `json:"ticket,omitempty"` // Reference of the tournament ticket to buy
+}
+
+// LoyaltyRedemption is the result of a redemption, and of GET /admin/accounts/{name}/loyalty
+// with only Player and Points set
+type LoyaltyRedemption struct {
+	Player      string         `json:"player"`
+	Points      int            `json:"points"` // Points left
+	PointsSpent int            `json:"pointsSpent,omitempty"`
+	Balance     *int           `json:"balance,omitempty"` // Balance after a chip redemption
+	Item        *InventoryItem `json:"item,omitempty"`    // Ticket bought
+}
+
+var (
+	errInsufficientPoints = errors.New("insufficient_points")
+	errInvalidRedemption  = errors.New("set either chips or a ticket offered for points")
+	errChipsNotRedeemable = errors.New("chip redemptions need pointsPerChip and bankroll accounting")
+)
+
+// AddLoyaltyPoints credits points to the named account and returns its new total
+func (s *AccountStore) AddLoyaltyPoints(name string, points int) (int, error) {
+	var total int
+	err := s.update(name, func(account *Account) error {
+		account.LoyaltyPoints += points
+		total = account.LoyaltyPoints
+		return nil
+	})
+	return total, err
+}
+
+// SpendLoyaltyPoints takes points from the named account, or fails with errInsufficientPoints
+// Returns the points left
+func (s *AccountStore) SpendLoyaltyPoints(name string, points int) (int, error) {
+	var left int
+	err := s.update(name, func(account *Account) error {
+		if account.LoyaltyPoints < points {
+			return errInsufficientPoints
+		}
+		account.LoyaltyPoints -= points
+		left = account.LoyaltyPoints
+		return nil
+	})
+	return left, err
+}
+
+// LoyaltyPoints returns the named account's loyalty points
+func (s *AccountStore) LoyaltyPoints(name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts[accountKey(name)].LoyaltyPoints
+}
+
+// RedeemLoyaltyPoints spends the named account's points on chips credited to the player's live
+// session, or on a tournament ticket added to their inventory. The points are given back if
+// the chips or ticket cannot be delivered.
+func (s *Server) RedeemLoyaltyPoints(name string, req LoyaltyRedeemRequest) (LoyaltyRedemption, error) {
+	cfg := s.Config().Loyalty
+	result := LoyaltyRedemption{Player: accountKey(name)}
+
+	var token string
+	switch {
+	case req.Chips < 0:
+		return result, errInvalidRedemption
+	case req.Chips > 0 && req.Ticket == "":
+		if cfg.PointsPerChip == 0 || !s.Config().Bankroll.Enabled {
+			return result, errChipsNotRedeemable
+		}
+		tokens := s.sessionManager.TokensByName(name)
+		switch {
+		case len(tokens) == 0:
+			return result, errPlayerNotFound
+		case len(tokens) > 1:
+			return result, errPlayerAmbiguous
+		}
+		token = tokens[0]
+		// Compared before multiplying, so no request is large enough to overflow into a cheap one
+		if req.Chips > s.accounts.LoyaltyPoints(name)/cfg.PointsPerChip {
+			return result, errInsufficientPoints
+		}
+		result.PointsSpent = req.Chips * cfg.PointsPerChip
+	case req.Chips == 0 && cfg.Tickets[req.Ticket] > 0:
+		result.PointsSpent = cfg.Tickets[req.Ticket]
+	default:
+		return result, errInvalidRedemption
+	}
+
+	left, err := s.accounts.SpendLoyaltyPoints(name, result.PointsSpent)
+	if err != nil {
+		return result, err
+	}
+	result.Points = left
+
+	if token != "" {
+		balance, err := s.sessionManager.AdjustBalance(token, cfg.currency(), req.Chips)
+		if err == nil {
+			result.Balance = &balance
+			s.sendBalances(token)
+			return result, nil
+		}
+		return result, s.refundLoyaltyPoints(name, result.PointsSpent, err)
+	}
+
+	item, err := s.accounts.GrantItem(name, InventoryItem{Kind: ItemTournamentTicket, Reference: req.Ticket, Source: "loyalty", GrantedAt: time.Now()})
+	if err != nil {
+		return result, s.refundLoyaltyPoints(name, result.PointsSpent, err)
+	}
+	result.Item = &item
+	return result, nil
+}
+
+// refundLoyaltyPoints gives back points spent on a redemption that failed with cause
+func (s *Server) refundLoyaltyPoints(name string, points int, cause error) error {
+	if _, err := s.accounts.AddLoyaltyPoints(name, points); err != nil {
+		s.logger.Error("loyalty points lost on a failed redemption", "name", name, "points", points, "error", err)
+	}
+	return cause
+}
+
+// handleLoyaltyPoints writes the loyalty points of the account in the path
+func (s *Server) handleLoyaltyPoints(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	writeAdminJSON(w, http.StatusOK, LoyaltyRedemption{Player: accountKey(name), Points: s.accounts.LoyaltyPoints(name)})
+}
+
+// handleRedeemLoyalty spends the loyalty points of the account in the path (LoyaltyRedeemRequest)
+func (s *Server) handleRedeemLoyalty(w http.ResponseWriter, r *http.Request) {
+	var req LoyaltyRedeemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid redeem request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	name := chi.URLParam(r, "name")
+	result, err := s.RedeemLoyaltyPoints(name, req)
+	switch {
+	case errors.Is(err, errInvalidRedemption):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case errors.Is(err, errPlayerNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	s.logger.Info("loyalty points redeemed", "name", name, "points", result.PointsSpent, "chips", req.Chips, "ticket", req.Ticket, "client_ip", ClientIP(r))
+	writeAdminJSON(w, http.StatusOK, result)
+}
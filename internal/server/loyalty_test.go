@@ -0,0 +1,100 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// useLoyalty sets the server's loyalty settings
+func useLoyalty(server *Server, cfg LoyaltyConfig) {
+	updateConfig(server, func(config *Config) { config.Loyalty = cfg })
+}
+
+// TestRunRakeLedger_AwardsLoyaltyPoints verifies each player dealt in earns points for the rake
+// charged to them, at tables of the loyalty currency only
+func TestRunRakeLedger_AwardsLoyaltyPoints(t *testing.T) {
+	server := newBankrollServer()
+	useLoyalty(server, LoyaltyConfig{PointsPerRake: 2})
+	alice, _ := server.sessionManager.CreateSession("Alice")
+	bob, _ := server.sessionManager.CreateSession("Bob")
+	seats := map[int]string{0: alice.Token, 1: bob.Token}
+	play, ledger := server.tables[0], server.tables[1]
+
+	events := make(chan Event, 8)
+	events <- Event{Type: EventHandStarted, TableID: play.ID, Seats: seats}
+	events <- Event{Type: EventHandEnded, TableID: play.ID, Rake: map[int]int{0: 3, 1: 1}}
+	events <- Event{Type: EventHandStarted, TableID: ledger.ID, Seats: seats}
+	events <- Event{Type: EventHandEnded, TableID: ledger.ID, Rake: map[int]int{0: 5, 1: 5}}
+	// A cancelled hand charges no rake
+	events <- Event{Type: EventHandStarted, TableID: play.ID, Seats: seats}
+	events <- Event{Type: EventHandCancelled, TableID: play.ID}
+	events <- Event{Type: EventHandEnded, TableID: play.ID, Rake: map[int]int{0: 9}}
+	close(events)
+	server.RunRakeLedger(events)
+
+	if got := server.accounts.LoyaltyPoints("alice"); got != 6 {
+		t.Errorf("expected Alice to earn 6 points, got %d", got)
+	}
+	if got := server.accounts.LoyaltyPoints("bob"); got != 2 {
+		t.Errorf("expected Bob to earn 2 points, got %d", got)
+	}
+}
+
+// TestRedeemLoyaltyPoints verifies points buy chips and tickets over the admin API, and are kept
+// when a redemption is refused
+func TestRedeemLoyaltyPoints(t *testing.T) {
+	server := newBankrollServer()
+	useLoyalty(server, LoyaltyConfig{PointsPerRake: 1, PointsPerChip: 10, Tickets: map[string]int{"sunday": 300}})
+	alice, _ := server.sessionManager.CreateSession("Alice")
+	if _, err := server.accounts.AddLoyaltyPoints("Alice", 1000); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := adminRequest(server, http.MethodPost, "/admin/accounts/Alice/loyalty/redeem", "secret", `{"chips": 50}`)
+	var result LoyaltyRedemption
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("status %d: %v", rec.Code, err)
+	}
+	if rec.Code != http.StatusOK || result.PointsSpent != 500 || result.Points != 500 || result.Balance == nil || *result.Balance != 50 {
+		t.Errorf("expected 50 chips for 500 points, got %d %+v", rec.Code, result)
+	}
+	if balances, _ := server.sessionManager.Balances(alice.Token); balances[CurrencyPlay] != 50 {
+		t.Errorf("expected a play balance of 50, got %v", balances)
+	}
+
+	rec = adminRequest(server, http.MethodPost, "/admin/accounts/Alice/loyalty/redeem", "secret", `{"ticket": "sunday"}`)
+	result = LoyaltyRedemption{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("status %d: %v", rec.Code, err)
+	}
+	if result.Points != 200 || result.Item == nil || result.Item.Kind != ItemTournamentTicket || result.Item.Reference != "sunday" {
+		t.Errorf("expected a sunday ticket for 300 points, got %+v", result)
+	}
+
+	for _, tt := range []struct {
+		body string
+		code int
+	}{
+		{`{"ticket": "sunday"}`, http.StatusConflict}, // 200 points left
+		{`{"ticket": "monday"}`, http.StatusBadRequest},
+		{`{"chips": 10, "ticket": "sunday"}`, http.StatusBadRequest},
+		{`{"chips": -5}`, http.StatusBadRequest},
+		{`{"chips": 21}`, http.StatusConflict},                 // 210 points at 10 each
+		{`{"chips": 922337203685477581}`, http.StatusConflict}, // The price overflows int64
+		{`{}`, http.StatusBadRequest},
+	} {
+		if rec := adminRequest(server, http.MethodPost, "/admin/accounts/Alice/loyalty/redeem", "secret", tt.body); rec.Code != tt.code {
+			t.Errorf("%s: expected %d, got %d", tt.body, tt.code, rec.Code)
+		}
+	}
+	if rec := adminRequest(server, http.MethodPost, "/admin/accounts/Carol/loyalty/redeem", "secret", `{"chips": 1}`); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 redeeming chips for a player who is not connected, got %d", rec.Code)
+	}
+
+	rec = adminRequest(server, http.MethodGet, "/admin/accounts/Alice/loyalty", "secret", "")
+	result = LoyaltyRedemption{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil || result.Points != 200 {
+		t.Errorf("expected 200 points left, got %+v (%v)", result, err)
+	}
+}
@@ -0,0 +1,186 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// tablePlayerLimit is how many players (by token) a table remembers clock calls and time bank use
+// for. Past it, the players no longer seated are forgotten, so a long-lived table with a steady
+// stream of newcomers does not grow without bound.
+// The hand's maps are bounded by the seats, the history by tableHistoryLength, replays by
+// replayHandLimit, activity by activityWindow and superlatives by superlativeDays and
+// superlativeTableLimit. Kept seats, reservations and observers go with their players.
+const tablePlayerLimit = 200
+
+// Rough sizes behind TableMemory.Bytes: a map entry keyed by a session token, with its key and a
+// small value, and a hand remembered for the activity indicators. Replays, history and
+// superlatives are measured by their JSON encoding.
+const (
+	tokenEntryBytes = 120
+	recentHandBytes = 48
+)
+
+// TableMemory counts what one table holds in memory, by structure
+type TableMemory struct {
+	TableID      string `json:"tableId"`
+	ClockCalls   int    `json:"clockCalls"`   // Players whose last clock call is remembered
+	TimeBanks    int    `json:"timeBanks"`    // Players whose time bank use is remembered
+	Disconnected int    `json:"disconnected"` // Seats kept for dropped connections
+	Reservations int    `json:"reservations"`
+	Challengers  int    `json:"challengers"`
+	History      int    `json:"history"`     // Hand summaries in the history panel
+	Replays      int    `json:"replays"`     // Finished hands kept for replays
+	RecentHands  int    `json:"recentHands"` // Hands remembered for the lobby's activity indicators
+	Observers    int    `json:"observers"`
+	Superlatives int    `json:"superlatives"` // Days the table's records are kept for
+	Bytes        int    `json:"bytes"`        // Estimated size of all of the above
+}
+
+// MemoryLimits are the caps on the structures in TableMemory
+type MemoryLimits struct {
+	Players int `json:"players"` // Per table, for ClockCalls and TimeBanks
+	History int `json:"history"` // Per table
+	Replays int `json:"replays"` // Across all tables
+	// Superlatives are kept for Days days, for at most Tables tables a day
+	SuperlativeDays   int `json:"superlativeDays"`
+	SuperlativeTables int `json:"superlativeTables"`
+	Emotes            int `json:"emotes"` // Players whose emote cooldown is remembered, before expired ones go
+}
+
+// MemoryReport is the response of GET /admin/memory
+type MemoryReport struct {
+	Tables []TableMemory `json:"tables"`
+	Emotes int           `json:"emotes"` // Players whose last emote is remembered, across all tables
+	Limits MemoryLimits  `json:"limits"`
+}
+
+// forgetPlayerLocked drops what the table remembers about the player with token
+// (internal, must be called with lock held)
+func (t *Table) forgetPlayerLocked(token string) {
+	delete(t.clockCalls, token)
+	delete(t.timeBankUsed, token)
+}
+
+// trimPlayersLocked forgets the players no longer seated once the table remembers more than
+// tablePlayerLimit (internal, must be called with lock held)
+func (t *Table) trimPlayersLocked() {
+	if len(t.clockCalls) <= tablePlayerLimit && len(t.timeBankUsed) <= tablePlayerLimit {
+		return
+	}
+	seated := make(map[string]bool, len(t.Seats))
+	for _, seat := range t.Seats {
+		if seat.Token != nil {
+			seated[*seat.Token] = true
+		}
+	}
+	for token := range t.clockCalls {
+		if !seated[token] {
+			delete(t.clockCalls, token)
+		}
+	}
+	for token := range t.timeBankUsed {
+		if !seated[token] {
+			delete(t.timeBankUsed, token)
+		}
+	}
+}
+
+// memory counts what the table holds in memory; the server fills in the rest
+func (t *Table) memory() TableMemory {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return TableMemory{
+		TableID:      t.ID,
+		ClockCalls:   len(t.clockCalls),
+		TimeBanks:    len(t.timeBankUsed),
+		Disconnected: len(t.disconnected),
+		Reservations: len(t.reservations),
+		Challengers:  len(t.challengers),
+	}
+}
+
+// forgetSession drops what the server remembers about an ended session
+func (s *Server) forgetSession(token string) {
+	s.stats.Forget(token)
+	s.emotes.forget(token)
+
+	s.mu.RLock()
+	tables := append([]*Table(nil), s.tables...)
+	s.mu.RUnlock()
+	for _, table := range tables {
+		table.mu.Lock()
+		table.forgetPlayerLocked(token)
+		table.mu.Unlock()
+	}
+}
+
+// Replays counts the finished hands kept from the table with tableID, and their encoded size
+func (rs *ReplayStore) Replays(tableID string) (count, bytes int) {
+	if rs == nil {
+		return 0, 0
+	}
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	for _, replay := range rs.hands {
+		if replay.TableID == tableID {
+			count++
+			bytes += jsonSize(replay)
+		}
+	}
+	return count, bytes
+}
+
+// jsonSize returns the length of v encoded as JSON
+func jsonSize(v any) int {
+	data, _ := json.Marshal(v)
+	return len(data)
+}
+
+// memoryReport counts what every table holds in memory
+func (s *Server) memoryReport(now time.Time) MemoryReport {
+	s.mu.RLock()
+	tables := append([]*Table(nil), s.tables...)
+	s.mu.RUnlock()
+
+	report := MemoryReport{
+		Tables: make([]TableMemory, 0, len(tables)),
+		Emotes: s.emotes.Players(),
+		Limits: MemoryLimits{
+			Players:           tablePlayerLimit,
+			History:           tableHistoryLength,
+			Replays:           replayHandLimit,
+			SuperlativeDays:   superlativeDays,
+			SuperlativeTables: superlativeTableLimit,
+			Emotes:            emoteLimiterLimit,
+		},
+	}
+	for _, table := range tables {
+		memory := table.memory()
+		history := s.replays.History(table.ID)
+		superlatives := s.stats.superlativesOf(table.ID)
+		var replayBytes int
+		memory.History = len(history)
+		memory.Replays, replayBytes = s.replays.Replays(table.ID)
+		memory.RecentHands = s.activity.Activity(table.ID, now).HandsPerHour
+		memory.Observers = s.observers.Count(table.ID)
+		memory.Superlatives = len(superlatives)
+
+		tokenEntries := memory.ClockCalls + memory.TimeBanks + memory.Disconnected + memory.Reservations + memory.Challengers + memory.Observers
+		memory.Bytes = tokenEntries*tokenEntryBytes + memory.RecentHands*recentHandBytes + replayBytes
+		for _, summary := range history {
+			memory.Bytes += jsonSize(summary)
+		}
+		for _, records := range superlatives {
+			memory.Bytes += jsonSize(records)
+		}
+		report.Tables = append(report.Tables, memory)
+	}
+	return report
+}
+
+// handleMemory serves the memory held per table
+func (s *Server) handleMemory(w http.ResponseWriter, r *http.Request) {
+	writeAdminJSON(w, http.StatusOK, s.memoryReport(time.Now()))
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestTrimPlayers verifies a table forgets the players no longer seated once it remembers more
+// than tablePlayerLimit, and keeps those still seated
+func TestTrimPlayers(t *testing.T) {
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+	clients := seatNamed(t, server, table, "Alice", "Bob")
+
+	table.mu.Lock()
+	table.timeBankUsed = map[string]time.Duration{clients[0].Token: time.Second}
+	for i := range tablePlayerLimit {
+		table.timeBankUsed[fmt.Sprintf("gone-%d", i)] = time.Second
+	}
+	table.clockCalls = map[string]time.Time{"gone-0": time.Now()}
+	table.trimPlayersLocked()
+	used, calls := table.timeBankUsed, len(table.clockCalls)
+	table.mu.Unlock()
+
+	if len(used) != 1 || used[clients[0].Token] != time.Second {
+		t.Errorf("expected only Alice's time bank remembered, got %d entries", len(used))
+	}
+	if calls != 0 {
+		t.Errorf("expected the departed player's clock call forgotten, got %d", calls)
+	}
+
+	server.emotes.allow(clients[0].Token, time.Now(), time.Second)
+	server.forgetSession(clients[0].Token)
+	if memory := table.memory(); memory.TimeBanks != 0 {
+		t.Errorf("expected an ended session forgotten, got %+v", memory)
+	}
+	if players := server.emotes.Players(); players != 0 {
+		t.Errorf("expected the ended session's emote cooldown forgotten, got %d", players)
+	}
+}
+
+// TestAdminAPI_Memory verifies /admin/memory counts what each table holds, with the limits
+func TestAdminAPI_Memory(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{Tables: DefaultTables(), AdminToken: "secret"})
+	table := server.tables[0]
+	seatNamed(t, server, table, "Alice", "Bob")
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	handID := handIDOf(table)
+	playOutChecking(t, server, table)
+	storedReplay(t, server, handID)
+
+	rec := adminRequest(server, http.MethodGet, "/admin/memory", "secret", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var report MemoryReport
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Tables) != len(server.tables) || report.Limits.Players != tablePlayerLimit {
+		t.Fatalf("expected every table and the limits, got %+v", report)
+	}
+	if memory := report.Tables[0]; memory.TableID != table.ID || memory.Replays != 1 || memory.History != 1 {
+		t.Errorf("expected the finished hand counted, got %+v", memory)
+	}
+	if memory := report.Tables[0]; memory.Superlatives != 1 || memory.Bytes <= 0 {
+		t.Errorf("expected the day's records counted and a size estimated, got %+v", memory)
+	}
+	if memory := report.Tables[1]; memory.Bytes != 0 {
+		t.Errorf("expected nothing held by an idle table, got %+v", memory)
+	}
+	if report.Limits.SuperlativeTables != superlativeTableLimit || report.Limits.Emotes != emoteLimiterLimit {
+		t.Errorf("expected the superlative and emote limits, got %+v", report.Limits)
+	}
+}
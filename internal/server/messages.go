@@ -0,0 +1,253 @@
+package server
+
+//go:generate go run ../../cmd/catalog -o ../../frontend/src/locales/en.json
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+)
+
+// Protocol-facing text is sent as a stable message key plus a parameter map so clients can word it
+// in their own language. The English wording of every key lives in messageCatalog below, which is
+// also written to frontend/src/locales/en.json (go generate ./internal/server) as the reference
+// catalog for translations. Templates name their parameters in braces, e.g. "{amount}".
+//
+// Keys are grouped by prefix:
+//   - error.*        error messages (ErrorPayload.Key)
+//   - narrator.*     narration lines (NarrationPayload.Key)
+//   - hand.*         hand names used by the "hand" narration parameter
+//   - status.<kind>.<value> labels for the enumerated values the protocol sends, such as seat
+//     statuses, pre-action statuses and quick-seat results
+var messageCatalog = map[string]string{
+	// Errors
+	"error.unexpected":              "something went wrong",
+	"error.invalid_json":            "invalid JSON message",
+	"error.unknown_message_type":    "unknown message type: {type}",
+	"error.invalid_payload":         "invalid {type} payload",
+	"error.invalid_token":           "invalid or expired token",
+	"error.session_not_found":       "session not found: {token}",
+	"error.session_expired":         "session expired: {token}",
+	"error.session_in_use":          "this session is already connected elsewhere",
+	"error.banned":                  "you are banned",
+	"error.kicked":                  "an admin removed you from the table",
+	"error.name_empty":              "name cannot be empty",
+	"error.name_too_long":           "name cannot exceed {max} characters",
+	"error.name_invalid_characters": "name can only contain alphanumeric characters, spaces, dashes, and underscores",
+	"error.invalid_table":           "no such table",
+	"error.table_not_found":         "table not found",
+	"error.table_full":              "table is full",
+	"error.table_frozen":            "table is frozen",
+	"error.table_closing":           "table is closing",
+	"error.seat_not_found":          "seat not found",
+	"error.already_seated":          "you are already seated at a table",
+	"error.not_seated":              "you are not seated at a table",
+	"error.player_not_seated":       "player not seated",
+	"error.recovery_code_invalid":   "that recovery code is not right",
+	"error.no_seat_to_recover":      "no seat is being kept for you to recover",
+	"error.seat_mismatch":           "seat index mismatch: client at seat {seatIndex}, action for seat {actionSeat}",
+	"error.insufficient_balance":    "not enough chips for the buy-in",
+	"error.bonus_disabled":          "the daily bonus is not available",
+	"error.bonus_cooldown":          "the daily bonus has already been claimed",
+	"error.item_not_found":          "item not found",
+	"error.manual_start_disabled":   "hands are dealt automatically at this table",
+	"error.hand_in_progress":        "hand already running",
+	"error.rng_unavailable":         "dealing is halted until the table's random number source is healthy again",
+	"error.not_enough_players":      "insufficient active players to start hand: {active} active, need at least {min}",
+	"error.hand_cancelled":          "the hand was cancelled and everyone's chips were returned",
+	"error.no_hand_in_progress":     "no hand in progress",
+	"error.not_in_hand":             "you are not in this hand",
+	"error.not_current_actor":       "not current actor: current actor is {currentActor}, player at seat {seatIndex}",
+	"error.your_turn":               "it is already your turn",
+	"error.not_your_turn":           "it is not your turn",
+	"error.time_bank_disabled":      "there is no time bank at this table",
+	"error.time_bank_in_use":        "your time bank is already running",
+	"error.time_bank_empty":         "your time bank is used up",
+	"error.missing_action_id":       "the action is missing its actionId",
+	"error.invalid_action":          "invalid action '{action}' for seat {seatIndex}: valid actions are {validActions}",
+	"error.unknown_action":          "invalid action: {action}",
+	"error.check_facing_bet":        "cannot check when behind current bet (need to call {callAmount})",
+	"error.raise_not_confirmed":     "the raise was not confirmed in time; send it again",
+	"error.raise_amount_required":   "raise action requires amount parameter",
+	"error.raise_below_minimum":     "raise amount below minimum",
+	"error.raise_exceeds_stack":     "raise exceeds player stack",
+	"error.raise_not_multiple":      "raise must be to a multiple of {increment}",
+	"error.invalid_pre_action":      "unknown pre-action",
+	"error.invalid_lobby_query":     "invalid lobby query",
+	"error.invalid_quick_seat":      "invalid quick seat request",
+	"error.not_waitlisted":          "you are not on the waitlist",
+	"error.call_clock_disabled":     "calling the clock is not enabled",
+	"error.clock_already_called":    "the clock has already been called on this player",
+	"error.clock_already_short":     "the player has less time left than the clock would give them",
+	"error.call_clock_cooldown":     "you can call the clock again in {seconds} seconds",
+	"error.not_watching":            "you are not watching a table",
+	"error.invalid_show":            "show one or both of your hole cards",
+	"error.nothing_to_show":         "you can only show cards after winning a hand uncontested, until the next hand",
+	"error.card_not_held":           "you do not hold {card}",
+	"error.emotes_disabled":         "emotes are not enabled",
+	"error.unknown_emote":           "unknown emote '{emote}'",
+	"error.emote_target_required":   "throwables must be aimed at a seat",
+	"error.invalid_emote_target":    "emotes can only be aimed at another player at your table",
+	"error.emote_cooldown":          "you can send another emote in {seconds} seconds",
+	"error.invalid_mute":            "name the player to mute",
+	"error.cannot_mute_self":        "you cannot mute yourself",
+	"error.mute_list_full":          "you cannot mute more than {limit} players",
+	"error.reservations_disabled":   "seat reservations are not enabled",
+	"error.no_reservation":          "you have no reserved seat to buy in for",
+	"error.invalid_buy_in":          "buy in for between {min} and {max} chips",
+	"error.not_heads_up":            "that table is not a heads-up table",
+	"error.not_challenging":         "you are not queued to challenge",
+	"error.not_a_host":              "you are not a host at that table",
+	"error.host_seat_taken":         "another host already has the host seat",
+	"error.not_hosting":             "you are not hosting a table",
+	"error.hosting":                 "leave the host seat before taking a seat",
+	"error.invalid_host_chat":       "host messages must be 1 to {max} characters",
+	"error.bomb_pot_pending":        "a bomb pot is already called for the next hand",
+	"error.clubs_disabled":          "clubs are not enabled",
+	"error.club_not_found":          "club not found",
+	"error.not_club_member":         "you are not a member of that club",
+	"error.club_name_shared":        "another session is using your name, so club actions are refused until it ends",
+	"error.club_permission":         "your club role does not allow that",
+	"error.invalid_club_name":       "names must be 1 to {max} characters",
+	"error.invalid_invite_code":     "that invite code is not valid",
+	"error.already_club_member":     "you are already a member of that club",
+	"error.owner_cannot_leave":      "the owner cannot leave their club",
+	"error.invalid_club_role":       "club roles are manager or member",
+	"error.invalid_club_table":      "club tables need a positive small blind, a big blind of at least the small blind and a buy-in of at least the big blind",
+	"error.club_table_limit":        "a club can open at most {max} tables",
+	"error.club_ledger_empty":       "nothing has been played at the club's tables since the last settle-up",
+	"error.no_rematch":              "you have no rematch offer",
+	"error.no_table_merge":          "your table has no merge offer for you",
+	"error.invalid_language":        "languages are tags such as de or pt-BR",
+
+	// Narration
+	"narrator.player_joined":    "{player} sits down in seat {seat}",
+	"narrator.player_left":      "Seat {seat} leaves the table",
+	"narrator.fold":             "Seat {seat} folds",
+	"narrator.fold_timeout":     "Seat {seat} runs out of time and folds",
+	"narrator.check":            "Seat {seat} checks",
+	"narrator.check_timeout":    "Seat {seat} runs out of time and checks",
+	"narrator.call":             "Seat {seat} calls {amount}",
+	"narrator.bet":              "Seat {seat} bets {amount}",
+	"narrator.raise":            "Seat {seat} raises to {amount}",
+	"narrator.clock_called":     "Seat {caller} calls the clock on seat {seat}",
+	"narrator.hand_cancelled":   "The hand is cancelled and all bets are returned",
+	"narrator.flop":             "Flop: {cards}",
+	"narrator.turn":             "Turn: {cards}",
+	"narrator.river":            "River: {cards}",
+	"narrator.wins":             "Seat {seat} wins {amount} with {hand}, {high} high",
+	"narrator.wins_uncontested": "Seat {seat} wins {amount}",
+	"narrator.shows_cards":      "Seat {seat} shows {cards}",
+	"hand.high_card":            "high card",
+	"hand.pair":                 "a pair",
+	"hand.two_pair":             "two pair",
+	"hand.three_of_a_kind":      "three of a kind",
+	"hand.straight":             "a straight",
+	"hand.flush":                "a flush",
+	"hand.full_house":           "a full house",
+	"hand.four_of_a_kind":       "four of a kind",
+	"hand.straight_flush":       "a straight flush",
+	"hand.royal_flush":          "a royal flush",
+
+	// Status labels
+	"status.seat.empty":                          "Empty",
+	"status.seat.waiting":                        "Waiting",
+	"status.seat.active":                         "Playing",
+	"status.pre_action." + PreActionQueued:       "Queued",
+	"status.pre_action." + PreActionCleared:      "Cleared",
+	"status.pre_action." + PreActionApplied:      "Played",
+	"status.pre_action." + PreActionDiscarded:    "Discarded",
+	"status.quick_seat." + QuickSeatSeated:       "Seated",
+	"status.quick_seat." + QuickSeatWaitlisted:   "On the waitlist",
+	"status.quick_seat." + QuickSeatCancelled:    "Left the waitlist",
+	"status.announcement." + AnnouncementInfo:    "Info",
+	"status.announcement." + AnnouncementWarning: "Warning",
+	"status.action.small_blind":                  "Small blind",
+	"status.action.big_blind":                    "Big blind",
+	"status.action.fold":                         "Folded",
+	"status.action.check":                        "Checked",
+	"status.action.call":                         "Called",
+	"status.action.bet":                          "Bet",
+	"status.action.raise":                        "Raised to",
+	"status.table.frozen":                        "The table is frozen by the operators; no hands are dealt and seats cannot change",
+	"status.table.closing":                       "The table is closing: play on, but nobody new can join and it closes when the last player leaves",
+	"status.street.preflop":                      "Preflop",
+	"status.street.flop":                         "Flop",
+	"status.street.turn":                         "Turn",
+	"status.street.river":                        "River",
+}
+
+// MessageCatalog returns the English wording of every message key, for writing the reference catalog
+func MessageCatalog() map[string]string {
+	return maps.Clone(messageCatalog)
+}
+
+// messageParam matches a {name} placeholder in a catalog template
+var messageParam = regexp.MustCompile(`\{(\w+)\}`)
+
+// englishMessage renders key in English with params; unknown keys render as the key itself and
+// placeholders without a parameter are left in place
+func englishMessage(key string, params map[string]any) string {
+	template, ok := messageCatalog[key]
+	if !ok {
+		return key
+	}
+	return messageParam.ReplaceAllStringFunc(template, func(placeholder string) string {
+		value, ok := params[placeholder[1:len(placeholder)-1]]
+		if !ok {
+			return placeholder
+		}
+		return fmt.Sprint(value)
+	})
+}
+
+// MessageError is an error shown to players, identified by a message key and its parameters.
+// Its Error text is the English wording, followed by the wrapped error if there is one.
+type MessageError struct {
+	Key    string
+	Params map[string]any
+	Err    error
+}
+
+// newMessageError returns a MessageError for key with params, which may be nil
+func newMessageError(key string, params map[string]any) error {
+	return &MessageError{Key: key, Params: params}
+}
+
+// invalidPayloadError reports a message whose payload could not be decoded
+func invalidPayloadError(msgType string, err error) error {
+	return &MessageError{Key: "error.invalid_payload", Params: map[string]any{"type": msgType}, Err: err}
+}
+
+func (e *MessageError) Error() string {
+	message := englishMessage(e.Key, e.Params)
+	if e.Err != nil {
+		return message + ": " + e.Err.Error()
+	}
+	return message
+}
+
+func (e *MessageError) Unwrap() error {
+	return e.Err
+}
+
+// errorCode matches the snake_case codes some errors carry as their whole message, like "table_full"
+var errorCode = regexp.MustCompile(`^[a-z]+(_[a-z]+)*$`)
+
+// errorMessageKey returns the message key and parameters describing err to a client: those of the
+// outermost MessageError it wraps, else error.<code> for errors whose message is a catalogued code,
+// else error.unexpected
+func errorMessageKey(err error) (string, map[string]any) {
+	var messageErr *MessageError
+	if errors.As(err, &messageErr) {
+		return messageErr.Key, messageErr.Params
+	}
+	message := err.Error()
+	if errorCode.MatchString(message) {
+		if key := "error." + message; messageCatalog[key] != "" {
+			return key, nil
+		}
+	}
+	return "error.unexpected", nil
+}
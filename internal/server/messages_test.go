@@ -0,0 +1,134 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// TestMessageCatalog_MatchesEnJSON verifies the reference catalog was regenerated after the code changed
+func TestMessageCatalog_MatchesEnJSON(t *testing.T) {
+	data, err := os.ReadFile("../../frontend/src/locales/en.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var catalog map[string]string
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(catalog, MessageCatalog()) {
+		t.Error("frontend/src/locales/en.json is out of date: run go generate ./internal/server")
+	}
+}
+
+// TestMessageCatalog_CoversNarration verifies every narration key and hand name has English wording
+func TestMessageCatalog_CoversNarration(t *testing.T) {
+	keys := []string{"narrator.player_joined", "narrator.player_left", "narrator.wins", "narrator.wins_uncontested"}
+	for _, action := range []string{"fold", "check", "call", "bet", "raise", "fold_timeout", "check_timeout"} {
+		keys = append(keys, "narrator."+action)
+	}
+	for _, street := range []string{"flop", "turn", "river"} {
+		keys = append(keys, "narrator."+street)
+	}
+	for _, hand := range handRankIDs {
+		keys = append(keys, "hand."+hand)
+	}
+	for _, key := range keys {
+		if _, ok := messageCatalog[key]; !ok {
+			t.Errorf("missing catalog entry for %s", key)
+		}
+	}
+}
+
+// TestErrorMessageKey verifies errors are described by their message key and parameters
+func TestErrorMessageKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantKey    string
+		wantParams map[string]any
+		wantText   string
+	}{
+		{
+			name:       "message error",
+			err:        newMessageError("error.check_facing_bet", map[string]any{"callAmount": 20}),
+			wantKey:    "error.check_facing_bet",
+			wantParams: map[string]any{"callAmount": 20},
+			wantText:   "cannot check when behind current bet (need to call 20)",
+		},
+		{
+			name:     "wrapped message error",
+			err:      fmt.Errorf("failed to process action: %w", newMessageError("error.raise_below_minimum", nil)),
+			wantKey:  "error.raise_below_minimum",
+			wantText: "failed to process action: raise amount below minimum",
+		},
+		{
+			name:       "invalid payload keeps the decoding error",
+			err:        invalidPayloadError("join_table", errors.New("unexpected end of JSON input")),
+			wantKey:    "error.invalid_payload",
+			wantParams: map[string]any{"type": "join_table"},
+			wantText:   "invalid join_table payload: unexpected end of JSON input",
+		},
+		{
+			name:     "error code",
+			err:      errTableFull,
+			wantKey:  "error.table_full",
+			wantText: "table_full",
+		},
+		{
+			name:     "unknown error",
+			err:      errors.New("disk on fire"),
+			wantKey:  "error.unexpected",
+			wantText: "disk on fire",
+		},
+		{
+			name:     "uncatalogued code",
+			err:      errors.New("mystery_code"),
+			wantKey:  "error.unexpected",
+			wantText: "mystery_code",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, params := errorMessageKey(tt.err)
+			if key != tt.wantKey || !reflect.DeepEqual(params, tt.wantParams) {
+				t.Errorf("expected %s %v, got %s %v", tt.wantKey, tt.wantParams, key, params)
+			}
+			if tt.err.Error() != tt.wantText {
+				t.Errorf("expected text %q, got %q", tt.wantText, tt.err.Error())
+			}
+		})
+	}
+}
+
+// TestSendError_IncludesKey verifies error messages carry the key and parameters next to the English text
+func TestSendError_IncludesKey(t *testing.T) {
+	server := NewServer(slog.Default())
+	client := connectTestClient(server, "player1")
+
+	if err := client.SendError(newMessageError("error.unknown_message_type", map[string]any{"type": "shuffle"}), slog.Default()); err != nil {
+		t.Fatal(err)
+	}
+
+	messages := drainRawMessages(client)
+	if len(messages) != 1 {
+		t.Fatalf("expected one message, got %v", messages)
+	}
+	var msg WebSocketMessage
+	if err := json.Unmarshal([]byte(messages[0]), &msg); err != nil {
+		t.Fatal(err)
+	}
+	var payload ErrorPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Type != "error" || payload.Key != "error.unknown_message_type" || payload.Params["type"] != "shuffle" ||
+		payload.Message != "unknown message type: shuffle" {
+		t.Errorf("unexpected error message %+v", payload)
+	}
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+)
+
+// muteListLimit is how many players one account may mute
+const muteListLimit = 200
+
+// Mute adds target to the named account's mute list; muting someone already muted does nothing
+func (s *AccountStore) Mute(name, target string) error {
+	target = accountKey(target)
+	if target == "" {
+		return newMessageError("error.invalid_mute", nil)
+	}
+	if target == accountKey(name) {
+		return newMessageError("error.cannot_mute_self", nil)
+	}
+	return s.update(name, func(account *Account) error {
+		if slices.Contains(account.Muted, target) {
+			return nil
+		}
+		if len(account.Muted) >= muteListLimit {
+			return newMessageError("error.mute_list_full", map[string]any{"limit": muteListLimit})
+		}
+		account.Muted = append(account.Muted, target)
+		slices.Sort(account.Muted)
+		return nil
+	})
+}
+
+// Unmute removes target from the named account's mute list
+func (s *AccountStore) Unmute(name, target string) error {
+	target = accountKey(target)
+	return s.update(name, func(account *Account) error {
+		account.Muted = slices.DeleteFunc(account.Muted, func(muted string) bool { return muted == target })
+		return nil
+	})
+}
+
+// MuteList returns the names the named account has muted, sorted
+func (s *AccountStore) MuteList(name string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.accounts[accountKey(name)].Muted...)
+}
+
+// Mutes reports whether the named account has muted sender
+func (s *AccountStore) Mutes(name, sender string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, found := slices.BinarySearch(s.accounts[accountKey(name)].Muted, accountKey(sender))
+	return found
+}
+
+// MutePlayerPayload represents the payload for mute_player and unmute_player messages
+type MutePlayerPayload struct {
+	Name string `json:"name"`
+}
+
+// MuteListPayload represents the payload for mute_list messages
+type MuteListPayload struct {
+	Names []string `json:"names"` // Lowercased player names, sorted
+}
+
+// HandleMutePlayer processes a mute_player (muted true) or unmute_player message
+// The mute list lives on the account, so it follows the player across sessions and restarts.
+func (c *Client) HandleMutePlayer(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte, muted bool) error {
+	msgType := "unmute_player"
+	if muted {
+		msgType = "mute_player"
+	}
+	var request MutePlayerPayload
+	if err := json.Unmarshal(payload, &request); err != nil {
+		return invalidPayloadError(msgType, err)
+	}
+	name, err := sm.GetPlayerName(c.Token)
+	if err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+
+	if muted {
+		err = server.accounts.Mute(name, request.Name)
+	} else {
+		err = server.accounts.Unmute(name, request.Name)
+	}
+	if err != nil {
+		return err
+	}
+	logger.Info("mute list changed", "name", name, "target", accountKey(request.Name), "muted", muted)
+	server.sendMuteList(name)
+	return nil
+}
+
+// HandleGetMuteList processes a get_mute_list message and replies with the player's mute list
+func (c *Client) HandleGetMuteList(sm *SessionManager, server *Server, logger *slog.Logger) error {
+	name, err := sm.GetPlayerName(c.Token)
+	if err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+	return c.sendMessage("mute_list", MuteListPayload{Names: server.accounts.MuteList(name)})
+}
+
+// sendMuteList privately sends the current mute list to every live session of the account
+func (s *Server) sendMuteList(name string) {
+	names := s.accounts.MuteList(name)
+	for _, token := range s.sessionManager.TokensByName(name) {
+		s.sendPrivate(token, "mute_list", MuteListPayload{Names: names})
+	}
+}
+
+// broadcastSocialMessage sends a message from sender (a player name), such as an emote, to
+// everyone at the table who has not muted them. Game messages never go through here.
+func (s *Server) broadcastSocialMessage(table *Table, sender, msgType string, payload any) error {
+	return s.broadcastTableMessageExcept(table, msgType, payload, func(client *Client) bool {
+		name, err := s.sessionManager.GetPlayerName(client.Token)
+		return err == nil && s.accounts.Mutes(name, sender)
+	})
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// TestAccountStore_MuteList verifies muting and unmuting by name, case-insensitively, and that
+// the list survives a reload
+func TestAccountStore_MuteList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.json")
+	store, err := LoadAccountStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, target := range []string{"Carol", "bob", " BOB "} {
+		if err := store.Mute("Alice", target); err != nil {
+			t.Fatalf("muting %q: %v", target, err)
+		}
+	}
+	if err := store.Mute("alice", "ALICE"); err == nil {
+		t.Error("expected an error muting oneself")
+	}
+	if err := store.Mute("Alice", " "); err == nil {
+		t.Error("expected an error muting an empty name")
+	}
+	if !store.Mutes("ALICE", "Bob") || store.Mutes("Bob", "Alice") {
+		t.Error("expected Alice to mute Bob and not the other way round")
+	}
+
+	reloaded, err := LoadAccountStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.MuteList("alice"); !slices.Equal(got, []string{"bob", "carol"}) {
+		t.Errorf("expected bob and carol muted after a reload, got %v", got)
+	}
+	if err := reloaded.Unmute("Alice", "Bob"); err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.MuteList("alice"); !slices.Equal(got, []string{"carol"}) {
+		t.Errorf("expected carol muted, got %v", got)
+	}
+}
+
+// TestMute_FiltersSocialMessages verifies a muted player's emotes are hidden from the player who
+// muted them, while everyone else and game messages are unaffected
+func TestMute_FiltersSocialMessages(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	updateConfig(server, func(config *Config) { config.Emotes = EmoteConfig{Enabled: true} })
+	muter, muted, other := clients[0], clients[1], clients[2]
+
+	payload, _ := json.Marshal(MutePlayerPayload{Name: "bob"})
+	if err := muter.HandleMutePlayer(server.sessionManager, server, slog.Default(), payload, true); err != nil {
+		t.Fatal(err)
+	}
+	for _, client := range clients {
+		drainRawMessages(client)
+	}
+
+	if key := emoteKey(server, muted, "laugh", nil); key != "" {
+		t.Fatalf("emote failed: %s", key)
+	}
+	actCurrent(t, server, table, "fold")
+
+	types := func(client *Client) []string {
+		var got []string
+		for _, raw := range drainRawMessages(client) {
+			var msg WebSocketMessage
+			if json.Unmarshal([]byte(raw), &msg) == nil {
+				got = append(got, msg.Type)
+			}
+		}
+		return got
+	}
+	if got := types(muter); slices.Contains(got, "emote") || !slices.Contains(got, "action_result") {
+		t.Errorf("expected game messages and no emote for the muter, got %v", got)
+	}
+	if got := types(other); !slices.Contains(got, "emote") {
+		t.Errorf("expected the emote for the other player, got %v", got)
+	}
+}
+
+// TestHandleMutePlayer_SendsMuteList verifies mute_player and get_mute_list reply with the list
+func TestHandleMutePlayer_SendsMuteList(t *testing.T) {
+	server, _, clients := preActionTable(t)
+	client := clients[0]
+	drainRawMessages(client)
+
+	lastList := func() []string {
+		var list []string
+		for _, raw := range drainRawMessages(client) {
+			var msg struct {
+				Type    string          `json:"type"`
+				Payload MuteListPayload `json:"payload"`
+			}
+			if json.Unmarshal([]byte(raw), &msg) == nil && msg.Type == "mute_list" {
+				list = msg.Payload.Names
+			}
+		}
+		return list
+	}
+
+	payload, _ := json.Marshal(MutePlayerPayload{Name: "Carol"})
+	if err := client.HandleMutePlayer(server.sessionManager, server, slog.Default(), payload, true); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastList(); !slices.Equal(got, []string{"carol"}) {
+		t.Errorf("expected carol muted, got %v", got)
+	}
+	if err := client.HandleMutePlayer(server.sessionManager, server, slog.Default(), payload, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.HandleGetMuteList(server.sessionManager, server, slog.Default()); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastList(); got == nil || len(got) != 0 {
+		t.Errorf("expected an empty mute list, got %v", got)
+	}
+}
@@ -0,0 +1,164 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// narrationCatalogs words hand results in languages other than English, by lowercase language
+// tag. A session's language falls back from a regional tag to its base language ("pt-br" to
+// "pt"), then to English: lines with no template in the language, and languages with no
+// catalog, are worded from messageCatalog. Parameters are worded from the same chain, so
+// {hand} = "flush" reads hand.flush in the session's language.
+var narrationCatalogs = map[string]map[string]string{
+	"de": {
+		"narrator.wins":             "Sitz {seat} gewinnt {amount} mit {hand}, {high} hoch",
+		"narrator.wins_uncontested": "Sitz {seat} gewinnt {amount}",
+		"narrator.shows_cards":      "Sitz {seat} zeigt {cards}",
+		"hand.high_card":            "einer hohen Karte",
+		"hand.pair":                 "einem Paar",
+		"hand.two_pair":             "zwei Paaren",
+		"hand.three_of_a_kind":      "einem Drilling",
+		"hand.straight":             "einer Straße",
+		"hand.flush":                "einem Flush",
+		"hand.full_house":           "einem Full House",
+		"hand.four_of_a_kind":       "einem Vierling",
+		"hand.straight_flush":       "einem Straight Flush",
+		"hand.royal_flush":          "einem Royal Flush",
+	},
+	"es": {
+		"narrator.wins":             "El asiento {seat} gana {amount} con {hand}, al {high}",
+		"narrator.wins_uncontested": "El asiento {seat} gana {amount}",
+		"narrator.shows_cards":      "El asiento {seat} muestra {cards}",
+		"hand.high_card":            "carta alta",
+		"hand.pair":                 "una pareja",
+		"hand.two_pair":             "doble pareja",
+		"hand.three_of_a_kind":      "un trío",
+		"hand.straight":             "una escalera",
+		"hand.flush":                "color",
+		"hand.full_house":           "un full",
+		"hand.four_of_a_kind":       "un póquer",
+		"hand.straight_flush":       "escalera de color",
+		"hand.royal_flush":          "escalera real",
+	},
+	"fr": {
+		"narrator.wins":             "Le siège {seat} gagne {amount} avec {hand}, hauteur {high}",
+		"narrator.wins_uncontested": "Le siège {seat} gagne {amount}",
+		"narrator.shows_cards":      "Le siège {seat} montre {cards}",
+		"hand.high_card":            "carte haute",
+		"hand.pair":                 "une paire",
+		"hand.two_pair":             "deux paires",
+		"hand.three_of_a_kind":      "un brelan",
+		"hand.straight":             "une quinte",
+		"hand.flush":                "une couleur",
+		"hand.full_house":           "un full",
+		"hand.four_of_a_kind":       "un carré",
+		"hand.straight_flush":       "une quinte flush",
+		"hand.royal_flush":          "une quinte flush royale",
+	},
+}
+
+// languageTag matches the language tags sessions may choose, such as "de" or "pt-BR"
+var languageTag = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+// languageChain returns the catalogs to word a line from for language, most specific first;
+// English is not included
+func languageChain(language string) []map[string]string {
+	var chain []map[string]string
+	tag := strings.ToLower(language)
+	for tag != "" {
+		if catalog, ok := narrationCatalogs[tag]; ok {
+			chain = append(chain, catalog)
+		}
+		cut := strings.LastIndexByte(tag, '-')
+		if cut < 0 {
+			break
+		}
+		tag = tag[:cut]
+	}
+	return chain
+}
+
+// localizedMessage words key with params in language, falling back as narrationCatalogs describes
+func localizedMessage(language, key string, params map[string]any) string {
+	chain := languageChain(language)
+	lookup := func(key string) (string, bool) {
+		for _, catalog := range chain {
+			if template, ok := catalog[key]; ok {
+				return template, true
+			}
+		}
+		template, ok := messageCatalog[key]
+		return template, ok
+	}
+
+	template, ok := lookup(key)
+	if !ok {
+		return key
+	}
+	return messageParam.ReplaceAllStringFunc(template, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		value, ok := params[name]
+		if !ok {
+			return placeholder
+		}
+		switch value := value.(type) {
+		case []string:
+			return strings.Join(value, " ")
+		case string:
+			if worded, ok := lookup(name + "." + value); ok {
+				return worded
+			}
+		}
+		return fmt.Sprint(value)
+	})
+}
+
+// broadcastNarration sends line to every client at the table, worded in each one's language
+func (s *Server) broadcastNarration(table *Table, line NarrationPayload) error {
+	messages := make(map[string][]byte) // By language
+	for _, client := range s.GetClientsAtTable(table.ID) {
+		language := s.sessionManager.Language(client.Token)
+		message, ok := messages[language]
+		if !ok {
+			worded := line
+			worded.Text = localizedMessage(language, line.Key, line.Params)
+			var err error
+			if message, err = marshalMessage("narration", worded); err != nil {
+				return err
+			}
+			messages[language] = message
+		}
+		select {
+		case client.send <- message:
+		default:
+			s.logger.Warn("client send channel full, skipping message", "type", "narration", "tableId", table.ID)
+		}
+	}
+	return nil
+}
+
+// LanguagePayload represents the payload for set_language messages and their language reply
+type LanguagePayload struct {
+	Language string `json:"language"` // Language tag such as "de" or "pt-BR"; empty for English
+}
+
+// HandleSetLanguage processes a set_language message: the language the player's narration is
+// worded in, and replies with language
+func (c *Client) HandleSetLanguage(sm *SessionManager, logger *slog.Logger, payload []byte) error {
+	var req LanguagePayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return invalidPayloadError("set_language", err)
+	}
+	if req.Language != "" && !languageTag.MatchString(req.Language) {
+		return newMessageError("error.invalid_language", nil)
+	}
+	if err := sm.SetLanguage(c.Token, req.Language); err != nil {
+		return err
+	}
+	logger.Info("language set", "token", c.Token, "language", req.Language)
+	return c.sendMessage("language", req)
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"log/slog"
+	"slices"
+	"testing"
+)
+
+// TestLocalizedMessage verifies hand results are worded in the session's language, falling back
+// from a regional tag to its base language and from there to English
+func TestLocalizedMessage(t *testing.T) {
+	wins := map[string]any{"seat": 2, "amount": 240, "hand": "flush", "high": "A"}
+	tests := []struct {
+		language, key string
+		params        map[string]any
+		want          string
+	}{
+		{"", "narrator.wins", wins, "Seat 2 wins 240 with a flush, A high"},
+		{"de", "narrator.wins", wins, "Sitz 2 gewinnt 240 mit einem Flush, A hoch"},
+		{"es-MX", "narrator.wins", wins, "El asiento 2 gana 240 con color, al A"},
+		{"FR", "narrator.wins_uncontested", wins, "Le siège 2 gagne 240"},
+		{"fr", "narrator.shows_cards", map[string]any{"seat": 1, "cards": []string{"As", "Kd"}}, "Le siège 1 montre As Kd"},
+		{"de", "narrator.check", map[string]any{"seat": 3}, "Seat 3 checks"},
+		{"pt-BR", "narrator.wins_uncontested", wins, "Seat 2 wins 240"},
+		{"de", "narrator.unknown", nil, "narrator.unknown"},
+	}
+	for _, tt := range tests {
+		if got := localizedMessage(tt.language, tt.key, tt.params); got != tt.want {
+			t.Errorf("%q %s: expected %q, got %q", tt.language, tt.key, tt.want, got)
+		}
+	}
+}
+
+// TestNarrationCatalogs_MatchEnglish verifies every translated template is a catalogued key
+// that takes the same parameters as its English wording
+func TestNarrationCatalogs_MatchEnglish(t *testing.T) {
+	params := func(template string) []string {
+		var names []string
+		for _, match := range messageParam.FindAllStringSubmatch(template, -1) {
+			names = append(names, match[1])
+		}
+		slices.Sort(names)
+		return names
+	}
+	for language, catalog := range narrationCatalogs {
+		for key, template := range catalog {
+			english, ok := messageCatalog[key]
+			if !ok {
+				t.Errorf("%s: %s is not in the message catalog", language, key)
+				continue
+			}
+			if !slices.Equal(params(template), params(english)) {
+				t.Errorf("%s: %s takes %v, English takes %v", language, key, params(template), params(english))
+			}
+		}
+	}
+}
+
+// TestBroadcastNarration_WordsLinePerSession verifies each player gets the line in their language
+func TestBroadcastNarration_WordsLinePerSession(t *testing.T) {
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+	clients := seatNamed(t, server, table, "Alice", "Bruno")
+	if err := server.sessionManager.SetLanguage(clients[1].Token, "es"); err != nil {
+		t.Fatal(err)
+	}
+	drainRawMessages(clients[0])
+	drainRawMessages(clients[1])
+
+	line := NarrationPayload{Key: "narrator.wins_uncontested", Params: map[string]any{"seat": 1, "amount": 30}}
+	if err := server.broadcastNarration(table, line); err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []string{"Seat 1 wins 30", "El asiento 1 gana 30"} {
+		lines := payloadsOf[NarrationPayload](t, clients[i], "narration")
+		if len(lines) != 1 || lines[0].Key != line.Key || lines[0].Text != want {
+			t.Errorf("client %d: expected %q, got %+v", i, want, lines)
+		}
+	}
+}
+
+// TestHandleSetLanguage verifies set_language validates the tag and answers with language
+func TestHandleSetLanguage(t *testing.T) {
+	server := NewServer(slog.Default())
+	session, _ := server.sessionManager.CreateSession("Alice")
+	client := connectTestClient(server, session.Token)
+
+	err := client.HandleSetLanguage(server.sessionManager, slog.Default(), []byte(`{"language": "not a tag"}`))
+	if key, _ := errorMessageKey(err); key != "error.invalid_language" {
+		t.Errorf("expected error.invalid_language, got %v", err)
+	}
+	if err := client.HandleSetLanguage(server.sessionManager, slog.Default(), []byte(`{"language": "pt-BR"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if got := server.sessionManager.Language(session.Token); got != "pt-BR" {
+		t.Errorf("expected pt-BR, got %q", got)
+	}
+	if replies := payloadsOf[LanguagePayload](t, client, "language"); len(replies) != 1 || replies[0].Language != "pt-BR" {
+		t.Errorf("expected a language reply, got %+v", replies)
+	}
+}
@@ -0,0 +1,167 @@
+package server
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// NarrationPayload represents the payload for narration messages: one line of dealer commentary
+// as a message key and its parameters, so clients can word it in their own language, and as Text
+// worded in the language the player chose with set_language
+// Seats in parameters are numbered from 1, as players see them
+type NarrationPayload struct {
+	Key    string         `json:"key"`
+	Params map[string]any `json:"params,omitempty"`
+	Text   string         `json:"text,omitempty"`
+}
+
+// handRankIDs names each HandRank.Rank in narration parameters
+var handRankIDs = []string{
+	"high_card", "pair", "two_pair", "three_of_a_kind", "straight",
+	"flush", "full_house", "four_of_a_kind", "straight_flush", "royal_flush",
+}
+
+// cardRankID returns the card rank ("2"-"9", "T", "J", "Q", "K", "A") of a numeric rank
+// as used by the hand evaluator, where aces are 14 (or 1 in a wheel)
+func cardRankID(rank int) string {
+	switch rank {
+	case 1, 14:
+		return "A"
+	case 13:
+		return "K"
+	case 12:
+		return "Q"
+	case 11:
+		return "J"
+	case 10:
+		return "T"
+	default:
+		return strconv.Itoa(rank)
+	}
+}
+
+// cardIDs lists cards as narration parameters, such as "As"
+func cardIDs(cards []Card) []string {
+	ids := make([]string, len(cards))
+	for i, card := range cards {
+		ids[i] = card.String()
+	}
+	return ids
+}
+
+// RunNarrator turns table events into narration messages for everyone at the table until the
+// channel is closed. Events are skipped while the narration feature is off.
+func (s *Server) RunNarrator(events <-chan Event) {
+	for e := range events {
+		if !s.Config().Features.Narration {
+			continue
+		}
+		lines := s.narrate(e)
+		if len(lines) == 0 {
+			continue
+		}
+		table := s.tableByID(e.TableID)
+		if table == nil {
+			continue
+		}
+		for _, line := range lines {
+			if err := s.broadcastNarration(table, line); err != nil {
+				s.logger.Warn("failed to broadcast narration", "tableId", e.TableID, "key", line.Key, "error", err)
+			}
+		}
+	}
+}
+
+// narrate returns the narration lines for e, none for events that are not narrated
+func (s *Server) narrate(e Event) []NarrationPayload {
+	switch e.Type {
+	case EventPlayerSeated:
+		return []NarrationPayload{{Key: "narrator.player_joined", Params: s.seatParams(e.TableID, e.SeatIndex, e.Token)}}
+
+	case EventPlayerLeft:
+		return []NarrationPayload{{Key: "narrator.player_left", Params: s.seatParams(e.TableID, e.SeatIndex, e.Token)}}
+
+	case EventPlayerAction:
+		params := s.seatParams(e.TableID, e.SeatIndex, e.Token)
+		key := "narrator." + e.Action
+		switch e.Action {
+		case "call":
+			params["amount"] = e.Amount
+		case "raise":
+			params["amount"] = e.StreetBet
+			// Only the big blind can act facing nothing before the flop, and that is a raise
+			if e.BetToCall == 0 && e.Street != "preflop" {
+				key = "narrator.bet"
+			}
+		}
+		if e.Timeout {
+			key += "_timeout"
+		}
+		return []NarrationPayload{{Key: key, Params: params}}
+
+	case EventClockCalled:
+		params := s.seatParams(e.TableID, e.SeatIndex, e.Token)
+		params["caller"] = e.CalledBy + 1
+		return []NarrationPayload{{Key: "narrator.clock_called", Params: params}}
+
+	case EventHandCancelled:
+		return []NarrationPayload{{Key: "narrator.hand_cancelled"}}
+
+	case EventCardsShown:
+		params := s.seatParams(e.TableID, e.SeatIndex, e.Token)
+		params["cards"] = cardIDs(e.Shown)
+		return []NarrationPayload{{Key: "narrator.shows_cards", Params: params}}
+
+	case EventBoardDealt:
+		return []NarrationPayload{{Key: "narrator." + e.Street, Params: map[string]any{"cards": cardIDs(e.Board)}}}
+
+	case EventHandEnded:
+		var lines []NarrationPayload
+		for _, seatIndex := range slices.Sorted(maps.Keys(e.Winnings)) {
+			amount := e.Winnings[seatIndex]
+			params := s.seatParams(e.TableID, seatIndex, s.seatToken(e.TableID, seatIndex))
+			params["amount"] = amount
+			if e.WinningRank == nil {
+				lines = append(lines, NarrationPayload{Key: "narrator.wins_uncontested", Params: params})
+				continue
+			}
+			if e.WinningRank.Rank >= 0 && e.WinningRank.Rank < len(handRankIDs) {
+				params["hand"] = handRankIDs[e.WinningRank.Rank]
+			}
+			if len(e.WinningRank.Kickers) > 0 {
+				params["high"] = cardRankID(e.WinningRank.Kickers[0])
+			}
+			lines = append(lines, NarrationPayload{Key: "narrator.wins", Params: params})
+		}
+		return lines
+	}
+	return nil
+}
+
+// seatParams returns the narration parameters naming a seat of a table and, when known, its
+// player as the table shows them
+func (s *Server) seatParams(tableID string, seatIndex int, token string) map[string]any {
+	params := map[string]any{"seat": seatIndex + 1}
+	if token == "" {
+		return params
+	}
+	if name, err := s.displayName(tableID, token); err == nil && name != "" {
+		params["player"] = name
+	}
+	return params
+}
+
+// seatToken returns the token of the player in a seat of a table, empty if the seat is free
+func (s *Server) seatToken(tableID string, seatIndex int) string {
+	table := s.tableByID(tableID)
+	if table == nil {
+		return ""
+	}
+	table.mu.RLock()
+	defer table.mu.RUnlock()
+	if seatIndex < 0 || seatIndex >= len(table.Seats) || table.Seats[seatIndex].Token == nil {
+		return ""
+	}
+	return *table.Seats[seatIndex].Token
+}
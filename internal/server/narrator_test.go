@@ -0,0 +1,149 @@
+package server
+
+import (
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestNarrate_Actions verifies player actions are narrated with the seat number, player and amount
+func TestNarrate_Actions(t *testing.T) {
+	server := NewServer(slog.Default())
+	session, err := server.sessionManager.CreateSession("Alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := session.Token
+
+	tests := []struct {
+		name  string
+		event Event
+		want  NarrationPayload
+	}{
+		{
+			name:  "fold",
+			event: Event{Type: EventPlayerAction, SeatIndex: 2, Token: token, Street: "flop", Action: "fold"},
+			want:  NarrationPayload{Key: "narrator.fold", Params: map[string]any{"seat": 3, "player": "Alice"}},
+		},
+		{
+			name:  "timed out check",
+			event: Event{Type: EventPlayerAction, SeatIndex: 0, Token: token, Street: "turn", Action: "check", Timeout: true},
+			want:  NarrationPayload{Key: "narrator.check_timeout", Params: map[string]any{"seat": 1, "player": "Alice"}},
+		},
+		{
+			name:  "call",
+			event: Event{Type: EventPlayerAction, SeatIndex: 1, Token: token, Street: "preflop", Action: "call", Amount: 15, StreetBet: 20},
+			want:  NarrationPayload{Key: "narrator.call", Params: map[string]any{"seat": 2, "player": "Alice", "amount": 15}},
+		},
+		{
+			name:  "opening bet",
+			event: Event{Type: EventPlayerAction, SeatIndex: 1, Token: token, Street: "river", Action: "raise", Amount: 80, StreetBet: 80},
+			want:  NarrationPayload{Key: "narrator.bet", Params: map[string]any{"seat": 2, "player": "Alice", "amount": 80}},
+		},
+		{
+			name:  "raise counts the whole street bet",
+			event: Event{Type: EventPlayerAction, SeatIndex: 1, Token: token, Street: "flop", Action: "raise", Amount: 60, BetToCall: 40, StreetBet: 120},
+			want:  NarrationPayload{Key: "narrator.raise", Params: map[string]any{"seat": 2, "player": "Alice", "amount": 120}},
+		},
+		{
+			name:  "big blind option raise",
+			event: Event{Type: EventPlayerAction, SeatIndex: 1, Token: token, Street: "preflop", Action: "raise", Amount: 40, StreetBet: 60},
+			want:  NarrationPayload{Key: "narrator.raise", Params: map[string]any{"seat": 2, "player": "Alice", "amount": 60}},
+		},
+		{
+			name:  "unknown player",
+			event: Event{Type: EventPlayerAction, SeatIndex: 4, Token: "gone", Street: "flop", Action: "check"},
+			want:  NarrationPayload{Key: "narrator.check", Params: map[string]any{"seat": 5}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := server.narrate(tt.event)
+			if len(lines) != 1 || !reflect.DeepEqual(lines[0], tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, lines)
+			}
+		})
+	}
+}
+
+// TestNarrate_BoardAndWinners verifies board cards and pot winners are narrated, with the
+// winning hand named when there was a showdown
+func TestNarrate_BoardAndWinners(t *testing.T) {
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+	seatTwoPlayers(table)
+
+	lines := server.narrate(Event{TableID: table.ID, Type: EventBoardDealt, Street: "flop",
+		Board: []Card{NewCard("9", "h"), NewCard("T", "s"), NewCard("2", "c")}})
+	want := NarrationPayload{Key: "narrator.flop", Params: map[string]any{"cards": []string{"9h", "Ts", "2c"}}}
+	if len(lines) != 1 || !reflect.DeepEqual(lines[0], want) {
+		t.Errorf("expected %+v, got %+v", want, lines)
+	}
+
+	lines = server.narrate(Event{TableID: table.ID, Type: EventHandEnded,
+		Winnings: map[int]int{1: 120, 0: 120}, WinningRank: &HandRank{Rank: 4, Kickers: []int{9}}})
+	if len(lines) != 2 {
+		t.Fatalf("expected a line per winner, got %+v", lines)
+	}
+	for i, line := range lines {
+		if line.Key != "narrator.wins" || line.Params["seat"] != i+1 || line.Params["amount"] != 120 ||
+			line.Params["hand"] != "straight" || line.Params["high"] != "9" {
+			t.Errorf("unexpected winner line %+v", line)
+		}
+	}
+
+	lines = server.narrate(Event{TableID: table.ID, Type: EventHandEnded, Winnings: map[int]int{0: 30}})
+	if len(lines) != 1 || lines[0].Key != "narrator.wins_uncontested" || lines[0].Params["amount"] != 30 {
+		t.Errorf("expected an uncontested win, got %+v", lines)
+	}
+
+	if lines := server.narrate(Event{TableID: table.ID, Type: EventHandStarted}); len(lines) != 0 {
+		t.Errorf("expected hand_started not to be narrated, got %+v", lines)
+	}
+}
+
+// TestRunNarrator_SendsToTable verifies narration reaches the players at the table and nobody else
+func TestRunNarrator_SendsToTable(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{Features: FeatureFlags{Narration: true}})
+	table := server.tables[0]
+	seatTwoPlayers(table)
+	seated := connectTestClient(server, "player1")
+	lobby := connectTestClient(server, "lobby-player")
+
+	table.mu.Lock()
+	table.publishEvent(Event{Type: EventPlayerAction, SeatIndex: 0, Token: "player1", Street: "flop", Action: "check"})
+	table.mu.Unlock()
+
+	deadline := time.Now().Add(time.Second)
+	var messages []string
+	for len(messages) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		messages = drainRawMessages(seated)
+	}
+	if len(messages) != 1 || !strings.Contains(messages[0], `"type":"narration"`) || !strings.Contains(messages[0], `"key":"narrator.check"`) {
+		t.Errorf("expected a check narration, got %v", messages)
+	}
+	if messages := drainRawMessages(lobby); len(messages) != 0 {
+		t.Errorf("expected nothing in the lobby, got %v", messages)
+	}
+}
+
+// TestRunNarrator_DisabledByDefault verifies the zero Config sends no narration
+func TestRunNarrator_DisabledByDefault(t *testing.T) {
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+	seatTwoPlayers(table)
+	seated := connectTestClient(server, "player1")
+
+	table.mu.Lock()
+	table.publishEvent(Event{Type: EventPlayerAction, SeatIndex: 0, Token: "player1", Street: "flop", Action: "check"})
+	table.mu.Unlock()
+
+	time.Sleep(50 * time.Millisecond)
+	if messages := drainRawMessages(seated); len(messages) != 0 {
+		t.Errorf("expected no narration, got %v", messages)
+	}
+}
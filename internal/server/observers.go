@@ -0,0 +1,120 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+)
+
+// WatchTablePayload represents the payload for watch_table messages
+type WatchTablePayload struct {
+	TableId string `json:"tableId"`
+}
+
+// Observers tracks the sessions watching each table without a seat
+// Each session watches at most one table at a time
+type Observers struct {
+	mu       sync.Mutex
+	watching map[string]string // Session token -> table ID
+}
+
+// NewObservers creates an empty Observers
+func NewObservers() *Observers {
+	return &Observers{watching: make(map[string]string)}
+}
+
+// Watch makes token an observer of tableID, replacing whatever it watched before
+func (o *Observers) Watch(token, tableID string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.watching[token] = tableID
+}
+
+// Remove stops token watching and reports whether it was watching a table
+// Safe on a nil Observers
+func (o *Observers) Remove(token string) bool {
+	if o == nil {
+		return false
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	_, ok := o.watching[token]
+	delete(o.watching, token)
+	return ok
+}
+
+// Tokens returns the sessions watching tableID in a stable order
+func (o *Observers) Tokens(tableID string) []string {
+	if o == nil {
+		return nil
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var tokens []string
+	for token, watched := range o.watching {
+		if watched == tableID {
+			tokens = append(tokens, token)
+		}
+	}
+	sort.Strings(tokens)
+	return tokens
+}
+
+// Count returns how many sessions are watching tableID
+func (o *Observers) Count(tableID string) int {
+	return len(o.Tokens(tableID))
+}
+
+// HandleWatchTable processes a watch_table message: the client starts receiving the table's
+// broadcasts as a spectator and is sent its current state
+func (c *Client) HandleWatchTable(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+	var watchPayload WatchTablePayload
+	if err := json.Unmarshal(payload, &watchPayload); err != nil {
+		return invalidPayloadError("watch_table", err)
+	}
+	if _, err := sm.GetSession(c.Token); err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+	if server.FindPlayerSeat(&c.Token) != nil {
+		return fmt.Errorf("already_seated")
+	}
+
+	table := server.tableByID(watchPayload.TableId)
+	if table == nil {
+		return fmt.Errorf("invalid_table")
+	}
+	if err := server.checkClubAccess(table, c.Token); err != nil {
+		return err
+	}
+
+	server.observers.Watch(c.Token, table.ID)
+	logger.Info("client watching table", "token", c.Token, "tableId", table.ID)
+
+	if err := server.sendPersonalizedTableState(c, table); err != nil {
+		return err
+	}
+	return server.broadcastLobbyState()
+}
+
+// HandleUnwatchTable processes an unwatch_table message
+func (c *Client) HandleUnwatchTable(server *Server, logger *slog.Logger) error {
+	if !server.observers.Remove(c.Token) {
+		return fmt.Errorf("not_watching")
+	}
+	logger.Info("client stopped watching table", "token", c.Token)
+	return server.broadcastLobbyState()
+}
+
+// stopWatching removes token from the observers, updating the lobby if it was watching
+func (s *Server) stopWatching(token string) {
+	if !s.observers.Remove(token) {
+		return
+	}
+	if err := s.broadcastLobbyState(); err != nil {
+		s.logger.Warn("failed to broadcast lobby state after observer left", "error", err)
+	}
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// TestWatchTable_CountsAndBroadcasts verifies watchers are counted in the lobby, receive the
+// table's broadcasts without hole cards, and stop being counted when they sit down or leave
+func TestWatchTable_CountsAndBroadcasts(t *testing.T) {
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+	seatTwoPlayers(table)
+	session, _ := server.sessionManager.CreateSession("Watcher")
+	watcher := connectTestClient(server, session.Token)
+
+	payload, _ := json.Marshal(WatchTablePayload{TableId: table.ID})
+	if err := watcher.HandleWatchTable(server.sessionManager, server, slog.Default(), payload); err != nil {
+		t.Fatalf("watch_table: %v", err)
+	}
+	messages := drainRawMessages(watcher)
+	if len(messages) == 0 || !strings.Contains(messages[0], `"type":"table_state"`) {
+		t.Fatalf("expected the table state on watching, got %v", messages)
+	}
+	if info := server.GetLobbyState()[0]; info.Observers != 1 || info.SeatsOccupied != 2 {
+		t.Errorf("expected 1 observer and 2 seated, got %+v", info)
+	}
+	if clients := server.GetClientsAtTable(table.ID); len(clients) != 1 || clients[0] != watcher {
+		t.Errorf("expected only the watcher to be connected at the table, got %d clients", len(clients))
+	}
+
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	sawHandStarted := false
+	for _, msg := range drainRawMessages(watcher) {
+		if strings.Contains(msg, `"type":"hand_started"`) {
+			sawHandStarted = true
+		}
+		if strings.Contains(msg, `"type":"cards_dealt"`) {
+			t.Errorf("watcher must not be dealt hole cards: %s", msg)
+		}
+	}
+	if !sawHandStarted {
+		t.Error("expected the watcher to see hand_started")
+	}
+
+	// Watching one table replaces the previous one
+	otherPayload, _ := json.Marshal(WatchTablePayload{TableId: server.tables[1].ID})
+	watcher.HandleWatchTable(server.sessionManager, server, slog.Default(), otherPayload)
+	if lobby := server.GetLobbyState(); lobby[0].Observers != 0 || lobby[1].Observers != 1 {
+		t.Errorf("expected the watcher to have moved tables, got %d and %d", lobby[0].Observers, lobby[1].Observers)
+	}
+
+	if err := server.HandleDisconnect(session.Token); err != nil {
+		t.Fatal(err)
+	}
+	if info := server.GetLobbyState()[1]; info.Observers != 0 {
+		t.Errorf("expected no observers after disconnect, got %d", info.Observers)
+	}
+}
+
+// TestWatchTable_Errors verifies seated players, unknown tables and unwatching without watching are refused
+func TestWatchTable_Errors(t *testing.T) {
+	server := NewServer(slog.Default())
+	session, _ := server.sessionManager.CreateSession("Alice")
+	client := connectTestClient(server, session.Token)
+
+	unknown, _ := json.Marshal(WatchTablePayload{TableId: "no-such-table"})
+	if err := client.HandleWatchTable(server.sessionManager, server, slog.Default(), unknown); err == nil || err.Error() != "invalid_table" {
+		t.Errorf("expected invalid_table, got %v", err)
+	}
+	if err := client.HandleUnwatchTable(server, slog.Default()); err == nil || err.Error() != "not_watching" {
+		t.Errorf("expected not_watching, got %v", err)
+	}
+
+	server.tables[0].AssignSeat(&session.Token)
+	payload, _ := json.Marshal(WatchTablePayload{TableId: server.tables[1].ID})
+	if err := client.HandleWatchTable(server.sessionManager, server, slog.Default(), payload); err == nil || err.Error() != "already_seated" {
+		t.Errorf("expected already_seated, got %v", err)
+	}
+}
+
+// TestWatchTable_SeatingStopsWatching verifies an observer who takes a seat is no longer counted
+func TestWatchTable_SeatingStopsWatching(t *testing.T) {
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+	session, _ := server.sessionManager.CreateSession("Alice")
+	client := connectTestClient(server, session.Token)
+
+	payload, _ := json.Marshal(WatchTablePayload{TableId: table.ID})
+	client.HandleWatchTable(server.sessionManager, server, slog.Default(), payload)
+	if _, err := server.seatPlayer(session.Token, "", table); err != nil {
+		t.Fatal(err)
+	}
+	if info := server.GetLobbyState()[0]; info.Observers != 0 || info.SeatsOccupied != 1 {
+		t.Errorf("expected a seated player and no observers, got %+v", info)
+	}
+}
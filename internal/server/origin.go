@@ -0,0 +1,144 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// AllowAllOrigins is the AllowedOrigins entry that disables origin checks (development only)
+const AllowAllOrigins = "*"
+
+// originPattern is a parsed AllowedOrigins entry
+// Scheme is empty when the entry matches any scheme; Wildcard entries match
+// any subdomain of Host but not Host itself
+type originPattern struct {
+	Scheme   string
+	Host     string
+	Wildcard bool
+}
+
+// parseOriginPattern parses an allow-list entry such as "https://poker.example.com",
+// "https://*.example.com" or "localhost:5173"
+func parseOriginPattern(entry string) (originPattern, error) {
+	entry = strings.ToLower(strings.TrimSpace(entry))
+	var pattern originPattern
+
+	if scheme, rest, ok := strings.Cut(entry, "://"); ok {
+		if scheme != "http" && scheme != "https" {
+			return originPattern{}, fmt.Errorf("invalid origin %q: scheme must be http or https", entry)
+		}
+		pattern.Scheme = scheme
+		entry = rest
+	}
+
+	if strings.HasPrefix(entry, "*.") {
+		pattern.Wildcard = true
+		entry = strings.TrimPrefix(entry, "*.")
+	}
+
+	if entry == "" || strings.ContainsAny(entry, "/*?#@") {
+		return originPattern{}, fmt.Errorf("invalid origin %q: expected [scheme://][*.]host[:port]", entry)
+	}
+	pattern.Host = entry
+	return pattern, nil
+}
+
+// matches reports whether the origin's scheme and host (host[:port]) fit the pattern
+func (p originPattern) matches(scheme, host string) bool {
+	if p.Scheme != "" && p.Scheme != scheme {
+		return false
+	}
+	if p.Wildcard {
+		return strings.HasSuffix(host, "."+p.Host)
+	}
+	return host == p.Host
+}
+
+// validateAllowedOrigins reports the first malformed AllowedOrigins entry
+func validateAllowedOrigins(entries []string) error {
+	for _, entry := range entries {
+		if entry == AllowAllOrigins {
+			continue
+		}
+		if _, err := parseOriginPattern(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// originAllowed decides whether a browser at origin may talk to this server at requestHost
+// Requests without an Origin header (non-browser clients) and same-origin requests are
+// always allowed; anything else must match an allow-list entry
+func originAllowed(origin, requestHost string, allowed []string) bool {
+	if origin == "" {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Host)
+
+	if host == strings.ToLower(requestHost) {
+		return true
+	}
+
+	for _, entry := range allowed {
+		if entry == AllowAllOrigins {
+			return true
+		}
+		pattern, err := parseOriginPattern(entry)
+		if err != nil {
+			continue
+		}
+		if pattern.matches(scheme, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkOrigin is the WebSocket upgrader's origin check
+// The allow-list is read on every upgrade so config reloads apply immediately
+func (s *Server) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if originAllowed(origin, r.Host, s.Config().AllowedOrigins) {
+		return true
+	}
+
+	s.logger.Warn("websocket upgrade rejected: origin not allowed", "origin", origin, "host", r.Host, "client_ip", ClientIP(r))
+	return false
+}
+
+// corsMiddleware adds CORS headers for cross-origin requests from allowed origins
+// and answers their preflight requests
+func (s *Server) corsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Origin")
+		if !originAllowed(origin, r.Host, s.Config().AllowedOrigins) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
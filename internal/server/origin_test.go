@@ -0,0 +1,113 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// TestOriginAllowed verifies same-origin, exact, wildcard and allow-all matching
+func TestOriginAllowed(t *testing.T) {
+	allowed := []string{"https://poker.example.com", "https://*.games.example.com", "localhost:5173"}
+
+	tests := []struct {
+		name     string
+		origin   string
+		host     string
+		allowed  []string
+		expected bool
+	}{
+		{"no origin header", "", "poker.local:8080", nil, true},
+		{"same origin", "http://poker.local:8080", "poker.local:8080", nil, true},
+		{"cross origin without allow-list", "https://evil.example", "poker.local:8080", nil, false},
+		{"exact match", "https://poker.example.com", "api.internal", allowed, true},
+		{"scheme mismatch", "http://poker.example.com", "api.internal", allowed, false},
+		{"port mismatch", "https://poker.example.com:8443", "api.internal", allowed, false},
+		{"wildcard subdomain", "https://eu.games.example.com", "api.internal", allowed, true},
+		{"wildcard nested subdomain", "https://a.eu.games.example.com", "api.internal", allowed, true},
+		{"wildcard excludes apex", "https://games.example.com", "api.internal", allowed, false},
+		{"wildcard suffix trick", "https://evilgames.example.com", "api.internal", allowed, false},
+		{"schemeless entry", "http://localhost:5173", "api.internal", allowed, true},
+		{"case insensitive", "HTTPS://Poker.Example.com", "api.internal", allowed, true},
+		{"allow all", "https://anything.example", "api.internal", []string{AllowAllOrigins}, true},
+		{"null origin", "null", "api.internal", allowed, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := originAllowed(tt.origin, tt.host, tt.allowed); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestValidateAllowedOrigins verifies malformed entries are rejected
+func TestValidateAllowedOrigins(t *testing.T) {
+	for _, entry := range []string{"ftp://example.com", "https://example.com/path", "https://*", "*.*.example.com"} {
+		if err := validateAllowedOrigins([]string{entry}); err == nil {
+			t.Errorf("expected error for %q", entry)
+		}
+	}
+	if err := validateAllowedOrigins([]string{AllowAllOrigins, "https://*.example.com"}); err != nil {
+		t.Errorf("expected valid entries, got %v", err)
+	}
+}
+
+// TestHandleWebSocket_RejectsDisallowedOrigin verifies cross-site upgrades fail unless allow-listed
+func TestHandleWebSocket_RejectsDisallowedOrigin(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{AllowedOrigins: []string{"https://poker.example.com"}})
+	testServer := httptest.NewServer(server.HandleWebSocket(server.hub))
+	defer testServer.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(testServer.URL, "http")
+	dialer := websocket.Dialer{}
+
+	header := http.Header{"Origin": []string{"https://evil.example"}}
+	ws, resp, err := dialer.Dial(wsURL, header)
+	if err == nil {
+		ws.Close()
+		t.Fatal("expected upgrade from disallowed origin to fail")
+	}
+	if resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected 403 response, got %v", resp)
+	}
+
+	header = http.Header{"Origin": []string{"https://poker.example.com"}}
+	ws, _, err = dialer.Dial(wsURL, header)
+	if err != nil {
+		t.Fatalf("expected upgrade from allowed origin to succeed: %v", err)
+	}
+	ws.Close()
+}
+
+// TestCORSMiddleware verifies CORS headers are only granted to allowed origins
+func TestCORSMiddleware(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{AllowedOrigins: []string{"https://poker.example.com"}})
+
+	req := httptest.NewRequest(http.MethodOptions, "/health", nil)
+	req.Header.Set("Origin", "https://poker.example.com")
+	req.Header.Set("Access-Control-Request-Method", "GET")
+	w := httptest.NewRecorder()
+	server.Router().ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected preflight status 204, got %d", w.Code)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://poker.example.com" {
+		t.Errorf("expected allowed origin header, got %q", got)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.Header.Set("Origin", "https://evil.example")
+	w = httptest.NewRecorder()
+	server.Router().ServeHTTP(w, req)
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("expected no CORS header for disallowed origin, got %q", got)
+	}
+}
@@ -0,0 +1,205 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+)
+
+// Pre-actions a player can queue before it is their turn
+const (
+	PreActionFold      = "fold"       // Fold if facing a bet; left to the player when checking is free
+	PreActionCheck     = "check"      // Check if nobody has bet; discarded otherwise
+	PreActionCheckFold = "check_fold" // Check if possible, fold otherwise
+	PreActionCall      = "call"       // Call the amount faced when queued; discarded if it changes
+	PreActionCallAny   = "call_any"   // Call whatever is bet, or check when nothing is
+)
+
+// Pre-action statuses reported in pre_action_status messages
+const (
+	PreActionQueued    = "queued"
+	PreActionCleared   = "cleared"
+	PreActionApplied   = "applied"
+	PreActionDiscarded = "discarded"
+)
+
+// PreActionPayload represents the payload for pre_action messages; an empty Action clears the queue
+type PreActionPayload struct {
+	Action string `json:"action"`
+}
+
+// PreActionStatusPayload represents the payload for pre_action_status messages, sent privately
+type PreActionStatusPayload struct {
+	Action     string `json:"action"`
+	CallAmount int    `json:"callAmount,omitempty"` // The amount a "call" pre-action will call
+	Status     string `json:"status"`
+}
+
+// PreAction is an action a seat queued for its next turn on the current street
+type PreAction struct {
+	Action     string
+	CallAmount int     // Chips to call when queued; "call" is discarded if this changes
+	Token      string  // Player who queued it
+	Client     *Client // Connection it came from, so it is applied as the player's own action
+	hand       *Hand
+	street     string
+}
+
+// validPreAction reports whether action names a pre-action
+func validPreAction(action string) bool {
+	switch action {
+	case PreActionFold, PreActionCheck, PreActionCheckFold, PreActionCall, PreActionCallAny:
+		return true
+	default:
+		return false
+	}
+}
+
+// resolve returns the action the pre-action stands for when the seat faces callAmount,
+// or false if it no longer applies
+func (p PreAction) resolve(callAmount int) (string, bool) {
+	switch p.Action {
+	case PreActionFold:
+		return "fold", callAmount > 0
+	case PreActionCheck:
+		return "check", callAmount == 0
+	case PreActionCheckFold:
+		if callAmount == 0 {
+			return "check", true
+		}
+		return "fold", true
+	case PreActionCall:
+		if callAmount != p.CallAmount {
+			return "", false
+		}
+		if callAmount == 0 {
+			return "check", true
+		}
+		return "call", true
+	case PreActionCallAny:
+		if callAmount == 0 {
+			return "check", true
+		}
+		return "call", true
+	default:
+		return "", false
+	}
+}
+
+// takePreActionLocked removes and returns the pre-action queued by seatIndex if it was queued
+// during the current street by the player still in the seat
+// (internal, must be called with lock held)
+func (t *Table) takePreActionLocked(seatIndex int) (PreAction, bool) {
+	preAction, ok := t.preActions[seatIndex]
+	if !ok {
+		return PreAction{}, false
+	}
+	delete(t.preActions, seatIndex)
+
+	hand := t.CurrentHand
+	token := t.Seats[seatIndex].Token
+	if hand == nil || preAction.hand != hand || preAction.street != hand.Street || token == nil || *token != preAction.Token {
+		return PreAction{}, false
+	}
+	return preAction, true
+}
+
+// HandlePreAction processes a pre_action message: the player's action for their next turn on
+// this street is queued, replacing any earlier one, or cleared when the action is empty
+func (c *Client) HandlePreAction(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+	var preActionPayload PreActionPayload
+	if err := json.Unmarshal(payload, &preActionPayload); err != nil {
+		return invalidPayloadError("pre_action", err)
+	}
+	if preActionPayload.Action != "" && !validPreAction(preActionPayload.Action) {
+		return fmt.Errorf("invalid_pre_action")
+	}
+
+	session, err := sm.GetSession(c.Token)
+	if err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+	if session.TableID == nil || session.SeatIndex == nil {
+		return newMessageError("error.player_not_seated", nil)
+	}
+	table := server.tableByID(*session.TableID)
+	if table == nil {
+		return newMessageError("error.table_not_found", nil)
+	}
+	seatIndex := *session.SeatIndex
+
+	table.mu.Lock()
+	hand := table.CurrentHand
+	if hand == nil {
+		table.mu.Unlock()
+		return fmt.Errorf("no_hand_in_progress")
+	}
+
+	if preActionPayload.Action == "" {
+		delete(table.preActions, seatIndex)
+		table.mu.Unlock()
+		return c.sendMessage("pre_action_status", PreActionStatusPayload{Status: PreActionCleared})
+	}
+
+	if len(hand.HoleCards[seatIndex]) == 0 || hand.FoldedPlayers[seatIndex] || table.Seats[seatIndex].Stack == 0 {
+		table.mu.Unlock()
+		return fmt.Errorf("not_in_hand")
+	}
+	if hand.CurrentActor != nil && *hand.CurrentActor == seatIndex {
+		table.mu.Unlock()
+		return fmt.Errorf("your_turn")
+	}
+
+	preAction := PreAction{
+		Action:     preActionPayload.Action,
+		CallAmount: hand.GetCallAmount(seatIndex),
+		Token:      c.Token,
+		Client:     c,
+		hand:       hand,
+		street:     hand.Street,
+	}
+	if table.preActions == nil {
+		table.preActions = make(map[int]PreAction)
+	}
+	table.preActions[seatIndex] = preAction
+	table.mu.Unlock()
+
+	logger.Info("pre-action queued", "tableID", table.ID, "seatIndex", seatIndex, "action", preAction.Action)
+	return c.sendMessage("pre_action_status", PreActionStatusPayload{
+		Action:     preAction.Action,
+		CallAmount: preAction.CallAmount,
+		Status:     PreActionQueued,
+	})
+}
+
+// applyPreAction plays the pre-action seatIndex queued now that it is their turn, or tells the
+// player it was discarded when the betting changed so that it no longer applies
+// Must be called without the table lock held
+func (s *Server) applyPreAction(table *Table, seatIndex int, preAction PreAction) {
+	table.mu.RLock()
+	hand := table.CurrentHand
+	stillActing := hand != nil && hand == preAction.hand && hand.CurrentActor != nil && *hand.CurrentActor == seatIndex
+	callAmount := 0
+	if stillActing {
+		callAmount = hand.GetCallAmount(seatIndex)
+	}
+	table.mu.RUnlock()
+	if !stillActing {
+		return
+	}
+
+	action, ok := preAction.resolve(callAmount)
+	if !ok {
+		s.sendPrivate(preAction.Token, "pre_action_status", PreActionStatusPayload{Action: preAction.Action, Status: PreActionDiscarded})
+		return
+	}
+
+	s.logger.Info("applying pre-action", "tableID", table.ID, "seatIndex", seatIndex, "preAction", preAction.Action, "action", action)
+	if err := s.processTableAction(context.Background(), table, preAction.Client, "", seatIndex, action); err != nil {
+		s.logger.Warn("failed to apply pre-action", "tableID", table.ID, "seatIndex", seatIndex, "error", err)
+		s.sendPrivate(preAction.Token, "pre_action_status", PreActionStatusPayload{Action: preAction.Action, Status: PreActionDiscarded})
+		return
+	}
+	s.sendPrivate(preAction.Token, "pre_action_status", PreActionStatusPayload{Action: preAction.Action, Status: PreActionApplied})
+}
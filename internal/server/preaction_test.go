@@ -0,0 +1,174 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// preActionTable seats three players with live sessions and connections and starts a hand
+func preActionTable(t *testing.T) (*Server, *Table, map[int]*Client) {
+	t.Helper()
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+	clients := make(map[int]*Client)
+	for _, name := range []string{"Alice", "Bob", "Carol"} {
+		session, _ := server.sessionManager.CreateSession(name)
+		seat, err := server.seatPlayer(session.Token, "", table)
+		if err != nil {
+			t.Fatal(err)
+		}
+		clients[seat.Index] = connectTestClient(server, session.Token)
+	}
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	return server, table, clients
+}
+
+// queuePreAction sends a pre_action for client and fails the test on error
+func queuePreAction(t *testing.T, server *Server, client *Client, action string) {
+	t.Helper()
+	payload, _ := json.Marshal(PreActionPayload{Action: action})
+	if err := client.HandlePreAction(server.sessionManager, server, slog.Default(), payload); err != nil {
+		t.Fatalf("pre_action %q: %v", action, err)
+	}
+}
+
+// currentActor returns the seat on the clock, or -1 between streets
+func currentActor(table *Table) int {
+	table.mu.RLock()
+	defer table.mu.RUnlock()
+	if table.CurrentHand == nil || table.CurrentHand.CurrentActor == nil {
+		return -1
+	}
+	return *table.CurrentHand.CurrentActor
+}
+
+// waitForPreActionStatus polls client's messages for a pre_action_status with status
+func waitForPreActionStatus(client *Client, status string) bool {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		for _, msg := range drainRawMessages(client) {
+			if strings.Contains(msg, `"type":"pre_action_status"`) && strings.Contains(msg, `"status":"`+status+`"`) {
+				return true
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return false
+}
+
+// TestPreAction_AppliedWhenActionArrives verifies queued pre-actions play out in turn, calling
+// the bet and checking when there is nothing more to call
+func TestPreAction_AppliedWhenActionArrives(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	actor := currentActor(table)
+	for seat, client := range clients {
+		if seat != actor {
+			queuePreAction(t, server, client, PreActionCallAny)
+		}
+	}
+
+	actCurrent(t, server, table, "call")
+
+	if !waitForStreet(table, "flop", time.Second) {
+		t.Fatal("expected the queued call and check to close preflop")
+	}
+	for seat, client := range clients {
+		if seat != actor && !waitForPreActionStatus(client, PreActionApplied) {
+			t.Errorf("expected seat %d to be told its pre-action was applied", seat)
+		}
+	}
+}
+
+// TestPreAction_CallDiscardedByRaise verifies a raise invalidates a queued call, leaving the
+// decision to the player, and that pre-actions expire with their street
+func TestPreAction_CallDiscardedByRaise(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	actor := currentActor(table)
+	for seat, client := range clients {
+		if seat != actor {
+			queuePreAction(t, server, client, PreActionCall)
+		}
+	}
+
+	actCurrent(t, server, table, "raise", 60)
+	next := currentActor(table)
+	if !waitForPreActionStatus(clients[next], PreActionDiscarded) {
+		t.Fatal("expected the call to be discarded after the raise")
+	}
+	if currentActor(table) != next {
+		t.Errorf("expected seat %d to still be deciding, got %d", next, currentActor(table))
+	}
+}
+
+// TestPreAction_Errors verifies pre-actions are refused on your own turn, for unknown actions
+// and between hands, and that an empty action clears the queue
+func TestPreAction_Errors(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	actor := currentActor(table)
+
+	payload, _ := json.Marshal(PreActionPayload{Action: PreActionCheckFold})
+	if err := clients[actor].HandlePreAction(server.sessionManager, server, slog.Default(), payload); err == nil || err.Error() != "your_turn" {
+		t.Errorf("expected your_turn, got %v", err)
+	}
+
+	var other *Client
+	for seat, client := range clients {
+		if seat != actor {
+			other = client
+			break
+		}
+	}
+	bad, _ := json.Marshal(PreActionPayload{Action: "raise"})
+	if err := other.HandlePreAction(server.sessionManager, server, slog.Default(), bad); err == nil || err.Error() != "invalid_pre_action" {
+		t.Errorf("expected invalid_pre_action, got %v", err)
+	}
+
+	queuePreAction(t, server, other, PreActionCheckFold)
+	queuePreAction(t, server, other, "")
+	table.mu.RLock()
+	queued := len(table.preActions)
+	table.mu.RUnlock()
+	if queued != 0 {
+		t.Errorf("expected the pre-action to be cleared, %d still queued", queued)
+	}
+
+	table.mu.Lock()
+	table.CurrentHand = nil
+	table.mu.Unlock()
+	if err := other.HandlePreAction(server.sessionManager, server, slog.Default(), payload); err == nil || err.Error() != "no_hand_in_progress" {
+		t.Errorf("expected no_hand_in_progress, got %v", err)
+	}
+}
+
+// TestPreAction_Resolve verifies what each pre-action turns into facing a bet or not
+func TestPreAction_Resolve(t *testing.T) {
+	tests := []struct {
+		preAction  PreAction
+		callAmount int
+		want       string
+		ok         bool
+	}{
+		{PreAction{Action: PreActionFold}, 20, "fold", true},
+		{PreAction{Action: PreActionFold}, 0, "", false},
+		{PreAction{Action: PreActionCheck}, 0, "check", true},
+		{PreAction{Action: PreActionCheck}, 20, "", false},
+		{PreAction{Action: PreActionCheckFold}, 0, "check", true},
+		{PreAction{Action: PreActionCheckFold}, 20, "fold", true},
+		{PreAction{Action: PreActionCall, CallAmount: 20}, 20, "call", true},
+		{PreAction{Action: PreActionCall, CallAmount: 20}, 60, "", false},
+		{PreAction{Action: PreActionCall}, 0, "check", true},
+		{PreAction{Action: PreActionCallAny}, 500, "call", true},
+		{PreAction{Action: PreActionCallAny}, 0, "check", true},
+	}
+	for _, tt := range tests {
+		got, ok := tt.preAction.resolve(tt.callAmount)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("%s facing %d: got %q, %v; want %q, %v", tt.preAction.Action, tt.callAmount, got, ok, tt.want, tt.ok)
+		}
+	}
+}
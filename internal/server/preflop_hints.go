@@ -0,0 +1,193 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+// preflopRanks orders card ranks from lowest to highest, as the chart notation uses them
+const preflopRanks = "23456789TJQKA"
+
+// The embedded preflop chart, in the usual range notation: "77+" is sevens or better, "ATs+"
+// suited ace-ten up to ace-king, "AQo" offsuit ace-queen. It is deliberately simple: a rough
+// guide for learning at practice tables, not a solver's answer.
+var (
+	// preflopOpenRanges are the hands to raise with by position when nobody has raised yet
+	preflopOpenRanges = map[string]string{
+		"UTG": "77+ ATs+ KJs+ QJs JTs AQo+",
+		"HJ":  "66+ A9s+ KTs+ QTs+ JTs T9s AJo+ KQo",
+		"CO":  "44+ A2s+ K9s+ Q9s+ J9s+ T9s 98s 87s ATo+ KJo+ QJo",
+		"BTN": "22+ A2s+ K5s+ Q8s+ J8s+ T8s+ 97s+ 86s+ 76s 65s A7o+ K9o+ Q9o+ J9o+ T9o",
+		"SB":  "22+ A2s+ K7s+ Q9s+ J9s+ T9s 98s 87s A8o+ KTo+ QTo+ JTo",
+		"BB":  "88+ ATs+ KJs+ AJo+ KQo",
+	}
+	// preflopThreeBetRange is the hands to reraise with against a raise
+	preflopThreeBetRange = "QQ+ AKs AKo"
+	// preflopCallRange is the hands to call a raise with, and preflopDefendRange the wider
+	// range the big blind calls with
+	preflopCallRange   = "22+ ATs+ KTs+ QTs+ JTs T9s 98s AJo+ KQo"
+	preflopDefendRange = "22+ A2s+ K2s+ Q6s+ J7s+ T7s+ 97s+ 86s+ 75s+ 65s 54s A2o+ K8o+ Q9o+ J9o+ T9o"
+)
+
+// PreflopHintsPayload represents the payload for set_preflop_hints messages and their
+// preflop_hints reply
+type PreflopHintsPayload struct {
+	Enabled bool `json:"enabled"`
+}
+
+// PreflopHintPayload represents the payload for preflop_hint messages, sent privately to a
+// player who turned hints on when it is their turn preflop at a practice table
+type PreflopHintPayload struct {
+	TableID      string `json:"tableId"`
+	HandID       string `json:"handId"`
+	Position     string `json:"position"`     // UTG, HJ, CO, BTN, SB or BB
+	Hand         string `json:"hand"`         // The hole cards as a chart entry, like "AKs", "T9o" or "77"
+	Facing       string `json:"facing"`       // "unopened" (no raise yet) or "raise"
+	Action       string `json:"action"`       // fold, check, call or raise
+	TrainingOnly bool   `json:"trainingOnly"` // Always true: a simplified chart for practice, not advice
+}
+
+// parsePreflopRange returns the chart entries of a range like "77+ ATs+ KQo"
+func parsePreflopRange(spec string) map[string]bool {
+	hands := make(map[string]bool)
+	for _, entry := range strings.Fields(spec) {
+		plus := strings.HasSuffix(entry, "+")
+		entry = strings.TrimSuffix(entry, "+")
+		high, low := strings.IndexByte(preflopRanks, entry[0]), strings.IndexByte(preflopRanks, entry[1])
+		if high == low {
+			for r := low; r <= low || (plus && r < len(preflopRanks)); r++ {
+				hands[preflopRanks[r:r+1]+preflopRanks[r:r+1]] = true
+			}
+			continue
+		}
+		for r := low; r <= low || (plus && r < high); r++ {
+			hands[entry[:1]+preflopRanks[r:r+1]+entry[2:]] = true
+		}
+	}
+	return hands
+}
+
+// preflopHand returns two hole cards as a chart entry: the higher rank first, then "s" when
+// suited or "o" when not; pairs have no suffix
+func preflopHand(cards []Card) string {
+	if len(cards) != 2 {
+		return ""
+	}
+	high, low := cards[0], cards[1]
+	if high.Rank() < low.Rank() {
+		high, low = low, high
+	}
+	switch {
+	case high.Rank() == low.Rank():
+		return high.RankLetter() + low.RankLetter()
+	case high.Suit() == low.Suit():
+		return high.RankLetter() + low.RankLetter() + "s"
+	default:
+		return high.RankLetter() + low.RankLetter() + "o"
+	}
+}
+
+// preflopPositionLocked names seat's position in the hand, counting back from the small blind:
+// the button, then the cutoff, hijack and under the gun (caller must hold t.mu)
+func (t *Table) preflopPositionLocked(hand *Hand, seat int) string {
+	switch seat {
+	case hand.BigBlindSeat:
+		return "BB"
+	case hand.SmallBlindSeat:
+		// Heads-up the button posts the small blind
+		if len(hand.HoleCards) == 2 {
+			return "BTN"
+		}
+		return "SB"
+	}
+	labels := []string{"BTN", "CO", "HJ", "UTG"}
+	behind := 0
+	for n := 1; n < len(t.Seats); n++ {
+		i := (hand.SmallBlindSeat - n + len(t.Seats)) % len(t.Seats)
+		if _, dealt := hand.HoleCards[i]; !dealt {
+			continue
+		}
+		if i == seat {
+			return labels[min(behind, len(labels)-1)]
+		}
+		behind++
+	}
+	return ""
+}
+
+// preflopAdvice returns the chart's action for hand at position, facing a raise or not, among
+// the valid actions
+func preflopAdvice(position, hand string, raised bool, validActions []string) string {
+	action := "fold"
+	switch {
+	case !raised:
+		if parsePreflopRange(preflopOpenRanges[position])[hand] {
+			action = "raise"
+		}
+	case parsePreflopRange(preflopThreeBetRange)[hand]:
+		action = "raise"
+	case parsePreflopRange(preflopCallRange)[hand],
+		position == "BB" && parsePreflopRange(preflopDefendRange)[hand]:
+		action = "call"
+	}
+
+	// Never suggest folding what can be checked, or raising when only calling is left
+	if action == "fold" && slices.Contains(validActions, "check") {
+		return "check"
+	}
+	if action == "raise" && !slices.Contains(validActions, "raise") {
+		if slices.Contains(validActions, "call") {
+			return "call"
+		}
+		return "check"
+	}
+	return action
+}
+
+// sendPreflopHint sends the player at seatIndex a hint for their turn, if the table is a
+// practice table, the hand is preflop and they turned hints on
+func (s *Server) sendPreflopHint(table *Table, seatIndex int, validActions []string) {
+	if !table.Practice {
+		return
+	}
+	table.mu.RLock()
+	hand := table.CurrentHand
+	token := table.Seats[seatIndex].Token
+	if hand == nil || hand.Street != "preflop" || token == nil || !s.sessionManager.PreflopHints(*token) {
+		table.mu.RUnlock()
+		return
+	}
+	hint := PreflopHintPayload{
+		TableID:      table.ID,
+		HandID:       hand.ID,
+		Position:     table.preflopPositionLocked(hand, seatIndex),
+		Hand:         preflopHand(hand.HoleCards[seatIndex]),
+		Facing:       "unopened",
+		TrainingOnly: true,
+	}
+	if hand.CurrentBet > table.BigBlind {
+		hint.Facing = "raise"
+	}
+	recipient := *token
+	table.mu.RUnlock()
+
+	hint.Action = preflopAdvice(hint.Position, hint.Hand, hint.Facing == "raise", validActions)
+	s.sendPrivate(recipient, "preflop_hint", hint)
+}
+
+// HandleSetPreflopHints processes a set_preflop_hints message: whether the player gets
+// preflop_hint messages at practice tables, and replies with preflop_hints. Hints are never
+// sent at other tables.
+func (c *Client) HandleSetPreflopHints(sm *SessionManager, logger *slog.Logger, payload []byte) error {
+	var req PreflopHintsPayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return invalidPayloadError("set_preflop_hints", err)
+	}
+	if err := sm.SetPreflopHints(c.Token, req.Enabled); err != nil {
+		return err
+	}
+	logger.Info("preflop hints set", "token", c.Token, "enabled", req.Enabled)
+	return c.sendMessage("preflop_hints", req)
+}
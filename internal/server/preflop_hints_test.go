@@ -0,0 +1,94 @@
+package server
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// TestPreflopChart verifies range notation, hole card entries and the chart's advice
+func TestPreflopChart(t *testing.T) {
+	hands := parsePreflopRange("TT+ A9s+ KQo")
+	for _, hand := range []string{"TT", "JJ", "AA", "A9s", "AKs", "KQo"} {
+		if !hands[hand] {
+			t.Errorf("expected %s in the range", hand)
+		}
+	}
+	if len(hands) != 5+5+1 {
+		t.Errorf("expected 11 entries, got %v", hands)
+	}
+
+	for hole, want := range map[string]string{"As Kh": "AKo", "9d Td": "T9s", "7c 7h": "77"} {
+		if got := preflopHand(cards(hole)); got != want {
+			t.Errorf("expected %s for %s, got %s", want, hole, got)
+		}
+	}
+
+	tests := []struct {
+		position, hand string
+		raised         bool
+		valid          []string
+		want           string
+	}{
+		{"BTN", "K5s", false, []string{"fold", "call", "raise"}, "raise"},
+		{"UTG", "K5s", false, []string{"fold", "call", "raise"}, "fold"},
+		{"BB", "72o", false, []string{"check", "raise"}, "check"},
+		{"CO", "AKo", true, []string{"fold", "call", "raise"}, "raise"},
+		{"CO", "T9s", true, []string{"fold", "call", "raise"}, "call"},
+		{"CO", "K5s", true, []string{"fold", "call", "raise"}, "fold"},
+		{"BB", "K5s", true, []string{"fold", "call", "raise"}, "call"},
+		{"HJ", "AA", true, []string{"fold", "call"}, "call"},
+	}
+	for _, tt := range tests {
+		if got := preflopAdvice(tt.position, tt.hand, tt.raised, tt.valid); got != tt.want {
+			t.Errorf("%s %s (raised %v): expected %s, got %s", tt.position, tt.hand, tt.raised, tt.want, got)
+		}
+	}
+}
+
+// TestPreflopHints_PracticeTablesOnly verifies hints go to the player on the clock at a practice
+// table once they opt in, and never at a normal table
+func TestPreflopHints_PracticeTablesOnly(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{Tables: []TableConfig{
+		{Name: "Practice", SmallBlind: 10, BigBlind: 20, BuyIn: 1000, Practice: true},
+		{Name: "Main", SmallBlind: 10, BigBlind: 20, BuyIn: 1000},
+	}})
+	ledger := DefaultConfig()
+	ledger.Bankroll.Enabled = true
+	ledger.Tables = []TableConfig{{Name: "Practice", SmallBlind: 10, BigBlind: 20, BuyIn: 1000, Practice: true, Currency: CurrencyLedger}}
+	if err := ledger.Validate(); err == nil || !strings.Contains(err.Error(), "practice") {
+		t.Errorf("expected a ledger practice table refused, got %v", err)
+	}
+
+	for _, table := range server.tables {
+		clients := seatNamed(t, server, table, table.Name+" Alice", table.Name+" Bob", table.Name+" Carol")
+		for _, client := range clients {
+			if err := server.sessionManager.SetPreflopHints(client.Token, true); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := table.StartHand(); err != nil {
+			t.Fatal(err)
+		}
+
+		table.mu.RLock()
+		actor := *table.CurrentHand.CurrentActor
+		token := *table.Seats[actor].Token
+		table.mu.RUnlock()
+		for _, client := range clients {
+			hints := payloadsOf[PreflopHintPayload](t, client, "preflop_hint")
+			switch {
+			case !table.Practice || client.Token != token:
+				if len(hints) != 0 {
+					t.Errorf("%s: expected no hint for %s, got %+v", table.Name, client.Token, hints)
+				}
+			case len(hints) != 1 || hints[0].Position != "BTN" || hints[0].Facing != "unopened" || !hints[0].TrainingOnly || hints[0].Action == "":
+				t.Errorf("%s: expected one training hint for the button, got %+v", table.Name, hints)
+			}
+		}
+	}
+
+	if lobby := server.GetLobbyState(); !lobby[0].Practice || lobby[1].Practice {
+		t.Errorf("expected only the practice table marked in the lobby, got %+v", lobby)
+	}
+}
@@ -0,0 +1,167 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// handRetentionSweep is how often hands past Config.HandRetention are looked for
+const handRetentionSweep = time.Minute
+
+// PrivacyPayload represents the payload for set_privacy messages and their privacy reply: the
+// account's privacy settings
+type PrivacyPayload struct {
+	// HideStats keeps the player's statistics out of table_state on tables that show them, and
+	// their name out of the day's superlatives
+	HideStats bool `json:"hideStats"`
+	// LimitHistory deletes the hands the player was dealt into once they are Config.HandRetention
+	// old, the regulatory minimum, instead of keeping them as long as the replay store can
+	LimitHistory bool `json:"limitHistory"`
+}
+
+// SetPrivacy stores the named account's privacy settings
+func (s *AccountStore) SetPrivacy(name string, privacy PrivacyPayload) error {
+	return s.update(name, func(account *Account) error {
+		account.HideStats = privacy.HideStats
+		account.LimitHistory = privacy.LimitHistory
+		return nil
+	})
+}
+
+// Privacy returns the named account's privacy settings; everything is public and kept by default
+func (s *AccountStore) Privacy(name string) PrivacyPayload {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	account := s.accounts[accountKey(name)]
+	return PrivacyPayload{HideStats: account.HideStats, LimitHistory: account.LimitHistory}
+}
+
+// publicSuperlatives returns records without the names of players who hide their statistics, or
+// without any names when they were set at an anonymous table
+func (s *Server) publicSuperlatives(records Superlatives, anonymous bool) Superlatives {
+	hidden := func(name string) bool { return anonymous || s.accounts.Privacy(name).HideStats }
+	if records.BiggestPot != nil && hidden(records.BiggestPot.Winner) {
+		pot := *records.BiggestPot
+		pot.Winner = ""
+		records.BiggestPot = &pot
+	}
+	if records.BestHand != nil && hidden(records.BestHand.Player) {
+		hand := *records.BestHand
+		hand.Player = ""
+		records.BestHand = &hand
+	}
+	if records.FastestElimination != nil && hidden(records.FastestElimination.Player) {
+		bust := *records.FastestElimination
+		bust.Player = ""
+		records.FastestElimination = &bust
+	}
+	return records
+}
+
+// Prune deletes the finished hands that ended before cutoff and drop selects, with their
+// summaries. Returns the IDs of the hands deleted.
+func (rs *ReplayStore) Prune(cutoff time.Time, drop func(replay *HandReplay) bool) []string {
+	if rs == nil {
+		return nil
+	}
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	var pruned []string
+	rs.order = slices.DeleteFunc(rs.order, func(id string) bool {
+		replay := rs.hands[id]
+		if !replay.EndedAt.Before(cutoff) || !drop(replay) {
+			return false
+		}
+		delete(rs.hands, id)
+		pruned = append(pruned, id)
+		return true
+	})
+	for tableID, history := range rs.history {
+		rs.history[tableID] = slices.DeleteFunc(history, func(summary HandSummary) bool {
+			return slices.Contains(pruned, summary.HandID)
+		})
+	}
+	return pruned
+}
+
+// remove deletes the snippets of the hands with the given IDs
+func (ss *SnippetStore) remove(handIDs []string) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	for _, id := range handIDs {
+		delete(ss.snippets, id)
+	}
+	ss.order = slices.DeleteFunc(ss.order, func(id string) bool {
+		return slices.Contains(handIDs, id)
+	})
+}
+
+// runHandRetention deletes hands past their retention every interval until stop is closed
+func (s *Server) runHandRetention(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			s.pruneHandHistories(now)
+		}
+	}
+}
+
+// pruneHandHistories deletes the hands older than Config.HandRetention at now that were dealt to
+// a player who limited their history, with their replays and snippets
+func (s *Server) pruneHandHistories(now time.Time) {
+	retention := s.Config().HandRetention
+	if retention <= 0 {
+		return
+	}
+	pruned := s.replays.Prune(now.Add(-retention), func(replay *HandReplay) bool {
+		for _, name := range replay.Players {
+			if s.accounts.Privacy(name).LimitHistory {
+				return true
+			}
+		}
+		return false
+	})
+	if len(pruned) == 0 {
+		return
+	}
+	s.snippets.remove(pruned)
+	s.logger.Info("hand histories deleted past their retention", "hands", len(pruned), "retention", retention)
+}
+
+// HandleSetPrivacy processes a set_privacy message: the player's privacy settings, and replies
+// with privacy
+// The settings live on the account, so they follow the player across sessions and restarts.
+func (c *Client) HandleSetPrivacy(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+	var req PrivacyPayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return invalidPayloadError("set_privacy", err)
+	}
+	session, err := sm.GetSession(c.Token)
+	if err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+	if err := server.accounts.SetPrivacy(session.Name, req); err != nil {
+		return err
+	}
+	logger.Info("privacy set", "name", session.Name, "hideStats", req.HideStats, "limitHistory", req.LimitHistory)
+	if err := c.sendMessage("privacy", req); err != nil {
+		return err
+	}
+
+	// Stats shown at the player's table appear or disappear at once
+	if session.TableID != nil {
+		if err := server.broadcastTableState(*session.TableID, nil); err != nil {
+			logger.Warn("failed to broadcast table_state", "tableID", *session.TableID, "error", err)
+		}
+	}
+	return nil
+}
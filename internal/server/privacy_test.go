@@ -0,0 +1,99 @@
+package server
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// TestPrivacy_HideStats verifies a player who hides their statistics gets privacy back, and
+// their seat stops showing stats and their name leaves the superlatives
+func TestPrivacy_HideStats(t *testing.T) {
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+	table.mu.Lock()
+	table.ShowStats = true
+	table.mu.Unlock()
+	clients := seatNamed(t, server, table, "Alice", "Bob")
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	handID := handIDOf(table)
+	playOutChecking(t, server, table)
+	storedReplay(t, server, handID)
+	if !eventually(func() bool { return len(statsShown(server, table)) == 2 }) {
+		t.Fatal("expected both seats to show stats")
+	}
+	drainRawMessages(clients[0])
+
+	if err := clients[0].HandleSetPrivacy(server.sessionManager, server, slog.Default(), []byte(`{"hideStats":true}`)); err != nil {
+		t.Fatal(err)
+	}
+	if replies := payloadsOf[PrivacyPayload](t, clients[0], "privacy"); len(replies) != 1 || !replies[0].HideStats {
+		t.Errorf("expected the settings echoed, got %+v", replies)
+	}
+	if shown := statsShown(server, table); len(shown) != 1 || shown[0] != "Bob" {
+		t.Errorf("expected only Bob's stats shown, got %v", shown)
+	}
+	if !server.accounts.Privacy("alice").HideStats {
+		t.Error("expected the setting stored on the account")
+	}
+
+	records := server.publicSuperlatives(Superlatives{
+		BiggestPot: &PotRecord{Winner: "Alice", Pot: 40},
+		BestHand:   &HandRecord{Player: "Bob", Hand: "pair"},
+	}, false)
+	if records.BiggestPot.Winner != "" || records.BestHand.Player != "Bob" {
+		t.Errorf("expected only Alice's name removed, got %+v %+v", records.BiggestPot, records.BestHand)
+	}
+}
+
+// statsShown returns the names of the players whose stats table_state shows
+func statsShown(server *Server, table *Table) []string {
+	var names []string
+	for _, seat := range server.buildTableState(table, nil).Seats {
+		if seat.Stats != nil && seat.PlayerName != nil {
+			names = append(names, *seat.PlayerName)
+		}
+	}
+	return names
+}
+
+// TestPrivacy_LimitHistory verifies hands dealt to a player who limited their history are
+// deleted once past the retention minimum, and other hands are kept
+func TestPrivacy_LimitHistory(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{Tables: DefaultTables(), HandRetention: time.Hour})
+	defer server.Shutdown(t.Context())
+	limited, kept := server.tables[0], server.tables[1]
+	seatNamed(t, server, limited, "Alice", "Bob")
+	seatNamed(t, server, kept, "Carol", "Dave")
+	if err := server.accounts.SetPrivacy("Alice", PrivacyPayload{LimitHistory: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	var handIDs []string
+	for _, table := range []*Table{limited, kept} {
+		if err := table.StartHand(); err != nil {
+			t.Fatal(err)
+		}
+		handIDs = append(handIDs, handIDOf(table))
+		playOutChecking(t, server, table)
+		storedReplay(t, server, handIDs[len(handIDs)-1])
+	}
+
+	server.pruneHandHistories(time.Now())
+	if _, ok := server.replays.Hand(handIDs[0]); !ok {
+		t.Fatal("expected the hand kept until the retention minimum")
+	}
+
+	server.pruneHandHistories(time.Now().Add(2 * time.Hour))
+	if _, ok := server.replays.Hand(handIDs[0]); ok {
+		t.Error("expected Alice's hand deleted past the retention minimum")
+	}
+	if len(server.replays.History(limited.ID)) != 0 {
+		t.Error("expected the hand gone from the table history")
+	}
+	if _, ok := server.replays.Hand(handIDs[1]); !ok {
+		t.Error("expected other players' hands kept")
+	}
+}
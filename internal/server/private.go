@@ -0,0 +1,98 @@
+package server
+
+// Hole cards must only ever reach their owner. Everything that puts hole cards on the
+// wire goes through this file: redactHoleCards for payloads built for a viewer, and
+// sendPrivate for messages addressed to one seated player. Table broadcasts never
+// carry hole cards, with one exception: when everyone still in the hand is all-in
+// before the river, revealLiveHoleCards turns the live hands face up for the table.
+
+// HandsRevealedPayload represents the payload for hands_revealed messages
+type HandsRevealedPayload struct {
+	HoleCards map[int][]Card `json:"holeCards"` // Live hands by seat
+	Reason    string         `json:"reason"`    // Why the hands were revealed ("all_in")
+}
+
+// redactHoleCards returns the hole cards viewerSeat may see: only its own, or none for a
+// spectator (nil viewerSeat). The cards are copied so the payload never aliases the hand.
+func redactHoleCards(holeCards map[int][]Card, viewerSeat *int) map[int][]Card {
+	if viewerSeat == nil {
+		return nil
+	}
+
+	redacted := make(map[int][]Card)
+	if cards, ok := holeCards[*viewerSeat]; ok {
+		redacted[*viewerSeat] = append([]Card(nil), cards...)
+	}
+	return redacted
+}
+
+// sendPrivate delivers a message to the one connection holding token, and no one else
+// Returns false when the player is offline or their queue is full
+func (s *Server) sendPrivate(token string, msgType string, payload interface{}) bool {
+	if token == "" || s.hub == nil {
+		return false
+	}
+
+	message, err := marshalMessage(msgType, payload)
+	if err != nil {
+		s.logger.Warn("failed to marshal private message", "type", msgType, "error", err)
+		return false
+	}
+
+	s.hub.mu.RLock()
+	defer s.hub.mu.RUnlock()
+
+	client, ok := s.hub.sessions[token]
+	if !ok {
+		return false
+	}
+	// The hub lock keeps client.send open; never block while holding it
+	select {
+	case client.send <- message:
+		return true
+	default:
+		s.logger.Warn("client send channel full, dropping private message", "type", msgType)
+		return false
+	}
+}
+
+// revealedHoleCards returns copies of the hole cards revealed to the whole table, nil if none
+// (internal, must be called with the table lock held)
+func revealedHoleCards(hand *Hand) map[int][]Card {
+	if len(hand.RevealedSeats) == 0 {
+		return nil
+	}
+
+	revealed := make(map[int][]Card, len(hand.RevealedSeats))
+	for seatIndex := range hand.RevealedSeats {
+		revealed[seatIndex] = append([]Card(nil), hand.HoleCards[seatIndex]...)
+	}
+	return revealed
+}
+
+// revealLiveHoleCards turns every live (unfolded) hand face up and broadcasts them in
+// hands_revealed. Called when no further betting is possible, before the runout.
+// Must be called without the table lock held
+func (t *Table) revealLiveHoleCards(reason string) error {
+	t.mu.Lock()
+	hand := t.CurrentHand
+	if hand == nil {
+		t.mu.Unlock()
+		return newMessageError("error.no_hand_in_progress", nil)
+	}
+	if hand.RevealedSeats == nil {
+		hand.RevealedSeats = make(map[int]bool)
+	}
+	for i, seat := range t.Seats {
+		if seat.Status == "active" && !hand.FoldedPlayers[i] && len(hand.HoleCards[i]) > 0 {
+			hand.RevealedSeats[i] = true
+		}
+	}
+	payload := HandsRevealedPayload{HoleCards: revealedHoleCards(hand), Reason: reason}
+	t.mu.Unlock()
+
+	if t.Server == nil {
+		return nil
+	}
+	return t.Server.broadcastTableMessage(t, "hands_revealed", payload)
+}
@@ -0,0 +1,324 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRedactHoleCards verifies that redactHoleCards returns only the viewer's cards
+func TestRedactHoleCards(t *testing.T) {
+	// Create test hole cards - using a map[int][]Card structure (slice, not array)
+	holeCards := map[int][]Card{
+		0: {NewCard("A", "s"), NewCard("K", "h")},
+		1: {NewCard("Q", "d"), NewCard("J", "c")},
+		2: {NewCard("T", "s"), NewCard("9", "h")},
+		3: {NewCard("8", "d"), NewCard("7", "c")},
+	}
+
+	// Test filtering for player at seat 0
+	filtered := redactHoleCards(holeCards, &[]int{0}[0])
+	if len(filtered) != 1 {
+		t.Errorf("expected 1 card entry for player 0, got %d", len(filtered))
+	}
+	if cards, ok := filtered[0]; ok {
+		if len(cards) != 2 {
+			t.Errorf("expected 2 cards for player 0, got %d", len(cards))
+		}
+		if cards[0].RankLetter() != "A" || cards[0].SuitLetter() != "s" {
+			t.Errorf("expected As, got %s", cards[0].String())
+		}
+		if cards[1].RankLetter() != "K" || cards[1].SuitLetter() != "h" {
+			t.Errorf("expected Kh, got %s", cards[1].String())
+		}
+	} else {
+		t.Error("expected seat 0 in filtered map")
+	}
+
+	// Verify no other seats are present
+	for seat := range filtered {
+		if seat != 0 {
+			t.Errorf("unexpected seat %d in filtered map", seat)
+		}
+	}
+
+	// Test filtering for player at seat 2
+	filtered = redactHoleCards(holeCards, &[]int{2}[0])
+	if len(filtered) != 1 {
+		t.Errorf("expected 1 card entry for player 2, got %d", len(filtered))
+	}
+	if cards, ok := filtered[2]; ok {
+		if len(cards) != 2 {
+			t.Errorf("expected 2 cards for player 2, got %d", len(cards))
+		}
+		if cards[0].RankLetter() != "T" || cards[0].SuitLetter() != "s" {
+			t.Errorf("expected Ts, got %s", cards[0].String())
+		}
+	} else {
+		t.Error("expected seat 2 in filtered map")
+	}
+
+	// Test filtering for player not in holeCards
+	filtered = redactHoleCards(holeCards, &[]int{5}[0])
+	if len(filtered) != 0 {
+		t.Errorf("expected empty map for player 5, got %d entries", len(filtered))
+	}
+
+	// Spectators see no hole cards at all
+	if filtered = redactHoleCards(holeCards, nil); filtered != nil {
+		t.Errorf("expected nil for a spectator, got %v", filtered)
+	}
+
+	// The redacted cards must not alias the hand's
+	filtered = redactHoleCards(holeCards, &[]int{0}[0])
+	filtered[0][0] = NewCard("2", "c")
+	if holeCards[0][0].RankLetter() != "A" {
+		t.Error("modifying redacted cards changed the hand")
+	}
+}
+
+// connectTestClient registers a client holding token with the server's hub
+func connectTestClient(server *Server, token string) *Client {
+	client := &Client{hub: server.hub, send: make(chan []byte, 256)}
+	server.hub.mu.Lock()
+	server.hub.clients[client] = true
+	server.hub.mu.Unlock()
+	if token != "" {
+		client.setToken(token)
+	}
+	return client
+}
+
+// drainRawMessages returns all messages queued for client
+func drainRawMessages(client *Client) []string {
+	var messages []string
+	for {
+		select {
+		case msg := <-client.send:
+			messages = append(messages, string(msg))
+		default:
+			return messages
+		}
+	}
+}
+
+// cardJSON is how card appears inside a serialized payload
+func cardJSON(t *testing.T, card Card) string {
+	t.Helper()
+	data, err := json.Marshal(card)
+	if err != nil {
+		t.Fatalf("failed to marshal card: %v", err)
+	}
+	return string(data)
+}
+
+// TestHoleCards_NeverBroadcast plays a hand to the flop and checks that no message to any
+// client carries another seat's hole cards, and that spectators never see hole cards
+func TestHoleCards_NeverBroadcast(t *testing.T) {
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+	alice, bob := seatSessionPlayers(t, server, table)
+	spectatorSession, _ := server.sessionManager.CreateSession("Carol")
+
+	clients := map[string]*Client{
+		alice:                  connectTestClient(server, alice),
+		bob:                    connectTestClient(server, bob),
+		spectatorSession.Token: connectTestClient(server, spectatorSession.Token),
+	}
+
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	for _, client := range clients {
+		if err := client.SendTableState(server, table.ID, slog.Default()); err != nil {
+			t.Fatalf("SendTableState failed: %v", err)
+		}
+	}
+
+	// Call and check to the flop so action, board and table updates are covered
+	for _, action := range []string{"call", "check"} {
+		table.mu.RLock()
+		actor := *table.CurrentHand.CurrentActor
+		table.mu.RUnlock()
+		if err := server.processTableAction(context.Background(), table, nil, "", actor, action); err != nil {
+			t.Fatalf("%s failed: %v", action, err)
+		}
+	}
+
+	table.mu.RLock()
+	if table.CurrentHand.Street != "flop" {
+		t.Fatalf("expected the flop, got %s", table.CurrentHand.Street)
+	}
+	ownCards := make(map[string][]Card)
+	for i, seat := range table.Seats {
+		if seat.Token != nil {
+			ownCards[*seat.Token] = table.CurrentHand.HoleCards[i]
+		}
+	}
+	table.mu.RUnlock()
+
+	for token, client := range clients {
+		messages := drainRawMessages(client)
+		if len(messages) == 0 {
+			t.Fatalf("client %s received no messages", token)
+		}
+
+		sawOwnCards := false
+		for _, message := range messages {
+			for owner, cards := range ownCards {
+				for _, card := range cards {
+					if !strings.Contains(message, cardJSON(t, card)) {
+						continue
+					}
+					if owner != token {
+						t.Errorf("client %s received another seat's hole card %s in %s", token, card, message)
+					} else {
+						sawOwnCards = true
+					}
+				}
+			}
+		}
+
+		if _, seated := ownCards[token]; seated && !sawOwnCards {
+			t.Errorf("seated client %s never received its own hole cards", token)
+		}
+	}
+}
+
+// TestSendPrivate_ReachesOnlyTheSessionHolder verifies private messages go to one connection
+func TestSendPrivate_ReachesOnlyTheSessionHolder(t *testing.T) {
+	server := NewServer(slog.Default())
+	owner := connectTestClient(server, "owner")
+	other := connectTestClient(server, "other")
+	spectator := connectTestClient(server, "")
+
+	if !server.sendPrivate("owner", "cards_dealt", CardsDealtPayload{}) {
+		t.Fatal("sendPrivate to a connected session failed")
+	}
+	if server.sendPrivate("offline", "cards_dealt", CardsDealtPayload{}) {
+		t.Error("sendPrivate to an offline session reported success")
+	}
+
+	if types := drainMessageTypes(t, owner); len(types) != 1 || types[0] != "cards_dealt" {
+		t.Errorf("owner received %v, want one cards_dealt", types)
+	}
+	if types := drainMessageTypes(t, other); len(types) != 0 {
+		t.Errorf("other client received %v", types)
+	}
+	if types := drainMessageTypes(t, spectator); len(types) != 0 {
+		t.Errorf("spectator received %v", types)
+	}
+}
+
+// TestAllInRunout_RevealsLiveHands verifies an all-in before the river shows the live hands
+// to the table before the first runout street, and never the folded hand
+func TestAllInRunout_RevealsLiveHands(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{Pacing: PacingConfig{Flop: 50 * time.Millisecond, Turn: time.Minute}})
+	table := server.tables[0]
+	seatTwoPlayers(table)
+	token3 := "player3"
+	table.mu.Lock()
+	table.Seats[2] = Seat{Index: 2, Token: &token3, Status: "active", Stack: 1000}
+	table.mu.Unlock()
+
+	watcher := connectTestClient(server, "player1")
+
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	table.mu.RLock()
+	folder := *table.CurrentHand.CurrentActor
+	table.mu.RUnlock()
+
+	actCurrent(t, server, table, "fold")
+	actCurrent(t, server, table, "raise", 1000)
+	actCurrent(t, server, table, "call")
+
+	var types []string
+	var revealed HandsRevealedPayload
+	deadline := time.After(2 * time.Second)
+	for !containsType(types, "board_dealt") {
+		select {
+		case msg := <-watcher.send:
+			var wsMsg WebSocketMessage
+			if err := json.Unmarshal(msg, &wsMsg); err != nil {
+				t.Fatalf("failed to unmarshal message: %v", err)
+			}
+			types = append(types, wsMsg.Type)
+			if wsMsg.Type == "hands_revealed" {
+				if err := json.Unmarshal(wsMsg.Payload, &revealed); err != nil {
+					t.Fatalf("failed to unmarshal hands_revealed: %v", err)
+				}
+			}
+		case <-deadline:
+			t.Fatalf("no board_dealt, got %v", types)
+		}
+	}
+
+	if !containsType(types, "hands_revealed") {
+		t.Fatalf("expected hands_revealed before the runout, got %v", types)
+	}
+	if len(revealed.HoleCards) != 2 || revealed.Reason != "all_in" {
+		t.Errorf("expected two live hands revealed for all_in, got %+v", revealed)
+	}
+	if _, ok := revealed.HoleCards[folder]; ok {
+		t.Errorf("folded seat %d was revealed", folder)
+	}
+
+	// A spectator arriving mid-runout sees the revealed hands but no private hole cards
+	state := server.buildTableState(table, nil)
+	if len(state.RevealedCards) != 2 || state.HoleCards != nil {
+		t.Errorf("spectator table_state: revealed %v, hole cards %v", state.RevealedCards, state.HoleCards)
+	}
+}
+
+// TestAllInRunout_NoRevealWhileOthersCanBet verifies one short all-in keeps hands down while two players still have chips
+func TestAllInRunout_NoRevealWhileOthersCanBet(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{Pacing: PacingConfig{Flop: 50 * time.Millisecond, Turn: time.Minute}})
+	table := server.tables[0]
+	seatTwoPlayers(table)
+	token3 := "player3"
+	table.mu.Lock()
+	table.Seats[2] = Seat{Index: 2, Token: &token3, Status: "active", Stack: 1000}
+	table.mu.Unlock()
+
+	watcher := connectTestClient(server, "player1")
+
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+	// The first actor posted no blind, so a short stack leaves them all-in for 100
+	table.mu.Lock()
+	shortStack := *table.CurrentHand.CurrentActor
+	table.Seats[shortStack].Stack = 100
+	table.mu.Unlock()
+
+	actCurrent(t, server, table, "raise", 100)
+	actCurrent(t, server, table, "call")
+	actCurrent(t, server, table, "call")
+
+	var types []string
+	deadline := time.After(2 * time.Second)
+	for !containsType(types, "board_dealt") {
+		select {
+		case msg := <-watcher.send:
+			var wsMsg WebSocketMessage
+			if err := json.Unmarshal(msg, &wsMsg); err != nil {
+				t.Fatalf("failed to unmarshal message: %v", err)
+			}
+			types = append(types, wsMsg.Type)
+		case <-deadline:
+			t.Fatalf("no board_dealt, got %v", types)
+		}
+	}
+
+	if containsType(types, "hands_revealed") {
+		t.Errorf("hands revealed while two players could still bet, got %v", types)
+	}
+	if state := server.buildTableState(table, nil); len(state.RevealedCards) != 0 {
+		t.Errorf("spectator table_state revealed %v", state.RevealedCards)
+	}
+}
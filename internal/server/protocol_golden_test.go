@@ -0,0 +1,236 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// goldenEntry is one message in a golden transcript: an inbound message a player sent, or an
+// outbound message a player received
+type goldenEntry struct {
+	From    string `json:"from,omitempty"`
+	To      string `json:"to,omitempty"`
+	Message any    `json:"message"`
+}
+
+// protocolHarness drives a table through the real message router (Client.handleMessage) with
+// fake clients and records every message each client sends and receives
+type protocolHarness struct {
+	t          *testing.T
+	server     *Server
+	table      *Table
+	names      []string
+	clients    map[string]*Client
+	tokens     map[string]string // Session token -> placeholder
+	actions    int               // Actions sent so far, numbering their action IDs
+	transcript []goldenEntry
+}
+
+// newProtocolHarness returns a harness whose table always deals the deck shuffled by seed
+func newProtocolHarness(t *testing.T, seed byte) *protocolHarness {
+	t.Helper()
+	server := NewServer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	table := server.tables[0]
+	table.shuffleSeeds = func() (ShuffleSeed, error) { return ShuffleSeed{seed}, nil }
+	return &protocolHarness{
+		t:       t,
+		server:  server,
+		table:   table,
+		clients: make(map[string]*Client),
+		tokens:  make(map[string]string),
+	}
+}
+
+// connect creates a session for name and connects a fake client with it
+func (h *protocolHarness) connect(name string) {
+	h.t.Helper()
+	session, err := h.server.sessionManager.CreateSession(name)
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	h.names = append(h.names, name)
+	h.clients[name] = connectTestClient(h.server, session.Token)
+	h.tokens[session.Token] = "<token:" + name + ">"
+}
+
+// send routes a message from name as if it had arrived on the WebSocket, then records what
+// every client received
+func (h *protocolHarness) send(name, msgType string, payload any) {
+	h.t.Helper()
+	data, err := json.Marshal(payload)
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	raw, _ := json.Marshal(WebSocketMessage{Type: msgType, Payload: data})
+	h.transcript = append(h.transcript, goldenEntry{From: name, Message: h.normalize(raw)})
+	if !h.clients[name].handleMessage(h.server.sessionManager, h.server, slog.New(slog.NewTextHandler(io.Discard, nil)), raw) {
+		h.t.Fatalf("%s was disconnected sending %s", name, msgType)
+	}
+	h.collect()
+}
+
+// collect records the messages queued for each client, clients in the order they connected
+func (h *protocolHarness) collect() {
+	for _, name := range h.names {
+		for _, msg := range drainRawMessages(h.clients[name]) {
+			h.transcript = append(h.transcript, goldenEntry{To: name, Message: h.normalize([]byte(msg))})
+		}
+	}
+}
+
+// normalize decodes a message and replaces values that change from run to run
+func (h *protocolHarness) normalize(raw []byte) any {
+	h.t.Helper()
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		h.t.Fatalf("invalid message %s: %v", raw, err)
+	}
+	return h.normalizeValue("", value)
+}
+
+func (h *protocolHarness) normalizeValue(key string, value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, item := range v {
+			v[k] = h.normalizeValue(k, item)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = h.normalizeValue(key, item)
+		}
+		return v
+	case float64:
+		if key == "serverTime" || key == "revealAt" {
+			return "<time>"
+		}
+		return v
+	case string:
+		if placeholder, ok := h.tokens[v]; ok {
+			return placeholder
+		}
+		if key == "handId" {
+			return "<hand>"
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// verify compares the transcript with testdata/golden/<name>.json, rewriting it with -update
+func (h *protocolHarness) verify(name string) {
+	h.t.Helper()
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(h.transcript); err != nil {
+		h.t.Fatal(err)
+	}
+	got := buf.Bytes()
+	path := filepath.Join("testdata", "golden", name+".json")
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			h.t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			h.t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		h.t.Fatalf("%v (run go test -run %s -update to create it)", err, h.t.Name())
+	}
+	if !bytes.Equal(got, want) {
+		h.t.Errorf("messages differ from %s (run go test -run %s -update to accept):\n%s", path, h.t.Name(), firstDifference(want, got))
+	}
+}
+
+// firstDifference describes the first line where two transcripts differ
+func firstDifference(want, got []byte) string {
+	wantLines := strings.Split(string(want), "\n")
+	gotLines := strings.Split(string(got), "\n")
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			return fmt.Sprintf("line %d:\n  want: %s\n  got:  %s", i+1, w, g)
+		}
+	}
+	return "transcripts differ"
+}
+
+// act sends the next action of the script for whoever is on the clock
+func (h *protocolHarness) act(action string, amount ...int) {
+	h.t.Helper()
+	seat := currentActor(h.table)
+	if seat < 0 {
+		h.t.Fatalf("nobody to %s", action)
+	}
+	h.actions++
+	payload := PlayerActionPayload{ActionID: fmt.Sprintf("action-%d", h.actions), SeatIndex: seat, Action: action}
+	if len(amount) > 0 {
+		payload.Amount = &amount[0]
+	}
+	h.send(h.names[seat], "player_action", payload) // Seat i is the i-th player to connect
+}
+
+// seatThree connects Alice, Bob and Carol, seats them in that order (seats 0 to 2), has Dave
+// watch the table and starts a hand
+func (h *protocolHarness) seatThree() {
+	h.t.Helper()
+	for _, name := range []string{"Alice", "Bob", "Carol", "Dave"} {
+		h.connect(name)
+	}
+	h.collect()
+	for _, name := range h.names[:3] {
+		h.send(name, "join_table", JoinTablePayload{TableId: h.table.ID})
+	}
+	h.send("Dave", "watch_table", WatchTablePayload{TableId: h.table.ID})
+	h.send("Alice", "start_hand", struct{}{})
+}
+
+// TestProtocolGolden_Showdown plays a three-handed hand to showdown
+func TestProtocolGolden_Showdown(t *testing.T) {
+	h := newProtocolHarness(t, 1)
+	h.seatThree()
+	h.act("call")
+	h.act("call")
+	h.act("check")
+	h.act("check")
+	h.act("raise", 60)
+	h.act("fold")
+	h.act("call")
+	h.act("check")
+	h.act("check")
+	h.act("check")
+	h.act("check")
+	h.verify("showdown")
+}
+
+// TestProtocolGolden_FoldedAround plays a hand everyone folds to a raise
+func TestProtocolGolden_FoldedAround(t *testing.T) {
+	h := newProtocolHarness(t, 2)
+	h.seatThree()
+	h.act("raise", 60)
+	h.act("fold")
+	h.act("fold")
+	h.verify("folded_around")
+}
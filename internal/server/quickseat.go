@@ -0,0 +1,253 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// QuickSeatPayload represents the payload for quick_seat messages: what kind of table the
+// player wants. Empty fields accept any table.
+type QuickSeatPayload struct {
+	MinBigBlind int          `json:"minBigBlind,omitempty"`
+	MaxBigBlind int          `json:"maxBigBlind,omitempty"`
+	GameType    string       `json:"gameType,omitempty"`
+	Speed       string       `json:"speed,omitempty"`
+	Currency    ChipCurrency `json:"currency,omitempty"`
+}
+
+// Quick-seat outcomes reported in QuickSeatResultPayload.Status
+const (
+	QuickSeatSeated     = "seated"     // The player was seated at TableID
+	QuickSeatWaitlisted = "waitlisted" // No table had room; the player is queued at Position
+	QuickSeatCancelled  = "cancelled"  // The player left the waitlist
+)
+
+// QuickSeatResultPayload represents the payload for quick_seat_result messages
+type QuickSeatResultPayload struct {
+	Status    string `json:"status"`
+	TableID   string `json:"tableId,omitempty"`
+	SeatIndex *int   `json:"seatIndex,omitempty"`
+	Position  int    `json:"position,omitempty"` // 1-based place in the waitlist
+}
+
+// accepts reports whether a listed table matches the preferences, has an open seat and is not frozen
+func (p QuickSeatPayload) accepts(table TableInfo) bool {
+	filter := LobbyFilter{
+		MinBigBlind:  p.MinBigBlind,
+		MaxBigBlind:  p.MaxBigBlind,
+		GameType:     p.GameType,
+		Speed:        p.Speed,
+		MinOpenSeats: 1,
+	}
+	if table.Frozen || table.Closing || (p.Currency != "" && p.Currency != table.Currency) {
+		return false
+	}
+	return filter.matches(table)
+}
+
+// waitlistEntry is a player waiting for a quick seat
+type waitlistEntry struct {
+	Token    string
+	RemoteIP string
+	Prefs    QuickSeatPayload
+	QueuedAt time.Time
+}
+
+// Waitlist queues players for whom quick-seat found no open seat, first come first served
+// A player is seated as soon as a matching table frees a seat
+type Waitlist struct {
+	mu      sync.Mutex
+	entries []waitlistEntry
+}
+
+// NewWaitlist creates an empty Waitlist
+func NewWaitlist() *Waitlist {
+	return &Waitlist{}
+}
+
+// Add queues entry, or updates the preferences of a player already waiting in place
+// Returns the player's 1-based position
+func (w *Waitlist) Add(entry waitlistEntry) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for i := range w.entries {
+		if w.entries[i].Token == entry.Token {
+			w.entries[i].Prefs = entry.Prefs
+			w.entries[i].RemoteIP = entry.RemoteIP
+			return i + 1
+		}
+	}
+	w.entries = append(w.entries, entry)
+	return len(w.entries)
+}
+
+// Remove takes the player off the waitlist, reporting whether they were on it
+// Safe to call on the nil Waitlist
+func (w *Waitlist) Remove(token string) bool {
+	if w == nil {
+		return false
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for i := range w.entries {
+		if w.entries[i].Token == token {
+			w.entries = append(w.entries[:i], w.entries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Entries returns the queued players in order
+func (w *Waitlist) Entries() []waitlistEntry {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return append([]waitlistEntry(nil), w.entries...)
+}
+
+// HandleQuickSeat processes a quick_seat message: the player is seated at the matching table
+// with the most players that still has room, or queued on the waitlist if there is none
+func (c *Client) HandleQuickSeat(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+	var prefs QuickSeatPayload
+	if len(payload) > 0 {
+		if err := json.Unmarshal(payload, &prefs); err != nil {
+			return invalidPayloadError("quick_seat", err)
+		}
+	}
+	if prefs.MinBigBlind < 0 || prefs.MaxBigBlind < 0 || validateCurrency(prefs.Currency) != nil {
+		return fmt.Errorf("invalid_quick_seat")
+	}
+
+	if _, err := sm.GetSession(c.Token); err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+	if server.FindPlayerSeat(&c.Token) != nil {
+		return fmt.Errorf("already_seated")
+	}
+
+	// Busiest tables first, so players are brought together rather than spread thin
+	tables, _ := server.QueryLobby(LobbyFilter{Sort: LobbySortPlayers})
+	matched, affordable := false, false
+	for _, info := range tables {
+		if !prefs.accepts(info) {
+			continue
+		}
+		matched = true
+		table := server.tableByID(info.ID)
+		if table == nil {
+			continue
+		}
+
+		seat, err := server.seatPlayer(c.Token, c.RemoteIP, table)
+		if errors.Is(err, errInsufficientBalance) {
+			continue
+		}
+		affordable = true
+		if err != nil {
+			// Filled up since the lobby was read; try the next table
+			logger.Debug("quick_seat candidate unavailable", "tableID", table.ID, "error", err)
+			continue
+		}
+
+		logger.Info("player quick-seated", "token", c.Token, "tableID", table.ID, "seatIndex", seat.Index)
+		if err := c.sendMessage("quick_seat_result", QuickSeatResultPayload{Status: QuickSeatSeated, TableID: table.ID, SeatIndex: &seat.Index}); err != nil {
+			return err
+		}
+		server.announceSeat(table, c.Token, seat)
+		return nil
+	}
+
+	if matched && !affordable {
+		return errInsufficientBalance
+	}
+
+	position := server.waitlist.Add(waitlistEntry{Token: c.Token, RemoteIP: c.RemoteIP, Prefs: prefs, QueuedAt: time.Now()})
+	logger.Info("player waitlisted", "token", c.Token, "position", position)
+	return c.sendMessage("quick_seat_result", QuickSeatResultPayload{Status: QuickSeatWaitlisted, Position: position})
+}
+
+// HandleLeaveWaitlist processes a leave_waitlist message
+func (c *Client) HandleLeaveWaitlist(server *Server, logger *slog.Logger) error {
+	if !server.waitlist.Remove(c.Token) {
+		return fmt.Errorf("not_waitlisted")
+	}
+	logger.Info("player left the waitlist", "token", c.Token)
+	return c.sendMessage("quick_seat_result", QuickSeatResultPayload{Status: QuickSeatCancelled})
+}
+
+// announceSeat tells a newly seated player and the table about the seat, then starts the
+// countdown to the next hand if it can
+func (s *Server) announceSeat(table *Table, token string, seat Seat) {
+	s.sendPrivate(token, "seat_assigned", SeatAssignedPayload{TableId: table.ID, SeatIndex: seat.Index, Status: seat.Status})
+	if err := s.broadcastTableState(table.ID, nil); err != nil {
+		s.logger.Warn("failed to broadcast table_state", "error", err)
+	}
+	if err := s.broadcastLobbyState(); err != nil {
+		s.logger.Warn("failed to broadcast lobby state", "error", err)
+	}
+	table.ScheduleNextHand()
+}
+
+// RunWaitlist seats waiting players whenever a seat is freed
+// Returns when events is closed
+func (s *Server) RunWaitlist(events <-chan Event) {
+	for e := range events {
+		if e.Type == EventPlayerLeft {
+			s.fillFromWaitlist(e.TableID)
+		}
+	}
+}
+
+// fillFromWaitlist seats waiting players at the table, in queue order, while it has room
+// Players whose preferences do not match the table, or who cannot afford it, keep their place
+func (s *Server) fillFromWaitlist(tableID string) {
+	table := s.tableByID(tableID)
+	if table == nil {
+		return
+	}
+	// A heads-up table's challengers were waiting for this table in particular
+	if table.HeadsUp {
+		s.fillChallengers(table)
+	}
+
+	for _, entry := range s.waitlist.Entries() {
+		info, ok := s.lobbyInfo(tableID)
+		if !ok || info.SeatsOccupied >= info.MaxSeats {
+			return
+		}
+		if !entry.Prefs.accepts(info) {
+			continue
+		}
+
+		seat, err := s.seatPlayer(entry.Token, entry.RemoteIP, table)
+		if err != nil {
+			if !errors.Is(err, errInsufficientBalance) && !errors.Is(err, errTableFull) {
+				// The session is gone or unusable; it will never be seated
+				s.waitlist.Remove(entry.Token)
+			}
+			s.logger.Debug("waitlisted player not seated", "token", entry.Token, "tableID", tableID, "error", err)
+			continue
+		}
+
+		s.logger.Info("player seated from the waitlist", "token", entry.Token, "tableID", tableID, "seatIndex", seat.Index)
+		s.sendPrivate(entry.Token, "quick_seat_result", QuickSeatResultPayload{Status: QuickSeatSeated, TableID: tableID, SeatIndex: &seat.Index})
+		s.announceSeat(table, entry.Token, seat)
+	}
+}
+
+// lobbyInfo returns the lobby listing of one table
+func (s *Server) lobbyInfo(tableID string) (TableInfo, bool) {
+	for _, info := range s.GetLobbyState() {
+		if info.ID == tableID {
+			return info, true
+		}
+	}
+	return TableInfo{}, false
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fillSeats seats count placeholder players at table
+func fillSeats(table *Table, count int) {
+	table.mu.Lock()
+	defer table.mu.Unlock()
+	for i := 0; i < count; i++ {
+		token := fmt.Sprintf("%s-filler-%d", table.ID, i)
+		table.Seats[i].Token = &token
+		table.Seats[i].Status = "waiting"
+		table.Seats[i].Stack = table.BuyIn
+	}
+}
+
+// lastQuickSeatResult returns the last quick_seat_result queued for client
+func lastQuickSeatResult(t *testing.T, client *Client) QuickSeatResultPayload {
+	t.Helper()
+	var result QuickSeatResultPayload
+	found := false
+	for _, raw := range drainRawMessages(client) {
+		var msg WebSocketMessage
+		json.Unmarshal([]byte(raw), &msg)
+		if msg.Type == "quick_seat_result" {
+			json.Unmarshal(msg.Payload, &result)
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("expected a quick_seat_result message")
+	}
+	return result
+}
+
+// TestQuickSeat_PicksBusiestOpenTable verifies the matching table with the most players and a free seat is chosen
+func TestQuickSeat_PicksBusiestOpenTable(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{Tables: []TableConfig{
+		{Name: "Quiet", SmallBlind: 10, BigBlind: 20, BuyIn: 1000},
+		{Name: "Busy", SmallBlind: 10, BigBlind: 20, BuyIn: 1000},
+		{Name: "Full", SmallBlind: 10, BigBlind: 20, BuyIn: 1000},
+		{Name: "High", SmallBlind: 50, BigBlind: 100, BuyIn: 5000},
+	}})
+	fillSeats(server.tables[0], 1)
+	fillSeats(server.tables[1], 3)
+	fillSeats(server.tables[2], 6)
+	fillSeats(server.tables[3], 5)
+
+	session, _ := server.sessionManager.CreateSession("Alice")
+	client := connectTestClient(server, session.Token)
+
+	if err := client.HandleQuickSeat(server.sessionManager, server, slog.Default(), []byte(`{"maxBigBlind":20}`)); err != nil {
+		t.Fatalf("quick_seat: %v", err)
+	}
+	result := lastQuickSeatResult(t, client)
+	if result.Status != QuickSeatSeated || result.TableID != "table-2" || result.SeatIndex == nil || *result.SeatIndex != 3 {
+		t.Errorf("expected a seat at table-2, got %+v", result)
+	}
+	if seat, found := server.tables[1].GetSeatByToken(&session.Token); !found || seat.Stack != 1000 {
+		t.Errorf("expected Alice seated with a buy-in at Busy, got %+v (found=%v)", seat, found)
+	}
+
+	err := client.HandleQuickSeat(server.sessionManager, server, slog.Default(), nil)
+	if err == nil || err.Error() != "already_seated" {
+		t.Errorf("expected already_seated, got %v", err)
+	}
+}
+
+// TestQuickSeat_WaitlistSeatsWhenASeatFrees verifies players are queued when tables are full
+// and seated in order when a matching seat opens
+func TestQuickSeat_WaitlistSeatsWhenASeatFrees(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{Tables: []TableConfig{
+		{Name: "Full", SmallBlind: 10, BigBlind: 20, BuyIn: 1000},
+	}})
+	table := server.tables[0]
+	fillSeats(table, 6)
+
+	var clients []*Client
+	for _, name := range []string{"Alice", "Bob"} {
+		session, _ := server.sessionManager.CreateSession(name)
+		client := connectTestClient(server, session.Token)
+		if err := client.HandleQuickSeat(server.sessionManager, server, slog.Default(), []byte(`{}`)); err != nil {
+			t.Fatalf("quick_seat: %v", err)
+		}
+		clients = append(clients, client)
+	}
+	if result := lastQuickSeatResult(t, clients[1]); result.Status != QuickSeatWaitlisted || result.Position != 2 {
+		t.Fatalf("expected Bob waitlisted second, got %+v", result)
+	}
+
+	leaving := *table.Seats[4].Token
+	if err := table.ClearSeat(&leaving); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for len(server.waitlist.Entries()) != 1 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if _, found := table.GetSeatByToken(&clients[0].Token); !found {
+		t.Fatal("expected Alice to be seated from the waitlist")
+	}
+	if result := lastQuickSeatResult(t, clients[0]); result.Status != QuickSeatSeated || result.TableID != table.ID {
+		t.Errorf("expected Alice told about her seat, got %+v", result)
+	}
+
+	if err := clients[1].HandleLeaveWaitlist(server, slog.Default()); err != nil {
+		t.Fatalf("leave_waitlist: %v", err)
+	}
+	if len(server.waitlist.Entries()) != 0 {
+		t.Error("expected the waitlist to be empty")
+	}
+	if err := clients[1].HandleLeaveWaitlist(server, slog.Default()); err == nil || !strings.Contains(err.Error(), "not_waitlisted") {
+		t.Errorf("expected not_waitlisted, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"time"
+)
+
+// raiseConfirmWindow is how long a player has to confirm a large raise before it lapses
+const raiseConfirmWindow = 2 * time.Second
+
+// RaiseConfirmPayload represents the payload for confirm_raise messages, sent privately instead
+// of playing a raise that puts more than the table's ConfirmRaisePercent of the player's stack
+// in. The raise is played once the same player_action comes back with "confirm": true before
+// ExpiresAt; until then nobody else hears of it.
+type RaiseConfirmPayload struct {
+	ActionID  string `json:"actionId"`
+	TableID   string `json:"tableId"`
+	SeatIndex int    `json:"seatIndex"`
+	Amount    int    `json:"amount"`    // Total raised to
+	Chips     int    `json:"chips"`     // Chips the raise puts in from the stack
+	Stack     int    `json:"stack"`     // Stack before the raise
+	ExpiresAt int64  `json:"expiresAt"` // Unix ms
+}
+
+// raiseConfirmation is a large raise waiting for its player's confirmation
+type raiseConfirmation struct {
+	handID    string
+	seatIndex int
+	amount    int
+	expiresAt time.Time
+}
+
+// holdLargeRaise checks a raise from client against the confirmation window of their table.
+// A large raise sent without confirm is held: client gets confirm_raise and held is true. A
+// confirmed raise goes ahead only if it matches the one held and the window is still open.
+// Anything else, including raises at tables without a window, is left to the normal action path.
+func (s *Server) holdLargeRaise(client *Client, actionID string, seatIndex, amount int, confirm bool) (held bool, err error) {
+	session, err := s.sessionManager.GetSession(client.Token)
+	if err != nil || session.TableID == nil {
+		return false, nil
+	}
+	table := s.tableByID(*session.TableID)
+	if table == nil || table.ConfirmRaisePercent == 0 {
+		return false, nil
+	}
+
+	table.mu.Lock()
+	prompt, err := table.holdLargeRaiseLocked(client.Token, actionID, seatIndex, amount, confirm)
+	table.mu.Unlock()
+	if prompt == nil || err != nil {
+		return false, err
+	}
+
+	s.logger.Info("raise held for confirmation", "tableID", table.ID, "seatIndex", seatIndex, "amount", amount, "stack", prompt.Stack)
+	return true, client.sendMessage("confirm_raise", *prompt)
+}
+
+// holdLargeRaiseLocked returns the confirm_raise prompt for a raise that must wait for its
+// player's confirmation, or nil when it can be played (must be called with the table lock held)
+func (t *Table) holdLargeRaiseLocked(token, actionID string, seatIndex, amount int, confirm bool) (*RaiseConfirmPayload, error) {
+	hand := t.CurrentHand
+	if hand == nil || hand.CurrentActor == nil || *hand.CurrentActor != seatIndex {
+		return nil, nil
+	}
+	if _, done := t.getProcessedActionLocked(token, actionID); done {
+		return nil, nil
+	}
+
+	now := t.clock().Now()
+	if confirm {
+		pending := t.raiseConfirm
+		t.raiseConfirm = nil
+		if pending == nil || pending.handID != hand.ID || pending.seatIndex != seatIndex || pending.amount != amount || now.After(pending.expiresAt) {
+			return nil, newMessageError("error.raise_not_confirmed", nil)
+		}
+		return nil, nil
+	}
+
+	stack := t.Seats[seatIndex].Stack
+	chips := amount - hand.PlayerBets[seatIndex]
+	if chips*100 <= stack*t.ConfirmRaisePercent {
+		return nil, nil
+	}
+	expiresAt := now.Add(raiseConfirmWindow)
+	t.raiseConfirm = &raiseConfirmation{handID: hand.ID, seatIndex: seatIndex, amount: amount, expiresAt: expiresAt}
+	return &RaiseConfirmPayload{
+		ActionID:  actionID,
+		TableID:   t.ID,
+		SeatIndex: seatIndex,
+		Amount:    amount,
+		Chips:     chips,
+		Stack:     stack,
+		ExpiresAt: expiresAt.UnixMilli(),
+	}, nil
+}
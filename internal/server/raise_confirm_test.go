@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// TestHoldLargeRaise verifies a raise over the table's share of the stack is only played once
+// confirmed within the window, and is not broadcast before
+func TestHoldLargeRaise(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{
+		Tables: []TableConfig{{Name: "Casual", SmallBlind: 10, BigBlind: 20, BuyIn: 1000, ConfirmRaisePercent: 50}},
+	})
+	clock := useFakeClock(server)
+	table := server.tables[0]
+	clients := seatNamed(t, server, table, "Alice", "Bob")
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	actor := currentActor(table)
+	client := clients[0]
+	if seat, _ := table.GetSeatByToken(&clients[1].Token); seat.Index == actor {
+		client = clients[1]
+	}
+	raise := func(actionID string, amount int, confirm bool) error {
+		payload, _ := json.Marshal(PlayerActionPayload{ActionID: actionID, SeatIndex: actor, Action: "raise", Amount: &amount, Confirm: confirm})
+		return client.HandlePlayerActionMessage(context.Background(), server.sessionManager, server, slog.Default(), payload)
+	}
+	drainRawMessages(clients[0])
+	drainRawMessages(clients[1])
+
+	// A shove is held and nobody hears of it
+	if err := raise("a1", 800, false); err != nil {
+		t.Fatal(err)
+	}
+	prompts := payloadsOf[RaiseConfirmPayload](t, client, "confirm_raise")
+	if len(prompts) != 1 || prompts[0].ActionID != "a1" || prompts[0].Amount != 800 || prompts[0].Chips != 790 || prompts[0].Stack != 990 {
+		t.Fatalf("expected a confirm_raise for the shove, got %+v", prompts)
+	}
+	if currentActor(table) != actor {
+		t.Fatal("expected the raise not played before it is confirmed")
+	}
+	for _, c := range clients {
+		if results := payloadsOf[ActionResultPayload](t, c, "action_result"); len(results) != 0 {
+			t.Errorf("expected no action_result before confirmation, got %+v", results)
+		}
+	}
+
+	// A confirmation too late, or for another amount, is refused
+	clock.Advance(raiseConfirmWindow + time.Millisecond)
+	if err := raise("a1", 800, true); err == nil {
+		t.Error("expected a late confirmation refused")
+	} else if key, _ := errorMessageKey(err); key != "error.raise_not_confirmed" {
+		t.Errorf("expected error.raise_not_confirmed, got %v", err)
+	}
+	if err := raise("a2", 800, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := raise("a2", 700, true); err == nil {
+		t.Error("expected a confirmation for another amount refused")
+	}
+
+	// Confirmed in time, the raise is played
+	if err := raise("a3", 800, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := raise("a3", 800, true); err != nil {
+		t.Fatal(err)
+	}
+	if currentActor(table) == actor {
+		t.Fatal("expected the confirmed raise played")
+	}
+	if results := payloadsOf[ActionResultPayload](t, client, "action_result"); len(results) != 1 || results[0].ActionID != "a3" {
+		t.Errorf("expected one action_result for the confirmed raise, got %+v", results)
+	}
+}
+
+// TestHoldLargeRaise_SmallRaisesAndOtherTables verifies raises under the threshold, and raises
+// at tables without a confirmation window, are played at once
+func TestHoldLargeRaise_SmallRaisesAndOtherTables(t *testing.T) {
+	for _, percent := range []int{0, 50} {
+		server := NewServerWithConfig(slog.Default(), Config{
+			Tables: []TableConfig{{Name: "Main", SmallBlind: 10, BigBlind: 20, BuyIn: 1000, ConfirmRaisePercent: percent}},
+		})
+		table := server.tables[0]
+		clients := seatNamed(t, server, table, "Alice", "Bob")
+		if err := table.StartHand(); err != nil {
+			t.Fatal(err)
+		}
+		actor := currentActor(table)
+		client := clients[0]
+		if seat, _ := table.GetSeatByToken(&clients[1].Token); seat.Index == actor {
+			client = clients[1]
+		}
+		amount := 100
+		if percent == 0 {
+			amount = 1000
+		}
+		payload, _ := json.Marshal(PlayerActionPayload{ActionID: "a1", SeatIndex: actor, Action: "raise", Amount: &amount})
+		if err := client.HandlePlayerActionMessage(context.Background(), server.sessionManager, server, slog.Default(), payload); err != nil {
+			t.Fatal(err)
+		}
+		if currentActor(table) == actor {
+			t.Errorf("percent %d: expected the raise to %d played at once", percent, amount)
+		}
+	}
+}
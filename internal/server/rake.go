@@ -0,0 +1,107 @@
+package server
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"sort"
+)
+
+// computeRake returns the rake due on a pot of potAmount under cfg
+// The rake is rounded down to whole chips and limited to cfg.Cap when a cap is set
+func computeRake(cfg RakeConfig, potAmount int) int {
+	if cfg.Percent <= 0 || potAmount <= 0 {
+		return 0
+	}
+
+	rake := int(float64(potAmount) * cfg.Percent / 100)
+	if cfg.Cap > 0 && rake > cfg.Cap {
+		rake = cfg.Cap
+	}
+	return rake
+}
+
+// takeRakeLocked deducts the configured rake from the winners' shares in distribution
+// and returns the amount taken (internal, must be called with lock held)
+// Each winner pays in proportion to what they won; chips left over from rounding
+// are taken from winners in seat order. With NoFlopNoDrop, hands that end before
+// the flop are not raked.
+func (t *Table) takeRakeLocked(distribution map[int]int) int {
+	if t.Server == nil || t.CurrentHand == nil {
+		return 0
+	}
+
+	cfg := t.rulesLocked().rake
+	if cfg.NoFlopNoDrop && len(t.CurrentHand.BoardCards) == 0 {
+		return 0
+	}
+
+	potAmount := 0
+	seats := make([]int, 0, len(distribution))
+	for seatIdx, amount := range distribution {
+		potAmount += amount
+		seats = append(seats, seatIdx)
+	}
+	sort.Ints(seats)
+
+	rake := computeRake(cfg, potAmount)
+	if rake == 0 {
+		return 0
+	}
+
+	taken := 0
+	for _, seatIdx := range seats {
+		share := rake * distribution[seatIdx] / potAmount
+		distribution[seatIdx] -= share
+		taken += share
+	}
+	for _, seatIdx := range seats {
+		if taken == rake {
+			break
+		}
+		if distribution[seatIdx] > 0 {
+			distribution[seatIdx]--
+			taken++
+		}
+	}
+
+	t.RakeCollected += rake
+	return rake
+}
+
+// attributeRake shares rake among the seats that paid into the pot in proportion to the chips each
+// put in (contributions), so every player is charged for the pots they played, won or lost.
+// Chips left over from rounding go to the largest contributors first, then by seat.
+func attributeRake(rake int, contributions map[int]int) map[int]int {
+	total := 0
+	for _, amount := range contributions {
+		total += amount
+	}
+	if rake <= 0 || total <= 0 {
+		return nil
+	}
+
+	seats := slices.Collect(maps.Keys(contributions))
+	slices.SortFunc(seats, func(a, b int) int {
+		return cmp.Or(contributions[b]-contributions[a], a-b)
+	})
+	attributed := make(map[int]int, len(seats))
+	charged := 0
+	for _, seatIdx := range seats {
+		share := rake * contributions[seatIdx] / total
+		if share > 0 {
+			attributed[seatIdx] = share
+		}
+		charged += share
+	}
+	for _, seatIdx := range seats {
+		if charged == rake {
+			break
+		}
+		if contributions[seatIdx] > 0 {
+			attributed[seatIdx]++
+			charged++
+		}
+	}
+	return attributed
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"log/slog"
+	"maps"
+	"testing"
+)
+
+// TestComputeRake verifies percentage rounding and the cap
+func TestComputeRake(t *testing.T) {
+	tests := []struct {
+		cfg      RakeConfig
+		pot      int
+		expected int
+	}{
+		{RakeConfig{}, 1000, 0},
+		{RakeConfig{Percent: 5}, 1000, 50},
+		{RakeConfig{Percent: 5}, 39, 1},
+		{RakeConfig{Percent: 5, Cap: 30}, 1000, 30},
+		{RakeConfig{Percent: 5, Cap: 30}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		if got := computeRake(tt.cfg, tt.pot); got != tt.expected {
+			t.Errorf("computeRake(%+v, %d): expected %d, got %d", tt.cfg, tt.pot, tt.expected, got)
+		}
+	}
+}
+
+// TestAttributeRake verifies rake is charged in proportion to the chips put in, rounding chips
+// going to the largest contributors
+func TestAttributeRake(t *testing.T) {
+	tests := []struct {
+		rake          int
+		contributions map[int]int
+		expected      map[int]int
+	}{
+		{10, map[int]int{0: 100, 1: 100}, map[int]int{0: 5, 1: 5}},
+		{10, map[int]int{0: 150, 1: 50}, map[int]int{0: 8, 1: 2}},
+		{5, map[int]int{0: 100, 1: 100, 2: 100}, map[int]int{0: 2, 1: 2, 2: 1}},
+		{7, map[int]int{2: 60, 4: 200, 5: 0}, map[int]int{2: 1, 4: 6}},
+		{0, map[int]int{0: 100}, nil},
+	}
+
+	for _, tt := range tests {
+		if got := attributeRake(tt.rake, tt.contributions); !maps.Equal(got, tt.expected) {
+			t.Errorf("attributeRake(%d, %v): expected %v, got %v", tt.rake, tt.contributions, tt.expected, got)
+		}
+	}
+}
+
+// TestTakeRakeLocked_SplitPot verifies rake is shared by winners and rounding chips are not lost
+func TestTakeRakeLocked_SplitPot(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{Rake: RakeConfig{Percent: 5}})
+	table := server.tables[0]
+	table.CurrentHand = &Hand{BoardCards: []Card{NewCard("A", "s"), NewCard("K", "s"), NewCard("Q", "s")}}
+
+	distribution := map[int]int{0: 205, 3: 205}
+	rake := table.takeRakeLocked(distribution)
+
+	if rake != 20 {
+		t.Errorf("expected rake 20, got %d", rake)
+	}
+	if distribution[0]+distribution[3] != 390 {
+		t.Errorf("expected 390 chips awarded after rake, got %v", distribution)
+	}
+	if distribution[0] != 195 || distribution[3] != 195 {
+		t.Errorf("expected rake split evenly, got %v", distribution)
+	}
+	if table.RakeCollected != 20 {
+		t.Errorf("expected RakeCollected 20, got %d", table.RakeCollected)
+	}
+}
+
+// TestTakeRakeLocked_NoFlopNoDrop verifies hands that end preflop are not raked when configured
+func TestTakeRakeLocked_NoFlopNoDrop(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{Rake: RakeConfig{Percent: 5, NoFlopNoDrop: true}})
+	table := server.tables[0]
+	table.CurrentHand = &Hand{BoardCards: []Card{}}
+
+	distribution := map[int]int{0: 30}
+	if rake := table.takeRakeLocked(distribution); rake != 0 {
+		t.Errorf("expected no rake preflop, got %d", rake)
+	}
+	if distribution[0] != 30 {
+		t.Errorf("expected full pot awarded, got %d", distribution[0])
+	}
+}
+
+// TestHandleShowdown_TakesRake verifies the winner receives the pot minus rake
+func TestHandleShowdown_TakesRake(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{Rake: RakeConfig{Percent: 10}})
+	table := server.tables[0]
+	seatTwoPlayers(table)
+
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("failed to start hand: %v", err)
+	}
+
+	// Small blind completes to 20, then seat 1 folds so seat 0 wins 40 chips (4 raked)
+	table.mu.Lock()
+	hand := table.CurrentHand
+	table.Seats[hand.SmallBlindSeat].Stack -= 10
+	hand.PlayerBets[hand.SmallBlindSeat] = 20
+	hand.TotalContributions[hand.SmallBlindSeat] = 20
+	hand.FoldedPlayers[1] = true
+	table.mu.Unlock()
+	table.HandleShowdown()
+
+	table.mu.RLock()
+	defer table.mu.RUnlock()
+	if total := table.Seats[0].Stack + table.Seats[1].Stack; total != 1996 {
+		t.Errorf("expected 1996 chips left in play after 4 chip rake, got %d", total)
+	}
+	if table.RakeCollected != 4 {
+		t.Errorf("expected RakeCollected 4, got %d", table.RakeCollected)
+	}
+}
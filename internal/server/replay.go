@@ -0,0 +1,344 @@
+package server
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// replayHandLimit is how many finished hands the ReplayStore keeps; the oldest go first
+const replayHandLimit = 1000
+
+// Replay step types
+const (
+	ReplayStepBlind  = "blind"  // A blind was posted; Seat and Amount are set
+	ReplayStepAction = "action" // A betting action; Seat, Action and Amount are set
+	ReplayStepBoard  = "board"  // Board cards were dealt; Board is the whole board
+	ReplayStepResult = "result" // The pot was paid out; Winnings is set
+)
+
+// HandReplay is the record of one finished hand, built from table events
+// HoleCards only holds the cards shown down or shown by choice, so replays can be public.
+type HandReplay struct {
+	ID          string         `json:"id"`
+	TableID     string         `json:"tableId"`
+	StartedAt   time.Time      `json:"startedAt"`
+	EndedAt     time.Time      `json:"endedAt"`
+	DealerSeat  int            `json:"dealerSeat"`
+	Players     map[int]string `json:"players"` // Name per seat dealt in
+	Aliases     map[int]string `json:"-"`       // Name per seat shown at an anonymous table, nil elsewhere
+	Stacks      map[int]int    `json:"stacks"`  // Stack per seat before the blinds
+	HoleCards   map[int][]Card `json:"holeCards,omitempty"`
+	Steps       []ReplayStep   `json:"steps"`
+	Winnings    map[int]int    `json:"winnings"` // Chips won per seat, after rake
+	Pot         int            `json:"pot"`      // Chips paid out, rake included
+	WinningHand string         `json:"winningHand,omitempty"`
+}
+
+// ReplayStep is one thing that happened in a hand
+type ReplayStep struct {
+	Type     string      `json:"type"`
+	Time     time.Time   `json:"time"`
+	Street   string      `json:"street"`
+	Seat     *int        `json:"seat,omitempty"`
+	Action   string      `json:"action,omitempty"`
+	Amount   int         `json:"amount,omitempty"` // Chips moved into the pot
+	Timeout  bool        `json:"timeout,omitempty"`
+	Board    []Card      `json:"board,omitempty"`
+	Winnings map[int]int `json:"winnings,omitempty"`
+}
+
+// ReplayFrame is the table as it stood after a number of steps of a hand
+type ReplayFrame struct {
+	Step   int          `json:"step"` // Steps applied: 0 is the deal, len(Steps) the end of the hand
+	Street string       `json:"street"`
+	Board  []Card       `json:"board"`
+	Pot    int          `json:"pot"` // Chips swept in from finished streets
+	Seats  []ReplaySeat `json:"seats"`
+	Last   *ReplayStep  `json:"last,omitempty"` // The step that led to this frame
+}
+
+// ReplaySeat is one seat in a ReplayFrame
+type ReplaySeat struct {
+	Seat      int    `json:"seat"`
+	Name      string `json:"name"`
+	Stack     int    `json:"stack"`
+	Bet       int    `json:"bet,omitempty"` // Chips in front of the seat on this street
+	Folded    bool   `json:"folded,omitempty"`
+	HoleCards []Card `json:"holeCards,omitempty"`
+}
+
+// Frame returns the table after the first step steps of the hand, clamped to the hand's length
+func (r *HandReplay) Frame(step int) ReplayFrame {
+	step = max(0, min(step, len(r.Steps)))
+	frame := ReplayFrame{Step: step, Street: "preflop", Board: []Card{}}
+	seats := slices.Sorted(maps.Keys(r.Stacks))
+	stacks := maps.Clone(r.Stacks)
+	bets := make(map[int]int)
+	folded := make(map[int]bool)
+
+	sweep := func() {
+		for seat, bet := range bets {
+			frame.Pot += bet
+			delete(bets, seat)
+		}
+	}
+	for i := range step {
+		s := r.Steps[i]
+		frame.Street = s.Street
+		switch s.Type {
+		case ReplayStepBlind, ReplayStepAction:
+			stacks[*s.Seat] -= s.Amount
+			bets[*s.Seat] += s.Amount
+			if s.Action == "fold" {
+				folded[*s.Seat] = true
+			}
+		case ReplayStepBoard:
+			sweep()
+			frame.Board = s.Board
+		case ReplayStepResult:
+			sweep()
+			for seat, won := range s.Winnings {
+				stacks[seat] += won
+			}
+			frame.Pot = 0
+		}
+		frame.Last = &r.Steps[i]
+	}
+
+	for _, seat := range seats {
+		frame.Seats = append(frame.Seats, ReplaySeat{
+			Seat:      seat,
+			Name:      r.Players[seat],
+			Stack:     stacks[seat],
+			Bet:       bets[seat],
+			Folded:    folded[seat],
+			HoleCards: r.HoleCards[seat],
+		})
+	}
+	return frame
+}
+
+// public returns the replay as it may be shown: at an anonymous table the players go by their
+// aliases, except viewer, who sees their own name (empty for nobody). The replay itself keeps
+// the real names, which accounting, data exports and deletions go by.
+func (r *HandReplay) public(viewer string) *HandReplay {
+	if len(r.Aliases) == 0 {
+		return r
+	}
+	shown := *r
+	shown.Players = maps.Clone(r.Aliases)
+	shown.Aliases = nil
+	for seat, name := range r.Players {
+		if viewer != "" && accountKey(name) == accountKey(viewer) {
+			shown.Players[seat] = name
+		}
+	}
+	return &shown
+}
+
+// ReplayStore records hands from table events and keeps the most recent replayHandLimit of
+// them, plus a summary of each table's last hands for its history panel.
+// The nil ReplayStore records nothing.
+type ReplayStore struct {
+	mu      sync.RWMutex
+	nameOf  func(tableID, token string) (string, string)
+	playing map[string]*HandReplay   // Hand in progress per table
+	hands   map[string]*HandReplay   // Finished hands by ID
+	order   []string                 // Finished hand IDs, oldest first
+	history map[string][]HandSummary // Last tableHistoryLength hands per table, oldest first
+
+	finished func(replay *HandReplay)
+}
+
+// NewReplayStore creates an empty ReplayStore; nameOf looks up the name of a player with token
+// and, at an anonymous table, the alias they are shown under there (empty elsewhere), and
+// finished, if not nil, receives each hand once it is recorded
+func NewReplayStore(nameOf func(tableID, token string) (name, alias string), finished func(replay *HandReplay)) *ReplayStore {
+	return &ReplayStore{
+		nameOf:   nameOf,
+		finished: finished,
+		playing:  make(map[string]*HandReplay),
+		hands:    make(map[string]*HandReplay),
+		history:  make(map[string][]HandSummary),
+	}
+}
+
+// Run records events until the channel is closed
+func (rs *ReplayStore) Run(events <-chan Event) {
+	for e := range events {
+		if replay := rs.handle(e); replay != nil && rs.finished != nil {
+			rs.finished(replay)
+		}
+	}
+}
+
+// handle adds e to the hand in progress at its table
+// Returns the hand when e finished it
+func (rs *ReplayStore) handle(e Event) *HandReplay {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if e.Type == EventCardsShown {
+		rs.showCardsLocked(e)
+		return nil
+	}
+	if e.Type == EventHandStarted {
+		replay := &HandReplay{
+			ID:         e.HandID,
+			TableID:    e.TableID,
+			StartedAt:  e.Time,
+			DealerSeat: e.DealerSeat,
+			Players:    make(map[int]string),
+			Stacks:     maps.Clone(e.Stacks),
+		}
+		for seat, token := range e.Seats {
+			name, alias := rs.nameOf(e.TableID, token)
+			replay.Players[seat] = name
+			if alias != "" {
+				if replay.Aliases == nil {
+					replay.Aliases = make(map[int]string)
+				}
+				replay.Aliases[seat] = alias
+			}
+		}
+		for _, seat := range slices.Sorted(maps.Keys(e.Blinds)) {
+			replay.Steps = append(replay.Steps, ReplayStep{Type: ReplayStepBlind, Time: e.Time, Street: "preflop", Seat: &seat, Amount: e.Blinds[seat]})
+		}
+		rs.playing[e.TableID] = replay
+		return nil
+	}
+
+	replay, ok := rs.playing[e.TableID]
+	if !ok {
+		return nil
+	}
+	switch e.Type {
+	case EventPlayerAction:
+		seat := e.SeatIndex
+		replay.Steps = append(replay.Steps, ReplayStep{Type: ReplayStepAction, Time: e.Time, Street: e.Street, Seat: &seat, Action: e.Action, Amount: e.Amount, Timeout: e.Timeout})
+	case EventBoardDealt:
+		replay.Steps = append(replay.Steps, ReplayStep{Type: ReplayStepBoard, Time: e.Time, Street: e.Street, Board: slices.Clone(e.Board)})
+	case EventHandCancelled:
+		delete(rs.playing, e.TableID)
+	case EventHandEnded:
+		delete(rs.playing, e.TableID)
+		street := "preflop"
+		if len(replay.Steps) > 0 {
+			street = replay.Steps[len(replay.Steps)-1].Street
+		}
+		replay.EndedAt = e.Time
+		replay.Winnings = maps.Clone(e.Winnings)
+		replay.Pot = e.Pot
+		replay.Steps = append(replay.Steps, ReplayStep{Type: ReplayStepResult, Time: e.Time, Street: street, Winnings: replay.Winnings})
+		if e.WinningRank != nil {
+			if e.WinningRank.Rank >= 0 && e.WinningRank.Rank < len(handRankIDs) {
+				replay.WinningHand = handRankIDs[e.WinningRank.Rank]
+			}
+			// Only the hands turned over at showdown are public; mucked hands stay hidden
+			replay.HoleCards = make(map[int][]Card)
+			for seat, cards := range e.ShownDown {
+				replay.HoleCards[seat] = slices.Clone(cards)
+			}
+		}
+		rs.addLocked(replay)
+		return replay
+	}
+	return nil
+}
+
+// addLocked stores a finished hand and its summary, evicting the oldest past the limits
+// (caller must hold rs.mu)
+func (rs *ReplayStore) addLocked(replay *HandReplay) {
+	rs.hands[replay.ID] = replay
+	rs.order = append(rs.order, replay.ID)
+	if len(rs.order) > replayHandLimit {
+		delete(rs.hands, rs.order[0])
+		rs.order = rs.order[1:]
+	}
+
+	history := append(rs.history[replay.TableID], replay.summarize())
+	if len(history) > tableHistoryLength {
+		history = slices.Clone(history[len(history)-tableHistoryLength:])
+	}
+	rs.history[replay.TableID] = history
+}
+
+// showCardsLocked adds cards a winner showed after the hand to its replay and summary
+// Finished replays are shared with readers, so they are copied rather than changed
+// (caller must hold rs.mu)
+func (rs *ReplayStore) showCardsLocked(e Event) {
+	replay, ok := rs.hands[e.HandID]
+	if !ok {
+		return
+	}
+	updated := *replay
+	updated.HoleCards = maps.Clone(replay.HoleCards)
+	if updated.HoleCards == nil {
+		updated.HoleCards = make(map[int][]Card)
+	}
+	updated.HoleCards[e.SeatIndex] = slices.Clone(e.Shown)
+	rs.hands[e.HandID] = &updated
+
+	history := rs.history[e.TableID]
+	for i := range history {
+		if history[i].HandID == e.HandID {
+			history[i].Shown = updated.HoleCards
+		}
+	}
+}
+
+// Hand returns the finished hand with the given ID
+// Replays are never modified once finished, so the caller may read it without a lock.
+func (rs *ReplayStore) Hand(id string) (*HandReplay, bool) {
+	if rs == nil {
+		return nil, false
+	}
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	replay, ok := rs.hands[id]
+	return replay, ok
+}
+
+// ReplayResponse is the body of GET /api/replays/{handID}: the hand and the table after each
+// of its steps, so a viewer can pause, step and seek without further requests
+type ReplayResponse struct {
+	Hand   *HandReplay   `json:"hand"`
+	Frames []ReplayFrame `json:"frames"` // Frames[i] is the table after i steps
+}
+
+// handleReplay serves GET /api/replays/{handID}
+func (s *Server) handleReplay(w http.ResponseWriter, r *http.Request) {
+	replay, ok := s.replays.Hand(chi.URLParam(r, "handID"))
+	if !ok {
+		http.Error(w, "hand not found", http.StatusNotFound)
+		return
+	}
+	replay = replay.public("")
+	frames := make([]ReplayFrame, len(replay.Steps)+1)
+	for i := range frames {
+		frames[i] = replay.Frame(i)
+	}
+	writeAdminJSON(w, http.StatusOK, ReplayResponse{Hand: replay, Frames: frames})
+}
+
+// handleReplayFrame serves GET /api/replays/{handID}/frames/{step}, for tools that seek
+// without downloading the whole hand
+func (s *Server) handleReplayFrame(w http.ResponseWriter, r *http.Request) {
+	replay, ok := s.replays.Hand(chi.URLParam(r, "handID"))
+	if !ok {
+		http.Error(w, "hand not found", http.StatusNotFound)
+		return
+	}
+	step, err := strconv.Atoi(chi.URLParam(r, "step"))
+	if err != nil || step < 0 || step > len(replay.Steps) {
+		http.Error(w, "step must be between 0 and "+strconv.Itoa(len(replay.Steps)), http.StatusBadRequest)
+		return
+	}
+	writeAdminJSON(w, http.StatusOK, replay.public("").Frame(step))
+}
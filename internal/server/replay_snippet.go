@@ -0,0 +1,174 @@
+package server
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// replaySnippetLimit is how many replay snippets the SnippetStore keeps; the oldest go first
+const replaySnippetLimit = 200
+
+// dramaticEquitySwing is how far a player's equity must move between the all-in and the river
+// for the hand to get a snippet: at 0.5, the player behind when the money went in came back to
+// win, or the player ahead lost
+const dramaticEquitySwing = 0.5
+
+// StreetEquity is every shown hand's equity on one street of a replay snippet
+type StreetEquity struct {
+	Street string          `json:"street"`
+	Board  []Card          `json:"board"`
+	Equity map[int]float64 `json:"equity"` // Share of the pot per seat, from 0 to 1
+}
+
+// ReplaySnippet is the shareable record of a dramatic all-in: the public replay of the hand and
+// how each player's chances moved as the board ran out
+type ReplaySnippet struct {
+	Hand        *HandReplay    `json:"hand"`
+	AllInStreet string         `json:"allInStreet"` // Street the last bet went in on
+	Equities    []StreetEquity `json:"equities"`    // From the all-in to the river
+	Swing       float64        `json:"swing"`       // Largest equity change of any player
+}
+
+// ReplaySnippetPayload represents the payload for replay_snippet messages, broadcast to the
+// table when one of its hands gets a snippet
+type ReplaySnippetPayload struct {
+	HandID  string  `json:"handId"`
+	TableID string  `json:"tableId"`
+	URL     string  `json:"url"` // Path of the snippet, GET /api/snippets/{handID}
+	Swing   float64 `json:"swing"`
+}
+
+// buildSnippet returns the snippet of a hand whose board ran out after an all-in between at
+// least two shown hands, if some player's equity swung by dramaticEquitySwing or more. The
+// equities are simulated on pool; returns an error if ctx is done first.
+func buildSnippet(ctx context.Context, pool *EquityPool, replay *HandReplay) (*ReplaySnippet, bool, error) {
+	if len(replay.HoleCards) < 2 {
+		return nil, false, nil
+	}
+	lastAction := -1
+	for i, step := range replay.Steps {
+		if step.Type == ReplayStepAction || step.Type == ReplayStepBlind {
+			lastAction = i
+		}
+	}
+	if lastAction < 0 {
+		return nil, false, nil
+	}
+
+	// The board at the all-in, then each street dealt out without further betting
+	snippet := &ReplaySnippet{Hand: replay.public(""), AllInStreet: replay.Steps[lastAction].Street}
+	boards := []StreetEquity{{Street: snippet.AllInStreet, Board: []Card{}}}
+	for i, step := range replay.Steps {
+		if step.Type != ReplayStepBoard {
+			continue
+		}
+		if i < lastAction {
+			boards[0].Board = step.Board
+		} else {
+			boards = append(boards, StreetEquity{Street: step.Street, Board: step.Board})
+		}
+	}
+	if len(boards) < 2 || len(boards[len(boards)-1].Board) != 5 {
+		return nil, false, nil
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(replay.ID))
+	rng := rand.New(rand.NewPCG(hash.Sum64(), 0))
+	for _, board := range boards {
+		equity, err := pool.Equities(ctx, replay.HoleCards, board.Board, rng)
+		if err != nil {
+			return nil, false, err
+		}
+		board.Equity = equity
+		snippet.Equities = append(snippet.Equities, board)
+	}
+	first, last := snippet.Equities[0].Equity, snippet.Equities[len(snippet.Equities)-1].Equity
+	for seat := range first {
+		snippet.Swing = max(snippet.Swing, math.Abs(last[seat]-first[seat]))
+	}
+	return snippet, snippet.Swing >= dramaticEquitySwing, nil
+}
+
+// SnippetStore keeps the most recent replaySnippetLimit replay snippets by hand ID
+type SnippetStore struct {
+	mu       sync.RWMutex
+	snippets map[string]*ReplaySnippet
+	order    []string // Hand IDs, oldest first
+}
+
+// NewSnippetStore creates an empty SnippetStore
+func NewSnippetStore() *SnippetStore {
+	return &SnippetStore{snippets: make(map[string]*ReplaySnippet)}
+}
+
+// add stores a snippet, evicting the oldest past the limit
+func (ss *SnippetStore) add(snippet *ReplaySnippet) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.snippets[snippet.Hand.ID] = snippet
+	ss.order = append(ss.order, snippet.Hand.ID)
+	if len(ss.order) > replaySnippetLimit {
+		delete(ss.snippets, ss.order[0])
+		ss.order = slices.Clone(ss.order[1:])
+	}
+}
+
+// Snippet returns the snippet of the hand with the given ID
+func (ss *SnippetStore) Snippet(handID string) (*ReplaySnippet, bool) {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	snippet, ok := ss.snippets[handID]
+	return snippet, ok
+}
+
+// considerSnippet keeps a snippet of a finished hand that qualifies and tells its table where
+// to find it
+func (s *Server) considerSnippet(replay *HandReplay) {
+	config := s.Config()
+	if !config.Features.ReplaySnippets {
+		return
+	}
+	ctx := context.Background()
+	if config.Equity.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Equity.Timeout)
+		defer cancel()
+	}
+	snippet, ok, err := buildSnippet(ctx, s.equity, replay)
+	if err != nil {
+		s.logger.Warn("replay snippet abandoned", "handID", replay.ID, "tableID", replay.TableID, "error", err)
+		return
+	}
+	if !ok {
+		return
+	}
+	s.snippets.add(snippet)
+	s.logger.Info("replay snippet created", "handID", replay.ID, "tableID", replay.TableID, "swing", snippet.Swing)
+
+	table := s.tableByID(replay.TableID)
+	if table == nil {
+		return
+	}
+	payload := ReplaySnippetPayload{HandID: replay.ID, TableID: replay.TableID, URL: "/api/snippets/" + replay.ID, Swing: snippet.Swing}
+	if err := s.broadcastTableMessage(table, "replay_snippet", payload); err != nil {
+		s.logger.Warn("failed to broadcast replay snippet", "handID", replay.ID, "error", err)
+	}
+}
+
+// handleSnippet serves GET /api/snippets/{handID}
+func (s *Server) handleSnippet(w http.ResponseWriter, r *http.Request) {
+	snippet, ok := s.snippets.Snippet(chi.URLParam(r, "handID"))
+	if !ok {
+		http.Error(w, "snippet not found", http.StatusNotFound)
+		return
+	}
+	writeAdminJSON(w, http.StatusOK, snippet)
+}
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// cards parses cards written like "As Kd"
+func cards(s string) []Card {
+	var parsed []Card
+	for i := 0; i+1 < len(s); i += 3 {
+		parsed = append(parsed, NewCard(s[i:i+1], s[i+1:i+2]))
+	}
+	return parsed
+}
+
+// TestHandEquities verifies equities are dealt out exactly with two cards or fewer to come,
+// ties split
+func TestHandEquities(t *testing.T) {
+	holeCards := map[int][]Card{0: cards("As Ah"), 1: cards("Kd Kc")}
+	rng := rand.New(rand.NewPCG(1, 0))
+
+	ctx := context.Background()
+	turn, _ := handEquities(ctx, holeCards, cards("2s 7h 9d Qc"), rng)
+	if want := 2.0 / 44; turn[1] != want || turn[0] != 1-want {
+		t.Errorf("expected kings to hit 2 of 44 rivers, got %v", turn)
+	}
+	if split, _ := handEquities(ctx, holeCards, cards("Ts Jh Qd Kh Ac"), rng); split[0] != 0.5 || split[1] != 0.5 {
+		t.Errorf("expected a board straight split, got %v", split)
+	}
+	if preflop, _ := handEquities(ctx, holeCards, nil, rng); preflop[0] < 0.75 || preflop[0] > 0.87 {
+		t.Errorf("expected aces about 82%% preflop, got %v", preflop)
+	}
+}
+
+// TestEquityPool verifies simulations wait for a free worker, give up when their context or
+// the pool is done, and are counted in the pool's figures
+func TestEquityPool(t *testing.T) {
+	pool := NewEquityPool(1)
+	holeCards := map[int][]Card{0: cards("As Ah"), 1: cards("Kd Kc")}
+	board := cards("2s 7h 9d Qc")
+	rng := rand.New(rand.NewPCG(1, 0))
+
+	// With the only worker taken, a simulation waits until its deadline
+	pool.slots <- struct{}{}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := pool.Equities(ctx, holeCards, board, rng); err != context.DeadlineExceeded {
+		t.Errorf("expected the queued simulation to time out, got %v", err)
+	}
+	<-pool.slots
+
+	if equities, err := pool.Equities(context.Background(), holeCards, board, rng); err != nil || equities[1] != 2.0/44 {
+		t.Errorf("expected the simulation run once a worker was free, got %v (%v)", equities, err)
+	}
+	if stats := pool.Stats(); stats.Workers != 1 || stats.Busy != 0 || stats.Waiting != 0 || stats.Completed != 1 || stats.Cancelled != 1 {
+		t.Errorf("expected one completed and one cancelled simulation, got %+v", stats)
+	}
+
+	pool.Close()
+	if _, err := pool.Equities(context.Background(), holeCards, cards("2s 7h"), rng); err == nil {
+		t.Error("expected simulations refused once the pool closed")
+	}
+}
+
+// allInReplay returns a finished hand where aces and kings got it in preflop and board ran out
+func allInReplay(board string) *HandReplay {
+	seat0, seat1 := 0, 1
+	at := time.Now()
+	full := cards(board)
+	return &HandReplay{
+		ID:        "hand-1",
+		TableID:   "table-1",
+		Players:   map[int]string{0: "Alice", 1: "Bob"},
+		Stacks:    map[int]int{0: 1000, 1: 1000},
+		HoleCards: map[int][]Card{0: cards("As Ah"), 1: cards("Kd Kc")},
+		Steps: []ReplayStep{
+			{Type: ReplayStepBlind, Time: at, Street: "preflop", Seat: &seat0, Amount: 10},
+			{Type: ReplayStepBlind, Time: at, Street: "preflop", Seat: &seat1, Amount: 20},
+			{Type: ReplayStepAction, Time: at, Street: "preflop", Seat: &seat0, Action: "raise", Amount: 990},
+			{Type: ReplayStepAction, Time: at, Street: "preflop", Seat: &seat1, Action: "call", Amount: 980},
+			{Type: ReplayStepBoard, Time: at, Street: "flop", Board: full[:3]},
+			{Type: ReplayStepBoard, Time: at, Street: "turn", Board: full[:4]},
+			{Type: ReplayStepBoard, Time: at, Street: "river", Board: full},
+		},
+	}
+}
+
+// TestBuildSnippet verifies only all-ins the board turned around get a snippet, with the
+// equities from the all-in to the river
+func TestBuildSnippet(t *testing.T) {
+	ctx := context.Background()
+	if _, ok, _ := buildSnippet(ctx, nil, allInReplay("2s 7h 9d Qc 3h")); ok {
+		t.Error("expected no snippet when the favourite held")
+	}
+
+	snippet, ok, err := buildSnippet(ctx, nil, allInReplay("2s 7h 9d Qc Kh"))
+	if err != nil || !ok {
+		t.Fatalf("expected a snippet for kings hitting the river, got %v", err)
+	}
+	streets := []string{"preflop", "flop", "turn", "river"}
+	if snippet.AllInStreet != "preflop" || len(snippet.Equities) != len(streets) {
+		t.Fatalf("expected equities for %v, got %+v", streets, snippet.Equities)
+	}
+	for i, street := range streets {
+		if snippet.Equities[i].Street != street {
+			t.Errorf("expected %s at %d, got %s", street, i, snippet.Equities[i].Street)
+		}
+	}
+	if river := snippet.Equities[3].Equity; river[1] != 1 || river[0] != 0 {
+		t.Errorf("expected kings to win on the river, got %v", river)
+	}
+
+	folded := allInReplay("2s 7h 9d Qc Kh")
+	delete(folded.HoleCards, 0)
+	if _, ok, _ := buildSnippet(ctx, nil, folded); ok {
+		t.Error("expected no snippet without two hands shown")
+	}
+}
+
+// TestReplaySnippets_SharedWithTable verifies a snippet is announced to the table and served
+// from its link, and only with the feature on
+func TestReplaySnippets_SharedWithTable(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{
+		Tables: []TableConfig{{Name: "Main", SmallBlind: 10, BigBlind: 20, BuyIn: 1000}},
+	})
+	table := server.tables[0]
+	clients := seatNamed(t, server, table, "Alice", "Bob")
+	replay := allInReplay("2s 7h 9d Qc Kh")
+	replay.TableID = table.ID
+
+	server.considerSnippet(replay)
+	if _, ok := server.snippets.Snippet(replay.ID); ok {
+		t.Error("expected no snippet with the feature off")
+	}
+
+	updateConfig(server, func(config *Config) { config.Features.ReplaySnippets = true })
+	server.considerSnippet(replay)
+	announced := payloadsOf[ReplaySnippetPayload](t, clients[0], "replay_snippet")
+	if len(announced) != 1 || announced[0].URL != "/api/snippets/hand-1" {
+		t.Fatalf("expected the table told where the snippet is, got %+v", announced)
+	}
+
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, httptest.NewRequest("GET", announced[0].URL, nil))
+	var snippet ReplaySnippet
+	if err := json.NewDecoder(w.Body).Decode(&snippet); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("expected the snippet served, got status %d (%v)", w.Code, err)
+	}
+	if snippet.Hand.ID != replay.ID || len(snippet.Equities) != 4 {
+		t.Errorf("expected the hand with four streets of equities, got %+v", snippet)
+	}
+}
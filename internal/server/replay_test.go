@@ -0,0 +1,159 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// playOutChecking checks or calls for whoever is to act until the hand ends
+func playOutChecking(t *testing.T, server *Server, table *Table) {
+	t.Helper()
+	for i := 0; i < 20; i++ {
+		table.mu.RLock()
+		hand := table.CurrentHand
+		if hand == nil {
+			table.mu.RUnlock()
+			return
+		}
+		action := "check"
+		if hand.CurrentActor != nil && hand.CurrentBet > hand.PlayerBets[*hand.CurrentActor] {
+			action = "call"
+		}
+		table.mu.RUnlock()
+		actCurrent(t, server, table, action)
+	}
+	t.Fatal("hand did not end")
+}
+
+// handIDOf returns the ID of the hand in progress
+func handIDOf(table *Table) string {
+	table.mu.RLock()
+	defer table.mu.RUnlock()
+	return table.CurrentHand.ID
+}
+
+// storedReplay waits for the replay of handID to be recorded
+func storedReplay(t *testing.T, server *Server, handID string) *HandReplay {
+	t.Helper()
+	var replay *HandReplay
+	if !eventually(func() bool {
+		var ok bool
+		replay, ok = server.replays.Hand(handID)
+		return ok
+	}) {
+		t.Fatalf("hand %s was not recorded", handID)
+	}
+	return replay
+}
+
+// TestReplay_RecordsShowdown verifies a hand played to showdown is recorded step by step, with
+// frames running from the stacks before the blinds to the stacks after the payout
+func TestReplay_RecordsShowdown(t *testing.T) {
+	server, table, _ := preActionTable(t)
+	handID := handIDOf(table)
+	before := stacksBeforeHand(table)
+	playOutChecking(t, server, table)
+	replay := storedReplay(t, server, handID)
+
+	if replay.TableID != table.ID || len(replay.Players) != 3 || replay.Players[0] != "Alice" {
+		t.Errorf("unexpected hand header: table %s, players %v", replay.TableID, replay.Players)
+	}
+	if len(replay.Steps) < 2 || replay.Steps[0].Type != ReplayStepBlind || replay.Steps[1].Type != ReplayStepBlind {
+		t.Fatalf("expected the hand to open with two blinds, got %+v", replay.Steps)
+	}
+	if last := replay.Steps[len(replay.Steps)-1]; last.Type != ReplayStepResult || last.Street != "river" {
+		t.Errorf("expected the hand to end with a result on the river, got %+v", last)
+	}
+	if replay.WinningHand == "" || len(replay.HoleCards) != 3 {
+		t.Errorf("expected a showdown with every hand shown, got %q and %d hands", replay.WinningHand, len(replay.HoleCards))
+	}
+
+	start := replay.Frame(0)
+	for _, seat := range start.Seats {
+		if seat.Stack != before[seat.Seat] {
+			t.Errorf("frame 0: seat %d has %d, expected %d", seat.Seat, seat.Stack, before[seat.Seat])
+		}
+	}
+	end := replay.Frame(len(replay.Steps))
+	if len(end.Board) != 5 || end.Pot != 0 {
+		t.Errorf("expected the final frame to show the whole board and an empty pot, got %v and %d", end.Board, end.Pot)
+	}
+	table.mu.RLock()
+	for _, seat := range end.Seats {
+		if got := table.Seats[seat.Seat].Stack; seat.Stack != got {
+			t.Errorf("final frame: seat %d has %d, the table has %d", seat.Seat, seat.Stack, got)
+		}
+	}
+	table.mu.RUnlock()
+}
+
+// TestReplay_HidesFoldedCards verifies nobody's cards are published when the hand is not shown down
+func TestReplay_HidesFoldedCards(t *testing.T) {
+	server, table, _ := preActionTable(t)
+	handID := handIDOf(table)
+	actCurrent(t, server, table, "fold")
+	actCurrent(t, server, table, "fold")
+	replay := storedReplay(t, server, handID)
+
+	if len(replay.HoleCards) != 0 || replay.WinningHand != "" {
+		t.Errorf("expected no cards shown, got %v (%q)", replay.HoleCards, replay.WinningHand)
+	}
+	folded := 0
+	for _, seat := range replay.Frame(len(replay.Steps)).Seats {
+		if seat.Folded {
+			folded++
+		}
+		if len(seat.HoleCards) > 0 {
+			t.Errorf("seat %d's cards are visible", seat.Seat)
+		}
+	}
+	if folded != 2 {
+		t.Errorf("expected 2 folded seats, got %d", folded)
+	}
+}
+
+// TestReplay_API verifies the replay endpoints and the viewer page
+func TestReplay_API(t *testing.T) {
+	server, table, _ := preActionTable(t)
+	handID := handIDOf(table)
+	playOutChecking(t, server, table)
+	replay := storedReplay(t, server, handID)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		return w
+	}
+
+	if w := get("/api/replays/unknown"); w.Code != http.StatusNotFound {
+		t.Errorf("unknown hand: expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+
+	w := get("/api/replays/" + handID)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	var body ReplayResponse
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Hand.ID != handID || len(body.Frames) != len(replay.Steps)+1 {
+		t.Errorf("expected hand %s with %d frames, got %s with %d", handID, len(replay.Steps)+1, body.Hand.ID, len(body.Frames))
+	}
+
+	w = get("/api/replays/" + handID + "/frames/2")
+	var frame ReplayFrame
+	if err := json.NewDecoder(w.Body).Decode(&frame); err != nil || frame.Step != 2 || frame.Last == nil {
+		t.Errorf("expected frame 2, got %+v (%v)", frame, err)
+	}
+	if w := get("/api/replays/" + handID + "/frames/999"); w.Code != http.StatusBadRequest {
+		t.Errorf("step past the end: expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	if w := get("/replay/" + handID); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "replay.js") {
+		t.Errorf("expected the viewer page, got status %d", w.Code)
+	}
+}
@@ -0,0 +1,246 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// seatReservation is a seat held for a player who has not bought in yet
+type seatReservation struct {
+	expiresAt time.Time
+	timer     ClockTimer // Clears the seat at expiresAt
+}
+
+// buyInRange returns the smallest and largest stack a player may buy in for after reserving a
+// seat; both default to the table's BuyIn
+func (t *Table) buyInRange() (int, int) {
+	minBuyIn, maxBuyIn := t.BuyIn, t.BuyIn
+	if t.MinBuyIn > 0 {
+		minBuyIn = t.MinBuyIn
+	}
+	if t.MaxBuyIn > 0 {
+		maxBuyIn = t.MaxBuyIn
+	}
+	return minBuyIn, maxBuyIn
+}
+
+// dropReservationLocked forgets a player's reservation, stopping its timer (caller must hold t.mu)
+func (t *Table) dropReservationLocked(token string) {
+	if r, ok := t.reservations[token]; ok {
+		r.timer.Stop()
+		delete(t.reservations, token)
+	}
+}
+
+// reserveSeat holds the first empty seat at table for token until hold has passed
+// The seat is "reserved": others cannot take it and no hand deals it in until the player buys in.
+func (s *Server) reserveSeat(table *Table, token string, hold time.Duration) (Seat, time.Time, error) {
+	table.mu.Lock()
+	defer table.mu.Unlock()
+
+	if table.freeze != nil {
+		return Seat{}, time.Time{}, newMessageError("error.table_frozen", nil)
+	}
+	if !table.seatOpenToLocked(token) {
+		return Seat{}, time.Time{}, errTableFull
+	}
+	table.clearRematchLocked()
+	for i := 0; i < table.MaxSeats; i++ {
+		if table.Seats[i].Token != nil {
+			continue
+		}
+		table.Seats[i].Token = &token
+		table.Seats[i].Status = "reserved"
+		table.Seats[i].Stack = 0
+
+		r := &seatReservation{expiresAt: table.clock().Now().Add(hold)}
+		r.timer = table.clock().AfterFunc(hold, func() { s.reservationExpired(table, token, r) })
+		if table.reservations == nil {
+			table.reservations = make(map[string]*seatReservation)
+		}
+		table.reservations[token] = r
+		return table.Seats[i], r.expiresAt, nil
+	}
+	return Seat{}, time.Time{}, errTableFull
+}
+
+// reservationExpired gives up the seat of a player who did not buy in in time
+func (s *Server) reservationExpired(table *Table, token string, r *seatReservation) {
+	table.mu.Lock()
+	if table.reservations[token] != r {
+		// The player bought in, or left, first
+		table.mu.Unlock()
+		return
+	}
+	delete(table.reservations, token)
+	table.mu.Unlock()
+
+	if err := table.ClearSeat(&token); err != nil {
+		return
+	}
+	if _, err := s.sessionManager.UpdateSession(token, nil, nil); err != nil {
+		s.logger.Warn("failed to update session after seat reservation expired", "token", token, "error", err)
+	}
+	s.sendPrivate(token, "seat_cleared", SeatClearedPayload{})
+	if err := s.broadcastTableState(table.ID, nil); err != nil {
+		s.logger.Warn("failed to broadcast table_state after seat reservation expired", "error", err)
+	}
+	if err := s.broadcastLobbyState(); err != nil {
+		s.logger.Warn("failed to broadcast lobby state after seat reservation expired", "error", err)
+	}
+	s.logger.Info("seat reservation expired", "token", token, "tableId", table.ID)
+}
+
+// completeBuyIn pays for amount chips and turns the player's reserved seat into a waiting seat
+func (s *Server) completeBuyIn(token, remoteIP string, table *Table, amount int) (Seat, error) {
+	minBuyIn, maxBuyIn := table.buyInRange()
+	if amount == 0 {
+		amount = table.BuyIn
+	}
+	if amount < minBuyIn || amount > maxBuyIn {
+		return Seat{}, newMessageError("error.invalid_buy_in", map[string]any{"min": minBuyIn, "max": maxBuyIn})
+	}
+	if err := s.buyIn(token, table, amount); err != nil {
+		if errors.Is(err, errInsufficientBalance) {
+			return Seat{}, err
+		}
+		return Seat{}, fmt.Errorf("failed to buy in: %w", err)
+	}
+
+	table.mu.Lock()
+	if _, ok := table.reservations[token]; !ok {
+		table.mu.Unlock()
+		s.refundBuyIn(token, table, amount)
+		return Seat{}, newMessageError("error.no_reservation", nil)
+	}
+	table.dropReservationLocked(token)
+	var seat Seat
+	for i := range table.Seats {
+		if table.Seats[i].Token != nil && *table.Seats[i].Token == token {
+			table.Seats[i].Status = "waiting"
+			table.Seats[i].Stack = amount
+			seat = table.Seats[i]
+		}
+	}
+	table.publishEvent(Event{Type: EventPlayerSeated, SeatIndex: seat.Index, Token: token, RemoteIP: remoteIP})
+	table.mu.Unlock()
+
+	s.sendBalances(token)
+	return seat, nil
+}
+
+// ReserveSeatPayload represents the payload for reserve_seat messages
+type ReserveSeatPayload struct {
+	TableID string `json:"tableId"`
+}
+
+// SeatReservedPayload represents the payload for seat_reserved messages
+type SeatReservedPayload struct {
+	TableID   string `json:"tableId"`
+	SeatIndex int    `json:"seatIndex"`
+	ExpiresAt int64  `json:"expiresAt"` // Unix ms; the seat is given up unless the player buys in first
+	MinBuyIn  int    `json:"minBuyIn"`
+	MaxBuyIn  int    `json:"maxBuyIn"`
+}
+
+// BuyInPayload represents the payload for buy_in messages
+type BuyInPayload struct {
+	Amount int `json:"amount"` // Stack to buy; 0 buys the table's default
+}
+
+// HandleReserveSeat processes a reserve_seat message: the player gets a seat held for them
+// while they choose how much to buy in for, and replies with seat_reserved
+func (c *Client) HandleReserveSeat(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+	hold := server.Config().SeatReservation
+	if hold <= 0 {
+		return newMessageError("error.reservations_disabled", nil)
+	}
+	var request ReserveSeatPayload
+	if err := json.Unmarshal(payload, &request); err != nil {
+		return invalidPayloadError("reserve_seat", err)
+	}
+	if _, err := sm.GetSession(c.Token); err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+	if server.FindPlayerSeat(&c.Token) != nil {
+		return fmt.Errorf("already_seated")
+	}
+	table := server.tableByID(request.TableID)
+	if table == nil {
+		return newMessageError("error.table_not_found", nil)
+	}
+	if err := server.checkClubAccess(table, c.Token); err != nil {
+		return err
+	}
+
+	seat, expiresAt, err := server.reserveSeat(table, c.Token, hold)
+	if err != nil {
+		return err
+	}
+	if _, err := sm.UpdateSession(c.Token, &table.ID, &seat.Index); err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+	server.waitlist.Remove(c.Token)
+	server.observers.Remove(c.Token)
+
+	minBuyIn, maxBuyIn := table.buyInRange()
+	logger.Info("seat reserved", "token", c.Token, "tableId", table.ID, "seatIndex", seat.Index, "expiresAt", expiresAt)
+	if err := c.sendMessage("seat_reserved", SeatReservedPayload{
+		TableID:   table.ID,
+		SeatIndex: seat.Index,
+		ExpiresAt: expiresAt.UnixMilli(),
+		MinBuyIn:  minBuyIn,
+		MaxBuyIn:  maxBuyIn,
+	}); err != nil {
+		return err
+	}
+	if err := server.broadcastTableState(table.ID, nil); err != nil {
+		logger.Warn("failed to broadcast table_state", "error", err)
+	}
+	if err := server.broadcastLobbyState(); err != nil {
+		logger.Warn("failed to broadcast lobby state", "error", err)
+	}
+	return nil
+}
+
+// HandleBuyIn processes a buy_in message for the player's reserved seat, seating them as a
+// join_table would
+func (c *Client) HandleBuyIn(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+	var request BuyInPayload
+	if err := json.Unmarshal(payload, &request); err != nil {
+		return invalidPayloadError("buy_in", err)
+	}
+	session, err := sm.GetSession(c.Token)
+	if err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+	if session.TableID == nil {
+		return newMessageError("error.no_reservation", nil)
+	}
+	table := server.tableByID(*session.TableID)
+	if table == nil {
+		return newMessageError("error.table_not_found", nil)
+	}
+
+	seat, err := server.completeBuyIn(c.Token, c.RemoteIP, table, request.Amount)
+	if err != nil {
+		return err
+	}
+	if err := c.SendSeatAssigned(table.ID, seat.Index, seat.Status, logger); err != nil {
+		return fmt.Errorf("failed to send seat_assigned: %w", err)
+	}
+	if err := server.broadcastTableState(table.ID, nil); err != nil {
+		logger.Warn("failed to broadcast table_state", "error", err)
+	}
+	if err := server.broadcastLobbyState(); err != nil {
+		logger.Warn("failed to broadcast lobby state", "error", err)
+	}
+	logger.Info("player bought in", "token", c.Token, "tableId", table.ID, "seatIndex", seat.Index, "amount", seat.Stack)
+
+	// Start the countdown to the first hand once enough players are seated
+	table.ScheduleNextHand()
+	return nil
+}
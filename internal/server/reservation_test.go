@@ -0,0 +1,135 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newReservationServer returns a bankroll server whose play table takes buy-ins of 500 to 2000
+// and holds reserved seats for a minute
+func newReservationServer() *Server {
+	return NewServerWithConfig(slog.Default(), Config{
+		SeatReservation: time.Minute,
+		Bankroll:        BankrollConfig{Enabled: true, StartingPlayChips: 5000},
+		Tables: []TableConfig{
+			{Name: "Play", SmallBlind: 10, BigBlind: 20, BuyIn: 1000, MinBuyIn: 500, MaxBuyIn: 2000},
+		},
+	})
+}
+
+// reservingClient creates a session with its starting balance and a client for it
+func reservingClient(server *Server, name string) *Client {
+	session, _ := server.sessionManager.CreateSession(name)
+	server.grantStartingBalance(session.Token)
+	return connectTestClient(server, session.Token)
+}
+
+// reserve sends reserve_seat for the server's first table
+func reserve(server *Server, client *Client) error {
+	payload, _ := json.Marshal(ReserveSeatPayload{TableID: server.tables[0].ID})
+	return client.HandleReserveSeat(server.sessionManager, server, slog.Default(), payload)
+}
+
+// buyIn sends buy_in for amount
+func buyIn(server *Server, client *Client, amount int) error {
+	payload, _ := json.Marshal(BuyInPayload{Amount: amount})
+	return client.HandleBuyIn(server.sessionManager, server, slog.Default(), payload)
+}
+
+// TestSeatReservation_BuyIn verifies a reserved seat is held out of hands until the player buys
+// in for the amount they chose
+func TestSeatReservation_BuyIn(t *testing.T) {
+	server := newReservationServer()
+	table := server.tables[0]
+	alice, bob := reservingClient(server, "Alice"), reservingClient(server, "Bob")
+
+	if err := reserve(server, alice); err != nil {
+		t.Fatal(err)
+	}
+	var reserved SeatReservedPayload
+	for _, raw := range drainRawMessages(alice) {
+		var msg struct {
+			Type    string              `json:"type"`
+			Payload SeatReservedPayload `json:"payload"`
+		}
+		if json.Unmarshal([]byte(raw), &msg) == nil && msg.Type == "seat_reserved" {
+			reserved = msg.Payload
+		}
+	}
+	if reserved.TableID != table.ID || reserved.MinBuyIn != 500 || reserved.MaxBuyIn != 2000 || reserved.ExpiresAt == 0 {
+		t.Errorf("unexpected seat_reserved: %+v", reserved)
+	}
+
+	if _, err := server.seatPlayer(bob.Token, "", table); err != nil {
+		t.Fatal(err)
+	}
+	if seat := server.buildTableState(table, nil).Seats[reserved.SeatIndex]; seat.Status != "reserved" || seat.PlayerName == nil || seat.Metrics != nil {
+		t.Errorf("expected the seat shown as reserved, got %+v", seat)
+	}
+	if table.CanStartHand() {
+		t.Error("expected a reserved seat not to count towards starting a hand")
+	}
+
+	var msgErr *MessageError
+	if err := buyIn(server, alice, 2500); !errors.As(err, &msgErr) || msgErr.Key != "error.invalid_buy_in" {
+		t.Fatalf("expected error.invalid_buy_in, got %v", err)
+	}
+	if err := buyIn(server, alice, 1500); err != nil {
+		t.Fatal(err)
+	}
+	seat, _ := table.GetSeatByToken(&alice.Token)
+	if seat.Status != "waiting" || seat.Stack != 1500 {
+		t.Errorf("expected a waiting seat with 1500, got %+v", seat)
+	}
+	if balances, _ := server.sessionManager.Balances(alice.Token); balances[CurrencyPlay] != 3500 {
+		t.Errorf("expected 3500 play chips left, got %v", balances)
+	}
+	if !table.CanStartHand() {
+		t.Error("expected a hand to be possible once both players bought in")
+	}
+	if err := buyIn(server, alice, 1000); !errors.As(err, &msgErr) || msgErr.Key != "error.no_reservation" {
+		t.Errorf("expected error.no_reservation buying in twice, got %v", err)
+	}
+}
+
+// TestSeatReservation_Expires verifies a seat not bought in for in time is given up
+func TestSeatReservation_Expires(t *testing.T) {
+	server := newReservationServer()
+	clock := useFakeClock(server)
+	table := server.tables[0]
+	alice := reservingClient(server, "Alice")
+
+	if err := reserve(server, alice); err != nil {
+		t.Fatal(err)
+	}
+	drainRawMessages(alice)
+	clock.Advance(time.Minute)
+
+	if _, seated := table.GetSeatByToken(&alice.Token); seated {
+		t.Error("expected the reserved seat cleared")
+	}
+	if session, _ := server.sessionManager.GetSession(alice.Token); session.TableID != nil {
+		t.Error("expected the session unseated")
+	}
+	if !slices.ContainsFunc(drainRawMessages(alice), func(raw string) bool { return strings.Contains(raw, `"seat_cleared"`) }) {
+		t.Error("expected seat_cleared")
+	}
+	if balances, _ := server.sessionManager.Balances(alice.Token); balances[CurrencyPlay] != 5000 {
+		t.Errorf("expected the balance untouched, got %v", balances)
+	}
+}
+
+// TestSeatReservation_Disabled verifies reserve_seat is refused without a reservation hold
+func TestSeatReservation_Disabled(t *testing.T) {
+	server := NewServer(slog.Default())
+	session, _ := server.sessionManager.CreateSession("Alice")
+	var msgErr *MessageError
+	if err := reserve(server, connectTestClient(server, session.Token)); !errors.As(err, &msgErr) || msgErr.Key != "error.reservations_disabled" {
+		t.Errorf("expected error.reservations_disabled, got %v", err)
+	}
+}
@@ -0,0 +1,269 @@
+package server
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	mathrand "math/rand/v2"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ShuffleSeed determines a hand's deck order
+// Each hand gets a fresh seed from crypto/rand; players are shown its SHA-256 commitment
+// when the hand starts, and the seed itself is kept in the RNG audit log so the shuffle
+// can be replayed later
+type ShuffleSeed [32]byte
+
+// newShuffleSeed draws a seed from the operating system's CSPRNG
+func newShuffleSeed() (ShuffleSeed, error) {
+	var seed ShuffleSeed
+	if _, err := rand.Read(seed[:]); err != nil {
+		return ShuffleSeed{}, fmt.Errorf("failed to generate shuffle seed: %w", err)
+	}
+	return seed, nil
+}
+
+// Commitment returns the hex SHA-256 of the seed, safe to publish before the hand is played
+func (s ShuffleSeed) Commitment() string {
+	sum := sha256.Sum256(s[:])
+	return hex.EncodeToString(sum[:])
+}
+
+// String returns the seed as hex
+func (s ShuffleSeed) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// parseShuffleSeed decodes a hex seed written by String
+func parseShuffleSeed(value string) (ShuffleSeed, error) {
+	var seed ShuffleSeed
+	decoded, err := hex.DecodeString(value)
+	if err != nil || len(decoded) != len(seed) {
+		return ShuffleSeed{}, fmt.Errorf("invalid shuffle seed %q", value)
+	}
+	copy(seed[:], decoded)
+	return seed, nil
+}
+
+// ShuffleDeckWithSeed performs a Fisher-Yates shuffle driven by a ChaCha8 stream keyed with seed
+// The same seed always yields the same order, which is what makes the audit log replayable.
+// Indices come from rejection sampling on the raw stream so the result depends only on the
+// ChaCha8 output, not on math/rand helper implementations.
+func ShuffleDeckWithSeed(deck []Card, seed ShuffleSeed) {
+	stream := mathrand.NewChaCha8(seed)
+	for i := len(deck) - 1; i > 0; i-- {
+		j := uniformIndex(stream, uint64(i+1))
+		deck[i], deck[j] = deck[j], deck[i]
+	}
+}
+
+// uniformIndex returns an unbiased value in [0, n) from stream
+func uniformIndex(stream *mathrand.ChaCha8, n uint64) int {
+	// Values at or above limit would favor the low residues, so they are redrawn
+	limit := ^uint64(0) - (^uint64(0) % n)
+	for {
+		v := stream.Uint64()
+		if v < limit {
+			return int(v % n)
+		}
+	}
+}
+
+// AuditRecord is one line of the RNG audit log: everything needed to replay a hand's shuffle
+// Records are hash-chained: Hash covers the record and the previous record's hash, so
+// editing, removing or reordering any line breaks every hash after it
+type AuditRecord struct {
+	Seq        int64     `json:"seq"`
+	TableID    string    `json:"tableId"`
+	Time       time.Time `json:"time"`
+	Commitment string    `json:"commitment"` // SHA-256 of Seed, as shown to players in hand_started
+	Seed       string    `json:"seed"`
+	Deck       []string  `json:"deck"` // Deck order after the shuffle, before any card is dealt
+	PrevHash   string    `json:"prevHash"`
+	Hash       string    `json:"hash,omitempty"`
+}
+
+// genesisHash is the PrevHash of the first record in a log
+var genesisHash = strings.Repeat("0", 64)
+
+// computeHash returns the chain hash of the record: SHA-256 over its JSON with Hash left empty
+func (r AuditRecord) computeHash() (string, error) {
+	r.Hash = ""
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// RNGAuditLog appends hash-chained AuditRecords to a JSON Lines file
+// The nil RNGAuditLog records nothing
+type RNGAuditLog struct {
+	mu       sync.Mutex
+	file     *os.File
+	seq      int64
+	lastHash string
+}
+
+// OpenRNGAuditLog opens (or creates) the audit log at path and continues its chain
+// The existing file is verified first; a broken chain is reported rather than extended
+func OpenRNGAuditLog(path string) (*RNGAuditLog, error) {
+	log := &RNGAuditLog{lastHash: genesisHash}
+
+	if existing, err := os.Open(path); err == nil {
+		last, verifyErr := verifyRNGAuditLog(existing)
+		existing.Close()
+		if verifyErr != nil {
+			return nil, fmt.Errorf("existing rng audit log %s: %w", path, verifyErr)
+		}
+		if last != nil {
+			log.seq = last.Seq
+			log.lastHash = last.Hash
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to open rng audit log: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open rng audit log: %w", err)
+	}
+	log.file = file
+	return log, nil
+}
+
+// Record appends the shuffle of a hand starting at tableID and syncs it to disk
+func (l *RNGAuditLog) Record(tableID string, seed ShuffleSeed, deck []Card) error {
+	if l == nil {
+		return nil
+	}
+
+	cards := make([]string, len(deck))
+	for i, card := range deck {
+		cards[i] = card.String()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	record := AuditRecord{
+		Seq:        l.seq + 1,
+		TableID:    tableID,
+		Time:       time.Now().UTC(),
+		Commitment: seed.Commitment(),
+		Seed:       seed.String(),
+		Deck:       cards,
+		PrevHash:   l.lastHash,
+	}
+	hash, err := record.computeHash()
+	if err != nil {
+		return err
+	}
+	record.Hash = hash
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	if err := l.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync rng audit log: %w", err)
+	}
+
+	l.seq = record.Seq
+	l.lastHash = record.Hash
+	return nil
+}
+
+// Close closes the log file
+func (l *RNGAuditLog) Close() error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
+
+// VerifyRNGAuditLog checks every record read from r: the hash chain is intact, each seed
+// matches its commitment, and replaying the shuffle with the seed reproduces the logged deck.
+// Returns the number of records verified, or an error naming the first bad line.
+func VerifyRNGAuditLog(r io.Reader) (int, error) {
+	last, err := verifyRNGAuditLog(r)
+	if last == nil {
+		return 0, err
+	}
+	return int(last.Seq), err
+}
+
+// verifyRNGAuditLog verifies r and returns the last valid record (nil for an empty log)
+func verifyRNGAuditLog(r io.Reader) (*AuditRecord, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var last *AuditRecord
+	prevHash := genesisHash
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		var record AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return last, fmt.Errorf("line %d: invalid record: %w", lineNumber, err)
+		}
+		if err := verifyAuditRecord(record, int64(lineNumber), prevHash); err != nil {
+			return last, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		prevHash = record.Hash
+		last = &record
+	}
+	if err := scanner.Err(); err != nil {
+		return last, fmt.Errorf("failed to read rng audit log: %w", err)
+	}
+	return last, nil
+}
+
+// verifyAuditRecord checks a single record against its expected position in the chain
+func verifyAuditRecord(record AuditRecord, wantSeq int64, prevHash string) error {
+	if record.Seq != wantSeq {
+		return fmt.Errorf("sequence %d, expected %d (record missing or reordered)", record.Seq, wantSeq)
+	}
+	if record.PrevHash != prevHash {
+		return fmt.Errorf("chain broken: prevHash does not match the previous record")
+	}
+	hash, err := record.computeHash()
+	if err != nil {
+		return err
+	}
+	if hash != record.Hash {
+		return fmt.Errorf("record hash mismatch (record modified)")
+	}
+
+	seed, err := parseShuffleSeed(record.Seed)
+	if err != nil {
+		return err
+	}
+	if seed.Commitment() != record.Commitment {
+		return fmt.Errorf("seed does not match its commitment")
+	}
+
+	deck := NewDeck()
+	ShuffleDeckWithSeed(deck, seed)
+	if len(deck) != len(record.Deck) {
+		return fmt.Errorf("deck has %d cards, expected %d", len(record.Deck), len(deck))
+	}
+	for i, card := range deck {
+		if card.String() != record.Deck[i] {
+			return fmt.Errorf("deck differs from the seeded shuffle at position %d", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestShuffleDeckWithSeed_Deterministic verifies a seed always produces the same permutation
+func TestShuffleDeckWithSeed_Deterministic(t *testing.T) {
+	seed, err := newShuffleSeed()
+	if err != nil {
+		t.Fatalf("newShuffleSeed: %v", err)
+	}
+
+	first := NewDeck()
+	second := NewDeck()
+	ShuffleDeckWithSeed(first, seed)
+	ShuffleDeckWithSeed(second, seed)
+
+	seen := make(map[string]bool)
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("same seed produced different decks at position %d", i)
+		}
+		seen[first[i].String()] = true
+	}
+	if len(seen) != 52 {
+		t.Errorf("shuffled deck has %d distinct cards, want 52", len(seen))
+	}
+
+	other := NewDeck()
+	ShuffleDeckWithSeed(other, ShuffleSeed{1})
+	if slicesEqualCards(first, other) {
+		t.Error("different seeds produced the same deck")
+	}
+}
+
+// slicesEqualCards reports whether two decks are in the same order
+func slicesEqualCards(a, b []Card) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// writeAuditLog records n shuffles to a new log and returns its path
+func writeAuditLog(t *testing.T, n int) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rng-audit.jsonl")
+	log, err := OpenRNGAuditLog(path)
+	if err != nil {
+		t.Fatalf("OpenRNGAuditLog: %v", err)
+	}
+	for i := 0; i < n; i++ {
+		seed, _ := newShuffleSeed()
+		deck := NewDeck()
+		ShuffleDeckWithSeed(deck, seed)
+		if err := log.Record("table-1", seed, deck); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+	log.Close()
+	return path
+}
+
+// TestRNGAuditLog_VerifiesAndResumesChain verifies a written log passes and reopening extends the chain
+func TestRNGAuditLog_VerifiesAndResumesChain(t *testing.T) {
+	path := writeAuditLog(t, 3)
+
+	log, err := OpenRNGAuditLog(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	seed, _ := newShuffleSeed()
+	deck := NewDeck()
+	ShuffleDeckWithSeed(deck, seed)
+	if err := log.Record("table-2", seed, deck); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	log.Close()
+
+	data, _ := os.ReadFile(path)
+	verified, err := VerifyRNGAuditLog(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("VerifyRNGAuditLog: %v", err)
+	}
+	if verified != 4 {
+		t.Errorf("verified %d records, want 4", verified)
+	}
+}
+
+// TestRNGAuditLog_DetectsTampering verifies edited, removed and re-hashed records are reported
+func TestRNGAuditLog_DetectsTampering(t *testing.T) {
+	path := writeAuditLog(t, 3)
+	data, _ := os.ReadFile(path)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+
+	// Swapping two cards without fixing the hash breaks the record hash
+	var record AuditRecord
+	json.Unmarshal([]byte(lines[1]), &record)
+	record.Deck[0], record.Deck[1] = record.Deck[1], record.Deck[0]
+	swapped, _ := json.Marshal(record)
+
+	// Re-hashing the edited record passes the hash check but not the shuffle replay
+	record.Hash, _ = record.computeHash()
+	rehashed, _ := json.Marshal(record)
+
+	tests := []struct {
+		name  string
+		lines []string
+		want  string
+	}{
+		{"edited deck", []string{lines[0], string(swapped), lines[2]}, "line 2: record hash mismatch"},
+		{"rehashed deck", []string{lines[0], string(rehashed), lines[2]}, "line 2: deck differs"},
+		{"removed record", []string{lines[0], lines[2]}, "line 2: sequence 3"},
+		{"reordered records", []string{lines[1], lines[0], lines[2]}, "line 1: sequence 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := VerifyRNGAuditLog(strings.NewReader(strings.Join(tt.lines, "\n")))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+
+	// A tampered log must not be extended
+	os.WriteFile(path, []byte(strings.Join([]string{lines[0], string(swapped)}, "\n")+"\n"), 0o600)
+	if _, err := OpenRNGAuditLog(path); err == nil {
+		t.Error("OpenRNGAuditLog accepted a tampered log")
+	}
+}
+
+// TestStartHand_RecordsShuffleCommitment verifies StartHand audits the deck it deals from
+func TestStartHand_RecordsShuffleCommitment(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rng-audit.jsonl")
+	server := NewServerWithConfig(slog.Default(), Config{RNGAuditFile: path})
+	table := server.tables[0]
+	seatTwoPlayers(table)
+
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("StartHand: %v", err)
+	}
+	table.mu.RLock()
+	commitment := table.CurrentHand.SeedCommitment
+	table.mu.RUnlock()
+	server.rngAudit.Close()
+
+	data, _ := os.ReadFile(path)
+	if verified, err := VerifyRNGAuditLog(bytes.NewReader(data)); err != nil || verified != 1 {
+		t.Fatalf("VerifyRNGAuditLog = %d, %v; want 1 record", verified, err)
+	}
+	var record AuditRecord
+	json.Unmarshal(bytes.TrimSpace(data), &record)
+	if commitment == "" || record.Commitment != commitment || record.TableID != table.ID {
+		t.Errorf("record commitment %q table %q, hand commitment %q", record.Commitment, record.TableID, commitment)
+	}
+}
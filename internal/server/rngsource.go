@@ -0,0 +1,238 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// RNG source kinds
+const (
+	RNGSourceDevice = "device" // A hardware RNG read as a file, such as /dev/hwrng
+	RNGSourceRemote = "remote" // A remote service answering GET with random bytes
+)
+
+const (
+	defaultRNGHealthInterval = 30 * time.Second
+	defaultRNGRemoteTimeout  = 5 * time.Second
+)
+
+// RNGSourceConfig describes an external, certified random source that shuffle seeds are drawn
+// from instead of the operating system's CSPRNG. Tables choose one by name (TableConfig.RNGSource).
+type RNGSourceConfig struct {
+	Kind string `yaml:"kind"` // "device" or "remote"
+	Path string `yaml:"path"` // device: the file to read
+	URL  string `yaml:"url"`  // remote: answers GET with at least 32 random bytes
+	// Timeout bounds one request to a remote source (0 = 5s)
+	Timeout time.Duration `yaml:"timeout"`
+	// HealthInterval is how often the source is checked, and how soon dealing can resume after
+	// a failure (0 = 30s)
+	HealthInterval time.Duration `yaml:"healthInterval"`
+}
+
+// validate reports the first invalid setting of the source called name
+func (c RNGSourceConfig) validate(name string) error {
+	switch c.Kind {
+	case RNGSourceDevice:
+		if c.Path == "" {
+			return fmt.Errorf("rngSources.%s: device sources need a path", name)
+		}
+	case RNGSourceRemote:
+		if u, err := url.Parse(c.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("rngSources.%s: remote sources need an http(s) url", name)
+		}
+	default:
+		return fmt.Errorf("rngSources.%s: kind must be %q or %q", name, RNGSourceDevice, RNGSourceRemote)
+	}
+	if c.Timeout < 0 || c.HealthInterval < 0 {
+		return fmt.Errorf("rngSources.%s: timeout and healthInterval must not be negative", name)
+	}
+	return nil
+}
+
+// healthInterval returns the configured health check interval, or the default
+func (c RNGSourceConfig) healthInterval() time.Duration {
+	if c.HealthInterval == 0 {
+		return defaultRNGHealthInterval
+	}
+	return c.HealthInterval
+}
+
+// EntropySource fills p with random bytes from a random number generator
+type EntropySource interface {
+	Read(p []byte) error
+}
+
+// EntropyFunc adapts a function to EntropySource
+type EntropyFunc func(p []byte) error
+
+func (f EntropyFunc) Read(p []byte) error { return f(p) }
+
+// newEntropySource returns the EntropySource cfg describes
+func newEntropySource(cfg RNGSourceConfig) EntropySource {
+	if cfg.Kind == RNGSourceDevice {
+		return EntropyFunc(func(p []byte) error {
+			device, err := os.Open(cfg.Path)
+			if err != nil {
+				return err
+			}
+			defer device.Close()
+			_, err = io.ReadFull(device, p)
+			return err
+		})
+	}
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultRNGRemoteTimeout
+	}
+	client := &http.Client{Timeout: timeout}
+	return EntropyFunc(func(p []byte) error {
+		resp, err := client.Get(cfg.URL)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("remote source answered %s", resp.Status)
+		}
+		_, err = io.ReadFull(resp.Body, p)
+		return err
+	})
+}
+
+// RNGSourceStatus is the health of an RNG source, for GET /admin/rng
+type RNGSourceStatus struct {
+	Name      string    `json:"name"`
+	Healthy   bool      `json:"healthy"`
+	Error     string    `json:"error,omitempty"` // Why the source was last found unhealthy
+	CheckedAt time.Time `json:"checkedAt"`       // Last health check or seed drawn (zero = never)
+}
+
+// RNGSource draws shuffle seeds from an EntropySource. A source that fails, or returns bytes a
+// working generator would not (all one value, or the same seed twice), is marked unhealthy:
+// its tables deal no hand until a health check passes again, rather than falling back to
+// another generator that the deployment was not certified with.
+type RNGSource struct {
+	name    string
+	entropy EntropySource
+
+	mu       sync.Mutex
+	status   RNGSourceStatus
+	lastSeed ShuffleSeed
+}
+
+var errRNGUnavailable = errors.New("rng source unavailable")
+
+// NewRNGSource returns a healthy RNGSource called name that reads from entropy
+func NewRNGSource(name string, entropy EntropySource) *RNGSource {
+	return &RNGSource{name: name, entropy: entropy, status: RNGSourceStatus{Name: name, Healthy: true}}
+}
+
+// Seed draws a shuffle seed, or fails with error.rng_unavailable while the source is unhealthy
+func (s *RNGSource) Seed(now time.Time) (ShuffleSeed, error) {
+	if !s.Status().Healthy {
+		return ShuffleSeed{}, &MessageError{Key: "error.rng_unavailable", Err: errRNGUnavailable}
+	}
+
+	var seed ShuffleSeed
+	if err := s.draw(seed[:], now); err != nil {
+		return ShuffleSeed{}, &MessageError{Key: "error.rng_unavailable", Err: err}
+	}
+	return seed, nil
+}
+
+// Check draws a sample from the source and records whether it is healthy
+// Returns whether the source was unhealthy before and passed, so dealing can resume.
+func (s *RNGSource) Check(now time.Time) (recovered bool, err error) {
+	wasHealthy := s.Status().Healthy
+	var sample ShuffleSeed
+	if err := s.draw(sample[:], now); err != nil {
+		return false, err
+	}
+	s.mu.Lock()
+	s.status.Healthy = true
+	s.mu.Unlock()
+	return !wasHealthy, nil
+}
+
+// draw fills p from the source and checks it, marking the source unhealthy on failure
+// The source is read without holding s.mu: a remote one can take seconds to answer, and status
+// requests and other tables' draws should not wait on it.
+func (s *RNGSource) draw(p []byte, now time.Time) error {
+	err := s.entropy.Read(p)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.CheckedAt = now
+	switch {
+	case err != nil:
+		err = fmt.Errorf("%s: %w", s.name, err)
+	case bytes.Count(p, p[:1]) == len(p):
+		err = fmt.Errorf("%s: returned %d identical bytes", s.name, len(p))
+	case len(p) == len(s.lastSeed) && bytes.Equal(p, s.lastSeed[:]):
+		err = fmt.Errorf("%s: repeated its last output", s.name)
+	}
+	if err != nil {
+		s.status.Healthy = false
+		s.status.Error = err.Error()
+		return err
+	}
+	copy(s.lastSeed[:], p)
+	s.status.Error = ""
+	return nil
+}
+
+// Status returns the source's health
+func (s *RNGSource) Status() RNGSourceStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.status
+}
+
+// runRNGHealthChecks checks source every interval until stop is closed. Failures are logged as
+// errors; when the source recovers its tables are scheduled to deal again.
+func (s *Server) runRNGHealthChecks(source *RNGSource, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			s.checkRNGSource(source, now)
+		}
+	}
+}
+
+// checkRNGSource runs one health check of source and acts on the outcome
+func (s *Server) checkRNGSource(source *RNGSource, now time.Time) {
+	recovered, err := source.Check(now)
+	if err != nil {
+		s.logger.Error("rng source unhealthy; dealing halted at its tables", "source", source.name, "error", err)
+		return
+	}
+	if !recovered {
+		return
+	}
+
+	s.logger.Info("rng source healthy again; dealing resumes", "source", source.name)
+	s.mu.RLock()
+	var tables []*Table
+	for _, table := range s.tables {
+		if table != nil && table.rngSource == source {
+			tables = append(tables, table)
+		}
+	}
+	s.mu.RUnlock()
+	for _, table := range tables {
+		table.ScheduleNextHand()
+	}
+}
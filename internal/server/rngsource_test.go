@@ -0,0 +1,182 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRNGSource_HaltsDealingUntilHealthy verifies a table whose RNG source fails deals no hand,
+// with no fallback to another generator, until a health check finds the source working again
+func TestRNGSource_HaltsDealingUntilHealthy(t *testing.T) {
+	var broken atomic.Bool
+	source := NewRNGSource("hwrng", EntropyFunc(func(p []byte) error {
+		if broken.Load() {
+			return errors.New("device unplugged")
+		}
+		_, err := rand.Read(p)
+		return err
+	}))
+	server := NewServerWithConfig(slog.Default(), Config{AdminToken: "secret"})
+	server.rngSources = map[string]*RNGSource{"hwrng": source}
+	table := server.tables[0]
+	table.rngSource = source
+	seatNamed(t, server, table, "Alice", "Bob")
+
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("expected a hand from a healthy source, got %v", err)
+	}
+	playOutChecking(t, server, table)
+
+	broken.Store(true)
+	err := table.StartHand()
+	if key, _ := errorMessageKey(err); key != "error.rng_unavailable" {
+		t.Fatalf("expected error.rng_unavailable, got %v", err)
+	}
+	broken.Store(false)
+	if key, _ := errorMessageKey(table.StartHand()); key != "error.rng_unavailable" {
+		t.Errorf("expected dealing to stay halted until a health check passes")
+	}
+	if status := source.Status(); status.Healthy || status.Error == "" {
+		t.Errorf("expected the source reported unhealthy with its error, got %+v", status)
+	}
+
+	server.checkRNGSource(source, time.Now())
+	if !source.Status().Healthy {
+		t.Fatal("expected the health check to restore the source")
+	}
+	if err := table.StartHand(); err != nil {
+		t.Errorf("expected dealing to resume, got %v", err)
+	}
+
+	rec := adminRequest(server, http.MethodGet, "/admin/rng", "secret", "")
+	var status RNGStatusResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Sources) != 1 || status.Sources[0].Name != "hwrng" || !status.Sources[0].Healthy {
+		t.Errorf("expected the source listed as healthy, got %+v", status.Sources)
+	}
+}
+
+// TestRNGSource_SlowSourceLeavesTableUnlocked verifies the seed of a hand is drawn before the
+// table lock is taken, so a slow source does not hold up everyone at the table
+func TestRNGSource_SlowSourceLeavesTableUnlocked(t *testing.T) {
+	reading, release := make(chan struct{}), make(chan struct{})
+	source := NewRNGSource("remote", EntropyFunc(func(p []byte) error {
+		reading <- struct{}{}
+		<-release
+		_, err := rand.Read(p)
+		return err
+	}))
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+	table.rngSource = source
+	seatNamed(t, server, table, "Alice", "Bob")
+
+	started := make(chan error, 1)
+	go func() { started <- table.StartHand() }()
+	<-reading
+	if !table.mu.TryLock() {
+		t.Fatal("expected the table lock free while the seed is fetched")
+	}
+	table.mu.Unlock()
+	if !source.Status().Healthy {
+		t.Error("expected the source status readable while the seed is fetched")
+	}
+
+	close(release)
+	if err := <-started; err != nil {
+		t.Fatal(err)
+	}
+	if handIDOf(table) == "" {
+		t.Error("expected the hand dealt once the seed arrived")
+	}
+}
+
+// TestRNGSource_RejectsImplausibleOutput verifies a source stuck on one value is marked unhealthy
+func TestRNGSource_RejectsImplausibleOutput(t *testing.T) {
+	zeros := NewRNGSource("zeros", EntropyFunc(func(p []byte) error { clear(p); return nil }))
+	if _, err := zeros.Seed(time.Now()); err == nil || zeros.Status().Healthy {
+		t.Error("expected an all-zero seed to mark the source unhealthy")
+	}
+
+	fixed := make([]byte, 32)
+	rand.Read(fixed)
+	repeating := NewRNGSource("repeating", EntropyFunc(func(p []byte) error { copy(p, fixed); return nil }))
+	if _, err := repeating.Seed(time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repeating.Seed(time.Now()); err == nil || repeating.Status().Healthy {
+		t.Error("expected a repeated seed to mark the source unhealthy")
+	}
+}
+
+// TestEntropySource_DeviceAndRemote verifies seeds are read from a device file and a remote service
+func TestEntropySource_DeviceAndRemote(t *testing.T) {
+	random := make([]byte, 64)
+	rand.Read(random)
+	path := filepath.Join(t.TempDir(), "hwrng")
+	if err := os.WriteFile(path, random, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	remote := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(random)
+	}))
+	defer remote.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	tests := []struct {
+		name   string
+		config RNGSourceConfig
+		ok     bool
+	}{
+		{"device", RNGSourceConfig{Kind: RNGSourceDevice, Path: path}, true},
+		{"missing device", RNGSourceConfig{Kind: RNGSourceDevice, Path: path + ".missing"}, false},
+		{"remote", RNGSourceConfig{Kind: RNGSourceRemote, URL: remote.URL}, true},
+		{"remote error", RNGSourceConfig{Kind: RNGSourceRemote, URL: failing.URL}, false},
+	}
+	for _, tt := range tests {
+		seed := make([]byte, 32)
+		err := newEntropySource(tt.config).Read(seed)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: expected ok=%v, got %v", tt.name, tt.ok, err)
+		}
+		if tt.ok && string(seed) != string(random[:32]) {
+			t.Errorf("%s: expected the source's bytes", tt.name)
+		}
+	}
+}
+
+// TestConfigValidate_RNGSources verifies sources are checked and tables may only name known ones
+func TestConfigValidate_RNGSources(t *testing.T) {
+	table := TableConfig{Name: "Certified", SmallBlind: 10, BigBlind: 20, BuyIn: 1000, RNGSource: "hwrng"}
+	tests := []struct {
+		name    string
+		sources map[string]RNGSourceConfig
+		ok      bool
+	}{
+		{"valid", map[string]RNGSourceConfig{"hwrng": {Kind: RNGSourceDevice, Path: "/dev/hwrng"}}, true},
+		{"unknown source", nil, false},
+		{"device without path", map[string]RNGSourceConfig{"hwrng": {Kind: RNGSourceDevice}}, false},
+		{"remote without url", map[string]RNGSourceConfig{"hwrng": {Kind: RNGSourceRemote, URL: "ftp://rng"}}, false},
+		{"unknown kind", map[string]RNGSourceConfig{"hwrng": {Kind: "dice"}}, false},
+	}
+	for _, tt := range tests {
+		cfg := Config{Tables: []TableConfig{table}, RNGSources: tt.sources}
+		if err := cfg.Validate(); (err == nil) != tt.ok {
+			t.Errorf("%s: expected ok=%v, got %v", tt.name, tt.ok, err)
+		}
+	}
+}
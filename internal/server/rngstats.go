@@ -0,0 +1,285 @@
+package server
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// deckSize is the number of cards, and of positions, in a deck
+const deckSize = 52
+
+const (
+	// rngMinExpected is how many times each card must be expected at each position before a
+	// chi-square test is trusted
+	rngMinExpected = 5
+	// rngSignificance is the p-value under which a distribution is reported as failing;
+	// the per-position test divides it by deckSize to allow for testing every position
+	rngSignificance = 0.001
+	// rngSelfTestHistory is how many self-test results the RNGMonitor keeps
+	rngSelfTestHistory = 24
+	// rngMaxSelfTestSamples caps the shuffles one self-test may take
+	rngMaxSelfTestSamples = 1_000_000
+	// defaultRNGSelfTestSamples is the size of a self-test when none is configured
+	defaultRNGSelfTestSamples = 20_000
+)
+
+// RNGSelfTestConfig schedules the statistical self-test of the shuffler
+type RNGSelfTestConfig struct {
+	// Interval between self-tests. Zero runs them only on request through the admin API.
+	Interval time.Duration `yaml:"interval"`
+	// Samples is the number of shuffles each self-test takes (0 = 20000)
+	Samples int `yaml:"samples"`
+}
+
+// validate reports the first invalid self-test setting
+func (c RNGSelfTestConfig) validate() error {
+	if c.Interval < 0 {
+		return fmt.Errorf("rngSelfTest.interval must not be negative")
+	}
+	if c.Samples < 0 || c.Samples > rngMaxSelfTestSamples {
+		return fmt.Errorf("rngSelfTest.samples must be between 0 and %d", rngMaxSelfTestSamples)
+	}
+	return nil
+}
+
+// samples returns the configured self-test size, or the default
+func (c RNGSelfTestConfig) samples() int {
+	if c.Samples == 0 {
+		return defaultRNGSelfTestSamples
+	}
+	return c.Samples
+}
+
+// cardIndex maps each card to its place in a fresh NewDeck
+var cardIndex = func() map[Card]int {
+	index := make(map[Card]int, deckSize)
+	for i, card := range NewDeck() {
+		index[card] = i
+	}
+	return index
+}()
+
+// deckCounts counts how often each card landed at each deck position
+type deckCounts struct {
+	decks  int64
+	counts [deckSize][deckSize]int64 // [position][card]
+}
+
+// add counts one shuffled deck; anything but a whole standard deck is ignored
+func (d *deckCounts) add(deck []Card) {
+	if len(deck) != deckSize {
+		return
+	}
+	for position, card := range deck {
+		if i, ok := cardIndex[card]; ok {
+			d.counts[position][i]++
+		}
+	}
+	d.decks++
+}
+
+// RNGStatsReport is a chi-square test of card positions over a number of shuffled decks:
+// with an unbiased shuffle every card is equally likely at every position
+type RNGStatsReport struct {
+	Time             time.Time `json:"time"`
+	Decks            int64     `json:"decks"`
+	ChiSquare        float64   `json:"chiSquare"` // Over every position and card
+	DegreesOfFreedom int       `json:"degreesOfFreedom"`
+	PValue           float64   `json:"pValue"`
+	// PositionPValues holds the p-value of each deck position's card distribution;
+	// WorstPosition is the position with the lowest
+	PositionPValues []float64 `json:"positionPValues"`
+	WorstPosition   int       `json:"worstPosition"`
+	// Conclusive is false until each card is expected rngMinExpected times at each position
+	Conclusive bool `json:"conclusive"`
+	Passed     bool `json:"passed"`
+}
+
+// report runs the chi-square tests on the counts
+func (d *deckCounts) report(now time.Time) RNGStatsReport {
+	report := RNGStatsReport{
+		Time:             now,
+		Decks:            d.decks,
+		DegreesOfFreedom: (deckSize - 1) * (deckSize - 1),
+		PositionPValues:  make([]float64, deckSize),
+	}
+	if d.decks == 0 {
+		for i := range report.PositionPValues {
+			report.PositionPValues[i] = 1
+		}
+		report.PValue = 1
+		return report
+	}
+
+	expected := float64(d.decks) / deckSize
+	for position := range d.counts {
+		var chi float64
+		for _, observed := range d.counts[position] {
+			diff := float64(observed) - expected
+			chi += diff * diff / expected
+		}
+		report.ChiSquare += chi
+		report.PositionPValues[position] = chiSquarePValue(chi, deckSize-1)
+		if report.PositionPValues[position] < report.PositionPValues[report.WorstPosition] {
+			report.WorstPosition = position
+		}
+	}
+	report.PValue = chiSquarePValue(report.ChiSquare, report.DegreesOfFreedom)
+	report.Conclusive = expected >= rngMinExpected
+	report.Passed = report.Conclusive &&
+		report.PValue >= rngSignificance &&
+		report.PositionPValues[report.WorstPosition] >= rngSignificance/deckSize
+	return report
+}
+
+// chiSquarePValue returns the chance of a chi-square statistic of at least x with df degrees of
+// freedom, by the Wilson-Hilferty normal approximation, which is close for the df used here
+func chiSquarePValue(x float64, df int) float64 {
+	k := float64(df)
+	variance := 2 / (9 * k)
+	z := (math.Cbrt(x/k) - (1 - variance)) / math.Sqrt(variance)
+	return math.Erfc(z/math.Sqrt2) / 2
+}
+
+// RNGMonitor keeps card position statistics of every deck dealt and the results of the
+// shuffler's self-tests, for GET /admin/rng
+// The nil RNGMonitor records nothing.
+type RNGMonitor struct {
+	mu        sync.Mutex
+	live      deckCounts
+	selfTests []RNGStatsReport // Oldest first
+}
+
+// NewRNGMonitor creates an RNGMonitor with no decks counted
+func NewRNGMonitor() *RNGMonitor {
+	return &RNGMonitor{}
+}
+
+// Observe counts a deck dealt at a table, shuffled and before any card is dealt
+func (m *RNGMonitor) Observe(deck []Card) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.live.add(deck)
+}
+
+// Live returns the statistics of every deck dealt since startup
+func (m *RNGMonitor) Live(now time.Time) RNGStatsReport {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.live.report(now)
+}
+
+// SelfTests returns the kept self-test results, newest first
+func (m *RNGMonitor) SelfTests() []RNGStatsReport {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tests := make([]RNGStatsReport, len(m.selfTests))
+	for i, test := range m.selfTests {
+		tests[len(tests)-1-i] = test
+	}
+	return tests
+}
+
+// SelfTest shuffles samples decks the way hands are dealt, each from a fresh seed, and keeps
+// the result of testing their card positions
+func (m *RNGMonitor) SelfTest(samples int, now time.Time) (RNGStatsReport, error) {
+	var counts deckCounts
+	deck := NewDeck()
+	for range samples {
+		seed, err := newShuffleSeed()
+		if err != nil {
+			return RNGStatsReport{}, err
+		}
+		ShuffleDeckWithSeed(deck, seed)
+		counts.add(deck)
+	}
+	report := counts.report(now)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.selfTests = append(m.selfTests, report)
+	if len(m.selfTests) > rngSelfTestHistory {
+		m.selfTests = m.selfTests[len(m.selfTests)-rngSelfTestHistory:]
+	}
+	return report, nil
+}
+
+// runRNGSelfTests runs a self-test every interval until stop is closed
+func (s *Server) runRNGSelfTests(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			s.rngSelfTest(s.Config().RNGSelfTest.samples(), now)
+		}
+	}
+}
+
+// rngSelfTest runs one self-test and logs its outcome; a failure is logged as an error
+func (s *Server) rngSelfTest(samples int, now time.Time) (RNGStatsReport, error) {
+	report, err := s.rngMonitor.SelfTest(samples, now)
+	if err != nil {
+		s.logger.Error("rng self-test failed to run", "error", err)
+		return report, err
+	}
+	attrs := []any{"decks", report.Decks, "pValue", report.PValue, "worstPosition", report.WorstPosition,
+		"worstPositionPValue", report.PositionPValues[report.WorstPosition]}
+	if report.Conclusive && !report.Passed {
+		s.logger.Error("rng self-test found a biased card distribution", attrs...)
+	} else {
+		s.logger.Info("rng self-test finished", attrs...)
+	}
+	return report, nil
+}
+
+// RNGStatusResponse is the body of GET /admin/rng
+type RNGStatusResponse struct {
+	Live      RNGStatsReport    `json:"live"`              // Every deck dealt since startup
+	SelfTests []RNGStatsReport  `json:"selfTests"`         // Newest first
+	Sources   []RNGSourceStatus `json:"sources,omitempty"` // External seed sources, by name
+}
+
+// handleRNGStatus writes the card position statistics of dealt decks and recent self-tests
+func (s *Server) handleRNGStatus(w http.ResponseWriter, r *http.Request) {
+	response := RNGStatusResponse{
+		Live:      s.rngMonitor.Live(time.Now()),
+		SelfTests: s.rngMonitor.SelfTests(),
+	}
+	for _, name := range slices.Sorted(maps.Keys(s.rngSources)) {
+		response.Sources = append(response.Sources, s.rngSources[name].Status())
+	}
+	writeAdminJSON(w, http.StatusOK, response)
+}
+
+// handleRNGSelfTest runs a self-test now, of ?samples= shuffles or the configured number
+func (s *Server) handleRNGSelfTest(w http.ResponseWriter, r *http.Request) {
+	samples := s.Config().RNGSelfTest.samples()
+	if param := r.URL.Query().Get("samples"); param != "" {
+		parsed, err := strconv.Atoi(param)
+		if err != nil || parsed <= 0 || parsed > rngMaxSelfTestSamples {
+			http.Error(w, fmt.Sprintf("samples must be between 1 and %d", rngMaxSelfTestSamples), http.StatusBadRequest)
+			return
+		}
+		samples = parsed
+	}
+
+	report, err := s.rngSelfTest(samples, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeAdminJSON(w, http.StatusOK, report)
+}
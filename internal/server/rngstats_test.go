@@ -0,0 +1,113 @@
+package server
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"log/slog"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestChiSquarePValue verifies the approximation against textbook critical values
+func TestChiSquarePValue(t *testing.T) {
+	cases := []struct {
+		x    float64
+		df   int
+		want float64
+	}{
+		{77.386, 51, 0.01},  // 99th percentile with 51 degrees of freedom
+		{68.669, 51, 0.05},  // 95th percentile
+		{50.335, 51, 0.5},   // Median
+		{2602, 2601, 0.495}, // Close to the mean of the whole-deck test
+	}
+	for _, c := range cases {
+		if got := chiSquarePValue(c.x, c.df); math.Abs(got-c.want) > 0.005 {
+			t.Errorf("chiSquarePValue(%v, %d) = %.4f, expected about %v", c.x, c.df, got, c.want)
+		}
+	}
+}
+
+// TestDeckCounts_Report verifies fair shuffles pass and an unshuffled deck fails
+func TestDeckCounts_Report(t *testing.T) {
+	var fair deckCounts
+	deck := NewDeck()
+	for i := range 5000 {
+		var seed ShuffleSeed
+		binary.LittleEndian.PutUint64(seed[:], uint64(i))
+		ShuffleDeckWithSeed(deck, seed)
+		fair.add(deck)
+	}
+	report := fair.report(time.Now())
+	if report.Decks != 5000 || !report.Conclusive || !report.Passed {
+		t.Errorf("expected fair shuffles to pass, got %+v", report)
+	}
+
+	var biased deckCounts
+	for range 1000 {
+		biased.add(NewDeck())
+	}
+	if report := biased.report(time.Now()); !report.Conclusive || report.Passed || report.PValue > 1e-9 {
+		t.Errorf("expected an unshuffled deck to fail, got p=%v passed=%v", report.PValue, report.Passed)
+	}
+
+	var few deckCounts
+	few.add(NewDeck())
+	if report := few.report(time.Now()); report.Conclusive || report.Passed {
+		t.Errorf("expected one deck to be inconclusive, got %+v", report)
+	}
+}
+
+// TestRNGMonitor_SelfTests verifies self-test results are kept newest first, up to the limit
+func TestRNGMonitor_SelfTests(t *testing.T) {
+	monitor := NewRNGMonitor()
+	start := time.Unix(0, 0)
+	for i := range rngSelfTestHistory + 2 {
+		if _, err := monitor.SelfTest(10, start.Add(time.Duration(i)*time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := monitor.SelfTests()
+	if len(tests) != rngSelfTestHistory {
+		t.Fatalf("expected %d results, got %d", rngSelfTestHistory, len(tests))
+	}
+	if tests[0].Time != start.Add(time.Duration(rngSelfTestHistory+1)*time.Hour) || tests[0].Decks != 10 {
+		t.Errorf("expected the newest result first, got %+v", tests[0])
+	}
+}
+
+// TestAdminAPI_RNG verifies dealt decks are counted and self-tests run on request
+func TestAdminAPI_RNG(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{AdminToken: "secret"})
+	table := server.tables[0]
+	for _, name := range []string{"Alice", "Bob"} {
+		session, _ := server.sessionManager.CreateSession(name)
+		if _, err := server.seatPlayer(session.Token, "", table); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	if rec := adminRequest(server, http.MethodPost, "/admin/rng/selftest?samples=0", "secret", ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for zero samples, got %d", rec.Code)
+	}
+	rec := adminRequest(server, http.MethodPost, "/admin/rng/selftest?samples=300", "secret", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = adminRequest(server, http.MethodGet, "/admin/rng", "secret", "")
+	var status RNGStatusResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil {
+		t.Fatal(err)
+	}
+	if status.Live.Decks != 1 || status.Live.Conclusive {
+		t.Errorf("expected the dealt hand counted and inconclusive, got %+v", status.Live)
+	}
+	if len(status.SelfTests) != 1 || status.SelfTests[0].Decks != 300 || len(status.SelfTests[0].PositionPValues) != deckSize {
+		t.Errorf("expected the self-test listed, got %+v", status.SelfTests)
+	}
+}
@@ -0,0 +1,250 @@
+package server
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"strings"
+)
+
+// recoveryCodeBytes is the length of a seat recovery code before hex encoding
+const recoveryCodeBytes = 10
+
+// RecoveryCodePayload represents the payload for recovery_code messages, the reply to
+// get_recovery_code: the code to keep somewhere other than the browser
+type RecoveryCodePayload struct {
+	Code string `json:"code"`
+}
+
+// RecoverSeatPayload represents the payload for recover_seat messages
+type RecoverSeatPayload struct {
+	Code string `json:"code"`
+}
+
+// recoveryCodeHash is what the account stores of a recovery code; codes are matched without
+// regard to case or surrounding spaces
+func recoveryCodeHash(code string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(code))))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewRecoveryCode issues the session with token a new seat recovery code, which replaces any
+// earlier one. The code belongs to that session alone, so only the token holding a seat can
+// issue or rotate the code that moves it. Only its hash is kept, so the code cannot be shown again.
+func (sm *SessionManager) NewRecoveryCode(token string) (string, error) {
+	random := make([]byte, recoveryCodeBytes)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	code := hex.EncodeToString(random)
+
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	session, ok := sm.sessions[token]
+	if !ok {
+		return "", newMessageError("error.session_not_found", map[string]any{"token": token})
+	}
+	session.RecoveryCode = recoveryCodeHash(code)
+	return code, nil
+}
+
+// RecoveryCodeMatches reports whether code is the current recovery code of the session with token
+func (sm *SessionManager) RecoveryCodeMatches(token, code string) bool {
+	sm.mutex.RLock()
+	var stored string
+	if session, ok := sm.sessions[token]; ok {
+		stored = session.RecoveryCode
+	}
+	sm.mutex.RUnlock()
+	return stored != "" && subtle.ConstantTimeCompare([]byte(stored), []byte(recoveryCodeHash(code))) == 1
+}
+
+// transferSeat moves the kept seat of the disconnected player with token from to token to, with
+// the clock calls and time bank use the table remembers for them. moveSession runs under the
+// table lock once the seat is found, before anything here changes; if it fails the seat stays
+// kept for from and its error is returned. Returns the seat index, false when from holds no
+// seat kept for a dropped connection here.
+func (t *Table) transferSeat(from, to string, moveSession func() error) (int, bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.disconnected[from]; !ok {
+		return 0, false, nil
+	}
+	for i := range t.Seats {
+		if t.Seats[i].Token == nil || *t.Seats[i].Token != from {
+			continue
+		}
+		if err := moveSession(); err != nil {
+			return i, true, err
+		}
+		t.dropConnectionLocked(from)
+		t.Seats[i].Token = &to
+		if calledAt, ok := t.clockCalls[from]; ok {
+			t.clockCalls[to] = calledAt
+		}
+		if used, ok := t.timeBankUsed[from]; ok {
+			t.timeBankUsed[to] = used
+		}
+		t.forgetPlayerLocked(from)
+		if t.timeBank != nil && t.timeBank.token == from {
+			t.timeBank.token = to
+		}
+		return i, true, nil
+	}
+	return 0, false, nil
+}
+
+// RecoverSeat moves the seat the player lost with their token onto their new session token,
+// once code proves the new session is the one that asked for it: the code must be the one the
+// lost session issued while it held the seat. The seat must still be kept for the dropped
+// connection: held for a reconnect, or on a frozen table. The old session is removed, with its
+// balances added to the new one, and its code with it. Returns the table and seat index.
+func (s *Server) RecoverSeat(token, code, remoteIP string) (*Table, int, error) {
+	session, err := s.sessionManager.GetSession(token)
+	if err != nil {
+		return nil, 0, err
+	}
+	if session.TableID != nil {
+		return nil, 0, newMessageError("error.already_seated", nil)
+	}
+
+	matched := false
+	for _, previous := range s.sessionManager.TokensByName(session.Name) {
+		if previous == token || !s.sessionManager.RecoveryCodeMatches(previous, code) {
+			continue
+		}
+		matched = true
+		lost, err := s.sessionManager.GetSession(previous)
+		if err != nil || lost.TableID == nil {
+			continue
+		}
+		table := s.tableByID(*lost.TableID)
+		if table == nil {
+			continue
+		}
+		// The session moves while the table is locked, so the seat cannot time out and be
+		// cashed out to the old session in between
+		seatIndex, ok, err := table.transferSeat(previous, token, func() error {
+			return s.sessionManager.TransferSession(previous, token)
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		if !ok {
+			continue
+		}
+
+		s.waitlist.Remove(token)
+		s.observers.Remove(token)
+		table.publishEvent(Event{Type: EventSeatTransferred, SeatIndex: seatIndex, Token: token, PreviousToken: previous, RemoteIP: remoteIP})
+		return table, seatIndex, nil
+	}
+	if !matched {
+		return nil, 0, newMessageError("error.recovery_code_invalid", nil)
+	}
+	return nil, 0, newMessageError("error.no_seat_to_recover", nil)
+}
+
+// TransferSession moves the table placement and balances of the session from onto the session
+// to, then removes from
+func (sm *SessionManager) TransferSession(from, to string) error {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	source, ok := sm.sessions[from]
+	if !ok {
+		return newMessageError("error.session_not_found", map[string]any{"token": from})
+	}
+	target, ok := sm.sessions[to]
+	if !ok {
+		return newMessageError("error.session_not_found", map[string]any{"token": to})
+	}
+
+	target.TableID = source.TableID
+	target.SeatIndex = source.SeatIndex
+	if target.Balances == nil {
+		target.Balances = make(map[ChipCurrency]int)
+	}
+	for currency, balance := range source.Balances {
+		target.Balances[currency] += balance
+	}
+	delete(sm.sessions, from)
+	sm.logger.Info("session transferred", "from", from, "to", to)
+	return nil
+}
+
+// transferPlayerLocked moves the statistics and open stay of the player with token from to
+// token to, after their seat moved to a new session (caller must hold st.mu)
+func (st *StatsTracker) transferPlayerLocked(e Event) {
+	if stats, ok := st.players[e.PreviousToken]; ok {
+		st.players[e.Token] = stats
+		delete(st.players, e.PreviousToken)
+	}
+	if stay, ok := st.sittings[e.PreviousToken]; ok {
+		st.sittings[e.Token] = stay
+		delete(st.sittings, e.PreviousToken)
+	}
+	// The seats map came with hand_started and is shared with the other subscribers
+	if seats := st.dealtIn[e.TableID]; seats[e.SeatIndex] == e.PreviousToken {
+		seats = maps.Clone(seats)
+		seats[e.SeatIndex] = e.Token
+		st.dealtIn[e.TableID] = seats
+	}
+}
+
+// HandleGetRecoveryCode processes a get_recovery_code message: issues the seated player a new
+// seat recovery code for their session, replacing any earlier one, and replies with recovery_code
+func (c *Client) HandleGetRecoveryCode(sm *SessionManager, server *Server, logger *slog.Logger) error {
+	session, err := sm.GetSession(c.Token)
+	if err != nil {
+		return err
+	}
+	seated := false
+	if session.TableID != nil {
+		if table := server.tableByID(*session.TableID); table != nil {
+			_, seated = table.GetSeatByToken(&c.Token)
+		}
+	}
+	if !seated {
+		return newMessageError("error.not_seated", nil)
+	}
+	code, err := sm.NewRecoveryCode(c.Token)
+	if err != nil {
+		return err
+	}
+	logger.Info("recovery code issued", "name", session.Name)
+	return c.sendMessage("recovery_code", RecoveryCodePayload{Code: code})
+}
+
+// HandleRecoverSeat processes a recover_seat message: a player who lost their token, and made
+// a new session under the same name, reclaims the seat kept for their dropped connection with
+// their recovery code. Replies with seat_assigned and tells the table the player is back.
+func (c *Client) HandleRecoverSeat(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+	var req RecoverSeatPayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return invalidPayloadError("recover_seat", err)
+	}
+	table, seatIndex, err := server.RecoverSeat(c.Token, req.Code, c.RemoteIP)
+	if err != nil {
+		logger.Warn("seat recovery refused", "token", c.Token, "client_ip", c.RemoteIP, "error", err)
+		return err
+	}
+	logger.Info("seat recovered by new session", "token", c.Token, "tableId", table.ID, "seatIndex", seatIndex, "client_ip", c.RemoteIP)
+
+	seat, _ := table.GetSeatByToken(&c.Token)
+	if err := c.SendSeatAssigned(table.ID, seatIndex, seat.Status, logger); err != nil {
+		return err
+	}
+	server.sendBalances(c.Token)
+	server.broadcastConnectionStatus(table, c.Token, seatIndex, ConnectionConnected, nil)
+	if err := server.broadcastTableState(table.ID, nil); err != nil {
+		logger.Warn("failed to broadcast table_state on seat recovery", "error", err)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package server
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// recoveryCode issues client's player a recovery code and returns it
+func recoveryCode(t *testing.T, server *Server, client *Client) string {
+	t.Helper()
+	drainRawMessages(client)
+	if err := client.HandleGetRecoveryCode(server.sessionManager, server, slog.Default()); err != nil {
+		t.Fatal(err)
+	}
+	codes := payloadsOf[RecoveryCodePayload](t, client, "recovery_code")
+	if len(codes) != 1 || codes[0].Code == "" {
+		t.Fatalf("expected one recovery code, got %+v", codes)
+	}
+	return codes[0].Code
+}
+
+// recoverSeat sends a recover_seat for client with code
+func recoverSeat(server *Server, client *Client, code string) error {
+	return client.HandleRecoverSeat(server.sessionManager, server, slog.Default(), []byte(`{"code":"`+code+`"}`))
+}
+
+// TestSeatRecovery_NewSession verifies a player who lost their token reclaims their held seat
+// from a new session with their recovery code, and the seat is no longer given up
+func TestSeatRecovery_NewSession(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	clock := useFakeClock(server)
+	updateConfig(server, func(config *Config) { config.ReconnectGrace = 30 * time.Second })
+	lost := clients[1].Token
+	code := recoveryCode(t, server, clients[1])
+	server.HandleDisconnect(lost)
+
+	session, _ := server.sessionManager.CreateSession("bob")
+	client := connectTestClient(server, session.Token)
+	if err := recoverSeat(server, client, "0123456789abcdef0123"); err == nil {
+		t.Fatal("expected a wrong code refused")
+	} else if key, _ := errorMessageKey(err); key != "error.recovery_code_invalid" {
+		t.Errorf("expected error.recovery_code_invalid, got %s", key)
+	}
+
+	drainRawMessages(clients[0])
+	if err := recoverSeat(server, client, code); err != nil {
+		t.Fatal(err)
+	}
+	if seats := payloadsOf[SeatAssignedPayload](t, client, "seat_assigned"); len(seats) != 1 || seats[0].TableId != table.ID || seats[0].SeatIndex != 1 {
+		t.Errorf("expected seat 1 assigned, got %+v", seats)
+	}
+	if statuses := connectionStatuses(clients[0]); len(statuses) != 1 || statuses[0].SeatIndex != 1 || statuses[0].Status != ConnectionConnected {
+		t.Errorf("expected Bob connected again, got %+v", statuses)
+	}
+	if seat, ok := table.GetSeatByToken(&session.Token); !ok || seat.Index != 1 {
+		t.Errorf("expected the seat under the new token, got %+v", seat)
+	}
+	if _, err := server.sessionManager.GetSession(lost); err == nil {
+		t.Error("expected the lost session removed")
+	}
+	if recovered, _ := server.sessionManager.GetSession(session.Token); recovered.TableID == nil || *recovered.SeatIndex != 1 {
+		t.Errorf("expected the new session seated, got %+v", recovered)
+	}
+
+	clock.Advance(time.Minute)
+	if _, ok := table.GetSeatByToken(&session.Token); !ok {
+		t.Error("expected the seat kept past the reconnect deadline")
+	}
+}
+
+// TestSeatRecovery_NoKeptSeat verifies a recovery code does not take a seat whose player is
+// still connected
+func TestSeatRecovery_NoKeptSeat(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	code := recoveryCode(t, server, clients[2])
+
+	session, _ := server.sessionManager.CreateSession("Carol")
+	client := connectTestClient(server, session.Token)
+	err := recoverSeat(server, client, code)
+	if key, _ := errorMessageKey(err); key != "error.no_seat_to_recover" {
+		t.Errorf("expected error.no_seat_to_recover, got %v", err)
+	}
+	if seat, ok := table.GetSeatByToken(&clients[2].Token); !ok || seat.Index != 2 {
+		t.Error("expected Carol's seat left with her connection")
+	}
+}
+
+// TestSeatRecovery_SameNameCannotTakeSeat verifies another session choosing the seated player's
+// name can neither replace their recovery code nor take the seat with a code of its own
+func TestSeatRecovery_SameNameCannotTakeSeat(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	updateConfig(server, func(config *Config) { config.ReconnectGrace = 30 * time.Second })
+	lost := clients[1].Token
+	code := recoveryCode(t, server, clients[1])
+	server.HandleDisconnect(lost)
+
+	impostorSession, _ := server.sessionManager.CreateSession("Bob")
+	impostor := connectTestClient(server, impostorSession.Token)
+	err := impostor.HandleGetRecoveryCode(server.sessionManager, server, slog.Default())
+	if key, _ := errorMessageKey(err); key != "error.not_seated" {
+		t.Errorf("expected an unseated session refused a code, got %v", err)
+	}
+
+	// A code issued while the impostor sat at another table is theirs alone
+	if _, err := server.seatPlayer(impostorSession.Token, "", server.tables[1]); err != nil {
+		t.Fatal(err)
+	}
+	own := recoveryCode(t, server, impostor)
+	if err := impostor.HandleLeaveTable(server.sessionManager, server, slog.Default(), nil); err != nil {
+		t.Fatal(err)
+	}
+	err = recoverSeat(server, impostor, own)
+	if key, _ := errorMessageKey(err); key != "error.recovery_code_invalid" {
+		t.Errorf("expected the impostor's own code refused, got %v", err)
+	}
+	if seat, ok := table.GetSeatByToken(&lost); !ok || seat.Index != 1 {
+		t.Fatal("expected Bob's seat still kept for his lost session")
+	}
+
+	session, _ := server.sessionManager.CreateSession("Bob")
+	if err := recoverSeat(server, connectTestClient(server, session.Token), code); err != nil {
+		t.Fatalf("expected Bob's own code to still work, got %v", err)
+	}
+	if seat, ok := table.GetSeatByToken(&session.Token); !ok || seat.Index != 1 {
+		t.Errorf("expected the seat under Bob's new session, got %+v", seat)
+	}
+}
+
+// TestSeatRecovery_SessionTransferFails verifies a seat stays kept for the lost session when
+// moving the session fails, and is still given up at the reconnect deadline
+func TestSeatRecovery_SessionTransferFails(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	clock := useFakeClock(server)
+	updateConfig(server, func(config *Config) { config.ReconnectGrace = 30 * time.Second })
+	lost := clients[1].Token
+	server.HandleDisconnect(lost)
+
+	session, _ := server.sessionManager.CreateSession("bob")
+	failed := newMessageError("error.session_not_found", nil)
+	if _, _, err := table.transferSeat(lost, session.Token, func() error { return failed }); err != failed {
+		t.Fatalf("expected the session error returned, got %v", err)
+	}
+	if seat, ok := table.GetSeatByToken(&lost); !ok || seat.Index != 1 {
+		t.Errorf("expected the seat still under the lost token, got %+v", seat)
+	}
+	table.mu.Lock()
+	status, _ := table.connectionLocked(lost)
+	table.mu.Unlock()
+	if status != ConnectionReconnecting {
+		t.Errorf("expected the seat still held for a reconnect, got %q", status)
+	}
+	if moved, _ := server.sessionManager.GetSession(session.Token); moved.TableID != nil {
+		t.Errorf("expected the new session left unseated, got %+v", moved)
+	}
+
+	clock.Advance(time.Minute)
+	if _, ok := table.GetSeatByToken(&lost); ok {
+		t.Error("expected the seat given up at the reconnect deadline")
+	}
+}
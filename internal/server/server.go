@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/gorilla/websocket"
@@ -15,41 +17,168 @@ import (
 
 // Server represents the HTTP server with router and WebSocket support.
 type Server struct {
-	router         chi.Router
-	logger         *slog.Logger
-	upgrader       *websocket.Upgrader
-	httpServer     *http.Server
-	hub            *Hub
-	sessionManager *SessionManager
-	tables         [4]*Table
-	mu             sync.RWMutex
+	router     chi.Router
+	logger     *slog.Logger
+	upgrader   *websocket.Upgrader
+	httpServer *http.Server
+	// diagnosticsServer serves DiagnosticsHandler when StartDiagnostics is called
+	diagnosticsServer *http.Server
+	hub               *Hub
+	sessionManager    *SessionManager
+	tables            []*Table
+	config            Config
+	configMu          sync.RWMutex  // Guards config, which ReloadConfig replaces at runtime
+	trustedProxies    []*net.IPNet  // Parsed Config.TrustedProxies; fixed at startup
+	sweeperStop       chan struct{} // Closed by Shutdown to stop the session sweeper; nil when sessions never expire
+	bans              *BanList
+	accounts          *AccountStore // Per-account state persisted across sessions (bonus claims)
+	clubs             *ClubStore    // Clubs, their members and their private tables
+	connections       *connLimiter  // Open WebSocket connections per client IP
+	abuse             *abuseTracker // Protocol violations per client IP
+	events            *EventBus
+	fraud             *FraudDetector
+	stats             *StatsTracker
+	latency           *LatencyTracker // How quickly players answer their action requests
+	bandwidth         *BandwidthMeter // What is sent to clients, by message type
+	waitlist          *Waitlist       // Players waiting for a quick seat
+	activity          *ActivityTracker
+	replays           *ReplayStore  // Recently finished hands, for /api/replays
+	snippets          *SnippetStore // Replays of dramatic all-ins, for /api/snippets
+	equity            *EquityPool   // Bounded workers for the snippets' equity simulations
+	emotes            *EmoteLimiter
+	observers         *Observers // Sessions watching a table without a seat
+	announcements     *AnnouncementLog
+	incidents         *IncidentLog
+	aliasKey          []byte                // Secret behind the aliases at anonymous tables (see tableAlias)
+	rngAudit          *RNGAuditLog          // Shuffle audit trail; nil when Config.RNGAuditFile is empty
+	rngMonitor        *RNGMonitor           // Card position statistics of dealt decks and shuffler self-tests
+	rngSelfTestStop   chan struct{}         // Closed by Shutdown to stop scheduled RNG self-tests; nil when none are scheduled
+	rngSources        map[string]*RNGSource // External seed sources by name, from Config.RNGSources
+	rngHealthStop     chan struct{}         // Closed by Shutdown to stop the RNG source health checks; nil when there are none
+	retentionStop     chan struct{}         // Closed by Shutdown to stop deleting hands past their retention; nil when hands are kept
+	tableBreakingStop chan struct{}         // Closed by Shutdown to stop table breaking; nil when it is disabled
+	clubSettleStop    chan struct{}         // Closed by Shutdown to stop scheduled club settle-ups; nil when clubs are disabled
+	closedTables      []*Table              // Tables out of the lobby for lack of players, reopened when needed
+	clock             Clock                 // Drives the table timers; tests replace it with a fake clock
+	mu                sync.RWMutex
 }
 
-// NewServer creates and returns a new Server instance.
+// NewServer creates and returns a new Server instance with the zero Config.
 func NewServer(logger *slog.Logger) *Server {
+	return NewServerWithConfig(logger, Config{})
+}
+
+// NewServerWithConfig creates and returns a new Server instance using the given configuration.
+func NewServerWithConfig(logger *slog.Logger, config Config) *Server {
 	hub := NewHub(logger)
-	sessionManager := NewSessionManager(logger)
+	sessionManager := NewSessionManagerWithTTL(logger, config.SessionTTL)
 	s := &Server{
-		router: chi.NewRouter(),
-		logger: logger,
-		upgrader: &websocket.Upgrader{
-			CheckOrigin: func(r *http.Request) bool {
-				// CheckOrigin: true allows all origins for development.
-				// SECURITY: In production, this must be restricted to specific origins
-				// to prevent Cross-Site WebSocket Hijacking (CSWSH) attacks.
-				// Use a whitelist of allowed origins in production.
-				return true
-			},
-		},
+		router:         chi.NewRouter(),
+		logger:         logger,
+		config:         config,
 		hub:            hub,
 		sessionManager: sessionManager,
+		connections:    newConnLimiter(),
+		bandwidth:      NewBandwidthMeter(),
+		abuse:          newAbuseTracker(),
+		events:         NewEventBus(logger),
+		aliasKey:       newAliasKey(),
+		clock:          systemClock{},
+	}
+
+	// Browsers may only upgrade from the same origin or an allow-listed one,
+	// which prevents Cross-Site WebSocket Hijacking (CSWSH)
+	s.upgrader = &websocket.Upgrader{
+		CheckOrigin: s.checkOrigin,
+	}
+
+	// Invalid entries are rejected by Config.Validate; here they are only logged
+	trustedProxies, err := parseTrustedProxies(config.TrustedProxies)
+	if err != nil {
+		logger.Error("ignoring trusted proxies", "error", err)
+	}
+	s.trustedProxies = trustedProxies
+
+	bans, err := LoadBanList(config.BanListFile)
+	if err != nil {
+		// Keep bans in memory rather than overwrite a file we could not read
+		logger.Error("ban list not loaded; bans will not be persisted", "error", err)
+		bans, _ = LoadBanList("")
+	}
+	s.bans = bans
+
+	accounts, err := LoadAccountStore(config.AccountStoreFile)
+	if err != nil {
+		// Keep accounts in memory rather than overwrite a file we could not read
+		logger.Error("account store not loaded; accounts will not be persisted", "error", err)
+		accounts, _ = LoadAccountStore("")
+	}
+	s.accounts = accounts
+
+	clubs, err := LoadClubStore(config.ClubStoreFile)
+	if err != nil {
+		// Keep clubs in memory rather than overwrite a file we could not read
+		logger.Error("club store not loaded; clubs will not be persisted", "error", err)
+		clubs, _ = LoadClubStore("")
+	}
+	s.clubs = clubs
+
+	if config.RNGAuditFile != "" {
+		rngAudit, err := OpenRNGAuditLog(config.RNGAuditFile)
+		if err != nil {
+			// Refuse to extend a chain that does not verify; hands are dealt unaudited instead
+			logger.Error("rng audit log not opened; shuffles will not be audited", "error", err)
+		}
+		s.rngAudit = rngAudit
+	}
+
+	// External RNG sources are shared by the tables that name them
+	s.rngSources = make(map[string]*RNGSource, len(config.RNGSources))
+	for name, sourceConfig := range config.RNGSources {
+		s.rngSources[name] = NewRNGSource(name, newEntropySource(sourceConfig))
 	}
 
-	// Preseed 4 tables
-	tableNames := [4]string{"Table 1", "Table 2", "Table 3", "Table 4"}
-	for i := 0; i < 4; i++ {
+	// Create the configured tables (four 10/20 tables by default)
+	if len(s.config.Tables) == 0 {
+		s.config.Tables = DefaultTables()
+	}
+	for i, tableConfig := range s.config.Tables {
 		tableID := fmt.Sprintf("table-%d", i+1)
-		s.tables[i] = NewTable(tableID, tableNames[i], s)
+		table := NewTable(tableID, tableConfig.Name, s)
+		table.SmallBlind = tableConfig.SmallBlind
+		table.BigBlind = tableConfig.BigBlind
+		table.BuyIn = tableConfig.BuyIn
+		table.MinBuyIn = tableConfig.MinBuyIn
+		table.MaxBuyIn = tableConfig.MaxBuyIn
+		table.ShowStats = tableConfig.ShowStats
+		table.Description = tableConfig.Description
+		table.Theme = tableConfig.Theme
+		table.Tags = normalizeTags(tableConfig.Tags)
+		if tableConfig.Speed != "" {
+			table.Speed = tableConfig.Speed
+		}
+		if tableConfig.Currency != "" {
+			table.Currency = tableConfig.Currency
+		}
+		if tableConfig.HeadsUp {
+			table.HeadsUp = true
+			table.MaxSeats = 2
+		}
+		for _, host := range tableConfig.Hosts {
+			table.Hosts = append(table.Hosts, accountKey(host))
+		}
+		table.BombPotAnte = tableConfig.BombPotAnte
+		table.Practice = tableConfig.Practice
+		table.Anonymous = tableConfig.Anonymous
+		table.ConfirmRaisePercent = tableConfig.ConfirmRaisePercent
+		table.BetIncrement = tableConfig.BetIncrement
+		table.rngSource = s.rngSources[tableConfig.RNGSource]
+		s.tables = append(s.tables, table)
+	}
+	for _, club := range s.clubs.Clubs("") {
+		for _, clubTable := range club.Tables {
+			s.openClubTable(club.ID, clubTable)
+		}
 	}
 
 	s.RegisterRoutes()
@@ -57,13 +186,128 @@ func NewServer(logger *slog.Logger) *Server {
 	// Start the Hub's event loop in a goroutine
 	go hub.Run()
 
+	// The anti-fraud detector watches the tables through the event bus
+	s.fraud = NewFraudDetector(
+		func() FraudConfig { return s.Config().Fraud },
+		func(token string) string {
+			name, _ := s.sessionManager.GetPlayerName(token)
+			return name
+		},
+		logger,
+	)
+	fraudEvents, _ := s.events.Subscribe()
+	go s.fraud.Run(fraudEvents)
+
+	// Player statistics and table session summaries are built from the same events
+	s.stats = NewStatsTracker(func(token string) string {
+		name, _ := s.sessionManager.GetPlayerName(token)
+		return name
+	}, s.endTableSession)
+	statsEvents, _ := s.events.Subscribe()
+	go s.stats.Run(statsEvents)
+
+	// The rake ledger statements are drawn from, and the loyalty points the rake earns
+	rakeEvents, _ := s.events.Subscribe()
+	go s.RunRakeLedger(rakeEvents)
+
+	// Action latency per table and per player, for the admin API and diagnostics
+	s.latency = NewLatencyTracker(func(token string) string {
+		name, _ := s.sessionManager.GetPlayerName(token)
+		return name
+	})
+	latencyEvents, _ := s.events.Subscribe()
+	go s.latency.Run(latencyEvents)
+
+	// One structured log record per finished hand, and per action at debug level
+	handLogger := NewHandLogger(logger, func(token string) string {
+		name, _ := s.sessionManager.GetPlayerName(token)
+		return name
+	})
+	handLogEvents, _ := s.events.Subscribe()
+	go handLogger.Run(handLogEvents)
+
+	// Freed seats go to the quick-seat waitlist
+	s.waitlist = NewWaitlist()
+	waitlistEvents, _ := s.events.Subscribe()
+	go s.RunWaitlist(waitlistEvents)
+
+	// Lobby activity indicators: hands played and pots won recently, and who is watching
+	s.activity = NewActivityTracker()
+	activityEvents, _ := s.events.Subscribe()
+	go s.activity.Run(activityEvents)
+	s.observers = NewObservers()
+
+	// Finished hands are kept for replays, and dramatic all-ins get a shareable snippet; the
+	// equity pool queues simulations so all-ins at many tables share a few workers
+	s.snippets = NewSnippetStore()
+	s.equity = NewEquityPool(config.Equity.Workers)
+	s.replays = NewReplayStore(func(tableID, token string) (string, string) {
+		name, _ := s.sessionManager.GetPlayerName(token)
+		if !s.tableAnonymous(tableID) {
+			return name, ""
+		}
+		return name, s.tableAlias(tableID, token)
+	}, func(replay *HandReplay) { go s.considerSnippet(replay) })
+	replayEvents, _ := s.events.Subscribe()
+	go s.replays.Run(replayEvents)
+
+	// Dealer commentary for everyone at the table
+	narratorEvents, _ := s.events.Subscribe()
+	go s.RunNarrator(narratorEvents)
+
+	s.announcements = NewAnnouncementLog()
+	s.emotes = NewEmoteLimiter()
+	s.incidents = NewIncidentLog()
+
+	// Deal statistics and scheduled self-tests watch the shuffler for bias
+	s.rngMonitor = NewRNGMonitor()
+	if config.RNGSelfTest.Interval > 0 {
+		s.rngSelfTestStop = make(chan struct{})
+		go s.runRNGSelfTests(config.RNGSelfTest.Interval, s.rngSelfTestStop)
+	}
+	if len(s.rngSources) > 0 {
+		s.rngHealthStop = make(chan struct{})
+		for name, source := range s.rngSources {
+			go s.runRNGHealthChecks(source, config.RNGSources[name].healthInterval(), s.rngHealthStop)
+		}
+	}
+	if config.HandRetention > 0 {
+		s.retentionStop = make(chan struct{})
+		go s.runHandRetention(handRetentionSweep, s.retentionStop)
+	}
+	if config.TableBreaking.Interval > 0 {
+		s.tableBreakingStop = make(chan struct{})
+		go s.runTableBreaking(config.TableBreaking.Interval, s.tableBreakingStop)
+	}
+	if config.Clubs.Enabled {
+		s.clubSettleStop = make(chan struct{})
+		go s.runClubSettlement(s.clubSettleStop)
+	}
+
+	// Collect expired sessions and free their seats
+	if config.SessionTTL > 0 {
+		s.sweeperStop = make(chan struct{})
+		go s.runSessionSweeper(sessionSweepInterval(config.SessionTTL), s.sweeperStop)
+	}
+
 	return s
 }
 
 // RegisterRoutes sets up all HTTP routes for the server.
 func (s *Server) RegisterRoutes() {
+	// Resolve the real client address (X-Forwarded-For from trusted proxies) for every request
+	s.router.Use(s.clientIPMiddleware)
+	s.router.Use(s.corsMiddleware)
+
 	s.router.Get("/health", HealthCheckHandler(s.logger))
 	s.router.HandleFunc("/ws", s.HandleWebSocket(s.hub))
+	s.router.Get("/api/lobby", s.handleLobbyQuery)
+	s.router.Get("/api/replays/{handID}", s.handleReplay)
+	s.router.Get("/api/replays/{handID}/frames/{step}", s.handleReplayFrame)
+	s.router.Get("/api/snippets/{handID}", s.handleSnippet)
+	s.router.Get("/api/digest", s.handleDigest)
+	s.router.Mount("/admin", s.adminRoutes())
+	s.serveWebUI()
 
 	// Serve static files from web/static directory
 	s.logger.Debug("registering static file routes")
@@ -115,6 +359,11 @@ func (s *Server) serveStaticHandler() http.HandlerFunc {
 		}
 
 		s.logger.Debug("no SPA fallback available")
+		// Without a built frontend, send visitors of the root to the embedded client instead
+		if r.URL.Path == "/" {
+			http.Redirect(w, r, webUIPath, http.StatusFound)
+			return
+		}
 		// No index.html fallback available
 		http.Error(w, "404 page not found", http.StatusNotFound)
 	}
@@ -129,9 +378,10 @@ func (s *Server) Start(addr string) error {
 	}
 	s.mu.Unlock()
 
-	s.logger.Info("starting server", "addr", addr)
+	tlsConfig := s.Config().TLS
+	s.logger.Info("starting server", "addr", addr, "tls", tlsConfig.Enabled())
 
-	err := s.httpServer.ListenAndServe()
+	err := s.listenAndServe(s.httpServer, tlsConfig)
 	if err != nil && err != http.ErrServerClosed {
 		return fmt.Errorf("server error: %w", err)
 	}
@@ -140,13 +390,46 @@ func (s *Server) Start(addr string) error {
 
 // Shutdown gracefully shuts down the HTTP server with the given context.
 func (s *Server) Shutdown(ctx context.Context) error {
-	s.mu.RLock()
+	s.mu.Lock()
 	httpServer := s.httpServer
-	s.mu.RUnlock()
+	diagnosticsServer := s.diagnosticsServer
+	if s.sweeperStop != nil {
+		close(s.sweeperStop)
+		s.sweeperStop = nil
+	}
+	if s.rngSelfTestStop != nil {
+		close(s.rngSelfTestStop)
+		s.rngSelfTestStop = nil
+	}
+	if s.rngHealthStop != nil {
+		close(s.rngHealthStop)
+		s.rngHealthStop = nil
+	}
+	if s.retentionStop != nil {
+		close(s.retentionStop)
+		s.retentionStop = nil
+	}
+	if s.tableBreakingStop != nil {
+		close(s.tableBreakingStop)
+		s.tableBreakingStop = nil
+	}
+	if s.clubSettleStop != nil {
+		close(s.clubSettleStop)
+		s.clubSettleStop = nil
+	}
+	s.mu.Unlock()
+	s.equity.Close()
 
 	if httpServer == nil {
 		return fmt.Errorf("server not running")
 	}
+	defer s.rngAudit.Close()
+
+	if diagnosticsServer != nil {
+		if err := diagnosticsServer.Shutdown(ctx); err != nil {
+			s.logger.Warn("failed to shut down diagnostics listener", "error", err)
+		}
+	}
 
 	return httpServer.Shutdown(ctx)
 }
@@ -162,7 +445,7 @@ func (s *Server) FindPlayerSeat(token *string) *Seat {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	// Search all 4 tables for the token
+	// Search all tables for the token
 	for _, table := range s.tables {
 		if table == nil {
 			continue
@@ -180,23 +463,25 @@ func (s *Server) FindPlayerSeat(token *string) *Seat {
 }
 
 // HandleDisconnect handles client disconnect by clearing their seat if they were seated
+// With a reconnect grace the seat is held for the player to come back first, and a frozen
+// table keeps it. A disconnected player also loses their place on the waitlist, and a host
+// their host seat.
 func (s *Server) HandleDisconnect(token string) error {
-	// Find player's seat
-	playerSeat := s.FindPlayerSeat(&token)
-	if playerSeat == nil {
-		// Player not seated, nothing to do
-		return nil
-	}
+	s.waitlist.Remove(token)
+	s.removeChallenger(token)
+	s.removeHost(token)
+	s.stopWatching(token)
 
 	// Find the table containing the player
 	var table *Table
+	var playerSeat Seat
 	s.mu.RLock()
 	for _, t := range s.tables {
 		if t != nil {
 			seat, found := t.GetSeatByToken(&token)
 			if found {
 				table = t
-				playerSeat = &seat
+				playerSeat = seat
 				break
 			}
 		}
@@ -204,14 +489,34 @@ func (s *Server) HandleDisconnect(token string) error {
 	s.mu.RUnlock()
 
 	if table == nil {
+		// Player not seated, nothing to do
+		return nil
+	}
+	if table.Frozen() {
+		s.logger.Info("seat kept on disconnect while table is frozen", "token", token, "tableId", table.ID)
+		s.keepDisconnectedSeat(table, token, playerSeat.Index, 0)
+		return nil
+	}
+	if grace := s.Config().ReconnectGrace; grace > 0 {
+		s.logger.Info("seat held for reconnect", "token", token, "tableId", table.ID, "grace", grace)
+		s.keepDisconnectedSeat(table, token, playerSeat.Index, grace)
 		return nil
 	}
 
+	s.broadcastConnectionStatus(table, token, playerSeat.Index, ConnectionDisconnected, nil)
+	s.clearDisconnectedSeat(table, token)
+	s.logger.Info("player disconnected and seat cleared", "token", token, "tableId", table.ID)
+	return nil
+}
+
+// clearDisconnectedSeat clears the seat of a player whose connection is gone and tells the
+// table and the lobby
+func (s *Server) clearDisconnectedSeat(table *Table, token string) {
 	// Clear the seat
 	err := table.ClearSeat(&token)
 	if err != nil {
 		s.logger.Warn("failed to clear seat on disconnect", "token", token, "error", err)
-		return nil // Don't error on disconnect, just log
+		return // Don't error on disconnect, just log
 	}
 
 	// Update session to clear TableID and SeatIndex
@@ -221,8 +526,7 @@ func (s *Server) HandleDisconnect(token string) error {
 	}
 
 	// Broadcast table_state to remaining players at the table BEFORE broadcasting lobby_state
-	tableID := table.ID
-	err = s.broadcastTableState(tableID, nil)
+	err = s.broadcastTableState(table.ID, nil)
 	if err != nil {
 		s.logger.Warn("failed to broadcast table_state on disconnect", "error", err)
 	}
@@ -232,13 +536,23 @@ func (s *Server) HandleDisconnect(token string) error {
 	if err != nil {
 		s.logger.Warn("failed to broadcast lobby state on disconnect", "error", err)
 	}
+}
 
-	s.logger.Info("player disconnected and seat cleared", "token", token, "tableId", table.ID)
+// tableByID returns the table with the given ID, or nil
+func (s *Server) tableByID(tableID string) *Table {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
+	for _, table := range s.tables {
+		if table != nil && table.ID == tableID {
+			return table
+		}
+	}
 	return nil
 }
 
-// GetClientsAtTable returns all clients currently at a specific table (thread-safe)
+// GetClientsAtTable returns all clients currently at a specific table, seated players first,
+// then observers (thread-safe)
 func (s *Server) GetClientsAtTable(tableID string) []*Client {
 	var clients []*Client
 
@@ -275,6 +589,20 @@ func (s *Server) GetClientsAtTable(tableID string) []*Client {
 		}
 	}
 
+	// Observers and the host see everything the table sees except hole cards, which are sent
+	// privately
+	observers := s.observers.Tokens(tableID)
+	if table.host != nil {
+		observers = append(observers, *table.host)
+	}
+	s.hub.mu.RLock()
+	for _, token := range observers {
+		if client, ok := s.hub.sessions[token]; ok {
+			clients = append(clients, client)
+		}
+	}
+	s.hub.mu.RUnlock()
+
 	return clients
 }
 
@@ -297,15 +625,35 @@ func (s *Server) BroadcastActionRequest(tableID string, seatIndex int, validActi
 		return fmt.Errorf("table not found: %s", tableID)
 	}
 
-	// Calculate minRaise and maxRaise
+	// Work out the legal amounts and put the actor on the clock
+	playerBet := 0
 	minRaise := 0
 	maxRaise := 0
-	table.mu.RLock()
-	if table.CurrentHand != nil {
-		minRaise = table.CurrentHand.GetMinRaise()
-		maxRaise = table.GetMaxRaise(seatIndex, table.CurrentHand)
+	raiseStep := 1
+	var actionDeadline, timeBank int64
+	table.mu.Lock()
+	if hand := table.CurrentHand; hand != nil {
+		stack := table.Seats[seatIndex].Stack
+		// A call the stack cannot cover puts the player all-in for what they have
+		callAmount = min(callAmount, stack)
+		playerBet = hand.PlayerBets[seatIndex]
+		// Both bounds are 0 when the player cannot raise
+		minRaise, maxRaise, _ = hand.RaiseRange(seatIndex, stack)
+		raiseStep = hand.RaiseStep()
+		table.startActionClockLocked(seatIndex)
+		if table.ActionDeadline != nil {
+			actionDeadline = table.ActionDeadline.UnixMilli()
+			if token := table.Seats[seatIndex].Token; token != nil {
+				timeBank = table.timeBankLeftLocked(*token).Milliseconds()
+			}
+		}
 	}
-	table.mu.RUnlock()
+	preAction, queued := table.takePreActionLocked(seatIndex)
+	table.actionRequestedAt = time.Time{}
+	if !queued {
+		table.actionRequestedAt = table.clock().Now()
+	}
+	table.mu.Unlock()
 
 	// Create the action request payload
 	payload := ActionRequestPayload{
@@ -313,10 +661,13 @@ func (s *Server) BroadcastActionRequest(tableID string, seatIndex int, validActi
 		ValidActions: validActions,
 		CallAmount:   callAmount,
 		CurrentBet:   currentBet,
-		PlayerBet:    currentBet,
+		PlayerBet:    playerBet,
 		Pot:          pot,
 		MinRaise:     minRaise,
 		MaxRaise:     maxRaise,
+		RaiseStep:    raiseStep,
+		Deadline:     actionDeadline,
+		TimeBankMs:   timeBank,
 	}
 
 	// Marshal the payload to JSON
@@ -327,8 +678,9 @@ func (s *Server) BroadcastActionRequest(tableID string, seatIndex int, validActi
 
 	// Create the WebSocket message
 	msg := WebSocketMessage{
-		Type:    "action_request",
-		Payload: payloadBytes,
+		Type:       "action_request",
+		Payload:    payloadBytes,
+		ServerTime: serverTimeMillis(),
 	}
 
 	// Marshal the message
@@ -351,6 +703,13 @@ func (s *Server) BroadcastActionRequest(tableID string, seatIndex int, validActi
 		}
 	}
 
+	// A queued pre-action answers the request as soon as it has gone out
+	if queued {
+		go s.applyPreAction(table, seatIndex, preAction)
+	} else {
+		s.sendPreflopHint(table, seatIndex, validActions)
+	}
+
 	return nil
 }
 
@@ -369,6 +728,11 @@ func (s *Server) BroadcastActionResult(tableID string, seatIndex int, action str
 		RoundWinner: roundWinner,
 	}
 
+	return s.broadcastActionResultPayload(tableID, payload)
+}
+
+// broadcastActionResultPayload sends a prebuilt action_result payload to all clients at a specific table
+func (s *Server) broadcastActionResultPayload(tableID string, payload ActionResultPayload) error {
 	// Marshal the payload to JSON
 	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
@@ -377,8 +741,9 @@ func (s *Server) BroadcastActionResult(tableID string, seatIndex int, action str
 
 	// Create the WebSocket message
 	msg := WebSocketMessage{
-		Type:    "action_result",
-		Payload: payloadBytes,
+		Type:       "action_result",
+		Payload:    payloadBytes,
+		ServerTime: serverTimeMillis(),
 	}
 
 	// Marshal the message
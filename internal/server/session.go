@@ -1,8 +1,8 @@
 package server
 
 import (
-	"fmt"
 	"log/slog"
+	"maps"
 	"regexp"
 	"strings"
 	"sync"
@@ -18,6 +18,15 @@ type Session struct {
 	TableID   *string
 	SeatIndex *int
 	CreatedAt time.Time
+	ExpiresAt time.Time            // When the session lapses unless renewed (zero = never)
+	Balances  map[ChipCurrency]int // Chips held off the tables, per currency (see AdjustBalance)
+	AutoMuck  bool                 // Muck losing hands at showdown instead of showing them
+	// PreflopHints sends the player preflop_hint messages on their turn at practice tables
+	PreflopHints bool
+	Language     string // Language tag narration is worded in (empty = English)
+	// RecoveryCode is the SHA-256 of the code that moves the session's seat to a new session
+	// (see RecoverSeat); empty until the seated player asks for one
+	RecoveryCode string
 }
 
 // SessionManager manages player sessions with thread-safe operations
@@ -25,16 +34,37 @@ type SessionManager struct {
 	sessions map[string]*Session
 	mutex    sync.RWMutex
 	logger   *slog.Logger
+	ttl      time.Duration // Session lifetime from creation or last renewal (0 = sessions never expire)
 }
 
-// NewSessionManager creates and returns a new SessionManager instance
+// NewSessionManager creates and returns a new SessionManager whose sessions never expire
 func NewSessionManager(logger *slog.Logger) *SessionManager {
+	return NewSessionManagerWithTTL(logger, 0)
+}
+
+// NewSessionManagerWithTTL creates a SessionManager whose sessions expire ttl after
+// creation or their last renewal; a ttl of 0 disables expiry
+func NewSessionManagerWithTTL(logger *slog.Logger, ttl time.Duration) *SessionManager {
 	return &SessionManager{
 		sessions: make(map[string]*Session),
 		logger:   logger,
+		ttl:      ttl,
 	}
 }
 
+// expiresAt returns the expiry for a session created or renewed at now
+func (sm *SessionManager) expiresAt(now time.Time) time.Time {
+	if sm.ttl <= 0 {
+		return time.Time{}
+	}
+	return now.Add(sm.ttl)
+}
+
+// isExpired reports whether session has lapsed at now
+func isExpired(session *Session, now time.Time) bool {
+	return !session.ExpiresAt.IsZero() && !now.Before(session.ExpiresAt)
+}
+
 // nameValidationRegex matches names with 1-20 alphanumeric characters, spaces, dashes, or underscores
 var nameValidationRegex = regexp.MustCompile(`^[a-zA-Z0-9 _-]{1,20}$`)
 
@@ -42,13 +72,13 @@ var nameValidationRegex = regexp.MustCompile(`^[a-zA-Z0-9 _-]{1,20}$`)
 func validateName(name string) error {
 	trimmed := strings.TrimSpace(name)
 	if trimmed == "" {
-		return fmt.Errorf("name cannot be empty")
+		return newMessageError("error.name_empty", nil)
 	}
 	if len(trimmed) > 20 {
-		return fmt.Errorf("name cannot exceed 20 characters")
+		return newMessageError("error.name_too_long", map[string]any{"max": 20})
 	}
 	if !nameValidationRegex.MatchString(trimmed) {
-		return fmt.Errorf("name can only contain alphanumeric characters, spaces, dashes, and underscores")
+		return newMessageError("error.name_invalid_characters", nil)
 	}
 	return nil
 }
@@ -68,12 +98,15 @@ func (sm *SessionManager) CreateSession(name string) (*Session, error) {
 	token := uuid.New().String()
 
 	// Create session
+	now := time.Now()
 	session := &Session{
 		Token:     token,
 		Name:      trimmedName,
 		TableID:   nil,
 		SeatIndex: nil,
-		CreatedAt: time.Now(),
+		CreatedAt: now,
+		ExpiresAt: sm.expiresAt(now),
+		Balances:  make(map[ChipCurrency]int),
 	}
 
 	// Store session in map (thread-safe)
@@ -85,17 +118,57 @@ func (sm *SessionManager) CreateSession(name string) (*Session, error) {
 	return session, nil
 }
 
-// GetSession retrieves a session by token
+// GetSession retrieves a copy of the session with token, taken under the lock so callers can read
+// it on any goroutine while the session's placement and balances change
 func (sm *SessionManager) GetSession(token string) (*Session, error) {
 	sm.mutex.RLock()
 	defer sm.mutex.RUnlock()
 
 	session, ok := sm.sessions[token]
 	if !ok {
-		return nil, fmt.Errorf("session not found: %s", token)
+		return nil, newMessageError("error.session_not_found", map[string]any{"token": token})
+	}
+	if isExpired(session, time.Now()) {
+		return nil, newMessageError("error.session_expired", map[string]any{"token": token})
 	}
 
-	return session, nil
+	snapshot := *session
+	snapshot.Balances = maps.Clone(session.Balances)
+	return &snapshot, nil
+}
+
+// RenewSession extends a live session by the manager's TTL and returns the new expiry
+// Expired sessions cannot be renewed; the player must create a new session
+func (sm *SessionManager) RenewSession(token string) (time.Time, error) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	session, ok := sm.sessions[token]
+	if !ok {
+		return time.Time{}, newMessageError("error.session_not_found", map[string]any{"token": token})
+	}
+	now := time.Now()
+	if isExpired(session, now) {
+		return time.Time{}, newMessageError("error.session_expired", map[string]any{"token": token})
+	}
+
+	session.ExpiresAt = sm.expiresAt(now)
+	sm.logger.Debug("session renewed", "token", token, "expiresAt", session.ExpiresAt)
+	return session.ExpiresAt, nil
+}
+
+// ExpiredSessions returns the tokens of all sessions that have lapsed at now
+func (sm *SessionManager) ExpiredSessions(now time.Time) []string {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	var tokens []string
+	for token, session := range sm.sessions {
+		if isExpired(session, now) {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
 }
 
 // UpdateSession updates a session's table and seat information
@@ -105,7 +178,7 @@ func (sm *SessionManager) UpdateSession(token string, tableID *string, seatIndex
 
 	session, ok := sm.sessions[token]
 	if !ok {
-		return nil, fmt.Errorf("session not found: %s", token)
+		return nil, newMessageError("error.session_not_found", map[string]any{"token": token})
 	}
 
 	session.TableID = tableID
@@ -122,7 +195,7 @@ func (sm *SessionManager) RemoveSession(token string) error {
 
 	_, ok := sm.sessions[token]
 	if !ok {
-		return fmt.Errorf("session not found: %s", token)
+		return newMessageError("error.session_not_found", map[string]any{"token": token})
 	}
 
 	delete(sm.sessions, token)
@@ -137,8 +210,143 @@ func (sm *SessionManager) GetPlayerName(token string) (string, error) {
 
 	session, ok := sm.sessions[token]
 	if !ok {
-		return "", fmt.Errorf("session not found: %s", token)
+		return "", newMessageError("error.session_not_found", map[string]any{"token": token})
 	}
 
 	return session.Name, nil
 }
+
+// SetAutoMuck sets whether the session's losing hands are mucked at showdown
+func (sm *SessionManager) SetAutoMuck(token string, autoMuck bool) error {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	session, ok := sm.sessions[token]
+	if !ok {
+		return newMessageError("error.session_not_found", map[string]any{"token": token})
+	}
+	session.AutoMuck = autoMuck
+	return nil
+}
+
+// AutoMuck reports whether the session mucks losing hands at showdown
+func (sm *SessionManager) AutoMuck(token string) bool {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	session, ok := sm.sessions[token]
+	return ok && session.AutoMuck
+}
+
+// SetLanguage sets the language the session's narration is worded in
+func (sm *SessionManager) SetLanguage(token, language string) error {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	session, ok := sm.sessions[token]
+	if !ok {
+		return newMessageError("error.session_not_found", map[string]any{"token": token})
+	}
+	session.Language = language
+	return nil
+}
+
+// Language returns the language the session's narration is worded in, empty for English
+func (sm *SessionManager) Language(token string) string {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	if session, ok := sm.sessions[token]; ok {
+		return session.Language
+	}
+	return ""
+}
+
+// SetPreflopHints sets whether the session gets preflop hints at practice tables
+func (sm *SessionManager) SetPreflopHints(token string, enabled bool) error {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	session, ok := sm.sessions[token]
+	if !ok {
+		return newMessageError("error.session_not_found", map[string]any{"token": token})
+	}
+	session.PreflopHints = enabled
+	return nil
+}
+
+// PreflopHints reports whether the session gets preflop hints at practice tables
+func (sm *SessionManager) PreflopHints(token string) bool {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	session, ok := sm.sessions[token]
+	return ok && session.PreflopHints
+}
+
+// AdjustBalance adds delta (negative to debit) to the session's balance in currency
+// Returns the new balance, or errInsufficientBalance and no change if it would go negative
+func (sm *SessionManager) AdjustBalance(token string, currency ChipCurrency, delta int) (int, error) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	session, ok := sm.sessions[token]
+	if !ok {
+		return 0, newMessageError("error.session_not_found", map[string]any{"token": token})
+	}
+	if session.Balances == nil {
+		session.Balances = make(map[ChipCurrency]int)
+	}
+
+	balance := session.Balances[currency] + delta
+	if balance < 0 {
+		return session.Balances[currency], errInsufficientBalance
+	}
+	session.Balances[currency] = balance
+	return balance, nil
+}
+
+// Balances returns a copy of the session's balances
+func (sm *SessionManager) Balances(token string) (map[ChipCurrency]int, error) {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	session, ok := sm.sessions[token]
+	if !ok {
+		return nil, newMessageError("error.session_not_found", map[string]any{"token": token})
+	}
+	balances := make(map[ChipCurrency]int, len(session.Balances))
+	for currency, balance := range session.Balances {
+		balances[currency] = balance
+	}
+	return balances, nil
+}
+
+// TotalBalances sums the balances of all sessions per currency
+func (sm *SessionManager) TotalBalances() map[ChipCurrency]int {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	totals := make(map[ChipCurrency]int)
+	for _, session := range sm.sessions {
+		for currency, balance := range session.Balances {
+			totals[currency] += balance
+		}
+	}
+	return totals
+}
+
+// TokensByName returns the tokens of the live sessions named name (case-insensitive)
+func (sm *SessionManager) TokensByName(name string) []string {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	var tokens []string
+	now := time.Now()
+	for token, session := range sm.sessions {
+		if strings.EqualFold(session.Name, strings.TrimSpace(name)) && !isExpired(session, now) {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,248 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// SessionRenewedPayload represents the payload for session_renewed messages
+type SessionRenewedPayload struct {
+	ExpiresAt int64 `json:"expiresAt,omitempty"` // Unix ms when the session lapses (omitted = never)
+}
+
+// SessionEndedPayload represents the payload for logged_out, session_expired and session_taken_over messages
+type SessionEndedPayload struct {
+	Reason string `json:"reason"` // "logout", "expired" or "taken_over"
+}
+
+// expiresAtMillis converts a session expiry to the protocol's Unix ms, 0 for sessions that never expire
+func expiresAtMillis(expiresAt time.Time) int64 {
+	if expiresAt.IsZero() {
+		return 0
+	}
+	return expiresAt.UnixMilli()
+}
+
+// HandleRenewSession extends the client's session and replies with the new expiry
+func (c *Client) HandleRenewSession(sm *SessionManager, logger *slog.Logger) error {
+	expiresAt, err := sm.RenewSession(c.Token)
+	if err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+
+	logger.Info("session renewed via websocket", "token", c.Token)
+	return c.sendMessage("session_renewed", SessionRenewedPayload{ExpiresAt: expiresAtMillis(expiresAt)})
+}
+
+// HandleLogout ends the client's session: the player is unseated (folding any live hand),
+// the session is removed, and the connection stays open without a session
+func (c *Client) HandleLogout(sm *SessionManager, server *Server, logger *slog.Logger) error {
+	if _, err := sm.GetSession(c.Token); err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+
+	token := c.Token
+	server.waitlist.Remove(token)
+	server.stopWatching(token)
+	server.unseatPlayer(token, "logout")
+
+	if err := sm.RemoveSession(token); err != nil {
+		logger.Warn("failed to remove session on logout", "token", token, "error", err)
+	}
+	server.forgetSession(token)
+	c.setToken("")
+
+	logger.Info("player logged out", "token", token)
+
+	if err := c.sendMessage("logged_out", SessionEndedPayload{Reason: "logout"}); err != nil {
+		return err
+	}
+	return c.SendLobbyState(server, logger)
+}
+
+// sendMessage marshals payload into a message of msgType and queues it for the client
+func (c *Client) sendMessage(msgType string, payload interface{}) error {
+	responseBytes, err := marshalMessage(msgType, payload)
+	if err != nil {
+		return err
+	}
+
+	c.send <- responseBytes
+	return nil
+}
+
+// marshalMessage encodes payload as a WebSocketMessage of msgType stamped with the server time
+func marshalMessage(msgType string, payload interface{}) ([]byte, error) {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	response := WebSocketMessage{
+		Type:       msgType,
+		Payload:    json.RawMessage(payloadBytes),
+		ServerTime: serverTimeMillis(),
+	}
+
+	responseBytes, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+	return responseBytes, nil
+}
+
+// unseatPlayer removes the player with token from their table, folding their hand first
+// if one is in progress, then updates the session and notifies the table and lobby
+// Does nothing if the player is not seated. reason is used for logging only.
+func (s *Server) unseatPlayer(token string, reason string) {
+	var table *Table
+	var seatIndex int
+	s.mu.RLock()
+	for _, t := range s.tables {
+		if t == nil {
+			continue
+		}
+		if seat, found := t.GetSeatByToken(&token); found {
+			table = t
+			seatIndex = seat.Index
+			break
+		}
+	}
+	s.mu.RUnlock()
+
+	if table == nil {
+		return
+	}
+	if table.Frozen() {
+		s.logger.Info("seat kept while table is frozen", "token", token, "tableId", table.ID, "reason", reason)
+		return
+	}
+
+	if err := table.ClearSeat(&token); err != nil {
+		s.logger.Warn("failed to clear seat", "token", token, "reason", reason, "error", err)
+		return
+	}
+	if _, err := s.sessionManager.UpdateSession(token, nil, nil); err != nil {
+		s.logger.Debug("session not updated after unseat", "token", token, "error", err)
+	}
+
+	if err := s.broadcastTableState(table.ID, nil); err != nil {
+		s.logger.Warn("failed to broadcast table_state after unseat", "error", err)
+	}
+	if err := s.broadcastLobbyState(); err != nil {
+		s.logger.Warn("failed to broadcast lobby state after unseat", "error", err)
+	}
+
+	s.logger.Info("player unseated", "token", token, "tableId", table.ID, "seatIndex", seatIndex, "reason", reason)
+}
+
+// foldDepartingPlayer folds seatIndex out of the current hand so the seat can be cleared
+// without leaving the hand stuck. The current actor folds through the normal action path;
+// anyone else is folded out of turn, and the pot is awarded if only one player remains.
+func (s *Server) foldDepartingPlayer(table *Table, seatIndex int) {
+	table.mu.RLock()
+	hand := table.CurrentHand
+	inHand := hand != nil && table.Seats[seatIndex].Status == "active" && !hand.FoldedPlayers[seatIndex]
+	isActor := inHand && hand.CurrentActor != nil && *hand.CurrentActor == seatIndex
+	table.mu.RUnlock()
+
+	if !inHand {
+		return
+	}
+
+	if isActor {
+		err := s.processTableAction(context.Background(), table, nil, "", seatIndex, "fold")
+		if err == nil {
+			return
+		}
+		// The action moved on in the meantime; fall through to an out-of-turn fold
+		s.logger.Debug("departing player no longer current actor", "tableID", table.ID, "seatIndex", seatIndex, "error", err)
+	}
+
+	table.mu.Lock()
+	hand = table.CurrentHand
+	if hand == nil || hand.FoldedPlayers[seatIndex] {
+		table.mu.Unlock()
+		return
+	}
+	hand.FoldedPlayers[seatIndex] = true
+	remaining := 0
+	for i := 0; i < 6; i++ {
+		if table.Seats[i].Status == "active" && !hand.FoldedPlayers[i] {
+			remaining++
+		}
+	}
+	result := ActionResultPayload{
+		SeatIndex: seatIndex,
+		Action:    "fold",
+		NewStack:  table.Seats[seatIndex].Stack,
+		Pot:       hand.Pot,
+		NextActor: hand.CurrentActor,
+		RoundOver: remaining <= 1,
+	}
+	table.mu.Unlock()
+
+	if err := s.broadcastActionResultPayload(table.ID, result); err != nil {
+		s.logger.Warn("failed to broadcast departing fold", "error", err)
+	}
+
+	if remaining <= 1 {
+		table.HandleShowdown()
+	}
+}
+
+// sessionSweepInterval returns how often expired sessions are collected for ttl
+func sessionSweepInterval(ttl time.Duration) time.Duration {
+	interval := ttl / 4
+	if interval < time.Second {
+		interval = time.Second
+	}
+	if interval > time.Minute {
+		interval = time.Minute
+	}
+	return interval
+}
+
+// runSessionSweeper removes expired sessions every interval until stop is closed
+func (s *Server) runSessionSweeper(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			s.sweepExpiredSessions(now)
+		}
+	}
+}
+
+// sweepExpiredSessions unseats and removes every session that has lapsed at now,
+// telling any client still connected with it
+func (s *Server) sweepExpiredSessions(now time.Time) {
+	for _, token := range s.sessionManager.ExpiredSessions(now) {
+		s.stopWatching(token)
+		s.unseatPlayer(token, "expired")
+
+		if err := s.sessionManager.RemoveSession(token); err != nil {
+			continue
+		}
+		s.forgetSession(token)
+
+		s.hub.mu.RLock()
+		for client := range s.hub.clients {
+			if client.Token == token {
+				if err := client.sendMessage("session_expired", SessionEndedPayload{Reason: "expired"}); err != nil {
+					s.logger.Warn("failed to send session_expired", "error", err)
+				}
+			}
+		}
+		s.hub.mu.RUnlock()
+
+		s.logger.Info("session expired", "token", token)
+	}
+}
@@ -0,0 +1,218 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// seatSessionPlayers creates sessions for two players and seats them at table
+func seatSessionPlayers(t *testing.T, server *Server, table *Table) (string, string) {
+	t.Helper()
+	alice, err := server.sessionManager.CreateSession("Alice")
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	bob, err := server.sessionManager.CreateSession("Bob")
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	for _, token := range []string{alice.Token, bob.Token} {
+		seat, err := table.AssignSeat(&token)
+		if err != nil {
+			t.Fatalf("AssignSeat failed: %v", err)
+		}
+		tableID := table.ID
+		seatIndex := seat.Index
+		server.sessionManager.UpdateSession(token, &tableID, &seatIndex)
+	}
+	return alice.Token, bob.Token
+}
+
+// drainMessageTypes returns the types of all messages queued for client
+func drainMessageTypes(t *testing.T, client *Client) []string {
+	t.Helper()
+	var types []string
+	for {
+		select {
+		case msg := <-client.send:
+			var wsMsg WebSocketMessage
+			if err := json.Unmarshal(msg, &wsMsg); err != nil {
+				t.Fatalf("failed to unmarshal message: %v", err)
+			}
+			types = append(types, wsMsg.Type)
+		default:
+			return types
+		}
+	}
+}
+
+// containsType reports whether types includes msgType
+func containsType(types []string, msgType string) bool {
+	for _, got := range types {
+		if got == msgType {
+			return true
+		}
+	}
+	return false
+}
+
+// TestHandleLogout_UnseatsAndRemovesSession verifies logout frees the seat and ends the session
+func TestHandleLogout_UnseatsAndRemovesSession(t *testing.T) {
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+	alice, _ := seatSessionPlayers(t, server, table)
+
+	client := &Client{hub: server.hub, Token: alice, send: make(chan []byte, 256)}
+	if err := client.HandleLogout(server.sessionManager, server, slog.Default()); err != nil {
+		t.Fatalf("HandleLogout failed: %v", err)
+	}
+
+	if client.Token != "" {
+		t.Errorf("expected client token cleared, got %q", client.Token)
+	}
+	if _, err := server.sessionManager.GetSession(alice); err == nil {
+		t.Error("expected session to be removed")
+	}
+	if _, found := table.GetSeatByToken(&alice); found {
+		t.Error("expected player to be unseated")
+	}
+	if types := drainMessageTypes(t, client); !containsType(types, "logged_out") {
+		t.Errorf("expected logged_out message, got %v", types)
+	}
+
+	if err := client.HandleLogout(server.sessionManager, server, slog.Default()); err == nil {
+		t.Error("expected second logout to fail without a session")
+	}
+}
+
+// TestHandleLogout_FoldsLiveHand verifies logging out mid-hand folds the player and
+// awards the pot rather than leaving the hand stuck, whether or not it is their turn
+func TestHandleLogout_FoldsLiveHand(t *testing.T) {
+	for _, leaverIsActor := range []bool{true, false} {
+		server := NewServer(slog.Default())
+		table := server.tables[0]
+		alice, bob := seatSessionPlayers(t, server, table)
+
+		if err := table.StartHand(); err != nil {
+			t.Fatalf("StartHand failed: %v", err)
+		}
+
+		table.mu.RLock()
+		actor := *table.CurrentHand.CurrentActor
+		table.mu.RUnlock()
+
+		leaver, stayer := alice, bob
+		if (actor == 0) != leaverIsActor {
+			leaver, stayer = bob, alice
+		}
+		stayerSeat, _ := table.GetSeatByToken(&stayer)
+
+		client := &Client{hub: server.hub, Token: leaver, send: make(chan []byte, 256)}
+		if err := client.HandleLogout(server.sessionManager, server, slog.Default()); err != nil {
+			t.Fatalf("HandleLogout failed: %v", err)
+		}
+
+		table.mu.RLock()
+		hand := table.CurrentHand
+		stack := table.Seats[stayerSeat.Index].Stack
+		table.mu.RUnlock()
+
+		if hand != nil {
+			t.Errorf("leaverIsActor=%v: expected hand to be over", leaverIsActor)
+		}
+		// The remaining player wins the blinds: 1000 - own blind + both blinds
+		if stack <= 1000 {
+			t.Errorf("leaverIsActor=%v: expected remaining player to win the pot, stack %d", leaverIsActor, stack)
+		}
+		if _, found := table.GetSeatByToken(&leaver); found {
+			t.Errorf("leaverIsActor=%v: expected leaver to be unseated", leaverIsActor)
+		}
+	}
+}
+
+// TestHandleRenewSession_RepliesWithExpiry verifies renew_session answers with the new expiry
+func TestHandleRenewSession_RepliesWithExpiry(t *testing.T) {
+	sm := NewSessionManagerWithTTL(slog.Default(), time.Hour)
+	session, _ := sm.CreateSession("Alice")
+	client := &Client{Token: session.Token, send: make(chan []byte, 1)}
+
+	before := time.Now().Add(time.Hour).UnixMilli()
+	if err := client.HandleRenewSession(sm, slog.Default()); err != nil {
+		t.Fatalf("HandleRenewSession failed: %v", err)
+	}
+
+	var wsMsg WebSocketMessage
+	if err := json.Unmarshal(<-client.send, &wsMsg); err != nil {
+		t.Fatalf("failed to unmarshal message: %v", err)
+	}
+	if wsMsg.Type != "session_renewed" {
+		t.Fatalf("expected session_renewed, got %q", wsMsg.Type)
+	}
+	var payload SessionRenewedPayload
+	if err := json.Unmarshal(wsMsg.Payload, &payload); err != nil {
+		t.Fatalf("failed to unmarshal payload: %v", err)
+	}
+	if payload.ExpiresAt < before {
+		t.Errorf("expected expiresAt >= %d, got %d", before, payload.ExpiresAt)
+	}
+
+	unknown := &Client{Token: "unknown", send: make(chan []byte, 1)}
+	if err := unknown.HandleRenewSession(sm, slog.Default()); err == nil {
+		t.Error("expected error for unknown session")
+	}
+}
+
+// TestSweepExpiredSessions verifies the sweeper unseats and removes lapsed sessions and notifies their clients
+func TestSweepExpiredSessions(t *testing.T) {
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+	alice, bob := seatSessionPlayers(t, server, table)
+
+	client := &Client{hub: server.hub, Token: alice, send: make(chan []byte, 256)}
+	server.hub.mu.Lock()
+	server.hub.clients[client] = true
+	server.hub.mu.Unlock()
+	defer func() {
+		server.hub.mu.Lock()
+		delete(server.hub.clients, client)
+		server.hub.mu.Unlock()
+	}()
+
+	server.sessionManager.mutex.Lock()
+	server.sessionManager.sessions[alice].ExpiresAt = time.Now().Add(-time.Second)
+	server.sessionManager.mutex.Unlock()
+
+	server.sweepExpiredSessions(time.Now())
+
+	if _, found := table.GetSeatByToken(&alice); found {
+		t.Error("expected expired player to be unseated")
+	}
+	if _, found := table.GetSeatByToken(&bob); !found {
+		t.Error("expected live player to stay seated")
+	}
+	if tokens := server.sessionManager.ExpiredSessions(time.Now()); len(tokens) != 0 {
+		t.Errorf("expected expired session removed, still have %v", tokens)
+	}
+	if types := drainMessageTypes(t, client); !containsType(types, "session_expired") {
+		t.Errorf("expected session_expired message, got %v", types)
+	}
+}
+
+// TestSessionSweepInterval verifies the sweep interval is clamped to [1s, 1m]
+func TestSessionSweepInterval(t *testing.T) {
+	tests := []struct {
+		ttl  time.Duration
+		want time.Duration
+	}{
+		{ttl: time.Second, want: time.Second},
+		{ttl: 2 * time.Minute, want: 30 * time.Second},
+		{ttl: 24 * time.Hour, want: time.Minute},
+	}
+	for _, tt := range tests {
+		if got := sessionSweepInterval(tt.ttl); got != tt.want {
+			t.Errorf("sessionSweepInterval(%v) = %v, want %v", tt.ttl, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package server
+
+import (
+	"cmp"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// sessionHistoryLength is how many table sessions each account keeps
+const sessionHistoryLength = 100
+
+// SessionSummary is the payload of session_summary messages: one stay at a table, from taking
+// a seat to standing up, busting or disconnecting. It is also what account histories keep.
+type SessionSummary struct {
+	TableID     string `json:"tableId"`
+	SeatedAt    int64  `json:"seatedAt"` // Unix ms
+	LeftAt      int64  `json:"leftAt"`   // Unix ms
+	DurationMs  int64  `json:"durationMs"`
+	HandsPlayed int    `json:"handsPlayed"` // Hands dealt to the player
+	NetChips    int    `json:"netChips"`    // Chips won less chips put in; negative when down
+	BiggestPot  int    `json:"biggestPot"`  // Most chips won in one hand, after rake
+	Rake        int    `json:"rake"`        // Rake charged in proportion to the chips put into each raked pot
+}
+
+// RecordSession appends summary to the named account's session history, dropping the oldest
+// sessions beyond sessionHistoryLength
+func (s *AccountStore) RecordSession(name string, summary SessionSummary) error {
+	return s.update(name, func(account *Account) error {
+		account.Sessions = append(account.Sessions, summary)
+		if len(account.Sessions) > sessionHistoryLength {
+			account.Sessions = account.Sessions[len(account.Sessions)-sessionHistoryLength:]
+		}
+		return nil
+	})
+}
+
+// SessionHistory returns the named account's table sessions, newest first
+func (s *AccountStore) SessionHistory(name string) []SessionSummary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sessions := s.accounts[accountKey(name)].Sessions
+	history := make([]SessionSummary, len(sessions))
+	for i, session := range sessions {
+		history[len(history)-1-i] = session
+	}
+	return history
+}
+
+// rakeLedgerDays is how many days of rake totals each account keeps, counting the latest
+const rakeLedgerDays = 400
+
+// RakeDay is the rake charged to a player over one UTC day, in their account's rake ledger
+type RakeDay struct {
+	Day   int64 `json:"day"`   // Unix ms of the day's start
+	Hands int   `json:"hands"` // Hands the player was charged rake in
+	Rake  int   `json:"rake"`
+}
+
+// startOfDay returns the start of t's UTC day
+func startOfDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// RecordRake adds the rake each named player was charged in a hand that ended at `at` to that
+// day's total in their ledger, dropping days more than rakeLedgerDays old, and credits them the
+// loyalty points the rake earned. The players of the hand are saved together.
+func (s *AccountStore) RecordRake(at time.Time, rake, points map[string]int) error {
+	day := startOfDay(at).UnixMilli()
+	oldest := startOfDay(at).AddDate(0, 0, 1-rakeLedgerDays).UnixMilli()
+	return s.updateAll(slices.Collect(maps.Keys(rake)), func(name string, account *Account) error {
+		i, found := slices.BinarySearchFunc(account.RakeLedger, day, func(d RakeDay, day int64) int {
+			return cmp.Compare(d.Day, day)
+		})
+		if !found {
+			account.RakeLedger = slices.Insert(account.RakeLedger, i, RakeDay{Day: day})
+		}
+		account.RakeLedger[i].Hands++
+		account.RakeLedger[i].Rake += rake[name]
+		account.RakeLedger = slices.DeleteFunc(account.RakeLedger, func(d RakeDay) bool { return d.Day < oldest })
+		account.LoyaltyPoints += points[name]
+		return nil
+	})
+}
+
+// RunRakeLedger records the rake charged to each player in every hand until the channel is
+// closed, with the loyalty points it earns at tables of the loyalty currency, in one account
+// update per hand. Players are named when the hand starts, so one who leaves before it ends is
+// still charged their share.
+func (s *Server) RunRakeLedger(events <-chan Event) {
+	type dealtHand struct {
+		currency ChipCurrency // Empty for a table that is gone
+		names    map[int]string
+	}
+	hands := make(map[string]dealtHand) // By table ID
+	for e := range events {
+		switch e.Type {
+		case EventHandStarted:
+			hand := dealtHand{names: make(map[int]string, len(e.Seats))}
+			if table := s.tableByID(e.TableID); table != nil {
+				hand.currency = table.Currency
+			}
+			for seat, token := range e.Seats {
+				if name, err := s.sessionManager.GetPlayerName(token); err == nil && name != "" {
+					hand.names[seat] = name
+				}
+			}
+			hands[e.TableID] = hand
+		case EventHandEnded:
+			hand := hands[e.TableID]
+			delete(hands, e.TableID)
+			cfg := s.Config().Loyalty
+			loyal := cfg.PointsPerRake > 0 && hand.currency == cfg.currency()
+			charged := make(map[string]int, len(e.Rake))
+			points := make(map[string]int, len(e.Rake))
+			for seat, rake := range e.Rake {
+				name := accountKey(hand.names[seat])
+				if name == "" || rake <= 0 {
+					continue
+				}
+				charged[name] += rake
+				if loyal {
+					points[name] += rake * cfg.PointsPerRake
+				}
+			}
+			if len(charged) == 0 {
+				continue
+			}
+			if err := s.accounts.RecordRake(e.Time, charged, points); err != nil {
+				s.logger.Warn("failed to record rake", "tableID", e.TableID, "handID", e.HandID, "error", err)
+			}
+		case EventHandCancelled:
+			delete(hands, e.TableID)
+		}
+	}
+}
+
+// RakeStatement is the result of GET /admin/accounts/{name}/statement: the rake an account was
+// charged over a period, the basis for rakeback and other promotions
+type RakeStatement struct {
+	Player   string           `json:"player"`
+	Since    int64            `json:"since,omitempty"` // Unix ms; earlier days' hands and earlier sessions are left out
+	Hands    int              `json:"hands"`           // Hands the player was charged rake in
+	Rake     int              `json:"rake"`
+	Sessions []SessionSummary `json:"sessions"` // The recorded sessions of the period, newest first
+}
+
+// RakeStatement totals the rake charged to the named account in the hands that ended on or
+// after since's UTC day (all the ledger's days when since is zero). The totals come from the
+// rake ledger, so they cover the whole period even when it holds more sessions than the history
+// keeps, as long as it falls within the last rakeLedgerDays days.
+func (s *AccountStore) RakeStatement(name string, since time.Time) RakeStatement {
+	statement := RakeStatement{Player: accountKey(name), Sessions: []SessionSummary{}}
+	var from int64
+	if !since.IsZero() {
+		statement.Since = since.UnixMilli()
+		from = startOfDay(since).UnixMilli()
+	}
+	s.mu.Lock()
+	for _, day := range s.accounts[accountKey(name)].RakeLedger {
+		if day.Day < from {
+			continue
+		}
+		statement.Hands += day.Hands
+		statement.Rake += day.Rake
+	}
+	s.mu.Unlock()
+	for _, session := range s.SessionHistory(name) {
+		if session.LeftAt >= statement.Since {
+			statement.Sessions = append(statement.Sessions, session)
+		}
+	}
+	return statement
+}
+
+// endTableSession sends a player the summary of the stay at a table they just ended and
+// records it on their account, and on its club's ledger at a club table. Stays without a hand
+// dealt are not recorded.
+func (s *Server) endTableSession(token, name string, summary SessionSummary) {
+	s.sendPrivate(token, "session_summary", summary)
+	if name == "" || summary.HandsPlayed == 0 {
+		return
+	}
+	if err := s.accounts.RecordSession(name, summary); err != nil {
+		s.logger.Warn("failed to record table session", "name", name, "tableID", summary.TableID, "error", err)
+	}
+	s.recordClubStay(name, summary)
+}
+
+// handleListSessions writes the table sessions of the account in the path, newest first
+func (s *Server) handleListSessions(w http.ResponseWriter, r *http.Request) {
+	writeAdminJSON(w, http.StatusOK, s.accounts.SessionHistory(chi.URLParam(r, "name")))
+}
+
+// handleRakeStatement writes the rake statement of the account in the path, from the since query
+// parameter (Unix ms) when given
+func (s *Server) handleRakeStatement(w http.ResponseWriter, r *http.Request) {
+	var since time.Time
+	if param := r.URL.Query().Get("since"); param != "" {
+		ms, err := strconv.ParseInt(param, 10, 64)
+		if err != nil || ms < 0 {
+			http.Error(w, "invalid since", http.StatusBadRequest)
+			return
+		}
+		since = time.UnixMilli(ms)
+	}
+	writeAdminJSON(w, http.StatusOK, s.accounts.RakeStatement(chi.URLParam(r, "name"), since))
+}
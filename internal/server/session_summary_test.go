@@ -0,0 +1,249 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestStatsTracker_SessionSummary verifies a stay is summed up from blinds, bets and winnings,
+// with cancelled hands refunded, when the player leaves
+func TestStatsTracker_SessionSummary(t *testing.T) {
+	var ended []SessionSummary
+	var endedName string
+	stats := NewStatsTracker(
+		func(token string) string { return "name-" + token },
+		func(token, name string, summary SessionSummary) {
+			endedName = name
+			ended = append(ended, summary)
+		},
+	)
+	seated := time.Unix(1000, 0)
+	stats.handle(Event{Type: EventPlayerSeated, TableID: "table-1", Token: "alice", Time: seated})
+	stats.handle(Event{Type: EventPlayerSeated, TableID: "table-1", Token: "bob", Time: seated})
+
+	// Alice wins 60 after putting in 30; Bob loses his 30. Each is charged half the 6 chip rake
+	seats := map[int]string{0: "alice", 1: "bob"}
+	stats.handle(Event{Type: EventHandStarted, TableID: "table-1", Players: []string{"alice", "bob"}, Seats: seats, Blinds: map[int]int{0: 10, 1: 20}})
+	stats.handle(Event{Type: EventPlayerAction, TableID: "table-1", Token: "alice", Action: "call", Amount: 10})
+	stats.handle(Event{Type: EventPlayerAction, TableID: "table-1", Token: "bob", Action: "raise", Amount: 10})
+	stats.handle(Event{Type: EventPlayerAction, TableID: "table-1", Token: "alice", Action: "call", Amount: 10})
+	stats.handle(Event{Type: EventHandEnded, TableID: "table-1", Winnings: map[int]int{0: 60}, Rake: map[int]int{0: 3, 1: 3}})
+
+	// A cancelled hand costs nothing
+	stats.handle(Event{Type: EventHandStarted, TableID: "table-1", Players: []string{"alice", "bob"}, Seats: seats, Blinds: map[int]int{1: 10, 0: 20}})
+	stats.handle(Event{Type: EventPlayerAction, TableID: "table-1", Token: "bob", Action: "raise", Amount: 50})
+	stats.handle(Event{Type: EventHandCancelled, TableID: "table-1"})
+
+	// Leaving another table does not end the stay
+	stats.handle(Event{Type: EventPlayerLeft, TableID: "table-2", Token: "alice"})
+	if len(ended) != 0 {
+		t.Fatalf("expected no summary yet, got %+v", ended)
+	}
+
+	stats.handle(Event{Type: EventPlayerLeft, TableID: "table-1", Token: "alice", Time: seated.Add(90 * time.Second)})
+	want := SessionSummary{
+		TableID:     "table-1",
+		SeatedAt:    seated.UnixMilli(),
+		LeftAt:      seated.Add(90 * time.Second).UnixMilli(),
+		DurationMs:  90_000,
+		HandsPlayed: 2,
+		NetChips:    30,
+		BiggestPot:  60,
+		Rake:        3,
+	}
+	if len(ended) != 1 || ended[0] != want || endedName != "name-alice" {
+		t.Errorf("expected %+v for name-alice, got %+v for %s", want, ended, endedName)
+	}
+}
+
+// TestSessionSummary_SentAndRecordedOnLeave verifies a player standing up gets a session_summary
+// and the stay is kept on their account
+func TestSessionSummary_SentAndRecordedOnLeave(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	updateConfig(server, func(config *Config) { config.AdminToken = "secret" })
+	playOutChecking(t, server, table)
+	client := clients[0]
+	name, _ := server.sessionManager.GetPlayerName(client.Token)
+	drainRawMessages(client)
+
+	if err := client.HandleLeaveTable(server.sessionManager, server, slog.Default(), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var summary SessionSummary
+	received := eventually(func() bool {
+		for _, raw := range drainRawMessages(client) {
+			var msg struct {
+				Type    string         `json:"type"`
+				Payload SessionSummary `json:"payload"`
+			}
+			if json.Unmarshal([]byte(raw), &msg) == nil && msg.Type == "session_summary" {
+				summary = msg.Payload
+				return true
+			}
+		}
+		return false
+	})
+	if !received {
+		t.Fatal("expected a session_summary")
+	}
+	if summary.TableID != table.ID || summary.HandsPlayed != 1 || summary.LeftAt < summary.SeatedAt {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+
+	if !eventually(func() bool { return len(server.accounts.SessionHistory(name)) == 1 }) {
+		t.Fatal("expected the session recorded on the account")
+	}
+	rec := adminRequest(server, http.MethodGet, "/admin/accounts/"+name+"/sessions", "secret", "")
+	var history []SessionSummary
+	if err := json.Unmarshal(rec.Body.Bytes(), &history); err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 || history[0] != summary {
+		t.Errorf("expected the summary listed, got %+v", history)
+	}
+}
+
+// TestAccountStore_SessionHistoryIsBounded verifies only the latest sessions are kept, newest first
+func TestAccountStore_SessionHistoryIsBounded(t *testing.T) {
+	store, _ := LoadAccountStore("")
+	for i := range sessionHistoryLength + 5 {
+		if err := store.RecordSession("Alice", SessionSummary{HandsPlayed: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	history := store.SessionHistory("alice")
+	if len(history) != sessionHistoryLength || history[0].HandsPlayed != sessionHistoryLength+4 {
+		t.Errorf("expected %d sessions, newest first, got %d starting with %+v", sessionHistoryLength, len(history), history[0])
+	}
+}
+
+// TestRakeStatement verifies the statement totals the rake of the days since the given time,
+// with the sessions of the period, over the admin API too
+func TestRakeStatement(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{AdminToken: "secret"})
+	first := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, rake := range []int{5, 7, 11} {
+		at := first.AddDate(0, 0, i)
+		if err := server.accounts.RecordRake(at, map[string]int{"Alice": rake}, nil); err != nil {
+			t.Fatal(err)
+		}
+		summary := SessionSummary{TableID: "table-1", LeftAt: at.UnixMilli(), HandsPlayed: 10, Rake: rake}
+		if err := server.accounts.RecordSession("Alice", summary); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	statement := server.accounts.RakeStatement("alice", time.Time{})
+	if statement.Player != "alice" || statement.Hands != 3 || statement.Rake != 23 || len(statement.Sessions) != 3 {
+		t.Errorf("expected 23 rake over 3 hands in 3 sessions, got %+v", statement)
+	}
+
+	since := first.AddDate(0, 0, 1).UnixMilli()
+	rec := adminRequest(server, http.MethodGet, fmt.Sprintf("/admin/accounts/Alice/statement?since=%d", since), "secret", "")
+	if err := json.Unmarshal(rec.Body.Bytes(), &statement); err != nil {
+		t.Fatal(err)
+	}
+	if statement.Since != since || statement.Rake != 18 || len(statement.Sessions) != 2 || statement.Sessions[0].Rake != 11 {
+		t.Errorf("expected the last two sessions with 18 rake, newest first, got %+v", statement)
+	}
+
+	rec = adminRequest(server, http.MethodGet, "/admin/accounts/Alice/statement?since=soon", "secret", "")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid since, got %d", rec.Code)
+	}
+}
+
+// TestRakeStatement_LongerThanSessionHistory verifies a statement counts the rake of a period
+// with more sessions than the history keeps
+func TestRakeStatement_LongerThanSessionHistory(t *testing.T) {
+	store, _ := LoadAccountStore("")
+	first := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	sessions := sessionHistoryLength + 50
+	for i := range sessions {
+		at := first.Add(time.Duration(i) * 6 * time.Hour)
+		if err := store.RecordRake(at, map[string]int{"Alice": 2}, nil); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.RecordSession("Alice", SessionSummary{TableID: "table-1", LeftAt: at.UnixMilli(), HandsPlayed: 1, Rake: 2}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	statement := store.RakeStatement("alice", time.Time{})
+	if statement.Rake != 2*sessions || statement.Hands != sessions || len(statement.Sessions) != sessionHistoryLength {
+		t.Errorf("expected %d rake over %d hands, with the last %d sessions, got %d over %d with %d",
+			2*sessions, sessions, sessionHistoryLength, statement.Rake, statement.Hands, len(statement.Sessions))
+	}
+	// Four hands a day, the first at noon: the first day has two
+	if statement := store.RakeStatement("alice", first.AddDate(0, 0, 1)); statement.Rake != 2*(sessions-2) {
+		t.Errorf("expected the rake since the second day, got %d", statement.Rake)
+	}
+}
+
+// TestRecordRake_DailyTotals verifies the ledger keeps one total per day, in order even when a
+// hand ends out of turn, drops days past rakeLedgerDays and takes loyalty points with the rake
+func TestRecordRake_DailyTotals(t *testing.T) {
+	store, _ := LoadAccountStore("")
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	for _, at := range []time.Time{day.Add(time.Hour), day.Add(20 * time.Hour), day.AddDate(0, 0, 2), day.AddDate(0, 0, 1)} {
+		if err := store.RecordRake(at, map[string]int{"alice": 3, "Bob": 1}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	account, _ := store.Account("Alice")
+	want := []RakeDay{
+		{Day: day.UnixMilli(), Hands: 2, Rake: 6},
+		{Day: day.AddDate(0, 0, 1).UnixMilli(), Hands: 1, Rake: 3},
+		{Day: day.AddDate(0, 0, 2).UnixMilli(), Hands: 1, Rake: 3},
+	}
+	if !slices.Equal(account.RakeLedger, want) {
+		t.Errorf("expected %+v, got %+v", want, account.RakeLedger)
+	}
+
+	if err := store.RecordRake(day.AddDate(0, 0, rakeLedgerDays), map[string]int{"alice": 4}, map[string]int{"alice": 8}); err != nil {
+		t.Fatal(err)
+	}
+	account, _ = store.Account("Alice")
+	if len(account.RakeLedger) != 3 || account.RakeLedger[0].Day != day.AddDate(0, 0, 1).UnixMilli() {
+		t.Errorf("expected the oldest day dropped, got %+v", account.RakeLedger)
+	}
+	if account.LoyaltyPoints != 8 {
+		t.Errorf("expected the points credited with the rake, got %d", account.LoyaltyPoints)
+	}
+	if bob, _ := store.Account("Bob"); len(bob.RakeLedger) != 3 || bob.RakeLedger[0].Rake != 2 {
+		t.Errorf("expected Bob's days kept apart, got %+v", bob.RakeLedger)
+	}
+}
+
+// TestRunRakeLedger verifies each player's rake is recorded with the hand, including a player who
+// left before the hand ended
+func TestRunRakeLedger(t *testing.T) {
+	server := NewServer(slog.Default())
+	alice, _ := server.sessionManager.CreateSession("Alice")
+	bob, _ := server.sessionManager.CreateSession("Bob")
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	events := make(chan Event, 3)
+	events <- Event{Type: EventHandStarted, TableID: "table-1", HandID: "h1", Seats: map[int]string{0: alice.Token, 1: bob.Token}}
+	events <- Event{Type: EventPlayerLeft, TableID: "table-1", SeatIndex: 1, Token: bob.Token}
+	events <- Event{Type: EventHandEnded, TableID: "table-1", HandID: "h1", Time: at, Rake: map[int]int{0: 3, 1: 1}}
+	close(events)
+	server.RunRakeLedger(events)
+
+	for name, rake := range map[string]int{"alice": 3, "bob": 1} {
+		statement := server.accounts.RakeStatement(name, time.Time{})
+		if statement.Rake != rake || statement.Hands != 1 {
+			t.Errorf("expected %s charged %d in one hand, got %+v", name, rake, statement)
+		}
+	}
+	account, _ := server.accounts.Account("Bob")
+	if want := (RakeDay{Day: startOfDay(at).UnixMilli(), Hands: 1, Rake: 1}); len(account.RakeLedger) != 1 || account.RakeLedger[0] != want {
+		t.Errorf("expected the hand in Bob's ledger, got %+v", account.RakeLedger)
+	}
+}
@@ -190,6 +190,48 @@ func TestSessionManager_GetSession(t *testing.T) {
 	}
 }
 
+// TestSessionManager_GetSession_Copy tests a retrieved session can be read while another
+// goroutine moves it and changes its balances
+func TestSessionManager_GetSession_Copy(t *testing.T) {
+	sm := NewSessionManager(slog.Default())
+	session, err := sm.CreateSession("Alice")
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+
+	start, _ := sm.Balances(session.Token)
+	tableID := "table-1"
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for seat := range 100 {
+			sm.UpdateSession(session.Token, &tableID, &seat)
+			sm.AdjustBalance(session.Token, CurrencyPlay, 1)
+		}
+	}()
+	for range 100 {
+		retrieved, err := sm.GetSession(session.Token)
+		if err != nil {
+			t.Fatalf("GetSession failed: %v", err)
+		}
+		if retrieved.TableID != nil && (*retrieved.TableID != tableID || *retrieved.SeatIndex >= 100) {
+			t.Fatalf("unexpected placement %s/%d", *retrieved.TableID, *retrieved.SeatIndex)
+		}
+		_ = retrieved.Balances[CurrencyPlay]
+	}
+	wg.Wait()
+
+	retrieved, _ := sm.GetSession(session.Token)
+	if retrieved.TableID == nil || *retrieved.SeatIndex != 99 || retrieved.Balances[CurrencyPlay] != start[CurrencyPlay]+100 {
+		t.Errorf("expected table-1 seat 99 with 100 chips, got %+v", retrieved)
+	}
+	retrieved.Balances[CurrencyPlay] = 0
+	if balances, _ := sm.Balances(session.Token); balances[CurrencyPlay] != start[CurrencyPlay]+100 {
+		t.Error("expected changing the copy to leave the session alone")
+	}
+}
+
 // TestSessionManager_GetSession_NotFound tests that non-existent tokens return error
 func TestSessionManager_GetSession_NotFound(t *testing.T) {
 	logger := slog.Default()
@@ -432,3 +474,90 @@ func TestSessionManager_NameTrimming(t *testing.T) {
 		t.Errorf("Name should be trimmed, got %s", session.Name)
 	}
 }
+
+// TestSessionManager_NoTTLNeverExpires verifies sessions from NewSessionManager have no expiry
+func TestSessionManager_NoTTLNeverExpires(t *testing.T) {
+	sm := NewSessionManager(slog.Default())
+
+	session, err := sm.CreateSession("Alice")
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	if !session.ExpiresAt.IsZero() {
+		t.Errorf("expected no expiry, got %v", session.ExpiresAt)
+	}
+	if tokens := sm.ExpiredSessions(time.Now().Add(1000 * time.Hour)); len(tokens) != 0 {
+		t.Errorf("expected no expired sessions, got %v", tokens)
+	}
+}
+
+// TestSessionManager_ExpiredSessionRejected verifies GetSession and RenewSession refuse lapsed sessions
+func TestSessionManager_ExpiredSessionRejected(t *testing.T) {
+	sm := NewSessionManagerWithTTL(slog.Default(), time.Hour)
+
+	session, err := sm.CreateSession("Alice")
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	if session.ExpiresAt.Sub(session.CreatedAt) != time.Hour {
+		t.Errorf("expected expiry one hour after creation, got %v", session.ExpiresAt.Sub(session.CreatedAt))
+	}
+
+	sm.mutex.Lock()
+	sm.sessions[session.Token].ExpiresAt = time.Now().Add(-time.Second)
+	sm.mutex.Unlock()
+
+	if _, err := sm.GetSession(session.Token); err == nil {
+		t.Error("expected GetSession to fail for an expired session")
+	}
+	if _, err := sm.RenewSession(session.Token); err == nil {
+		t.Error("expected RenewSession to fail for an expired session")
+	}
+}
+
+// TestSessionManager_RenewSession verifies renewal pushes the expiry out by the TTL
+func TestSessionManager_RenewSession(t *testing.T) {
+	sm := NewSessionManagerWithTTL(slog.Default(), time.Hour)
+
+	session, err := sm.CreateSession("Alice")
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+
+	sm.mutex.Lock()
+	sm.sessions[session.Token].ExpiresAt = time.Now().Add(time.Minute)
+	sm.mutex.Unlock()
+
+	before := time.Now()
+	expiresAt, err := sm.RenewSession(session.Token)
+	if err != nil {
+		t.Fatalf("RenewSession failed: %v", err)
+	}
+	if expiresAt.Before(before.Add(time.Hour)) {
+		t.Errorf("expected expiry at least an hour out, got %v", expiresAt.Sub(before))
+	}
+
+	if _, err := sm.RenewSession("unknown"); err == nil {
+		t.Error("expected RenewSession to fail for an unknown token")
+	}
+}
+
+// TestSessionManager_ExpiredSessions verifies only lapsed sessions are reported
+func TestSessionManager_ExpiredSessions(t *testing.T) {
+	sm := NewSessionManagerWithTTL(slog.Default(), time.Hour)
+
+	alice, _ := sm.CreateSession("Alice")
+	bob, _ := sm.CreateSession("Bob")
+
+	sm.mutex.Lock()
+	sm.sessions[alice.Token].ExpiresAt = time.Now().Add(-time.Second)
+	sm.mutex.Unlock()
+
+	tokens := sm.ExpiredSessions(time.Now())
+	if len(tokens) != 1 || tokens[0] != alice.Token {
+		t.Errorf("expected only %s to be expired, got %v", alice.Token, tokens)
+	}
+	if tokens := sm.ExpiredSessions(time.Now().Add(2 * time.Hour)); len(tokens) != 2 {
+		t.Errorf("expected both sessions expired two hours out, got %v (bob %s)", tokens, bob.Token)
+	}
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+)
+
+// uncontestedWin is the winner of the last hand when nobody called them down. Their cards were
+// never shown, so they may choose to show them until the next hand starts.
+type uncontestedWin struct {
+	handID    string
+	seatIndex int
+	token     string
+	holeCards []Card
+	shown     []Card // Cards shown so far, in the order they were shown
+}
+
+// ShowCardsPayload represents the payload for show_cards messages: one or both hole cards
+type ShowCardsPayload struct {
+	Cards []Card `json:"cards"`
+}
+
+// CardsShownPayload represents the payload for cards_shown messages
+type CardsShownPayload struct {
+	HandID    string `json:"handId"`
+	SeatIndex int    `json:"seatIndex"`
+	Cards     []Card `json:"cards"` // Every card the seat has shown from the hand
+}
+
+// ShowCards reveals cards from token's holding in the hand they just won uncontested
+// Returns everything they have shown from that hand. Showing a card twice is harmless.
+func (t *Table) ShowCards(token string, cards []Card) (CardsShownPayload, error) {
+	if len(cards) == 0 || len(cards) > 2 {
+		return CardsShownPayload{}, newMessageError("error.invalid_show", nil)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	win := t.uncontested
+	if win == nil || win.token != token {
+		return CardsShownPayload{}, newMessageError("error.nothing_to_show", nil)
+	}
+	for _, card := range cards {
+		if !slices.Contains(win.holeCards, card) {
+			return CardsShownPayload{}, newMessageError("error.card_not_held", map[string]any{"card": card.String()})
+		}
+	}
+	for _, card := range cards {
+		if !slices.Contains(win.shown, card) {
+			win.shown = append(win.shown, card)
+		}
+	}
+
+	shown := slices.Clone(win.shown)
+	t.publishEvent(Event{Type: EventCardsShown, HandID: win.handID, SeatIndex: win.seatIndex, Token: token, Shown: shown})
+	return CardsShownPayload{HandID: win.handID, SeatIndex: win.seatIndex, Cards: shown}, nil
+}
+
+// HandleShowCards processes a show_cards message and broadcasts cards_shown to the table
+func (c *Client) HandleShowCards(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+	var show ShowCardsPayload
+	if err := json.Unmarshal(payload, &show); err != nil {
+		return invalidPayloadError("show_cards", err)
+	}
+
+	session, err := sm.GetSession(c.Token)
+	if err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+	if session.TableID == nil {
+		return newMessageError("error.not_seated", nil)
+	}
+	table := server.tableByID(*session.TableID)
+	if table == nil {
+		return newMessageError("error.table_not_found", nil)
+	}
+
+	shown, err := table.ShowCards(c.Token, show.Cards)
+	if err != nil {
+		return err
+	}
+	logger.Info("cards shown", "tableID", table.ID, "seatIndex", shown.SeatIndex, "handId", shown.HandID)
+	return server.broadcastTableMessage(table, "cards_shown", shown)
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+)
+
+// showCardsKey shows cards as client and returns the message key of the error, empty on success
+func showCardsKey(server *Server, client *Client, cards ...Card) string {
+	payload, _ := json.Marshal(ShowCardsPayload{Cards: cards})
+	err := client.HandleShowCards(server.sessionManager, server, slog.Default(), payload)
+	if err == nil {
+		return ""
+	}
+	var messageErr *MessageError
+	if errors.As(err, &messageErr) {
+		return messageErr.Key
+	}
+	return err.Error()
+}
+
+// foldToBigBlind folds the first two actors so the big blind wins uncontested, returning the
+// big blind's seat and hole cards
+func foldToBigBlind(t *testing.T, server *Server, table *Table) (int, []Card) {
+	t.Helper()
+	table.mu.RLock()
+	bb := table.CurrentHand.BigBlindSeat
+	hole := table.CurrentHand.HoleCards[bb]
+	table.mu.RUnlock()
+	actCurrent(t, server, table, "fold")
+	actCurrent(t, server, table, "fold")
+	return bb, hole
+}
+
+// TestShowCards_UncontestedWinner verifies the winner of an uncontested hand can show one card
+// and then the other, and the table and the hand's history see them
+func TestShowCards_UncontestedWinner(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	handID := handIDOf(table)
+	bb, hole := foldToBigBlind(t, server, table)
+	storedReplay(t, server, handID)
+	for _, client := range clients {
+		drainRawMessages(client)
+	}
+
+	if key := showCardsKey(server, clients[bb], hole[0]); key != "" {
+		t.Fatalf("showing a card failed: %s", key)
+	}
+	if key := showCardsKey(server, clients[bb], hole[1], hole[0]); key != "" {
+		t.Fatalf("showing both cards failed: %s", key)
+	}
+
+	other := clients[(bb+1)%3]
+	var shown []CardsShownPayload
+	for _, raw := range drainRawMessages(other) {
+		var msg struct {
+			Type    string            `json:"type"`
+			Payload CardsShownPayload `json:"payload"`
+		}
+		if json.Unmarshal([]byte(raw), &msg) == nil && msg.Type == "cards_shown" {
+			shown = append(shown, msg.Payload)
+		}
+	}
+	if len(shown) != 2 || shown[0].HandID != handID || shown[0].SeatIndex != bb || len(shown[0].Cards) != 1 || len(shown[1].Cards) != 2 {
+		t.Fatalf("expected two cards_shown messages for seat %d, got %+v", bb, shown)
+	}
+
+	if !eventually(func() bool {
+		replay, _ := server.replays.Hand(handID)
+		return len(replay.HoleCards[bb]) == 2
+	}) {
+		t.Error("expected the shown cards in the replay")
+	}
+	if history := server.replays.History(table.ID); len(history) != 1 || len(history[0].Shown[bb]) != 2 {
+		t.Errorf("expected the shown cards in the history, got %+v", history)
+	}
+}
+
+// TestShowCards_Refused verifies who may show what, and when
+func TestShowCards_Refused(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	bb, hole := foldToBigBlind(t, server, table)
+	loser := (bb + 1) % 3
+
+	notHeld := NewCard("2", "c")
+	if notHeld == hole[0] || notHeld == hole[1] {
+		notHeld = NewCard("3", "c")
+	}
+	cases := []struct {
+		name   string
+		client *Client
+		cards  []Card
+		want   string
+	}{
+		{"loser", clients[loser], hole[:1], "error.nothing_to_show"},
+		{"card not held", clients[bb], []Card{notHeld}, "error.card_not_held"},
+		{"no cards", clients[bb], nil, "error.invalid_show"},
+		{"three cards", clients[bb], []Card{hole[0], hole[1], notHeld}, "error.invalid_show"},
+	}
+	for _, tc := range cases {
+		if key := showCardsKey(server, tc.client, tc.cards...); key != tc.want {
+			t.Errorf("%s: expected %s, got %q", tc.name, tc.want, key)
+		}
+	}
+
+	// The chance to show ends when the next hand starts
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if key := showCardsKey(server, clients[bb], hole[0]); key != "error.nothing_to_show" {
+		t.Errorf("after the next hand started: expected error.nothing_to_show, got %q", key)
+	}
+}
+
+// TestShowCards_NotAfterShowdown verifies cards shown down cannot be "shown" again
+func TestShowCards_NotAfterShowdown(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	playOutChecking(t, server, table)
+	for seat, client := range clients {
+		if key := showCardsKey(server, client, NewCard("A", "s")); key != "error.nothing_to_show" {
+			t.Errorf("seat %d: expected error.nothing_to_show, got %q", seat, key)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// Showdown orders: who turns their cards over first when a hand is shown down
+const (
+	// ShowdownOrderAggressor starts with the last player to bet or raise on the river, or with
+	// the first player left of the button when the river was checked through (the default)
+	ShowdownOrderAggressor = "aggressor"
+	// ShowdownOrderClockwise always starts with the first player left of the button
+	ShowdownOrderClockwise = "clockwise"
+)
+
+// ShowdownConfig sets the order hands are revealed in at showdown and the pause between reveals
+type ShowdownConfig struct {
+	// Order is ShowdownOrderAggressor (the default when empty) or ShowdownOrderClockwise
+	Order string `yaml:"order"`
+	// RevealInterval spaces out the reveals: each showdown_reveal carries the time the client
+	// should turn the hand over. Zero, or pacing.instant, reveals every hand at once, still in order.
+	RevealInterval time.Duration `yaml:"revealInterval"`
+}
+
+// validate reports the first invalid showdown setting
+func (c ShowdownConfig) validate() error {
+	switch c.Order {
+	case "", ShowdownOrderAggressor, ShowdownOrderClockwise:
+	default:
+		return fmt.Errorf("showdown.order must be %q or %q", ShowdownOrderAggressor, ShowdownOrderClockwise)
+	}
+	if c.RevealInterval < 0 {
+		return fmt.Errorf("showdown.revealInterval must not be negative")
+	}
+	return nil
+}
+
+// ShowdownRevealPayload represents the payload for showdown_reveal messages, one per player
+// still in the hand at showdown, sent in the order they act
+type ShowdownRevealPayload struct {
+	HandID    string `json:"handId"`
+	SeatIndex int    `json:"seatIndex"`
+	Order     int    `json:"order"`           // 0 reveals first
+	Cards     []Card `json:"cards,omitempty"` // Nil when the hand was mucked
+	Hand      string `json:"hand,omitempty"`  // Hand rank ID, like "straight"; empty when mucked
+	Mucked    bool   `json:"mucked,omitempty"`
+	RevealAt  int64  `json:"revealAt"` // Unix ms; when clients should turn the hand over
+}
+
+// AutoMuckPayload represents the payload for set_auto_muck messages and their auto_muck reply
+type AutoMuckPayload struct {
+	AutoMuck bool `json:"autoMuck"`
+}
+
+// showdownOrderLocked returns the seats still in the hand in the order they reveal: from the
+// river's last aggressor (or the first seat left of the button) clockwise
+// (caller must hold t.mu)
+func (t *Table) showdownOrderLocked(order string) []int {
+	hand := t.CurrentHand
+	first := (hand.DealerSeat + 1) % len(t.Seats)
+	if order != ShowdownOrderClockwise && hand.Aggressor != nil && hand.AggressorStreet == "river" && !hand.FoldedPlayers[*hand.Aggressor] {
+		first = *hand.Aggressor
+	}
+
+	var seats []int
+	for offset := range len(t.Seats) {
+		i := (first + offset) % len(t.Seats)
+		if t.Seats[i].Status == "active" && !hand.FoldedPlayers[i] && len(hand.HoleCards[i]) > 0 {
+			seats = append(seats, i)
+		}
+	}
+	return seats
+}
+
+// showdownRevealsLocked decides, in showdown order, who shows and who mucks
+// The first player always shows, as does everyone who won chips or was already all-in and face
+// up. The rest muck only if they asked to muck losing hands.
+// Returns the reveals and the cards shown by seat (caller must hold t.mu)
+func (t *Table) showdownRevealsLocked(distribution map[int]int) ([]ShowdownRevealPayload, map[int][]Card) {
+	hand := t.CurrentHand
+	var cfg ShowdownConfig
+	var interval time.Duration
+	if t.Server != nil {
+		config := t.Server.Config()
+		cfg = config.Showdown
+		if !config.Pacing.Instant {
+			interval = t.speedUp(cfg.RevealInterval)
+		}
+	}
+	start := t.clock().Now()
+
+	var reveals []ShowdownRevealPayload
+	shown := make(map[int][]Card)
+	for order, seat := range t.showdownOrderLocked(cfg.Order) {
+		reveal := ShowdownRevealPayload{
+			HandID:    hand.ID,
+			SeatIndex: seat,
+			Order:     order,
+			RevealAt:  start.Add(time.Duration(order) * interval).UnixMilli(),
+		}
+		mustShow := order == 0 || distribution[seat] > 0 || hand.RevealedSeats[seat]
+		if !mustShow && t.autoMucksLocked(seat) {
+			reveal.Mucked = true
+		} else {
+			reveal.Cards = slices.Clone(hand.HoleCards[seat])
+			if rank := EvaluateHand(hand.HoleCards[seat], hand.BoardCards).Rank; rank >= 0 && rank < len(handRankIDs) {
+				reveal.Hand = handRankIDs[rank]
+			}
+			shown[seat] = slices.Clone(reveal.Cards)
+		}
+		reveals = append(reveals, reveal)
+	}
+	return reveals, shown
+}
+
+// autoMucksLocked reports whether the player at seat mucks losing hands (caller must hold t.mu)
+func (t *Table) autoMucksLocked(seat int) bool {
+	token := t.Seats[seat].Token
+	return token != nil && t.Server != nil && t.Server.sessionManager != nil && t.Server.sessionManager.AutoMuck(*token)
+}
+
+// broadcastShowdownReveals sends the table each showdown_reveal, in order
+// Assumes the table lock has already been released
+func (s *Server) broadcastShowdownReveals(table *Table, reveals []ShowdownRevealPayload) {
+	for _, reveal := range reveals {
+		if err := s.broadcastTableMessage(table, "showdown_reveal", reveal); err != nil {
+			s.logger.Warn("failed to broadcast showdown_reveal", "tableID", table.ID, "seatIndex", reveal.SeatIndex, "error", err)
+		}
+	}
+}
+
+// HandleSetAutoMuck processes a set_auto_muck message: whether the player's losing hands are
+// mucked at showdown rather than shown, and replies with auto_muck
+func (c *Client) HandleSetAutoMuck(sm *SessionManager, logger *slog.Logger, payload []byte) error {
+	var req AutoMuckPayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return invalidPayloadError("set_auto_muck", err)
+	}
+	if err := sm.SetAutoMuck(c.Token, req.AutoMuck); err != nil {
+		return err
+	}
+	logger.Info("auto-muck set", "token", c.Token, "autoMuck", req.AutoMuck)
+	return c.sendMessage("auto_muck", req)
+}
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// checkToRiver checks or calls for whoever is to act until the river is dealt
+func checkToRiver(t *testing.T, server *Server, table *Table) {
+	t.Helper()
+	for i := 0; i < 20; i++ {
+		table.mu.RLock()
+		hand := table.CurrentHand
+		if hand == nil {
+			table.mu.RUnlock()
+			t.Fatal("hand ended before the river")
+		}
+		if hand.Street == "river" {
+			table.mu.RUnlock()
+			return
+		}
+		action := "check"
+		if hand.CurrentActor != nil && hand.CurrentBet > hand.PlayerBets[*hand.CurrentActor] {
+			action = "call"
+		}
+		table.mu.RUnlock()
+		actCurrent(t, server, table, action)
+	}
+	t.Fatal("river was not dealt")
+}
+
+// showdownReveals returns the showdown_reveal messages queued for client, in order
+func showdownReveals(t *testing.T, client *Client) []ShowdownRevealPayload {
+	t.Helper()
+	var reveals []ShowdownRevealPayload
+	for _, raw := range drainRawMessages(client) {
+		var msg struct {
+			Type    string                `json:"type"`
+			Payload ShowdownRevealPayload `json:"payload"`
+		}
+		if json.Unmarshal([]byte(raw), &msg) == nil && msg.Type == "showdown_reveal" {
+			reveals = append(reveals, msg.Payload)
+		}
+	}
+	return reveals
+}
+
+// TestShowdown_RiverAggressorShowsFirst verifies the last player to bet on the river reveals
+// first and the others follow clockwise
+func TestShowdown_RiverAggressorShowsFirst(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	checkToRiver(t, server, table)
+	bettor := currentActor(table)
+	actCurrent(t, server, table, "raise", 40)
+	playOutChecking(t, server, table)
+
+	reveals := showdownReveals(t, clients[0])
+	if len(reveals) != 3 {
+		t.Fatalf("expected three reveals, got %+v", reveals)
+	}
+	for i, reveal := range reveals {
+		if want := (bettor + i) % 3; reveal.SeatIndex != want || reveal.Order != i {
+			t.Errorf("reveal %d: expected seat %d, got seat %d (order %d)", i, want, reveal.SeatIndex, reveal.Order)
+		}
+		if len(reveal.Cards) != 2 || reveal.Hand == "" || reveal.Mucked {
+			t.Errorf("reveal %d: expected the hand shown, got %+v", i, reveal)
+		}
+	}
+}
+
+// TestShowdown_CheckedRiverStartsLeftOfButton verifies a river checked through is revealed from
+// the first player left of the button, with reveals spaced by the configured interval
+func TestShowdown_CheckedRiverStartsLeftOfButton(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	updateConfig(server, func(config *Config) { config.Showdown.RevealInterval = 2 * time.Second })
+	table.mu.RLock()
+	dealer := table.CurrentHand.DealerSeat
+	table.mu.RUnlock()
+	playOutChecking(t, server, table)
+
+	reveals := showdownReveals(t, clients[0])
+	if len(reveals) != 3 || reveals[0].SeatIndex != (dealer+1)%3 {
+		t.Fatalf("expected seat %d to reveal first, got %+v", (dealer+1)%3, reveals)
+	}
+	if gap := reveals[2].RevealAt - reveals[1].RevealAt; gap != 2000 {
+		t.Errorf("expected reveals 2s apart, got %dms", gap)
+	}
+}
+
+// TestShowdown_AutoMuck verifies players who asked to muck losing hands keep them hidden, from
+// the table and from the replay, while the first to reveal and the winners still show
+func TestShowdown_AutoMuck(t *testing.T) {
+	server, table, clients := preActionTable(t)
+	for _, client := range clients {
+		payload, _ := json.Marshal(AutoMuckPayload{AutoMuck: true})
+		if err := client.HandleSetAutoMuck(server.sessionManager, slog.Default(), payload); err != nil {
+			t.Fatal(err)
+		}
+	}
+	handID := handIDOf(table)
+	playOutChecking(t, server, table)
+	replay := storedReplay(t, server, handID)
+
+	for _, reveal := range showdownReveals(t, clients[0]) {
+		won := replay.Winnings[reveal.SeatIndex] > 0
+		if reveal.Mucked == (reveal.Order == 0 || won) {
+			t.Errorf("seat %d (order %d, won %v): unexpected mucked=%v", reveal.SeatIndex, reveal.Order, won, reveal.Mucked)
+		}
+		if _, shown := replay.HoleCards[reveal.SeatIndex]; shown == reveal.Mucked {
+			t.Errorf("seat %d: replay shows cards %v, mucked %v", reveal.SeatIndex, replay.HoleCards[reveal.SeatIndex], reveal.Mucked)
+		}
+	}
+}
@@ -0,0 +1,407 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// BotView is what a simulated player sees when it is their turn
+type BotView struct {
+	Seat         int
+	Street       string
+	HoleCards    []Card
+	Board        []Card
+	Texture      BoardTexture // Texture of Board
+	ValidActions []string
+	CallAmount   int // Chips needed to call
+	Pot          int // Chips committed to the hand, current street included
+	Stack        int
+	MinRaise     int // Smallest total bet a raise may make
+	MaxRaise     int // Largest total bet the player can make (all-in)
+	BigBlind     int
+}
+
+// can reports whether action is one of the valid actions
+func (v BotView) can(action string) bool {
+	return slices.Contains(v.ValidActions, action)
+}
+
+// first returns the first of actions that is valid
+func (v BotView) first(actions ...string) string {
+	for _, action := range actions {
+		if v.can(action) {
+			return action
+		}
+	}
+	return "fold"
+}
+
+// raiseTo returns the total bet a raise to amount makes, kept within the legal range
+func (v BotView) raiseTo(amount int) int {
+	return max(min(amount, v.MaxRaise), min(v.MinRaise, v.MaxRaise))
+}
+
+// Strategy decides a simulated player's action; amount is the total bet for raises
+type Strategy func(view BotView, rng *rand.Rand) (action string, amount int)
+
+// simStrategies are the bot strategies RunSimulation knows by name
+var simStrategies = map[string]Strategy{
+	// check_fold never puts in a chip it does not have to
+	"check_fold": func(view BotView, rng *rand.Rand) (string, int) {
+		return view.first("check", "fold"), 0
+	},
+	// calling_station calls everything and never raises
+	"calling_station": func(view BotView, rng *rand.Rand) (string, int) {
+		return view.first("check", "call", "fold"), 0
+	},
+	// maniac raises the pot whenever it can
+	"maniac": func(view BotView, rng *rand.Rand) (string, int) {
+		if view.can("raise") {
+			return "raise", view.raiseTo(view.CallAmount + view.Pot)
+		}
+		return view.first("call", "check", "fold"), 0
+	},
+	// random picks any valid action, raising a random amount
+	"random": func(view BotView, rng *rand.Rand) (string, int) {
+		action := view.ValidActions[rng.Intn(len(view.ValidActions))]
+		if action != "raise" {
+			return action, 0
+		}
+		low := min(view.MinRaise, view.MaxRaise)
+		return action, low + rng.Intn(view.MaxRaise-low+1)
+	},
+	// tight plays strong starting hands and made hands, raising the best of them; a lone pair
+	// is only checked, not called, on a board wet enough for straights and flushes
+	"tight": func(view BotView, rng *rand.Rand) (string, int) {
+		strength := handStrength(view)
+		switch {
+		case strength >= 2 && view.can("raise"):
+			return "raise", view.raiseTo(view.MinRaise + view.Pot/2)
+		case strength == 1 && view.Texture.Wetness >= 4:
+			return view.first("check", "fold"), 0
+		case strength >= 1:
+			return view.first("check", "call", "fold"), 0
+		default:
+			return view.first("check", "fold"), 0
+		}
+	},
+}
+
+// SimStrategyNames lists the bot strategies in alphabetical order
+func SimStrategyNames() []string {
+	return slices.Sorted(maps.Keys(simStrategies))
+}
+
+// handStrength rates the player's hand 0 (weak), 1 (playable) or 2 (strong) for the tight strategy
+// Preflop pairs and two big cards are playable, high pairs and ace-king strong; after the flop a
+// pair is playable and two pair or better strong
+func handStrength(view BotView) int {
+	if len(view.HoleCards) < 2 {
+		return 0
+	}
+	if len(view.Board) == 0 {
+		high := view.HoleCards[0].Rank()
+		low := view.HoleCards[1].Rank()
+		if low > high {
+			high, low = low, high
+		}
+		switch {
+		case high == low && high >= 10, high == 14 && low == 13:
+			return 2
+		case high == low, low >= 10:
+			return 1
+		default:
+			return 0
+		}
+	}
+	switch rank := EvaluateHand(view.HoleCards, view.Board).Rank; {
+	case rank >= 2:
+		return 2
+	case rank == 1:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// SimulationConfig describes a simulated session between bots at one table
+type SimulationConfig struct {
+	Hands      int         // Hands to play
+	Strategies []string    // Strategy of each bot, 2 to 6 of them, seated in order
+	Seed       int64       // Seeds the random strategy; shuffles always use fresh seeds
+	Table      TableConfig // Blinds and buy-in; DefaultTables when zero
+	Rake       RakeConfig
+	History    io.Writer // Receives one HandHistory per hand as JSON Lines when set
+}
+
+// HandHistory is the record of one simulated hand
+type HandHistory struct {
+	Hand        int              `json:"hand"`
+	Dealer      int              `json:"dealer"`
+	Players     map[int]string   `json:"players"` // Strategy per seat dealt in
+	HoleCards   map[int][]Card   `json:"holeCards"`
+	Actions     []HistoryAction  `json:"actions"`
+	Board       []Card           `json:"board"`
+	Winnings    map[int]int      `json:"winnings"` // Chips won per seat, after rake
+	Showdown    bool             `json:"showdown"`
+	WinningHand string           `json:"winningHand,omitempty"` // Best hand shown down, as in handRankIDs
+	Net         map[int]int      `json:"net"`                   // Chips won or lost per seat
+	folded      map[int]struct{} // Seats that folded
+}
+
+// HistoryAction is one action in a HandHistory
+type HistoryAction struct {
+	Seat    int    `json:"seat"`
+	Street  string `json:"street"`
+	Action  string `json:"action"`
+	Amount  int    `json:"amount,omitempty"` // Chips moved into the pot
+	Timeout bool   `json:"timeout,omitempty"`
+}
+
+// StrategyStats aggregates the results of every bot playing one strategy
+type StrategyStats struct {
+	Strategy     string  `json:"strategy"`
+	Bots         int     `json:"bots"`
+	HandsDealt   int     `json:"handsDealt"`
+	NetChips     int     `json:"netChips"`
+	ChipsPerHand float64 `json:"chipsPerHand"` // Chip EV per hand dealt
+	BBPer100     float64 `json:"bbPer100"`     // Big blinds won per 100 hands dealt
+	HandsWon     int     `json:"handsWon"`
+	Showdowns    int     `json:"showdowns"`    // Hands dealt that the bot took to showdown
+	ShowdownsWon int     `json:"showdownsWon"` // Showdowns the bot won a share of
+	Rebuys       int     `json:"rebuys"`       // Times a busted bot bought in again
+}
+
+// SimulationReport summarizes a simulation
+type SimulationReport struct {
+	Hands      int             `json:"hands"`
+	Showdowns  int             `json:"showdowns"` // Hands that went to showdown
+	Rake       int             `json:"rake"`
+	Strategies []StrategyStats `json:"strategies"` // In the order the strategies were first listed
+}
+
+// simBot is one simulated player
+type simBot struct {
+	token    string
+	strategy string
+	play     Strategy
+}
+
+// maxSimActions bounds the actions in one simulated hand, to stop a stuck engine looping forever
+const maxSimActions = 500
+
+// RunSimulation plays cfg.Hands hands between bots through the real game engine, without any
+// network connections. Bots who bust buy in again. Returns the aggregate results per strategy.
+func RunSimulation(cfg SimulationConfig) (SimulationReport, error) {
+	if cfg.Hands <= 0 {
+		return SimulationReport{}, fmt.Errorf("hands must be positive")
+	}
+	if len(cfg.Strategies) < 2 || len(cfg.Strategies) > 6 {
+		return SimulationReport{}, fmt.Errorf("a simulation needs 2 to 6 bots, got %d", len(cfg.Strategies))
+	}
+	if cfg.Table.BigBlind == 0 {
+		cfg.Table = DefaultTables()[0]
+	}
+	config := Config{Tables: []TableConfig{cfg.Table}, Rake: cfg.Rake}
+	if err := config.Validate(); err != nil {
+		return SimulationReport{}, err
+	}
+
+	server := NewServerWithConfig(slog.New(slog.NewTextHandler(io.Discard, nil)), config)
+	table := server.tables[0]
+	events, unsubscribe := server.events.Subscribe()
+	defer unsubscribe()
+
+	report := SimulationReport{Hands: cfg.Hands}
+	stats := make(map[string]*StrategyStats)
+	bots := make([]simBot, len(cfg.Strategies))
+	for i, name := range cfg.Strategies {
+		play, ok := simStrategies[name]
+		if !ok {
+			return SimulationReport{}, fmt.Errorf("unknown strategy %q (known: %s)", name, strings.Join(SimStrategyNames(), ", "))
+		}
+		session, err := server.sessionManager.CreateSession(fmt.Sprintf("bot%d-%s", i+1, name))
+		if err != nil {
+			return SimulationReport{}, err
+		}
+		bots[i] = simBot{token: session.Token, strategy: name, play: play}
+		if stats[name] == nil {
+			stats[name] = &StrategyStats{Strategy: name}
+			report.Strategies = append(report.Strategies, StrategyStats{Strategy: name})
+		}
+		stats[name].Bots++
+	}
+
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	var history *json.Encoder
+	if cfg.History != nil {
+		history = json.NewEncoder(cfg.History)
+	}
+	for handNumber := 1; handNumber <= cfg.Hands; handNumber++ {
+		// Everyone who busted buys in again, so the table stays full
+		stacks := make(map[int]int)
+		seatBot := make(map[int]simBot)
+		for _, bot := range bots {
+			seat, seated := table.GetSeatByToken(&bot.token)
+			if !seated {
+				var err error
+				if seat, err = server.seatPlayer(bot.token, "", table); err != nil {
+					return report, fmt.Errorf("hand %d: reseating %s: %w", handNumber, bot.strategy, err)
+				}
+				if handNumber > 1 {
+					stats[bot.strategy].Rebuys++
+				}
+			}
+			stacks[seat.Index] = seat.Stack
+			seatBot[seat.Index] = bot
+		}
+
+		record, err := playSimHand(server, table, events, seatBot, rng)
+		if err != nil {
+			return report, fmt.Errorf("hand %d: %w", handNumber, err)
+		}
+		record.Hand = handNumber
+		record.Net = make(map[int]int)
+		for seat, bot := range seatBot {
+			after, _ := table.GetSeatByToken(&bot.token)
+			record.Net[seat] = after.Stack - stacks[seat]
+
+			s := stats[bot.strategy]
+			s.HandsDealt++
+			s.NetChips += record.Net[seat]
+			if record.Winnings[seat] > 0 {
+				s.HandsWon++
+			}
+			if _, folded := record.folded[seat]; record.Showdown && !folded {
+				s.Showdowns++
+				if record.Winnings[seat] > 0 {
+					s.ShowdownsWon++
+				}
+			}
+		}
+		if record.Showdown {
+			report.Showdowns++
+		}
+
+		if history != nil {
+			if err := history.Encode(record); err != nil {
+				return report, fmt.Errorf("writing hand history: %w", err)
+			}
+		}
+	}
+
+	table.mu.RLock()
+	report.Rake = table.RakeCollected
+	table.mu.RUnlock()
+	for i, entry := range report.Strategies {
+		s := *stats[entry.Strategy]
+		if s.HandsDealt > 0 {
+			s.ChipsPerHand = float64(s.NetChips) / float64(s.HandsDealt)
+			s.BBPer100 = s.ChipsPerHand / float64(cfg.Table.BigBlind) * 100
+		}
+		report.Strategies[i] = s
+	}
+	return report, nil
+}
+
+// playSimHand deals one hand and lets the bots act until it is over
+func playSimHand(server *Server, table *Table, events <-chan Event, seatBot map[int]simBot, rng *rand.Rand) (*HandHistory, error) {
+	if err := table.StartHand(); err != nil {
+		return nil, fmt.Errorf("starting hand: %w", err)
+	}
+
+	record := &HandHistory{Players: make(map[int]string), HoleCards: make(map[int][]Card), folded: make(map[int]struct{})}
+	table.mu.RLock()
+	record.Dealer = table.CurrentHand.DealerSeat
+	for seat, cards := range table.CurrentHand.HoleCards {
+		record.HoleCards[seat] = slices.Clone(cards)
+		record.Players[seat] = seatBot[seat].strategy
+	}
+	table.mu.RUnlock()
+
+	for actions := 0; ; actions++ {
+		// Streets are dealt straight away without pacing, so the events are all queued by now
+		record.collect(events)
+
+		table.mu.RLock()
+		hand := table.CurrentHand
+		if hand == nil {
+			table.mu.RUnlock()
+			return record, nil
+		}
+		if hand.CurrentActor == nil || actions == maxSimActions {
+			table.mu.RUnlock()
+			return nil, fmt.Errorf("hand stuck on the %s", hand.Street)
+		}
+		seat := *hand.CurrentActor
+		view := BotView{
+			Seat:         seat,
+			Street:       hand.Street,
+			HoleCards:    slices.Clone(hand.HoleCards[seat]),
+			Board:        slices.Clone(hand.BoardCards),
+			Texture:      ClassifyBoard(hand.BoardCards),
+			ValidActions: hand.GetValidActions(seat, table.Seats[seat].Stack, table.Seats),
+			CallAmount:   hand.GetCallAmount(seat),
+			Pot:          hand.Pot,
+			Stack:        table.Seats[seat].Stack,
+			MinRaise:     hand.GetMinRaise(),
+			MaxRaise:     table.GetMaxRaise(seat, hand),
+			BigBlind:     table.BigBlind,
+		}
+		for _, bet := range hand.PlayerBets {
+			view.Pot += bet
+		}
+		table.mu.RUnlock()
+
+		action, amount := seatBot[seat].play(view, rng)
+		var err error
+		if action == "raise" {
+			err = server.processTableAction(context.Background(), table, nil, "", seat, action, amount)
+		} else {
+			err = server.processTableAction(context.Background(), table, nil, "", seat, action)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("seat %d (%s) %s %d: %w", seat, seatBot[seat].strategy, action, amount, err)
+		}
+	}
+}
+
+// collect adds the queued events of the hand to the record
+func (h *HandHistory) collect(events <-chan Event) {
+	for {
+		select {
+		case e := <-events:
+			h.add(e)
+		default:
+			return
+		}
+	}
+}
+
+// add records one event of the hand
+func (h *HandHistory) add(e Event) {
+	switch e.Type {
+	case EventPlayerAction:
+		h.Actions = append(h.Actions, HistoryAction{Seat: e.SeatIndex, Street: e.Street, Action: e.Action, Amount: e.Amount, Timeout: e.Timeout})
+		if e.Action == "fold" {
+			h.folded[e.SeatIndex] = struct{}{}
+		}
+	case EventBoardDealt:
+		h.Board = slices.Clone(e.Board)
+	case EventHandEnded:
+		h.Winnings = maps.Clone(e.Winnings)
+		if e.WinningRank != nil {
+			h.Showdown = true
+			if e.WinningRank.Rank >= 0 && e.WinningRank.Rank < len(handRankIDs) {
+				h.WinningHand = handRankIDs[e.WinningRank.Rank]
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// TestRunSimulation_ChipsAreConserved verifies every chip lost by one bot is won by another or
+// raked, and that one history line is written per hand
+func TestRunSimulation_ChipsAreConserved(t *testing.T) {
+	var history bytes.Buffer
+	report, err := RunSimulation(SimulationConfig{
+		Hands:      200,
+		Strategies: []string{"tight", "maniac", "calling_station", "random"},
+		Seed:       7,
+		Rake:       RakeConfig{Percent: 5, Cap: 30},
+		History:    &history,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Hands != 200 {
+		t.Errorf("expected 200 hands, got %d", report.Hands)
+	}
+	net := 0
+	for _, stats := range report.Strategies {
+		net += stats.NetChips
+		if stats.HandsDealt == 0 {
+			t.Errorf("%s: expected hands to be dealt", stats.Strategy)
+		}
+	}
+	if net+report.Rake != 0 {
+		t.Errorf("expected nets to add up to minus the rake %d, got %d", report.Rake, net)
+	}
+
+	lines := 0
+	scanner := bufio.NewScanner(&history)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var hand HandHistory
+		if err := json.Unmarshal(scanner.Bytes(), &hand); err != nil {
+			t.Fatalf("line %d: %v", lines+1, err)
+		}
+		lines++
+		sum := 0
+		for _, chips := range hand.Net {
+			sum += chips
+		}
+		if sum > 0 {
+			t.Errorf("hand %d: the bots won %d chips more than they put in", hand.Hand, sum)
+		}
+	}
+	if lines != 200 {
+		t.Errorf("expected 200 hand histories, got %d", lines)
+	}
+}
+
+// TestRunSimulation_RejectsBadConfig verifies unknown strategies and too few bots are rejected
+func TestRunSimulation_RejectsBadConfig(t *testing.T) {
+	if _, err := RunSimulation(SimulationConfig{Hands: 1, Strategies: []string{"tight", "shark"}}); err == nil {
+		t.Error("expected an error for an unknown strategy")
+	}
+	if _, err := RunSimulation(SimulationConfig{Hands: 1, Strategies: []string{"tight"}}); err == nil {
+		t.Error("expected an error for a single bot")
+	}
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Session policies decide what happens when a token that already has an open
+// WebSocket connects again
+const (
+	// SessionPolicyTakeover closes the old connection and moves the session to the new one
+	SessionPolicyTakeover = "takeover"
+	// SessionPolicyReject refuses the new connection while the old one is open
+	SessionPolicyReject = "reject"
+)
+
+// closeNoticeDelay gives a connection about to be closed time to receive its last message
+const closeNoticeDelay = 10 * time.Millisecond
+
+// errSessionInUse is returned when SessionPolicyReject refuses a second connection
+var errSessionInUse = errors.New("session_in_use")
+
+// validateSessionPolicy reports an unknown session policy; empty means takeover
+func validateSessionPolicy(policy string) error {
+	switch policy {
+	case "", SessionPolicyTakeover, SessionPolicyReject:
+		return nil
+	default:
+		return fmt.Errorf("sessionPolicy must be %q or %q", SessionPolicyTakeover, SessionPolicyReject)
+	}
+}
+
+// claimSession makes c the only connection holding token and sets c.Token
+// If another connection holds the token, policy decides: reject returns errSessionInUse and
+// leaves everything unchanged; takeover marks the old connection as replaced, queues
+// session_taken_over for it and returns it so the caller can close it
+func (h *Hub) claimSession(c *Client, token string, policy string) (*Client, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	previous := h.sessions[token]
+	if previous == c {
+		previous = nil
+	}
+
+	if previous != nil {
+		if policy == SessionPolicyReject {
+			return nil, errSessionInUse
+		}
+
+		previous.takenOver.Store(true)
+		// The hub lock keeps previous.send open; never block while holding it
+		if message, err := marshalMessage("session_taken_over", SessionEndedPayload{Reason: "taken_over"}); err == nil {
+			select {
+			case previous.send <- message:
+			default:
+				h.logger.Warn("client send channel full, dropping session_taken_over")
+			}
+		}
+	}
+
+	if c.Token != "" && c.Token != token && h.sessions[c.Token] == c {
+		delete(h.sessions, c.Token)
+	}
+	h.sessions[token] = c
+	c.Token = token
+	return previous, nil
+}
+
+// releaseSessionLocked drops c's claim on its token, if it still holds it (caller must hold h.mu)
+func (h *Hub) releaseSessionLocked(c *Client) {
+	if c.Token != "" && h.sessions[c.Token] == c {
+		delete(h.sessions, c.Token)
+	}
+}
+
+// setToken binds the client to token, or unbinds it when token is empty
+// Tokens set here are freshly created, so the session policy never applies
+func (c *Client) setToken(token string) {
+	if c.hub == nil {
+		c.Token = token
+		return
+	}
+
+	if token != "" {
+		c.hub.claimSession(c, token, SessionPolicyTakeover)
+		return
+	}
+
+	c.hub.mu.Lock()
+	c.hub.releaseSessionLocked(c)
+	c.Token = ""
+	c.hub.mu.Unlock()
+}
+
+// closeAfterNotice closes the client's connection once a final notification had time to go out
+// A connection replaced by a takeover then exits its read loop without unseating the player,
+// since the seat now belongs to the new connection
+func (c *Client) closeAfterNotice() {
+	if c.conn == nil {
+		return
+	}
+	time.AfterFunc(closeNoticeDelay, func() {
+		c.conn.Close()
+	})
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// dialWithToken opens a WebSocket to testServer carrying token and reads the first message
+func dialWithToken(t *testing.T, testServer *httptest.Server, token string) (*websocket.Conn, WebSocketMessage) {
+	t.Helper()
+	wsURL := "ws" + strings.TrimPrefix(testServer.URL, "http") + "?token=" + token
+	ws, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	ws.SetReadDeadline(time.Now().Add(2 * time.Second))
+	return ws, readMessage(t, ws)
+}
+
+// readUntilType reads messages until one of msgType arrives, returning false if the connection ends first
+func readUntilType(ws *websocket.Conn, msgType string) bool {
+	for {
+		var msg WebSocketMessage
+		if err := ws.ReadJSON(&msg); err != nil {
+			return false
+		}
+		if msg.Type == msgType {
+			return true
+		}
+	}
+}
+
+// TestSessionTakeover_ClosesOldConnectionKeepsSeat verifies a second connection takes over
+// the session, the first is notified and closed, and the player keeps their seat
+func TestSessionTakeover_ClosesOldConnectionKeepsSeat(t *testing.T) {
+	server := NewServer(slog.Default())
+	session, err := server.sessionManager.CreateSession("Grace")
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	table := server.tables[0]
+	if _, err := table.AssignSeat(&session.Token); err != nil {
+		t.Fatalf("AssignSeat failed: %v", err)
+	}
+
+	testServer := httptest.NewServer(server.HandleWebSocket(server.hub))
+	defer testServer.Close()
+
+	ws1, msg1 := dialWithToken(t, testServer, session.Token)
+	defer ws1.Close()
+	if msg1.Type != "session_restored" {
+		t.Fatalf("expected session_restored, got %q", msg1.Type)
+	}
+
+	ws2, msg2 := dialWithToken(t, testServer, session.Token)
+	defer ws2.Close()
+	if msg2.Type != "session_restored" {
+		t.Fatalf("expected session_restored for the new connection, got %q", msg2.Type)
+	}
+
+	if !readUntilType(ws1, "session_taken_over") {
+		t.Fatal("expected old connection to receive session_taken_over")
+	}
+	var msg WebSocketMessage
+	if err := ws1.ReadJSON(&msg); err == nil {
+		t.Errorf("expected old connection to be closed, got %q", msg.Type)
+	}
+
+	// Give the old connection's read loop time to run its disconnect handling
+	time.Sleep(50 * time.Millisecond)
+	if _, found := table.GetSeatByToken(&session.Token); !found {
+		t.Error("expected the seat to survive the takeover")
+	}
+
+	server.hub.mu.RLock()
+	holder := server.hub.sessions[session.Token]
+	server.hub.mu.RUnlock()
+	if holder == nil || holder.takenOver.Load() {
+		t.Error("expected the new connection to hold the session")
+	}
+}
+
+// TestSessionPolicyReject_RefusesSecondConnection verifies the reject policy keeps the first connection
+func TestSessionPolicyReject_RefusesSecondConnection(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{SessionPolicy: SessionPolicyReject})
+	session, err := server.sessionManager.CreateSession("Grace")
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	testServer := httptest.NewServer(server.HandleWebSocket(server.hub))
+	defer testServer.Close()
+
+	ws1, msg1 := dialWithToken(t, testServer, session.Token)
+	defer ws1.Close()
+	if msg1.Type != "session_restored" {
+		t.Fatalf("expected session_restored, got %q", msg1.Type)
+	}
+
+	ws2, msg2 := dialWithToken(t, testServer, session.Token)
+	defer ws2.Close()
+	if msg2.Type != "error" {
+		t.Fatalf("expected error for the second connection, got %q", msg2.Type)
+	}
+	var errorPayload ErrorPayload
+	if err := json.Unmarshal(msg2.Payload, &errorPayload); err != nil {
+		t.Fatalf("failed to unmarshal payload: %v", err)
+	}
+	if errorPayload.Message != "session_in_use" {
+		t.Errorf("expected session_in_use, got %q", errorPayload.Message)
+	}
+
+	// The first connection keeps working
+	sendMessage(t, ws1, "renew_session", struct{}{})
+	if !readUntilType(ws1, "session_renewed") {
+		t.Error("expected the first connection to keep its session")
+	}
+}
+
+// TestValidateSessionPolicy verifies only known policies are accepted
+func TestValidateSessionPolicy(t *testing.T) {
+	for _, policy := range []string{"", SessionPolicyTakeover, SessionPolicyReject} {
+		if err := validateSessionPolicy(policy); err != nil {
+			t.Errorf("expected %q to be valid: %v", policy, err)
+		}
+	}
+	if err := validateSessionPolicy("kick"); err == nil {
+		t.Error("expected unknown policy to be rejected")
+	}
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"fmt"
+	"time"
+)
+
+// Table speeds, chosen per table in the config and listed in the lobby
+const (
+	SpeedRegular = "regular" // The configured timers as they are
+	SpeedTurbo   = "turbo"   // Half the action clock, inter-hand delay and street pacing
+	SpeedHyper   = "hyper"   // A quarter of them
+)
+
+// speedDivisors maps each speed to how much faster than regular it runs
+var speedDivisors = map[string]time.Duration{
+	SpeedRegular: 1,
+	SpeedTurbo:   2,
+	SpeedHyper:   4,
+}
+
+// validateSpeed reports an unknown speed; empty means regular
+func validateSpeed(speed string) error {
+	if _, ok := speedDivisors[speed]; speed != "" && !ok {
+		return fmt.Errorf("speed must be %q, %q or %q", SpeedRegular, SpeedTurbo, SpeedHyper)
+	}
+	return nil
+}
+
+// speedUp shortens d for the table's speed
+func (t *Table) speedUp(d time.Duration) time.Duration {
+	divisor, ok := speedDivisors[t.Speed]
+	if !ok {
+		return d
+	}
+	return d / divisor
+}
+
+// actionTimeoutLocked returns how long a player at this table has to act (0 = no clock)
+// (internal, must be called with lock held)
+func (t *Table) actionTimeoutLocked() time.Duration {
+	return t.speedUp(t.rulesLocked().actionTimeout)
+}
+
+// nextHandDelay returns the pause between hands at this table (0 = no automatic start)
+func (t *Table) nextHandDelay() time.Duration {
+	if t.Server == nil {
+		return 0
+	}
+	return t.speedUp(t.Server.Config().NextHandDelay)
+}
+
+// pacingDelayLocked returns the pause before street is dealt at this table
+// (internal, must be called with lock held)
+func (t *Table) pacingDelayLocked(street string) time.Duration {
+	return t.speedUp(t.rulesLocked().pacing.delay(street))
+}
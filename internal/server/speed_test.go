@@ -0,0 +1,76 @@
+package server
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// TestTableSpeed_ScalesTimers verifies turbo and hyper tables shorten every configured timer
+func TestTableSpeed_ScalesTimers(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{
+		ActionTimeout: 40 * time.Second,
+		NextHandDelay: 8 * time.Second,
+		Pacing:        PacingConfig{Flop: 2 * time.Second, Turn: 2 * time.Second, River: 2 * time.Second},
+		Tables: []TableConfig{
+			{Name: "Regular", SmallBlind: 10, BigBlind: 20, BuyIn: 1000},
+			{Name: "Turbo", SmallBlind: 10, BigBlind: 20, BuyIn: 1000, Speed: SpeedTurbo},
+			{Name: "Hyper", SmallBlind: 10, BigBlind: 20, BuyIn: 1000, Speed: SpeedHyper},
+		},
+	})
+
+	want := []struct {
+		speed                string
+		action, next, street time.Duration
+	}{
+		{SpeedRegular, 40 * time.Second, 8 * time.Second, 2 * time.Second},
+		{SpeedTurbo, 20 * time.Second, 4 * time.Second, time.Second},
+		{SpeedHyper, 10 * time.Second, 2 * time.Second, 500 * time.Millisecond},
+	}
+	for i, w := range want {
+		table := server.tables[i]
+		if table.Speed != w.speed {
+			t.Errorf("%s: expected speed %s, got %s", table.Name, w.speed, table.Speed)
+		}
+		if got := table.actionTimeoutLocked(); got != w.action {
+			t.Errorf("%s: action timeout %s, expected %s", table.Name, got, w.action)
+		}
+		if got := table.nextHandDelay(); got != w.next {
+			t.Errorf("%s: next hand delay %s, expected %s", table.Name, got, w.next)
+		}
+		if got := table.pacingDelayLocked("turn"); got != w.street {
+			t.Errorf("%s: turn pacing %s, expected %s", table.Name, got, w.street)
+		}
+	}
+
+	if tables, _ := server.QueryLobby(LobbyFilter{Speed: SpeedHyper}); len(tables) != 1 || tables[0].Name != "Hyper" {
+		t.Errorf("expected only the hyper table listed, got %+v", tables)
+	}
+}
+
+// TestTableSpeed_ActionClockUsesTableSpeed verifies the action deadline follows the table's speed
+func TestTableSpeed_ActionClockUsesTableSpeed(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{
+		ActionTimeout: time.Minute,
+		Tables:        []TableConfig{{Name: "Hyper", SmallBlind: 10, BigBlind: 20, BuyIn: 1000, Speed: SpeedHyper}},
+	})
+	table := server.tables[0]
+
+	table.mu.Lock()
+	table.startActionClockLocked(0)
+	deadline := *table.ActionDeadline
+	table.stopActionClockLocked()
+	table.mu.Unlock()
+
+	if remaining := time.Until(deadline); remaining > 15*time.Second || remaining < 14*time.Second {
+		t.Errorf("expected a 15s clock, got %s", remaining)
+	}
+}
+
+// TestConfigValidate_RejectsUnknownSpeed verifies only the speed presets are accepted
+func TestConfigValidate_RejectsUnknownSpeed(t *testing.T) {
+	config := Config{Tables: []TableConfig{{Name: "Warp", SmallBlind: 10, BigBlind: 20, BuyIn: 1000, Speed: "warp"}}}
+	if err := config.Validate(); err == nil {
+		t.Error("expected unknown speed to be rejected")
+	}
+}
@@ -0,0 +1,58 @@
+package server
+
+import "math"
+
+// StackMetrics are figures derived from a seat's stack, computed by the server so every client
+// and bot shows the same values. Ratios are rounded to one decimal.
+type StackMetrics struct {
+	BigBlinds float64 `json:"bigBlinds"` // Stack in big blinds
+	M         float64 `json:"m"`         // Stack over the cost of one orbit: the blinds plus every ante
+	// EffectiveStack is the most the seat can win from or lose to the player who made the bet
+	// being faced: the smaller of their stacks, counting both players' bets on this street.
+	// Only set during a hand for seats still in it, other than the aggressor.
+	EffectiveStack *int `json:"effectiveStack,omitempty"`
+}
+
+// roundTenth rounds x to one decimal
+func roundTenth(x float64) float64 {
+	return math.Round(x*10) / 10
+}
+
+// stackMetricsLocked computes the metrics of every occupied seat but the reserved ones, which
+// have no stack yet (caller must hold t.mu)
+func (t *Table) stackMetricsLocked() map[int]StackMetrics {
+	players := 0
+	for _, seat := range t.Seats {
+		if seat.Token != nil && seat.Status != "reserved" {
+			players++
+		}
+	}
+	// There are no antes yet; each would add ante*players to the orbit
+	orbit := t.SmallBlind + t.BigBlind
+
+	hand := t.CurrentHand
+	var aggressor *int
+	if hand != nil && hand.Aggressor != nil && !hand.FoldedPlayers[*hand.Aggressor] {
+		aggressor = hand.Aggressor
+	}
+
+	metrics := make(map[int]StackMetrics, players)
+	for i, seat := range t.Seats {
+		if seat.Token == nil || seat.Status == "reserved" {
+			continue
+		}
+		m := StackMetrics{}
+		if t.BigBlind > 0 {
+			m.BigBlinds = roundTenth(float64(seat.Stack) / float64(t.BigBlind))
+		}
+		if orbit > 0 {
+			m.M = roundTenth(float64(seat.Stack) / float64(orbit))
+		}
+		if aggressor != nil && *aggressor != i && len(hand.HoleCards[i]) > 0 && !hand.FoldedPlayers[i] {
+			effective := min(seat.Stack+hand.PlayerBets[i], t.Seats[*aggressor].Stack+hand.PlayerBets[*aggressor])
+			m.EffectiveStack = &effective
+		}
+		metrics[i] = m
+	}
+	return metrics
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"log/slog"
+	"testing"
+)
+
+// TestStackMetrics_NoHand verifies big blinds and M for seated players between hands
+func TestStackMetrics_NoHand(t *testing.T) {
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+	seatTwoPlayers(table)
+	table.mu.Lock()
+	table.SmallBlind, table.BigBlind = 10, 20
+	table.Seats[1].Stack = 455
+	metrics := table.stackMetricsLocked()
+	table.mu.Unlock()
+
+	if len(metrics) != 2 {
+		t.Fatalf("expected metrics for 2 seats, got %d", len(metrics))
+	}
+	if m := metrics[0]; m.BigBlinds != 50 || m.M != 33.3 || m.EffectiveStack != nil {
+		t.Errorf("seat 0: unexpected metrics %+v", m)
+	}
+	if m := metrics[1]; m.BigBlinds != 22.8 || m.M != 15.2 {
+		t.Errorf("seat 1: unexpected metrics %+v", m)
+	}
+}
+
+// TestStackMetrics_EffectiveStackAgainstAggressor verifies effective stacks follow the player
+// who made the bet being faced and skip folded seats
+func TestStackMetrics_EffectiveStackAgainstAggressor(t *testing.T) {
+	server, table, _ := preActionTable(t)
+
+	table.mu.RLock()
+	bb := table.CurrentHand.BigBlindSeat
+	metrics := table.stackMetricsLocked()
+	table.mu.RUnlock()
+	for seat, m := range metrics {
+		switch {
+		case seat == bb && m.EffectiveStack != nil:
+			t.Errorf("the big blind has an effective stack against themselves: %d", *m.EffectiveStack)
+		case seat != bb && (m.EffectiveStack == nil || *m.EffectiveStack != 1000):
+			t.Errorf("seat %d: expected an effective stack of 1000 against the big blind, got %+v", seat, m)
+		}
+	}
+
+	// The first actor raises with a short stack, capping everyone's effective stack at theirs
+	raiser := currentActor(table)
+	table.mu.Lock()
+	table.Seats[raiser].Stack = 300
+	table.mu.Unlock()
+	actCurrent(t, server, table, "raise", 100)
+	folder := currentActor(table)
+	actCurrent(t, server, table, "fold")
+
+	table.mu.RLock()
+	metrics = table.stackMetricsLocked()
+	table.mu.RUnlock()
+	if m := metrics[folder]; m.EffectiveStack != nil {
+		t.Errorf("folded seat %d still has an effective stack of %d", folder, *m.EffectiveStack)
+	}
+	if m := metrics[bb]; m.EffectiveStack == nil || *m.EffectiveStack != 300 {
+		t.Errorf("big blind: expected an effective stack of 300 against the raiser, got %+v", m)
+	}
+	if m := metrics[raiser]; m.BigBlinds != 10 || m.EffectiveStack != nil {
+		t.Errorf("raiser: expected 10 big blinds behind and no effective stack, got %+v", m)
+	}
+}
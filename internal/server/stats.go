@@ -0,0 +1,231 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// PlayerStats are the public statistics shown for a seat on tables with ShowStats
+type PlayerStats struct {
+	HandsPlayed int `json:"handsPlayed"` // Hands dealt to the player at this table this session
+	VPIP        int `json:"vpip"`        // Percentage of hands this session where the player voluntarily put chips in preflop
+}
+
+// sessionStats accumulates one session's statistics
+type sessionStats struct {
+	handsDealt   int
+	vpipHands    int
+	handsByTable map[string]int
+	// vpipCounted is set once the current hand counted toward VPIP, so a call
+	// followed by a raise in the same hand is counted once
+	vpipCounted bool
+}
+
+// sitting accumulates one stay at a table, from taking a seat to leaving it
+type sitting struct {
+	tableID    string
+	name       string
+	seatedAt   time.Time
+	hands      int
+	net        int
+	biggestPot int
+	rake       int
+	committed  int // Chips put into the hand in progress, given back if it is cancelled
+}
+
+// StatsTracker consumes table events and keeps per-session statistics
+// Sessions are keyed by token, so statistics start afresh with every new session.
+// The nil StatsTracker has no statistics.
+type StatsTracker struct {
+	mu       sync.Mutex
+	players  map[string]*sessionStats
+	sittings map[string]*sitting       // By token, while the player is seated
+	dealtIn  map[string]map[int]string // Token per seat dealt into each table's current hand
+	// superlatives are the records of the last superlativeDays days, by day then table ID
+	superlatives map[string]map[string]Superlatives
+
+	playerName   func(token string) string
+	sessionEnded func(token, name string, summary SessionSummary)
+}
+
+// NewStatsTracker creates an empty StatsTracker
+// playerName names a player as they sit down, and sessionEnded, if not nil, receives the
+// summary of each stay at a table when the player leaves it.
+func NewStatsTracker(playerName func(token string) string, sessionEnded func(token, name string, summary SessionSummary)) *StatsTracker {
+	return &StatsTracker{
+		players:      make(map[string]*sessionStats),
+		sittings:     make(map[string]*sitting),
+		dealtIn:      make(map[string]map[int]string),
+		superlatives: make(map[string]map[string]Superlatives),
+		playerName:   playerName,
+		sessionEnded: sessionEnded,
+	}
+}
+
+// Run handles events until the channel is closed
+func (st *StatsTracker) Run(events <-chan Event) {
+	for e := range events {
+		st.handle(e)
+	}
+}
+
+// handle updates the statistics with e and reports a finished stay at a table
+func (st *StatsTracker) handle(e Event) {
+	name, summary, ended := st.record(e)
+	if ended && st.sessionEnded != nil {
+		st.sessionEnded(e.Token, name, summary)
+	}
+}
+
+// record updates the statistics with e
+// Returns the player's name and summary when e ends their stay at a table
+func (st *StatsTracker) record(e Event) (string, SessionSummary, bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.recordSuperlativesLocked(e)
+
+	switch e.Type {
+	case EventPlayerSeated:
+		name := ""
+		if st.playerName != nil {
+			name = st.playerName(e.Token)
+		}
+		st.sittings[e.Token] = &sitting{tableID: e.TableID, name: name, seatedAt: e.Time}
+	case EventSeatTransferred:
+		st.transferPlayerLocked(e)
+	case EventPlayerLeft:
+		stay, ok := st.sittings[e.Token]
+		if !ok || stay.tableID != e.TableID {
+			return "", SessionSummary{}, false
+		}
+		delete(st.sittings, e.Token)
+		return stay.name, SessionSummary{
+			TableID:     stay.tableID,
+			SeatedAt:    stay.seatedAt.UnixMilli(),
+			LeftAt:      e.Time.UnixMilli(),
+			DurationMs:  e.Time.Sub(stay.seatedAt).Milliseconds(),
+			HandsPlayed: stay.hands,
+			NetChips:    stay.net,
+			BiggestPot:  stay.biggestPot,
+			Rake:        stay.rake,
+		}, true
+	case EventHandStarted:
+		for _, token := range e.Players {
+			stats := st.playerLocked(token)
+			stats.handsDealt++
+			stats.handsByTable[e.TableID]++
+			stats.vpipCounted = false
+		}
+		st.dealtIn[e.TableID] = e.Seats
+		for seat, token := range e.Seats {
+			if stay := st.sittingLocked(token, e.TableID); stay != nil {
+				stay.hands++
+				stay.committed = e.Blinds[seat]
+				stay.net -= e.Blinds[seat]
+			}
+		}
+	case EventPlayerAction:
+		if stay := st.sittingLocked(e.Token, e.TableID); stay != nil {
+			stay.committed += e.Amount
+			stay.net -= e.Amount
+		}
+		// Only calls and raises count; checking the big blind option or posting blinds does not
+		if e.Street != "preflop" || (e.Action != "call" && e.Action != "raise") {
+			break
+		}
+		stats, ok := st.players[e.Token]
+		if !ok || stats.vpipCounted {
+			break
+		}
+		stats.vpipHands++
+		stats.vpipCounted = true
+	case EventHandEnded:
+		for seat, token := range st.dealtIn[e.TableID] {
+			if stay := st.sittingLocked(token, e.TableID); stay != nil {
+				stay.net += e.Winnings[seat]
+				stay.biggestPot = max(stay.biggestPot, e.Winnings[seat])
+				stay.rake += e.Rake[seat]
+				stay.committed = 0
+			}
+		}
+		delete(st.dealtIn, e.TableID)
+	case EventHandCancelled:
+		// The chips put into a cancelled hand are refunded
+		for _, token := range st.dealtIn[e.TableID] {
+			if stay := st.sittingLocked(token, e.TableID); stay != nil {
+				stay.net += stay.committed
+				stay.committed = 0
+			}
+		}
+		delete(st.dealtIn, e.TableID)
+	}
+	return "", SessionSummary{}, false
+}
+
+// sittingLocked returns token's stay at tableID, nil if they are not seated there
+// (caller must hold st.mu)
+func (st *StatsTracker) sittingLocked(token, tableID string) *sitting {
+	stay, ok := st.sittings[token]
+	if !ok || stay.tableID != tableID {
+		return nil
+	}
+	return stay
+}
+
+// playerLocked returns the statistics of token, creating them if needed (caller must hold st.mu)
+func (st *StatsTracker) playerLocked(token string) *sessionStats {
+	stats, ok := st.players[token]
+	if !ok {
+		stats = &sessionStats{handsByTable: make(map[string]int)}
+		st.players[token] = stats
+	}
+	return stats
+}
+
+// Stats returns the statistics of token as shown at tableID
+// Returns false if the player has not been dealt a hand this session
+func (st *StatsTracker) Stats(token, tableID string) (PlayerStats, bool) {
+	if st == nil {
+		return PlayerStats{}, false
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	stats, ok := st.players[token]
+	if !ok || stats.handsDealt == 0 {
+		return PlayerStats{}, false
+	}
+	return PlayerStats{
+		HandsPlayed: stats.handsByTable[tableID],
+		VPIP:        (stats.vpipHands*100 + stats.handsDealt/2) / stats.handsDealt,
+	}, true
+}
+
+// OpenStays returns the net chips of the players still seated at tableID, by name, for stays
+// with a hand dealt
+func (st *StatsTracker) OpenStays(tableID string) map[string]int {
+	if st == nil {
+		return nil
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	open := make(map[string]int)
+	for _, stay := range st.sittings {
+		if stay.tableID == tableID && stay.name != "" && stay.hands > 0 {
+			open[stay.name] += stay.net
+		}
+	}
+	return open
+}
+
+// Forget drops the statistics of an ended session
+func (st *StatsTracker) Forget(token string) {
+	if st == nil {
+		return
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	delete(st.players, token)
+}
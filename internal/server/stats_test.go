@@ -0,0 +1,86 @@
+package server
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// TestStatsTracker_HandsAndVPIP verifies hands are counted per table and VPIP once per hand
+func TestStatsTracker_HandsAndVPIP(t *testing.T) {
+	stats := NewStatsTracker(nil, nil)
+
+	stats.handle(Event{Type: EventHandStarted, TableID: "table-1", Players: []string{"alice", "bob"}})
+	stats.handle(Event{Type: EventPlayerAction, TableID: "table-1", Token: "alice", Street: "preflop", Action: "call"})
+	stats.handle(Event{Type: EventPlayerAction, TableID: "table-1", Token: "alice", Street: "preflop", Action: "raise"})
+	stats.handle(Event{Type: EventPlayerAction, TableID: "table-1", Token: "bob", Street: "preflop", Action: "check"})
+	stats.handle(Event{Type: EventPlayerAction, TableID: "table-1", Token: "bob", Street: "flop", Action: "raise"})
+
+	stats.handle(Event{Type: EventHandStarted, TableID: "table-2", Players: []string{"alice"}})
+	stats.handle(Event{Type: EventPlayerAction, TableID: "table-2", Token: "alice", Street: "preflop", Action: "fold"})
+
+	if got, _ := stats.Stats("alice", "table-1"); got != (PlayerStats{HandsPlayed: 1, VPIP: 50}) {
+		t.Errorf("alice at table-1: got %+v", got)
+	}
+	if got, _ := stats.Stats("alice", "table-2"); got.HandsPlayed != 1 {
+		t.Errorf("alice at table-2: got %+v", got)
+	}
+	if got, _ := stats.Stats("bob", "table-1"); got != (PlayerStats{HandsPlayed: 1, VPIP: 0}) {
+		t.Errorf("bob: got %+v", got)
+	}
+
+	stats.Forget("alice")
+	if _, ok := stats.Stats("alice", "table-1"); ok {
+		t.Error("expected no statistics after Forget")
+	}
+}
+
+// TestBuildTableState_StatsFollowPrivacyFlag verifies statistics only appear on tables with ShowStats
+func TestBuildTableState_StatsFollowPrivacyFlag(t *testing.T) {
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+	alice, _ := seatSessionPlayers(t, server, table)
+	table.mu.Lock()
+	for i := range table.Seats {
+		if table.Seats[i].Token != nil {
+			table.Seats[i].Status = "active"
+		}
+	}
+	table.mu.Unlock()
+
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("StartHand failed: %v", err)
+	}
+
+	// Statistics are built asynchronously from the event bus
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if _, ok := server.stats.Stats(alice, table.ID); ok {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	for _, seat := range server.buildTableState(table, nil).Seats {
+		if seat.Stats != nil {
+			t.Fatalf("seat %d shows stats on a table without ShowStats", seat.Index)
+		}
+	}
+
+	table.mu.Lock()
+	table.ShowStats = true
+	table.mu.Unlock()
+
+	shown := 0
+	for _, seat := range server.buildTableState(table, nil).Seats {
+		if seat.Stats != nil {
+			shown++
+			if seat.Stats.HandsPlayed != 1 {
+				t.Errorf("seat %d: expected 1 hand played, got %+v", seat.Index, seat.Stats)
+			}
+		}
+	}
+	if shown != 2 {
+		t.Errorf("expected stats for 2 seats, got %d", shown)
+	}
+}
@@ -0,0 +1,232 @@
+package server
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// superlativeDays is how many days of superlatives the StatsTracker keeps, today included
+const superlativeDays = 7
+
+// superlativeTableLimit is how many tables' records the StatsTracker keeps for one day. Past it,
+// the table whose records are oldest is dropped, so a day of tables opened and closed one after
+// another does not grow without bound.
+const superlativeTableLimit = 500
+
+// Superlatives are a table's records for one day (UTC): the biggest pot, the best hand shown
+// down and the quickest bust-out after sitting down. Records nobody set yet are nil.
+type Superlatives struct {
+	BiggestPot         *PotRecord  `json:"biggestPot,omitempty"`
+	BestHand           *HandRecord `json:"bestHand,omitempty"`
+	FastestElimination *BustRecord `json:"fastestElimination,omitempty"`
+}
+
+// PotRecord is the biggest pot of a day
+type PotRecord struct {
+	HandID string    `json:"handId"`
+	Pot    int       `json:"pot"`    // Chips paid out, rake included
+	Winner string    `json:"winner"` // The player who won the most of it
+	At     time.Time `json:"at"`
+}
+
+// HandRecord is the best hand shown down in a day
+type HandRecord struct {
+	HandID string    `json:"handId"`
+	Player string    `json:"player"`
+	Hand   string    `json:"hand"`  // Hand rank, like "full_house"
+	Cards  []Card    `json:"cards"` // The player's hole cards
+	At     time.Time `json:"at"`
+
+	rank HandRank
+}
+
+// BustRecord is the quickest elimination of a day: a player losing their last chip soonest
+// after sitting down
+type BustRecord struct {
+	Player     string    `json:"player"`
+	DurationMs int64     `json:"durationMs"` // From sitting down to busting
+	Hands      int       `json:"hands"`      // Hands dealt to the player in that time
+	At         time.Time `json:"at"`
+}
+
+// DailyDigest is the response of GET /api/digest: every table's superlatives for a day, and
+// the day's records across all tables
+type DailyDigest struct {
+	Date    string                  `json:"date"` // YYYY-MM-DD, UTC
+	Overall Superlatives            `json:"overall"`
+	Tables  map[string]Superlatives `json:"tables"` // By table ID
+}
+
+// superlativeDay returns the UTC day of t as YYYY-MM-DD
+func superlativeDay(t time.Time) string {
+	return t.UTC().Format(time.DateOnly)
+}
+
+// merge keeps the better of each record in s and other
+func (s Superlatives) merge(other Superlatives) Superlatives {
+	if other.BiggestPot != nil && (s.BiggestPot == nil || other.BiggestPot.Pot > s.BiggestPot.Pot) {
+		s.BiggestPot = other.BiggestPot
+	}
+	if other.BestHand != nil && (s.BestHand == nil || CompareHands(other.BestHand.rank, s.BestHand.rank) > 0) {
+		s.BestHand = other.BestHand
+	}
+	if other.FastestElimination != nil && (s.FastestElimination == nil || other.FastestElimination.DurationMs < s.FastestElimination.DurationMs) {
+		s.FastestElimination = other.FastestElimination
+	}
+	return s
+}
+
+// recordSuperlativesLocked updates the day's records of e's table with a finished hand or a
+// bust-out; it runs before record updates the stays (caller must hold st.mu)
+func (st *StatsTracker) recordSuperlativesLocked(e Event) {
+	var record Superlatives
+	switch {
+	case e.Type == EventHandEnded:
+		seats := st.dealtIn[e.TableID]
+		winner := -1
+		for _, seat := range slices.Sorted(maps.Keys(e.Winnings)) {
+			if winner < 0 || e.Winnings[seat] > e.Winnings[winner] {
+				winner = seat
+			}
+		}
+		if winner >= 0 && e.Pot > 0 {
+			record.BiggestPot = &PotRecord{HandID: e.HandID, Pot: e.Pot, Winner: st.nameLocked(seats[winner]), At: e.Time}
+		}
+		if e.WinningRank != nil && e.WinningRank.Rank >= 0 && e.WinningRank.Rank < len(handRankIDs) {
+			for _, seat := range slices.Sorted(maps.Keys(e.ShownDown)) {
+				if e.Winnings[seat] > 0 {
+					record.BestHand = &HandRecord{
+						HandID: e.HandID,
+						Player: st.nameLocked(seats[seat]),
+						Hand:   handRankIDs[e.WinningRank.Rank],
+						Cards:  slices.Clone(e.ShownDown[seat]),
+						At:     e.Time,
+						rank:   *e.WinningRank,
+					}
+					break
+				}
+			}
+		}
+	case e.Type == EventPlayerLeft && e.Busted:
+		stay := st.sittingLocked(e.Token, e.TableID)
+		if stay == nil || stay.hands == 0 {
+			return
+		}
+		record.FastestElimination = &BustRecord{
+			Player:     stay.name,
+			DurationMs: e.Time.Sub(stay.seatedAt).Milliseconds(),
+			Hands:      stay.hands,
+			At:         e.Time,
+		}
+	default:
+		return
+	}
+
+	day := superlativeDay(e.Time)
+	tables, ok := st.superlatives[day]
+	if !ok {
+		tables = make(map[string]Superlatives)
+		st.superlatives[day] = tables
+		// Days sort as strings, so the oldest go first
+		for _, old := range slices.Sorted(maps.Keys(st.superlatives)) {
+			if len(st.superlatives) <= superlativeDays {
+				break
+			}
+			delete(st.superlatives, old)
+		}
+	}
+	tables[e.TableID] = tables[e.TableID].merge(record)
+	if len(tables) > superlativeTableLimit {
+		oldest := e.TableID
+		for tableID, records := range tables {
+			if records.latest().Before(tables[oldest].latest()) {
+				oldest = tableID
+			}
+		}
+		delete(tables, oldest)
+	}
+}
+
+// latest returns when the newest of the records was set
+func (s Superlatives) latest() time.Time {
+	var latest time.Time
+	if s.BiggestPot != nil && s.BiggestPot.At.After(latest) {
+		latest = s.BiggestPot.At
+	}
+	if s.BestHand != nil && s.BestHand.At.After(latest) {
+		latest = s.BestHand.At
+	}
+	if s.FastestElimination != nil && s.FastestElimination.At.After(latest) {
+		latest = s.FastestElimination.At
+	}
+	return latest
+}
+
+// superlativesOf returns the table's records for each day they are kept, oldest first
+func (st *StatsTracker) superlativesOf(tableID string) []Superlatives {
+	if st == nil {
+		return nil
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	var days []Superlatives
+	for _, day := range slices.Sorted(maps.Keys(st.superlatives)) {
+		if records, ok := st.superlatives[day][tableID]; ok {
+			days = append(days, records)
+		}
+	}
+	return days
+}
+
+// nameLocked returns the name of the player with token, as they sat down if still seated
+// (caller must hold st.mu)
+func (st *StatsTracker) nameLocked(token string) string {
+	if stay, ok := st.sittings[token]; ok && stay.name != "" {
+		return stay.name
+	}
+	if st.playerName == nil || token == "" {
+		return ""
+	}
+	return st.playerName(token)
+}
+
+// Superlatives returns each table's records for day (YYYY-MM-DD, UTC), by table ID
+func (st *StatsTracker) Superlatives(day string) map[string]Superlatives {
+	if st == nil {
+		return nil
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return maps.Clone(st.superlatives[day])
+}
+
+// todaysSuperlatives returns the table's records so far today, nil when it has none
+func (s *Server) todaysSuperlatives(table *Table, now time.Time) *Superlatives {
+	records, ok := s.stats.Superlatives(superlativeDay(now))[table.ID]
+	if !ok {
+		return nil
+	}
+	records = s.publicSuperlatives(records, table.Anonymous)
+	return &records
+}
+
+// handleDigest serves GET /api/digest?date=YYYY-MM-DD, today when the date is omitted
+func (s *Server) handleDigest(w http.ResponseWriter, r *http.Request) {
+	day := r.URL.Query().Get("date")
+	if day == "" {
+		day = superlativeDay(time.Now())
+	} else if _, err := time.Parse(time.DateOnly, day); err != nil {
+		http.Error(w, "date must be YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	digest := DailyDigest{Date: day, Tables: map[string]Superlatives{}}
+	for tableID, records := range s.stats.Superlatives(day) {
+		records = s.publicSuperlatives(records, s.tableAnonymous(tableID))
+		digest.Tables[tableID] = records
+		digest.Overall = digest.Overall.merge(records)
+	}
+	writeAdminJSON(w, http.StatusOK, digest)
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// playSuperlativeHand feeds the tracker a hand at tableID between alice (seat 0) and bob
+// (seat 1) that seat 0 wins with pot chips, shown down with rank when it is not nil
+func playSuperlativeHand(st *StatsTracker, tableID, handID string, at time.Time, pot int, rank *HandRank) {
+	seats := map[int]string{0: "alice", 1: "bob"}
+	st.handle(Event{Type: EventHandStarted, TableID: tableID, Time: at, Players: []string{"alice", "bob"}, Seats: seats})
+	ended := Event{Type: EventHandEnded, TableID: tableID, HandID: handID, Time: at, Pot: pot, Winnings: map[int]int{0: pot}, WinningRank: rank}
+	if rank != nil {
+		ended.ShownDown = map[int][]Card{0: cards("As Ah"), 1: cards("Kd Kc")}
+	}
+	st.handle(ended)
+}
+
+// TestStatsTracker_Superlatives verifies each table keeps the day's biggest pot, best hand and
+// fastest elimination, and old days are dropped
+func TestStatsTracker_Superlatives(t *testing.T) {
+	names := map[string]string{"alice": "Alice", "bob": "Bob", "carol": "Carol"}
+	st := NewStatsTracker(func(token string) string { return names[token] }, nil)
+	day := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+
+	st.handle(Event{Type: EventPlayerSeated, TableID: "table-1", Token: "alice", Time: day})
+	st.handle(Event{Type: EventPlayerSeated, TableID: "table-1", Token: "bob", Time: day})
+	playSuperlativeHand(st, "table-1", "h1", day.Add(time.Minute), 300, &HandRank{Rank: 6, Kickers: []int{14, 13}})
+	playSuperlativeHand(st, "table-1", "h2", day.Add(2*time.Minute), 900, nil)
+	playSuperlativeHand(st, "table-1", "h3", day.Add(3*time.Minute), 200, &HandRank{Rank: 2, Kickers: []int{14, 13, 2}})
+	st.handle(Event{Type: EventPlayerLeft, TableID: "table-1", Token: "bob", Time: day.Add(4 * time.Minute), Busted: true})
+	st.handle(Event{Type: EventPlayerSeated, TableID: "table-1", Token: "carol", Time: day.Add(5 * time.Minute)})
+	st.handle(Event{Type: EventPlayerLeft, TableID: "table-1", Token: "carol", Time: day.Add(6 * time.Minute), Busted: true})
+
+	records := st.Superlatives("2026-10-18")["table-1"]
+	if pot := records.BiggestPot; pot == nil || pot.HandID != "h2" || pot.Pot != 900 || pot.Winner != "Alice" {
+		t.Errorf("expected Alice's 900 chip pot, got %+v", pot)
+	}
+	if best := records.BestHand; best == nil || best.HandID != "h1" || best.Hand != "full_house" || best.Player != "Alice" {
+		t.Errorf("expected Alice's full house, got %+v", best)
+	}
+	if bust := records.FastestElimination; bust == nil || bust.Player != "Bob" || bust.DurationMs != 4*60*1000 || bust.Hands != 3 {
+		t.Errorf("expected Bob out after 3 hands in 4 minutes (Carol was never dealt in), got %+v", bust)
+	}
+
+	for i := 1; i <= superlativeDays; i++ {
+		playSuperlativeHand(st, "table-1", "later", day.AddDate(0, 0, i), 10, nil)
+	}
+	if _, ok := st.Superlatives("2026-10-18")["table-1"]; ok {
+		t.Errorf("expected records older than %d days dropped", superlativeDays)
+	}
+}
+
+// TestStatsTracker_SuperlativeTableLimit verifies a day keeps the records of at most
+// superlativeTableLimit tables, dropping the table whose records are oldest
+func TestStatsTracker_SuperlativeTableLimit(t *testing.T) {
+	st := NewStatsTracker(func(token string) string { return token }, nil)
+	day := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+	for i := range superlativeTableLimit + 1 {
+		playSuperlativeHand(st, fmt.Sprintf("table-%d", i), "h", day.Add(time.Duration(i)*time.Second), 10, nil)
+	}
+
+	records := st.Superlatives("2026-10-18")
+	if len(records) != superlativeTableLimit {
+		t.Fatalf("expected %d tables kept, got %d", superlativeTableLimit, len(records))
+	}
+	if _, ok := records["table-0"]; ok {
+		t.Error("expected the table with the oldest records dropped")
+	}
+	if len(st.superlativesOf(fmt.Sprintf("table-%d", superlativeTableLimit))) != 1 {
+		t.Error("expected the newest table kept")
+	}
+}
+
+// TestSuperlatives_LobbyAndDigest verifies today's records show in the lobby and the digest
+// picks the best across tables
+func TestSuperlatives_LobbyAndDigest(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{Tables: []TableConfig{{Name: "One"}, {Name: "Two"}}})
+	now := time.Now()
+	playSuperlativeHand(server.stats, server.tables[0].ID, "h1", now, 300, nil)
+	playSuperlativeHand(server.stats, server.tables[1].ID, "h2", now, 500, nil)
+
+	lobby := server.GetLobbyState()
+	if lobby[0].Today == nil || lobby[0].Today.BiggestPot.Pot != 300 {
+		t.Errorf("expected today's biggest pot in the lobby, got %+v", lobby[0].Today)
+	}
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		return w
+	}
+	var digest DailyDigest
+	if err := json.NewDecoder(get("/api/digest").Body).Decode(&digest); err != nil {
+		t.Fatal(err)
+	}
+	if len(digest.Tables) != 2 || digest.Overall.BiggestPot == nil || digest.Overall.BiggestPot.HandID != "h2" {
+		t.Errorf("expected both tables and h2 the biggest pot overall, got %+v", digest)
+	}
+	if w := get("/api/digest?date=yesterday"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected a malformed date refused, got status %d", w.Code)
+	}
+}
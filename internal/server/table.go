@@ -1,22 +1,18 @@
 package server
 
 import (
+	"context"
 	"crypto/rand"
 	"fmt"
 	"math/big"
+	"slices"
 	"sync"
-)
-
-// Card represents a playing card with rank and suit
-type Card struct {
-	Rank string // A, 2, 3, 4, 5, 6, 7, 8, 9, T, J, Q, K
-	Suit string // s (spades), h (hearts), d (diamonds), c (clubs)
-}
+	"time"
 
-// String returns the 2-character string representation of a card (e.g., "As", "Kh")
-func (c Card) String() string {
-	return c.Rank + c.Suit
-}
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
 
 // NewDeck creates and returns a new 52-card deck with all unique cards
 func NewDeck() []Card {
@@ -26,7 +22,7 @@ func NewDeck() []Card {
 	deck := make([]Card, 0, 52)
 	for _, suit := range suits {
 		for _, rank := range ranks {
-			deck = append(deck, Card{Rank: rank, Suit: suit})
+			deck = append(deck, NewCard(rank, suit))
 		}
 	}
 	return deck
@@ -34,35 +30,60 @@ func NewDeck() []Card {
 
 // Hand represents the current game hand state
 type Hand struct {
-	DealerSeat         int            // Seat number of the dealer
-	SmallBlindSeat     int            // Seat number of the small blind
-	BigBlindSeat       int            // Seat number of the big blind
-	Pot                int            // Current pot amount
-	Deck               []Card         // Cards remaining in the deck
-	HoleCards          map[int][]Card // Hole cards for each seat (key = seat number, value = 2 cards)
-	BoardCards         []Card         // Community cards on the board (flop=3, turn=4, river=5)
-	CurrentActor       *int           // Seat number of the player whose turn it is (nil if no active action)
-	CurrentBet         int            // Current bet amount in this round (what players must match)
-	PlayerBets         map[int]int    // Amount each player has bet in current round (key = seat number)
-	FoldedPlayers      map[int]bool   // Players who have folded (key = seat number, value = true if folded)
-	ActedPlayers       map[int]bool   // Players who have acted this round (key = seat number, value = true if acted)
-	Street             string         // Current street: "preflop", "flop", "turn", "river"
-	LastRaise          int            // Amount of the last raise increment (used to compute min-raise)
-	BigBlindHasOption  bool           // True when BB has the option to close preflop betting (preflop only)
-	TotalContributions map[int]int    // Cumulative chip contributions per player across all streets (key = seat number, value = total chips contributed)
+	ID                 string             // Random identifier, announced in hand_started and used by replays
+	DealerSeat         int                // Seat number of the dealer
+	SmallBlindSeat     int                // Seat number of the small blind
+	BigBlindSeat       int                // Seat number of the big blind
+	Pot                int                // Current pot amount
+	Deck               []Card             // Cards remaining in the deck
+	HoleCards          map[int][]Card     // Hole cards for each seat (key = seat number, value = 2 cards)
+	BoardCards         []Card             // Community cards on the board (flop=3, turn=4, river=5)
+	CurrentActor       *int               // Seat number of the player whose turn it is (nil if no active action)
+	CurrentBet         int                // Current bet amount in this round (what players must match)
+	PlayerBets         map[int]int        // Amount each player has bet in current round (key = seat number)
+	FoldedPlayers      map[int]bool       // Players who have folded (key = seat number, value = true if folded)
+	ActedPlayers       map[int]bool       // Players who have acted this round (key = seat number, value = true if acted)
+	Street             string             // Current street: "preflop", "flop", "turn", "river"
+	LastRaise          int                // Amount of the last raise increment (used to compute min-raise)
+	BigBlindHasOption  bool               // True when BB has the option to close preflop betting (preflop only)
+	TotalContributions map[int]int        // Cumulative chip contributions per player across all streets (key = seat number, value = total chips contributed)
+	Aggressor          *int               // Seat that made the current bet (the big blind until someone raises)
+	AggressorStreet    string             // Street Aggressor made the bet on
+	SeedCommitment     string             // SHA-256 of the shuffle seed, announced in hand_started
+	RevealedSeats      map[int]bool       // Seats whose hole cards were shown to the whole table (all-in runout)
+	LastActions        map[int]SeatAction // Each seat's latest action this street; folds carry over to later streets
+	BombPot            bool               // Every player anted and the hand went straight to the flop (see postBombPotLocked)
+	BetIncrement       int                // Raises must be to a multiple of this, all-ins excepted (0 = any amount)
+}
+
+// SeatAction is a seat's latest action, as shown next to the seat: "raise" with Amount 60
+// reads "raised to 60"
+type SeatAction struct {
+	Action string `json:"action"`           // small_blind, big_blind, ante, fold, check, call, bet or raise
+	Amount int    `json:"amount,omitempty"` // The seat's total bet on the street after the action
+	AllIn  bool   `json:"allIn,omitempty"`
+}
+
+// recordLastAction remembers action as seatIndex's latest action this street
+func (h *Hand) recordLastAction(seatIndex int, action SeatAction) {
+	if h.LastActions == nil {
+		h.LastActions = make(map[int]SeatAction)
+	}
+	h.LastActions[seatIndex] = action
 }
 
 // SidePot represents a single pot in a multi-way all-in situation
 type SidePot struct {
 	Amount        int   // Amount of chips in this pot
 	EligibleSeats []int // Seat numbers eligible to win this pot
+	Contributors  []int // Seat numbers that put chips in this pot, folded or not
 }
 
 // Seat represents a seat at a poker table
 type Seat struct {
 	Index  int     // 0-5
 	Token  *string // nil = empty, non-nil = occupied
-	Status string  // "empty", "waiting", "active"
+	Status string  // "empty", "reserved" (buying in), "waiting", "active"
 	Stack  int     // Chip stack for the player (0 for empty seats, 1000 for new players)
 }
 
@@ -70,22 +91,142 @@ type Seat struct {
 type Table struct {
 	ID                     string
 	Name                   string
-	MaxSeats               int     // Always 6
-	Seats                  [6]Seat // Fixed array of 6 seats
-	DealerSeat             *int    // Seat number of the current dealer (nil = no dealer assigned yet)
-	CurrentHand            *Hand   // Currently active hand (nil = no hand running)
-	DealerRotatedThisRound bool    // True if dealer has been rotated after this hand (prevents double-rotation in StartHand)
-	Server                 *Server // Reference to the server for broadcasting events
+	MaxSeats               int          // 6, or 2 at heads-up tables
+	Seats                  [6]Seat      // Fixed array of 6 seats
+	DealerSeat             *int         // Seat number of the current dealer (nil = no dealer assigned yet)
+	CurrentHand            *Hand        // Currently active hand (nil = no hand running)
+	DealerRotatedThisRound bool         // True if dealer has been rotated after this hand (prevents double-rotation in StartHand)
+	Server                 *Server      // Reference to the server for broadcasting events
+	SmallBlind             int          // Small blind posted each hand
+	BigBlind               int          // Big blind posted each hand
+	BuyIn                  int          // Stack given to a player when they sit down
+	MinBuyIn               int          // Smallest stack a player reserving a seat may buy (0 = BuyIn)
+	MaxBuyIn               int          // Largest stack a player reserving a seat may buy (0 = BuyIn)
+	ShowStats              bool         // Include public player statistics in table snapshots
+	Currency               ChipCurrency // Currency buy-ins, stacks and rake are counted in
+	Description            string       // Operator-written blurb shown in the lobby
+	Theme                  string       // Visual theme name for clients (e.g. "classic", "neon")
+	Tags                   []string     // Lowercased lobby tags such as "beginners" or "deep stack"
+	GameType               string       // Poker variant dealt at the table (see GameTypeHoldem)
+	Speed                  string       // Regular, turbo or hyper; scales the table's timers (see speedUp)
+	HeadsUp                bool         // Winner-stays heads-up table: two seats and a challenger queue (see endMatchLocked)
+	ClubID                 string       // Club whose members alone may see and join the table (see clubs.go)
+	Hosts                  []string     // Account keys of the players who may take the host seat (see TakeHostSeat)
+	BombPotAnte            int          // Ante each player posts in a bomb pot (0 = two big blinds)
+	Practice               bool         // Training table: play chips only, optional preflop hints
+	Anonymous              bool         // Players appear to others under per-table aliases (see tableAlias)
+	ConfirmRaisePercent    int          // Raises putting in more than this percent of the stack wait for confirmation (0 = never)
+	BetIncrement           int          // Raises must be to multiples of this, all-ins excepted (0 = any amount)
+	RakeCollected          int          // Total rake taken at this table since startup
 	mu                     sync.RWMutex
+
+	// phase tracks the showdown and payout stages of the hand lifecycle; betting
+	// phases are derived from CurrentHand.Street (see phaseLocked)
+	phase HandPhase
+
+	// nextHandCancel is non-nil while an automatic next-hand countdown is running;
+	// closing it cancels the countdown (see ScheduleNextHand)
+	nextHandCancel chan struct{}
+	NextHandAt     *time.Time // When the pending automatic hand starts (nil = none scheduled)
+	// paused is set while automatic dealing waits for a second player (see ScheduleNextHand)
+	paused bool
+
+	// actionClockCancel is non-nil while the current actor is on the clock;
+	// closing it stops the clock (see startActionClockLocked)
+	actionClockCancel chan struct{}
+	ActionDeadline    *time.Time // When the current actor's clock runs out (nil = no clock running)
+	// actionRequestedAt is when the current actor's action_request went out, for the latency
+	// metrics; zero when a queued pre-action answers it
+	actionRequestedAt time.Time
+	// clockCalled is set once an opponent has called the clock on the current actor this turn;
+	// clockCalls remembers when each player (by token) last called the clock, for the cooldown
+	clockCalled bool
+	clockCalls  map[string]time.Time
+	// timeBankUsed is how much of each player's (by token) time bank is spent at this table;
+	// timeBank is set while the current actor is playing on theirs (see engageTimeBankLocked)
+	timeBankUsed map[string]time.Duration
+	timeBank     *timeBankUse
+
+	// freeze is set while an admin has the table frozen (see Freeze)
+	freeze *TableFreeze
+	// pendingBlinds is the blind level an admin set during a hand, applied when the next starts
+	pendingBlinds *BlindLevel
+	// handRules are the timers and rake the current hand was dealt under; config reloads during
+	// the hand apply from the next (see rulesLocked)
+	handRules *handRules
+
+	// disconnected holds the seated players (by token) whose connection dropped, while their
+	// seat is kept for them (see keepDisconnectedSeat)
+	disconnected map[string]*seatDisconnect
+
+	// reservations holds the seats (by token) reserved for players still choosing their
+	// buy-in (see reserveSeat)
+	reservations map[string]*seatReservation
+
+	// challengers queue players for the next free seat at a heads-up table; rematch holds that
+	// seat for the player who just lost while they decide whether to play again
+	challengers []challenger
+	rematch     *rematchOffer
+
+	// merge is the open offer to move this table's players to another (see balanceTables);
+	// emptySince is when the table was first seen empty, and closed is set while it is out of
+	// the lobby for lack of players
+	merge      *tableMerge
+	emptySince time.Time
+	closed     bool
+	// closing is set while an admin drains the table (see SoftClose)
+	closing bool
+
+	// raiseConfirm is the large raise waiting for its player's confirmation (see holdLargeRaise)
+	raiseConfirm *raiseConfirmation
+
+	// host is the player in the table's host seat, who is never dealt in; hostPaused is set while
+	// they have dealing paused, and bombPot once they have called a bomb pot for the next hand
+	host       *string
+	hostPaused bool
+	bombPot    bool
+
+	// uncontested is the last hand's winner while they may still show their cards (see ShowCards)
+	uncontested *uncontestedWin
+
+	// shuffleSeeds draws the seed of each hand's shuffle; nil uses newShuffleSeed. Tests set it
+	// to deal known decks.
+	shuffleSeeds func() (ShuffleSeed, error)
+	// rngSource is the external source shuffle seeds are drawn from; nil uses newShuffleSeed
+	rngSource *RNGSource
+
+	// processedActions records the result of every ID-tagged action in the current hand
+	// (reset by StartHand) so resent actions can be answered without reprocessing
+	processedActions map[processedActionKey]ActionResultPayload
+
+	// preActions holds the pre-action each seat queued for its next turn (reset by StartHand)
+	preActions map[int]PreAction
+
+	// handCtx and handSpan carry the trace of the current hand from StartHand to
+	// HandleShowdown (nil between hands, see startHandSpanLocked)
+	handCtx  context.Context
+	handSpan trace.Span
+}
+
+// processedActionKey identifies a client-generated action ID; IDs are scoped per player token
+type processedActionKey struct {
+	Token    string
+	ActionID string
 }
 
 // NewTable creates and returns a new Table instance with 6 empty seats
 func NewTable(id, name string, server *Server) *Table {
 	table := &Table{
-		ID:       id,
-		Name:     name,
-		MaxSeats: 6,
-		Server:   server,
+		ID:         id,
+		Name:       name,
+		MaxSeats:   6,
+		Server:     server,
+		SmallBlind: defaultSmallBlind,
+		BigBlind:   defaultBigBlind,
+		BuyIn:      defaultBuyIn,
+		Currency:   CurrencyPlay,
+		GameType:   GameTypeHoldem,
+		Speed:      SpeedRegular,
 	}
 
 	// Initialize all seats with Index and nil Token
@@ -164,10 +305,12 @@ func (h *Hand) DetermineWinner(seats []*Seat) (winners []int, winningRank *HandR
 }
 
 // HandleShowdown orchestrates the showdown logic
-// Determines winner(s) and distributes pot (Phase 3 complete)
+// Moves the hand through the showdown and payout phases: determines winner(s),
+// distributes the pot, removes busted players, rotates the dealer and returns
+// the table to waiting for the next hand
 // Handles both full showdown with multiple players and early winner when all others fold
 func (t *Table) HandleShowdown() {
-	t.mu.Lock()
+	showdownStart, lockWait := t.lockTimed()
 
 	// Verify hand exists
 	if t.CurrentHand == nil {
@@ -175,130 +318,172 @@ func (t *Table) HandleShowdown() {
 		return
 	}
 
-	// Get non-folded players count
-	nonFoldedCount := 0
-	for i := 0; i < 6; i++ {
-		if t.Seats[i].Status == "active" && !t.CurrentHand.FoldedPlayers[i] {
-			nonFoldedCount++
-		}
-	}
-
-	// If only one player remains, it's an early winner (all others folded)
-	if nonFoldedCount <= 1 {
-		// Find the remaining player
-		for i := 0; i < 6; i++ {
-			if t.Seats[i].Status == "active" && !t.CurrentHand.FoldedPlayers[i] {
-				if t.Server != nil {
-					t.Server.logger.Info("early winner (all folded)", "tableID", t.ID, "winner", i)
-				}
-
-				// CRITICAL: Sweep any remaining PlayerBets into Pot before calculating winner payout
-				// This handles early winner case where ShowDown is called mid-betting before AdvanceStreet()
-				for _, bet := range t.CurrentHand.PlayerBets {
-					t.CurrentHand.Pot += bet
-				}
-				t.CurrentHand.PlayerBets = make(map[int]int)
+	_, span := tracer().Start(t.handContextLocked(), SpanShowdown,
+		trace.WithTimestamp(showdownStart),
+		trace.WithAttributes(lockWaitAttribute(lockWait)),
+	)
 
-				// Distribute pot to the early winner (new signature: DistributePot takes only winners)
-				distribution := t.DistributePot([]int{i})
-				for seatIdx, amount := range distribution {
-					t.Seats[seatIdx].Stack += amount
-				}
-
-				// Handle bust-outs and collect busted tokens
-				bustedTokens := t.handleBustOutsWithNotificationsLocked()
+	if err := t.transitionLocked(PhaseShowdown); err != nil {
+		t.mu.Unlock()
+		failSpan(span, err)
+		span.End()
+		t.logWarn("cannot enter showdown", "error", err)
+		t.cancelHandOnError(fmt.Errorf("cannot enter showdown: %w", err))
+		return
+	}
 
-				// Rotate dealer for next hand and clear hand
-				t.assignDealerLocked()
-				t.DealerRotatedThisRound = true
-				t.CurrentHand = nil
-				t.mu.Unlock()
+	winners, winningRank := t.determineShowdownWinnersLocked()
 
-				// Broadcast showdown and hand complete for early winner
-				if t.Server != nil {
-					t.Server.broadcastShowdown(t, []int{i}, nil, distribution)
-					t.Server.broadcastHandComplete(t)
+	// Transition is always legal here: showdown -> payout -> waiting
+	_ = t.transitionLocked(PhasePayout)
 
-					// Send bust-out notifications if any
-					if len(bustedTokens) > 0 {
-						t.Server.handleBustOutNotifications(t, bustedTokens)
-					}
-				}
-				return
-			}
+	var distribution map[int]int
+	var rake int
+	var rakePaid map[int]int
+	var bustedTokens []string
+	var match *matchEnd
+	var reveals []ShowdownRevealPayload
+	var shown map[int][]Card
+	if len(winners) > 0 {
+		// CRITICAL: Sweep any remaining PlayerBets into Pot before distribution
+		// This handles showdown case where Player Bets may not have been advanced to Pot yet,
+		// and the early winner case where showdown is called mid-betting before AdvanceStreet()
+		for _, bet := range t.CurrentHand.PlayerBets {
+			t.CurrentHand.Pot += bet
 		}
-	}
-
-	// Multiple players remain - do full hand evaluation
-	seatsSlice := make([]*Seat, 6)
-	for i := 0; i < 6; i++ {
-		seatsSlice[i] = &t.Seats[i]
-	}
-	winners, winningRank := t.CurrentHand.DetermineWinner(seatsSlice)
+		t.CurrentHand.PlayerBets = make(map[int]int)
 
-	if len(winners) == 0 {
-		if t.Server != nil {
-			t.Server.logger.Warn("no winners found at showdown", "tableID", t.ID)
+		// Distribute the pot to winners (new signature: DistributePot takes only winners)
+		distribution = t.DistributePot(winners)
+		rake = t.takeRakeLocked(distribution)
+		rakePaid = attributeRake(rake, t.CurrentHand.TotalContributions)
+		for seatIdx, amount := range distribution {
+			t.Seats[seatIdx].Stack += amount
 		}
-		// Still need to clean up even if no winners found
-		t.assignDealerLocked()
-		t.DealerRotatedThisRound = true
-		t.CurrentHand = nil
-		t.mu.Unlock()
 
-		// Broadcast hand complete even with no winners
-		if t.Server != nil {
-			t.Server.broadcastHandComplete(t)
+		// Hands shown down are revealed in turn, before busted players lose their seats
+		if winningRank != nil {
+			reveals, shown = t.showdownRevealsLocked(distribution)
 		}
-		return
-	}
 
-	// Log winners
-	if t.Server != nil {
-		if len(winners) == 1 {
-			t.Server.logger.Info("showdown winner determined", "tableID", t.ID, "winner", winners[0], "rank", winningRank.Rank)
-		} else {
-			t.Server.logger.Info("showdown tie", "tableID", t.ID, "winners", winners, "rank", winningRank.Rank)
+		// Handle bust-outs and collect busted tokens
+		bustedTokens = t.handleBustOutsWithNotificationsLocked()
+		if len(bustedTokens) == 1 {
+			match = t.endMatchLocked(bustedTokens[0])
 		}
+	} else {
+		t.logWarn("no winners found at showdown")
 	}
 
-	// CRITICAL: Sweep any remaining PlayerBets into Pot before distribution
-	// This handles showdown case where Player Bets may not have been advanced to Pot yet
-	for _, bet := range t.CurrentHand.PlayerBets {
-		t.CurrentHand.Pot += bet
+	potAwarded := 0
+	for _, amount := range distribution {
+		potAwarded += amount
 	}
-	t.CurrentHand.PlayerBets = make(map[int]int)
-
-	// Distribute the pot to winners (new signature: DistributePot takes only winners)
-	distribution := t.DistributePot(winners)
-	for seatIdx, amount := range distribution {
-		t.Seats[seatIdx].Stack += amount
+	span.SetAttributes(
+		attribute.IntSlice("poker.winners", winners),
+		attribute.Int("poker.pot_awarded", potAwarded),
+		attribute.Int("poker.busted_players", len(bustedTokens)),
+		attribute.Int("poker.rake", rake),
+	)
+	if winningRank != nil {
+		span.SetAttributes(attribute.String("poker.winning_rank", handRankToString(winningRank.Rank)))
 	}
 
-	// Handle bust-outs and collect busted tokens
-	bustedTokens := t.handleBustOutsWithNotificationsLocked()
-
 	// Rotate dealer for next hand and clear hand
+	t.stopActionClockLocked()
 	t.assignDealerLocked()
 	t.DealerRotatedThisRound = true
+	t.uncontested = nil
+	if len(winners) == 1 && winningRank == nil {
+		if token := t.Seats[winners[0]].Token; token != nil {
+			t.uncontested = &uncontestedWin{
+				handID:    t.CurrentHand.ID,
+				seatIndex: winners[0],
+				token:     *token,
+				holeCards: slices.Clone(t.CurrentHand.HoleCards[winners[0]]),
+			}
+		}
+	}
+	handID := t.CurrentHand.ID
 	t.CurrentHand = nil
+	t.publishEvent(Event{Type: EventHandEnded, HandID: handID, Pot: potAwarded + rake, Winnings: distribution, Rake: rakePaid, WinningRank: winningRank, ShownDown: shown})
+	handSpan := t.detachHandSpanLocked()
+	_ = t.transitionLocked(PhaseWaitingForPlayers)
 	t.mu.Unlock()
 
+	// The hand's trace ends once its results have been sent
+	defer func() {
+		span.End()
+		handSpan.End()
+	}()
+
 	// Broadcast showdown results and hand complete
 	if t.Server != nil {
-		t.Server.broadcastShowdown(t, winners, winningRank, distribution)
+		t.Server.broadcastShowdownReveals(t, reveals)
+		if len(winners) > 0 {
+			t.Server.broadcastShowdown(t, winners, winningRank, distribution, rake)
+		}
 		t.Server.broadcastHandComplete(t)
 
 		// Send bust-out notifications if any
 		if len(bustedTokens) > 0 {
 			t.Server.handleBustOutNotifications(t, bustedTokens)
 		}
+		if match != nil {
+			t.Server.announceMatchEnd(t, match)
+		}
+		// Players who agreed to a merge move now that the hand is over
+		t.Server.completeMerge(t)
+		if t.Server.closeDrainedTable(t) {
+			return
+		}
+
+		// Queue up the next hand if the table still has enough players
+		t.ScheduleNextHand()
 	}
 }
 
+// determineShowdownWinnersLocked returns the winner(s) of the current hand (internal, must be called with lock held)
+// If only one player remains (all others folded) they win without evaluation and winningRank is nil
+func (t *Table) determineShowdownWinnersLocked() ([]int, *HandRank) {
+	// Get non-folded players
+	nonFolded := []int{}
+	for i := 0; i < 6; i++ {
+		if t.Seats[i].Status == "active" && !t.CurrentHand.FoldedPlayers[i] {
+			nonFolded = append(nonFolded, i)
+		}
+	}
+
+	// If only one player remains, it's an early winner (all others folded)
+	if len(nonFolded) == 1 {
+		t.logInfo("early winner (all folded)", "winner", nonFolded[0])
+		return nonFolded, nil
+	}
+
+	// Multiple players remain - do full hand evaluation
+	seatsSlice := make([]*Seat, 6)
+	for i := 0; i < 6; i++ {
+		seatsSlice[i] = &t.Seats[i]
+	}
+	winners, winningRank := t.CurrentHand.DetermineWinner(seatsSlice)
+
+	// Log winners
+	if len(winners) == 1 {
+		t.logInfo("showdown winner determined", "winner", winners[0], "rank", winningRank.Rank)
+	} else if len(winners) > 1 {
+		t.logInfo("showdown tie", "winners", winners, "rank", winningRank.Rank)
+	}
+
+	return winners, winningRank
+}
+
 // DistributePot distributes the pot to winners using proper side pot logic
 // Uses CalculateSidePots() to handle multiple all-in situations correctly
-// Only eligible winners for each pot level can win that pot
+// Only eligible winners for each pot level can win that pot; odd chips from a split go to the
+// winners nearest the button on its left
+// A dead pot, whose contributors have all folded, is settled by policy: a lone contributor's
+// uncalled excess is returned to them if they are still seated, and otherwise, like chips matched
+// by several folded players, it is dead money for the live player(s) who won the pot below it
 // Returns map of seat index to amount won
 // Sets t.CurrentHand.Pot to 0 after distribution
 func (t *Table) DistributePot(winners []int) map[int]int {
@@ -318,14 +503,7 @@ func (t *Table) DistributePot(winners []int) map[int]int {
 	contributions := t.CurrentHand.TotalContributions
 	if len(contributions) == 0 {
 		// Fallback: if no contributions tracked, just split evenly (shouldn't happen)
-		share := t.CurrentHand.Pot / len(winners)
-		remainder := t.CurrentHand.Pot % len(winners)
-		for _, seatIdx := range winners {
-			result[seatIdx] = share
-		}
-		if remainder > 0 {
-			result[winners[0]] += remainder
-		}
+		t.splitPotLocked(result, t.CurrentHand.Pot, winners)
 		t.CurrentHand.Pot = 0
 		return result
 	}
@@ -334,6 +512,7 @@ func (t *Table) DistributePot(winners []int) map[int]int {
 	sidePots := CalculateSidePots(contributions, foldedPlayers)
 
 	// Distribute each side pot to only the winners eligible for that pot
+	var lastAwarded []int
 	for _, pot := range sidePots {
 		// Filter winners to only those eligible for this pot
 		eligibleWinners := []int{}
@@ -346,24 +525,21 @@ func (t *Table) DistributePot(winners []int) map[int]int {
 			}
 		}
 
-		// If no eligible winners for this pot, skip it (unclaimed chips are discarded)
+		// The overall winners may all have been covered out of this pot: it then goes to the
+		// best hand among the seats still contesting it, which is the bettor alone when a bet
+		// was never called
 		if len(eligibleWinners) == 0 {
-			continue
+			eligibleWinners = t.potWinnersLocked(pot.EligibleSeats)
 		}
 
-		// Divide pot amount among eligible winners
-		share := pot.Amount / len(eligibleWinners)
-		remainder := pot.Amount % len(eligibleWinners)
-
-		// Award base share to each eligible winner
-		for _, seatIdx := range eligibleWinners {
-			result[seatIdx] += share
+		// Everyone who put chips in this pot has folded
+		if len(eligibleWinners) == 0 {
+			eligibleWinners = deadPotRecipients(pot, lastAwarded, winners, t.Seats[pot.Contributors[0]].Status == "active")
 		}
+		lastAwarded = eligibleWinners
 
-		// Award remainder to first eligible winner
-		if remainder > 0 && len(eligibleWinners) > 0 {
-			result[eligibleWinners[0]] += remainder
-		}
+		// Divide pot amount among eligible winners
+		t.splitPotLocked(result, pot.Amount, eligibleWinners)
 	}
 
 	// Clear the pot
@@ -372,11 +548,65 @@ func (t *Table) DistributePot(winners []int) map[int]int {
 	return result
 }
 
+// splitPotLocked divides amount evenly between seats, adding the shares to result. Chips that do not
+// divide evenly go one each to the seats nearest the button on its left, the dealer last
+// (caller must hold t.mu)
+func (t *Table) splitPotLocked(result map[int]int, amount int, seats []int) {
+	share := amount / len(seats)
+	remainder := amount % len(seats)
+	for i, seatIdx := range t.oddChipOrderLocked(seats) {
+		result[seatIdx] += share
+		if i < remainder {
+			result[seatIdx]++
+		}
+	}
+}
+
+// oddChipOrderLocked returns seats ordered clockwise from the first seat left of the current
+// hand's button (caller must hold t.mu)
+func (t *Table) oddChipOrderLocked(seats []int) []int {
+	first := (t.CurrentHand.DealerSeat + 1) % len(t.Seats)
+	ordered := slices.Clone(seats)
+	slices.SortFunc(ordered, func(a, b int) int {
+		return (a-first+len(t.Seats))%len(t.Seats) - (b-first+len(t.Seats))%len(t.Seats)
+	})
+	return ordered
+}
+
+// deadPotRecipients returns who takes a pot whose contributors have all folded: the lone
+// contributor when nobody matched their chips and they are still seated (lonerSeated), otherwise
+// the seats awarded the pot below it (belowWinners), or the hand's winners when there is no pot
+// below. A player who left mid-hand has cashed out their seat, so a refund would be lost.
+func deadPotRecipients(pot SidePot, belowWinners, winners []int, lonerSeated bool) []int {
+	if len(pot.Contributors) == 1 && lonerSeated {
+		return pot.Contributors
+	}
+	if len(belowWinners) > 0 {
+		return belowWinners
+	}
+	return winners
+}
+
+// potWinnersLocked returns the best hand(s) among the given seats, or the seat itself when only
+// one is eligible
+func (t *Table) potWinnersLocked(eligibleSeats []int) []int {
+	if len(eligibleSeats) <= 1 {
+		return eligibleSeats
+	}
+	seats := make([]*Seat, 6)
+	for _, seatIdx := range eligibleSeats {
+		seats[seatIdx] = &t.Seats[seatIdx]
+	}
+	winners, _ := t.CurrentHand.DetermineWinner(seats)
+	return winners
+}
+
 // HandleBustOuts clears seats with stack == 0 (Token = nil, Status = "empty")
 // This version assumes the lock is already held (use for internal calls within locked sections)
 func (t *Table) handleBustOutsLocked() {
 	for i := 0; i < 6; i++ {
-		if t.Seats[i].Stack == 0 && t.Seats[i].Token != nil {
+		if t.Seats[i].Stack == 0 && t.Seats[i].Token != nil && t.Seats[i].Status != "reserved" {
+			t.publishEvent(Event{Type: EventPlayerLeft, SeatIndex: i, Token: *t.Seats[i].Token, Busted: true})
 			t.Seats[i].Token = nil
 			t.Seats[i].Status = "empty"
 		}
@@ -392,7 +622,7 @@ func (t *Table) handleBustOutsWithNotificationsLocked() []string {
 
 	// First, collect tokens of players with stack == 0
 	for i := 0; i < 6; i++ {
-		if t.Seats[i].Stack == 0 && t.Seats[i].Token != nil {
+		if t.Seats[i].Stack == 0 && t.Seats[i].Token != nil && t.Seats[i].Status != "reserved" {
 			bustedTokens = append(bustedTokens, *t.Seats[i].Token)
 		}
 	}
@@ -411,6 +641,21 @@ func (t *Table) HandleBustOuts() {
 	t.handleBustOutsLocked()
 }
 
+// getProcessedActionLocked returns the recorded result for a token's action ID (must be called with lock held)
+func (t *Table) getProcessedActionLocked(token, actionID string) (ActionResultPayload, bool) {
+	result, ok := t.processedActions[processedActionKey{Token: token, ActionID: actionID}]
+	return result, ok
+}
+
+// recordProcessedActionLocked remembers the result of a token's action ID (must be called with lock held)
+func (t *Table) recordProcessedActionLocked(token, actionID string, result ActionResultPayload) {
+	if t.processedActions == nil {
+		t.processedActions = make(map[processedActionKey]ActionResultPayload)
+		t.preActions = nil
+	}
+	t.processedActions[processedActionKey{Token: token, ActionID: actionID}] = result
+}
+
 // GetOccupiedSeatCount returns the count of seats with non-nil Token (thread-safe)
 func (t *Table) GetOccupiedSeatCount() int {
 	t.mu.RLock()
@@ -425,46 +670,120 @@ func (t *Table) GetOccupiedSeatCount() int {
 	return count
 }
 
+// lobbyCounts returns the number of occupied seats, a seat held for a rematch included, and the
+// chips in the middle of the current hand, committed bets included (thread-safe)
+func (t *Table) lobbyCounts() (seated int, pot int) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, seat := range t.Seats {
+		if seat.Token != nil {
+			seated++
+		}
+	}
+	if t.rematch != nil {
+		seated++
+	}
+	if t.CurrentHand != nil {
+		pot = t.CurrentHand.Pot
+		for _, bet := range t.CurrentHand.PlayerBets {
+			pot += bet
+		}
+	}
+	return seated, pot
+}
+
 // AssignSeat assigns a player token to the first available seat (thread-safe)
 // Returns the assigned seat (by value) and nil error on success
 // Returns empty Seat and error if table is full
 func (t *Table) AssignSeat(token *string) (Seat, error) {
+	return t.assignSeat(token, 0)
+}
+
+// assignSeat assigns token the first available seat with stack chips, or the buy-in for 0
+func (t *Table) assignSeat(token *string, stack int) (Seat, error) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	// The seat left open at a heads-up table is held while its loser is offered a rematch
+	if !t.seatOpenToLocked(*token) {
+		return Seat{}, newMessageError("error.table_full", nil)
+	}
+	t.clearRematchLocked()
+
 	// Find first empty seat
-	for i := 0; i < 6; i++ {
+	for i := 0; i < t.MaxSeats; i++ {
 		if t.Seats[i].Token == nil {
 			t.Seats[i].Token = token
 			t.Seats[i].Status = "waiting"
-			t.Seats[i].Stack = 1000
+			t.Seats[i].Stack = t.BuyIn
+			if stack > 0 {
+				t.Seats[i].Stack = stack
+			}
 			return t.Seats[i], nil
 		}
 	}
 
 	// No empty seats found
-	return Seat{}, fmt.Errorf("table is full")
+	return Seat{}, newMessageError("error.table_full", nil)
 }
 
 // ClearSeat removes a player from a table by token (thread-safe)
+// A player still in the current hand is folded first, so the hand never waits on an empty seat
+// and the chips they put in stay in the pot. The remaining stack is then cashed out to the
+// player's balance when bankroll accounting is on.
 // Returns nil error on success
 // Returns error if token not found
 func (t *Table) ClearSeat(token *string) error {
+	seat, found := t.GetSeatByToken(token)
+	if found && t.Server != nil {
+		t.Server.foldDepartingPlayer(t, seat.Index)
+	}
+
 	t.mu.Lock()
-	defer t.mu.Unlock()
 
 	// Find seat with matching token
 	for i := 0; i < 6; i++ {
 		if t.Seats[i].Token != nil && *t.Seats[i].Token == *token {
+			// Without a server to play the fold, the seat is at least out of the hand
+			if hand := t.CurrentHand; hand != nil && t.Seats[i].Status == "active" && !hand.FoldedPlayers[i] {
+				if hand.FoldedPlayers == nil {
+					hand.FoldedPlayers = make(map[int]bool)
+				}
+				hand.FoldedPlayers[i] = true
+			}
+			stack := t.Seats[i].Stack
+			reserved := t.Seats[i].Status == "reserved"
+			t.dropConnectionLocked(*token)
+			t.dropReservationLocked(*token)
 			t.Seats[i].Token = nil
 			t.Seats[i].Status = "empty"
 			t.Seats[i].Stack = 0
+			// A reserved seat was never taken, so nobody left it
+			if !reserved {
+				t.publishEvent(Event{Type: EventPlayerLeft, SeatIndex: i, Token: *token})
+			}
+			t.mu.Unlock()
+
+			t.cashOut(*token, stack)
+			// A closing table leaves with its last player
+			if t.Server != nil && t.Server.closeDrainedTable(t) {
+				return nil
+			}
+			// Pause between hands if too few players are left
+			t.ScheduleNextHand()
 			return nil
 		}
 	}
+	t.mu.Unlock()
+
+	// The fold ended the hand and the player went out with their last chip
+	if found {
+		return nil
+	}
 
 	// Token not found
-	return fmt.Errorf("seat not found")
+	return newMessageError("error.seat_not_found", nil)
 }
 
 // GetSeatByToken returns the seat occupied by a player token (thread-safe)
@@ -711,37 +1030,102 @@ func (t *Table) CanStartHand() bool {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
-	// Check if a hand is already running
-	if t.CurrentHand != nil {
+	return t.canStartHandLocked()
+}
+
+// canStartHandLocked checks if a new hand can be started (internal, must be called with lock held)
+func (t *Table) canStartHandLocked() bool {
+	// Check if a hand is already running, the table is frozen or its host paused it
+	if t.CurrentHand != nil || t.freeze != nil || t.hostPaused {
 		return false
 	}
 
-	// Count players (both "waiting" and "active" can start a hand)
+	// Need at least 2 players
+	return t.playerCountLocked() >= 2
+}
+
+// playerCountLocked counts the players who can be dealt the next hand (internal, must be called
+// with lock held); both "waiting" and "active" seats count
+func (t *Table) playerCountLocked() int {
 	playerCount := 0
 	for i := 0; i < 6; i++ {
 		if t.Seats[i].Status == "waiting" || t.Seats[i].Status == "active" {
 			playerCount++
 		}
 	}
+	return playerCount
+}
 
-	// Need at least 2 players
-	return playerCount >= 2
+// handSeeds are the shuffle seeds of the next hand, drawn by drawHandSeeds
+type handSeeds struct {
+	hand   ShuffleSeed
+	button *ShuffleSeed // For the draw for the button, when the hand needs one
+}
+
+// drawHandSeeds draws the shuffle seeds StartHand needs from the table's RNG source, if it has
+// one. It runs without the table lock, as a remote source can take seconds to answer; while the
+// table has no hand to start it draws nothing and returns nil, for StartHand to say why.
+func (t *Table) drawHandSeeds() (*handSeeds, error) {
+	t.mu.RLock()
+	players := 0
+	for i := range t.Seats {
+		if t.Seats[i].Status == "active" || t.Seats[i].Status == "waiting" {
+			players++
+		}
+	}
+	ready := players >= 2 && t.CurrentHand == nil
+	buttonDraw := t.buttonDrawDueLocked()
+	t.mu.RUnlock()
+	if !ready {
+		return nil, nil
+	}
+
+	newSeed := newShuffleSeed
+	switch {
+	case t.shuffleSeeds != nil:
+		newSeed = t.shuffleSeeds
+	case t.rngSource != nil:
+		// No fallback: a table whose source fails deals nothing until the source recovers
+		newSeed = func() (ShuffleSeed, error) { return t.rngSource.Seed(t.clock().Now()) }
+	}
+
+	seeds := &handSeeds{}
+	if buttonDraw {
+		seed, err := newSeed()
+		if err != nil {
+			return nil, fmt.Errorf("failed to draw for the button: %w", err)
+		}
+		seeds.button = &seed
+	}
+	seed, err := newSeed()
+	if err != nil {
+		return nil, fmt.Errorf("failed to shuffle deck: %w", err)
+	}
+	seeds.hand = seed
+	return seeds, nil
 }
 
 // StartHand initializes and starts a new poker hand
 // This method orchestrates the full hand start sequence:
-// 1. Transitions "waiting" players to "active" status
-// 2. Validates that a hand can be started (≥2 active players, no hand running)
-// 3. Assigns dealer via NextDealer()
-// 4. Gets blind positions
-// 5. Creates new deck and shuffles
-// 6. Posts blinds (SB=10, BB=20), handles all-in if stack < blind
-// 7. Deals hole cards to all active players
-// 8. Sets CurrentHand with all game state
-// 9. Broadcasts hand_started, blind_posted, and cards_dealt events
+//  1. Transitions "waiting" players to "active" status
+//  2. Validates that a hand can be started (≥2 active players, no hand running)
+//  3. Assigns dealer via NextDealer()
+//  4. Gets blind positions
+//  5. Creates new deck and shuffles
+//  6. Posts the table's blinds (SmallBlind/BigBlind), handles all-in if stack < blind; a bomb pot
+//     called by the host posts everyone's ante instead and skips preflop betting
+//  7. Deals hole cards to all active players
+//  8. Sets CurrentHand with all game state
+//  9. Broadcasts hand_started, blind_posted, and cards_dealt events
+//
 // Returns error if hand cannot be started or if operations fail
 func (t *Table) StartHand() error {
-	t.mu.Lock()
+	// A remote RNG source can take seconds to answer, so the seeds are drawn before the lock
+	seeds, err := t.drawHandSeeds()
+	if err != nil {
+		return err
+	}
+	handStart, lockWait := t.lockTimed()
 
 	// Step 0: Transition all "waiting" players to "active" status
 	// Players become active when the first/next hand starts
@@ -762,18 +1146,25 @@ func (t *Table) StartHand() error {
 
 	if activeCount < 2 {
 		t.mu.Unlock()
-		return fmt.Errorf("insufficient active players to start hand: %d active, need at least 2", activeCount)
+		return newMessageError("error.not_enough_players", map[string]any{"active": activeCount, "min": 2})
 	}
 
 	if t.CurrentHand != nil {
 		t.mu.Unlock()
-		return fmt.Errorf("hand already running")
+		return newMessageError("error.hand_in_progress", nil)
+	}
+
+	// Players sat down, or the table was reset, while the seeds were drawn: draw what it needs now
+	if seeds == nil || (seeds.button == nil && t.buttonDrawDueLocked()) {
+		t.mu.Unlock()
+		return t.StartHand()
 	}
 
 	// Step 1: Assign dealer
 	// If dealer was just rotated (after previous hand ended), reuse the rotated position
-	// Otherwise, rotate to next active seat
+	// Otherwise, rotate to next active seat, or draw for the button before a new table's first hand
 	var dealerSeat int
+	var buttonDraw *ButtonDrawPayload
 	if t.DealerRotatedThisRound {
 		// Dealer was already rotated after previous hand ended
 		// Use the current dealer position (don't rotate again)
@@ -784,6 +1175,14 @@ func (t *Table) StartHand() error {
 			dealerSeat = *t.DealerSeat
 		}
 		t.DealerRotatedThisRound = false
+	} else if t.buttonDrawDueLocked() {
+		draw, err := t.drawForButtonLocked(*seeds.button)
+		if err != nil {
+			t.mu.Unlock()
+			return fmt.Errorf("failed to draw for the button: %w", err)
+		}
+		buttonDraw = draw
+		dealerSeat = draw.DealerSeat
 	} else {
 		// No rotation yet, assign dealer (either first hand or re-use)
 		dealerSeat = t.assignDealerLocked()
@@ -796,12 +1195,23 @@ func (t *Table) StartHand() error {
 		return fmt.Errorf("failed to get blind positions: %w", err)
 	}
 
+	// Blinds an admin changed during the last hand apply from this one
+	if t.pendingBlinds != nil {
+		t.SmallBlind, t.BigBlind = t.pendingBlinds.SmallBlind, t.pendingBlinds.BigBlind
+		t.pendingBlinds = nil
+	}
+	if t.Server != nil {
+		rules := rulesFromConfig(t.Server.Config())
+		t.handRules = &rules
+	}
+
 	// Blind amounts
-	const smallBlind = 10
-	const bigBlind = 20
+	smallBlind := t.SmallBlind
+	bigBlind := t.BigBlind
 
 	// Step 3: Create new hand and deck with action state initialized
 	hand := &Hand{
+		ID:                 uuid.New().String(),
 		DealerSeat:         dealerSeat,
 		SmallBlindSeat:     sbSeat,
 		BigBlindSeat:       bbSeat,
@@ -816,7 +1226,10 @@ func (t *Table) StartHand() error {
 		ActedPlayers:       make(map[int]bool),
 		LastRaise:          bigBlind,
 		BigBlindHasOption:  true,
+		BetIncrement:       t.BetIncrement,
 		TotalContributions: make(map[int]int),
+		Aggressor:          &bbSeat,
+		AggressorStreet:    "preflop",
 	}
 
 	// Initialize TotalContributions for all active players (even if they haven't acted yet)
@@ -827,99 +1240,174 @@ func (t *Table) StartHand() error {
 		}
 	}
 
-	// Step 4: Shuffle the deck
-	err = ShuffleDeck(hand.Deck)
-	if err != nil {
-		t.mu.Unlock()
-		return fmt.Errorf("failed to shuffle deck: %w", err)
+	// Step 4: Shuffle the deck from a fresh seed and record it in the RNG audit log
+	// before any card is dealt, so the published commitment binds the whole deck order
+	seed := seeds.hand
+	ShuffleDeckWithSeed(hand.Deck, seed)
+	hand.SeedCommitment = seed.Commitment()
+	if t.Server != nil {
+		if err := t.Server.rngAudit.Record(t.ID, seed, hand.Deck); err != nil {
+			t.mu.Unlock()
+			return fmt.Errorf("failed to record shuffle: %w", err)
+		}
+		t.Server.rngMonitor.Observe(hand.Deck)
 	}
 
-	// Step 5: Post blinds (handle all-in if necessary)
-	// Post small blind
-	sbPosted := smallBlind
-	if t.Seats[sbSeat].Stack < smallBlind {
-		// All-in with remaining chips
-		sbPosted = t.Seats[sbSeat].Stack
-		t.Seats[sbSeat].Stack = 0
+	// Step 5: Post blinds (handle all-in if necessary), or every player's ante in a bomb pot
+	var posted []blindPost
+	bombPot := t.bombPot
+	if bombPot {
+		t.bombPot = false
+		posted = t.postBombPotLocked(hand)
 	} else {
-		t.Seats[sbSeat].Stack -= smallBlind
-	}
+		// Post small blind
+		sbPosted := smallBlind
+		if t.Seats[sbSeat].Stack < smallBlind {
+			// All-in with remaining chips
+			sbPosted = t.Seats[sbSeat].Stack
+			t.Seats[sbSeat].Stack = 0
+		} else {
+			t.Seats[sbSeat].Stack -= smallBlind
+		}
 
-	// Post big blind
-	bbPosted := bigBlind
-	if t.Seats[bbSeat].Stack < bigBlind {
-		// All-in with remaining chips
-		bbPosted = t.Seats[bbSeat].Stack
-		t.Seats[bbSeat].Stack = 0
-	} else {
-		t.Seats[bbSeat].Stack -= bigBlind
-	}
+		// Post big blind
+		bbPosted := bigBlind
+		if t.Seats[bbSeat].Stack < bigBlind {
+			// All-in with remaining chips
+			bbPosted = t.Seats[bbSeat].Stack
+			t.Seats[bbSeat].Stack = 0
+		} else {
+			t.Seats[bbSeat].Stack -= bigBlind
+		}
 
-	// Update PlayerBets to track blinds posted (Pot will be filled when street advances)
-	hand.PlayerBets[sbSeat] = sbPosted
-	hand.PlayerBets[bbSeat] = bbPosted
+		// Update PlayerBets to track blinds posted (Pot will be filled when street advances)
+		hand.PlayerBets[sbSeat] = sbPosted
+		hand.PlayerBets[bbSeat] = bbPosted
 
-	// Track blind contributions in TotalContributions
-	hand.TotalContributions[sbSeat] = sbPosted
-	hand.TotalContributions[bbSeat] = bbPosted
+		// Track blind contributions in TotalContributions
+		hand.TotalContributions[sbSeat] = sbPosted
+		hand.TotalContributions[bbSeat] = bbPosted
+
+		hand.recordLastAction(sbSeat, SeatAction{Action: "small_blind", Amount: sbPosted, AllIn: t.Seats[sbSeat].Stack == 0})
+		hand.recordLastAction(bbSeat, SeatAction{Action: "big_blind", Amount: bbPosted, AllIn: t.Seats[bbSeat].Stack == 0})
+		// A big blind all-in from posting has no option left to take
+		hand.BigBlindHasOption = t.Seats[bbSeat].Stack > 0
+		posted = []blindPost{{seat: sbSeat, amount: sbPosted}, {seat: bbSeat, amount: bbPosted}}
+	}
 
 	// Step 6: Deal hole cards to all active players
 	err = hand.DealHoleCards(t.Seats)
 	if err != nil {
+		// The hand never started, so the blinds go back
+		t.refundHandLocked(hand)
 		t.mu.Unlock()
 		return fmt.Errorf("failed to deal hole cards: %w", err)
 	}
 
-	// Step 6a: Set the first actor (who acts first preflop)
-	firstActor := hand.GetFirstActor(t.Seats)
-	hand.CurrentActor = &firstActor
+	// Step 6a: Set the first actor (who acts first preflop), passing over a blind all-in from posting
+	// A bomb pot has no preflop betting, so nobody is
+	if !bombPot {
+		firstActor := hand.GetFirstActor(t.Seats)
+		if t.Seats[firstActor].Stack == 0 {
+			if next := hand.nextSeatToAct(firstActor, t.Seats); next != nil {
+				firstActor = *next
+			}
+		}
+		hand.CurrentActor = &firstActor
+	}
 
-	// Step 7: Set CurrentHand
+	// Step 7: Set CurrentHand and forget action IDs from the previous hand
+	if err := t.transitionLocked(PhasePreflop); err != nil {
+		t.mu.Unlock()
+		return err
+	}
 	t.CurrentHand = hand
+	t.uncontested = nil
+	t.processedActions = make(map[processedActionKey]ActionResultPayload)
+	t.startHandSpanLocked(hand, handStart, lockWait)
+
+	var dealtIn []string
+	blinds := make(map[int]int)
+	for _, post := range posted {
+		blinds[post.seat] = post.amount
+	}
+	seatTokens := make(map[int]string)
+	stacks := make(map[int]int)
+	holeCards := make(map[int][]Card)
+	for i, seat := range t.Seats {
+		if seat.Token != nil && len(hand.HoleCards[i]) > 0 {
+			dealtIn = append(dealtIn, *seat.Token)
+			seatTokens[i] = *seat.Token
+			stacks[i] = seat.Stack + hand.PlayerBets[i]
+			holeCards[i] = slices.Clone(hand.HoleCards[i])
+		}
+	}
+	t.publishEvent(Event{
+		Type:       EventHandStarted,
+		Players:    dealtIn,
+		HandID:     hand.ID,
+		DealerSeat: dealerSeat,
+		Seats:      seatTokens,
+		Stacks:     stacks,
+		Blinds:     blinds,
+		HoleCards:  holeCards,
+	})
+
+	// A manually started hand supersedes any pending automatic start
+	t.cancelNextHandLocked()
 
 	// Unlock before broadcasting to avoid holding the lock during network operations
 	t.mu.Unlock()
 
 	// Step 8: Broadcast events to all table clients
 	if t.Server != nil {
-		// Broadcast hand_started with dealer and blind positions
-		err = t.Server.broadcastHandStarted(t)
-		if err != nil {
-			t.mu.Lock()
-			// Revert the hand state on broadcast failure
-			t.CurrentHand = nil
-			t.mu.Unlock()
-			return fmt.Errorf("failed to broadcast hand_started: %w", err)
+		// Show the table the draw for the button before the hand it decided
+		if buttonDraw != nil {
+			t.logInfo("button drawn for", "dealerSeat", buttonDraw.DealerSeat)
+			if err := t.Server.broadcastTableMessage(t, "button_draw", buttonDraw); err != nil {
+				t.logWarn("failed to broadcast button_draw", "error", err)
+			}
 		}
 
-		// Broadcast small blind posted
-		err = t.Server.broadcastBlindPosted(t, sbSeat, sbPosted)
+		// Broadcast hand_started with dealer and blind positions
+		err = t.Server.broadcastHandStarted(t)
 		if err != nil {
+			err = fmt.Errorf("failed to broadcast hand_started: %w", err)
 			t.mu.Lock()
-			// Revert the hand state on broadcast failure
+			// Revert the hand state on broadcast failure, giving the blinds back
+			t.refundHandLocked(hand)
 			t.CurrentHand = nil
+			t.endHandSpanLocked(err)
 			t.mu.Unlock()
-			return fmt.Errorf("failed to broadcast small blind: %w", err)
+			return err
 		}
 
-		// Broadcast big blind posted
-		err = t.Server.broadcastBlindPosted(t, bbSeat, bbPosted)
-		if err != nil {
-			t.mu.Lock()
-			// Revert the hand state on broadcast failure
-			t.CurrentHand = nil
-			t.mu.Unlock()
-			return fmt.Errorf("failed to broadcast big blind: %w", err)
+		// Broadcast the blinds, or antes, posted
+		for _, post := range posted {
+			err = t.Server.broadcastBlindPosted(t, post.seat, post.amount)
+			if err != nil {
+				err = fmt.Errorf("failed to broadcast blind: %w", err)
+				t.mu.Lock()
+				// Revert the hand state on broadcast failure, giving the blinds back
+				t.refundHandLocked(hand)
+				t.CurrentHand = nil
+				t.endHandSpanLocked(err)
+				t.mu.Unlock()
+				return err
+			}
 		}
 
 		// Broadcast hole cards dealt
 		err = t.Server.broadcastCardsDealt(t)
 		if err != nil {
+			err = fmt.Errorf("failed to broadcast cards_dealt: %w", err)
 			t.mu.Lock()
-			// Revert the hand state on broadcast failure
+			// Revert the hand state on broadcast failure, giving the blinds back
+			t.refundHandLocked(hand)
 			t.CurrentHand = nil
+			t.endHandSpanLocked(err)
 			t.mu.Unlock()
-			return fmt.Errorf("failed to broadcast cards_dealt: %w", err)
+			return err
 		}
 
 		// Broadcast table state to sync card counts for all clients
@@ -955,6 +1443,11 @@ func (t *Table) StartHand() error {
 				t.Server.logger.Warn("failed to broadcast first action_request", "error", err)
 			}
 		}
+
+		// A bomb pot goes straight to the flop
+		if bombPot {
+			t.ProgressHand()
+		}
 	}
 
 	return nil
@@ -1232,17 +1725,9 @@ func (h *Hand) GetValidActions(seatIndex int, playerStack int, seats [6]Seat) []
 	if callAmount > 0 {
 		// Player is behind, must call to continue
 		// Check if they can also raise
-		minRaise := h.GetMinRaise()
-		// Player needs: callAmount (to match current bet) + minRaise (for the raise)
-		// But actually, to raise minimum, they need to put out callAmount + minRaise total
-		// Wait, that's not right. MinRaise is the increment AFTER calling.
-		// Actually, minRaise is already CurrentBet + LastRaise
-		// So to raise minimum, player needs to put out minRaise total
-		// But they're currently only bet PlayerBets[seatIndex]
-		// So they need minRaise - PlayerBets[seatIndex] more chips
-
-		chipsNeeded := minRaise - h.PlayerBets[seatIndex]
-		if chipsNeeded <= playerStack {
+		// MinRaise is the total to raise to, so the player needs
+		// minRaise - PlayerBets[seatIndex] more chips
+		if _, _, ok := h.RaiseRange(seatIndex, playerStack); ok {
 			// Player can raise
 			return []string{"fold", "call", "raise"}
 		}
@@ -1252,9 +1737,7 @@ func (h *Hand) GetValidActions(seatIndex int, playerStack int, seats [6]Seat) []
 
 	// Player has matched current bet, can check or fold
 	// Also check if they can raise even when callAmount == 0
-	minRaise := h.GetMinRaise()
-	chipsNeeded := minRaise - h.PlayerBets[seatIndex]
-	if chipsNeeded <= playerStack {
+	if _, _, ok := h.RaiseRange(seatIndex, playerStack); ok {
 		// Player can raise
 		return []string{"check", "fold", "raise"}
 	}
@@ -1263,11 +1746,45 @@ func (h *Hand) GetValidActions(seatIndex int, playerStack int, seats [6]Seat) []
 }
 
 // GetMinRaise returns the minimum raise amount (what players must raise to at minimum)
-// Minimum raise = CurrentBet + LastRaise
+// Minimum raise = CurrentBet + LastRaise, rounded up to the hand's BetIncrement
 // Example: If BB=20, then min-raise to 40 (20 + 20)
 // After raise to 60, min-raise becomes 100 (60 + 40)
 func (h *Hand) GetMinRaise() int {
-	return h.CurrentBet + h.LastRaise
+	minRaise := h.CurrentBet + h.LastRaise
+	if h.BetIncrement > 0 && minRaise%h.BetIncrement != 0 {
+		minRaise += h.BetIncrement - minRaise%h.BetIncrement
+	}
+	return minRaise
+}
+
+// RaiseRange returns the totals a seat with playerStack behind may raise to: from the minimum
+// raise up to going all-in. ok is false when the stack cannot cover the minimum raise. Both
+// GetValidActions and action_request use it, so clients are offered exactly what is accepted.
+func (h *Hand) RaiseRange(seatIndex, playerStack int) (minRaise, maxRaise int, ok bool) {
+	playerBet := 0
+	if h.PlayerBets != nil {
+		playerBet = h.PlayerBets[seatIndex]
+	}
+	minRaise = h.GetMinRaise()
+	maxRaise = playerBet + playerStack
+	if playerStack == 0 || minRaise > maxRaise {
+		return 0, 0, false
+	}
+	return minRaise, maxRaise, true
+}
+
+// RaiseStep returns the step raise amounts go up in: the hand's BetIncrement, or 1
+func (h *Hand) RaiseStep() int {
+	return max(h.BetIncrement, 1)
+}
+
+// checkRaiseIncrement returns error.raise_not_multiple when raising to raiseTo breaks the hand's
+// BetIncrement; an all-in (allIn) may be any amount
+func (h *Hand) checkRaiseIncrement(raiseTo int, allIn bool) error {
+	if h.BetIncrement <= 0 || allIn || raiseTo%h.BetIncrement == 0 {
+		return nil
+	}
+	return newMessageError("error.raise_not_multiple", map[string]any{"increment": h.BetIncrement})
 }
 
 // GetMaxRaise returns the maximum total chips a player can commit
@@ -1315,15 +1832,16 @@ func (h *Hand) ValidateRaise(seatIndex, raiseAmount, playerStack int, seats [6]S
 	// Check minimum raise
 	minRaise := h.GetMinRaise()
 	if raiseAmount < minRaise {
-		return fmt.Errorf("raise amount below minimum")
+		return newMessageError("error.raise_below_minimum", nil)
 	}
 
 	// Check that raise doesn't exceed player's stack
 	if raiseAmount > playerStack {
-		return fmt.Errorf("raise exceeds player stack")
+		return newMessageError("error.raise_exceeds_stack", nil)
 	}
 
-	return nil
+	// Check the table's chip increment
+	return h.checkRaiseIncrement(raiseAmount, false)
 }
 
 // GetMaxOpponentCoverage returns the maximum amount active opponents can cover
@@ -1392,7 +1910,7 @@ func (h *Hand) ProcessAction(seatIndex int, action string, playerStack int, amou
 		// Check is only valid when player has matched the current bet
 		callAmount := h.GetCallAmount(seatIndex)
 		if callAmount > 0 {
-			return 0, fmt.Errorf("cannot check when behind current bet (need to call %d)", callAmount)
+			return 0, newMessageError("error.check_facing_bet", map[string]any{"callAmount": callAmount})
 		}
 
 		// Mark player as acted
@@ -1433,7 +1951,7 @@ func (h *Hand) ProcessAction(seatIndex int, action string, playerStack int, amou
 	case "raise":
 		// Extract raise amount from variadic parameter
 		if len(amount) == 0 {
-			return 0, fmt.Errorf("raise action requires amount parameter")
+			return 0, newMessageError("error.raise_amount_required", nil)
 		}
 		raiseAmount := amount[0]
 
@@ -1444,7 +1962,10 @@ func (h *Hand) ProcessAction(seatIndex int, action string, playerStack int, amou
 			// Not all-in, so validate min/max bounds
 			minRaise := h.GetMinRaise()
 			if raiseAmount < minRaise {
-				return 0, fmt.Errorf("raise amount below minimum")
+				return 0, newMessageError("error.raise_below_minimum", nil)
+			}
+			if err := h.checkRaiseIncrement(raiseAmount, raiseAmount-h.PlayerBets[seatIndex] == playerStack); err != nil {
+				return 0, err
 			}
 			// Note: Max raise validation would need table context, handled by caller
 		}
@@ -1455,7 +1976,7 @@ func (h *Hand) ProcessAction(seatIndex int, action string, playerStack int, amou
 
 		// Sanity check: don't exceed player's stack
 		if chipsToBet > playerStack {
-			return 0, fmt.Errorf("raise exceeds player stack")
+			return 0, newMessageError("error.raise_exceeds_stack", nil)
 		}
 
 		// Update CurrentBet to this raise amount
@@ -1480,7 +2001,7 @@ func (h *Hand) ProcessAction(seatIndex int, action string, playerStack int, amou
 		return chipsToBet, nil
 
 	default:
-		return 0, fmt.Errorf("invalid action: %s", action)
+		return 0, newMessageError("error.unknown_action", map[string]any{"action": action})
 	}
 }
 
@@ -1541,7 +2062,7 @@ func (h *Hand) ProcessActionWithSeats(seatIndex int, action string, playerStack
 	case "raise":
 		// Extract raise amount from variadic parameter
 		if len(amount) == 0 {
-			return 0, fmt.Errorf("raise action requires amount parameter")
+			return 0, newMessageError("error.raise_amount_required", nil)
 		}
 		raiseAmount := amount[0]
 
@@ -1556,7 +2077,10 @@ func (h *Hand) ProcessActionWithSeats(seatIndex int, action string, playerStack
 			// Not all-in, so validate min/max bounds
 			minRaise := h.GetMinRaise()
 			if raiseAmount < minRaise {
-				return 0, fmt.Errorf("raise amount below minimum")
+				return 0, newMessageError("error.raise_below_minimum", nil)
+			}
+			if err := h.checkRaiseIncrement(raiseAmount, raiseAmount-h.PlayerBets[seatIndex] == playerStack); err != nil {
+				return 0, err
 			}
 		}
 
@@ -1566,7 +2090,7 @@ func (h *Hand) ProcessActionWithSeats(seatIndex int, action string, playerStack
 
 		// Sanity check: don't exceed player's stack
 		if chipsToBet > playerStack {
-			return 0, fmt.Errorf("raise exceeds player stack")
+			return 0, newMessageError("error.raise_exceeds_stack", nil)
 		}
 
 		// Update CurrentBet to this raise amount
@@ -1637,8 +2161,9 @@ func (h *Hand) IsBettingRoundComplete(seats [6]Seat) bool {
 	}
 
 	// Check if all active (non-folded) players have acted
+	// All-in players (blinds posted with their last chips) have nothing left to act on
 	for _, seatNum := range activePlayers {
-		if !h.FoldedPlayers[seatNum] && !h.ActedPlayers[seatNum] {
+		if !h.FoldedPlayers[seatNum] && !h.ActedPlayers[seatNum] && seats[seatNum].Stack > 0 {
 			// This player hasn't acted yet, round not complete
 			return false
 		}
@@ -1713,7 +2238,18 @@ func (h *Hand) AreAllActivePlayersAllIn(seats [6]Seat) bool {
 	return false
 }
 
-// AdvanceAction moves the current actor to the next active player
+// playersWhoCanAct counts the players still in the hand (not folded) with chips left to bet
+func (h *Hand) playersWhoCanAct(seats [6]Seat) int {
+	count := 0
+	for i := 0; i < 6; i++ {
+		if seats[i].Status == "active" && !h.FoldedPlayers[i] && seats[i].Stack > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// AdvanceAction moves the current actor to the next active player who still has chips to act with
 // Returns the seat number of the next actor, or nil if no next actor exists (only one player left)
 // Returns error if CurrentActor is nil (no current actor set)
 func (h *Hand) AdvanceAction(seats [6]Seat) (*int, error) {
@@ -1721,9 +2257,24 @@ func (h *Hand) AdvanceAction(seats [6]Seat) (*int, error) {
 		return nil, fmt.Errorf("current actor is nil")
 	}
 
-	// Get the next active seat after current actor
-	nextSeat := h.GetNextActiveSeat(*h.CurrentActor, seats)
-	return nextSeat, nil
+	return h.nextSeatToAct(*h.CurrentActor, seats), nil
+}
+
+// nextSeatToAct returns the next active seat after fromSeat, passing over all-in players
+// Returns nil if only one player is left; if everyone else is all-in, the next active seat
+// is returned as GetNextActiveSeat would
+func (h *Hand) nextSeatToAct(fromSeat int, seats [6]Seat) *int {
+	first := h.GetNextActiveSeat(fromSeat, seats)
+	for next := first; next != nil; {
+		if seats[*next].Stack > 0 {
+			return next
+		}
+		next = h.GetNextActiveSeat(*next, seats)
+		if next == nil || *next == *first || *next == fromSeat {
+			break
+		}
+	}
+	return first
 }
 
 // AdvanceStreet moves the hand to the next street and resets betting state
@@ -1758,6 +2309,11 @@ func (h *Hand) AdvanceStreet() {
 	h.ActedPlayers = make(map[int]bool)
 	h.CurrentActor = nil
 	h.BigBlindHasOption = false
+	for seatIndex, last := range h.LastActions {
+		if last.Action != "fold" {
+			delete(h.LastActions, seatIndex)
+		}
+	}
 
 	// On postflop streets, preserve the big blind as minimum raise increment
 	if h.Street != "preflop" {
@@ -1810,26 +2366,18 @@ func (h *Hand) AdvanceToNextStreet() error {
 
 // AdvanceToNextStreetWithBroadcast advances the hand to the next street and broadcasts the board dealt event
 // This is the table-level method that wraps the hand's AdvanceToNextStreet and adds WebSocket broadcasting
+// The street change is a guarded phase transition; advancing past the river is a no-op
 func (t *Table) AdvanceToNextStreetWithBroadcast() error {
-	t.mu.RLock()
+	streetStart, lockWait := t.lockTimed()
 	hand := t.CurrentHand
 	if hand == nil {
-		t.mu.RUnlock()
-		return fmt.Errorf("no hand in progress")
-	}
-
-	currentStreet := hand.Street
-	t.mu.RUnlock()
-
-	// Advance to the next street (deals the board cards)
-	err := hand.AdvanceToNextStreet()
-	if err != nil {
-		return err
+		t.mu.Unlock()
+		return newMessageError("error.no_hand_in_progress", nil)
 	}
 
-	// Determine the street name for the broadcast
+	// Determine the street being dealt
 	var streetName string
-	switch currentStreet {
+	switch hand.Street {
 	case "preflop":
 		streetName = "flop"
 	case "flop":
@@ -1838,9 +2386,40 @@ func (t *Table) AdvanceToNextStreetWithBroadcast() error {
 		streetName = "river"
 	default:
 		// No board cards on river or if hand is complete
+		t.mu.Unlock()
 		return nil
 	}
 
+	_, span := tracer().Start(t.handContextLocked(), SpanStreet,
+		trace.WithTimestamp(streetStart),
+		trace.WithAttributes(
+			attribute.String("poker.street", streetName),
+			lockWaitAttribute(lockWait),
+		),
+	)
+	defer span.End()
+
+	if err := t.transitionLocked(streetPhase(streetName)); err != nil {
+		t.mu.Unlock()
+		failSpan(span, err)
+		return err
+	}
+
+	// Advance to the next street (deals the board cards)
+	err := hand.AdvanceToNextStreet()
+	if err == nil {
+		// The new board cards must not clash with the rest of the deck
+		_, err = t.checkHandInvariantsLocked()
+	}
+	if err == nil {
+		t.publishEvent(Event{Type: EventBoardDealt, Street: streetName, Board: slices.Clone(hand.BoardCards), Texture: ClassifyBoard(hand.BoardCards)})
+	}
+	t.mu.Unlock()
+	if err != nil {
+		failSpan(span, err)
+		return err
+	}
+
 	// Broadcast the board dealt event to all players at the table
 	if t.Server != nil {
 		err = t.Server.broadcastBoardDealt(t, streetName)
@@ -1908,12 +2487,17 @@ func CalculateSidePots(contributions map[int]int, foldedPlayers map[int]bool) []
 		potAmount := (currentLevel - previousLevel) * playersAtThisLevel
 
 		// Collect all eligible seats (contributors at this level and not folded)
-		var eligibleSeats []int
+		var eligibleSeats, contributors []int
 		for seat, amount := range contributions {
-			if amount >= currentLevel && !foldedPlayers[seat] {
+			if amount < currentLevel {
+				continue
+			}
+			contributors = append(contributors, seat)
+			if !foldedPlayers[seat] {
 				eligibleSeats = append(eligibleSeats, seat)
 			}
 		}
+		slices.Sort(contributors)
 
 		// Sort eligible seats for consistent ordering
 		for i := 0; i < len(eligibleSeats); i++ {
@@ -1928,6 +2512,7 @@ func CalculateSidePots(contributions map[int]int, foldedPlayers map[int]bool) []
 		pots = append(pots, SidePot{
 			Amount:        potAmount,
 			EligibleSeats: eligibleSeats,
+			Contributors:  contributors,
 		})
 
 		previousLevel = currentLevel
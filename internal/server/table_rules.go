@@ -0,0 +1,95 @@
+package server
+
+import "time"
+
+// TableRules are the stakes and timers a table's hands are played under. A hand keeps the rules
+// it was dealt with: blinds an admin sets and config reloads during a hand wait for the next one.
+type TableRules struct {
+	Blinds          BlindLevel `json:"blinds"`
+	ActionTimeoutMs int64      `json:"actionTimeoutMs"` // The action clock at the table's speed; 0 = no clock
+	TimeBankMs      int64      `json:"timeBankMs"`      // Each player's time bank at the table's speed; 0 = none
+	RakePercent     float64    `json:"rakePercent"`
+	RakeCap         int        `json:"rakeCap"` // 0 = no cap
+}
+
+// RulesPendingPayload is the payload of rules_pending, sent to a table when its rules change
+// during a hand: the rules the next hand is dealt under
+type RulesPendingPayload struct {
+	TableID string `json:"tableId"`
+	TableRules
+}
+
+// handRules are the config values that govern a hand once it is dealt
+type handRules struct {
+	actionTimeout time.Duration
+	timeBank      time.Duration
+	pacing        PacingConfig
+	rake          RakeConfig
+}
+
+// rulesFromConfig returns the hand rules cfg sets
+func rulesFromConfig(cfg Config) handRules {
+	return handRules{actionTimeout: cfg.ActionTimeout, timeBank: cfg.TimeBank, pacing: cfg.Pacing, rake: cfg.Rake}
+}
+
+// rulesLocked returns the rules of the hand in progress, or the current config's between hands
+// (internal, must be called with lock held)
+func (t *Table) rulesLocked() handRules {
+	if t.CurrentHand != nil && t.handRules != nil {
+		return *t.handRules
+	}
+	if t.Server == nil {
+		return handRules{}
+	}
+	return rulesFromConfig(t.Server.Config())
+}
+
+// tableRulesLocked returns the wire form of rules played with blinds
+// (internal, must be called with lock held)
+func (t *Table) tableRulesLocked(rules handRules, blinds BlindLevel) TableRules {
+	return TableRules{
+		Blinds:          blinds,
+		ActionTimeoutMs: t.speedUp(rules.actionTimeout).Milliseconds(),
+		TimeBankMs:      t.speedUp(rules.timeBank).Milliseconds(),
+		RakePercent:     rules.rake.Percent,
+		RakeCap:         rules.rake.Cap,
+	}
+}
+
+// pendingRulesLocked returns the rules the next hand is dealt under when they differ from those
+// of the hand in progress; nil between hands or when nothing changes
+// (internal, must be called with lock held)
+func (t *Table) pendingRulesLocked() *TableRules {
+	if t.CurrentHand == nil || t.Server == nil {
+		return nil
+	}
+	blinds := BlindLevel{SmallBlind: t.SmallBlind, BigBlind: t.BigBlind}
+	current := t.tableRulesLocked(t.rulesLocked(), blinds)
+	if t.pendingBlinds != nil {
+		blinds = *t.pendingBlinds
+	}
+	next := t.tableRulesLocked(rulesFromConfig(t.Server.Config()), blinds)
+	if next == current {
+		return nil
+	}
+	return &next
+}
+
+// announcePendingRules tells the table which rules its next hand is dealt under, if they
+// changed during the hand in progress
+func (s *Server) announcePendingRules(table *Table) {
+	table.mu.RLock()
+	pending := table.pendingRulesLocked()
+	table.mu.RUnlock()
+	if pending == nil {
+		return
+	}
+
+	payload := RulesPendingPayload{TableID: table.ID, TableRules: *pending}
+	if err := s.broadcastTableMessage(table, "rules_pending", payload); err != nil {
+		s.logger.Warn("failed to broadcast rules_pending", "tableID", table.ID, "error", err)
+	}
+	if err := s.broadcastTableState(table.ID, nil); err != nil {
+		s.logger.Warn("failed to broadcast table_state", "tableID", table.ID, "error", err)
+	}
+}
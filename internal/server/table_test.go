@@ -1,7 +1,10 @@
 package server
 
 import (
+	"context"
 	"log/slog"
+	"maps"
+	"slices"
 	"sync"
 	"testing"
 )
@@ -526,9 +529,9 @@ func TestCardString(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		card := Card{Rank: tt.rank, Suit: tt.suit}
+		card := NewCard(tt.rank, tt.suit)
 		if got := card.String(); got != tt.want {
-			t.Errorf("Card{Rank: %q, Suit: %q}.String() = %q, want %q", tt.rank, tt.suit, got, tt.want)
+			t.Errorf("NewCard(%q, %q).String() = %q, want %q", tt.rank, tt.suit, got, tt.want)
 		}
 	}
 }
@@ -1378,9 +1381,9 @@ func TestDealHoleCardsAllPlayersActive(t *testing.T) {
 func TestDealHoleCardsInsufficientCards(t *testing.T) {
 	// Create a small deck with only 3 cards
 	smallDeck := []Card{
-		{Rank: "A", Suit: "s"},
-		{Rank: "K", Suit: "h"},
-		{Rank: "Q", Suit: "d"},
+		NewCard("A", "s"),
+		NewCard("K", "h"),
+		NewCard("Q", "d"),
 	}
 
 	hand := &Hand{
@@ -1770,6 +1773,50 @@ func TestStartHandAllInBlind(t *testing.T) {
 	}
 }
 
+// TestStartHandAllInBigBlind verifies a big blind all-in from posting is never asked to act and
+// the round closes once the others call
+func TestStartHandAllInBigBlind(t *testing.T) {
+	table := NewTable("table-1", "Table 1", nil)
+
+	tokens := []string{"player-0", "player-1", "player-2"}
+	stacks := []int{1000, 1000, 15} // The big blind can only post 15
+	for i := range tokens {
+		table.Seats[i].Token = &tokens[i]
+		table.Seats[i].Status = "active"
+		table.Seats[i].Stack = stacks[i]
+	}
+
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("expected no error starting hand, got %v", err)
+	}
+	hand := table.CurrentHand
+	if hand.BigBlindHasOption {
+		t.Error("expected no option for an all-in big blind")
+	}
+
+	for _, seat := range []int{0, 1} {
+		if hand.CurrentActor == nil || *hand.CurrentActor != seat {
+			t.Fatalf("expected seat %d to act, got %v", seat, hand.CurrentActor)
+		}
+		moved, err := hand.ProcessAction(seat, "call", table.Seats[seat].Stack)
+		if err != nil {
+			t.Fatalf("seat %d call failed: %v", seat, err)
+		}
+		table.Seats[seat].Stack -= moved
+		if !hand.IsBettingRoundComplete(table.Seats) {
+			next, err := hand.AdvanceAction(table.Seats)
+			if err != nil {
+				t.Fatal(err)
+			}
+			hand.CurrentActor = next
+		}
+	}
+
+	if !hand.IsBettingRoundComplete(table.Seats) {
+		t.Errorf("expected the round to be complete, actor is %d", *hand.CurrentActor)
+	}
+}
+
 // ============ PHASE 1: TURN ORDER & ACTION STATE TESTS ============
 
 // TestGetFirstActor_HeadsUp verifies dealer acts first preflop in heads-up
@@ -2050,8 +2097,11 @@ func TestDistributePot_SingleWinner(t *testing.T) {
 	// Seat 0 (30), Seat 1 (40), Seat 2 (30) - only seat 2 is not folded
 	// Main pot at level 30: 30 * 3 = 90 (all contributed at least 30)
 	// Seat 2 only eligible for main pot (level 30) since it's the only non-folded player
-	// Side pot at level 40: 10 * 1 (only seat 1 at this level, but it's folded) = 10, no eligible winners
-	// So seat 2 wins 90, the 10 remains unawarded
+	// Side pot at level 40: 10 * 1 (only seat 1 at this level, and it's folded) = 10, no eligible winners
+	// So seat 2 wins 90, and the 10 nobody matched is returned to seat 1, who is still seated
+	for seat := 0; seat < 3; seat++ {
+		table.Seats[seat].Status = "active"
+	}
 	table.CurrentHand = &Hand{
 		Pot: 100,
 		TotalContributions: map[int]int{
@@ -2078,9 +2128,12 @@ func TestDistributePot_SingleWinner(t *testing.T) {
 		t.Errorf("expected winner at seat 2 to receive 90 (main pot), got %d", result[2])
 	}
 
-	// Verify only the winner seat is in the map
-	if len(result) != 1 {
-		t.Errorf("expected only 1 entry in result map, got %d", len(result))
+	// Seat 1 gets back its uncalled 10
+	if result[1] != 10 {
+		t.Errorf("expected seat 1 to get its uncalled 10 back, got %d", result[1])
+	}
+	if len(result) != 2 {
+		t.Errorf("expected 2 entries in result map, got %d", len(result))
 	}
 }
 
@@ -2239,11 +2292,11 @@ func TestHandleShowdown_UpdatesStacks(t *testing.T) {
 
 	// Add board cards (5 cards for river) for proper hand evaluation
 	table.CurrentHand.BoardCards = []Card{
-		{Rank: "A", Suit: "s"},
-		{Rank: "K", Suit: "h"},
-		{Rank: "Q", Suit: "d"},
-		{Rank: "J", Suit: "c"},
-		{Rank: "T", Suit: "s"},
+		NewCard("A", "s"),
+		NewCard("K", "h"),
+		NewCard("Q", "d"),
+		NewCard("J", "c"),
+		NewCard("T", "s"),
 	}
 
 	// Verify initial stacks after blind posting and seat 0's call
@@ -2307,11 +2360,11 @@ func TestHandleShowdown_DetectsBustOut(t *testing.T) {
 
 	// Add board cards for proper hand evaluation
 	table.CurrentHand.BoardCards = []Card{
-		{Rank: "2", Suit: "s"},
-		{Rank: "3", Suit: "h"},
-		{Rank: "4", Suit: "d"},
-		{Rank: "5", Suit: "c"},
-		{Rank: "6", Suit: "s"},
+		NewCard("2", "s"),
+		NewCard("3", "h"),
+		NewCard("4", "d"),
+		NewCard("5", "c"),
+		NewCard("6", "s"),
 	}
 
 	// Before HandleShowdown, both seats are occupied
@@ -2358,11 +2411,11 @@ func TestHandleShowdown_ClearsBustOutSeat(t *testing.T) {
 
 	// Add board cards for proper hand evaluation
 	table.CurrentHand.BoardCards = []Card{
-		{Rank: "7", Suit: "s"},
-		{Rank: "8", Suit: "h"},
-		{Rank: "9", Suit: "d"},
-		{Rank: "T", Suit: "c"},
-		{Rank: "J", Suit: "s"},
+		NewCard("7", "s"),
+		NewCard("8", "h"),
+		NewCard("9", "d"),
+		NewCard("T", "c"),
+		NewCard("J", "s"),
 	}
 
 	// Call HandleShowdown
@@ -2568,9 +2621,9 @@ func TestHand_DealFlop_ErrorsIfDeckExhausted(t *testing.T) {
 		BigBlindSeat:   2,
 		Pot:            30,
 		Deck: []Card{
-			{Rank: "A", Suit: "s"},
-			{Rank: "K", Suit: "h"},
-			{Rank: "Q", Suit: "d"},
+			NewCard("A", "s"),
+			NewCard("K", "h"),
+			NewCard("Q", "d"),
 		},
 		HoleCards:  make(map[int][]Card),
 		BoardCards: []Card{},
@@ -2602,7 +2655,7 @@ func TestHand_DealTurn_DealsOneCard(t *testing.T) {
 		Pot:            30,
 		Deck:           NewDeck(),
 		HoleCards:      make(map[int][]Card),
-		BoardCards:     []Card{{Rank: "A", Suit: "s"}, {Rank: "K", Suit: "h"}, {Rank: "Q", Suit: "d"}},
+		BoardCards:     []Card{NewCard("A", "s"), NewCard("K", "h"), NewCard("Q", "d")},
 		Street:         "flop",
 	}
 
@@ -2645,7 +2698,7 @@ func TestHand_DealTurn_BurnsCardBeforeDealing(t *testing.T) {
 		Pot:            30,
 		Deck:           NewDeck(),
 		HoleCards:      make(map[int][]Card),
-		BoardCards:     []Card{{Rank: "A", Suit: "s"}, {Rank: "K", Suit: "h"}, {Rank: "Q", Suit: "d"}},
+		BoardCards:     []Card{NewCard("A", "s"), NewCard("K", "h"), NewCard("Q", "d")},
 		Street:         "flop",
 	}
 
@@ -2679,8 +2732,8 @@ func TestHand_DealRiver_DealsOneCard(t *testing.T) {
 		Deck:           NewDeck(),
 		HoleCards:      make(map[int][]Card),
 		BoardCards: []Card{
-			{Rank: "A", Suit: "s"}, {Rank: "K", Suit: "h"}, {Rank: "Q", Suit: "d"},
-			{Rank: "J", Suit: "c"},
+			NewCard("A", "s"), NewCard("K", "h"), NewCard("Q", "d"),
+			NewCard("J", "c"),
 		},
 		Street: "turn",
 	}
@@ -2725,8 +2778,8 @@ func TestHand_DealRiver_BurnsCardBeforeDealing(t *testing.T) {
 		Deck:           NewDeck(),
 		HoleCards:      make(map[int][]Card),
 		BoardCards: []Card{
-			{Rank: "A", Suit: "s"}, {Rank: "K", Suit: "h"}, {Rank: "Q", Suit: "d"},
-			{Rank: "J", Suit: "c"},
+			NewCard("A", "s"), NewCard("K", "h"), NewCard("Q", "d"),
+			NewCard("J", "c"),
 		},
 		Street: "turn",
 	}
@@ -3900,6 +3953,55 @@ func TestAdvanceAction_WithFoldedPlayers(t *testing.T) {
 	}
 }
 
+// TestAdvanceAction_SkipsAllInPlayers passes over a player with no chips left to act with
+func TestAdvanceAction_SkipsAllInPlayers(t *testing.T) {
+	table := NewTable("table-1", "Test Table", nil)
+
+	// Set up 3 active players, player 1 all-in
+	token1, token2, token3 := "player1", "player2", "player3"
+	table.Seats[0].Token = &token1
+	table.Seats[0].Status = "active"
+	table.Seats[0].Stack = 1000
+	table.Seats[1].Token = &token2
+	table.Seats[1].Status = "active"
+	table.Seats[1].Stack = 0
+	table.Seats[2].Token = &token3
+	table.Seats[2].Status = "active"
+	table.Seats[2].Stack = 1000
+
+	table.CurrentHand = &Hand{
+		DealerSeat:     0,
+		SmallBlindSeat: 1,
+		BigBlindSeat:   2,
+		Pot:            30,
+		CurrentBet:     20,
+		Street:         "preflop",
+		FoldedPlayers:  make(map[int]bool),
+		ActedPlayers:   map[int]bool{0: true},
+		PlayerBets:     map[int]int{0: 20, 1: 10, 2: 20},
+	}
+
+	currentActor := 0
+	table.CurrentHand.CurrentActor = &currentActor
+
+	nextActor, err := table.CurrentHand.AdvanceAction(table.Seats)
+	if err != nil {
+		t.Errorf("expected no error advancing action, got %v", err)
+	}
+
+	if nextActor == nil {
+		t.Error("expected nextActor to not be nil")
+	} else if *nextActor != 2 {
+		t.Errorf("expected next actor to skip all-in player and be 2, got %d", *nextActor)
+	}
+
+	// The all-in player has nothing to act on, so the round closes once player 2 acts
+	table.CurrentHand.ActedPlayers[2] = true
+	if !table.CurrentHand.IsBettingRoundComplete(table.Seats) {
+		t.Error("expected the round to be complete without the all-in player acting")
+	}
+}
+
 // TestAdvanceAction_ReturnNilWhenOnlyOnePlayerLeft returns nil when only one player remains
 func TestAdvanceAction_ReturnNilWhenOnlyOnePlayerLeft(t *testing.T) {
 	table := NewTable("table-1", "Test Table", nil)
@@ -5123,7 +5225,7 @@ func TestHand_AdvanceToNextStreet_FlopToTurn(t *testing.T) {
 		Pot:            50,
 		Deck:           NewDeck(),
 		HoleCards:      make(map[int][]Card),
-		BoardCards:     []Card{{Rank: "A", Suit: "s"}, {Rank: "K", Suit: "h"}, {Rank: "Q", Suit: "d"}},
+		BoardCards:     []Card{NewCard("A", "s"), NewCard("K", "h"), NewCard("Q", "d")},
 		Street:         "flop",
 		CurrentBet:     20,
 		PlayerBets:     make(map[int]int),
@@ -5169,8 +5271,8 @@ func TestHand_AdvanceToNextStreet_TurnToRiver(t *testing.T) {
 		Deck:           NewDeck(),
 		HoleCards:      make(map[int][]Card),
 		BoardCards: []Card{
-			{Rank: "A", Suit: "s"}, {Rank: "K", Suit: "h"}, {Rank: "Q", Suit: "d"},
-			{Rank: "J", Suit: "c"},
+			NewCard("A", "s"), NewCard("K", "h"), NewCard("Q", "d"),
+			NewCard("J", "c"),
 		},
 		Street:        "turn",
 		CurrentBet:    20,
@@ -5217,8 +5319,8 @@ func TestHand_AdvanceToNextStreet_RiverDoesNotAdvance(t *testing.T) {
 		Deck:           NewDeck(),
 		HoleCards:      make(map[int][]Card),
 		BoardCards: []Card{
-			{Rank: "A", Suit: "s"}, {Rank: "K", Suit: "h"}, {Rank: "Q", Suit: "d"},
-			{Rank: "J", Suit: "c"}, {Rank: "T", Suit: "s"},
+			NewCard("A", "s"), NewCard("K", "h"), NewCard("Q", "d"),
+			NewCard("J", "c"), NewCard("T", "s"),
 		},
 		Street:        "river",
 		CurrentBet:    0,
@@ -5260,8 +5362,8 @@ func TestHand_AdvanceToNextStreet_ErrorsIfInsufficientDeck(t *testing.T) {
 		BigBlindSeat:   2,
 		Pot:            30,
 		Deck: []Card{
-			{Rank: "A", Suit: "s"},
-			{Rank: "K", Suit: "h"},
+			NewCard("A", "s"),
+			NewCard("K", "h"),
 		},
 		HoleCards:     make(map[int][]Card),
 		BoardCards:    []Card{},
@@ -6251,12 +6353,12 @@ func TestDetermineWinner_SingleWinner_HighCard(t *testing.T) {
 		BigBlindSeat:   2,
 		Street:         "river",
 		HoleCards: map[int][]Card{
-			0: {Card{Rank: "A", Suit: "s"}, Card{Rank: "K", Suit: "s"}}, // Strong hand
-			1: {Card{Rank: "2", Suit: "h"}, Card{Rank: "3", Suit: "h"}}, // Weak hand
+			0: {NewCard("A", "s"), NewCard("K", "s")}, // Strong hand
+			1: {NewCard("2", "h"), NewCard("3", "h")}, // Weak hand
 		},
 		BoardCards: []Card{
-			{Rank: "T", Suit: "d"}, {Rank: "J", Suit: "c"}, {Rank: "Q", Suit: "s"},
-			{Rank: "K", Suit: "h"}, {Rank: "2", Suit: "d"},
+			NewCard("T", "d"), NewCard("J", "c"), NewCard("Q", "s"),
+			NewCard("K", "h"), NewCard("2", "d"),
 		},
 		FoldedPlayers: make(map[int]bool),
 	}
@@ -6290,12 +6392,12 @@ func TestDetermineWinner_SingleWinner_Flush(t *testing.T) {
 		BigBlindSeat:   2,
 		Street:         "river",
 		HoleCards: map[int][]Card{
-			0: {Card{Rank: "A", Suit: "s"}, Card{Rank: "K", Suit: "s"}}, // Flush
-			1: {Card{Rank: "Q", Suit: "h"}, Card{Rank: "J", Suit: "h"}}, // No flush
+			0: {NewCard("A", "s"), NewCard("K", "s")}, // Flush
+			1: {NewCard("Q", "h"), NewCard("J", "h")}, // No flush
 		},
 		BoardCards: []Card{
-			{Rank: "T", Suit: "s"}, {Rank: "9", Suit: "s"}, {Rank: "8", Suit: "s"},
-			{Rank: "5", Suit: "c"}, {Rank: "4", Suit: "d"},
+			NewCard("T", "s"), NewCard("9", "s"), NewCard("8", "s"),
+			NewCard("5", "c"), NewCard("4", "d"),
 		},
 		FoldedPlayers: make(map[int]bool),
 	}
@@ -6329,12 +6431,12 @@ func TestDetermineWinner_Tie_TwoPlayers(t *testing.T) {
 		BigBlindSeat:   2,
 		Street:         "river",
 		HoleCards: map[int][]Card{
-			0: {Card{Rank: "A", Suit: "s"}, Card{Rank: "K", Suit: "h"}}, // Identical best 5
-			1: {Card{Rank: "2", Suit: "d"}, Card{Rank: "3", Suit: "c"}}, // Identical best 5
+			0: {NewCard("A", "s"), NewCard("K", "h")}, // Identical best 5
+			1: {NewCard("2", "d"), NewCard("3", "c")}, // Identical best 5
 		},
 		BoardCards: []Card{
-			{Rank: "A", Suit: "d"}, {Rank: "K", Suit: "c"}, {Rank: "Q", Suit: "s"},
-			{Rank: "J", Suit: "h"}, {Rank: "T", Suit: "d"},
+			NewCard("A", "d"), NewCard("K", "c"), NewCard("Q", "s"),
+			NewCard("J", "h"), NewCard("T", "d"),
 		},
 		FoldedPlayers: make(map[int]bool),
 	}
@@ -6380,13 +6482,13 @@ func TestDetermineWinner_Tie_ThreePlayers(t *testing.T) {
 		BigBlindSeat:   2,
 		Street:         "river",
 		HoleCards: map[int][]Card{
-			0: {Card{Rank: "2", Suit: "s"}, Card{Rank: "3", Suit: "s"}},
-			1: {Card{Rank: "4", Suit: "h"}, Card{Rank: "5", Suit: "h"}},
-			2: {Card{Rank: "6", Suit: "d"}, Card{Rank: "7", Suit: "d"}},
+			0: {NewCard("2", "s"), NewCard("3", "s")},
+			1: {NewCard("4", "h"), NewCard("5", "h")},
+			2: {NewCard("6", "d"), NewCard("7", "d")},
 		},
 		BoardCards: []Card{
-			{Rank: "A", Suit: "c"}, {Rank: "K", Suit: "s"}, {Rank: "Q", Suit: "h"},
-			{Rank: "J", Suit: "d"}, {Rank: "T", Suit: "c"},
+			NewCard("A", "c"), NewCard("K", "s"), NewCard("Q", "h"),
+			NewCard("J", "d"), NewCard("T", "c"),
 		},
 		FoldedPlayers: make(map[int]bool),
 	}
@@ -6417,12 +6519,12 @@ func TestDetermineWinner_HeadsUp(t *testing.T) {
 		BigBlindSeat:   1,
 		Street:         "river",
 		HoleCards: map[int][]Card{
-			0: {Card{Rank: "A", Suit: "s"}, Card{Rank: "A", Suit: "h"}}, // Pair of aces
-			1: {Card{Rank: "K", Suit: "d"}, Card{Rank: "K", Suit: "c"}}, // Pair of kings
+			0: {NewCard("A", "s"), NewCard("A", "h")}, // Pair of aces
+			1: {NewCard("K", "d"), NewCard("K", "c")}, // Pair of kings
 		},
 		BoardCards: []Card{
-			{Rank: "2", Suit: "s"}, {Rank: "3", Suit: "h"}, {Rank: "4", Suit: "d"},
-			{Rank: "5", Suit: "c"}, {Rank: "7", Suit: "s"},
+			NewCard("2", "s"), NewCard("3", "h"), NewCard("4", "d"),
+			NewCard("5", "c"), NewCard("7", "s"),
 		},
 		FoldedPlayers: make(map[int]bool),
 	}
@@ -6456,14 +6558,14 @@ func TestDetermineWinner_MultiWay_FourPlayers(t *testing.T) {
 		BigBlindSeat:   2,
 		Street:         "river",
 		HoleCards: map[int][]Card{
-			0: {Card{Rank: "A", Suit: "s"}, Card{Rank: "A", Suit: "h"}}, // Pair of aces (best)
-			1: {Card{Rank: "K", Suit: "d"}, Card{Rank: "K", Suit: "c"}}, // Pair of kings
-			2: {Card{Rank: "Q", Suit: "s"}, Card{Rank: "J", Suit: "h"}}, // High card QJ
-			3: {Card{Rank: "T", Suit: "d"}, Card{Rank: "9", Suit: "c"}}, // High card T9
+			0: {NewCard("A", "s"), NewCard("A", "h")}, // Pair of aces (best)
+			1: {NewCard("K", "d"), NewCard("K", "c")}, // Pair of kings
+			2: {NewCard("Q", "s"), NewCard("J", "h")}, // High card QJ
+			3: {NewCard("T", "d"), NewCard("9", "c")}, // High card T9
 		},
 		BoardCards: []Card{
-			{Rank: "2", Suit: "s"}, {Rank: "3", Suit: "h"}, {Rank: "4", Suit: "d"},
-			{Rank: "6", Suit: "c"}, {Rank: "8", Suit: "s"},
+			NewCard("2", "s"), NewCard("3", "h"), NewCard("4", "d"),
+			NewCard("6", "c"), NewCard("8", "s"),
 		},
 		FoldedPlayers: make(map[int]bool),
 	}
@@ -6499,13 +6601,13 @@ func TestDetermineWinner_SkipsFoldedPlayers(t *testing.T) {
 		BigBlindSeat:   2,
 		Street:         "river",
 		HoleCards: map[int][]Card{
-			0: {Card{Rank: "2", Suit: "h"}, Card{Rank: "3", Suit: "h"}}, // Weak but not folded
-			1: {Card{Rank: "A", Suit: "s"}, Card{Rank: "K", Suit: "s"}}, // Strong but folded
-			2: {Card{Rank: "Q", Suit: "d"}, Card{Rank: "J", Suit: "d"}}, // Medium but folded
+			0: {NewCard("2", "h"), NewCard("3", "h")}, // Weak but not folded
+			1: {NewCard("A", "s"), NewCard("K", "s")}, // Strong but folded
+			2: {NewCard("Q", "d"), NewCard("J", "d")}, // Medium but folded
 		},
 		BoardCards: []Card{
-			{Rank: "4", Suit: "s"}, {Rank: "5", Suit: "h"}, {Rank: "6", Suit: "d"},
-			{Rank: "7", Suit: "c"}, {Rank: "8", Suit: "s"},
+			NewCard("4", "s"), NewCard("5", "h"), NewCard("6", "d"),
+			NewCard("7", "c"), NewCard("8", "s"),
 		},
 		FoldedPlayers: map[int]bool{
 			1: true, // Folded
@@ -6545,12 +6647,12 @@ func TestHandleShowdown_TriggersOnRiverComplete(t *testing.T) {
 		BigBlindSeat:   2,
 		Street:         "river",
 		HoleCards: map[int][]Card{
-			0: {Card{Rank: "A", Suit: "s"}, Card{Rank: "K", Suit: "s"}},
-			1: {Card{Rank: "2", Suit: "h"}, Card{Rank: "3", Suit: "h"}},
+			0: {NewCard("A", "s"), NewCard("K", "s")},
+			1: {NewCard("2", "h"), NewCard("3", "h")},
 		},
 		BoardCards: []Card{
-			{Rank: "5", Suit: "d"}, {Rank: "6", Suit: "c"}, {Rank: "7", Suit: "s"},
-			{Rank: "8", Suit: "h"}, {Rank: "9", Suit: "d"},
+			NewCard("5", "d"), NewCard("6", "c"), NewCard("7", "s"),
+			NewCard("8", "h"), NewCard("9", "d"),
 		},
 		FoldedPlayers: make(map[int]bool),
 	}
@@ -6599,11 +6701,11 @@ func TestHandleShowdown_EarlyWinner_AllFold(t *testing.T) {
 		BigBlindSeat:   2,
 		Street:         "flop",
 		HoleCards: map[int][]Card{
-			0: {Card{Rank: "A", Suit: "s"}, Card{Rank: "K", Suit: "s"}},
-			1: {Card{Rank: "2", Suit: "h"}, Card{Rank: "3", Suit: "h"}},
+			0: {NewCard("A", "s"), NewCard("K", "s")},
+			1: {NewCard("2", "h"), NewCard("3", "h")},
 		},
 		BoardCards: []Card{
-			{Rank: "5", Suit: "d"}, {Rank: "6", Suit: "c"}, {Rank: "7", Suit: "s"},
+			NewCard("5", "d"), NewCard("6", "c"), NewCard("7", "s"),
 		},
 		FoldedPlayers: map[int]bool{
 			1: true, // All but seat 0 folded
@@ -6653,12 +6755,12 @@ func TestHandleShowdown_EarlyWinner_OpponentBustsOut(t *testing.T) {
 		BigBlindSeat:   0,
 		Street:         "river",
 		HoleCards: map[int][]Card{
-			0: {Card{Rank: "A", Suit: "s"}, Card{Rank: "K", Suit: "s"}},
-			1: {Card{Rank: "2", Suit: "h"}, Card{Rank: "3", Suit: "h"}},
+			0: {NewCard("A", "s"), NewCard("K", "s")},
+			1: {NewCard("2", "h"), NewCard("3", "h")},
 		},
 		BoardCards: []Card{
-			{Rank: "5", Suit: "d"}, {Rank: "6", Suit: "c"}, {Rank: "7", Suit: "s"},
-			{Rank: "8", Suit: "h"}, {Rank: "9", Suit: "c"},
+			NewCard("5", "d"), NewCard("6", "c"), NewCard("7", "s"),
+			NewCard("8", "h"), NewCard("9", "c"),
 		},
 		FoldedPlayers: map[int]bool{
 			1: true, // Opponent folded (early winner)
@@ -6717,8 +6819,8 @@ func TestHandleShowdown_EarlyWinner_UnsweptBets(t *testing.T) {
 		BigBlindSeat:   1,
 		Street:         "preflop",
 		HoleCards: map[int][]Card{
-			0: {Card{Rank: "A", Suit: "s"}, Card{Rank: "K", Suit: "s"}},
-			1: {Card{Rank: "2", Suit: "h"}, Card{Rank: "3", Suit: "h"}},
+			0: {NewCard("A", "s"), NewCard("K", "s")},
+			1: {NewCard("2", "h"), NewCard("3", "h")},
 		},
 		// Additional bets during preflop: SB raises additional 90 (to 100 total)
 		PlayerBets: map[int]int{
@@ -6953,14 +7055,14 @@ func TestShowdown_AllInPlayerBustsOut(t *testing.T) {
 
 	// Set specific hole cards to GUARANTEE player 0 wins and player 1 loses
 	table.CurrentHand.HoleCards[0] = []Card{
-		{Rank: "A", Suit: "s"},
-		{Rank: "A", Suit: "h"},
+		NewCard("A", "s"),
+		NewCard("A", "h"),
 	}
 
 	// Player 1 has 2-3 (worst possible hand, will have pair of 2s at best)
 	table.CurrentHand.HoleCards[1] = []Card{
-		{Rank: "2", Suit: "c"},
-		{Rank: "3", Suit: "d"},
+		NewCard("2", "c"),
+		NewCard("3", "d"),
 	}
 
 	// Set board cards that don't form complete hands: K-Q-J-9-2
@@ -6968,11 +7070,11 @@ func TestShowdown_AllInPlayerBustsOut(t *testing.T) {
 	// Player 1 will have pair of 2s (kicker K-Q-J)
 	// Player 0 wins due to higher pair
 	table.CurrentHand.BoardCards = []Card{
-		{Rank: "K", Suit: "c"},
-		{Rank: "Q", Suit: "d"},
-		{Rank: "J", Suit: "s"},
-		{Rank: "9", Suit: "h"},
-		{Rank: "2", Suit: "s"},
+		NewCard("K", "c"),
+		NewCard("Q", "d"),
+		NewCard("J", "s"),
+		NewCard("9", "h"),
+		NewCard("2", "s"),
 	}
 
 	// Manually set street to river (showdown state)
@@ -7078,20 +7180,20 @@ func TestShowdown_MultiplePlayersBustOut(t *testing.T) {
 	// Set specific hole cards to GUARANTEE player 0 wins, players 1 and 2 lose
 	// Player 0 has pair of Kings
 	table.CurrentHand.HoleCards[0] = []Card{
-		{Rank: "K", Suit: "s"},
-		{Rank: "K", Suit: "h"},
+		NewCard("K", "s"),
+		NewCard("K", "h"),
 	}
 
 	// Player 1 has 2-3 (worst possible hand - will have pair of 2s at best)
 	table.CurrentHand.HoleCards[1] = []Card{
-		{Rank: "2", Suit: "c"},
-		{Rank: "3", Suit: "d"},
+		NewCard("2", "c"),
+		NewCard("3", "d"),
 	}
 
 	// Player 2 has 4-5 (low hand - will have pair of 4s or nothing)
 	table.CurrentHand.HoleCards[2] = []Card{
-		{Rank: "4", Suit: "c"},
-		{Rank: "5", Suit: "d"},
+		NewCard("4", "c"),
+		NewCard("5", "d"),
 	}
 
 	// Set board cards that don't form complete hands: Q-J-T-9-2
@@ -7100,11 +7202,11 @@ func TestShowdown_MultiplePlayersBustOut(t *testing.T) {
 	// Player 2 will have high card (kicker K-Q-J-T-9)
 	// Player 0 wins with pair of Kings
 	table.CurrentHand.BoardCards = []Card{
-		{Rank: "Q", Suit: "c"},
-		{Rank: "J", Suit: "d"},
-		{Rank: "T", Suit: "s"},
-		{Rank: "9", Suit: "h"},
-		{Rank: "2", Suit: "s"},
+		NewCard("Q", "c"),
+		NewCard("J", "d"),
+		NewCard("T", "s"),
+		NewCard("9", "h"),
+		NewCard("2", "s"),
 	}
 
 	// Manually set street to river (showdown state)
@@ -7187,12 +7289,12 @@ func TestShowdown_WinnerWithStackNotKicked(t *testing.T) {
 
 	// Set specific hole cards to guarantee player 0 wins
 	table.CurrentHand.HoleCards[0] = []Card{
-		{Rank: "A", Suit: "s"},
-		{Rank: "A", Suit: "h"},
+		NewCard("A", "s"),
+		NewCard("A", "h"),
 	}
 	table.CurrentHand.HoleCards[1] = []Card{
-		{Rank: "K", Suit: "c"},
-		{Rank: "Q", Suit: "d"},
+		NewCard("K", "c"),
+		NewCard("Q", "d"),
 	}
 
 	// Manually set up showdown scenario
@@ -7200,11 +7302,11 @@ func TestShowdown_WinnerWithStackNotKicked(t *testing.T) {
 
 	// Board: 9-8-7-5-2 (no pairs/straights involving K,Q - player 0 has pair of Aces, player 1 has high card K)
 	table.CurrentHand.BoardCards = []Card{
-		{Rank: "9", Suit: "d"},
-		{Rank: "8", Suit: "h"},
-		{Rank: "7", Suit: "s"},
-		{Rank: "5", Suit: "c"},
-		{Rank: "2", Suit: "d"},
+		NewCard("9", "d"),
+		NewCard("8", "h"),
+		NewCard("7", "s"),
+		NewCard("5", "c"),
+		NewCard("2", "d"),
 	}
 
 	// Call HandleShowdown - player 0 should win with pair of Aces
@@ -7290,25 +7392,25 @@ func TestShowdown_AllInWinnerNotKicked(t *testing.T) {
 	// Set specific hole cards to GUARANTEE player 0 wins despite all-in with small stack
 	// Player 0 has pair of Aces (will win)
 	table.CurrentHand.HoleCards[0] = []Card{
-		{Rank: "A", Suit: "s"},
-		{Rank: "A", Suit: "h"},
+		NewCard("A", "s"),
+		NewCard("A", "h"),
 	}
 
 	// Player 1 has 2-3 (worst hand - will lose, will have pair of 2s at best)
 	table.CurrentHand.HoleCards[1] = []Card{
-		{Rank: "2", Suit: "c"},
-		{Rank: "3", Suit: "d"},
+		NewCard("2", "c"),
+		NewCard("3", "d"),
 	}
 
 	// Set board cards that don't form complete hands: K-Q-J-9-2
 	// Player 0 will have pair of Aces (best hand)
 	// Player 1 will have pair of 2s (loses)
 	table.CurrentHand.BoardCards = []Card{
-		{Rank: "K", Suit: "c"},
-		{Rank: "Q", Suit: "d"},
-		{Rank: "J", Suit: "s"},
-		{Rank: "9", Suit: "h"},
-		{Rank: "2", Suit: "s"},
+		NewCard("K", "c"),
+		NewCard("Q", "d"),
+		NewCard("J", "s"),
+		NewCard("9", "h"),
+		NewCard("2", "s"),
 	}
 
 	// Manually set street to river (showdown state)
@@ -7621,6 +7723,120 @@ func TestGetMaxRaise_6P_Whale_Overbets_Everyone(t *testing.T) {
 	}
 }
 
+// TestValidateRaise_BetIncrement verifies raises must be to a multiple of the hand's increment,
+// all-ins excepted, and the minimum raise is rounded up to one
+func TestValidateRaise_BetIncrement(t *testing.T) {
+	hand := &Hand{CurrentBet: 20, LastRaise: 20, BetIncrement: 10}
+
+	err := hand.ValidateRaise(0, 45, 1000, [6]Seat{})
+	if key, params := errorMessageKey(err); key != "error.raise_not_multiple" || params["increment"] != 10 {
+		t.Errorf("expected error.raise_not_multiple with increment 10, got %v", err)
+	}
+	if err := hand.ValidateRaise(0, 50, 1000, [6]Seat{}); err != nil {
+		t.Errorf("expected a raise to 50 valid, got %v", err)
+	}
+	if err := hand.ValidateRaise(0, 995, 995, [6]Seat{}); err != nil {
+		t.Errorf("expected an all-in of any amount valid, got %v", err)
+	}
+
+	// A short all-in raise leaves the minimum off the increment
+	hand = &Hand{CurrentBet: 35, LastRaise: 20, BetIncrement: 10}
+	if got := hand.GetMinRaise(); got != 60 {
+		t.Errorf("expected the minimum raise rounded up to 60, got %d", got)
+	}
+	if got := hand.RaiseStep(); got != 10 {
+		t.Errorf("expected a raise step of 10, got %d", got)
+	}
+	if got := (&Hand{}).RaiseStep(); got != 1 {
+		t.Errorf("expected a raise step of 1 without an increment, got %d", got)
+	}
+}
+
+// TestBetIncrement_ActionRequestAndActions verifies the table's increment is advertised in
+// action_request and enforced on raises
+func TestBetIncrement_ActionRequestAndActions(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{
+		Tables: []TableConfig{{Name: "Main", SmallBlind: 10, BigBlind: 20, BuyIn: 1000, BetIncrement: 10}},
+	})
+	table := server.tables[0]
+	clients := seatNamed(t, server, table, "Alice", "Bob")
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := payloadsOf[ActionRequestPayload](t, clients[0], "action_request")
+	if len(requests) != 1 || requests[0].RaiseStep != 10 || requests[0].MinRaise != 40 || requests[0].MaxRaise != 1000 {
+		t.Fatalf("expected one action_request with min 40, max 1000 and step 10, got %+v", requests)
+	}
+
+	actor := requests[0].SeatIndex
+	err := server.processTableAction(context.Background(), table, nil, "", actor, "raise", 45)
+	if key, _ := errorMessageKey(err); key != "error.raise_not_multiple" {
+		t.Errorf("expected error.raise_not_multiple raising to 45, got %v", err)
+	}
+	if err := server.processTableAction(context.Background(), table, nil, "", actor, "raise", 1000); err != nil {
+		t.Errorf("expected the all-in allowed, got %v", err)
+	}
+}
+
+// TestRaiseRange verifies the raise bounds match what a raise can actually be made for
+func TestRaiseRange(t *testing.T) {
+	hand := &Hand{CurrentBet: 60, LastRaise: 40, PlayerBets: map[int]int{1: 20}}
+
+	if minRaise, maxRaise, ok := hand.RaiseRange(1, 480); !ok || minRaise != 100 || maxRaise != 500 {
+		t.Errorf("expected raises from 100 to 500, got %d to %d (ok %v)", minRaise, maxRaise, ok)
+	}
+	// 20 bet plus 80 behind just covers the minimum raise to 100
+	if minRaise, maxRaise, ok := hand.RaiseRange(1, 80); !ok || minRaise != 100 || maxRaise != 100 {
+		t.Errorf("expected a single raise to 100, got %d to %d (ok %v)", minRaise, maxRaise, ok)
+	}
+	if minRaise, maxRaise, ok := hand.RaiseRange(1, 79); ok || minRaise != 0 || maxRaise != 0 {
+		t.Errorf("expected no raise short of the minimum, got %d to %d (ok %v)", minRaise, maxRaise, ok)
+	}
+	if _, _, ok := hand.RaiseRange(0, 0); ok {
+		t.Error("expected no raise for an all-in player")
+	}
+}
+
+// TestActionRequest_LegalAmounts verifies action_request carries the seat's own bet, a call capped
+// at its stack and no raise bounds when it cannot raise
+func TestActionRequest_LegalAmounts(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{
+		Tables: []TableConfig{{Name: "Main", SmallBlind: 10, BigBlind: 20, BuyIn: 1000}},
+	})
+	table := server.tables[0]
+	clients := seatNamed(t, server, table, "Alice", "Bob")
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := payloadsOf[ActionRequestPayload](t, clients[0], "action_request")
+	if len(requests) != 1 {
+		t.Fatalf("expected one action_request, got %+v", requests)
+	}
+	first := requests[0]
+	if first.CallAmount != 10 || first.PlayerBet != 10 || first.MinRaise != 40 || first.MaxRaise != 1000 {
+		t.Errorf("expected the small blind to call 10 or raise 40 to 1000, got %+v", first)
+	}
+
+	other := table.CurrentHand.BigBlindSeat
+	table.mu.Lock()
+	table.Seats[other].Stack = 300
+	table.mu.Unlock()
+	if err := server.processTableAction(context.Background(), table, nil, "", first.SeatIndex, "raise", 1000); err != nil {
+		t.Fatal(err)
+	}
+
+	requests = payloadsOf[ActionRequestPayload](t, clients[0], "action_request")
+	if len(requests) != 1 || requests[0].SeatIndex != other {
+		t.Fatalf("expected one action_request for the big blind, got %+v", requests)
+	}
+	got := requests[0]
+	if got.CallAmount != 300 || got.PlayerBet != 20 || got.MinRaise != 0 || got.MaxRaise != 0 || slices.Contains(got.ValidActions, "raise") {
+		t.Errorf("expected a call capped at 300 with no raise, got %+v", got)
+	}
+}
+
 // TestValidateRaise_AllIn_Always_Valid verifies all-in is always valid
 // (test for ValidateRaise function)
 func TestValidateRaise_AllIn_Always_Valid(t *testing.T) {
@@ -11181,14 +11397,14 @@ func TestDistributePot_Phase4_OneShortStack_WinnerIsShortStack(t *testing.T) {
 	distribution := table.DistributePot([]int{0})
 
 	// Main pot: 50 * 3 = 150 (short stack + both big stacks)
-	// Side pot: 50 * 2 = 100 (only big stacks can win, both folded, so no winner for side pot)
-	// Winner should get 150 (main pot only)
-	if distribution[0] != 150 {
-		t.Errorf("expected short stack to receive 150 (main pot), got %d", distribution[0])
+	// Side pot: 50 * 2 = 100 (only big stacks contested it, and both folded)
+	// The folded chips are dead money for the last live player, so the winner gets 250
+	if distribution[0] != 250 {
+		t.Errorf("expected short stack to receive 250 (main pot and dead side pot), got %d", distribution[0])
+	}
+	if len(distribution) != 1 {
+		t.Errorf("expected only the short stack paid, got %v", distribution)
 	}
-
-	// The side pot goes to neither seat (both folded), so shouldn't appear in distribution
-	// Total distributed should be 150 (the other 100 remains unawarded but pot is zeroed)
 }
 
 // TestDistributePot_Phase4_OneShortStack_WinnerIsBigStack: Big stack wins all pots
@@ -11375,6 +11591,65 @@ func TestDistributePot_Phase4_OddChip_RemainderGoesToFirst(t *testing.T) {
 	}
 }
 
+// TestDistributePot_OddChipsLeftOfButton verifies chips left over from a split go one each to the
+// winners nearest the button on its left, whatever order the winners are listed in
+func TestDistributePot_OddChipsLeftOfButton(t *testing.T) {
+	tests := []struct {
+		name          string
+		dealer        int
+		contributions map[int]int
+		folded        map[int]bool
+		winners       []int
+		want          map[int]int
+	}{
+		{
+			name:          "first winner left of the button",
+			dealer:        3,
+			contributions: map[int]int{0: 35, 2: 35, 4: 35},
+			folded:        map[int]bool{2: true},
+			winners:       []int{0, 4},
+			want:          map[int]int{0: 52, 4: 53},
+		},
+		{
+			name:          "order wraps past the last seat",
+			dealer:        4,
+			contributions: map[int]int{0: 35, 2: 35, 4: 35},
+			folded:        map[int]bool{2: true},
+			winners:       []int{4, 0},
+			want:          map[int]int{0: 53, 4: 52},
+		},
+		{
+			name:          "button winner gets an odd chip last",
+			dealer:        2,
+			contributions: map[int]int{1: 20, 2: 20, 3: 20, 5: 20},
+			folded:        map[int]bool{3: true},
+			winners:       []int{1, 2, 5},
+			want:          map[int]int{1: 27, 2: 26, 5: 27},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewTable("table-1", "Table 1", nil)
+			pot := 0
+			for seat, amount := range tt.contributions {
+				pot += amount
+				table.Seats[seat] = Seat{Index: seat, Status: "active"}
+			}
+			table.CurrentHand = &Hand{
+				Pot:                pot,
+				DealerSeat:         tt.dealer,
+				TotalContributions: tt.contributions,
+				FoldedPlayers:      tt.folded,
+			}
+
+			if got := table.DistributePot(tt.winners); !maps.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
 // TestDistributePot_Phase4_ZeroPot: No pot to distribute
 func TestDistributePot_Phase4_ZeroPot(t *testing.T) {
 	table := NewTable("table-1", "Table 1", nil)
@@ -11517,6 +11792,137 @@ func TestDistributePot_Phase4_PartialRefund(t *testing.T) {
 	}
 }
 
+// TestDistributePot_DeadSidePots verifies every pot whose contributors have all folded is settled:
+// a lone contributor's uncalled chips go back to them unless they left, and chips matched by
+// several folded players go to whoever won the pot below
+func TestDistributePot_DeadSidePots(t *testing.T) {
+	tests := []struct {
+		name          string
+		contributions map[int]int
+		folded        map[int]bool
+		left          map[int]bool // Seats emptied mid-hand
+		winners       []int
+		want          map[int]int
+	}{
+		{
+			name:          "lone folded overcontribution is returned",
+			contributions: map[int]int{0: 30, 1: 100},
+			folded:        map[int]bool{1: true},
+			winners:       []int{0},
+			want:          map[int]int{0: 60, 1: 70},
+		},
+		{
+			name:          "matched folded chips go to the live player",
+			contributions: map[int]int{0: 30, 1: 100, 2: 100},
+			folded:        map[int]bool{1: true, 2: true},
+			winners:       []int{0},
+			want:          map[int]int{0: 230},
+		},
+		{
+			name:          "matched layer to the live player, lone layer above returned",
+			contributions: map[int]int{0: 30, 1: 60, 2: 100},
+			folded:        map[int]bool{1: true, 2: true},
+			winners:       []int{0},
+			want:          map[int]int{0: 150, 2: 40},
+		},
+		{
+			name:          "dead pot follows the live side pot below it",
+			contributions: map[int]int{0: 50, 1: 100, 2: 150, 3: 150},
+			folded:        map[int]bool{2: true, 3: true},
+			winners:       []int{0},
+			want:          map[int]int{0: 200, 1: 250},
+		},
+		{
+			name:          "dead pot split between tied winners",
+			contributions: map[int]int{0: 40, 1: 40, 2: 75, 3: 75},
+			folded:        map[int]bool{2: true, 3: true},
+			winners:       []int{0, 1},
+			want:          map[int]int{0: 115, 1: 115},
+		},
+		{
+			name:          "single chip nobody matched is returned",
+			contributions: map[int]int{0: 40, 1: 40, 2: 75, 3: 76},
+			folded:        map[int]bool{2: true, 3: true},
+			winners:       []int{0, 1},
+			want:          map[int]int{0: 115, 1: 115, 3: 1},
+		},
+		{
+			name:          "lone overcontribution of a player who left goes to the live player",
+			contributions: map[int]int{0: 30, 1: 100},
+			folded:        map[int]bool{1: true},
+			left:          map[int]bool{1: true},
+			winners:       []int{0},
+			want:          map[int]int{0: 130},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewTable("table-1", "Table 1", nil)
+			pot := 0
+			for seat, amount := range tt.contributions {
+				pot += amount
+				table.Seats[seat] = Seat{Index: seat, Status: "active"}
+				if tt.left[seat] {
+					table.Seats[seat].Status = "empty"
+				}
+			}
+			table.CurrentHand = &Hand{Pot: pot, TotalContributions: tt.contributions, FoldedPlayers: tt.folded}
+
+			got := table.DistributePot(tt.winners)
+
+			awarded := 0
+			for _, amount := range got {
+				awarded += amount
+			}
+			if awarded != pot {
+				t.Errorf("expected all %d chips awarded, got %d (%v)", pot, awarded, got)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestDistributePot_SidePotGoesToBestEligibleHand: the short stack wins the main pot with the best
+// hand, and the side pot it is not eligible for goes to the better of the two remaining hands
+func TestDistributePot_SidePotGoesToBestEligibleHand(t *testing.T) {
+	table := NewTable("table-1", "Table 1", nil)
+
+	table.CurrentHand = &Hand{
+		Pot: 500,
+		TotalContributions: map[int]int{
+			0: 100, // all-in, best hand
+			1: 200, // second best hand
+			2: 200,
+		},
+		FoldedPlayers: map[int]bool{},
+		HoleCards: map[int][]Card{
+			0: {NewCard("A", "s"), NewCard("A", "h")},
+			1: {NewCard("K", "s"), NewCard("K", "h")},
+			2: {NewCard("7", "c"), NewCard("2", "d")},
+		},
+		BoardCards: []Card{NewCard("3", "c"), NewCard("8", "d"), NewCard("9", "h"), NewCard("J", "s"), NewCard("4", "c")},
+	}
+
+	for i := 0; i < 3; i++ {
+		table.Seats[i] = Seat{Index: i, Stack: 0, Status: "active"}
+	}
+
+	distribution := table.DistributePot([]int{0})
+
+	if distribution[0] != 300 {
+		t.Errorf("expected seat 0 to receive the main pot of 300, got %d", distribution[0])
+	}
+	if distribution[1] != 200 {
+		t.Errorf("expected seat 1 to receive the side pot of 200, got %d", distribution[1])
+	}
+	if distribution[2] != 0 {
+		t.Errorf("expected seat 2 to receive nothing, got %d", distribution[2])
+	}
+}
+
 // ============================================================================
 // PHASE 5: Integration Tests for HandleShowdown + Side Pot Distribution
 // ============================================================================
@@ -11601,15 +12007,15 @@ func TestHandleShowdown_Integration_SidePot_ShortStackWins(t *testing.T) {
 	table.CurrentHand.AdvanceStreet()
 
 	// Set up hole cards and board so seat 0 wins
-	table.CurrentHand.HoleCards[0] = []Card{{Rank: "A", Suit: "s"}, {Rank: "K", Suit: "s"}}
-	table.CurrentHand.HoleCards[1] = []Card{{Rank: "2", Suit: "h"}, {Rank: "3", Suit: "h"}}
-	table.CurrentHand.HoleCards[2] = []Card{{Rank: "4", Suit: "d"}, {Rank: "5", Suit: "d"}}
+	table.CurrentHand.HoleCards[0] = []Card{NewCard("A", "s"), NewCard("K", "s")}
+	table.CurrentHand.HoleCards[1] = []Card{NewCard("2", "h"), NewCard("3", "h")}
+	table.CurrentHand.HoleCards[2] = []Card{NewCard("4", "d"), NewCard("5", "d")}
 	table.CurrentHand.BoardCards = []Card{
-		{Rank: "A", Suit: "h"},
-		{Rank: "K", Suit: "h"},
-		{Rank: "Q", Suit: "h"},
-		{Rank: "J", Suit: "h"},
-		{Rank: "T", Suit: "h"},
+		NewCard("A", "h"),
+		NewCard("K", "h"),
+		NewCard("Q", "h"),
+		NewCard("J", "h"),
+		NewCard("T", "h"),
 	}
 
 	// Call HandleShowdown
@@ -11712,15 +12118,15 @@ func TestHandleShowdown_Integration_SidePot_BigStackWins(t *testing.T) {
 	table.CurrentHand.AdvanceStreet()
 
 	// Set up hole cards and board so seat 1 wins (not seat 0)
-	table.CurrentHand.HoleCards[0] = []Card{{Rank: "2", Suit: "s"}, {Rank: "3", Suit: "s"}}
-	table.CurrentHand.HoleCards[1] = []Card{{Rank: "A", Suit: "h"}, {Rank: "K", Suit: "h"}}
-	table.CurrentHand.HoleCards[2] = []Card{{Rank: "4", Suit: "d"}, {Rank: "5", Suit: "d"}}
+	table.CurrentHand.HoleCards[0] = []Card{NewCard("2", "s"), NewCard("3", "s")}
+	table.CurrentHand.HoleCards[1] = []Card{NewCard("A", "h"), NewCard("K", "h")}
+	table.CurrentHand.HoleCards[2] = []Card{NewCard("4", "d"), NewCard("5", "d")}
 	table.CurrentHand.BoardCards = []Card{
-		{Rank: "A", Suit: "d"},
-		{Rank: "K", Suit: "d"},
-		{Rank: "Q", Suit: "d"},
-		{Rank: "J", Suit: "d"},
-		{Rank: "T", Suit: "d"},
+		NewCard("A", "d"),
+		NewCard("K", "d"),
+		NewCard("Q", "d"),
+		NewCard("J", "d"),
+		NewCard("T", "d"),
 	}
 
 	// Call HandleShowdown
@@ -11836,16 +12242,16 @@ func TestHandleShowdown_Integration_MultipleSidePots_DifferentWinners(t *testing
 
 	// Set up hole cards so seat 0 has the best hand (should win main pot only)
 	// and seat 3 has the second best (should win side pot)
-	table.CurrentHand.HoleCards[0] = []Card{{Rank: "A", Suit: "s"}, {Rank: "K", Suit: "s"}}
-	table.CurrentHand.HoleCards[1] = []Card{{Rank: "2", Suit: "h"}, {Rank: "3", Suit: "h"}}
-	table.CurrentHand.HoleCards[2] = []Card{{Rank: "4", Suit: "d"}, {Rank: "5", Suit: "d"}}
-	table.CurrentHand.HoleCards[3] = []Card{{Rank: "A", Suit: "h"}, {Rank: "Q", Suit: "h"}}
+	table.CurrentHand.HoleCards[0] = []Card{NewCard("A", "s"), NewCard("K", "s")}
+	table.CurrentHand.HoleCards[1] = []Card{NewCard("2", "h"), NewCard("3", "h")}
+	table.CurrentHand.HoleCards[2] = []Card{NewCard("4", "d"), NewCard("5", "d")}
+	table.CurrentHand.HoleCards[3] = []Card{NewCard("A", "h"), NewCard("Q", "h")}
 	table.CurrentHand.BoardCards = []Card{
-		{Rank: "A", Suit: "d"},
-		{Rank: "K", Suit: "d"},
-		{Rank: "Q", Suit: "d"},
-		{Rank: "J", Suit: "d"},
-		{Rank: "T", Suit: "d"},
+		NewCard("A", "d"),
+		NewCard("K", "d"),
+		NewCard("Q", "d"),
+		NewCard("J", "d"),
+		NewCard("T", "d"),
 	}
 
 	// Call HandleShowdown
@@ -12110,14 +12516,14 @@ func TestHandleShowdown_Integration_TotalContributions_AccurateTracking(t *testi
 
 	// Set up for showdown
 	table.CurrentHand.AdvanceStreet()
-	table.CurrentHand.HoleCards[0] = []Card{{Rank: "A", Suit: "s"}, {Rank: "K", Suit: "s"}}
-	table.CurrentHand.HoleCards[1] = []Card{{Rank: "2", Suit: "h"}, {Rank: "3", Suit: "h"}}
+	table.CurrentHand.HoleCards[0] = []Card{NewCard("A", "s"), NewCard("K", "s")}
+	table.CurrentHand.HoleCards[1] = []Card{NewCard("2", "h"), NewCard("3", "h")}
 	table.CurrentHand.BoardCards = []Card{
-		{Rank: "A", Suit: "d"},
-		{Rank: "K", Suit: "d"},
-		{Rank: "Q", Suit: "d"},
-		{Rank: "J", Suit: "d"},
-		{Rank: "T", Suit: "d"},
+		NewCard("A", "d"),
+		NewCard("K", "d"),
+		NewCard("Q", "d"),
+		NewCard("J", "d"),
+		NewCard("T", "d"),
 	}
 
 	// Call HandleShowdown
@@ -12202,15 +12608,15 @@ func TestHandleShowdown_Integration_OddChip_SidePotDistribution(t *testing.T) {
 	table.CurrentHand.AdvanceStreet()
 
 	// Set up hole cards - two players tie for best hand
-	table.CurrentHand.HoleCards[0] = []Card{{Rank: "A", Suit: "s"}, {Rank: "K", Suit: "s"}}
-	table.CurrentHand.HoleCards[1] = []Card{{Rank: "A", Suit: "h"}, {Rank: "K", Suit: "h"}}
-	table.CurrentHand.HoleCards[2] = []Card{{Rank: "2", Suit: "d"}, {Rank: "3", Suit: "d"}}
+	table.CurrentHand.HoleCards[0] = []Card{NewCard("A", "s"), NewCard("K", "s")}
+	table.CurrentHand.HoleCards[1] = []Card{NewCard("A", "h"), NewCard("K", "h")}
+	table.CurrentHand.HoleCards[2] = []Card{NewCard("2", "d"), NewCard("3", "d")}
 	table.CurrentHand.BoardCards = []Card{
-		{Rank: "A", Suit: "d"},
-		{Rank: "K", Suit: "d"},
-		{Rank: "Q", Suit: "d"},
-		{Rank: "J", Suit: "d"},
-		{Rank: "T", Suit: "d"},
+		NewCard("A", "d"),
+		NewCard("K", "d"),
+		NewCard("Q", "d"),
+		NewCard("J", "d"),
+		NewCard("T", "d"),
 	}
 
 	// Call HandleShowdown
@@ -12292,18 +12698,18 @@ func TestHandleShowdown_Integration_AllInLadder_FiveWay(t *testing.T) {
 
 	// Set up hole cards - player 0 has best hand
 	// (other players have progressively worse hands)
-	table.CurrentHand.HoleCards[0] = []Card{{Rank: "A", Suit: "s"}, {Rank: "K", Suit: "s"}}
-	table.CurrentHand.HoleCards[1] = []Card{{Rank: "A", Suit: "h"}, {Rank: "Q", Suit: "h"}}
-	table.CurrentHand.HoleCards[2] = []Card{{Rank: "A", Suit: "d"}, {Rank: "J", Suit: "d"}}
-	table.CurrentHand.HoleCards[3] = []Card{{Rank: "K", Suit: "h"}, {Rank: "Q", Suit: "h"}}
-	table.CurrentHand.HoleCards[4] = []Card{{Rank: "2", Suit: "c"}, {Rank: "3", Suit: "c"}}
+	table.CurrentHand.HoleCards[0] = []Card{NewCard("A", "s"), NewCard("K", "s")}
+	table.CurrentHand.HoleCards[1] = []Card{NewCard("A", "h"), NewCard("Q", "h")}
+	table.CurrentHand.HoleCards[2] = []Card{NewCard("A", "d"), NewCard("J", "d")}
+	table.CurrentHand.HoleCards[3] = []Card{NewCard("K", "h"), NewCard("Q", "h")}
+	table.CurrentHand.HoleCards[4] = []Card{NewCard("2", "c"), NewCard("3", "c")}
 
 	table.CurrentHand.BoardCards = []Card{
-		{Rank: "A", Suit: "c"},
-		{Rank: "K", Suit: "c"},
-		{Rank: "Q", Suit: "c"},
-		{Rank: "J", Suit: "c"},
-		{Rank: "T", Suit: "c"},
+		NewCard("A", "c"),
+		NewCard("K", "c"),
+		NewCard("Q", "c"),
+		NewCard("J", "c"),
+		NewCard("T", "c"),
 	}
 
 	// Call HandleShowdown
@@ -0,0 +1,441 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// defaultMergeConsentTimeout is how long players have to answer a merge offer when none is configured
+const defaultMergeConsentTimeout = 30 * time.Second
+
+// How a table merge offer ended, reported in TableMergeResultPayload.Status
+const (
+	TableMergeMerged    = "merged"    // Everyone agreed and was moved to the target table
+	TableMergeDeclined  = "declined"  // A player declined
+	TableMergeExpired   = "expired"   // Not everyone answered in time
+	TableMergeCancelled = "cancelled" // The target table closed, froze or filled up in the meantime
+)
+
+// TableBreakingConfig keeps the lobby tidy as cash tables empty out: players at a nearly
+// empty table are offered a move to another table of the same stakes, and tables left empty
+// are closed while another of their stakes stays open. Heads-up tables are never touched.
+type TableBreakingConfig struct {
+	// Interval between checks of the tables. Zero disables table breaking.
+	Interval time.Duration `yaml:"interval"`
+	// MergeBelow offers a merge to tables with this many players or fewer (0 = never merge)
+	MergeBelow int `yaml:"mergeBelow"`
+	// ConsentTimeout is how long players have to accept a merge (0 = 30s)
+	ConsentTimeout time.Duration `yaml:"consentTimeout"`
+	// CloseAfter closes a table once it has been empty this long (0 = never close)
+	CloseAfter time.Duration `yaml:"closeAfter"`
+}
+
+// validate reports the first invalid table breaking setting
+func (c TableBreakingConfig) validate() error {
+	if c.Interval < 0 || c.ConsentTimeout < 0 || c.CloseAfter < 0 {
+		return fmt.Errorf("tableBreaking durations must not be negative")
+	}
+	if c.MergeBelow < 0 || c.MergeBelow > 5 {
+		return fmt.Errorf("tableBreaking.mergeBelow must be between 0 and 5")
+	}
+	return nil
+}
+
+// consentTimeout returns the configured time to answer a merge offer, or the default
+func (c TableBreakingConfig) consentTimeout() time.Duration {
+	if c.ConsentTimeout == 0 {
+		return defaultMergeConsentTimeout
+	}
+	return c.ConsentTimeout
+}
+
+// stakes identifies the tables players can be moved between without noticing a difference
+type stakes struct {
+	currency   ChipCurrency
+	smallBlind int
+	bigBlind   int
+	buyIn      int
+	maxSeats   int
+	speed      string
+}
+
+// stakesOf returns the stakes of table, which are fixed when it is created
+func stakesOf(table *Table) stakes {
+	return stakes{table.Currency, table.SmallBlind, table.BigBlind, table.BuyIn, table.MaxSeats, table.Speed}
+}
+
+// tableMerge is an offer to move every player at a table to target
+type tableMerge struct {
+	target    *Table
+	expiresAt time.Time
+	timer     ClockTimer
+	asked     []string          // Tokens of the players asked
+	accepted  map[string]string // Remote IP by token of the players who agreed
+	agreed    bool              // Everyone agreed; they move once no hand is in progress
+}
+
+// TableMergeOfferPayload represents the payload for table_merge_offer messages, sent privately
+// to each player at a table that is about to be merged into another
+type TableMergeOfferPayload struct {
+	TableID       string `json:"tableId"`
+	TargetTableID string `json:"targetTableId"`
+	TargetName    string `json:"targetName"`
+	ExpiresAt     int64  `json:"expiresAt"` // Unix ms
+}
+
+// TableMergeResponsePayload represents the payload for merge_response messages
+type TableMergeResponsePayload struct {
+	Accept bool `json:"accept"`
+}
+
+// TableMergeResultPayload represents the payload for table_merge_result messages, broadcast to
+// the table when a merge offer ends
+type TableMergeResultPayload struct {
+	TableID       string `json:"tableId"`
+	TargetTableID string `json:"targetTableId"`
+	Status        string `json:"status"` // TableMergeMerged, TableMergeDeclined, TableMergeExpired or TableMergeCancelled
+}
+
+// TableClosedPayload represents the payload for table_closed messages, sent to the observers
+// of a table closed for lack of players or drained after a soft close
+type TableClosedPayload struct {
+	TableID string `json:"tableId"`
+}
+
+// runTableBreaking checks the tables every interval until stop is closed
+func (s *Server) runTableBreaking(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			s.balanceTables(now)
+		}
+	}
+}
+
+// breakableTable is a table's state as seen by balanceTables
+type breakableTable struct {
+	table   *Table
+	players int
+	busy    bool // Frozen, closing, reserved or part of a merge; left alone for now
+}
+
+// balanceTables runs one table breaking pass at now, per group of tables with the same stakes:
+// a closed table is reopened when every open one is full, tables empty for CloseAfter are
+// closed while another stays open, and the emptiest table with MergeBelow players or fewer is
+// offered a merge into the fullest table that can take them all
+func (s *Server) balanceTables(now time.Time) {
+	cfg := s.Config().TableBreaking
+
+	s.mu.RLock()
+	groups := make(map[stakes][]*Table)
+	var order []stakes
+	for _, table := range s.tables {
+		if table.HeadsUp || table.ClubID != "" {
+			continue
+		}
+		key := stakesOf(table)
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], table)
+	}
+	closed := slices.Clone(s.closedTables)
+	s.mu.RUnlock()
+
+	// A group whose open tables were all dissolved gets a closed table back
+	for _, table := range closed {
+		if _, ok := groups[stakesOf(table)]; !ok {
+			s.reopenTable(table)
+			groups[stakesOf(table)] = nil
+		}
+	}
+
+	// Tables being merged into are busy too
+	targets := make(map[*Table]bool)
+	for _, tables := range groups {
+		for _, table := range tables {
+			table.mu.RLock()
+			if table.merge != nil {
+				targets[table.merge.target] = true
+			}
+			table.mu.RUnlock()
+		}
+	}
+
+	for _, key := range order {
+		var group []breakableTable
+		full := true
+		for _, table := range groups[key] {
+			table.mu.Lock()
+			state := breakableTable{table: table, busy: targets[table] || table.merge != nil || table.freeze != nil || table.closing || len(table.reservations) > 0}
+			for _, seat := range table.Seats {
+				if seat.Token != nil {
+					state.players++
+				}
+			}
+			if state.players > 0 || state.busy {
+				table.emptySince = time.Time{}
+			} else if table.emptySince.IsZero() {
+				table.emptySince = now
+			}
+			full = full && state.players >= table.MaxSeats
+			table.mu.Unlock()
+			group = append(group, state)
+		}
+
+		if full {
+			for _, table := range closed {
+				if stakesOf(table) == key {
+					s.reopenTable(table)
+					break
+				}
+			}
+			continue
+		}
+
+		// The first tables of a group are the ones kept open
+		open := len(group)
+		if cfg.CloseAfter > 0 {
+			for i := len(group) - 1; i >= 0 && open > 1; i-- {
+				if s.closeIdleTable(group[i].table, now.Add(-cfg.CloseAfter)) {
+					group = slices.Delete(group, i, i+1)
+					open--
+				}
+			}
+		}
+
+		if cfg.MergeBelow > 0 {
+			if source, target := mergeCandidates(group, cfg.MergeBelow); source != nil {
+				s.offerMerge(source, target, cfg.consentTimeout())
+			}
+		}
+	}
+}
+
+// mergeCandidates picks the emptiest table with at most mergeBelow players and the fullest
+// other table with room for all of them; nil if there is no such pair
+func mergeCandidates(group []breakableTable, mergeBelow int) (*Table, *Table) {
+	var source *breakableTable
+	for i := range group {
+		candidate := &group[i]
+		if candidate.busy || candidate.players == 0 || candidate.players > mergeBelow {
+			continue
+		}
+		if source == nil || candidate.players < source.players {
+			source = candidate
+		}
+	}
+	if source == nil {
+		return nil, nil
+	}
+
+	var target *breakableTable
+	for i := range group {
+		candidate := &group[i]
+		if candidate == source || candidate.busy || candidate.players+source.players > candidate.table.MaxSeats {
+			continue
+		}
+		if target == nil || candidate.players > target.players {
+			target = candidate
+		}
+	}
+	if target == nil {
+		return nil, nil
+	}
+	return source.table, target.table
+}
+
+// offerMerge asks every player at source to move to target
+func (s *Server) offerMerge(source, target *Table, timeout time.Duration) {
+	source.mu.Lock()
+	merge := &tableMerge{
+		target:    target,
+		expiresAt: source.clock().Now().Add(timeout),
+		accepted:  make(map[string]string),
+	}
+	for _, seat := range source.Seats {
+		if seat.Token != nil {
+			merge.asked = append(merge.asked, *seat.Token)
+		}
+	}
+	merge.timer = source.clock().AfterFunc(timeout, func() { s.endMerge(source, merge, TableMergeExpired) })
+	source.merge = merge
+	source.mu.Unlock()
+
+	s.logger.Info("table merge offered", "tableID", source.ID, "targetTableID", target.ID, "players", len(merge.asked))
+	offer := TableMergeOfferPayload{TableID: source.ID, TargetTableID: target.ID, TargetName: target.Name, ExpiresAt: merge.expiresAt.UnixMilli()}
+	for _, token := range merge.asked {
+		s.sendPrivate(token, "table_merge_offer", offer)
+	}
+}
+
+// endMerge withdraws merge from source, if it is still open, and tells the table why
+func (s *Server) endMerge(source *Table, merge *tableMerge, status string) {
+	source.mu.Lock()
+	if source.merge != merge {
+		source.mu.Unlock()
+		return
+	}
+	merge.timer.Stop()
+	source.merge = nil
+	source.mu.Unlock()
+
+	s.logger.Info("table merge ended", "tableID", source.ID, "targetTableID", merge.target.ID, "status", status)
+	result := TableMergeResultPayload{TableID: source.ID, TargetTableID: merge.target.ID, Status: status}
+	if err := s.broadcastTableMessage(source, "table_merge_result", result); err != nil {
+		s.logger.Warn("failed to broadcast table_merge_result", "tableID", source.ID, "error", err)
+	}
+}
+
+// completeMerge moves the players of source to the target table once they all agreed and no
+// hand is in progress, then closes source if nobody else sat down there
+// Must be called without the table lock held
+func (s *Server) completeMerge(source *Table) {
+	source.mu.Lock()
+	merge := source.merge
+	if merge == nil || !merge.agreed || source.CurrentHand != nil {
+		source.mu.Unlock()
+		return
+	}
+	source.mu.Unlock()
+
+	target := merge.target
+	seated, _ := target.lobbyCounts()
+	if s.tableByID(target.ID) != target || target.Frozen() || target.Closing() || seated+len(merge.asked) > target.MaxSeats {
+		s.endMerge(source, merge, TableMergeCancelled)
+		return
+	}
+	// Everyone is told before they leave the table
+	s.endMerge(source, merge, TableMergeMerged)
+
+	for _, token := range merge.asked {
+		seat, err := s.moveToTable(token, merge.accepted[token], source, target)
+		if err != nil {
+			s.logger.Warn("player not moved to merged table", "token", token, "tableID", source.ID, "targetTableID", target.ID, "error", err)
+			continue
+		}
+		s.announceSeat(target, token, seat)
+	}
+	if !s.closeIdleTable(source, time.Time{}) {
+		if err := s.broadcastTableState(source.ID, nil); err != nil {
+			s.logger.Warn("failed to broadcast table_state", "tableID", source.ID, "error", err)
+		}
+	}
+}
+
+// moveToTable takes token's seat at from, stack and all, to a free seat at to
+func (s *Server) moveToTable(token, remoteIP string, from, to *Table) (Seat, error) {
+	seat, ok := from.GetSeatByToken(&token)
+	if !ok {
+		return Seat{}, fmt.Errorf("not seated at %s", from.ID)
+	}
+	// The stack is cashed out and bought in again, so it is never in two places
+	if err := from.ClearSeat(&token); err != nil {
+		return Seat{}, err
+	}
+	moved, err := s.seatPlayerWithStack(token, remoteIP, to, seat.Stack)
+	if err != nil {
+		s.sendPrivate(token, "seat_cleared", SeatClearedPayload{})
+		return Seat{}, err
+	}
+	return moved, nil
+}
+
+// closeIdleTable takes table out of the lobby if it has been empty since before emptyBefore,
+// or at all for the zero time. Its observers are told. Returns false if the table is in use.
+func (s *Server) closeIdleTable(table *Table, emptyBefore time.Time) bool {
+	s.mu.Lock()
+	table.mu.Lock()
+	inUse := table.merge != nil || table.freeze != nil || table.closing || len(table.reservations) > 0 || table.CurrentHand != nil
+	for _, seat := range table.Seats {
+		inUse = inUse || seat.Token != nil
+	}
+	if inUse || (!emptyBefore.IsZero() && (table.emptySince.IsZero() || table.emptySince.After(emptyBefore))) {
+		table.mu.Unlock()
+		s.mu.Unlock()
+		return false
+	}
+	table.closed = true
+	table.emptySince = time.Time{}
+	table.cancelNextHandLocked()
+	table.mu.Unlock()
+	s.tables = slices.DeleteFunc(s.tables, func(t *Table) bool { return t == table })
+	s.closedTables = append(s.closedTables, table)
+	s.mu.Unlock()
+
+	for _, token := range s.observers.Tokens(table.ID) {
+		s.sendPrivate(token, "table_closed", TableClosedPayload{TableID: table.ID})
+		s.observers.Remove(token)
+	}
+	s.logger.Info("empty table closed", "tableID", table.ID)
+	if err := s.broadcastLobbyState(); err != nil {
+		s.logger.Warn("failed to broadcast lobby state after closing table", "error", err)
+	}
+	return true
+}
+
+// reopenTable puts a closed table back in the lobby
+func (s *Server) reopenTable(table *Table) {
+	s.mu.Lock()
+	s.closedTables = slices.DeleteFunc(s.closedTables, func(t *Table) bool { return t == table })
+	s.tables = append(s.tables, table)
+	s.mu.Unlock()
+
+	table.mu.Lock()
+	table.closed = false
+	table.mu.Unlock()
+
+	s.logger.Info("table reopened", "tableID", table.ID)
+	if err := s.broadcastLobbyState(); err != nil {
+		s.logger.Warn("failed to broadcast lobby state after reopening table", "error", err)
+	}
+}
+
+// HandleMergeResponse processes a merge_response message: a player's answer to a table merge
+// offer. One refusal withdraws the offer; once everyone agreed the players are moved together.
+func (c *Client) HandleMergeResponse(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+	var req TableMergeResponsePayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return invalidPayloadError("merge_response", err)
+	}
+	session, err := sm.GetSession(c.Token)
+	if err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+	var table *Table
+	if session.TableID != nil {
+		table = server.tableByID(*session.TableID)
+	}
+	if table == nil {
+		return newMessageError("error.no_table_merge", nil)
+	}
+
+	table.mu.Lock()
+	merge := table.merge
+	if merge == nil || !slices.Contains(merge.asked, c.Token) {
+		table.mu.Unlock()
+		return newMessageError("error.no_table_merge", nil)
+	}
+	if !req.Accept {
+		table.mu.Unlock()
+		logger.Info("table merge declined", "token", c.Token, "tableID", table.ID)
+		server.endMerge(table, merge, TableMergeDeclined)
+		return nil
+	}
+	merge.accepted[c.Token] = c.RemoteIP
+	merge.agreed = len(merge.accepted) == len(merge.asked)
+	agreed := merge.agreed
+	table.mu.Unlock()
+
+	logger.Info("table merge accepted", "token", c.Token, "tableID", table.ID, "agreed", agreed)
+	server.completeMerge(table)
+	return nil
+}
@@ -0,0 +1,196 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// newBreakingServer creates a server with count identical 10/20 tables and table breaking set
+// up with cfg (Interval is left zero; tests run balanceTables themselves)
+func newBreakingServer(t *testing.T, count int, cfg TableBreakingConfig) *Server {
+	t.Helper()
+	config := Config{TableBreaking: cfg}
+	for range count {
+		config.Tables = append(config.Tables, TableConfig{Name: "Cash", SmallBlind: 10, BigBlind: 20, BuyIn: 1000})
+	}
+	return NewServerWithConfig(slog.Default(), config)
+}
+
+// seatNamed creates a session for each name, seats it at table and connects a test client
+func seatNamed(t *testing.T, server *Server, table *Table, names ...string) []*Client {
+	t.Helper()
+	var clients []*Client
+	for _, name := range names {
+		session, _ := server.sessionManager.CreateSession(name)
+		if _, err := server.seatPlayer(session.Token, "", table); err != nil {
+			t.Fatal(err)
+		}
+		clients = append(clients, connectTestClient(server, session.Token))
+	}
+	return clients
+}
+
+// respondToMerge sends client's merge_response
+func respondToMerge(t *testing.T, server *Server, client *Client, accept bool) error {
+	t.Helper()
+	payload, _ := json.Marshal(TableMergeResponsePayload{Accept: accept})
+	return client.HandleMergeResponse(server.sessionManager, server, slog.Default(), payload)
+}
+
+// payloadsOf returns the payloads of client's queued messages of msgType
+func payloadsOf[P any](t *testing.T, client *Client, msgType string) []P {
+	t.Helper()
+	var payloads []P
+	for _, raw := range drainRawMessages(client) {
+		var msg struct {
+			Type    string `json:"type"`
+			Payload P      `json:"payload"`
+		}
+		if json.Unmarshal([]byte(raw), &msg) == nil && msg.Type == msgType {
+			payloads = append(payloads, msg.Payload)
+		}
+	}
+	return payloads
+}
+
+// TestTableBreaking_MergeMovesPlayersAndClosesTable verifies the lone player at a table is
+// offered a move to the fuller table, moves with their stack on accepting, and the empty table
+// leaves the lobby
+func TestTableBreaking_MergeMovesPlayersAndClosesTable(t *testing.T) {
+	server := newBreakingServer(t, 3, TableBreakingConfig{MergeBelow: 2})
+	target, source, other := server.tables[0], server.tables[1], server.tables[2]
+	seatNamed(t, server, target, "Alice", "Bob", "Carol")
+	dave := seatNamed(t, server, source, "Dave")[0]
+	source.mu.Lock()
+	source.Seats[0].Stack = 750
+	source.mu.Unlock()
+
+	server.balanceTables(time.Now())
+	offers := payloadsOf[TableMergeOfferPayload](t, dave, "table_merge_offer")
+	if len(offers) != 1 || offers[0].TableID != source.ID || offers[0].TargetTableID != target.ID {
+		t.Fatalf("expected an offer to move to %s, got %+v", target.ID, offers)
+	}
+
+	if err := respondToMerge(t, server, dave, true); err != nil {
+		t.Fatal(err)
+	}
+	results := payloadsOf[TableMergeResultPayload](t, dave, "table_merge_result")
+	if len(results) != 1 || results[0].Status != TableMergeMerged {
+		t.Errorf("expected the merge reported, got %+v", results)
+	}
+	seat, ok := target.GetSeatByToken(&dave.Token)
+	if !ok || seat.Stack != 750 {
+		t.Errorf("expected Dave at %s with 750 chips, got %+v (seated %v)", target.ID, seat, ok)
+	}
+	if server.tableByID(source.ID) != nil {
+		t.Errorf("expected %s closed", source.ID)
+	}
+	if server.tableByID(other.ID) == nil {
+		t.Error("expected the other empty table kept open")
+	}
+}
+
+// TestTableBreaking_MergeWaitsForHandToEnd verifies players who agree mid-hand move once the hand
+// is over, keeping what they won or lost
+func TestTableBreaking_MergeWaitsForHandToEnd(t *testing.T) {
+	server := newBreakingServer(t, 2, TableBreakingConfig{MergeBelow: 2})
+	target, source := server.tables[0], server.tables[1]
+	seatNamed(t, server, target, "Alice", "Bob", "Carol")
+	clients := seatNamed(t, server, source, "Dave", "Erin")
+	if err := source.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	server.balanceTables(time.Now())
+	for _, client := range clients {
+		if err := respondToMerge(t, server, client, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, ok := source.GetSeatByToken(&clients[0].Token); !ok {
+		t.Fatal("expected Dave to stay for the hand in progress")
+	}
+
+	playOutChecking(t, server, source)
+	total := 0
+	for _, client := range clients {
+		seat, ok := target.GetSeatByToken(&client.Token)
+		if !ok {
+			t.Fatalf("expected %s moved after the hand", client.Token)
+		}
+		total += seat.Stack
+	}
+	if total != 2000 {
+		t.Errorf("expected the stacks to move intact, got %d chips in total", total)
+	}
+}
+
+// TestTableBreaking_DeclinedAndExpiredOffers verifies one refusal or a lapsed offer leaves
+// everyone where they are
+func TestTableBreaking_DeclinedAndExpiredOffers(t *testing.T) {
+	server := newBreakingServer(t, 2, TableBreakingConfig{MergeBelow: 2, ConsentTimeout: 20 * time.Second})
+	clock := useFakeClock(server)
+	source, target := server.tables[0], server.tables[1]
+	alice := seatNamed(t, server, source, "Alice")[0]
+	bob := seatNamed(t, server, target, "Bob")[0]
+
+	if err := respondToMerge(t, server, alice, true); err == nil {
+		t.Error("expected an error answering without an offer")
+	}
+
+	server.balanceTables(clock.Now())
+	if err := respondToMerge(t, server, bob, true); err == nil {
+		t.Error("expected an error from a player at the target table")
+	}
+	if err := respondToMerge(t, server, alice, false); err != nil {
+		t.Fatal(err)
+	}
+	if results := payloadsOf[TableMergeResultPayload](t, alice, "table_merge_result"); len(results) != 1 || results[0].Status != TableMergeDeclined {
+		t.Errorf("expected the merge declined, got %+v", results)
+	}
+
+	server.balanceTables(clock.Now())
+	clock.Advance(20 * time.Second)
+	if results := payloadsOf[TableMergeResultPayload](t, alice, "table_merge_result"); len(results) != 1 || results[0].Status != TableMergeExpired {
+		t.Errorf("expected the merge expired, got %+v", results)
+	}
+	if _, ok := source.GetSeatByToken(&alice.Token); !ok {
+		t.Error("expected Alice to keep her seat")
+	}
+}
+
+// TestTableBreaking_CloseAndReopenIdleTables verifies empty tables close after CloseAfter while
+// one of their stakes stays open, and one comes back when the open tables are full
+func TestTableBreaking_CloseAndReopenIdleTables(t *testing.T) {
+	server := newBreakingServer(t, 3, TableBreakingConfig{CloseAfter: 10 * time.Minute})
+	first, third := server.tables[0], server.tables[2]
+	watcher := connectTestClient(server, "watcher")
+	server.observers.Watch("watcher", third.ID)
+	start := time.Now()
+
+	server.balanceTables(start)
+	server.balanceTables(start.Add(9 * time.Minute))
+	if len(server.GetLobbyState()) != 3 {
+		t.Fatal("expected no table closed before closeAfter")
+	}
+	server.balanceTables(start.Add(10 * time.Minute))
+	lobby := server.GetLobbyState()
+	if len(lobby) != 1 || lobby[0].ID != first.ID {
+		t.Fatalf("expected only %s left open, got %+v", first.ID, lobby)
+	}
+	if closed := payloadsOf[TableClosedPayload](t, watcher, "table_closed"); len(closed) != 1 || closed[0].TableID != third.ID {
+		t.Errorf("expected the observer told, got %+v", closed)
+	}
+	session, _ := server.sessionManager.CreateSession("Late")
+	if _, err := server.seatPlayer(session.Token, "", third); err == nil {
+		t.Error("expected a closed table to refuse players")
+	}
+
+	seatNamed(t, server, first, "A", "B", "C", "D", "E", "F")
+	server.balanceTables(start.Add(11 * time.Minute))
+	if len(server.GetLobbyState()) != 2 {
+		t.Errorf("expected a table reopened once the open one filled up, got %+v", server.GetLobbyState())
+	}
+}
@@ -0,0 +1,168 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// timeBankUse is a turn being played on the actor's time bank
+type timeBankUse struct {
+	token string
+	from  time.Time // When the action clock ran out and the bank started running
+}
+
+// TimeBankPayload represents the payload for time_bank messages, sent to the table when the
+// player to act starts using their time bank
+type TimeBankPayload struct {
+	SeatIndex  int   `json:"seatIndex"`
+	Deadline   int64 `json:"deadline"`   // Unix ms when the seat's time runs out, bank included
+	TimeBankMs int64 `json:"timeBankMs"` // Bank the player had left when it engaged
+}
+
+// AutoTimeBankPayload represents the payload for set_auto_time_bank messages and their
+// auto_time_bank reply
+type AutoTimeBankPayload struct {
+	AutoTimeBank bool `json:"autoTimeBank"`
+}
+
+// SetAutoTimeBank sets whether the named account's time bank engages by itself when the action
+// clock runs out, or only on use_time_bank
+func (s *AccountStore) SetAutoTimeBank(name string, auto bool) error {
+	return s.update(name, func(account *Account) error {
+		account.ManualTimeBank = !auto
+		return nil
+	})
+}
+
+// AutoTimeBank reports whether the named account's time bank engages by itself; it does unless
+// the player chose otherwise
+func (s *AccountStore) AutoTimeBank(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.accounts[accountKey(name)].ManualTimeBank
+}
+
+// autoTimeBankLocked reports whether the time bank of the player with token engages by itself
+// (internal, must be called with lock held)
+func (t *Table) autoTimeBankLocked(token string) bool {
+	if t.Server == nil {
+		return true
+	}
+	name, err := t.Server.sessionManager.GetPlayerName(token)
+	return err != nil || t.Server.accounts.AutoTimeBank(name)
+}
+
+// timeBankLeftLocked returns how much of the time bank of the player with token is left at this
+// table (internal, must be called with lock held)
+func (t *Table) timeBankLeftLocked(token string) time.Duration {
+	return max(0, t.speedUp(t.rulesLocked().timeBank)-t.timeBankUsed[token])
+}
+
+// engageTimeBankLocked gives seatIndex the rest of their time bank on top of the action clock
+// that runs out at from (internal, must be called with lock held)
+// Returns false, leaving the clock alone, when the player has no time bank left
+func (t *Table) engageTimeBankLocked(seatIndex int, from time.Time) (TimeBankPayload, bool) {
+	token := t.Seats[seatIndex].Token
+	if token == nil {
+		return TimeBankPayload{}, false
+	}
+	left := t.timeBankLeftLocked(*token)
+	if left <= 0 {
+		return TimeBankPayload{}, false
+	}
+
+	deadline := from.Add(left)
+	t.runActionClockLocked(seatIndex, deadline)
+	t.timeBank = &timeBankUse{token: *token, from: from}
+	return TimeBankPayload{SeatIndex: seatIndex, Deadline: deadline.UnixMilli(), TimeBankMs: left.Milliseconds()}, true
+}
+
+// settleTimeBankLocked charges the time bank in use for the time spent on it, once the player
+// acted or ran out of time (internal, must be called with lock held)
+func (t *Table) settleTimeBankLocked() {
+	use := t.timeBank
+	if use == nil {
+		return
+	}
+	t.timeBank = nil
+	spent := t.clock().Now().Sub(use.from)
+	if spent <= 0 {
+		return
+	}
+	if t.timeBankUsed == nil {
+		t.timeBankUsed = make(map[string]time.Duration)
+	}
+	t.timeBankUsed[use.token] += spent
+	t.trimPlayersLocked()
+}
+
+// broadcastTimeBank tells the table a player is on their time bank
+func (t *Table) broadcastTimeBank(payload TimeBankPayload) {
+	t.logInfo("time bank engaged", "seatIndex", payload.SeatIndex, "timeBankMs", payload.TimeBankMs)
+	if err := t.Server.broadcastTableMessage(t, "time_bank", payload); err != nil {
+		t.logWarn("failed to broadcast time_bank", "error", err)
+	}
+}
+
+// HandleUseTimeBank processes a use_time_bank message: the player to act adds the rest of their
+// time bank to their clock now, rather than waiting for it to run out
+func (c *Client) HandleUseTimeBank(sm *SessionManager, server *Server) error {
+	session, err := sm.GetSession(c.Token)
+	if err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+	if session.TableID == nil || session.SeatIndex == nil {
+		return newMessageError("error.not_seated", nil)
+	}
+	table := server.tableByID(*session.TableID)
+	if table == nil {
+		return newMessageError("error.table_not_found", nil)
+	}
+	seatIndex := *session.SeatIndex
+
+	table.mu.Lock()
+	hand := table.CurrentHand
+	switch {
+	case hand == nil || hand.CurrentActor == nil || *hand.CurrentActor != seatIndex:
+		table.mu.Unlock()
+		return newMessageError("error.not_your_turn", nil)
+	case table.ActionDeadline == nil || table.rulesLocked().timeBank <= 0:
+		table.mu.Unlock()
+		return newMessageError("error.time_bank_disabled", nil)
+	case table.timeBank != nil:
+		table.mu.Unlock()
+		return newMessageError("error.time_bank_in_use", nil)
+	case table.clockCalled:
+		table.mu.Unlock()
+		return newMessageError("error.clock_already_called", nil)
+	}
+	payload, ok := table.engageTimeBankLocked(seatIndex, *table.ActionDeadline)
+	table.mu.Unlock()
+	if !ok {
+		return newMessageError("error.time_bank_empty", nil)
+	}
+
+	table.broadcastTimeBank(payload)
+	return nil
+}
+
+// HandleSetAutoTimeBank processes a set_auto_time_bank message: whether the player's time bank
+// engages by itself when the action clock runs out, and replies with auto_time_bank
+// The choice lives on the account, so it follows the player across sessions and restarts.
+func (c *Client) HandleSetAutoTimeBank(sm *SessionManager, server *Server, logger *slog.Logger, payload []byte) error {
+	var req AutoTimeBankPayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return invalidPayloadError("set_auto_time_bank", err)
+	}
+	name, err := sm.GetPlayerName(c.Token)
+	if err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+	if err := server.accounts.SetAutoTimeBank(name, req.AutoTimeBank); err != nil {
+		return err
+	}
+	logger.Info("auto time bank set", "name", name, "autoTimeBank", req.AutoTimeBank)
+	return c.sendMessage("auto_time_bank", req)
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// timeBankTable starts a hand at a three-player table with a 10s action clock and 20s time banks
+func timeBankTable(t *testing.T) (*Server, *Table, []*Client, *fakeClock) {
+	t.Helper()
+	server := NewServerWithConfig(slog.Default(), Config{
+		ActionTimeout: 10 * time.Second,
+		TimeBank:      20 * time.Second,
+		Tables:        []TableConfig{{Name: "Main", SmallBlind: 10, BigBlind: 20, BuyIn: 1000}},
+	})
+	clock := useFakeClock(server)
+	table := server.tables[0]
+	clients := seatNamed(t, server, table, "Alice", "Bob", "Carol")
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	clock.waitForTimers(t, 1)
+	return server, table, clients, clock
+}
+
+// actionDeadline returns how long the table's current actor has left
+func actionDeadline(table *Table, clock *fakeClock) time.Duration {
+	table.mu.RLock()
+	defer table.mu.RUnlock()
+	if table.ActionDeadline == nil {
+		return 0
+	}
+	return table.ActionDeadline.Sub(clock.Now())
+}
+
+// TestTimeBank_EngagesWhenClockRunsOut verifies a player whose clock runs out plays on their time
+// bank, and is folded once that runs out too
+func TestTimeBank_EngagesWhenClockRunsOut(t *testing.T) {
+	_, table, clients, clock := timeBankTable(t)
+	actor := currentActor(table)
+	drainRawMessages(clients[actor])
+
+	clock.Advance(10 * time.Second)
+	if !eventually(func() bool { return actionDeadline(table, clock) == 20*time.Second }) {
+		t.Fatalf("expected the actor on a 20s time bank, %s left", actionDeadline(table, clock))
+	}
+	banks := payloadsOf[TimeBankPayload](t, clients[actor], "time_bank")
+	if len(banks) != 1 || banks[0].SeatIndex != actor || banks[0].TimeBankMs != 20000 {
+		t.Errorf("expected a time_bank broadcast for seat %d, got %+v", actor, banks)
+	}
+
+	clock.waitForTimers(t, 1)
+	clock.Advance(20 * time.Second)
+	eventually(func() bool { return currentActor(table) != actor })
+	table.mu.RLock()
+	folded := table.CurrentHand != nil && table.CurrentHand.FoldedPlayers[actor]
+	left := table.timeBankLeftLocked(*table.Seats[actor].Token)
+	table.mu.RUnlock()
+	if !folded || left != 0 {
+		t.Errorf("expected the actor folded with the bank spent, folded=%v left=%s", folded, left)
+	}
+}
+
+// TestTimeBank_ManualUse verifies players who turn the automatic time bank off time out on the
+// action clock alone, and can still spend the bank with use_time_bank
+func TestTimeBank_ManualUse(t *testing.T) {
+	server, table, clients, clock := timeBankTable(t)
+	actor := currentActor(table)
+	next := (actor + 1) % 3
+
+	for _, seat := range []int{actor, next} {
+		if err := clients[seat].HandleSetAutoTimeBank(server.sessionManager, server, slog.Default(), []byte(`{"autoTimeBank": false}`)); err != nil {
+			t.Fatal(err)
+		}
+		if replies := payloadsOf[AutoTimeBankPayload](t, clients[seat], "auto_time_bank"); len(replies) != 1 || replies[0].AutoTimeBank {
+			t.Errorf("expected auto_time_bank false, got %+v", replies)
+		}
+	}
+	name, _ := server.sessionManager.GetPlayerName(clients[actor].Token)
+	if server.accounts.AutoTimeBank(name) {
+		t.Error("expected the preference kept on the account")
+	}
+
+	clock.Advance(10 * time.Second)
+	if !eventually(func() bool { return currentActor(table) == next }) {
+		t.Fatalf("expected the actor to time out without the bank, actor is %d", currentActor(table))
+	}
+
+	useTimeBank := func(seat int) string {
+		err := clients[seat].HandleUseTimeBank(server.sessionManager, server)
+		if err == nil {
+			return ""
+		}
+		key, _ := errorMessageKey(err)
+		return key
+	}
+	if key := useTimeBank(actor); key != "error.not_your_turn" {
+		t.Errorf("expected not_your_turn, got %q", key)
+	}
+	clock.waitForTimers(t, 1)
+	clock.Advance(4 * time.Second)
+	if key := useTimeBank(next); key != "" {
+		t.Fatalf("expected the time bank used, got %q", key)
+	}
+	if left := actionDeadline(table, clock); left != 26*time.Second {
+		t.Errorf("expected 6s on the clock plus the 20s bank, got %s", left)
+	}
+	if key := useTimeBank(next); key != "error.time_bank_in_use" {
+		t.Errorf("expected time_bank_in_use, got %q", key)
+	}
+
+	// Acting before the action clock would have run out costs none of the bank
+	actCurrent(t, server, table, "call")
+	table.mu.RLock()
+	left := table.timeBankLeftLocked(*table.Seats[next].Token)
+	table.mu.RUnlock()
+	if left != 20*time.Second {
+		t.Errorf("expected the whole bank left, got %s", left)
+	}
+}
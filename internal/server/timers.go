@@ -0,0 +1,220 @@
+package server
+
+import (
+	"context"
+	"time"
+)
+
+// Timer names used in timer_tick messages
+const (
+	TimerAction   = "action"    // Time left for the current actor to act
+	TimerNextHand = "next_hand" // Time left until the next hand is dealt
+)
+
+// Clock is the source of time for the table timers (action clock, called clock, street pacing
+// and the next-hand countdown). Servers use the system clock; tests swap in a fake one to move
+// time forward without sleeping.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) ClockTimer
+	AfterFunc(d time.Duration, f func()) ClockTimer
+}
+
+// ClockTimer is a timer created by a Clock, see time.Timer
+type ClockTimer interface {
+	C() <-chan time.Time // Receives the time once the timer fires; nil for AfterFunc timers
+	Stop() bool
+}
+
+// systemClock is the Clock backed by package time
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) NewTimer(d time.Duration) ClockTimer { return systemTimer{time.NewTimer(d)} }
+
+func (systemClock) AfterFunc(d time.Duration, f func()) ClockTimer {
+	return systemTimer{time.AfterFunc(d, f)}
+}
+
+// systemTimer adapts time.Timer to ClockTimer
+type systemTimer struct{ timer *time.Timer }
+
+func (t systemTimer) C() <-chan time.Time { return t.timer.C }
+
+func (t systemTimer) Stop() bool { return t.timer.Stop() }
+
+// clock returns the server's Clock, the system clock for tables without a server
+func (t *Table) clock() Clock {
+	if t.Server == nil || t.Server.clock == nil {
+		return systemClock{}
+	}
+	return t.Server.clock
+}
+
+// TimerTickPayload represents the payload for timer_tick messages
+// Deadline and ServerTime are Unix times in milliseconds; clients can compare
+// ServerTime with their own clock on receipt to correct for latency and skew
+type TimerTickPayload struct {
+	Timer       string `json:"timer"`               // TimerAction or TimerNextHand
+	SeatIndex   *int   `json:"seatIndex,omitempty"` // Seat on the clock (action timer only)
+	RemainingMs int64  `json:"remainingMs"`         // Milliseconds until Deadline when the tick was sent
+	Deadline    int64  `json:"deadline"`            // When the timer expires
+	ServerTime  int64  `json:"serverTime"`          // When the tick was sent
+}
+
+// runTimer broadcasts a timer_tick for the named timer every second until deadline,
+// then calls onExpire. Returns without calling onExpire if cancel is closed first.
+// Ticks are aligned so one is sent on every whole second remaining.
+func (t *Table) runTimer(timer string, seatIndex *int, deadline time.Time, cancel <-chan struct{}, onExpire func()) {
+	clock := t.clock()
+	for {
+		remaining := deadline.Sub(clock.Now())
+		if remaining <= 0 {
+			break
+		}
+
+		t.broadcastTimerTick(timer, seatIndex, deadline, remaining)
+
+		// Wake on the next whole-second boundary before the deadline
+		wait := remaining % time.Second
+		if wait == 0 {
+			wait = time.Second
+		}
+		ticker := clock.NewTimer(wait)
+		select {
+		case <-cancel:
+			ticker.Stop()
+			return
+		case <-ticker.C():
+		}
+	}
+
+	// A cancel that raced with the final tick still wins
+	select {
+	case <-cancel:
+		return
+	default:
+	}
+
+	onExpire()
+}
+
+// broadcastTimerTick sends a timer_tick message to all clients at the table
+func (t *Table) broadcastTimerTick(timer string, seatIndex *int, deadline time.Time, remaining time.Duration) {
+	if t.Server == nil {
+		return
+	}
+
+	payload := TimerTickPayload{
+		Timer:       timer,
+		SeatIndex:   seatIndex,
+		RemainingMs: remaining.Milliseconds(),
+		Deadline:    deadline.UnixMilli(),
+		ServerTime:  t.clock().Now().UnixMilli(),
+	}
+
+	err := t.Server.broadcastTableMessage(t, "timer_tick", payload)
+	if err != nil {
+		t.logWarn("failed to broadcast timer_tick", "timer", timer, "error", err)
+	}
+}
+
+// startActionClockLocked puts seatIndex on the clock for the table's action timeout,
+// replacing any running action clock, at the start of their turn (internal, must be called with lock held)
+// Does nothing if action timeouts are disabled
+func (t *Table) startActionClockLocked(seatIndex int) {
+	t.stopActionClockLocked()
+	t.clockCalled = false
+
+	timeout := t.actionTimeoutLocked()
+	if timeout <= 0 {
+		return
+	}
+	t.runActionClockLocked(seatIndex, t.clock().Now().Add(timeout))
+}
+
+// runActionClockLocked puts seatIndex on the clock until deadline, replacing any running action
+// clock (internal, must be called with lock held)
+func (t *Table) runActionClockLocked(seatIndex int, deadline time.Time) {
+	t.stopActionClockLocked()
+
+	cancel := make(chan struct{})
+	t.actionClockCancel = cancel
+	t.ActionDeadline = &deadline
+
+	seat := seatIndex
+	go t.runTimer(TimerAction, &seat, deadline, cancel, func() {
+		t.handleActionTimeout(seat, cancel)
+	})
+}
+
+// stopActionClockLocked stops the running action clock, if any, charging any time bank in use
+// (internal, must be called with lock held)
+func (t *Table) stopActionClockLocked() {
+	t.settleTimeBankLocked()
+	if t.actionClockCancel != nil {
+		close(t.actionClockCancel)
+		t.actionClockCancel = nil
+	}
+	t.ActionDeadline = nil
+}
+
+// handleActionTimeout acts on behalf of a player whose clock ran out:
+// checks when checking is allowed, folds otherwise
+// A player with time bank left, who has not had the clock called on them, is put on it instead
+// unless they keep it for use_time_bank.
+func (t *Table) handleActionTimeout(seatIndex int, cancel chan struct{}) {
+	t.mu.Lock()
+	// Ignore clocks that were stopped or replaced while expiring
+	if t.actionClockCancel != cancel {
+		t.mu.Unlock()
+		return
+	}
+	deadline := *t.ActionDeadline
+	t.actionClockCancel = nil
+	t.ActionDeadline = nil
+	t.settleTimeBankLocked()
+
+	hand := t.CurrentHand
+	if hand == nil || hand.CurrentActor == nil || *hand.CurrentActor != seatIndex {
+		t.mu.Unlock()
+		return
+	}
+
+	if token := t.Seats[seatIndex].Token; token != nil && !t.clockCalled && t.autoTimeBankLocked(*token) {
+		if payload, ok := t.engageTimeBankLocked(seatIndex, deadline); ok {
+			t.mu.Unlock()
+			t.broadcastTimeBank(payload)
+			return
+		}
+	}
+
+	action := "fold"
+	for _, valid := range hand.GetValidActions(seatIndex, t.Seats[seatIndex].Stack, t.Seats) {
+		if valid == "check" {
+			action = "check"
+			break
+		}
+	}
+	t.mu.Unlock()
+
+	t.logInfo("action timeout", "seatIndex", seatIndex, "action", action)
+	if err := t.Server.processTableAction(context.Background(), t, nil, "", seatIndex, action); err != nil {
+		t.logWarn("failed to apply action timeout", "seatIndex", seatIndex, "error", err)
+	}
+}
+
+// deadlinesLocked returns the running action and next-hand deadlines as Unix milliseconds,
+// nil when the corresponding timer is not running (internal, must be called with lock held)
+func (t *Table) deadlinesLocked() (actionDeadline *int64, nextHandAt *int64) {
+	if t.ActionDeadline != nil {
+		deadline := t.ActionDeadline.UnixMilli()
+		actionDeadline = &deadline
+	}
+	if t.NextHandAt != nil {
+		startsAt := t.NextHandAt.UnixMilli()
+		nextHandAt = &startsAt
+	}
+	return actionDeadline, nextHandAt
+}
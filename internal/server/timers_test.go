@@ -0,0 +1,374 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock that only moves when a test advances it
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer // Pending timers
+}
+
+// fakeTimer is a timer of a fakeClock: it sends on ch, or calls fn for AfterFunc, once due
+type fakeTimer struct {
+	clock *fakeClock
+	when  time.Time
+	ch    chan time.Time
+	fn    func()
+}
+
+// useFakeClock replaces the timers' clock of server with a fake one, which must be done before
+// any timer is started
+func useFakeClock(server *Server) *fakeClock {
+	clock := &fakeClock{now: time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)}
+	server.clock = clock
+	return clock
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) ClockTimer {
+	return c.addTimer(d, make(chan time.Time, 1), nil)
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) ClockTimer {
+	return c.addTimer(d, nil, f)
+}
+
+func (c *fakeClock) addTimer(d time.Duration, ch chan time.Time, fn func()) *fakeTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	timer := &fakeTimer{clock: c, when: c.now.Add(d), ch: ch, fn: fn}
+	c.timers = append(c.timers, timer)
+	return timer
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.ch }
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	pending := slices.Contains(t.clock.timers, t)
+	t.clock.timers = slices.DeleteFunc(t.clock.timers, func(other *fakeTimer) bool { return other == t })
+	return pending
+}
+
+// Advance moves the clock forward by d and fires every timer due by then, earliest first.
+// AfterFunc callbacks run before Advance returns; goroutines waiting on a timer channel are
+// only woken, so wait for their effects.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	var due []*fakeTimer
+	c.timers = slices.DeleteFunc(c.timers, func(timer *fakeTimer) bool {
+		if timer.when.After(c.now) {
+			return false
+		}
+		due = append(due, timer)
+		return true
+	})
+	now := c.now
+	c.mu.Unlock()
+
+	slices.SortStableFunc(due, func(a, b *fakeTimer) int { return a.when.Compare(b.when) })
+	for _, timer := range due {
+		if timer.fn != nil {
+			timer.fn()
+		} else {
+			timer.ch <- now
+		}
+	}
+}
+
+// waitForTimers waits until n timers are pending, so a goroutine has armed its timer before the
+// test advances the clock
+func (c *fakeClock) waitForTimers(t *testing.T, n int) {
+	t.Helper()
+	if !eventually(func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return len(c.timers) >= n
+	}) {
+		t.Fatalf("expected %d pending timers", n)
+	}
+}
+
+// eventually polls cond until it holds or a second passes, for effects of timers that fire on
+// another goroutine
+func eventually(cond func() bool) bool {
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return true
+}
+
+// TestActionClock_DisabledByDefault verifies no clock runs with the zero Config
+func TestActionClock_DisabledByDefault(t *testing.T) {
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+	seatTwoPlayers(table)
+
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("failed to start hand: %v", err)
+	}
+
+	table.mu.RLock()
+	defer table.mu.RUnlock()
+	if table.ActionDeadline != nil {
+		t.Error("expected no action deadline with ActionTimeout = 0")
+	}
+}
+
+// TestActionClock_ActionRequestCarriesDeadline verifies action_request includes the absolute deadline
+func TestActionClock_ActionRequestCarriesDeadline(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{ActionTimeout: time.Minute})
+	table := server.tables[0]
+	seatTwoPlayers(table)
+
+	client := &Client{hub: server.hub, Token: "player1", send: make(chan []byte, 256)}
+	server.hub.mu.Lock()
+	server.hub.clients[client] = true
+	server.hub.mu.Unlock()
+
+	before := time.Now()
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("failed to start hand: %v", err)
+	}
+
+	var request *ActionRequestPayload
+	for request == nil {
+		select {
+		case msg := <-client.send:
+			var wsMsg WebSocketMessage
+			if err := json.Unmarshal(msg, &wsMsg); err != nil {
+				t.Fatalf("failed to unmarshal message: %v", err)
+			}
+			if wsMsg.Type == "action_request" {
+				request = &ActionRequestPayload{}
+				if err := json.Unmarshal(wsMsg.Payload, request); err != nil {
+					t.Fatalf("failed to unmarshal payload: %v", err)
+				}
+			}
+		default:
+			t.Fatal("expected action_request message")
+		}
+	}
+
+	expected := before.Add(time.Minute).UnixMilli()
+	if request.Deadline < expected || request.Deadline > expected+1000 {
+		t.Errorf("expected deadline around %d, got %d", expected, request.Deadline)
+	}
+
+	table.mu.Lock()
+	table.stopActionClockLocked()
+	table.mu.Unlock()
+}
+
+// TestActionClock_TimeoutFoldsWhenFacingBet verifies an expired clock folds a player who cannot check
+func TestActionClock_TimeoutFoldsWhenFacingBet(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{ActionTimeout: 30 * time.Second})
+	clock := useFakeClock(server)
+	table := server.tables[0]
+	seatTwoPlayers(table)
+
+	// Heads-up: the dealer (seat 0) is small blind and acts first facing the big blind
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("failed to start hand: %v", err)
+	}
+
+	clock.waitForTimers(t, 1)
+	clock.Advance(29 * time.Second)
+	clock.waitForTimers(t, 1)
+	if table.Phase() == PhaseWaitingForPlayers {
+		t.Fatal("expected the small blind to still be on the clock")
+	}
+
+	clock.Advance(time.Second)
+	if !eventually(func() bool { return table.Phase() == PhaseWaitingForPlayers }) {
+		t.Fatal("expected hand to end after the small blind timed out")
+	}
+
+	table.mu.RLock()
+	defer table.mu.RUnlock()
+	if table.Seats[1].Stack != 1010 {
+		t.Errorf("expected big blind to win the small blind (1010), got %d", table.Seats[1].Stack)
+	}
+}
+
+// TestActionClock_TimeoutChecksWhenPossible verifies an expired clock checks when there is no bet to call
+func TestActionClock_TimeoutChecksWhenPossible(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{ActionTimeout: time.Minute})
+	table := server.tables[0]
+	seatTwoPlayers(table)
+
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("failed to start hand: %v", err)
+	}
+
+	// Small blind completes, big blind is now on the clock with the option to check
+	if err := server.processTableAction(context.Background(), table, nil, "", 0, "call"); err != nil {
+		t.Fatalf("failed to call: %v", err)
+	}
+
+	table.mu.Lock()
+	cancel := table.actionClockCancel
+	table.mu.Unlock()
+	if cancel == nil {
+		t.Fatal("expected big blind to be on the clock")
+	}
+
+	// Expire the clock immediately instead of waiting a minute
+	table.handleActionTimeout(1, cancel)
+
+	table.mu.RLock()
+	defer table.mu.RUnlock()
+	if table.CurrentHand == nil {
+		t.Fatal("expected hand to continue after a timed-out check")
+	}
+	if table.CurrentHand.FoldedPlayers[1] {
+		t.Error("expected big blind to check, not fold")
+	}
+	if table.CurrentHand.Street != "flop" {
+		t.Errorf("expected check to close preflop, street is %s", table.CurrentHand.Street)
+	}
+	table.stopActionClockLocked()
+}
+
+// TestActionClock_StoppedWhenPlayerActs verifies acting stops the actor's clock
+func TestActionClock_StoppedWhenPlayerActs(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{ActionTimeout: time.Minute})
+	table := server.tables[0]
+	seatTwoPlayers(table)
+
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("failed to start hand: %v", err)
+	}
+
+	table.mu.Lock()
+	firstClock := table.actionClockCancel
+	table.mu.Unlock()
+
+	if err := server.processTableAction(context.Background(), table, nil, "", 0, "call"); err != nil {
+		t.Fatalf("failed to call: %v", err)
+	}
+
+	select {
+	case <-firstClock:
+	default:
+		t.Error("expected the small blind's clock to be stopped after acting")
+	}
+
+	table.mu.Lock()
+	table.stopActionClockLocked()
+	table.mu.Unlock()
+}
+
+// TestHandleTimeSync_EchoesClientTime verifies time_sync replies echo the client clock with the server clock
+func TestHandleTimeSync_EchoesClientTime(t *testing.T) {
+	client := &Client{Token: "player1", send: make(chan []byte, 1)}
+
+	before := time.Now().UnixMilli()
+	if err := client.HandleTimeSync(slog.Default(), []byte(`{"clientTime":12345}`)); err != nil {
+		t.Fatalf("HandleTimeSync failed: %v", err)
+	}
+	after := time.Now().UnixMilli()
+
+	var wsMsg WebSocketMessage
+	if err := json.Unmarshal(<-client.send, &wsMsg); err != nil {
+		t.Fatalf("failed to unmarshal message: %v", err)
+	}
+	if wsMsg.Type != "time_sync" {
+		t.Fatalf("expected time_sync, got %q", wsMsg.Type)
+	}
+	var payload TimeSyncResponsePayload
+	if err := json.Unmarshal(wsMsg.Payload, &payload); err != nil {
+		t.Fatalf("failed to unmarshal payload: %v", err)
+	}
+	if payload.ClientTime != 12345 {
+		t.Errorf("expected clientTime 12345, got %d", payload.ClientTime)
+	}
+	if payload.ServerTime < before || payload.ServerTime > after {
+		t.Errorf("expected serverTime in [%d, %d], got %d", before, after, payload.ServerTime)
+	}
+	if wsMsg.ServerTime != payload.ServerTime {
+		t.Errorf("expected envelope serverTime %d, got %d", payload.ServerTime, wsMsg.ServerTime)
+	}
+}
+
+// TestHandleTimeSync_InvalidPayload verifies malformed pings are rejected
+func TestHandleTimeSync_InvalidPayload(t *testing.T) {
+	client := &Client{Token: "player1", send: make(chan []byte, 1)}
+
+	if err := client.HandleTimeSync(slog.Default(), []byte(`not json`)); err == nil {
+		t.Error("expected error for invalid payload")
+	}
+}
+
+// TestSendTableState_IncludesDeadlines verifies reconnect snapshots carry absolute deadlines
+func TestSendTableState_IncludesDeadlines(t *testing.T) {
+	server := NewServerWithConfig(slog.Default(), Config{NextHandDelay: time.Minute})
+	table := server.tables[0]
+	seatTwoPlayers(table)
+
+	if !table.ScheduleNextHand() {
+		t.Fatal("expected countdown to be scheduled")
+	}
+	defer func() {
+		table.mu.Lock()
+		table.cancelNextHandLocked()
+		table.mu.Unlock()
+	}()
+
+	client := &Client{hub: server.hub, Token: "player1", send: make(chan []byte, 256)}
+	if err := client.SendTableState(server, table.ID, slog.Default()); err != nil {
+		t.Fatalf("SendTableState failed: %v", err)
+	}
+
+	for {
+		var wsMsg WebSocketMessage
+		select {
+		case msg := <-client.send:
+			if err := json.Unmarshal(msg, &wsMsg); err != nil {
+				t.Fatalf("failed to unmarshal message: %v", err)
+			}
+		default:
+			t.Fatal("expected table_state message")
+		}
+		if wsMsg.Type != "table_state" {
+			continue
+		}
+		if wsMsg.ServerTime == 0 {
+			t.Error("expected serverTime on table_state")
+		}
+		var payload TableStatePayload
+		if err := json.Unmarshal(wsMsg.Payload, &payload); err != nil {
+			t.Fatalf("failed to unmarshal payload: %v", err)
+		}
+		table.mu.RLock()
+		expected := table.NextHandAt.UnixMilli()
+		table.mu.RUnlock()
+		if payload.NextHandAt == nil || *payload.NextHandAt != expected {
+			t.Errorf("expected nextHandAt %d, got %v", expected, payload.NextHandAt)
+		}
+		if payload.ActionDeadline != nil {
+			t.Errorf("expected no actionDeadline between hands, got %d", *payload.ActionDeadline)
+		}
+		return
+	}
+}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies spans created by this package
+const tracerName = "github.com/robinr2/poker/internal/server"
+
+// Span names for the hand lifecycle
+// Each hand is its own trace: a poker.hand root span with street, action and showdown children
+const (
+	SpanHand     = "poker.hand"
+	SpanStreet   = "poker.street"
+	SpanAction   = "poker.action"
+	SpanShowdown = "poker.showdown"
+	SpanMessage  = "poker.ws_message"
+)
+
+// tracer returns the package tracer from the global provider
+// Looked up on every use so a provider installed after startup (or by tests) takes effect;
+// without one, OpenTelemetry's no-op tracer is used and spans cost next to nothing
+func tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// SetupTracing installs an OTLP/HTTP trace exporter as the global tracer provider when
+// OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT is set.
+// The exporter reads the standard OTEL_EXPORTER_OTLP_* variables for endpoint, headers and TLS.
+// Returns a shutdown function that flushes pending spans; it is a no-op when tracing is disabled.
+func SetupTracing(ctx context.Context, serviceName string) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return noop, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return noop, fmt.Errorf("failed to create trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+	))
+	if err != nil {
+		return noop, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// lockTimed acquires the table write lock and reports when the wait began and how long it took
+// Spans started after the lock use the start time so the wait is visible in the trace
+func (t *Table) lockTimed() (waitStart time.Time, wait time.Duration) {
+	waitStart = time.Now()
+	t.mu.Lock()
+	return waitStart, time.Since(waitStart)
+}
+
+// lockWaitAttribute records how long a span waited for the table lock
+func lockWaitAttribute(wait time.Duration) attribute.KeyValue {
+	return attribute.Int64("poker.lock_wait_us", wait.Microseconds())
+}
+
+// handContextLocked returns the context carrying the current hand's span
+// Falls back to a background context between hands (internal, must be called with lock held)
+func (t *Table) handContextLocked() context.Context {
+	if t.handCtx == nil {
+		return context.Background()
+	}
+	return t.handCtx
+}
+
+// startHandSpanLocked opens the root span for a new hand (internal, must be called with lock held)
+func (t *Table) startHandSpanLocked(hand *Hand, start time.Time, lockWait time.Duration) {
+	activePlayers := 0
+	for i := 0; i < 6; i++ {
+		if t.Seats[i].Status == "active" {
+			activePlayers++
+		}
+	}
+
+	t.handCtx, t.handSpan = tracer().Start(context.Background(), SpanHand,
+		trace.WithNewRoot(),
+		trace.WithTimestamp(start),
+		trace.WithAttributes(
+			attribute.String("poker.table_id", t.ID),
+			attribute.Int("poker.dealer_seat", hand.DealerSeat),
+			attribute.Int("poker.small_blind_seat", hand.SmallBlindSeat),
+			attribute.Int("poker.big_blind_seat", hand.BigBlindSeat),
+			attribute.Int("poker.active_players", activePlayers),
+			lockWaitAttribute(lockWait),
+		),
+	)
+}
+
+// detachHandSpanLocked clears the current hand's trace from the table and returns its span
+// for the caller to end; returns a no-op span if no hand span is open
+// (internal, must be called with lock held)
+func (t *Table) detachHandSpanLocked() trace.Span {
+	span := t.handSpan
+	t.handSpan = nil
+	t.handCtx = nil
+	if span == nil {
+		return trace.SpanFromContext(context.Background())
+	}
+	return span
+}
+
+// endHandSpanLocked ends the current hand's span as failed with err
+// Used when a hand is abandoned before reaching showdown (internal, must be called with lock held)
+func (t *Table) endHandSpanLocked(err error) {
+	span := t.detachHandSpanLocked()
+	failSpan(span, err)
+	span.End()
+}
+
+// failSpan records err on span and marks it failed
+func failSpan(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// installSpanRecorder routes spans to an in-memory recorder for the duration of the test
+func installSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		provider.Shutdown(context.Background())
+	})
+	return recorder
+}
+
+// spanAttribute returns the value of key on span, if present
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+// TestTracing_HandLifecycleSpans verifies a hand produces one trace with action and showdown children
+func TestTracing_HandLifecycleSpans(t *testing.T) {
+	recorder := installSpanRecorder(t)
+
+	server := NewServer(slog.Default())
+	table := server.tables[0]
+	seatTwoPlayers(table)
+
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("failed to start hand: %v", err)
+	}
+
+	table.mu.RLock()
+	actor := *table.CurrentHand.CurrentActor
+	table.mu.RUnlock()
+
+	if err := server.processTableAction(context.Background(), table, nil, "", actor, "fold"); err != nil {
+		t.Fatalf("failed to process fold: %v", err)
+	}
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	for _, name := range []string{SpanHand, SpanAction, SpanShowdown} {
+		if _, ok := spans[name]; !ok {
+			t.Fatalf("expected %s span to be ended, got %d spans", name, len(spans))
+		}
+	}
+
+	hand := spans[SpanHand]
+	if hand.Parent().IsValid() {
+		t.Error("expected hand span to be a trace root")
+	}
+	if value, ok := spanAttribute(hand, "poker.table_id"); !ok || value.AsString() != table.ID {
+		t.Errorf("expected poker.table_id %q on hand span, got %v", table.ID, value)
+	}
+
+	for _, name := range []string{SpanAction, SpanShowdown} {
+		child := spans[name]
+		if child.Parent().SpanID() != hand.SpanContext().SpanID() {
+			t.Errorf("expected %s span to be a child of the hand span", name)
+		}
+	}
+
+	action := spans[SpanAction]
+	if value, ok := spanAttribute(action, "poker.action"); !ok || value.AsString() != "fold" {
+		t.Errorf("expected poker.action fold, got %v", value)
+	}
+	if _, ok := spanAttribute(action, "poker.lock_wait_us"); !ok {
+		t.Error("expected poker.lock_wait_us on action span")
+	}
+
+	table.mu.RLock()
+	defer table.mu.RUnlock()
+	if table.handSpan != nil || table.handCtx != nil {
+		t.Error("expected hand trace to be cleared after showdown")
+	}
+}
+
+// TestTracing_StreetSpan verifies dealing a street records a span in the hand's trace
+func TestTracing_StreetSpan(t *testing.T) {
+	recorder := installSpanRecorder(t)
+
+	table := NewTable("table-1", "Table 1", nil)
+	seatTwoPlayers(table)
+
+	if err := table.StartHand(); err != nil {
+		t.Fatalf("failed to start hand: %v", err)
+	}
+	if err := table.AdvanceToNextStreetWithBroadcast(); err != nil {
+		t.Fatalf("failed to advance street: %v", err)
+	}
+
+	ended := recorder.Ended()
+	if len(ended) != 1 || ended[0].Name() != SpanStreet {
+		t.Fatalf("expected a single ended %s span, got %d", SpanStreet, len(ended))
+	}
+	if value, ok := spanAttribute(ended[0], "poker.street"); !ok || value.AsString() != "flop" {
+		t.Errorf("expected poker.street flop, got %v", value)
+	}
+
+	table.mu.RLock()
+	handTraceID := table.handSpan.SpanContext().TraceID()
+	table.mu.RUnlock()
+	if ended[0].SpanContext().TraceID() != handTraceID {
+		t.Error("expected street span to share the hand's trace")
+	}
+}
+
+// TestSetupTracing_DisabledWithoutEndpoint verifies tracing stays off unless an exporter endpoint is configured
+func TestSetupTracing_DisabledWithoutEndpoint(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+	previous := otel.GetTracerProvider()
+	shutdown, err := SetupTracing(context.Background(), "poker-test")
+	if err != nil {
+		t.Fatalf("SetupTracing failed: %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("expected no-op shutdown, got %v", err)
+	}
+	if otel.GetTracerProvider() != previous {
+		t.Error("expected global tracer provider to be left unchanged")
+	}
+}
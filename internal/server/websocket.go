@@ -1,18 +1,23 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
 	"log/slog"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Hub manages active WebSocket clients.
 type Hub struct {
 	clients    map[*Client]bool
+	sessions   map[string]*Client // The one connection allowed per session token
 	broadcast  chan []byte
 	register   chan *Client
 	unregister chan *Client
@@ -22,16 +27,26 @@ type Hub struct {
 
 // Client represents a WebSocket connection.
 type Client struct {
-	hub   *Hub
-	conn  *websocket.Conn
-	send  chan []byte
-	Token string
+	hub      *Hub
+	conn     *websocket.Conn
+	send     chan []byte
+	Token    string
+	RemoteIP string // Client address, resolved through trusted proxies
+	// wire counts the bytes written to the connection and meter records them by message type;
+	// messages shorter than compressMinBytes are not compressed
+	wire             *wireConn
+	meter            *BandwidthMeter
+	compressMinBytes int
+	// takenOver is set when another connection took over this client's session;
+	// the client then stops handling messages and leaves the seat alone on disconnect
+	takenOver atomic.Bool
 }
 
 // NewHub creates and returns a new Hub instance.
 func NewHub(logger *slog.Logger) *Hub {
 	return &Hub{
 		clients:    make(map[*Client]bool),
+		sessions:   make(map[string]*Client),
 		broadcast:  make(chan []byte),
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
@@ -52,6 +67,7 @@ func (h *Hub) Run() {
 
 		case client := <-h.unregister:
 			h.mu.Lock()
+			h.releaseSessionLocked(client)
 			if _, ok := h.clients[client]; ok {
 				delete(h.clients, client)
 				close(client.send)
@@ -78,43 +94,92 @@ func (h *Hub) Run() {
 // HandleWebSocket returns an HTTP handler for WebSocket upgrade and connection handling.
 func (s *Server) HandleWebSocket(hub *Hub) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		conn, err := s.upgrader.Upgrade(w, r, nil)
+		clientIP := ClientIP(r)
+		if ban, banned := s.bans.IPBan(clientIP, time.Now()); banned {
+			s.logger.Warn("websocket connection refused: ip banned", "client_ip", clientIP, "ban", ban.Value)
+			http.Error(w, "banned", http.StatusForbidden)
+			return
+		}
+		// The slot is released when the client's read loop ends
+		if !s.connections.acquire(clientIP, s.Config().MaxConnectionsPerIP) {
+			s.logger.Warn("websocket connection refused: too many connections", "client_ip", clientIP)
+			http.Error(w, "too many connections", http.StatusTooManyRequests)
+			return
+		}
+
+		// Compression settings apply to connections opened after a reload
+		bandwidth := s.Config().Bandwidth
+		upgrader := *s.upgrader
+		upgrader.EnableCompression = bandwidth.Compress
+		metered := &meteredResponseWriter{ResponseWriter: w}
+		conn, err := upgrader.Upgrade(metered, r, nil)
 		if err != nil {
+			s.connections.release(clientIP)
 			s.logger.Error("websocket upgrade failed", "error", err)
 			return
 		}
+		if bandwidth.Compress {
+			conn.SetCompressionLevel(bandwidth.level())
+		}
 
 		client := &Client{
-			hub:  hub,
-			conn: conn,
-			send: make(chan []byte, 256),
+			hub:              hub,
+			conn:             conn,
+			send:             make(chan []byte, 256),
+			RemoteIP:         clientIP,
+			wire:             metered.conn,
+			meter:            s.bandwidth,
+			compressMinBytes: bandwidth.minBytes(),
 		}
 
 		hub.register <- client
 
-		s.logger.Info("new websocket connection", "remote_addr", conn.RemoteAddr())
+		s.logger.Info("new websocket connection", "remote_addr", conn.RemoteAddr(), "client_ip", client.RemoteIP)
 
 		// Extract token from query parameter
 		token := r.URL.Query().Get("token")
-		shouldCloseOnInvalidToken := false
+		shouldClose := false
 		if token != "" {
 			// Try to validate the token
 			session, err := s.sessionManager.GetSession(token)
 			if err != nil {
 				s.logger.Warn("invalid token provided", "token", token, "error", err)
 				// Send error message and mark for immediate closure after sending
-				client.SendError("Invalid or expired token", s.logger)
-				shouldCloseOnInvalidToken = true
+				client.SendError(newMessageError("error.invalid_token", nil), s.logger)
+				shouldClose = true
+			} else if _, banned := s.bans.AccountBan(session.Name, time.Now()); banned {
+				s.logger.Warn("websocket session refused: account banned", "token", token, "name", session.Name)
+				client.SendError(newMessageError("error.banned", nil), s.logger)
+				shouldClose = true
+			} else if previous, err := hub.claimSession(client, token, s.Config().SessionPolicy); err != nil {
+				// Another connection holds this session and the policy refuses a second one
+				s.logger.Warn("session already in use", "token", token, "client_ip", client.RemoteIP)
+				client.SendError(err, s.logger)
+				shouldClose = true
 			} else {
-				// Token is valid, set client token and prepare to send session_restored
-				client.Token = token
+				if previous != nil {
+					s.logger.Info("session taken over by new connection", "token", token,
+						"previous_ip", previous.RemoteIP, "client_ip", client.RemoteIP)
+					previous.closeAfterNotice()
+				}
 				s.logger.Info("valid token provided", "token", token)
 
+				// Reconnecting counts as activity, so the session's lifetime starts over
+				restored := *session
+				if expiresAt, err := s.sessionManager.RenewSession(token); err == nil {
+					restored.ExpiresAt = expiresAt
+				}
+				// Snapshot the balances so the payload does not share the live map
+				if balances, err := s.sessionManager.Balances(token); err == nil {
+					restored.Balances = balances
+				}
+
 				// Send session_restored message after registration
 				go func() {
-					client.SendSessionRestored(session, s.logger)
+					client.SendSessionRestored(&restored, s.logger)
 					// Send lobby_state after session_restored
 					client.SendLobbyState(s, s.logger)
+					s.handleReconnect(token)
 				}()
 			}
 		}
@@ -123,8 +188,8 @@ func (s *Server) HandleWebSocket(hub *Hub) http.HandlerFunc {
 		go client.readPump(s.sessionManager, s, s.logger)
 		go client.writePump()
 
-		// If token was invalid, close the connection after a short delay to let message be sent
-		if shouldCloseOnInvalidToken {
+		// If the token was refused, close the connection after a short delay to let message be sent
+		if shouldClose {
 			go func() {
 				time.Sleep(10 * time.Millisecond)
 				client.conn.Close()
@@ -137,9 +202,13 @@ func (s *Server) HandleWebSocket(hub *Hub) http.HandlerFunc {
 // readPump reads messages from the WebSocket connection.
 func (c *Client) readPump(sm *SessionManager, server *Server, logger *slog.Logger) {
 	defer func() {
-		server.HandleDisconnect(c.Token)
+		// A replaced connection's seat now belongs to the connection that took it over
+		if !c.takenOver.Load() {
+			server.HandleDisconnect(c.Token)
+		}
 		c.hub.unregister <- c
 		c.conn.Close()
+		server.connections.release(c.RemoteIP)
 	}()
 
 	for {
@@ -150,52 +219,401 @@ func (c *Client) readPump(sm *SessionManager, server *Server, logger *slog.Logge
 			}
 			return
 		}
+		if c.takenOver.Load() {
+			return
+		}
 
-		// Parse the message as JSON
-		var wsMsg WebSocketMessage
-		err = json.Unmarshal(message, &wsMsg)
-		if err != nil {
-			c.SendError("Invalid JSON message", logger)
-			continue
+		if !c.handleMessage(sm, server, logger, message) {
+			return
 		}
+	}
+}
 
-		// Route message by type
-		switch wsMsg.Type {
-		case "set_name":
-			err := c.HandleSetName(sm, server, logger, wsMsg.Payload)
-			if err != nil {
-				c.SendError(err.Error(), logger)
-				logger.Warn("failed to handle set_name", "error", err)
-			}
-		case "join_table":
-			err := c.HandleJoinTable(sm, server, logger, wsMsg.Payload)
-			if err != nil {
-				c.SendError(err.Error(), logger)
-				logger.Warn("failed to handle join_table", "error", err)
-			}
-		case "leave_table":
-			err := c.HandleLeaveTable(sm, server, logger, wsMsg.Payload)
-			if err != nil {
-				c.SendError(err.Error(), logger)
-				logger.Warn("failed to handle leave_table", "error", err)
-			}
-		case "start_hand":
-			err := c.HandleStartHand(sm, server, logger, wsMsg.Payload)
-			if err != nil {
-				c.SendError(err.Error(), logger)
-				logger.Warn("failed to handle start_hand", "error", err)
-			}
-		case "player_action":
-			err := c.HandlePlayerActionMessage(sm, server, logger, wsMsg.Payload)
-			if err != nil {
-				c.SendError(err.Error(), logger)
-				logger.Warn("failed to handle player_action", "error", err)
-			}
-		default:
-			c.SendError("Unknown message type: "+wsMsg.Type, logger)
-			logger.Warn("unknown message type", "type", wsMsg.Type)
+// handleMessage parses one inbound message and routes it to its handler, sending any error back
+// to the client. Returns false when the connection should be closed for protocol abuse.
+func (c *Client) handleMessage(sm *SessionManager, server *Server, logger *slog.Logger, message []byte) bool {
+	// Parse the message as JSON
+	var wsMsg WebSocketMessage
+	if err := json.Unmarshal(message, &wsMsg); err != nil {
+		c.SendError(newMessageError("error.invalid_json", nil), logger)
+		return !server.recordProtocolAbuse(c, logger)
+	}
+
+	// Each inbound message gets its own span; handlers that touch a hand link to it
+	ctx, span := tracer().Start(context.Background(), SpanMessage,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("poker.message_type", wsMsg.Type)),
+	)
+
+	// Route message by type
+	switch wsMsg.Type {
+	case "set_name":
+		err := c.HandleSetName(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle set_name", "error", err)
+		}
+	case "join_table":
+		err := c.HandleJoinTable(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle join_table", "error", err)
+		}
+	case "reserve_seat":
+		err := c.HandleReserveSeat(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle reserve_seat", "error", err)
+		}
+	case "buy_in":
+		err := c.HandleBuyIn(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle buy_in", "error", err)
+		}
+	case "leave_table":
+		err := c.HandleLeaveTable(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle leave_table", "error", err)
+		}
+	case "start_hand":
+		err := c.HandleStartHand(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle start_hand", "error", err)
+		}
+	case "player_action":
+		err := c.HandlePlayerActionMessage(ctx, sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle player_action", "error", err)
+		}
+	case "pre_action":
+		err := c.HandlePreAction(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle pre_action", "error", err)
+		}
+	case "call_clock":
+		err := c.HandleCallClock(sm, server, logger)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle call_clock", "error", err)
+		}
+	case "time_sync":
+		err := c.HandleTimeSync(logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle time_sync", "error", err)
+		}
+	case "renew_session":
+		err := c.HandleRenewSession(sm, logger)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle renew_session", "error", err)
+		}
+	case "logout":
+		err := c.HandleLogout(sm, server, logger)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle logout", "error", err)
+		}
+	case "query_lobby":
+		err := c.HandleQueryLobby(server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle query_lobby", "error", err)
+		}
+	case "emote":
+		err := c.HandleEmote(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle emote", "error", err)
+		}
+	case "show_cards":
+		err := c.HandleShowCards(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle show_cards", "error", err)
+		}
+	case "query_hand_history":
+		err := c.HandleQueryHandHistory(server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle query_hand_history", "error", err)
+		}
+	case "quick_seat":
+		err := c.HandleQuickSeat(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle quick_seat", "error", err)
+		}
+	case "leave_waitlist":
+		err := c.HandleLeaveWaitlist(server, logger)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle leave_waitlist", "error", err)
+		}
+	case "set_preflop_hints":
+		err := c.HandleSetPreflopHints(sm, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle set_preflop_hints", "error", err)
+		}
+	case "set_language":
+		err := c.HandleSetLanguage(sm, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle set_language", "error", err)
+		}
+	case "use_time_bank":
+		err := c.HandleUseTimeBank(sm, server)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle use_time_bank", "error", err)
+		}
+	case "set_auto_time_bank":
+		err := c.HandleSetAutoTimeBank(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle set_auto_time_bank", "error", err)
+		}
+	case "set_privacy":
+		err := c.HandleSetPrivacy(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle set_privacy", "error", err)
+		}
+	case "get_recovery_code":
+		err := c.HandleGetRecoveryCode(sm, server, logger)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle get_recovery_code", "error", err)
+		}
+	case "recover_seat":
+		err := c.HandleRecoverSeat(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle recover_seat", "error", err)
+		}
+	case "set_auto_muck":
+		err := c.HandleSetAutoMuck(sm, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle set_auto_muck", "error", err)
+		}
+	case "challenge":
+		err := c.HandleChallenge(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle challenge", "error", err)
+		}
+	case "leave_challenge":
+		err := c.HandleLeaveChallenge(server, logger)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle leave_challenge", "error", err)
+		}
+	case "rematch":
+		err := c.HandleRematch(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle rematch", "error", err)
+		}
+	case "merge_response":
+		err := c.HandleMergeResponse(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle merge_response", "error", err)
+		}
+	case "take_host_seat":
+		err := c.HandleTakeHostSeat(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle take_host_seat", "error", err)
+		}
+	case "leave_host_seat":
+		err := c.HandleLeaveHostSeat(server, logger)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle leave_host_seat", "error", err)
+		}
+	case "host_chat":
+		err := c.HandleHostChat(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle host_chat", "error", err)
+		}
+	case "host_pause":
+		err := c.HandleHostPause(server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle host_pause", "error", err)
+		}
+	case "bomb_pot":
+		err := c.HandleBombPot(server, logger)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle bomb_pot", "error", err)
+		}
+	case "create_club":
+		err := c.HandleCreateClub(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle create_club", "error", err)
+		}
+	case "join_club":
+		err := c.HandleJoinClub(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle join_club", "error", err)
+		}
+	case "leave_club":
+		err := c.HandleLeaveClub(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle leave_club", "error", err)
+		}
+	case "list_clubs":
+		err := c.HandleListClubs(sm, server)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle list_clubs", "error", err)
+		}
+	case "rotate_club_invite":
+		err := c.HandleRotateClubInvite(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle rotate_club_invite", "error", err)
+		}
+	case "set_club_role":
+		err := c.HandleSetClubRole(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle set_club_role", "error", err)
+		}
+	case "remove_club_member":
+		err := c.HandleRemoveClubMember(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle remove_club_member", "error", err)
+		}
+	case "create_club_table":
+		err := c.HandleCreateClubTable(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle create_club_table", "error", err)
+		}
+	case "club_ledger":
+		err := c.HandleClubLedger(sm, server, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle club_ledger", "error", err)
+		}
+	case "settle_club":
+		err := c.HandleSettleClub(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle settle_club", "error", err)
+		}
+	case "watch_table":
+		err := c.HandleWatchTable(sm, server, logger, wsMsg.Payload)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle watch_table", "error", err)
+		}
+	case "unwatch_table":
+		err := c.HandleUnwatchTable(server, logger)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle unwatch_table", "error", err)
+		}
+	case "mute_player", "unmute_player":
+		err := c.HandleMutePlayer(sm, server, logger, wsMsg.Payload, wsMsg.Type == "mute_player")
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle "+wsMsg.Type, "error", err)
+		}
+	case "get_mute_list":
+		err := c.HandleGetMuteList(sm, server, logger)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle get_mute_list", "error", err)
+		}
+	case "get_inventory":
+		err := c.HandleGetInventory(sm, server, logger)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle get_inventory", "error", err)
+		}
+	case "claim_bonus":
+		err := c.HandleClaimBonus(sm, server, logger)
+		if err != nil {
+			c.SendError(err, logger)
+			failSpan(span, err)
+			logger.Warn("failed to handle claim_bonus", "error", err)
+		}
+	default:
+		c.SendError(newMessageError("error.unknown_message_type", map[string]any{"type": wsMsg.Type}), logger)
+		logger.Warn("unknown message type", "type", wsMsg.Type)
+		if server.recordProtocolAbuse(c, logger) {
+			span.End()
+			return false
 		}
 	}
+	span.End()
+	return true
 }
 
 // writePump writes messages to the WebSocket connection.
@@ -205,21 +623,13 @@ func (c *Client) writePump() {
 	}()
 
 	for message := range c.send {
+		// A no-op unless the client negotiated compression
+		c.conn.EnableWriteCompression(len(message) >= c.compressMinBytes)
+		written := c.wire.Written()
 		err := c.conn.WriteMessage(websocket.TextMessage, message)
 		if err != nil {
 			return
 		}
+		c.meter.Record(message, c.wire.Written()-written)
 	}
 }
-
-// filterHoleCardsForPlayer returns a map with only the specified player's hole cards
-// This ensures players only see their own cards, not opponents' cards
-func filterHoleCardsForPlayer(holeCards map[int][]Card, playerSeat int) map[int][]Card {
-	filtered := make(map[int][]Card)
-
-	if cards, ok := holeCards[playerSeat]; ok {
-		filtered[playerSeat] = cards
-	}
-
-	return filtered
-}
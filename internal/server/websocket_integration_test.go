@@ -2304,8 +2304,14 @@ func TestDisconnectBroadcastsTableStateToRemaining(t *testing.T) {
 	// Give server time to process disconnect
 	time.Sleep(50 * time.Millisecond)
 
-	// Player 1 should receive table_state broadcast showing only themselves
+	// Player 1 is told Player2's connection dropped
 	msg := readMessage(t, ws1)
+	if msg.Type != "connection_status" || !strings.Contains(string(msg.Payload), `"status":"disconnected"`) {
+		t.Fatalf("expected connection_status disconnected for player1, got %q %s", msg.Type, msg.Payload)
+	}
+
+	// Player 1 should receive table_state broadcast showing only themselves
+	msg = readMessage(t, ws1)
 	if msg.Type != "table_state" {
 		t.Fatalf("expected table_state for player1 after player2 disconnects, got %q", msg.Type)
 	}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// webUI is the minimal browser client compiled into the binary, so a server started without
+// the built frontend in web/static is still playable at /play/
+//
+//go:embed webui
+var webUI embed.FS
+
+// webUIPath is where the embedded client is served
+const webUIPath = "/play/"
+
+// serveWebUI mounts the embedded client at /play/ and its replay viewer at /replay/{handID}
+func (s *Server) serveWebUI() {
+	files, err := fs.Sub(webUI, "webui")
+	if err != nil {
+		s.logger.Error("embedded web client unavailable", "error", err)
+		return
+	}
+	s.router.Get("/play", http.RedirectHandler(webUIPath, http.StatusMovedPermanently).ServeHTTP)
+	s.router.Handle(webUIPath+"*", http.StripPrefix(webUIPath, http.FileServer(http.FS(files))))
+	s.router.Get("/replay/{handID}", func(w http.ResponseWriter, r *http.Request) {
+		// The page reads the hand ID from its URL and fetches /api/replays/{handID}
+		http.ServeFileFS(w, r, files, "replay.html")
+	})
+}
@@ -0,0 +1,421 @@
+// Minimal browser client served from the server binary. It speaks the same WebSocket protocol
+// as the React frontend and pkg/client: a lobby, one table at a time and the action buttons.
+"use strict";
+
+const $ = (id) => document.getElementById(id);
+const tokenKey = "poker.token";
+
+const state = {
+  ws: null,
+  name: "",
+  seat: null, // {tableId, seatIndex} while seated
+  watching: null, // Table ID while observing
+  table: null, // Last table_state
+  board: [],
+  hole: [],
+  request: null, // action_request addressed to us
+  handId: null, // Hand in progress, for its replay link
+  actions: 0,
+  retry: 1000,
+};
+
+function send(type, payload) {
+  if (state.ws && state.ws.readyState === WebSocket.OPEN) {
+    state.ws.send(JSON.stringify({ type, payload }));
+  }
+}
+
+function log(text, error, replay) {
+  const li = document.createElement("li");
+  li.textContent = text;
+  if (error) li.className = "error";
+  if (replay) {
+    const a = document.createElement("a");
+    a.href = "/replay/" + encodeURIComponent(replay);
+    a.target = "_blank";
+    a.textContent = "replay";
+    li.append(" ", a);
+  }
+  $("log").prepend(li);
+  while ($("log").children.length > 12) $("log").lastChild.remove();
+}
+
+function card(c) {
+  const suits = { s: "♠", h: "♥", d: "♦", c: "♣" };
+  const span = document.createElement("span");
+  span.textContent = c.Rank + (suits[c.Suit] || c.Suit) + " ";
+  if (c.Suit === "h" || c.Suit === "d") span.className = "red";
+  return span;
+}
+
+function showCards(el, cards) {
+  el.replaceChildren(...(cards.length ? cards.map(card) : [document.createTextNode("-")]));
+}
+
+function seatName(index) {
+  const seat = state.table && state.table.seats[index];
+  return (seat && seat.playerName) || "seat " + index;
+}
+
+// queryHistory asks for the table's recent hands. The server records a hand just after its
+// result is sent, so after a showdown the query waits a moment.
+function queryHistory(tableId, delay) {
+  setTimeout(() => send("query_hand_history", { tableId }), delay || 0);
+}
+
+// offerShow lets the winner of an uncontested hand show a card, or both, until the next hand
+function offerShow() {
+  const buttons = state.hole.map((c) => {
+    const button = document.createElement("button");
+    button.append(card(c));
+    button.onclick = () => send("show_cards", { cards: [c] });
+    return button;
+  });
+  const both = document.createElement("button");
+  both.textContent = "Both";
+  both.onclick = () => send("show_cards", { cards: state.hole });
+  $("show-cards").replaceChildren(...buttons, both);
+  $("show").hidden = state.hole.length === 0;
+}
+
+function renderHistory(hands) {
+  $("hands").replaceChildren(
+    ...hands.map((h) => {
+      const li = document.createElement("li");
+      const winners = h.winners.map((w) => w.name + " +" + w.amount).join(", ");
+      li.append(winners + (h.winningHand ? " with " + h.winningHand.replaceAll("_", " ") : "") + ", pot " + h.pot + "  ");
+      for (const c of h.board) li.append(card(c));
+      const a = document.createElement("a");
+      a.href = "/replay/" + encodeURIComponent(h.handId);
+      a.target = "_blank";
+      a.textContent = "replay";
+      li.append(a);
+      return li;
+    }),
+  );
+}
+
+function connect() {
+  const token = localStorage.getItem(tokenKey);
+  const scheme = location.protocol === "https:" ? "wss:" : "ws:";
+  const url = scheme + "//" + location.host + "/ws" + (token ? "?token=" + encodeURIComponent(token) : "");
+  const ws = new WebSocket(url);
+  state.ws = ws;
+  $("status").textContent = "connecting…";
+
+  ws.onopen = () => {
+    state.retry = 1000;
+    if (!token) send("set_name", { name: state.name });
+  };
+  ws.onmessage = (event) => handle(JSON.parse(event.data));
+  ws.onclose = () => {
+    if (state.ws !== ws) return;
+    $("status").textContent = "disconnected, retrying…";
+    if (localStorage.getItem(tokenKey)) {
+      setTimeout(connect, state.retry);
+      state.retry = Math.min(state.retry * 2, 30000);
+    } else {
+      askName();
+    }
+  };
+}
+
+function askName() {
+  state.ws = null;
+  $("status").textContent = "";
+  $("name-form").hidden = false;
+  $("lobby").hidden = true;
+  $("table").hidden = true;
+}
+
+function handle(msg) {
+  const p = msg.payload;
+  switch (msg.type) {
+    case "session_created":
+      localStorage.setItem(tokenKey, p.token);
+    // Fall through
+    case "session_restored":
+      state.name = p.name;
+      $("status").textContent = "playing as " + p.name;
+      $("name-form").hidden = true;
+      if (p.tableID && p.seatIndex != null) state.seat = { tableId: p.tableID, seatIndex: p.seatIndex };
+      render();
+      break;
+    case "lobby_state":
+      renderLobby(typeof p === "string" ? JSON.parse(p) : p);
+      break;
+    case "seat_assigned":
+      state.seat = { tableId: p.tableId, seatIndex: p.seatIndex };
+      log("seated at " + p.tableId + ", seat " + p.seatIndex);
+      render();
+      break;
+    case "seat_cleared":
+      state.seat = null;
+      state.table = null;
+      state.request = null;
+      log("left the table");
+      render();
+      break;
+    case "table_state":
+      if (!state.table || state.table.tableId !== p.tableId) queryHistory(p.tableId);
+      state.table = p;
+      if (!p.handInProgress) state.request = null;
+      if (state.seat && p.holeCards && p.holeCards[state.seat.seatIndex]) state.hole = p.holeCards[state.seat.seatIndex];
+      render();
+      break;
+    case "hand_started":
+      $("show").hidden = true;
+      state.handId = p.handId;
+      state.board = [];
+      state.hole = [];
+      state.request = null;
+      log("new hand, " + seatName(p.dealerSeat) + " has the button");
+      render();
+      break;
+    case "cards_dealt":
+      if (state.seat && p.holeCards[state.seat.seatIndex]) state.hole = p.holeCards[state.seat.seatIndex];
+      render();
+      break;
+    case "action_request":
+      state.request = state.seat && state.seat.seatIndex === p.seatIndex ? p : null;
+      render();
+      break;
+    case "action_result":
+      if (state.seat && state.seat.seatIndex === p.seatIndex) state.request = null;
+      log(seatName(p.seatIndex) + " " + p.action + (p.amountActed > 0 ? " " + p.amountActed : ""));
+      render();
+      break;
+    case "board_dealt":
+      state.board = p.boardCards;
+      log(p.street + ": " + p.boardCards.map((c) => c.Rank + c.Suit).join(" "));
+      render();
+      break;
+    case "showdown_reveal":
+      // Turned over one at a time, in showdown order
+      setTimeout(() => log(seatName(p.seatIndex) + (p.mucked ? " mucks" :
+        " shows " + p.cards.map((c) => c.Rank + c.Suit).join(" ") + " (" + p.hand.replaceAll("_", " ") + ")")),
+        Math.max(0, p.revealAt - Date.now()));
+      break;
+    case "showdown_result": {
+      const winners = p.winnerSeats.map((s) => seatName(s) + " +" + p.amountsWon[s]).join(", ");
+      log(winners + (p.winningHand ? " with " + p.winningHand : ""), false, state.handId);
+      state.request = null;
+      if (state.table) queryHistory(state.table.tableId, 500);
+      if (!p.winningHand && state.seat && p.winnerSeats.includes(state.seat.seatIndex)) offerShow();
+      render();
+      break;
+    }
+    case "cards_shown":
+      log(seatName(p.seatIndex) + " shows " + p.cards.map((c) => c.Rank + c.Suit).join(" "));
+      break;
+    case "waiting_for_players":
+      log("Waiting for players (" + p.players + "/" + p.minPlayers + ")");
+      break;
+    case "table_resumed":
+      log("Enough players, dealing again");
+      break;
+    case "match_result":
+      log(p.winner + " beats " + p.loser + (p.next === "rematch" ? ", rematch offered" : ", next challenger up"));
+      break;
+    case "rematch_offer":
+      log("Rematch " + p.opponent + "? Send rematch within " + Math.max(0, Math.round((p.expiresAt - Date.now()) / 1000)) + "s");
+      break;
+    case "session_summary": {
+      const minutes = Math.round(p.durationMs / 60000);
+      log("Left " + p.tableId + " after " + minutes + " min: " + p.handsPlayed + " hands, " +
+        (p.netChips >= 0 ? "+" : "") + p.netChips + " chips, biggest pot " + p.biggestPot);
+      break;
+    }
+    case "table_merge_offer":
+      log("Move to " + p.targetName + " with the rest of the table? Send merge_response within " +
+        Math.max(0, Math.round((p.expiresAt - Date.now()) / 1000)) + "s");
+      break;
+    case "table_merge_result":
+      log(p.status === "merged" ? "Table merged into " + p.targetTableId : "Table merge " + p.status);
+      break;
+    case "table_closed":
+      log("Table " + p.tableId + " closed for lack of players");
+      break;
+    case "club":
+      log("Club " + p.name + " (" + p.role + "): " + p.members.length + " members, " + p.tables.length + " tables" +
+        (p.inviteCode ? ", invite code " + p.inviteCode : ""));
+      break;
+    case "club_list":
+      log(p.clubs.length ? "Clubs: " + p.clubs.map((c) => c.name).join(", ") : "Not in any club");
+      break;
+    case "replay_snippet":
+      log("What a hand! Share it: " + location.origin + p.url);
+      break;
+    case "confirm_raise":
+      if (confirm("Raise to " + p.amount + ", putting in " + p.chips + " of your " + p.stack + " chips?")) {
+        send("player_action", { actionId: p.actionId, seatIndex: p.seatIndex, action: "raise", amount: p.amount, confirm: true });
+      }
+      break;
+    case "preflop_hint":
+      log("Training hint (" + p.position + ", " + p.hand + "): " + p.action);
+      break;
+    case "club_ledger":
+      log("Club ledger: " + p.positions.map((x) => x.name + " " + x.net).join(", "));
+      break;
+    case "club_settlement":
+      log("Settle-up: " + (p.transfers.length ? p.transfers.map((x) => x.from + " pays " + x.to + " " + x.amount).join(", ") : "nothing to pay"));
+      break;
+    case "club_left":
+      log("Left club " + p.clubId);
+      break;
+    case "host_seat":
+      log(p.tableId ? "Hosting " + p.tableId : "Left the host seat");
+      break;
+    case "host_chat":
+      log(p.host + " (host): " + p.text);
+      break;
+    case "time_bank":
+      log(seatName(p.seatIndex) + " is using their time bank (" + Math.round(p.timeBankMs / 1000) + "s)");
+      break;
+    case "button_draw":
+      log("Drawing for the button: " + p.cards.map((d) => seatName(d.seatIndex) + " " + d.card.Rank + d.card.Suit).join(", ") +
+        "; " + seatName(p.dealerSeat) + " has the button");
+      break;
+    case "rules_pending":
+      log("From the next hand: blinds " + p.blinds.smallBlind + "/" + p.blinds.bigBlind +
+        (p.actionTimeoutMs ? ", " + p.actionTimeoutMs / 1000 + "s to act" : "") +
+        (p.rakePercent ? ", rake " + p.rakePercent + "%" + (p.rakeCap ? " capped at " + p.rakeCap : "") : ""));
+      break;
+    case "bomb_pot_called":
+      log("Bomb pot next hand: everyone antes " + p.ante);
+      break;
+    case "challenge_status":
+      log(p.position ? "Challenger #" + p.position + " at " + p.tableId : "Left the challenger queue");
+      break;
+    case "connection_status":
+      log(p.playerName + " " + p.status.replace("_", " ") +
+        (p.reconnectDeadline ? " (seat held " + Math.max(0, Math.round((p.reconnectDeadline - Date.now()) / 1000)) + "s)" : ""));
+      break;
+    case "emote":
+      log(seatName(p.seatIndex) + " " + (p.targetSeat !== undefined ? "sends " + p.emote.replace("_", " ") + " at " + seatName(p.targetSeat) : p.emote.replace("_", " ")));
+      break;
+    case "hand_history":
+      if (state.table && state.table.tableId === p.tableId) renderHistory(p.hands);
+      break;
+    case "error":
+      log(p.message, true);
+      if (p.key === "error.invalid_token") {
+        localStorage.removeItem(tokenKey);
+      }
+      break;
+  }
+}
+
+function renderLobby(tables) {
+  const rows = tables.map((t) => {
+    const tr = document.createElement("tr");
+    for (const text of [t.name, t.seats_occupied + "/" + t.max_seats, t.small_blind + "/" + t.big_blind, t.buy_in]) {
+      const td = document.createElement("td");
+      td.textContent = text;
+      tr.append(td);
+    }
+    const td = document.createElement("td");
+    const join = document.createElement("button");
+    join.textContent = "Join";
+    join.onclick = () => send("join_table", { tableId: t.id });
+    const watch = document.createElement("button");
+    watch.textContent = "Watch";
+    watch.onclick = () => {
+      state.watching = t.id;
+      send("watch_table", { tableId: t.id });
+    };
+    td.append(join, " ", watch);
+    tr.append(td);
+    return tr;
+  });
+  $("tables").replaceChildren(...rows);
+}
+
+function render() {
+  const t = state.table;
+  $("lobby").hidden = !!t || !state.name;
+  $("table").hidden = !t;
+  if (!t) return;
+
+  const blinds = t.blinds.smallBlind + "/" + t.blinds.bigBlind + (t.blinds.ante ? " ante " + t.blinds.ante : "");
+  $("table-name").textContent = t.tableId + "  " + blinds + (t.frozen ? " (frozen)" : "") + (t.closing ? " (closing)" : "");
+  $("start").hidden = !state.seat || t.handInProgress;
+
+  let pot = t.pot || 0;
+  const seats = t.seats.map((seat) => {
+    const li = document.createElement("li");
+    pot += seat.bet || 0;
+    if (!seat.playerName) {
+      li.className = "empty";
+      li.textContent = seat.index + "  -";
+      return li;
+    }
+    let text = seat.index + "  " + (t.dealerSeat === seat.index ? "Ⓓ " : "") + seat.playerName;
+    if (state.seat && state.seat.seatIndex === seat.index) text += " (you)";
+    text += "  " + (seat.stack || 0);
+    if (seat.bet) text += "  bet " + seat.bet;
+    if (seat.status !== "active") text += "  " + seat.status;
+    li.textContent = text;
+    if (t.currentActor === seat.index) li.classList.add("actor");
+    if (seat.status === "folded") li.classList.add("folded");
+    return li;
+  });
+  $("seats").replaceChildren(...seats);
+  $("pot").textContent = t.handInProgress ? pot : 0;
+  showCards($("board"), t.handInProgress ? state.board : []);
+  showCards($("hole"), t.handInProgress ? state.hole : []);
+
+  const r = state.request;
+  $("actions").hidden = !r;
+  if (!r) return;
+  for (const button of $("actions").querySelectorAll("button")) {
+    button.hidden = !r.validActions.includes(button.dataset.action);
+  }
+  $("actions").querySelector('[data-action="call"]').textContent = "Call " + r.callAmount;
+  const amount = $("amount");
+  amount.hidden = !r.validActions.includes("raise");
+  amount.min = r.minRaise;
+  amount.max = r.maxRaise;
+  amount.step = r.raiseStep || 1;
+  amount.value = r.minRaise;
+}
+
+function act(action) {
+  const r = state.request;
+  if (!r) return;
+  const payload = { actionId: "web-" + Date.now() + "-" + ++state.actions, seatIndex: r.seatIndex, action };
+  if (action === "raise") payload.amount = Number($("amount").value);
+  send("player_action", payload);
+}
+
+$("name-form").onsubmit = (event) => {
+  event.preventDefault();
+  state.name = $("name").value.trim();
+  if (!state.name) return;
+  $("name-form").hidden = true;
+  connect();
+};
+$("start").onclick = () => send("start_hand", {});
+$("leave").onclick = () => {
+  if (state.seat) {
+    send("leave_table", {});
+  } else if (state.watching) {
+    send("unwatch_table", {});
+    state.watching = null;
+    state.table = null;
+    render();
+  }
+};
+for (const button of $("actions").querySelectorAll("button")) {
+  button.onclick = () => act(button.dataset.action);
+}
+setInterval(() => {
+  const r = state.request;
+  $("clock").textContent = r && r.deadline ? Math.max(0, Math.round((r.deadline - Date.now()) / 1000)) + "s" : "";
+}, 500);
+
+if (localStorage.getItem(tokenKey)) {
+  connect();
+} else {
+  askName();
+}
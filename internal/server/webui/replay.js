@@ -0,0 +1,99 @@
+// Replay viewer for /replay/{handID}. The server sends every frame of the hand up front, so
+// pausing, stepping and seeking only move through the array.
+"use strict";
+
+const $ = (id) => document.getElementById(id);
+const handID = decodeURIComponent(location.pathname.split("/").pop());
+const playInterval = 1000;
+
+let hand = null;
+let frames = [];
+let position = 0;
+let timer = null;
+
+function card(c) {
+  const suits = { s: "♠", h: "♥", d: "♦", c: "♣" };
+  const span = document.createElement("span");
+  span.textContent = c.Rank + (suits[c.Suit] || c.Suit) + " ";
+  if (c.Suit === "h" || c.Suit === "d") span.className = "red";
+  return span;
+}
+
+function describe(step) {
+  if (!step) return "Cards are dealt";
+  const name = step.seat != null ? hand.players[step.seat] || "seat " + step.seat : "";
+  switch (step.type) {
+    case "blind":
+      return name + " posts " + step.amount;
+    case "action":
+      return name + " " + step.action + (step.amount ? " " + step.amount : "") + (step.timeout ? " (timed out)" : "");
+    case "board":
+      return "The " + step.street + " is dealt";
+    case "result": {
+      const winners = Object.entries(step.winnings).map(([seat, won]) => (hand.players[seat] || "seat " + seat) + " wins " + won);
+      return winners.join(", ") + (hand.winningHand ? " with " + hand.winningHand.replaceAll("_", " ") : "");
+    }
+  }
+  return step.type;
+}
+
+function show(n) {
+  position = Math.max(0, Math.min(n, frames.length - 1));
+  const frame = frames[position];
+  let pot = frame.pot;
+  $("seats").replaceChildren(
+    ...frame.seats.map((seat) => {
+      pot += seat.bet || 0;
+      const li = document.createElement("li");
+      li.textContent =
+        seat.seat + "  " + (hand.dealerSeat === seat.seat ? "Ⓓ " : "") + seat.name + "  " + seat.stack + (seat.bet ? "  bet " + seat.bet : "") + "  ";
+      for (const c of seat.holeCards || []) li.append(card(c));
+      if (seat.folded) li.classList.add("folded");
+      if (frame.last && frame.last.seat === seat.seat) li.classList.add("actor");
+      return li;
+    }),
+  );
+  $("board").replaceChildren(...(frame.board.length ? frame.board.map(card) : [document.createTextNode("-")]));
+  $("pot").textContent = pot;
+  $("last").textContent = describe(frame.last);
+  $("seek").value = position;
+  $("position").textContent = position + " / " + (frames.length - 1);
+  if (position === frames.length - 1) pause();
+}
+
+function pause() {
+  clearInterval(timer);
+  timer = null;
+  $("play").textContent = "Play";
+}
+
+function play() {
+  if (position === frames.length - 1) show(0);
+  timer = setInterval(() => show(position + 1), playInterval);
+  $("play").textContent = "Pause";
+}
+
+$("first").onclick = () => show(0);
+$("back").onclick = () => show(position - 1);
+$("forward").onclick = () => show(position + 1);
+$("end").onclick = () => show(frames.length - 1);
+$("play").onclick = () => (timer ? pause() : play());
+$("seek").oninput = () => show(Number($("seek").value));
+
+fetch("/api/replays/" + encodeURIComponent(handID))
+  .then((response) => {
+    if (!response.ok) throw new Error(response.status === 404 ? "This hand is not stored (anymore)" : response.statusText);
+    return response.json();
+  })
+  .then((body) => {
+    hand = body.hand;
+    frames = body.frames;
+    $("status").textContent = "";
+    $("title").textContent = hand.tableId + ", " + new Date(hand.startedAt).toLocaleString();
+    $("seek").max = frames.length - 1;
+    $("replay").hidden = false;
+    show(0);
+  })
+  .catch((err) => {
+    $("status").textContent = err.message;
+  });
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWebUI(t *testing.T) {
+	// Tests run in internal/server, where there is no web/static, as for a lone binary
+	server := NewServer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		return w
+	}
+
+	t.Run("serves the embedded client", func(t *testing.T) {
+		w := get("/play/")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+		}
+		if body := w.Body.String(); !strings.Contains(body, `<script src="app.js">`) {
+			t.Errorf("expected the embedded index.html, got: %s", body)
+		}
+	})
+
+	t.Run("serves its assets", func(t *testing.T) {
+		w := get("/play/app.js")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+		}
+		if !strings.Contains(w.Body.String(), `"player_action"`) {
+			t.Error("expected app.js to send player_action messages")
+		}
+	})
+
+	t.Run("redirects to the client", func(t *testing.T) {
+		for _, path := range []string{"/play", "/"} {
+			w := get(path)
+			if location := w.Header().Get("Location"); location != webUIPath {
+				t.Errorf("GET %s: expected redirect to %s, got status %d location %q", path, webUIPath, w.Code, location)
+			}
+		}
+	})
+}
@@ -0,0 +1,997 @@
+// Package client is a Go client for the poker server's WebSocket protocol, for bots, tools and
+// integration tests. It creates or restores a session, sends typed requests (JoinTable, Act, ...)
+// and calls back with typed messages (OnActionRequest, OnHandResult, ...). A dropped connection
+// is re-established with the same session; if the seat survived, an action the server had not
+// acknowledged is sent again under its original action ID so it is never applied twice.
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Defaults for Config fields left zero
+const (
+	DefaultReconnectDelay    = 500 * time.Millisecond
+	DefaultMaxReconnectDelay = 30 * time.Second
+	DefaultHandshakeTimeout  = 10 * time.Second
+)
+
+// ErrNotSeated is returned by Act when the client has no seat
+var ErrNotSeated = errors.New("client: not seated at a table")
+
+// ErrClosed is returned when sending on a client that was closed
+var ErrClosed = errors.New("client: closed")
+
+// Config describes how to connect
+type Config struct {
+	URL               string        // WebSocket endpoint, e.g. ws://localhost:8080/ws
+	Name              string        // Player name for a new session; ignored when Token is set
+	Token             string        // Session to restore instead of creating one
+	ReconnectDelay    time.Duration // First wait before reconnecting, doubled on every failure
+	MaxReconnectDelay time.Duration // Longest wait between reconnection attempts
+	HandshakeTimeout  time.Duration // How long to wait for the session after connecting
+	Logger            *slog.Logger  // Defaults to discarding logs
+}
+
+// handlers holds the registered callbacks
+type handlers struct {
+	message       []func(Message)
+	lobby         []func([]TableInfo)
+	seatAssigned  []func(SeatAssignment)
+	seatCleared   []func()
+	tableState    []func(TableState)
+	handStarted   []func(HandStarted)
+	buttonDraw    []func(ButtonDraw)
+	cardsDealt    []func(CardsDealt)
+	actionRequest []func(ActionRequest)
+	actionResult  []func(ActionResult)
+	boardDealt    []func(BoardDealt)
+	reveal        []func(ShowdownReveal)
+	handResult    []func(HandResult)
+	summary       []func(SessionSummary)
+	hostChat      []func(HostChat)
+	rulesPending  []func(TableRules)
+	club          []func(Club)
+	clubList      []func([]Club)
+	clubLedger    []func(ClubLedger)
+	settlement    []func(ClubSettlement)
+	snippet       []func(ReplaySnippet)
+	preflopHint   []func(PreflopHint)
+	recoveryCode  []func(string)
+	confirmRaise  []func(RaiseConfirmation)
+	serverError   []func(*Error)
+	reconnect     []func()
+	closed        []func(error)
+}
+
+// Client is a connection to the poker server. Callbacks run one at a time on the client's read
+// goroutine, in the order messages arrive; they may call the client's methods.
+type Client struct {
+	cfg    Config
+	logger *slog.Logger
+
+	writeMu sync.Mutex // Serializes writes to conn
+
+	mu            sync.Mutex
+	conn          *websocket.Conn
+	session       Session
+	seat          *SeatAssignment
+	pendingAction *Message // Last player_action not yet acknowledged by an action_result
+	actionPrefix  string   // Makes action IDs unique across clients and restarts
+	actionCount   int
+	closed        bool
+	handlers      handlers
+
+	stop chan struct{} // Closed by Close
+	done chan struct{} // Closed when the client stops for good
+}
+
+// Dial connects to the server and waits for the session to be created or restored
+func Dial(ctx context.Context, cfg Config) (*Client, error) {
+	if cfg.Token == "" && cfg.Name == "" {
+		return nil, errors.New("client: a name or a session token is required")
+	}
+	if cfg.ReconnectDelay <= 0 {
+		cfg.ReconnectDelay = DefaultReconnectDelay
+	}
+	if cfg.MaxReconnectDelay <= 0 {
+		cfg.MaxReconnectDelay = DefaultMaxReconnectDelay
+	}
+	if cfg.HandshakeTimeout <= 0 {
+		cfg.HandshakeTimeout = DefaultHandshakeTimeout
+	}
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	prefix := make([]byte, 6)
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, fmt.Errorf("client: failed to generate action IDs: %w", err)
+	}
+	c := &Client{
+		cfg:          cfg,
+		logger:       logger,
+		session:      Session{Token: cfg.Token},
+		actionPrefix: hex.EncodeToString(prefix),
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+
+	conn, err := c.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.conn = conn
+	go c.run(conn)
+	return c, nil
+}
+
+// connect dials the server and completes the session handshake
+func (c *Client) connect(ctx context.Context) (*websocket.Conn, error) {
+	target, err := url.Parse(c.cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("client: invalid URL: %w", err)
+	}
+	token := c.Token()
+	if token != "" {
+		query := target.Query()
+		query.Set("token", token)
+		target.RawQuery = query.Encode()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.HandshakeTimeout)
+	defer cancel()
+	// Offer permessage-deflate; servers that do not compress ignore it
+	dialer := *websocket.DefaultDialer
+	dialer.EnableCompression = true
+	conn, _, err := dialer.DialContext(ctx, target.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to connect: %w", err)
+	}
+
+	if token == "" {
+		if err := writeMessage(conn, "set_name", setName{Name: c.cfg.Name}); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	deadline, _ := ctx.Deadline()
+	conn.SetReadDeadline(deadline)
+	for {
+		var msg Message
+		if err := conn.ReadJSON(&msg); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("client: handshake failed: %w", err)
+		}
+		switch msg.Type {
+		case "session_created", "session_restored":
+			var session Session
+			if err := json.Unmarshal(msg.Payload, &session); err != nil {
+				conn.Close()
+				return nil, fmt.Errorf("client: invalid %s: %w", msg.Type, err)
+			}
+			c.mu.Lock()
+			if session.Token == "" {
+				session.Token = c.session.Token
+			}
+			c.session = session
+			c.seat = nil
+			if session.TableID != nil && session.SeatIndex != nil {
+				c.seat = &SeatAssignment{TableID: *session.TableID, SeatIndex: *session.SeatIndex}
+			}
+			c.mu.Unlock()
+			conn.SetReadDeadline(time.Time{})
+			return conn, nil
+		case "error":
+			conn.Close()
+			return nil, decodeError(msg.Payload)
+		}
+	}
+}
+
+// run reads messages until the connection drops, then reconnects, until the client is closed
+// or the session can no longer be restored
+func (c *Client) run(conn *websocket.Conn) {
+	var cause error
+	defer func() {
+		c.mu.Lock()
+		c.closed = true
+		callbacks := c.handlers.closed
+		c.mu.Unlock()
+		for _, callback := range callbacks {
+			callback(cause)
+		}
+		close(c.done)
+	}()
+
+	for {
+		err := c.readLoop(conn)
+		if c.isClosed() {
+			cause = ErrClosed
+			return
+		}
+		c.logger.Warn("connection lost", "error", err)
+
+		conn, cause = c.reconnect()
+		if conn == nil {
+			return
+		}
+	}
+}
+
+// readLoop dispatches messages from conn until reading fails
+func (c *Client) readLoop(conn *websocket.Conn) error {
+	for {
+		var msg Message
+		if err := conn.ReadJSON(&msg); err != nil {
+			return err
+		}
+		c.dispatch(msg)
+	}
+}
+
+// reconnect restores the session on a new connection, backing off between attempts. Returns nil
+// and the reason when the client was closed or the server refused the session.
+func (c *Client) reconnect() (*websocket.Conn, error) {
+	delay := c.cfg.ReconnectDelay
+	for {
+		select {
+		case <-time.After(delay):
+		case <-c.stop:
+			return nil, ErrClosed
+		}
+
+		conn, err := c.connect(context.Background())
+		var serverErr *Error
+		if errors.As(err, &serverErr) {
+			c.logger.Error("session could not be restored", "error", err)
+			return nil, err
+		}
+		if err != nil {
+			c.logger.Warn("reconnection failed", "error", err, "retryIn", delay)
+			delay = min(delay*2, c.cfg.MaxReconnectDelay)
+			continue
+		}
+
+		c.mu.Lock()
+		if c.closed {
+			c.mu.Unlock()
+			conn.Close()
+			return nil, ErrClosed
+		}
+		c.conn = conn
+		pending := c.pendingAction
+		if c.seat == nil {
+			// The server let the seat go while the client was away
+			pending = nil
+			c.pendingAction = nil
+		}
+		callbacks := c.handlers.reconnect
+		c.mu.Unlock()
+
+		c.logger.Info("reconnected")
+		if pending != nil {
+			// The server answers a resent action ID without applying it twice
+			if err := c.write(pending.Type, pending.Payload); err != nil {
+				c.logger.Warn("failed to resend action", "error", err)
+			}
+		}
+		for _, callback := range callbacks {
+			callback()
+		}
+		return conn, nil
+	}
+}
+
+// dispatch updates the client's state from msg and calls the callbacks for it
+func (c *Client) dispatch(msg Message) {
+	c.mu.Lock()
+	h := c.handlers
+	c.mu.Unlock()
+
+	for _, callback := range h.message {
+		callback(msg)
+	}
+
+	switch msg.Type {
+	case "lobby_state":
+		tables, err := decodeLobby(msg.Payload)
+		if err != nil {
+			c.logger.Warn("invalid payload", "type", msg.Type, "error", err)
+			break
+		}
+		call(h.lobby, tables)
+	case "seat_assigned":
+		var seat SeatAssignment
+		if c.decode(msg, &seat) {
+			c.mu.Lock()
+			c.seat = &seat
+			c.mu.Unlock()
+			call(h.seatAssigned, seat)
+		}
+	case "seat_cleared":
+		c.mu.Lock()
+		c.seat = nil
+		c.pendingAction = nil
+		c.mu.Unlock()
+		for _, callback := range h.seatCleared {
+			callback()
+		}
+	case "table_state":
+		var state TableState
+		if c.decode(msg, &state) {
+			call(h.tableState, state)
+		}
+	case "hand_started":
+		var started HandStarted
+		if c.decode(msg, &started) {
+			call(h.handStarted, started)
+		}
+	case "cards_dealt":
+		var dealt CardsDealt
+		if c.decode(msg, &dealt) {
+			call(h.cardsDealt, dealt)
+		}
+	case "action_request":
+		var request ActionRequest
+		if c.decode(msg, &request) {
+			call(h.actionRequest, request)
+		}
+	case "action_result":
+		var result ActionResult
+		if c.decode(msg, &result) {
+			c.acknowledge(result.ActionID)
+			call(h.actionResult, result)
+		}
+	case "board_dealt":
+		var board BoardDealt
+		if c.decode(msg, &board) {
+			call(h.boardDealt, board)
+		}
+	case "showdown_reveal":
+		var reveal ShowdownReveal
+		if c.decode(msg, &reveal) {
+			call(h.reveal, reveal)
+		}
+	case "showdown_result":
+		var result HandResult
+		if c.decode(msg, &result) {
+			c.acknowledge("")
+			call(h.handResult, result)
+		}
+	case "session_summary":
+		var summary SessionSummary
+		if c.decode(msg, &summary) {
+			call(h.summary, summary)
+		}
+	case "club":
+		var club Club
+		if c.decode(msg, &club) {
+			call(h.club, club)
+		}
+	case "club_list":
+		var list struct {
+			Clubs []Club `json:"clubs"`
+		}
+		if c.decode(msg, &list) {
+			call(h.clubList, list.Clubs)
+		}
+	case "club_ledger":
+		var ledger ClubLedger
+		if c.decode(msg, &ledger) {
+			call(h.clubLedger, ledger)
+		}
+	case "club_settlement":
+		var settlement ClubSettlement
+		if c.decode(msg, &settlement) {
+			call(h.settlement, settlement)
+		}
+	case "replay_snippet":
+		var snippet ReplaySnippet
+		if c.decode(msg, &snippet) {
+			call(h.snippet, snippet)
+		}
+	case "preflop_hint":
+		var hint PreflopHint
+		if c.decode(msg, &hint) {
+			call(h.preflopHint, hint)
+		}
+	case "recovery_code":
+		var code recoveryCode
+		if c.decode(msg, &code) {
+			call(h.recoveryCode, code.Code)
+		}
+	case "confirm_raise":
+		var confirmation RaiseConfirmation
+		if c.decode(msg, &confirmation) {
+			call(h.confirmRaise, confirmation)
+		}
+	case "host_chat":
+		var chat HostChat
+		if c.decode(msg, &chat) {
+			call(h.hostChat, chat)
+		}
+	case "button_draw":
+		var draw ButtonDraw
+		if c.decode(msg, &draw) {
+			call(h.buttonDraw, draw)
+		}
+	case "rules_pending":
+		var rules TableRules
+		if c.decode(msg, &rules) {
+			call(h.rulesPending, rules)
+		}
+	case "error":
+		err := decodeError(msg.Payload)
+		for _, callback := range h.serverError {
+			callback(err)
+		}
+	}
+}
+
+// acknowledge forgets the pending action once the server answered it; an empty actionID
+// forgets it unconditionally (the hand is over)
+func (c *Client) acknowledge(actionID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.pendingAction == nil {
+		return
+	}
+	if actionID != "" {
+		var sent playerAction
+		if json.Unmarshal(c.pendingAction.Payload, &sent) != nil || sent.ActionID != actionID {
+			return
+		}
+	}
+	c.pendingAction = nil
+}
+
+// decode unmarshals the payload of msg into v, logging malformed payloads
+func (c *Client) decode(msg Message, v any) bool {
+	if err := json.Unmarshal(msg.Payload, v); err != nil {
+		c.logger.Warn("invalid payload", "type", msg.Type, "error", err)
+		return false
+	}
+	return true
+}
+
+// call runs each callback with value
+func call[T any](callbacks []func(T), value T) {
+	for _, callback := range callbacks {
+		callback(value)
+	}
+}
+
+// decodeError turns an error payload into an *Error
+func decodeError(payload json.RawMessage) *Error {
+	var serverErr Error
+	if err := json.Unmarshal(payload, &serverErr); err != nil {
+		return &Error{Message: string(payload)}
+	}
+	return &serverErr
+}
+
+// Token returns the session token, which Config.Token accepts to resume the session later
+func (c *Client) Token() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.session.Token
+}
+
+// Session returns the session as last sent by the server
+func (c *Client) Session() Session {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.session
+}
+
+// Seat returns the client's seat, false when it is not seated
+func (c *Client) Seat() (SeatAssignment, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.seat == nil {
+		return SeatAssignment{}, false
+	}
+	return *c.seat, true
+}
+
+// Done is closed once the client has stopped for good: closed, or its session refused on
+// reconnection
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Close disconnects and stops reconnecting. The session stays valid on the server.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	conn := c.conn
+	c.mu.Unlock()
+
+	close(c.stop)
+	conn.Close()
+	<-c.done
+	return nil
+}
+
+// isClosed reports whether Close was called
+func (c *Client) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+// JoinTable asks for a seat at tableID; OnSeatAssigned or OnError reports the outcome
+func (c *Client) JoinTable(tableID string) error {
+	return c.send("join_table", tablePayload{TableID: tableID})
+}
+
+// ReserveSeat holds a seat at tableID while the player chooses a stack; the server replies with
+// seat_reserved, giving the allowed range and when the hold expires
+func (c *Client) ReserveSeat(tableID string) error {
+	return c.send("reserve_seat", tablePayload{TableID: tableID})
+}
+
+// BuyIn buys amount chips for the reserved seat (0 buys the table's default) and takes it
+func (c *Client) BuyIn(amount int) error {
+	return c.send("buy_in", buyIn{Amount: amount})
+}
+
+// Challenge takes the open seat at the heads-up table tableID, or queues to play the winner of
+// the current match; the server replies with challenge_status
+func (c *Client) Challenge(tableID string) error {
+	return c.send("challenge", tablePayload{TableID: tableID})
+}
+
+// LeaveChallenge leaves the challenger queue
+func (c *Client) LeaveChallenge() error {
+	return c.send("leave_challenge", struct{}{})
+}
+
+// Rematch answers a rematch_offer after losing a heads-up match
+func (c *Client) Rematch(accept bool) error {
+	return c.send("rematch", rematch{Accept: accept})
+}
+
+// RespondToMerge answers a table_merge_offer to move the whole table to another of the same
+// stakes; the move happens only if every player accepts
+func (c *Client) RespondToMerge(accept bool) error {
+	return c.send("merge_response", mergeResponse{Accept: accept})
+}
+
+// TakeHostSeat takes the host seat at tableID, which the server lets only the table's listed
+// hosts do; the host is never dealt in. The server replies with host_seat.
+func (c *Client) TakeHostSeat(tableID string) error {
+	return c.send("take_host_seat", tablePayload{TableID: tableID})
+}
+
+// LeaveHostSeat gives up the host seat
+func (c *Client) LeaveHostSeat() error {
+	return c.send("leave_host_seat", struct{}{})
+}
+
+// HostChat sends text to the hosted table as a host_chat
+func (c *Client) HostChat(text string) error {
+	return c.send("host_chat", hostChat{Text: text})
+}
+
+// HostPause stops the hosted table dealing new hands after the current one, or resumes it
+func (c *Client) HostPause(paused bool) error {
+	return c.send("host_pause", hostPause{Paused: paused})
+}
+
+// CallBombPot makes the hosted table's next hand a bomb pot: everyone antes and the cards go
+// straight to the flop
+func (c *Client) CallBombPot() error {
+	return c.send("bomb_pot", struct{}{})
+}
+
+// CreateClub founds a club named name, owned by the client
+func (c *Client) CreateClub(name string) error {
+	return c.send("create_club", clubName{Name: name})
+}
+
+// JoinClub joins the club whose invite code is code
+func (c *Client) JoinClub(code string) error {
+	return c.send("join_club", clubInvite{InviteCode: code})
+}
+
+// LeaveClub leaves clubID; the server replies with club_left
+func (c *Client) LeaveClub(clubID string) error {
+	return c.send("leave_club", clubPayload{ClubID: clubID})
+}
+
+// ListClubs asks for the clubs the client belongs to, answered with club_list
+func (c *Client) ListClubs() error {
+	return c.send("list_clubs", struct{}{})
+}
+
+// RotateClubInvite replaces clubID's invite code; owners and managers only
+func (c *Client) RotateClubInvite(clubID string) error {
+	return c.send("rotate_club_invite", clubPayload{ClubID: clubID})
+}
+
+// SetClubRole makes name a manager or a member of clubID; the owner only
+func (c *Client) SetClubRole(clubID, name, role string) error {
+	return c.send("set_club_role", clubMember{ClubID: clubID, Name: name, Role: role})
+}
+
+// RemoveClubMember takes name out of clubID; owners and managers only
+func (c *Client) RemoveClubMember(clubID, name string) error {
+	return c.send("remove_club_member", clubMember{ClubID: clubID, Name: name})
+}
+
+// CreateClubTable opens a private table for clubID's members; owners and managers only
+func (c *Client) CreateClubTable(clubID, name string, smallBlind, bigBlind, buyIn int) error {
+	return c.send("create_club_table", clubTable{ClubID: clubID, Name: name, SmallBlind: smallBlind, BigBlind: bigBlind, BuyIn: buyIn})
+}
+
+// ClubLedger asks where clubID's players stand this period, answered with club_ledger; owners
+// and managers only
+func (c *Client) ClubLedger(clubID string) error {
+	return c.send("club_ledger", clubPayload{ClubID: clubID})
+}
+
+// SettleClub closes clubID's ledger period now, answered with club_settlement; the owner only
+func (c *Client) SettleClub(clubID string) error {
+	return c.send("settle_club", clubPayload{ClubID: clubID})
+}
+
+// LeaveTable gives up the client's seat
+func (c *Client) LeaveTable() error {
+	return c.send("leave_table", struct{}{})
+}
+
+// QuickSeat asks the server for a seat at any open table
+func (c *Client) QuickSeat() error {
+	return c.send("quick_seat", struct{}{})
+}
+
+// Watch observes tableID without taking a seat
+func (c *Client) Watch(tableID string) error {
+	return c.send("watch_table", tablePayload{TableID: tableID})
+}
+
+// Unwatch stops observing the watched table
+func (c *Client) Unwatch() error {
+	return c.send("unwatch_table", struct{}{})
+}
+
+// StartHand deals a hand at the client's table on servers that allow manual starts
+func (c *Client) StartHand() error {
+	return c.send("start_hand", struct{}{})
+}
+
+// ShowCards shows one or both hole cards after winning a hand uncontested, until the next hand
+// starts. The table receives a cards_shown message.
+func (c *Client) ShowCards(cards ...Card) error {
+	return c.send("show_cards", showCards{Cards: cards})
+}
+
+// SetAutoMuck sets whether the client's losing hands are mucked at showdown instead of shown
+func (c *Client) SetAutoMuck(enabled bool) error {
+	return c.send("set_auto_muck", autoMuck{AutoMuck: enabled})
+}
+
+// UseTimeBank adds the rest of the client's time bank to its clock on its turn
+func (c *Client) UseTimeBank() error {
+	return c.send("use_time_bank", struct{}{})
+}
+
+// SetAutoTimeBank sets whether the client's time bank engages by itself when its action clock
+// runs out, or only on UseTimeBank. The server keeps the choice with the player's account.
+func (c *Client) SetAutoTimeBank(enabled bool) error {
+	return c.send("set_auto_time_bank", autoTimeBank{AutoTimeBank: enabled})
+}
+
+// SetPrivacy sets whether the player's statistics are hidden from others, and whether their
+// hands are deleted once the operator's retention minimum has passed. The server keeps the
+// settings with the player's account.
+func (c *Client) SetPrivacy(hideStats, limitHistory bool) error {
+	return c.send("set_privacy", privacy{HideStats: hideStats, LimitHistory: limitHistory})
+}
+
+// RequestRecoveryCode asks for a new recovery code for the seat this session holds, which
+// replaces any earlier one and arrives through OnRecoveryCode. Keep it somewhere other than the
+// token: with it, RecoverSeat reclaims the seat from a new session if the token is lost.
+func (c *Client) RequestRecoveryCode() error {
+	return c.send("get_recovery_code", struct{}{})
+}
+
+// RecoverSeat moves the seat still kept for the player's lost session, under the same name, to
+// this one. code is the player's recovery code; the seat arrives through OnSeatAssigned.
+func (c *Client) RecoverSeat(code string) error {
+	return c.send("recover_seat", recoveryCode{Code: code})
+}
+
+// SetLanguage sets the language tag, such as "de" or "pt-BR", the server words narration text
+// in for the client; empty for English
+func (c *Client) SetLanguage(tag string) error {
+	return c.send("set_language", language{Language: tag})
+}
+
+// SetPreflopHints sets whether the client gets preflop_hint messages on its turn at practice
+// tables. Hints come from a simple chart for training and are never sent at other tables.
+func (c *Client) SetPreflopHints(enabled bool) error {
+	return c.send("set_preflop_hints", preflopHints{Enabled: enabled})
+}
+
+// Emote sends a predefined emote to the client's table, aimed at targetSeat if it is not nil.
+// Throwables (tomato, egg, rose, beer) need a target. The table receives an emote message.
+func (c *Client) Emote(emote string, targetSeat *int) error {
+	return c.send("emote", emotePayload{Emote: emote, TargetSeat: targetSeat})
+}
+
+// Mute hides name's emotes from this account until Unmute; the server replies with mute_list
+func (c *Client) Mute(name string) error {
+	return c.send("mute_player", mutePlayer{Name: name})
+}
+
+// Unmute shows name's emotes again
+func (c *Client) Unmute(name string) error {
+	return c.send("unmute_player", mutePlayer{Name: name})
+}
+
+// Act plays action (fold, check, call or raise) for the client's seat. amount is the total to
+// raise to and is ignored by the other actions. Returns the action ID the action_result will
+// carry.
+func (c *Client) Act(action string, amount int) (string, error) {
+	c.mu.Lock()
+	if c.seat == nil {
+		c.mu.Unlock()
+		return "", ErrNotSeated
+	}
+	c.actionCount++
+	payload := playerAction{
+		ActionID:  fmt.Sprintf("%s-%d", c.actionPrefix, c.actionCount),
+		SeatIndex: c.seat.SeatIndex,
+		Action:    action,
+	}
+	if action == "raise" {
+		payload.Amount = &amount
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		c.mu.Unlock()
+		return "", err
+	}
+	c.pendingAction = &Message{Type: "player_action", Payload: data}
+	c.mu.Unlock()
+
+	if err := c.write("player_action", data); err != nil {
+		// Kept pending: it is sent again once the connection is back
+		c.logger.Warn("action not sent, retrying after reconnecting", "error", err)
+	}
+	return payload.ActionID, nil
+}
+
+// ConfirmRaise plays the large raise the server held with confirm_raise. It must be sent before
+// the confirmation expires, two seconds after the raise; otherwise the raise is refused.
+func (c *Client) ConfirmRaise(confirmation RaiseConfirmation) error {
+	c.mu.Lock()
+	if c.seat == nil {
+		c.mu.Unlock()
+		return ErrNotSeated
+	}
+	amount := confirmation.Amount
+	data, err := json.Marshal(playerAction{
+		ActionID:  confirmation.ActionID,
+		SeatIndex: c.seat.SeatIndex,
+		Action:    "raise",
+		Amount:    &amount,
+		Confirm:   true,
+	})
+	if err != nil {
+		c.mu.Unlock()
+		return err
+	}
+	c.pendingAction = &Message{Type: "player_action", Payload: data}
+	c.mu.Unlock()
+
+	return c.write("player_action", data)
+}
+
+// Send sends a message of any type, for protocol messages without a typed method
+func (c *Client) Send(msgType string, payload any) error {
+	return c.send(msgType, payload)
+}
+
+// send marshals payload and writes it to the current connection
+func (c *Client) send(msgType string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return c.write(msgType, data)
+}
+
+// write sends a message with an encoded payload on the current connection
+func (c *Client) write(msgType string, payload json.RawMessage) error {
+	c.mu.Lock()
+	conn, closed := c.conn, c.closed
+	c.mu.Unlock()
+	if closed {
+		return ErrClosed
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return conn.WriteJSON(Message{Type: msgType, Payload: payload})
+}
+
+// writeMessage sends a message on conn during the handshake
+func writeMessage(conn *websocket.Conn, msgType string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return conn.WriteJSON(Message{Type: msgType, Payload: data})
+}
+
+// OnMessage registers f for every message received, before any typed callback
+func (c *Client) OnMessage(f func(Message)) {
+	c.register(func(h *handlers) { h.message = append(h.message, f) })
+}
+
+// OnLobby registers f for lobby_state
+func (c *Client) OnLobby(f func([]TableInfo)) {
+	c.register(func(h *handlers) { h.lobby = append(h.lobby, f) })
+}
+
+// OnSeatAssigned registers f for seat_assigned
+func (c *Client) OnSeatAssigned(f func(SeatAssignment)) {
+	c.register(func(h *handlers) { h.seatAssigned = append(h.seatAssigned, f) })
+}
+
+// OnSeatCleared registers f for seat_cleared, when the client lost or gave up its seat
+func (c *Client) OnSeatCleared(f func()) {
+	c.register(func(h *handlers) { h.seatCleared = append(h.seatCleared, f) })
+}
+
+// OnTableState registers f for table_state
+func (c *Client) OnTableState(f func(TableState)) {
+	c.register(func(h *handlers) { h.tableState = append(h.tableState, f) })
+}
+
+// OnHandStarted registers f for hand_started
+func (c *Client) OnHandStarted(f func(HandStarted)) {
+	c.register(func(h *handlers) { h.handStarted = append(h.handStarted, f) })
+}
+
+// OnCardsDealt registers f for cards_dealt
+func (c *Client) OnCardsDealt(f func(CardsDealt)) {
+	c.register(func(h *handlers) { h.cardsDealt = append(h.cardsDealt, f) })
+}
+
+// OnActionRequest registers f for action_request, sent to everyone at the table: compare
+// SeatIndex with Seat to know whether it is the client's turn
+func (c *Client) OnActionRequest(f func(ActionRequest)) {
+	c.register(func(h *handlers) { h.actionRequest = append(h.actionRequest, f) })
+}
+
+// OnActionResult registers f for action_result
+func (c *Client) OnActionResult(f func(ActionResult)) {
+	c.register(func(h *handlers) { h.actionResult = append(h.actionResult, f) })
+}
+
+// OnBoardDealt registers f for board_dealt
+func (c *Client) OnBoardDealt(f func(BoardDealt)) {
+	c.register(func(h *handlers) { h.boardDealt = append(h.boardDealt, f) })
+}
+
+// OnShowdownReveal registers f for showdown_reveal, sent for each player at showdown in the
+// order they show or muck, just before showdown_result
+func (c *Client) OnShowdownReveal(f func(ShowdownReveal)) {
+	c.register(func(h *handlers) { h.reveal = append(h.reveal, f) })
+}
+
+// OnHandResult registers f for showdown_result, sent when a hand is won
+func (c *Client) OnHandResult(f func(HandResult)) {
+	c.register(func(h *handlers) { h.handResult = append(h.handResult, f) })
+}
+
+// OnSessionSummary registers f for session_summary, sent when the player leaves a table
+func (c *Client) OnSessionSummary(f func(SessionSummary)) {
+	c.register(func(h *handlers) { h.summary = append(h.summary, f) })
+}
+
+// OnClub registers f for club, the server's answer to the club messages: the club as it now is
+func (c *Client) OnClub(f func(Club)) {
+	c.register(func(h *handlers) { h.club = append(h.club, f) })
+}
+
+// OnClubList registers f for club_list, the answer to ListClubs
+func (c *Client) OnClubList(f func([]Club)) {
+	c.register(func(h *handlers) { h.clubList = append(h.clubList, f) })
+}
+
+// OnClubLedger registers f for club_ledger, the answer to ClubLedger
+func (c *Client) OnClubLedger(f func(ClubLedger)) {
+	c.register(func(h *handlers) { h.clubLedger = append(h.clubLedger, f) })
+}
+
+// OnClubSettlement registers f for club_settlement, the settle-up reports sent to a club's owner
+func (c *Client) OnClubSettlement(f func(ClubSettlement)) {
+	c.register(func(h *handlers) { h.settlement = append(h.settlement, f) })
+}
+
+// OnReplaySnippet registers f for replay_snippet, sent when a hand at the table gets a
+// shareable replay
+func (c *Client) OnReplaySnippet(f func(ReplaySnippet)) {
+	c.register(func(h *handlers) { h.snippet = append(h.snippet, f) })
+}
+
+// OnPreflopHint registers f for preflop_hint, sent on the client's turn preflop at a practice
+// table once SetPreflopHints turned hints on
+func (c *Client) OnPreflopHint(f func(PreflopHint)) {
+	c.register(func(h *handlers) { h.preflopHint = append(h.preflopHint, f) })
+}
+
+// OnRecoveryCode registers f for recovery_code, the reply to RequestRecoveryCode
+func (c *Client) OnRecoveryCode(f func(code string)) {
+	c.register(func(h *handlers) { h.recoveryCode = append(h.recoveryCode, f) })
+}
+
+// OnConfirmRaise registers f for confirm_raise, sent instead of playing a raise that puts in
+// more of the stack than the table allows without confirmation (see ConfirmRaise)
+func (c *Client) OnConfirmRaise(f func(RaiseConfirmation)) {
+	c.register(func(h *handlers) { h.confirmRaise = append(h.confirmRaise, f) })
+}
+
+// OnHostChat registers f for host_chat, sent when the table's host messages the table
+func (c *Client) OnHostChat(f func(HostChat)) {
+	c.register(func(h *handlers) { h.hostChat = append(h.hostChat, f) })
+}
+
+// OnButtonDraw registers f for button_draw, sent before a new table's first hand when the
+// button is drawn for
+func (c *Client) OnButtonDraw(f func(ButtonDraw)) {
+	c.register(func(h *handlers) { h.buttonDraw = append(h.buttonDraw, f) })
+}
+
+// OnRulesPending registers f for rules_pending, sent when the blinds or timers change during a
+// hand: the rules the table's next hand is dealt under
+func (c *Client) OnRulesPending(f func(TableRules)) {
+	c.register(func(h *handlers) { h.rulesPending = append(h.rulesPending, f) })
+}
+
+// OnError registers f for error messages from the server
+func (c *Client) OnError(f func(*Error)) {
+	c.register(func(h *handlers) { h.serverError = append(h.serverError, f) })
+}
+
+// OnReconnect registers f for after the session was restored on a new connection
+func (c *Client) OnReconnect(f func()) {
+	c.register(func(h *handlers) { h.reconnect = append(h.reconnect, f) })
+}
+
+// OnClose registers f for when the client stops for good, with the reason (ErrClosed after Close)
+func (c *Client) OnClose(f func(error)) {
+	c.register(func(h *handlers) { h.closed = append(h.closed, f) })
+}
+
+// register adds a callback under the lock; dispatch works on a copy of the handlers, so
+// callbacks can be registered from inside a callback
+func (c *Client) register(add func(*handlers)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	add(&c.handlers)
+}
@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/robinr2/poker/internal/server"
+)
+
+// startServer runs a poker server with the zero Config for the test
+func startServer(t *testing.T) string {
+	t.Helper()
+	srv := server.NewServer(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	httpServer := httptest.NewServer(srv.Router())
+	t.Cleanup(httpServer.Close)
+	return "ws" + strings.TrimPrefix(httpServer.URL, "http") + "/ws"
+}
+
+// dial connects a client named name, closed when the test ends
+func dial(t *testing.T, cfg Config) *Client {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	c, err := Dial(ctx, cfg)
+	if err != nil {
+		t.Fatalf("dial %s: %v", cfg.Name, err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+// wait receives from ch or fails the test after a few seconds
+func wait[T any](t *testing.T, ch <-chan T, what string) T {
+	t.Helper()
+	select {
+	case value := <-ch:
+		return value
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for %s", what)
+		var zero T
+		return zero
+	}
+}
+
+// TestClient_PlaysHand seats two bots that check or call down and verifies both see the result
+func TestClient_PlaysHand(t *testing.T) {
+	url := startServer(t)
+
+	results := make(chan HandResult, 2)
+	var bots []*Client
+	for _, name := range []string{"Alice", "Bob"} {
+		bot := dial(t, Config{URL: url, Name: name})
+		if bot.Token() == "" || bot.Session().Name != name {
+			t.Fatalf("expected a session for %s, got %+v", name, bot.Session())
+		}
+		seated := make(chan SeatAssignment, 1)
+		bot.OnSeatAssigned(func(seat SeatAssignment) { seated <- seat })
+		bot.OnActionRequest(func(request ActionRequest) {
+			if seat, ok := bot.Seat(); !ok || seat.SeatIndex != request.SeatIndex {
+				return
+			}
+			action := "call"
+			if request.Can("check") {
+				action = "check"
+			}
+			if _, err := bot.Act(action, 0); err != nil {
+				t.Errorf("%s: %v", name, err)
+			}
+		})
+		bot.OnHandResult(func(result HandResult) { results <- result })
+		bot.OnError(func(err *Error) { t.Errorf("%s got an error: %v", name, err) })
+
+		if err := bot.JoinTable("table-1"); err != nil {
+			t.Fatal(err)
+		}
+		if seat := wait(t, seated, name+"'s seat"); seat.TableID != "table-1" {
+			t.Fatalf("expected a seat at table-1, got %+v", seat)
+		}
+		bots = append(bots, bot)
+	}
+
+	if err := bots[0].StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	for range bots {
+		result := wait(t, results, "the hand result")
+		if result.PotAmount != 40 || len(result.WinnerSeats) == 0 {
+			t.Errorf("expected a 40 chip pot to be won, got %+v", result)
+		}
+	}
+}
+
+// TestClient_Reconnects verifies a dropped connection is replaced with the same session
+func TestClient_Reconnects(t *testing.T) {
+	url := startServer(t)
+	bot := dial(t, Config{URL: url, Name: "Carol", ReconnectDelay: 10 * time.Millisecond})
+	token := bot.Token()
+
+	reconnected := make(chan struct{}, 1)
+	bot.OnReconnect(func() { reconnected <- struct{}{} })
+	lobby := make(chan []TableInfo, 4)
+	bot.OnLobby(func(tables []TableInfo) { lobby <- tables })
+
+	bot.mu.Lock()
+	conn := bot.conn
+	bot.mu.Unlock()
+	conn.Close()
+
+	wait(t, reconnected, "the reconnection")
+	if bot.Token() != token {
+		t.Errorf("expected the session %s to be restored, got %s", token, bot.Token())
+	}
+	if tables := wait(t, lobby, "the lobby after reconnecting"); len(tables) == 0 {
+		t.Error("expected tables in the lobby")
+	}
+
+	closed := make(chan error, 1)
+	bot.OnClose(func(err error) { closed <- err })
+	bot.Close()
+	if err := wait(t, closed, "the close callback"); err != ErrClosed {
+		t.Errorf("expected ErrClosed, got %v", err)
+	}
+	if err := bot.JoinTable("table-1"); err != ErrClosed {
+		t.Errorf("expected ErrClosed sending after Close, got %v", err)
+	}
+}
+
+// TestClient_RefusedSession verifies Dial reports the server's error for an unknown token
+func TestClient_RefusedSession(t *testing.T) {
+	url := startServer(t)
+	_, err := Dial(context.Background(), Config{URL: url, Token: "no-such-session"})
+	serverErr, ok := err.(*Error)
+	if !ok || serverErr.Key != "error.invalid_token" {
+		t.Errorf("expected error.invalid_token, got %v", err)
+	}
+}
@@ -0,0 +1,465 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Message is one message of the WebSocket protocol, in either direction
+type Message struct {
+	Type       string          `json:"type"`
+	Payload    json.RawMessage `json:"payload"`
+	ServerTime int64           `json:"serverTime,omitempty"` // Unix ms when the server sent it
+}
+
+// Error is an error message from the server. Key and Params identify it for localization;
+// Message is the English text.
+type Error struct {
+	Message string         `json:"message"`
+	Key     string         `json:"key"`
+	Params  map[string]any `json:"params,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if e.Key == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s (%s)", e.Message, e.Key)
+}
+
+// Session is the session the client plays under, from session_created or session_restored
+type Session struct {
+	Token     string         `json:"token,omitempty"` // Only sent in session_created; kept by the client after that
+	Name      string         `json:"name"`
+	TableID   *string        `json:"tableID,omitempty"`
+	SeatIndex *int           `json:"seatIndex,omitempty"`
+	ExpiresAt int64          `json:"expiresAt,omitempty"` // Unix ms when the session lapses unless renewed
+	Balances  map[string]int `json:"balances,omitempty"`  // Chips held off the tables, per currency
+}
+
+// Card is a playing card: Rank is one of 23456789TJQKA, Suit one of shdc
+type Card struct {
+	Rank string `json:"Rank"`
+	Suit string `json:"Suit"`
+}
+
+func (c Card) String() string {
+	return c.Rank + c.Suit
+}
+
+// TableInfo describes a table in the lobby
+type TableInfo struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	SeatsOccupied int      `json:"seats_occupied"`
+	MaxSeats      int      `json:"max_seats"`
+	SmallBlind    int      `json:"small_blind"`
+	BigBlind      int      `json:"big_blind"`
+	BuyIn         int      `json:"buy_in"`
+	Currency      string   `json:"currency"`
+	Description   string   `json:"description,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+	GameType      string   `json:"game_type"`
+	Speed         string   `json:"speed"`
+	Pot           int      `json:"pot"`
+	Observers     int      `json:"observers"`
+	Frozen        bool     `json:"frozen,omitempty"`
+	Closing       bool     `json:"closing,omitempty"` // No new players; the table closes once empty
+	HeadsUp       bool     `json:"heads_up,omitempty"`
+	Challengers   int      `json:"challengers,omitempty"`
+	Host          string   `json:"host,omitempty"`      // Player in the host seat
+	ClubID        string   `json:"club_id,omitempty"`   // Private table of this club
+	Practice      bool     `json:"practice,omitempty"`  // Training table where preflop hints are available
+	Anonymous     bool     `json:"anonymous,omitempty"` // Players are shown under per-table aliases
+	// ConfirmRaisePercent is the share of the stack above which raises must be confirmed
+	ConfirmRaisePercent int `json:"confirm_raise_percent,omitempty"`
+}
+
+// SeatAssignment is the seat the server gave the client, from seat_assigned
+type SeatAssignment struct {
+	TableID   string `json:"tableId"`
+	SeatIndex int    `json:"seatIndex"`
+	Status    string `json:"status"`
+}
+
+// HandStarted announces a new hand, from hand_started
+type HandStarted struct {
+	HandID         string `json:"handId"` // Replayable from /api/replays/{handId} once the hand ends
+	DealerSeat     int    `json:"dealerSeat"`
+	SmallBlindSeat int    `json:"smallBlindSeat"`
+	BigBlindSeat   int    `json:"bigBlindSeat"`
+	SeedCommitment string `json:"seedCommitment,omitempty"`
+	BombPot        bool   `json:"bombPot,omitempty"` // Everyone anted and the flop comes next
+}
+
+// ButtonDraw is the draw for the button before a new table's first hand, from button_draw: each
+// active seat's card, dealt face up, and the seat whose card won
+type ButtonDraw struct {
+	Cards          []ButtonDrawCard `json:"cards"` // In seat order
+	DealerSeat     int              `json:"dealerSeat"`
+	SeedCommitment string           `json:"seedCommitment"`
+}
+
+// ButtonDrawCard is the card one seat was dealt in a ButtonDraw
+type ButtonDrawCard struct {
+	SeatIndex int  `json:"seatIndex"`
+	Card      Card `json:"card"`
+}
+
+// CardsDealt carries the hole cards the client may see, from cards_dealt
+type CardsDealt struct {
+	HoleCards map[int][]Card `json:"holeCards"`
+}
+
+// ActionRequest asks a seat to act, from action_request. Raise amounts are the total the seat
+// raises to, between MinRaise and MaxRaise; both are 0 when the seat cannot raise. CallAmount is
+// what a call puts in, already capped at the seat's stack.
+type ActionRequest struct {
+	SeatIndex    int      `json:"seatIndex"`
+	ValidActions []string `json:"validActions"`
+	CallAmount   int      `json:"callAmount"`
+	CurrentBet   int      `json:"currentBet"`
+	PlayerBet    int      `json:"playerBet"`
+	Pot          int      `json:"pot"`
+	MinRaise     int      `json:"minRaise"`
+	MaxRaise     int      `json:"maxRaise"`
+	RaiseStep    int      `json:"raiseStep"`            // Raises go up in this step from MinRaise; MaxRaise is always allowed
+	Deadline     int64    `json:"deadline,omitempty"`   // Unix ms when the actor's clock runs out
+	TimeBankMs   int64    `json:"timeBankMs,omitempty"` // Time bank left beyond Deadline
+}
+
+// Can reports whether action is one of the valid actions
+func (r ActionRequest) Can(action string) bool {
+	for _, valid := range r.ValidActions {
+		if valid == action {
+			return true
+		}
+	}
+	return false
+}
+
+// ActionResult reports an action taken at the table, from action_result
+type ActionResult struct {
+	ActionID    string `json:"actionId,omitempty"`
+	SeatIndex   int    `json:"seatIndex"`
+	Action      string `json:"action"`
+	AmountActed int    `json:"amountActed"`
+	NewStack    int    `json:"newStack"`
+	Pot         int    `json:"pot"`
+	NextActor   *int   `json:"nextActor,omitempty"`
+	RoundOver   bool   `json:"roundOver,omitempty"`
+	RoundWinner *int   `json:"roundWinner,omitempty"`
+}
+
+// BoardDealt carries the board after a street is dealt, from board_dealt
+type BoardDealt struct {
+	BoardCards []Card       `json:"boardCards"`
+	Street     string       `json:"street"`
+	Texture    BoardTexture `json:"texture"`
+}
+
+// BoardTexture describes how coordinated the board is; Wetness runs from 0 (dry) to 6 (very wet)
+type BoardTexture struct {
+	Paired        bool `json:"paired"`
+	Monotone      bool `json:"monotone"`
+	TwoTone       bool `json:"twoTone"` // A flush draw is possible
+	FlushPossible bool `json:"flushPossible"`
+	Connected     bool `json:"connected"` // A straight is possible
+	Wetness       int  `json:"wetness"`
+}
+
+// ShowdownReveal is one player's hand turned over, or mucked, at showdown, from showdown_reveal
+type ShowdownReveal struct {
+	HandID    string `json:"handId"`
+	SeatIndex int    `json:"seatIndex"`
+	Order     int    `json:"order"` // 0 reveals first
+	Cards     []Card `json:"cards,omitempty"`
+	Hand      string `json:"hand,omitempty"` // Hand rank ID, like "straight"
+	Mucked    bool   `json:"mucked,omitempty"`
+	RevealAt  int64  `json:"revealAt"` // Unix ms; when to turn the hand over
+}
+
+// HandResult is how a hand was won, from showdown_result
+type HandResult struct {
+	WinnerSeats []int       `json:"winnerSeats"`
+	WinningHand string      `json:"winningHand"` // Empty when everyone else folded
+	PotAmount   int         `json:"potAmount"`
+	AmountsWon  map[int]int `json:"amountsWon"`
+	Rake        int         `json:"rake,omitempty"`
+}
+
+// SessionSummary sums up a stay at a table, from session_summary
+type SessionSummary struct {
+	TableID     string `json:"tableId"`
+	SeatedAt    int64  `json:"seatedAt"` // Unix ms
+	LeftAt      int64  `json:"leftAt"`   // Unix ms
+	DurationMs  int64  `json:"durationMs"`
+	HandsPlayed int    `json:"handsPlayed"`
+	NetChips    int    `json:"netChips"` // Negative when down
+	BiggestPot  int    `json:"biggestPot"`
+}
+
+// ClubMember is a member of a Club
+type ClubMember struct {
+	Name string `json:"name"`
+	Role string `json:"role"` // owner, manager or member
+}
+
+// Club is a club the player belongs to, from club and club_list
+type Club struct {
+	ID         string       `json:"id"`
+	Name       string       `json:"name"`
+	Role       string       `json:"role"`                 // The player's own role
+	InviteCode string       `json:"inviteCode,omitempty"` // Shown to the owner and managers
+	Members    []ClubMember `json:"members"`
+	Tables     []TableInfo  `json:"tables"`
+}
+
+// ClubPosition is one player's net chips over a club's ledger period
+type ClubPosition struct {
+	Name string `json:"name"`
+	Net  int    `json:"net"`
+}
+
+// ClubTransfer is one payment that squares a settle-up report
+type ClubTransfer struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Amount int    `json:"amount"`
+}
+
+// ClubSettlement is a settle-up report closing a club's ledger period, from club_settlement
+type ClubSettlement struct {
+	ClubID    string         `json:"clubId"`
+	From      time.Time      `json:"from"`
+	To        time.Time      `json:"to"`
+	Positions []ClubPosition `json:"positions"` // Biggest winner first
+	Transfers []ClubTransfer `json:"transfers"`
+}
+
+// ClubLedger is where a club's players stand this period, from club_ledger
+type ClubLedger struct {
+	ClubID      string           `json:"clubId"`
+	Since       time.Time        `json:"since"`
+	Positions   []ClubPosition   `json:"positions"`             // Stays that have ended
+	Seated      []ClubPosition   `json:"seated"`                // Players seated now, for this stay
+	Settlements []ClubSettlement `json:"settlements,omitempty"` // Past reports, newest first; owner only
+}
+
+// ReplaySnippet announces a shareable replay of a dramatic all-in at the table, from
+// replay_snippet
+type ReplaySnippet struct {
+	HandID  string  `json:"handId"`
+	TableID string  `json:"tableId"`
+	URL     string  `json:"url"` // Path of the snippet on the server
+	Swing   float64 `json:"swing"`
+}
+
+// PreflopHint is a training hint from the practice table's preflop chart, from preflop_hint
+type PreflopHint struct {
+	TableID      string `json:"tableId"`
+	HandID       string `json:"handId"`
+	Position     string `json:"position"` // UTG, HJ, CO, BTN, SB or BB
+	Hand         string `json:"hand"`     // Chart entry of the hole cards, like "AKs" or "77"
+	Facing       string `json:"facing"`   // "unopened" or "raise"
+	Action       string `json:"action"`   // fold, check, call or raise
+	TrainingOnly bool   `json:"trainingOnly"`
+}
+
+// RaiseConfirmation asks the player to confirm a large raise, from confirm_raise. The raise is
+// only played once ConfirmRaise answers it before ExpiresAt.
+type RaiseConfirmation struct {
+	ActionID  string `json:"actionId"`
+	TableID   string `json:"tableId"`
+	SeatIndex int    `json:"seatIndex"`
+	Amount    int    `json:"amount"` // Total raised to
+	Chips     int    `json:"chips"`  // Chips the raise puts in
+	Stack     int    `json:"stack"`
+	ExpiresAt int64  `json:"expiresAt"` // Unix ms
+}
+
+// HostChat is a message from the table's host, from host_chat
+type HostChat struct {
+	Host string `json:"host"`
+	Text string `json:"text"`
+}
+
+// TableSeat is one seat in a TableState
+type TableSeat struct {
+	Index      int           `json:"index"`
+	PlayerName *string       `json:"playerName"`
+	Status     string        `json:"status"`
+	Stack      *int          `json:"stack"`
+	Bet        int           `json:"bet,omitempty"`
+	Metrics    *StackMetrics `json:"metrics,omitempty"`
+	Connection string        `json:"connection,omitempty"` // connected, disconnected or reconnecting
+}
+
+// StackMetrics are a seat's stack figures as computed by the server
+type StackMetrics struct {
+	BigBlinds      float64 `json:"bigBlinds"`
+	M              float64 `json:"m"`
+	EffectiveStack *int    `json:"effectiveStack,omitempty"` // Against the player who made the bet being faced
+}
+
+// BlindLevel is the forced bets a table deals its hands with
+type BlindLevel struct {
+	SmallBlind int `json:"smallBlind"`
+	BigBlind   int `json:"bigBlind"`
+	Ante       int `json:"ante"`
+}
+
+// TableState is the whole state of a table as the client may see it, from table_state
+type TableState struct {
+	TableID        string         `json:"tableId"`
+	Blinds         BlindLevel     `json:"blinds"`
+	Seats          []TableSeat    `json:"seats"`
+	HandInProgress bool           `json:"handInProgress"`
+	DealerSeat     *int           `json:"dealerSeat,omitempty"`
+	Pot            *int           `json:"pot,omitempty"`
+	HoleCards      map[int][]Card `json:"holeCards,omitempty"`
+	CurrentActor   *int           `json:"currentActor,omitempty"`
+	ActionDeadline *int64         `json:"actionDeadline,omitempty"`
+	Frozen         bool           `json:"frozen,omitempty"`
+	Closing        bool           `json:"closing,omitempty"`
+	NextHandAt     *int64         `json:"nextHandAt,omitempty"`
+	// WaitingForPlayers is set while the table waits for a second player to deal again
+	WaitingForPlayers bool `json:"waitingForPlayers,omitempty"`
+	// Host is the player in the host seat; HostPaused is set while they have dealing paused and
+	// BombPotNext once they have called a bomb pot for the next hand
+	Host        string `json:"host,omitempty"`
+	HostPaused  bool   `json:"hostPaused,omitempty"`
+	BombPotNext bool   `json:"bombPotNext,omitempty"`
+	// PendingRules are the rules the next hand is dealt under, when they changed during this one
+	PendingRules *TableRules `json:"pendingRules,omitempty"`
+}
+
+// TableRules are the stakes and timers a table's hands are played under, from rules_pending
+type TableRules struct {
+	TableID         string     `json:"tableId,omitempty"` // Set in rules_pending only
+	Blinds          BlindLevel `json:"blinds"`
+	ActionTimeoutMs int64      `json:"actionTimeoutMs"` // 0 = no action clock
+	TimeBankMs      int64      `json:"timeBankMs"`      // 0 = no time bank
+	RakePercent     float64    `json:"rakePercent"`
+	RakeCap         int        `json:"rakeCap"`
+}
+
+// tablePayload, setName, playerAction, showCards, emotePayload, mutePlayer, buyIn, rematch,
+// mergeResponse, autoMuck, autoTimeBank, privacy, recoveryCode, language, hostChat, hostPause,
+// clubName, clubInvite, clubPayload, clubMember and clubTable are the payloads of the messages the
+// client sends
+type tablePayload struct {
+	TableID string `json:"tableId"`
+}
+
+type setName struct {
+	Name string `json:"name"`
+}
+
+type showCards struct {
+	Cards []Card `json:"cards"`
+}
+
+type emotePayload struct {
+	Emote      string `json:"emote"`
+	TargetSeat *int   `json:"targetSeat,omitempty"`
+}
+
+type mutePlayer struct {
+	Name string `json:"name"`
+}
+
+type buyIn struct {
+	Amount int `json:"amount"`
+}
+
+type rematch struct {
+	Accept bool `json:"accept"`
+}
+
+type mergeResponse struct {
+	Accept bool `json:"accept"`
+}
+
+type autoTimeBank struct {
+	AutoTimeBank bool `json:"autoTimeBank"`
+}
+
+type privacy struct {
+	HideStats    bool `json:"hideStats"`
+	LimitHistory bool `json:"limitHistory"`
+}
+
+type autoMuck struct {
+	AutoMuck bool `json:"autoMuck"`
+}
+
+// recoveryCode is also the payload of recovery_code
+type recoveryCode struct {
+	Code string `json:"code"`
+}
+
+type language struct {
+	Language string `json:"language"`
+}
+
+type preflopHints struct {
+	Enabled bool `json:"enabled"`
+}
+
+type hostChat struct {
+	Text string `json:"text"`
+}
+
+type hostPause struct {
+	Paused bool `json:"paused"`
+}
+
+type clubName struct {
+	Name string `json:"name"`
+}
+
+type clubInvite struct {
+	InviteCode string `json:"inviteCode"`
+}
+
+type clubPayload struct {
+	ClubID string `json:"clubId"`
+}
+
+type clubMember struct {
+	ClubID string `json:"clubId"`
+	Name   string `json:"name"`
+	Role   string `json:"role,omitempty"`
+}
+
+type clubTable struct {
+	ClubID     string `json:"clubId"`
+	Name       string `json:"name"`
+	SmallBlind int    `json:"smallBlind"`
+	BigBlind   int    `json:"bigBlind"`
+	BuyIn      int    `json:"buyIn"`
+}
+
+type playerAction struct {
+	ActionID  string `json:"actionId"`
+	SeatIndex int    `json:"seatIndex"`
+	Action    string `json:"action"`
+	Amount    *int   `json:"amount,omitempty"`
+	Confirm   bool   `json:"confirm,omitempty"`
+}
+
+// decodeLobby decodes a lobby_state payload, which the server sends either as an array or as
+// the array encoded in a JSON string
+func decodeLobby(payload json.RawMessage) ([]TableInfo, error) {
+	var encoded string
+	if err := json.Unmarshal(payload, &encoded); err == nil {
+		payload = json.RawMessage(encoded)
+	}
+	var tables []TableInfo
+	if err := json.Unmarshal(payload, &tables); err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
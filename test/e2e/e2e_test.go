@@ -0,0 +1,443 @@
+//go:build e2e
+
+// Package e2e plays hundreds of hands between SDK bots on a real server and checks the books
+// balance at the end: the top-level safety net for engine refactors.
+//
+// By default the test builds cmd/server and runs it with config.yaml on a free port:
+//
+//	go test -tags e2e ./test/e2e
+//
+// With E2E_SERVER_URL set it plays against that server instead, as docker-compose.e2e.yml does.
+// E2E_HANDS sets the hands each table must finish (100 by default).
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/robinr2/poker/pkg/client"
+)
+
+// Must match config.yaml
+const (
+	adminToken        = "e2e-secret"
+	startingPlayChips = 100000
+	rakeCap           = 30
+	botsPerTable      = 4
+)
+
+// tableIDs are the tables of config.yaml, one per variant
+var tableIDs = []string{"table-1", "table-2", "table-3", "table-4"}
+
+// currencySummary is one entry of GET /admin/currencies
+type currencySummary struct {
+	Currency      string `json:"currency"`
+	ChipsOnTables int    `json:"chipsOnTables"`
+	Balances      int    `json:"balances"`
+	RakeCollected int    `json:"rakeCollected"`
+}
+
+// handRecord is a finished hand as one bot at its table saw it, every pot's hand_result added up
+type handRecord struct {
+	tableID string
+	pot     int // Paid out to the winners
+	rake    int
+}
+
+// ledger collects what the bots observed, shared by all of them
+type ledger struct {
+	mu       sync.Mutex
+	hands    map[string]map[string]handRecord // By hand ID, then bot name
+	started  map[string]string                // Table of every hand a bot saw start
+	perTable map[string]int                   // Finished hands per table
+	problems []string
+}
+
+// record adds a pot's hand_result to a bot's view of a finished hand, noting any broken invariant
+func (l *ledger) record(bot, handID, tableID string, result client.HandResult) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	won := 0
+	for _, amount := range result.AmountsWon {
+		won += amount
+	}
+	if won != result.PotAmount {
+		l.problems = append(l.problems, fmt.Sprintf("hand %s: %d won is not the %d paid out", handID, won, result.PotAmount))
+	}
+	if result.Rake < 0 || result.Rake > rakeCap {
+		l.problems = append(l.problems, fmt.Sprintf("hand %s: rake %d outside 0..%d", handID, result.Rake, rakeCap))
+	}
+	views, ok := l.hands[handID]
+	if !ok {
+		views = make(map[string]handRecord)
+		l.hands[handID] = views
+		l.perTable[tableID]++
+	}
+	view := views[bot]
+	view.tableID = tableID
+	view.pot += result.PotAmount
+	view.rake += result.Rake
+	views[bot] = view
+}
+
+// start notes a hand a bot was dealt into; the bots may all leave before it ends, so its
+// result is then only in the replay
+func (l *ledger) start(handID, tableID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.started[handID] = tableID
+}
+
+// agreed returns each hand as every bot at its table saw it, noting hands they disagree on
+func (l *ledger) agreed() map[string]handRecord {
+	hands := make(map[string]handRecord, len(l.hands))
+	for handID, views := range l.hands {
+		for bot, view := range views {
+			if seen, ok := hands[handID]; ok && seen != view {
+				l.problems = append(l.problems, fmt.Sprintf("hand %s: %s saw %+v, another bot %+v", handID, bot, view, seen))
+			}
+			hands[handID] = view
+		}
+	}
+	return hands
+}
+
+// finished returns how many hands the table with the fewest has finished
+func (l *ledger) finished() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fewest := -1
+	for _, tableID := range tableIDs {
+		if fewest < 0 || l.perTable[tableID] < fewest {
+			fewest = l.perTable[tableID]
+		}
+	}
+	return fewest
+}
+
+// fail records a problem seen by a bot
+func (l *ledger) fail(format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.problems = append(l.problems, fmt.Sprintf(format, args...))
+}
+
+// bot is an SDK client playing a loose random strategy at one table, buying in again whenever
+// it busts
+type bot struct {
+	name    string
+	tableID string
+	client  *client.Client
+	ledger  *ledger
+	rng     *rand.Rand
+
+	mu       sync.Mutex
+	handID   string // Hand in progress the bot was dealt into
+	stopping bool   // Leave for good rather than buy in again
+}
+
+// play joins the bot's table and answers every action request until stop is called
+func (b *bot) play() error {
+	b.client.OnHandStarted(func(started client.HandStarted) {
+		b.mu.Lock()
+		b.handID = started.HandID
+		b.mu.Unlock()
+		b.ledger.start(started.HandID, b.tableID)
+	})
+	b.client.OnHandResult(func(result client.HandResult) {
+		// A bot seated during a hand gets its result without having seen it start; a hand with
+		// side pots has one result per pot
+		b.mu.Lock()
+		handID := b.handID
+		b.mu.Unlock()
+		if handID != "" {
+			b.ledger.record(b.name, handID, b.tableID, result)
+		}
+	})
+	b.client.OnActionRequest(func(request client.ActionRequest) {
+		if seat, ok := b.client.Seat(); !ok || seat.SeatIndex != request.SeatIndex {
+			return
+		}
+		action, amount := b.decide(request)
+		if _, err := b.client.Act(action, amount); err != nil {
+			b.ledger.fail("%s: %v", b.name, err)
+		}
+	})
+	b.client.OnSeatAssigned(func(client.SeatAssignment) {
+		b.mu.Lock()
+		b.handID = ""
+		stopping := b.stopping
+		b.mu.Unlock()
+		// A buy-in sent just before stop lands after it
+		if stopping {
+			b.client.LeaveTable()
+		}
+	})
+	b.client.OnSeatCleared(func() {
+		b.mu.Lock()
+		stopping := b.stopping
+		b.mu.Unlock()
+		if !stopping {
+			// Busted: buy in again from the balance
+			if err := b.client.JoinTable(b.tableID); err != nil {
+				b.ledger.fail("%s: rejoin: %v", b.name, err)
+			}
+		}
+	})
+	b.client.OnError(func(err *client.Error) {
+		b.mu.Lock()
+		stopping := b.stopping
+		b.mu.Unlock()
+		// Leaving can cross with a bust-out
+		if !stopping {
+			b.ledger.fail("%s got an error: %v", b.name, err)
+		}
+	})
+	return b.client.JoinTable(b.tableID)
+}
+
+// decide picks an action for request: mostly checking and calling, with some folds and raises
+// of every size up to all-in
+func (b *bot) decide(request client.ActionRequest) (string, int) {
+	roll := b.rng.Intn(100)
+	switch {
+	case roll < 20 && request.Can("raise"):
+		amount := request.MaxRaise
+		if b.rng.Intn(4) > 0 {
+			amount = request.MinRaise
+			if step := request.RaiseStep; step > 0 && request.MaxRaise > request.MinRaise {
+				amount += step * b.rng.Intn((request.MaxRaise-request.MinRaise)/step+1)
+			}
+		}
+		return "raise", amount
+	case roll < 35 && request.Can("fold") && !request.Can("check"):
+		return "fold", 0
+	case request.Can("check"):
+		return "check", 0
+	default:
+		return "call", 0
+	}
+}
+
+// stop makes the bot leave its table for good, in the middle of a hand if one is running: the
+// server folds it and the pot stays with the players still in
+func (b *bot) stop() {
+	b.mu.Lock()
+	b.stopping = true
+	b.mu.Unlock()
+	if _, seated := b.client.Seat(); seated {
+		b.client.LeaveTable()
+	}
+}
+
+// TestEndToEnd seats bots at every table variant, plays until each table has finished the
+// required hands, sends everyone home and checks no chip was created or lost
+func TestEndToEnd(t *testing.T) {
+	hands := 100
+	if value := os.Getenv("E2E_HANDS"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			t.Fatalf("E2E_HANDS: %v", err)
+		}
+		hands = n
+	}
+	baseURL := os.Getenv("E2E_SERVER_URL")
+	if baseURL == "" {
+		baseURL = startServer(t)
+	}
+	wsURL := "ws" + strings.TrimPrefix(baseURL, "http") + "/ws"
+
+	ledger := &ledger{hands: make(map[string]map[string]handRecord), started: make(map[string]string), perTable: make(map[string]int)}
+	var bots []*bot
+	for i, tableID := range tableIDs {
+		for seat := range botsPerTable {
+			name := fmt.Sprintf("Bot%d%d", i+1, seat+1)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			c, err := client.Dial(ctx, client.Config{URL: wsURL, Name: name})
+			cancel()
+			if err != nil {
+				t.Fatalf("dial %s: %v", name, err)
+			}
+			t.Cleanup(func() { c.Close() })
+			b := &bot{name: name, tableID: tableID, client: c, ledger: ledger, rng: rand.New(rand.NewSource(int64(len(bots) + 1)))}
+			if err := b.play(); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			bots = append(bots, b)
+		}
+	}
+
+	deadline := time.Now().Add(time.Duration(hands) * time.Second)
+	for ledger.finished() < hands {
+		if time.Now().After(deadline) {
+			t.Fatalf("only %d hands finished at the slowest table before the deadline, %v", ledger.finished(), ledger.perTable)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	for _, b := range bots {
+		b.stop()
+	}
+
+	// Once everyone has left, every chip is in a balance or the rake
+	total := len(bots) * startingPlayChips
+	var play currencySummary
+	settled := false
+	for end := time.Now().Add(30 * time.Second); time.Now().Before(end); time.Sleep(100 * time.Millisecond) {
+		play = playCurrency(t, baseURL)
+		if play.ChipsOnTables == 0 && play.Balances+play.RakeCollected == total {
+			settled = true
+			break
+		}
+	}
+	if !settled {
+		t.Errorf("expected %d chips in balances and rake with the tables empty, got %+v", total, play)
+	}
+
+	ledger.mu.Lock()
+	defer ledger.mu.Unlock()
+	rake := 0
+	played := ledger.agreed()
+	for handID, hand := range played {
+		rake += hand.rake
+		if replay, _ := fetchReplay(t, baseURL, handID); replay.Pot != hand.pot+hand.rake || replay.TableID != hand.tableID {
+			t.Errorf("hand %s: replay of a %d pot at %s, but %d paid out and %d rake at %s", handID, replay.Pot, replay.TableID, hand.pot, hand.rake, hand.tableID)
+		}
+	}
+	// The last players at a table can all leave mid-hand, and nobody is seated to see it end
+	for handID, tableID := range ledger.started {
+		if _, seen := played[handID]; seen {
+			continue
+		}
+		replay, ok := fetchReplay(t, baseURL, handID)
+		if !ok {
+			continue // Cancelled before it ended
+		}
+		if replay.TableID != tableID {
+			t.Errorf("hand %s: replay at %s, but dealt at %s", handID, replay.TableID, tableID)
+		}
+		rake += replay.rake()
+	}
+	if rake != play.RakeCollected {
+		t.Errorf("expected the %d rake collected to match the %d seen in hand results", play.RakeCollected, rake)
+	}
+	for _, problem := range ledger.problems {
+		t.Error(problem)
+	}
+	t.Logf("%d hands played, %v per table, %d rake", len(played), ledger.perTable, rake)
+}
+
+// replay is the part of GET /api/replays/{handID} the test checks
+type replay struct {
+	TableID  string      `json:"tableId"`
+	Pot      int         `json:"pot"`      // Paid out, rake included
+	Winnings map[int]int `json:"winnings"` // After rake
+}
+
+// rake returns what the house took from the hand
+func (r replay) rake() int {
+	rake := r.Pot
+	for _, won := range r.Winnings {
+		rake -= won
+	}
+	return rake
+}
+
+// fetchReplay fetches the replay of a finished hand, false when the server has none
+func fetchReplay(t *testing.T, baseURL, handID string) (replay, bool) {
+	t.Helper()
+	resp, err := http.Get(baseURL + "/api/replays/" + handID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return replay{}, false
+	}
+	var body struct {
+		Hand replay `json:"hand"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Errorf("decode replay %s: %v", handID, err)
+	}
+	return body.Hand, true
+}
+
+// playCurrency fetches the play-chip totals from the admin API
+func playCurrency(t *testing.T, baseURL string) currencySummary {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, baseURL+"/admin/currencies", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var summaries []currencySummary
+	if err := json.NewDecoder(resp.Body).Decode(&summaries); err != nil {
+		t.Fatalf("decode /admin/currencies: %v", err)
+	}
+	for _, summary := range summaries {
+		if summary.Currency == "play" {
+			return summary
+		}
+	}
+	t.Fatalf("no play currency in %+v", summaries)
+	return currencySummary{}
+}
+
+// startServer builds cmd/server, runs it with config.yaml on a free port until the test ends and
+// returns its base URL once it is healthy
+func startServer(t *testing.T) string {
+	t.Helper()
+	binary := filepath.Join(t.TempDir(), "poker")
+	build := exec.Command("go", "build", "-o", binary, "../../cmd/server")
+	if output, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("build server: %v\n%s", err, output)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+	listener.Close()
+
+	server := exec.Command(binary)
+	server.Env = append(os.Environ(), "CONFIG_FILE=config.yaml", "PORT="+port)
+	server.Stdout, server.Stderr = os.Stdout, os.Stderr
+	if err := server.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		server.Process.Signal(os.Interrupt)
+		server.Wait()
+	})
+
+	baseURL := "http://127.0.0.1:" + port
+	for end := time.Now().Add(10 * time.Second); time.Now().Before(end); time.Sleep(50 * time.Millisecond) {
+		if resp, err := http.Get(baseURL + "/health"); err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return baseURL
+			}
+		}
+	}
+	t.Fatal("server did not become healthy")
+	return ""
+}